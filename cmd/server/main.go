@@ -39,6 +39,16 @@ func main() {
 	log.Printf("Version: %s\nBuild date: %s\nBuild commit: %s\n",
 		buildVersion, buildDate, buildCommit)
 
+	if cfg.MigrateOnly {
+		if err := server.Migrate(ctx); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Print("Database migrations are applied")
+
+		return
+	}
+
 	go server.Run(ctx, statusCh)
 
 	select {
@@ -63,7 +63,6 @@ require (
 	golang.org/x/net v0.5.0 // indirect
 	golang.org/x/term v0.4.0 // indirect
 	golang.org/x/text v0.6.0 // indirect
-	google.golang.org/genproto v0.0.0-20230202175211-008b39050e57 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 )
 
@@ -79,5 +78,6 @@ require (
 	github.com/spf13/viper v1.15.0
 	golang.org/x/sys v0.4.0 // indirect
 	golang.org/x/tools v0.5.0
+	google.golang.org/genproto v0.0.0-20230202175211-008b39050e57
 	google.golang.org/grpc v1.52.0
 )
@@ -83,6 +83,7 @@ func TestingNewLoginItem() *Item {
 				ValueBool: true,
 			},
 		},
+		Tags: Tags{"prod", "customer-x"},
 	}
 }
 
@@ -100,6 +101,7 @@ func TestingNewPbLoginItem() *pb.Item {
 		Additions: &pb.Additions{
 			Uris:         []byte("uris"),
 			CustomFields: []byte("custom_fields"),
+			Tags:         []byte("tags"),
 		},
 	}
 }
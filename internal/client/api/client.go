@@ -3,6 +3,9 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/storage"
 	"github.com/artfuldog/gophkeeper/internal/pb"
@@ -18,13 +21,34 @@ var (
 	ErrEKeyDecryptionFailed = errors.New("failed to decrypt received from server encryption key")
 	ErrSecretTooBig         = errors.New("size of secret is too big")
 	ErrOutOfSync            = errors.New("local and server's information are out of sync")
+	ErrNotLoggedIn          = errors.New("user is not logged in")
+	ErrItemNotSaved         = errors.New("item must be saved before data upload")
+	ErrSecretDataCorrupted  = errors.New("downloaded data is corrupted or incomplete")
+	ErrKeyPairMissed        = errors.New("user's key pair is not set up, please relogin")
+	ErrShareDataItem        = errors.New("data items can't be shared")
+	ErrOrgKeyMissed         = errors.New("organization's key is missed, please reload organizations")
 )
 
+// LoginLockedError is returned by login, when server locks logins after too many failed attempts.
+type LoginLockedError struct {
+	// Remaining lockout period.
+	RetryAfter time.Duration
+}
+
+// Error implements error interface.
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry in %s", e.RetryAfter)
+}
+
 // Client is a general API-Client interface.
 type Client interface {
 	Executor
 	UsersInteractor
 	ItemsInteractor
+	FoldersInteractor
+	SharesInteractor
+	OrganizationsInteractor
+	EmergencyInteractor
 	Cryptor
 	Storager
 }
@@ -39,10 +63,25 @@ type Executor interface {
 // UsersInteractor defines methods for processing users-related events, such as login, AAA, registration.
 type UsersInteractor interface {
 	// Performs user login with password-based and OTP(optional) authentication and authorization.
-	// Returns ErrSecondFactorRequired if OTP-auth is enabled and required.
+	// Returns ErrSecondFactorRequired if OTP-auth is enabled and required, *LoginLockedError if
+	// logins are locked after too many failed attempts.
 	UserLogin(ctx context.Context, username, password, optCode string) error
 	// Registers new user.
 	UserRegister(context.Context, *NewUser) (*TOTPKey, error)
+	// Changes user's password and re-encrypts encryption key.
+	ChangePassword(context.Context, *PasswordChange) error
+	// Returns server's limits and user's current usage.
+	GetServerLimits(context.Context) (*pb.ServerLimits, error)
+	// Returns page of user's audit events newest first and next page's token.
+	GetAuditEvents(ctx context.Context, pageToken int64) ([]*pb.AuditEvent, int64, error)
+	// Revokes current session on server.
+	Logout(context.Context) error
+	// Returns user's active sessions, last seen first.
+	GetSessions(context.Context) ([]*pb.Session, error)
+	// Revokes user's session.
+	RevokeSession(ctx context.Context, id string) error
+	// Revokes all user's sessions, including current one.
+	RevokeAllSessions(context.Context) error
 }
 
 // ItemsInteractor defines methods for processing items-related events (CRUD).
@@ -56,8 +95,97 @@ type ItemsInteractor interface {
 	GetItemsForStorage(ctx context.Context, itemsIDs []int64) (storage.Items, error)
 	// Creates or updates item.
 	SaveItem(context.Context, *Item) error
-	// Deletes item.
+	// Moves item to trash.
 	DeleteItem(context.Context, *Item) error
+	// Returns trashed items' list of active user.
+	GetTrashList(context.Context) ([]*pb.ItemShort, error)
+	// Restores item from trash.
+	RestoreItem(ctx context.Context, itemID int64) error
+	// Permanently deletes trashed item.
+	PurgeItem(ctx context.Context, itemID int64) error
+	// Returns item's previous versions.
+	GetItemVersions(context.Context, *Item) ([]*ItemVersion, error)
+	// Restores item's state from previous version.
+	RestoreItemVersion(ctx context.Context, item *Item, version int64) error
+	// Replaces encryption key and re-encrypts all items with new key.
+	RotateEncryptionKey(context.Context) error
+	// Uploads data of data item by stream, every chunk is encrypted separately.
+	UploadSecretData(ctx context.Context, item *Item, r io.Reader, size int64, progress ProgressFunc) error
+	// Downloads data of data item by stream.
+	DownloadSecretData(ctx context.Context, item *Item, w io.Writer, progress ProgressFunc) error
+}
+
+// FoldersInteractor defines methods for organizing items in folders.
+type FoldersInteractor interface {
+	// Returns folders of active user sorted by name.
+	GetFolders(context.Context) ([]*Folder, error)
+	// Creates or renames folder.
+	SaveFolder(context.Context, *Folder) error
+	// Deletes folder, folder's items are kept without folder.
+	DeleteFolder(context.Context, *Folder) error
+	// Moves item to folder, zero folder's ID removes item from folder.
+	MoveItem(ctx context.Context, item *Item, folderID int64) error
+}
+
+// SharesInteractor defines methods for sharing items between users.
+type SharesInteractor interface {
+	// Shares read-only snapshot of item with another user, sharing with the same user again
+	// replaces previous snapshot.
+	ShareItem(ctx context.Context, item *Item, recipient string) error
+	// Returns items shared with active user.
+	GetSharedItems(context.Context) ([]*SharedItem, error)
+	// Returns shares of item.
+	GetItemShares(context.Context, *Item) ([]*Share, error)
+	// Revokes share of item.
+	RevokeShare(context.Context, *Share) error
+}
+
+// OrganizationsInteractor defines methods for managing organizations and their shared vaults.
+type OrganizationsInteractor interface {
+	// Returns organizations of active user including not accepted invites.
+	GetOrganizations(context.Context) ([]*Organization, error)
+	// Creates new organization, active user becomes organization's administrator.
+	CreateOrganization(ctx context.Context, name string) error
+	// Renames organization.
+	RenameOrganization(ctx context.Context, org *Organization, name string) error
+	// Deletes organization with all its items.
+	DeleteOrganization(context.Context, *Organization) error
+	// Returns organization's members and invited users.
+	GetMembers(context.Context, *Organization) ([]*Member, error)
+	// Invites user to organization with provided role.
+	InviteMember(ctx context.Context, org *Organization, username, role string) error
+	// Accepts invite to organization.
+	AcceptInvite(context.Context, *Organization) error
+	// Changes member's role.
+	UpdateMember(ctx context.Context, org *Organization, member *Member, role string) error
+	// Removes member from organization, removing active user leaves organization.
+	RemoveMember(ctx context.Context, org *Organization, username string) error
+	// Returns items' list of organization's vault.
+	GetOrgItemsList(context.Context, *Organization) ([]*pb.ItemShort, error)
+	// Returns full organization's item's information.
+	GetOrgItem(ctx context.Context, org *Organization, itemName, itemType string) (*Item, error)
+}
+
+// EmergencyInteractor defines methods for granting emergency access to vault to trusted contacts.
+type EmergencyInteractor interface {
+	// Returns emergency accesses granted by active user and granted to active user.
+	GetEmergencyAccesses(context.Context) (granted []*EmergencyAccess, received []*EmergencyAccess, err error)
+	// Invites trusted contact, which can request access to vault after waiting period.
+	InviteEmergencyContact(ctx context.Context, username, mode string, waitDays int) error
+	// Accepts invite as trusted contact.
+	AcceptEmergencyInvite(context.Context, *EmergencyAccess) error
+	// Requests access to grantor's vault.
+	RequestEmergencyAccess(context.Context, *EmergencyAccess) error
+	// Approves requested access before waiting period is over.
+	ApproveEmergencyAccess(context.Context, *EmergencyAccess) error
+	// Rejects requested or approved access.
+	RejectEmergencyAccess(context.Context, *EmergencyAccess) error
+	// Deletes emergency access or declines invite.
+	RevokeEmergencyAccess(context.Context, *EmergencyAccess) error
+	// Returns grantor's items by approved access.
+	GetEmergencyVault(context.Context, *EmergencyAccess) ([]*Item, error)
+	// Sets new password and secret key of grantor by approved takeover access.
+	TakeoverAccount(ctx context.Context, access *EmergencyAccess, newPassword, newSecretKey string) error
 }
 
 // Cryptor defines methods for encrypt/decrypt data.
@@ -85,3 +213,14 @@ type NewUser struct {
 	Email           string
 	TwoFactorEnable bool
 }
+
+// PasswordChange represents user's password change information.
+//
+// If NewSecretKey is empty current secret key is used for encryption key.
+type PasswordChange struct {
+	Password           string
+	NewPassword        string
+	NewPasswordConfirm string
+	NewSecretKey       string
+	OTPCode            string
+}
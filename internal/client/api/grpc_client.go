@@ -7,6 +7,8 @@ import (
 	"crypto/x509"
 	"fmt"
 	"os"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/config"
@@ -15,6 +17,7 @@ import (
 	"github.com/artfuldog/gophkeeper/internal/crypt"
 	"github.com/artfuldog/gophkeeper/internal/logger"
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -28,6 +31,10 @@ type GRPCClient struct {
 	usersClient pb.UsersClient
 	// gRPC-client for Items service.
 	itemsClient pb.ItemsClient
+	// gRPC-client for Organizations service.
+	orgsClient pb.OrganizationsClient
+	// gRPC-client for EmergencyAccesses service.
+	emergencyClient pb.EmergencyAccessesClient
 
 	// Configer instance for read and change configuraion parameters live.
 	config *config.Configer
@@ -36,6 +43,12 @@ type GRPCClient struct {
 
 	// Auth token.
 	Token string
+	// Refresh token, used for re-issue of expired auth token.
+	RefreshToken string
+	// Guards Token and RefreshToken, please see getTokens and setTokens.
+	tokenMu sync.RWMutex
+	// Serializes tokens' refresh.
+	refreshMu sync.Mutex
 	// Max secret size.
 	MaxSecretSize uint32
 
@@ -45,6 +58,13 @@ type GRPCClient struct {
 	// after successful authentication and authorization, for decrypting this key
 	// client uses secretkey which stored unecrypted on user's side.
 	encKey []byte
+	// Key pair used for receiving items shared by other users. Public key is stored
+	// on server as is, private key is stored encrypted with encryption key.
+	publicKey  []byte
+	privateKey []byte
+	// Keys of user's organizations by organizations' IDs, decrypted with private key.
+	orgKeys   map[int64][]byte
+	orgKeysMu sync.RWMutex
 
 	// Local storage of agent.
 	storage storage.S
@@ -82,7 +102,9 @@ func (c *GRPCClient) Connect(ctx context.Context, controlCh chan<- struct{}) err
 
 	conn, err := grpc.Dial(c.config.GetServer(),
 		grpc.WithTransportCredentials(creds),
-		grpc.WithUnaryInterceptor(AuthInterceptor(c.config.GetUser(), &c.Token)))
+		grpc.WithUserAgent(userAgent()),
+		grpc.WithUnaryInterceptor(AuthInterceptor(c.config.GetUser(), c.getToken, c.refreshToken)),
+		grpc.WithStreamInterceptor(AuthStreamInterceptor(c.config.GetUser(), c.getToken, c.refreshToken)))
 
 	if err != nil {
 		c.Logger.Error(err, fmt.Sprintf("connect to %s", c.config.GetServer()), componentName)
@@ -108,10 +130,22 @@ func (c *GRPCClient) Connect(ctx context.Context, controlCh chan<- struct{}) err
 
 	c.usersClient = pb.NewUsersClient(conn)
 	c.itemsClient = pb.NewItemsClient(conn)
+	c.orgsClient = pb.NewOrganizationsClient(conn)
+	c.emergencyClient = pb.NewEmergencyAccessesClient(conn)
 
 	return nil
 }
 
+// userAgent returns client's user agent, which describes client's device in user's sessions.
+func userAgent() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown host"
+	}
+
+	return fmt.Sprintf("gophkeeper-client (%s/%s; %s)", runtime.GOOS, runtime.GOARCH, hostname)
+}
+
 // getCredentials is a helper function used to configure transport credentials for
 // GRPC-connection to server.
 func (c *GRPCClient) getCredentials() (credentials.TransportCredentials, error) {
@@ -147,7 +181,10 @@ func (c *GRPCClient) getCredentials() (credentials.TransportCredentials, error)
 //
 // If two-step authentication is enabled for user during, and no verication code were provide
 // UserLogin returns ErrSecondFactorRequired.
+// Failure of user's key pair setup doesn't fail login, only sharing becomes unavailable.
 func (c *GRPCClient) UserLogin(ctx context.Context, username, password, verificationCode string) error {
+	componentName := "GRPCClient:UserLogin"
+
 	req := &pb.UserLoginRequest{
 		Username: username,
 		Password: password,
@@ -156,6 +193,10 @@ func (c *GRPCClient) UserLogin(ctx context.Context, username, password, verifica
 
 	resp, err := c.usersClient.UserLogin(ctx, req)
 	if err != nil {
+		if lockedErr := loginLockedError(err); lockedErr != nil {
+			return lockedErr
+		}
+
 		return err
 	}
 
@@ -167,9 +208,30 @@ func (c *GRPCClient) UserLogin(ctx context.Context, username, password, verifica
 		return ErrEKeyDecryptionFailed
 	}
 
-	c.Token = resp.Token
+	c.setTokens(resp.Token, resp.RefreshToken)
 	c.MaxSecretSize = uint32(resp.ServerLimits.MaxSecretSize)
 
+	if err := c.setKeyPair(ctx, username, resp.PublicKey, resp.PrivateKey); err != nil {
+		c.Logger.Warn(err, "failed to set up key pair", componentName)
+	}
+
+	return nil
+}
+
+// loginLockedError is a helper function, which converts server's error of locked login to
+// LoginLockedError. Returns nil for other errors.
+func loginLockedError(err error) *LoginLockedError {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return nil
+	}
+
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return &LoginLockedError{RetryAfter: retryInfo.GetRetryDelay().AsDuration()}
+		}
+	}
+
 	return nil
 }
 
@@ -177,6 +239,7 @@ func (c *GRPCClient) UserLogin(ctx context.Context, username, password, verifica
 //
 // During registration process randon 32-byte encryption key is generated. This key is encrypted with
 // user sectet key and then sent to server.
+// Key pair for sharing is generated too, private key is encrypted with encryption key.
 func (c *GRPCClient) UserRegister(ctx context.Context, user *NewUser) (*TOTPKey, error) {
 	pwdhash, err := crypt.CalculatePasswordHash(user.Password)
 	if err != nil {
@@ -195,12 +258,24 @@ func (c *GRPCClient) UserRegister(ctx context.Context, user *NewUser) (*TOTPKey,
 		return nil, ErrEKeyEncryptionFailed
 	}
 
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	encPrivateKey, err := crypt.EncryptAES(eKey, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &pb.CreateUserRequest{
 		User: &pb.User{
-			Username: user.Username,
-			Pwdhash:  common.PtrTo(pwdhash),
-			Ekey:     decryptedEKey,
-			Email:    email,
+			Username:   user.Username,
+			Pwdhash:    common.PtrTo(pwdhash),
+			Ekey:       decryptedEKey,
+			Email:      email,
+			PublicKey:  publicKey,
+			PrivateKey: encPrivateKey,
 		},
 		Twofactor: user.TwoFactorEnable,
 	}
@@ -224,10 +299,218 @@ func (c *GRPCClient) UserRegister(ctx context.Context, user *NewUser) (*TOTPKey,
 	return &TOTPKey{}, nil
 }
 
+// ChangePassword changes user's password.
+//
+// Encryption key is re-encrypted with new secret key (or current one, if new secret key is
+// not provided) and sent to server with new password hash. After successful change client
+// switches to new token and secret key, all previously issued tokens are revoked by server.
+func (c *GRPCClient) ChangePassword(ctx context.Context, change *PasswordChange) error {
+	if c.encKey == nil {
+		return ErrNotLoggedIn
+	}
+
+	pwdhash, err := crypt.CalculatePasswordHash(change.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	secretKey := c.config.GetSecretKey()
+	if change.NewSecretKey != "" {
+		secretKey = change.NewSecretKey
+	}
+
+	eKey, err := crypt.EncryptAESwithAD([]byte(secretKey), c.encKey)
+	if err != nil {
+		return ErrEKeyEncryptionFailed
+	}
+
+	req := &pb.ChangePasswordRequest{
+		Username:   c.config.GetUser(),
+		Password:   change.Password,
+		OtpCode:    change.OTPCode,
+		NewPwdhash: pwdhash,
+		Ekey:       eKey,
+	}
+
+	// Error is not wrapped, because wrong password or verification code are reported
+	// with PermissionDenied status and should not be treated as expired session.
+	resp, err := c.usersClient.ChangePassword(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	c.setTokens(resp.Token, resp.RefreshToken)
+	c.config.SetSecretKey(secretKey)
+
+	return nil
+}
+
+// GetServerLimits returns server's limits and user's current usage.
+//
+// Zero value of limit means, that limit is not set.
+func (c *GRPCClient) GetServerLimits(ctx context.Context) (*pb.ServerLimits, error) {
+	request := &pb.GetServerLimitsRequest{
+		Username: c.config.GetUser(),
+	}
+
+	resp, err := c.usersClient.GetServerLimits(ctx, request)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	if resp.ServerLimits == nil {
+		return nil, ErrMissedServerResponse
+	}
+
+	c.MaxSecretSize = uint32(resp.ServerLimits.MaxSecretSize)
+
+	return resp.ServerLimits, nil
+}
+
+// GetAuditEvents returns page of user's audit events newest first, which are older than
+// event with provided page's token (zero token means latest events).
+//
+// Zero next page's token means, that there are no more events.
+func (c *GRPCClient) GetAuditEvents(ctx context.Context, pageToken int64) ([]*pb.AuditEvent, int64, error) {
+	request := &pb.ListAuditEventsRequest{
+		Username:  c.config.GetUser(),
+		PageToken: pageToken,
+	}
+
+	resp, err := c.usersClient.ListAuditEvents(ctx, request)
+	if err != nil {
+		return nil, 0, c.wrapError(err)
+	}
+
+	return resp.Events, resp.NextPageToken, nil
+}
+
+// Logout revokes current session on server and forgets session's tokens.
+func (c *GRPCClient) Logout(ctx context.Context) error {
+	request := &pb.LogoutRequest{Username: c.config.GetUser()}
+
+	if _, err := c.usersClient.Logout(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	c.setTokens("", "")
+
+	return nil
+}
+
+// GetSessions returns user's active sessions, last seen first. Current session is marked.
+func (c *GRPCClient) GetSessions(ctx context.Context) ([]*pb.Session, error) {
+	request := &pb.ListSessionsRequest{Username: c.config.GetUser()}
+
+	resp, err := c.usersClient.ListSessions(ctx, request)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return resp.Sessions, nil
+}
+
+// RevokeSession revokes user's session with provided ID.
+func (c *GRPCClient) RevokeSession(ctx context.Context, id string) error {
+	request := &pb.RevokeSessionRequest{
+		Username: c.config.GetUser(),
+		Id:       id,
+	}
+
+	if _, err := c.usersClient.RevokeSession(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions revokes all user's sessions, including current one, and forgets session's tokens.
+func (c *GRPCClient) RevokeAllSessions(ctx context.Context) error {
+	request := &pb.RevokeSessionRequest{
+		Username: c.config.GetUser(),
+		All:      true,
+	}
+
+	if _, err := c.usersClient.RevokeSession(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	c.setTokens("", "")
+
+	return nil
+}
+
+// refreshToken re-issues expired token with refresh token, refresh token is rotated by server.
+//
+// If token was already refreshed by concurrent request, refreshToken does nothing.
+func (c *GRPCClient) refreshToken(ctx context.Context, expiredToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	token, refreshToken := c.getTokens()
+	if token != expiredToken {
+		return nil
+	}
+
+	if refreshToken == "" {
+		return ErrSessionExpired
+	}
+
+	request := &pb.RefreshTokenRequest{
+		Username:     c.config.GetUser(),
+		RefreshToken: refreshToken,
+	}
+
+	resp, err := c.usersClient.RefreshToken(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	c.setTokens(resp.Token, resp.RefreshToken)
+
+	return nil
+}
+
+// getToken returns current auth token.
+func (c *GRPCClient) getToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+
+	return c.Token
+}
+
+// getTokens returns current auth and refresh tokens.
+func (c *GRPCClient) getTokens() (string, string) {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+
+	return c.Token, c.RefreshToken
+}
+
+// setTokens replaces auth and refresh tokens.
+func (c *GRPCClient) setTokens(token, refreshToken string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	c.Token = token
+	c.RefreshToken = refreshToken
+}
+
 // GetItemsList returns list with short representation of items.
+//
+// Trashed items are not included in list. Items' tags are returned decrypted.
 func (c *GRPCClient) GetItemsList(ctx context.Context) ([]*pb.ItemShort, error) {
 	if c.config.GetMode() == config.ModeLocal {
-		return c.getItemsListFromStorage(ctx)
+		items, err := c.getItemsListFromStorage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := decryptPbItemsTags(c.encKey, items); err != nil {
+			return nil, err
+		}
+
+		return items, nil
 	}
 
 	request := &pb.GetItemListRequest{
@@ -239,7 +522,19 @@ func (c *GRPCClient) GetItemsList(ctx context.Context) ([]*pb.ItemShort, error)
 		return nil, c.wrapError(err)
 	}
 
-	return resp.Items, nil
+	items := make([]*pb.ItemShort, 0, len(resp.Items))
+
+	for _, item := range resp.Items {
+		if item.Deleted == nil {
+			items = append(items, item)
+		}
+	}
+
+	if err := decryptPbItemsTags(c.encKey, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
 }
 
 // getItemsListFromStorage returns list with short representation of items from local storage.
@@ -258,8 +553,11 @@ func (c *GRPCClient) itemsListFromStorageToPB(strItems storage.Items) []*pb.Item
 
 	for i, item := range strItems {
 		pbItems[i] = &pb.ItemShort{
-			Name: item.Name,
-			Type: item.Type,
+			Id:       item.ID,
+			Name:     item.Name,
+			Type:     item.Type,
+			FolderId: &strItems[i].FolderID,
+			Tags:     item.Tags,
 		}
 	}
 
@@ -316,11 +614,13 @@ func (c *GRPCClient) GetItemsForStorage(ctx context.Context, itemIDs []int64) (s
 	items := make(storage.Items, len(resp.Items))
 	for i, item := range resp.Items {
 		items[i] = &storage.Item{
-			ID:   item.Id,
-			Name: item.Name,
-			Type: item.Type,
-			Hash: item.Hash,
-			Data: toBytesUnsafe(item),
+			ID:       item.Id,
+			Name:     item.Name,
+			Type:     item.Type,
+			Hash:     item.Hash,
+			Data:     toBytesUnsafe(item),
+			FolderID: item.GetFolderId(),
+			Tags:     item.GetAdditions().GetTags(),
 		}
 	}
 
@@ -331,32 +631,36 @@ func (c *GRPCClient) GetItemsForStorage(ctx context.Context, itemIDs []int64) (s
 //
 // Which action to take - update or create, based on item ID - for new Item id is always 0,
 // for existing >0.
+//
+// Organization's item is encrypted with organization's key and saved to organization's vault.
 func (c *GRPCClient) SaveItem(ctx context.Context, item *Item) error {
+	key, err := c.itemKey(item)
+	if err != nil {
+		return err
+	}
+
 	pbItem := item.ToPB()
-	if err := c.EncryptPbItem(pbItem); err != nil {
+	if err := encryptPbItem(key, pbItem); err != nil {
 		return err
 	}
 
 	if len(pbItem.Secrets.Secret) > int(c.MaxSecretSize) {
-		gotSize := float64(len(pbItem.Secrets.Secret)) / 1024 / 1024
-		maxSize := float64(c.MaxSecretSize) / 1024 / 1024
-
-		return fmt.Errorf("%w: uploaded size %.2f Mb, max supported size %.2f Mb",
-			ErrSecretTooBig, gotSize, maxSize)
+		return c.errSecretTooBig(int64(len(pbItem.Secrets.Secret)))
 	}
 
 	if item.ID > 0 {
-		return c.updateItem(ctx, pbItem)
+		return c.updateItem(ctx, pbItem, item.OrgID)
 	}
 
-	return c.createItem(ctx, pbItem)
+	return c.createItem(ctx, pbItem, item.OrgID)
 }
 
 // createItem creates new item.
-func (c *GRPCClient) createItem(ctx context.Context, item *pb.Item) error {
+func (c *GRPCClient) createItem(ctx context.Context, item *pb.Item, orgID int64) error {
 	request := &pb.CreateItemRequest{
 		Username: c.config.GetUser(),
 		Item:     item,
+		OrgId:    orgID,
 	}
 
 	_, err := c.itemsClient.CreateItem(ctx, request)
@@ -364,14 +668,14 @@ func (c *GRPCClient) createItem(ctx context.Context, item *pb.Item) error {
 		return c.wrapError(err)
 	}
 
-	c.ForceSyncWithWait()
+	c.forceSyncOwnVault(orgID)
 
 	return nil
 }
 
 // updateItem updates existing item.
-func (c *GRPCClient) updateItem(ctx context.Context, item *pb.Item) error {
-	if c.config.GetMode() == config.ModeLocal {
+func (c *GRPCClient) updateItem(ctx context.Context, item *pb.Item, orgID int64) error {
+	if c.config.GetMode() == config.ModeLocal && orgID == 0 {
 		if err := c.checkItemRemoteChanges(ctx, item); err != nil {
 			return err
 		}
@@ -380,6 +684,7 @@ func (c *GRPCClient) updateItem(ctx context.Context, item *pb.Item) error {
 	request := &pb.UpdateItemRequest{
 		Username: c.config.GetUser(),
 		Item:     item,
+		OrgId:    orgID,
 	}
 
 	_, err := c.itemsClient.UpdateItem(ctx, request)
@@ -387,7 +692,7 @@ func (c *GRPCClient) updateItem(ctx context.Context, item *pb.Item) error {
 		return c.wrapError(err)
 	}
 
-	c.ForceSyncWithWait()
+	c.forceSyncOwnVault(orgID)
 
 	return nil
 }
@@ -399,7 +704,10 @@ func (c *GRPCClient) checkItemRemoteChanges(ctx context.Context, item *pb.Item)
 	}
 
 	if !synced {
-		resp, err := c.itemsClient.GetItemHash(ctx, &pb.GetItemHashRequest{Id: item.Id})
+		resp, err := c.itemsClient.GetItemHash(ctx, &pb.GetItemHashRequest{
+			Username: c.config.GetUser(),
+			Id:       item.Id,
+		})
 		if err != nil {
 			return ErrOutOfSync
 		}
@@ -412,11 +720,12 @@ func (c *GRPCClient) checkItemRemoteChanges(ctx context.Context, item *pb.Item)
 	return nil
 }
 
-// DeleteItem deletes existing item.
+// DeleteItem moves existing item to trash.
 func (c *GRPCClient) DeleteItem(ctx context.Context, item *Item) error {
 	request := &pb.DeleteItemRequest{
 		Username: c.config.GetUser(),
 		Id:       item.ID,
+		OrgId:    item.OrgID,
 	}
 
 	_, err := c.itemsClient.DeleteItem(ctx, request)
@@ -424,11 +733,210 @@ func (c *GRPCClient) DeleteItem(ctx context.Context, item *Item) error {
 		return c.wrapError(err)
 	}
 
+	c.forceSyncOwnVault(item.OrgID)
+
+	return nil
+}
+
+// GetItemVersions returns item's previous versions from newest to oldest.
+//
+// Versions are always requested from server and decrypted client-side.
+func (c *GRPCClient) GetItemVersions(ctx context.Context, item *Item) ([]*ItemVersion, error) {
+	key, err := c.itemKey(item)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &pb.ListItemVersionsRequest{
+		Username: c.config.GetUser(),
+		ItemId:   item.ID,
+		OrgId:    item.OrgID,
+	}
+
+	resp, err := c.itemsClient.ListItemVersions(ctx, request)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	versions := make([]*ItemVersion, 0, len(resp.Versions))
+
+	for _, v := range resp.Versions {
+		if err := decryptPbItem(key, v.Item); err != nil {
+			return nil, err
+		}
+
+		versionItem := NewItemFromPB(v.Item)
+		versionItem.OrgID = item.OrgID
+
+		versions = append(versions, &ItemVersion{
+			Version: v.Version,
+			Item:    versionItem,
+		})
+	}
+
+	return versions, nil
+}
+
+// RestoreItemVersion restores item's state from previous version.
+func (c *GRPCClient) RestoreItemVersion(ctx context.Context, item *Item, version int64) error {
+	request := &pb.RestoreItemVersionRequest{
+		Username: c.config.GetUser(),
+		ItemId:   item.ID,
+		Version:  version,
+		OrgId:    item.OrgID,
+	}
+
+	_, err := c.itemsClient.RestoreItemVersion(ctx, request)
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	c.forceSyncOwnVault(item.OrgID)
+
+	return nil
+}
+
+// GetTrashList returns list with short representation of trashed items.
+//
+// Trash is always requested from server, local storage keeps only active items.
+func (c *GRPCClient) GetTrashList(ctx context.Context) ([]*pb.ItemShort, error) {
+	request := &pb.ListTrashRequest{
+		Username: c.config.GetUser(),
+	}
+
+	resp, err := c.itemsClient.ListTrash(ctx, request)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	return resp.Items, nil
+}
+
+// RestoreItem restores item from trash.
+func (c *GRPCClient) RestoreItem(ctx context.Context, itemID int64) error {
+	request := &pb.RestoreItemRequest{
+		Username: c.config.GetUser(),
+		Id:       itemID,
+	}
+
+	_, err := c.itemsClient.RestoreItem(ctx, request)
+	if err != nil {
+		return c.wrapError(err)
+	}
+
 	c.ForceSyncWithWait()
 
 	return nil
 }
 
+// PurgeItem permanently deletes trashed item.
+func (c *GRPCClient) PurgeItem(ctx context.Context, itemID int64) error {
+	request := &pb.PurgeItemRequest{
+		Username: c.config.GetUser(),
+		Id:       itemID,
+	}
+
+	_, err := c.itemsClient.PurgeItem(ctx, request)
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	c.ForceSyncWithWait()
+
+	return nil
+}
+
+// RotateEncryptionKey replaces user's encryption key with new generated one.
+//
+// All user's items (including trashed) and folders are received from server, re-encrypted with new key
+// and sent to server with new encryption key at once. Server either applies all changes or nothing.
+// Items' history is deleted by server, because it is encrypted with previous key.
+// User's private key is re-encrypted with new key too.
+// After successful rotation local storage is rebuilt.
+func (c *GRPCClient) RotateEncryptionKey(ctx context.Context) error {
+	if c.encKey == nil {
+		return ErrNotLoggedIn
+	}
+
+	resp, err := c.itemsClient.GetAllItems(ctx, &pb.GetAllItemsRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	foldersResp, err := c.itemsClient.GetFolders(ctx, &pb.GetFoldersRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	newKey := crypt.GenerateRandomKey32()
+
+	for _, item := range resp.Items {
+		if err := decryptPbItem(c.encKey, item); err != nil {
+			return err
+		}
+
+		if err := encryptPbItem(newKey, item); err != nil {
+			return err
+		}
+	}
+
+	for _, folder := range foldersResp.Folders {
+		name, err := crypt.DecryptAES(c.encKey, folder.Name)
+		if err != nil {
+			return err
+		}
+
+		if folder.Name, err = crypt.EncryptAES(newKey, name); err != nil {
+			return err
+		}
+	}
+
+	eKey, err := crypt.EncryptAESwithAD([]byte(c.config.GetSecretKey()), newKey)
+	if err != nil {
+		return ErrEKeyEncryptionFailed
+	}
+
+	var privateKey []byte
+	if c.privateKey != nil {
+		if privateKey, err = crypt.EncryptAES(newKey, c.privateKey); err != nil {
+			return err
+		}
+	}
+
+	emergencyKeys, err := c.rewrapEmergencyKeys(ctx, newKey)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.RotateEncryptionKeyRequest{
+		Username:      c.config.GetUser(),
+		Ekey:          eKey,
+		PrivateKey:    privateKey,
+		Items:         resp.Items,
+		Folders:       foldersResp.Folders,
+		EmergencyKeys: emergencyKeys,
+	}
+
+	if _, err := c.itemsClient.RotateEncryptionKey(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	c.encKey = newKey
+
+	c.ForceRebuildWithWait()
+
+	return nil
+}
+
+// errSecretTooBig is a helper function which returns ErrSecretTooBig with sizes' details.
+func (c *GRPCClient) errSecretTooBig(size int64) error {
+	gotSize := float64(size) / 1024 / 1024
+	maxSize := float64(c.MaxSecretSize) / 1024 / 1024
+
+	return fmt.Errorf("%w: uploaded size %.2f Mb, max supported size %.2f Mb",
+		ErrSecretTooBig, gotSize, maxSize)
+}
+
 // wrapError wraps well-known returned errors:
 //   - server PermissionDenied wraps to ErrSessionExpired, for prompt user to relogin.
 func (c *GRPCClient) wrapError(err error) error {
@@ -444,8 +952,18 @@ func (c *GRPCClient) wrapError(err error) error {
 
 // EncryptPbItem encrypts item before send it to the server.
 func (c *GRPCClient) EncryptPbItem(item *pb.Item) error {
+	return encryptPbItem(c.encKey, item)
+}
+
+// DecryptPbItem decrypts received from server item.
+func (c *GRPCClient) DecryptPbItem(item *pb.Item) error {
+	return decryptPbItem(c.encKey, item)
+}
+
+// encryptPbItem encrypts item's sensitive information with provided key.
+func encryptPbItem(key []byte, item *pb.Item) error {
 	if len(item.Secrets.Secret) > 0 {
-		encrypted, err := crypt.EncryptAES(c.encKey, item.Secrets.Secret)
+		encrypted, err := crypt.EncryptAES(key, item.Secrets.Secret)
 		if err != nil {
 			return err
 		}
@@ -454,7 +972,7 @@ func (c *GRPCClient) EncryptPbItem(item *pb.Item) error {
 	}
 
 	if len(item.Secrets.Notes) > 0 {
-		encrypted, err := crypt.EncryptAES(c.encKey, item.Secrets.Notes)
+		encrypted, err := crypt.EncryptAES(key, item.Secrets.Notes)
 		if err != nil {
 			return err
 		}
@@ -463,7 +981,7 @@ func (c *GRPCClient) EncryptPbItem(item *pb.Item) error {
 	}
 
 	if len(item.Additions.Uris) > 0 {
-		encrypted, err := crypt.EncryptAES(c.encKey, item.Additions.Uris)
+		encrypted, err := crypt.EncryptAES(key, item.Additions.Uris)
 		if err != nil {
 			return err
 		}
@@ -472,7 +990,7 @@ func (c *GRPCClient) EncryptPbItem(item *pb.Item) error {
 	}
 
 	if len(item.Additions.CustomFields) > 0 {
-		encrypted, err := crypt.EncryptAES(c.encKey, item.Additions.CustomFields)
+		encrypted, err := crypt.EncryptAES(key, item.Additions.CustomFields)
 		if err != nil {
 			return err
 		}
@@ -480,17 +998,26 @@ func (c *GRPCClient) EncryptPbItem(item *pb.Item) error {
 		item.Additions.CustomFields = encrypted
 	}
 
+	if len(item.Additions.Tags) > 0 {
+		encrypted, err := crypt.EncryptAES(key, item.Additions.Tags)
+		if err != nil {
+			return err
+		}
+
+		item.Additions.Tags = encrypted
+	}
+
 	return nil
 }
 
-// DecryptPbItem decrypts received from server item.
-func (c *GRPCClient) DecryptPbItem(item *pb.Item) error {
+// decryptPbItem decrypts item's sensitive information with provided key.
+func decryptPbItem(key []byte, item *pb.Item) error {
 	if item == nil || item.Secrets == nil {
 		return ErrMissedServerResponse
 	}
 
 	if len(item.Secrets.Secret) > 0 {
-		decrypted, err := crypt.DecryptAES(c.encKey, item.Secrets.Secret)
+		decrypted, err := crypt.DecryptAES(key, item.Secrets.Secret)
 		if err != nil {
 			return err
 		}
@@ -499,7 +1026,7 @@ func (c *GRPCClient) DecryptPbItem(item *pb.Item) error {
 	}
 
 	if len(item.Secrets.Notes) > 0 {
-		decrypted, err := crypt.DecryptAES(c.encKey, item.Secrets.Notes)
+		decrypted, err := crypt.DecryptAES(key, item.Secrets.Notes)
 		if err != nil {
 			return err
 		}
@@ -508,7 +1035,7 @@ func (c *GRPCClient) DecryptPbItem(item *pb.Item) error {
 	}
 
 	if len(item.Additions.Uris) > 0 {
-		decrypted, err := crypt.DecryptAES(c.encKey, item.Additions.Uris)
+		decrypted, err := crypt.DecryptAES(key, item.Additions.Uris)
 		if err != nil {
 			return err
 		}
@@ -517,7 +1044,7 @@ func (c *GRPCClient) DecryptPbItem(item *pb.Item) error {
 	}
 
 	if len(item.Additions.CustomFields) > 0 {
-		decrypted, err := crypt.DecryptAES(c.encKey, item.Additions.CustomFields)
+		decrypted, err := crypt.DecryptAES(key, item.Additions.CustomFields)
 		if err != nil {
 			return err
 		}
@@ -525,6 +1052,33 @@ func (c *GRPCClient) DecryptPbItem(item *pb.Item) error {
 		item.Additions.CustomFields = decrypted
 	}
 
+	if len(item.Additions.Tags) > 0 {
+		decrypted, err := crypt.DecryptAES(key, item.Additions.Tags)
+		if err != nil {
+			return err
+		}
+
+		item.Additions.Tags = decrypted
+	}
+
+	return nil
+}
+
+// decryptPbItemsTags decrypts tags of items' short representation with provided key.
+func decryptPbItemsTags(key []byte, items []*pb.ItemShort) error {
+	for _, item := range items {
+		if len(item.Tags) == 0 {
+			continue
+		}
+
+		decrypted, err := crypt.DecryptAES(key, item.Tags)
+		if err != nil {
+			return err
+		}
+
+		item.Tags = decrypted
+	}
+
 	return nil
 }
 
@@ -3,12 +3,15 @@ package api
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/config"
 	"github.com/artfuldog/gophkeeper/internal/client/storage"
+	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/crypt"
 	"github.com/artfuldog/gophkeeper/internal/mocks/mockgrpc"
 	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
@@ -17,9 +20,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Helpers
@@ -36,6 +43,8 @@ type TestSuiteGRPClient struct {
 	Client      *GRPCClient
 	UsersClient *mockgrpc.MockUsersClient
 	ItemsClient *mockgrpc.MockItemsClient
+	OrgsClient  *mockgrpc.MockOrganizationsClient
+	EmergClient *mockgrpc.MockEmergencyAccessesClient
 	Storage     *mockstorage.MockS
 }
 
@@ -49,11 +58,15 @@ func NewTestSuiteGRPClient(t gomock.TestReporter) *TestSuiteGRPClient {
 
 	testUsersService := mockgrpc.NewMockUsersClient(mockCtrl)
 	testItemsService := mockgrpc.NewMockItemsClient(mockCtrl)
+	testOrgsService := mockgrpc.NewMockOrganizationsClient(mockCtrl)
+	testEmergService := mockgrpc.NewMockEmergencyAccessesClient(mockCtrl)
 	testStorage := mockstorage.NewMockS(mockCtrl)
 
 	testClient := NewGRPCClient(testConfig, testLogger)
 	testClient.itemsClient = testItemsService
 	testClient.usersClient = testUsersService
+	testClient.orgsClient = testOrgsService
+	testClient.emergencyClient = testEmergService
 	testClient.storage = testStorage
 
 	return &TestSuiteGRPClient{
@@ -61,6 +74,8 @@ func NewTestSuiteGRPClient(t gomock.TestReporter) *TestSuiteGRPClient {
 		Client:      testClient,
 		UsersClient: testUsersService,
 		ItemsClient: testItemsService,
+		OrgsClient:  testOrgsService,
+		EmergClient: testEmergService,
 		Storage:     testStorage,
 	}
 }
@@ -117,6 +132,22 @@ func TestGRPCClient_UserLogin(t *testing.T) {
 		assert.Error(t, ts.Client.UserLogin(testGRPCctx, "", "", ""))
 	})
 
+	t.Run("Login locked", func(t *testing.T) {
+		st, err := status.New(codes.ResourceExhausted, "too many failed login attempts").
+			WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(90 * time.Second)})
+		require.NoError(t, err)
+		ts.UsersClient.EXPECT().UserLogin(testGRPCctx, mockAnyVal).Return(nil, st.Err())
+
+		var lockedErr *LoginLockedError
+		require.ErrorAs(t, ts.Client.UserLogin(testGRPCctx, "", "", ""), &lockedErr)
+		assert.Equal(t, 90*time.Second, lockedErr.RetryAfter)
+
+		ts.UsersClient.EXPECT().UserLogin(testGRPCctx, mockAnyVal).
+			Return(nil, status.Error(codes.ResourceExhausted, "quota"))
+		err = ts.Client.UserLogin(testGRPCctx, "", "", "")
+		assert.False(t, errors.As(err, &lockedErr), "error without retry info")
+	})
+
 	t.Run("Second factor", func(t *testing.T) {
 		resp := &pb.UserLoginResponse{
 			SecondFactor: true,
@@ -150,6 +181,52 @@ func TestGRPCClient_UserLogin(t *testing.T) {
 		ts.UsersClient.EXPECT().UserLogin(testGRPCctx, mockAnyVal).Return(resp, nil)
 		assert.NoError(t, ts.Client.UserLogin(testGRPCctx, "", "", ""))
 	})
+
+	t.Run("User without key pair logged in", func(t *testing.T) {
+		encKey, err := crypt.EncryptAESwithAD([]byte(testGRPCSecretKey), testGRPCencKey)
+		require.NoError(t, err)
+		resp := &pb.UserLoginResponse{
+			Ekey:         encKey,
+			Token:        "123",
+			ServerLimits: &pb.ServerLimits{},
+		}
+
+		var req *pb.UpdateUserRequest
+
+		ts.UsersClient.EXPECT().UserLogin(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.UsersClient.EXPECT().UpdateUser(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.UpdateUserRequest,
+				_ ...grpc.CallOption) (*pb.UpdateUserResponse, error) {
+				req = r
+				return &pb.UpdateUserResponse{}, nil
+			})
+		require.NoError(t, ts.Client.UserLogin(testGRPCctx, "user", "", ""))
+
+		assert.Equal(t, "user", req.User.Username)
+		assert.Equal(t, ts.Client.publicKey, req.User.PublicKey)
+		privateKey, err := crypt.DecryptAES(testGRPCencKey, req.User.PrivateKey)
+		require.NoError(t, err)
+		assert.Equal(t, ts.Client.privateKey, privateKey)
+	})
+
+	t.Run("User with key pair logged in", func(t *testing.T) {
+		encKey, err := crypt.EncryptAESwithAD([]byte(testGRPCSecretKey), testGRPCencKey)
+		require.NoError(t, err)
+		privateKey, err := crypt.EncryptAES(testGRPCencKey, []byte("private"))
+		require.NoError(t, err)
+		resp := &pb.UserLoginResponse{
+			Ekey:         encKey,
+			Token:        "123",
+			ServerLimits: &pb.ServerLimits{},
+			PublicKey:    []byte("public"),
+			PrivateKey:   privateKey,
+		}
+
+		ts.UsersClient.EXPECT().UserLogin(testGRPCctx, mockAnyVal).Return(resp, nil)
+		require.NoError(t, ts.Client.UserLogin(testGRPCctx, "user", "", ""))
+		assert.Equal(t, []byte("public"), ts.Client.publicKey)
+		assert.Equal(t, []byte("private"), ts.Client.privateKey)
+	})
 }
 
 func TestGRPCClient_UserRegister(t *testing.T) {
@@ -201,6 +278,227 @@ func TestGRPCClient_UserRegister(t *testing.T) {
 		require.NoError(t, err)
 		assert.Empty(t, totpkey)
 	})
+
+	t.Run("Key pair is sent", func(t *testing.T) {
+		var req *pb.CreateUserRequest
+
+		ts.UsersClient.EXPECT().CreateUser(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.CreateUserRequest,
+				_ ...grpc.CallOption) (*pb.CreateUserResponse, error) {
+				req = r
+				return &pb.CreateUserResponse{}, nil
+			})
+		_, err := ts.Client.UserRegister(testGRPCctx, &NewUser{SecretKey: testGRPCSecretKey})
+		require.NoError(t, err)
+
+		eKey, err := crypt.DecryptAESwithAD([]byte(testGRPCSecretKey), req.User.Ekey)
+		require.NoError(t, err)
+		privateKey, err := crypt.DecryptAES(eKey, req.User.PrivateKey)
+		require.NoError(t, err)
+		assert.Len(t, privateKey, crypt.X25519KeyLength)
+		assert.Len(t, req.User.PublicKey, crypt.X25519KeyLength)
+	})
+}
+
+func TestGRPCClient_ChangePassword(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	change := &PasswordChange{
+		Password:    "password",
+		NewPassword: "newpassword",
+	}
+
+	t.Run("Not logged in", func(t *testing.T) {
+		assert.ErrorIs(t, ts.Client.ChangePassword(testGRPCctx, change), ErrNotLoggedIn)
+	})
+
+	ts.Client.encKey = testGRPCencKey
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.UsersClient.EXPECT().ChangePassword(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.ChangePassword(testGRPCctx, change))
+	})
+
+	t.Run("Password changed", func(t *testing.T) {
+		resp := &pb.ChangePasswordResponse{Token: "newtoken"}
+		ts.UsersClient.EXPECT().ChangePassword(testGRPCctx, mockAnyVal).Return(resp, nil)
+		require.NoError(t, ts.Client.ChangePassword(testGRPCctx, change))
+		assert.Equal(t, "newtoken", ts.Client.Token)
+		assert.Equal(t, testGRPCSecretKey, ts.Client.config.GetSecretKey())
+	})
+
+	t.Run("Password and secret key changed", func(t *testing.T) {
+		var ekey []byte
+
+		resp := &pb.ChangePasswordResponse{Token: "newtoken2"}
+		ts.UsersClient.EXPECT().ChangePassword(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, req *pb.ChangePasswordRequest, _ ...grpc.CallOption) (*pb.ChangePasswordResponse, error) {
+				ekey = req.Ekey
+				return resp, nil
+			})
+
+		secretChange := *change
+		secretChange.NewSecretKey = "newsecretkey"
+		require.NoError(t, ts.Client.ChangePassword(testGRPCctx, &secretChange))
+		assert.Equal(t, "newtoken2", ts.Client.Token)
+		assert.Equal(t, "newsecretkey", ts.Client.config.GetSecretKey())
+
+		decrypted, err := crypt.DecryptAESwithAD([]byte("newsecretkey"), ekey)
+		require.NoError(t, err)
+		assert.Equal(t, testGRPCencKey, decrypted)
+	})
+
+	ts.Client.config.SetSecretKey(testGRPCSecretKey)
+}
+
+func TestGRPCClient_GetServerLimits(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.UsersClient.EXPECT().GetServerLimits(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetServerLimits(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty server response", func(t *testing.T) {
+		ts.UsersClient.EXPECT().GetServerLimits(testGRPCctx, mockAnyVal).Return(&pb.GetServerLimitsResponse{}, nil)
+		_, err := ts.Client.GetServerLimits(testGRPCctx)
+		assert.ErrorIs(t, err, ErrMissedServerResponse)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		resp := &pb.GetServerLimitsResponse{
+			ServerLimits: &pb.ServerLimits{MaxSecretSize: 1024, MaxItems: 10, Items: 2},
+		}
+		ts.UsersClient.EXPECT().GetServerLimits(testGRPCctx, mockAnyVal).Return(resp, nil)
+		limits, err := ts.Client.GetServerLimits(testGRPCctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), limits.MaxItems)
+		assert.Equal(t, int64(2), limits.Items)
+		assert.Equal(t, uint32(1024), ts.Client.MaxSecretSize)
+	})
+}
+
+func TestGRPCClient_GetAuditEvents(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.UsersClient.EXPECT().ListAuditEvents(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, _, err := ts.Client.GetAuditEvents(testGRPCctx, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		resp := &pb.ListAuditEventsResponse{
+			Events:        []*pb.AuditEvent{{Id: 12, Event: common.AuditEventLogin}},
+			NextPageToken: 12,
+		}
+		ts.UsersClient.EXPECT().ListAuditEvents(testGRPCctx, &pb.ListAuditEventsRequest{
+			Username:  "username",
+			PageToken: 20,
+		}).Return(resp, nil)
+		events, next, err := ts.Client.GetAuditEvents(testGRPCctx, 20)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, common.AuditEventLogin, events[0].Event)
+		assert.Equal(t, int64(12), next)
+	})
+}
+
+func TestGRPCClient_Sessions(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Logout", func(t *testing.T) {
+		ts.Client.Token = "token"
+		ts.UsersClient.EXPECT().Logout(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.Logout(testGRPCctx))
+		assert.Equal(t, "token", ts.Client.Token)
+
+		ts.UsersClient.EXPECT().Logout(testGRPCctx, mockAnyVal).Return(&pb.LogoutResponse{}, nil)
+		require.NoError(t, ts.Client.Logout(testGRPCctx))
+		assert.Empty(t, ts.Client.Token)
+		assert.Empty(t, ts.Client.RefreshToken)
+	})
+
+	t.Run("Get sessions", func(t *testing.T) {
+		ts.UsersClient.EXPECT().ListSessions(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetSessions(testGRPCctx)
+		assert.Error(t, err)
+
+		resp := &pb.ListSessionsResponse{Sessions: []*pb.Session{{Id: "id", Current: true}}}
+		ts.UsersClient.EXPECT().ListSessions(testGRPCctx, mockAnyVal).Return(resp, nil)
+		sessions, err := ts.Client.GetSessions(testGRPCctx)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.True(t, sessions[0].Current)
+	})
+
+	t.Run("Revoke session", func(t *testing.T) {
+		ts.UsersClient.EXPECT().RevokeSession(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RevokeSession(testGRPCctx, "id"))
+
+		ts.UsersClient.EXPECT().RevokeSession(testGRPCctx, &pb.RevokeSessionRequest{Username: "username", Id: "id"}).
+			Return(&pb.RevokeSessionResponse{}, nil)
+		require.NoError(t, ts.Client.RevokeSession(testGRPCctx, "id"))
+	})
+
+	t.Run("Revoke all sessions", func(t *testing.T) {
+		ts.Client.Token = "token"
+		ts.UsersClient.EXPECT().RevokeSession(testGRPCctx, &pb.RevokeSessionRequest{Username: "username", All: true}).
+			Return(&pb.RevokeSessionResponse{}, nil)
+		require.NoError(t, ts.Client.RevokeAllSessions(testGRPCctx))
+		assert.Empty(t, ts.Client.Token)
+
+		ts.UsersClient.EXPECT().RevokeSession(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RevokeAllSessions(testGRPCctx))
+	})
+}
+
+func TestGRPCClient_RefreshToken(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("No refresh token", func(t *testing.T) {
+		ts.Client.Token = "token"
+		ts.Client.RefreshToken = ""
+		assert.ErrorIs(t, ts.Client.refreshToken(testGRPCctx, "token"), ErrSessionExpired)
+	})
+
+	t.Run("Token is already refreshed", func(t *testing.T) {
+		ts.Client.Token = "new token"
+		ts.Client.RefreshToken = "refresh"
+		require.NoError(t, ts.Client.refreshToken(testGRPCctx, "token"))
+		assert.Equal(t, "new token", ts.Client.Token)
+	})
+
+	t.Run("Refresh error", func(t *testing.T) {
+		ts.Client.Token = "token"
+		ts.Client.RefreshToken = "refresh"
+		ts.UsersClient.EXPECT().RefreshToken(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.refreshToken(testGRPCctx, "token"))
+		assert.Equal(t, "token", ts.Client.Token)
+		assert.Equal(t, "refresh", ts.Client.RefreshToken)
+	})
+
+	t.Run("Successful refresh", func(t *testing.T) {
+		ts.Client.Token = "token"
+		ts.Client.RefreshToken = "refresh"
+		ts.UsersClient.EXPECT().
+			RefreshToken(testGRPCctx, &pb.RefreshTokenRequest{Username: "username", RefreshToken: "refresh"}).
+			Return(&pb.RefreshTokenResponse{Token: "new token", RefreshToken: "new refresh"}, nil)
+		require.NoError(t, ts.Client.refreshToken(testGRPCctx, "token"))
+		assert.Equal(t, "new token", ts.Client.Token)
+		assert.Equal(t, "new refresh", ts.Client.RefreshToken)
+	})
 }
 
 func TestGRPCClient_GetItemsList(t *testing.T) {
@@ -223,6 +521,37 @@ func TestGRPCClient_GetItemsList(t *testing.T) {
 		assert.NotEmpty(t, items)
 	})
 
+	t.Run("Trashed items are skipped", func(t *testing.T) {
+		resp := &pb.GetItemListResponse{
+			Items: []*pb.ItemShort{{Name: "123"}, {Name: "456", Deleted: timestamppb.Now()}},
+		}
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(resp, nil)
+		items, err := ts.Client.GetItemsList(testGRPCctx)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "123", items[0].Name)
+	})
+
+	t.Run("Tags are decrypted", func(t *testing.T) {
+		ts.Client.encKey = testGRPCencKey
+		defer func() { ts.Client.encKey = nil }()
+
+		tags, err := crypt.EncryptAES(testGRPCencKey, []byte("tags"))
+		require.NoError(t, err)
+
+		resp := &pb.GetItemListResponse{Items: []*pb.ItemShort{{Name: "123", Tags: tags}}}
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(resp, nil)
+		items, err := ts.Client.GetItemsList(testGRPCctx)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, []byte("tags"), items[0].Tags)
+
+		resp = &pb.GetItemListResponse{Items: []*pb.ItemShort{{Name: "123", Tags: []byte("notencrypted")}}}
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(resp, nil)
+		_, err = ts.Client.GetItemsList(testGRPCctx)
+		assert.Error(t, err)
+	})
+
 	ts.Client.config.SetMode(config.ModeLocal)
 
 	t.Run("Local storage error", func(t *testing.T) {
@@ -390,10 +719,10 @@ func TestGRPCClient_SaveItem(t *testing.T) {
 		item := TestingNewLoginItem()
 		item.ID = 100
 
-		revResp := &pb.GetRevisionResponse{Revision: []byte("revision1")}
+		revResp := &pb.GetRevisionResponse{Revision: 1}
 
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(revResp, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return([]byte("revision2"), nil)
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(2), nil)
 		ts.ItemsClient.EXPECT().GetItemHash(mockAnyVal, mockAnyVal).Return(nil, assert.AnError)
 		assert.ErrorIs(t, ErrOutOfSync, ts.Client.SaveItem(testGRPCctx, item))
 	})
@@ -402,11 +731,11 @@ func TestGRPCClient_SaveItem(t *testing.T) {
 		item := TestingNewLoginItem()
 		item.ID = 100
 
-		revResp := &pb.GetRevisionResponse{Revision: []byte("revision1")}
+		revResp := &pb.GetRevisionResponse{Revision: 1}
 		hashResp := &pb.GetItemHashResponse{Hash: []byte("wrhash")}
 
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(revResp, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return([]byte("revision2"), nil)
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(2), nil)
 		ts.ItemsClient.EXPECT().GetItemHash(mockAnyVal, mockAnyVal).Return(hashResp, nil)
 		assert.ErrorIs(t, ErrOutOfSync, ts.Client.SaveItem(testGRPCctx, item))
 	})
@@ -415,9 +744,9 @@ func TestGRPCClient_SaveItem(t *testing.T) {
 		item := TestingNewLoginItem()
 		item.ID = 100
 
-		revResp := &pb.GetRevisionResponse{Revision: []byte("revision1")}
+		revResp := &pb.GetRevisionResponse{Revision: 1}
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(revResp, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return([]byte("revision1"), nil)
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(1), nil)
 
 		resp := &pb.UpdateItemResponse{}
 		ts.ItemsClient.EXPECT().UpdateItem(testGRPCctx, mockAnyVal).Return(resp, nil)
@@ -454,6 +783,295 @@ func TestGRPCClient_DeleteItem(t *testing.T) {
 	})
 }
 
+func TestGRPCClient_GetItemVersions(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Server response error", func(t *testing.T) {
+		item := TestingNewLoginItem()
+
+		ts.ItemsClient.EXPECT().ListItemVersions(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetItemVersions(testGRPCctx, item)
+		assert.Error(t, err)
+	})
+
+	t.Run("Decryption error", func(t *testing.T) {
+		item := TestingNewLoginItem()
+		resp := &pb.ListItemVersionsResponse{
+			Versions: []*pb.ItemVersion{{Version: 1}},
+		}
+
+		ts.ItemsClient.EXPECT().ListItemVersions(testGRPCctx, mockAnyVal).Return(resp, nil)
+		_, err := ts.Client.GetItemVersions(testGRPCctx, item)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get versions", func(t *testing.T) {
+		item := TestingNewLoginItem()
+		ts.Client.encKey = testGRPCencKey
+		pbItem := item.ToPB()
+		ts.Client.EncryptPbItem(pbItem)
+
+		resp := &pb.ListItemVersionsResponse{
+			Versions: []*pb.ItemVersion{{Version: 1, Item: pbItem}},
+		}
+
+		ts.ItemsClient.EXPECT().ListItemVersions(testGRPCctx, mockAnyVal).Return(resp, nil)
+		versions, err := ts.Client.GetItemVersions(testGRPCctx, item)
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		assert.Equal(t, int64(1), versions[0].Version)
+		assert.Equal(t, item.Secret, versions[0].Item.Secret)
+	})
+}
+
+func TestGRPCClient_RestoreItemVersion(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Restore version error", func(t *testing.T) {
+		item := TestingNewLoginItem()
+
+		ts.ItemsClient.EXPECT().RestoreItemVersion(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RestoreItemVersion(testGRPCctx, item, 1))
+	})
+
+	t.Run("Restore version", func(t *testing.T) {
+		item := TestingNewLoginItem()
+		resp := &pb.RestoreItemVersionResponse{}
+
+		ts.ItemsClient.EXPECT().RestoreItemVersion(testGRPCctx, mockAnyVal).Return(resp, nil)
+		require.NoError(t, ts.Client.RestoreItemVersion(testGRPCctx, item, 1))
+	})
+}
+
+func TestGRPCClient_GetTrashList(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().ListTrash(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetTrashList(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		resp := &pb.ListTrashResponse{
+			Items: []*pb.ItemShort{{Name: "123", Deleted: timestamppb.Now()}},
+		}
+		ts.ItemsClient.EXPECT().ListTrash(testGRPCctx, mockAnyVal).Return(resp, nil)
+		items, err := ts.Client.GetTrashList(testGRPCctx)
+		require.NoError(t, err)
+		assert.NotEmpty(t, items)
+	})
+}
+
+func TestGRPCClient_RestoreItem(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Restore item error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().RestoreItem(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RestoreItem(testGRPCctx, 1))
+	})
+
+	t.Run("Restore item", func(t *testing.T) {
+		resp := &pb.RestoreItemResponse{}
+
+		ts.ItemsClient.EXPECT().RestoreItem(testGRPCctx, mockAnyVal).Return(resp, nil)
+		require.NoError(t, ts.Client.RestoreItem(testGRPCctx, 1))
+	})
+}
+
+func TestGRPCClient_PurgeItem(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Purge item error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().PurgeItem(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.PurgeItem(testGRPCctx, 1))
+	})
+
+	t.Run("Purge item", func(t *testing.T) {
+		resp := &pb.PurgeItemResponse{}
+
+		ts.ItemsClient.EXPECT().PurgeItem(testGRPCctx, mockAnyVal).Return(resp, nil)
+		require.NoError(t, ts.Client.PurgeItem(testGRPCctx, 1))
+	})
+}
+
+func TestGRPCClient_RotateEncryptionKey(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.config.SetUser("username")
+
+	t.Run("Not logged in", func(t *testing.T) {
+		assert.ErrorIs(t, ts.Client.RotateEncryptionKey(testGRPCctx), ErrNotLoggedIn)
+	})
+
+	ts.Client.encKey = testGRPCencKey
+	defer func() { ts.Client.encKey = testGRPCencKey }()
+
+	newEncryptedItem := func() *pb.Item {
+		item := TestingNewLoginItem().ToPB()
+		require.NoError(t, ts.Client.EncryptPbItem(item))
+
+		return item
+	}
+
+	t.Run("Get items error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+	})
+
+	newEncryptedFolders := func() *pb.GetFoldersResponse {
+		name, err := crypt.EncryptAES(testGRPCencKey, []byte("folder"))
+		require.NoError(t, err)
+
+		return &pb.GetFoldersResponse{Folders: []*pb.Folder{{Id: 1, Name: name}}}
+	}
+
+	t.Run("Get folders error", func(t *testing.T) {
+		resp := &pb.GetAllItemsResponse{Items: []*pb.Item{newEncryptedItem()}}
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+	})
+
+	t.Run("Folder decryption error", func(t *testing.T) {
+		resp := &pb.GetAllItemsResponse{Items: []*pb.Item{newEncryptedItem()}}
+		folders := &pb.GetFoldersResponse{Folders: []*pb.Folder{{Id: 1, Name: []byte("notencrypted")}}}
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(folders, nil)
+		assert.Error(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+		assert.Equal(t, testGRPCencKey, ts.Client.encKey)
+	})
+
+	t.Run("Item decryption error", func(t *testing.T) {
+		resp := &pb.GetAllItemsResponse{Items: []*pb.Item{{Secrets: &pb.Secrets{Secret: []byte("notencrypted")}}}}
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(newEncryptedFolders(), nil)
+		assert.Error(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+		assert.Equal(t, testGRPCencKey, ts.Client.encKey)
+	})
+
+	t.Run("Rotation error", func(t *testing.T) {
+		resp := &pb.GetAllItemsResponse{Items: []*pb.Item{newEncryptedItem()}}
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(newEncryptedFolders(), nil)
+		ts.EmergClient.EXPECT().GetEmergencyAccesses(testGRPCctx, mockAnyVal).
+			Return(&pb.GetEmergencyAccessesResponse{}, nil)
+		ts.ItemsClient.EXPECT().RotateEncryptionKey(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+		assert.Equal(t, testGRPCencKey, ts.Client.encKey, "key mustn't be changed on failure")
+	})
+
+	t.Run("Key rotated", func(t *testing.T) {
+		var rotateReq *pb.RotateEncryptionKeyRequest
+
+		resp := &pb.GetAllItemsResponse{Items: []*pb.Item{newEncryptedItem()}}
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(newEncryptedFolders(), nil)
+		ts.EmergClient.EXPECT().GetEmergencyAccesses(testGRPCctx, mockAnyVal).
+			Return(&pb.GetEmergencyAccessesResponse{}, nil)
+		ts.ItemsClient.EXPECT().RotateEncryptionKey(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, req *pb.RotateEncryptionKeyRequest,
+				_ ...grpc.CallOption) (*pb.RotateEncryptionKeyResponse, error) {
+				rotateReq = req
+				return &pb.RotateEncryptionKeyResponse{}, nil
+			})
+
+		require.NoError(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+		assert.NotEqual(t, testGRPCencKey, ts.Client.encKey)
+
+		decryptedKey, err := crypt.DecryptAESwithAD([]byte(testGRPCSecretKey), rotateReq.Ekey)
+		require.NoError(t, err)
+		assert.Equal(t, ts.Client.encKey, decryptedKey)
+
+		require.Len(t, rotateReq.Items, 1)
+		require.NoError(t, ts.Client.DecryptPbItem(rotateReq.Items[0]))
+		assert.Equal(t, TestingNewLoginItem().ToPB().Secrets, rotateReq.Items[0].Secrets)
+
+		require.Len(t, rotateReq.Folders, 1)
+		name, err := crypt.DecryptAES(ts.Client.encKey, rotateReq.Folders[0].Name)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("folder"), name)
+		assert.Nil(t, rotateReq.PrivateKey)
+	})
+
+	t.Run("Key rotated with private key", func(t *testing.T) {
+		var rotateReq *pb.RotateEncryptionKeyRequest
+
+		ts.Client.encKey = testGRPCencKey
+		ts.Client.privateKey = []byte("private")
+		defer func() { ts.Client.privateKey = nil }()
+
+		resp := &pb.GetAllItemsResponse{}
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.EmergClient.EXPECT().GetEmergencyAccesses(testGRPCctx, mockAnyVal).
+			Return(&pb.GetEmergencyAccessesResponse{}, nil)
+		ts.ItemsClient.EXPECT().RotateEncryptionKey(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, req *pb.RotateEncryptionKeyRequest,
+				_ ...grpc.CallOption) (*pb.RotateEncryptionKeyResponse, error) {
+				rotateReq = req
+				return &pb.RotateEncryptionKeyResponse{}, nil
+			})
+
+		require.NoError(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+
+		privateKey, err := crypt.DecryptAES(ts.Client.encKey, rotateReq.PrivateKey)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("private"), privateKey)
+	})
+
+	t.Run("Get emergency accesses error", func(t *testing.T) {
+		ts.Client.encKey = testGRPCencKey
+
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(&pb.GetAllItemsResponse{}, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.EmergClient.EXPECT().GetEmergencyAccesses(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+		assert.Equal(t, testGRPCencKey, ts.Client.encKey)
+	})
+
+	t.Run("Key rotated with emergency keys", func(t *testing.T) {
+		var rotateReq *pb.RotateEncryptionKeyRequest
+
+		ts.Client.encKey = testGRPCencKey
+
+		publicKey, privateKey, err := crypt.GenerateKeyPair()
+		require.NoError(t, err)
+
+		accesses := &pb.GetEmergencyAccessesResponse{Granted: []*pb.EmergencyAccess{{Id: 3, Grantee: "contact"}}}
+		ts.ItemsClient.EXPECT().GetAllItems(testGRPCctx, mockAnyVal).Return(&pb.GetAllItemsResponse{}, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.EmergClient.EXPECT().GetEmergencyAccesses(testGRPCctx, mockAnyVal).Return(accesses, nil)
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, &pb.GetPublicKeyRequest{Username: "contact"}).
+			Return(&pb.GetPublicKeyResponse{PublicKey: publicKey}, nil)
+		ts.ItemsClient.EXPECT().RotateEncryptionKey(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, req *pb.RotateEncryptionKeyRequest,
+				_ ...grpc.CallOption) (*pb.RotateEncryptionKeyResponse, error) {
+				rotateReq = req
+				return &pb.RotateEncryptionKeyResponse{}, nil
+			})
+
+		require.NoError(t, ts.Client.RotateEncryptionKey(testGRPCctx))
+
+		require.Len(t, rotateReq.EmergencyKeys, 1)
+		assert.Equal(t, int64(3), rotateReq.EmergencyKeys[0].Id)
+
+		key, err := crypt.DecryptWithKeyPair(publicKey, privateKey, rotateReq.EmergencyKeys[0].Ekey)
+		require.NoError(t, err)
+		assert.Equal(t, ts.Client.encKey, key)
+	})
+}
+
 func TestGRPCClient_wrapError(t *testing.T) {
 	ts := NewTestSuiteGRPClient(t)
 	defer ts.Stop()
@@ -542,6 +1160,29 @@ func TestGRPCClient_encrypt_decryptPbItem(t *testing.T) {
 			t.Errorf("Encrypt/decrypt err: got %v, want %v", pbItem, wantItem)
 		}
 	})
+
+	t.Run("Tags encryption error", func(t *testing.T) {
+		pbItem := TestingNewPbLoginItem()
+		pbItem.Secrets.Secret = nil
+		pbItem.Secrets.Notes = nil
+		pbItem.Additions.Uris = nil
+		pbItem.Additions.CustomFields = nil
+
+		err := ts.Client.EncryptPbItem(pbItem)
+		assert.Error(t, err)
+		err = ts.Client.DecryptPbItem(pbItem)
+		assert.Error(t, err)
+
+		wantItem := TestingNewPbLoginItem()
+		wantItem.Secrets.Secret = nil
+		wantItem.Secrets.Notes = nil
+		wantItem.Additions.Uris = nil
+		wantItem.Additions.CustomFields = nil
+		pbItem.Updated = wantItem.Updated
+		if !reflect.DeepEqual(pbItem, wantItem) {
+			t.Errorf("Encrypt/decrypt err: got %v, want %v", pbItem, wantItem)
+		}
+	})
 }
 
 func BenchmarkGRPCClient_EncryptDecrypt(b *testing.B) {
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// EmergencyAccess represents emergency access to grantor's vault, granted to trusted contact (grantee).
+type EmergencyAccess struct {
+	ID           int64
+	Grantor      string
+	Grantee      string
+	Mode         string
+	Status       string
+	WaitDays     int
+	ApproveAfter *time.Time
+}
+
+// Approved returns true if grantee is able to access grantor's vault.
+func (a *EmergencyAccess) Approved() bool {
+	return a.Status == common.EmergencyStatusApproved
+}
+
+// Takeoverable returns true if grantee is able to take over grantor's account.
+func (a *EmergencyAccess) Takeoverable() bool {
+	return a.Approved() && a.Mode == common.EmergencyModeTakeover
+}
+
+// newEmergencyAccessFromPB is a helper function which converts emergency access received from server.
+func newEmergencyAccessFromPB(access *pb.EmergencyAccess) *EmergencyAccess {
+	a := &EmergencyAccess{
+		ID:       access.Id,
+		Grantor:  access.Grantor,
+		Grantee:  access.Grantee,
+		Mode:     access.Mode,
+		Status:   access.Status,
+		WaitDays: int(access.WaitDays),
+	}
+
+	if access.ApproveAfter != nil {
+		approveAfter := access.ApproveAfter.AsTime().Local()
+		a.ApproveAfter = &approveAfter
+	}
+
+	return a
+}
+
+// GetEmergencyAccesses returns emergency accesses granted by user to trusted contacts and
+// emergency accesses granted to user.
+func (c *GRPCClient) GetEmergencyAccesses(ctx context.Context) ([]*EmergencyAccess, []*EmergencyAccess, error) {
+	request := &pb.GetEmergencyAccessesRequest{Username: c.config.GetUser()}
+
+	resp, err := c.emergencyClient.GetEmergencyAccesses(ctx, request)
+	if err != nil {
+		return nil, nil, c.wrapError(err)
+	}
+
+	granted := make([]*EmergencyAccess, 0, len(resp.Granted))
+	for _, access := range resp.Granted {
+		granted = append(granted, newEmergencyAccessFromPB(access))
+	}
+
+	received := make([]*EmergencyAccess, 0, len(resp.Received))
+	for _, access := range resp.Received {
+		received = append(received, newEmergencyAccessFromPB(access))
+	}
+
+	return granted, received, nil
+}
+
+// InviteEmergencyContact invites trusted contact, which can request access to user's vault.
+//
+// User's encryption key is encrypted with contact's public key, so contact gets access to
+// user's items only after access is approved by user or waiting period is over.
+func (c *GRPCClient) InviteEmergencyContact(ctx context.Context, username, mode string, waitDays int) error {
+	if c.encKey == nil {
+		return ErrNotLoggedIn
+	}
+
+	keyResp, err := c.usersClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Username: username})
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	ekey, err := crypt.EncryptForPublicKey(keyResp.PublicKey, c.encKey)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.InviteEmergencyContactRequest{
+		Username: c.config.GetUser(),
+		Access: &pb.EmergencyAccess{
+			Grantee:  username,
+			Mode:     mode,
+			WaitDays: int32(waitDays),
+			Ekey:     ekey,
+		},
+	}
+
+	if _, err := c.emergencyClient.InviteEmergencyContact(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// AcceptEmergencyInvite accepts invite as trusted contact.
+func (c *GRPCClient) AcceptEmergencyInvite(ctx context.Context, access *EmergencyAccess) error {
+	request := &pb.AcceptEmergencyInviteRequest{Username: c.config.GetUser(), Id: access.ID}
+
+	if _, err := c.emergencyClient.AcceptEmergencyInvite(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// RequestEmergencyAccess requests access to grantor's vault.
+//
+// Access is approved automatically after waiting period, if grantor doesn't reject it.
+func (c *GRPCClient) RequestEmergencyAccess(ctx context.Context, access *EmergencyAccess) error {
+	request := &pb.RequestEmergencyAccessRequest{Username: c.config.GetUser(), Id: access.ID}
+
+	if _, err := c.emergencyClient.RequestEmergencyAccess(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// ApproveEmergencyAccess approves requested access before waiting period is over.
+func (c *GRPCClient) ApproveEmergencyAccess(ctx context.Context, access *EmergencyAccess) error {
+	request := &pb.ApproveEmergencyAccessRequest{Username: c.config.GetUser(), Id: access.ID}
+
+	if _, err := c.emergencyClient.ApproveEmergencyAccess(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// RejectEmergencyAccess rejects requested or approved access, trusted contact can request access again.
+func (c *GRPCClient) RejectEmergencyAccess(ctx context.Context, access *EmergencyAccess) error {
+	request := &pb.RejectEmergencyAccessRequest{Username: c.config.GetUser(), Id: access.ID}
+
+	if _, err := c.emergencyClient.RejectEmergencyAccess(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// RevokeEmergencyAccess deletes emergency access, used by grantor as well as by trusted contact.
+func (c *GRPCClient) RevokeEmergencyAccess(ctx context.Context, access *EmergencyAccess) error {
+	request := &pb.RevokeEmergencyAccessRequest{Username: c.config.GetUser(), Id: access.ID}
+
+	if _, err := c.emergencyClient.RevokeEmergencyAccess(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// GetEmergencyVault returns grantor's items, decrypted with grantor's encryption key.
+//
+// Grantor's items are always requested from server and never kept in local storage.
+func (c *GRPCClient) GetEmergencyVault(ctx context.Context, access *EmergencyAccess) ([]*Item, error) {
+	resp, key, err := c.getEmergencyVault(ctx, access)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*Item, 0, len(resp.Items))
+
+	for _, pbItem := range resp.Items {
+		if err := decryptPbItem(key, pbItem); err != nil {
+			return nil, err
+		}
+
+		items = append(items, NewItemFromPB(pbItem))
+	}
+
+	return items, nil
+}
+
+// TakeoverAccount sets new password and secret key of grantor.
+//
+// Grantor's encryption key is kept, so grantor's items don't require re-encryption.
+// Grantor's two-factor authentication is disabled by server.
+func (c *GRPCClient) TakeoverAccount(ctx context.Context, access *EmergencyAccess,
+	newPassword, newSecretKey string) error {
+	_, key, err := c.getEmergencyVault(ctx, access)
+	if err != nil {
+		return err
+	}
+
+	pwdhash, err := crypt.CalculatePasswordHash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	ekey, err := crypt.EncryptAESwithAD([]byte(newSecretKey), key)
+	if err != nil {
+		return ErrEKeyEncryptionFailed
+	}
+
+	request := &pb.TakeoverAccountRequest{
+		Username:   c.config.GetUser(),
+		Id:         access.ID,
+		NewPwdhash: pwdhash,
+		Ekey:       ekey,
+	}
+
+	if _, err := c.emergencyClient.TakeoverAccount(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// getEmergencyVault is a helper function which requests grantor's vault and decrypts grantor's
+// encryption key with user's private key.
+func (c *GRPCClient) getEmergencyVault(ctx context.Context,
+	access *EmergencyAccess) (*pb.GetEmergencyVaultResponse, []byte, error) {
+	if c.privateKey == nil {
+		return nil, nil, ErrKeyPairMissed
+	}
+
+	request := &pb.GetEmergencyVaultRequest{Username: c.config.GetUser(), Id: access.ID}
+
+	resp, err := c.emergencyClient.GetEmergencyVault(ctx, request)
+	if err != nil {
+		return nil, nil, c.wrapError(err)
+	}
+
+	key, err := crypt.DecryptWithKeyPair(c.publicKey, c.privateKey, resp.Ekey)
+	if err != nil {
+		return nil, nil, ErrEKeyDecryptionFailed
+	}
+
+	return resp, key, nil
+}
+
+// rewrapEmergencyKeys is a helper function which encrypts new encryption key with public keys of
+// all user's trusted contacts during encryption key rotation.
+func (c *GRPCClient) rewrapEmergencyKeys(ctx context.Context, newKey []byte) ([]*pb.EmergencyKey, error) {
+	granted, _, err := c.GetEmergencyAccesses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*pb.EmergencyKey, 0, len(granted))
+
+	for _, access := range granted {
+		keyResp, err := c.usersClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Username: access.Grantee})
+		if err != nil {
+			return nil, c.wrapError(err)
+		}
+
+		ekey, err := crypt.EncryptForPublicKey(keyResp.PublicKey, newKey)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, &pb.EmergencyKey{Id: access.ID, Ekey: ekey})
+	}
+
+	return keys, nil
+}
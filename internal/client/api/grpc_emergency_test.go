@@ -0,0 +1,242 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEmergencyAccess_Permissions(t *testing.T) {
+	takeover := &EmergencyAccess{Mode: common.EmergencyModeTakeover, Status: common.EmergencyStatusApproved}
+	assert.True(t, takeover.Approved())
+	assert.True(t, takeover.Takeoverable())
+
+	view := &EmergencyAccess{Mode: common.EmergencyModeView, Status: common.EmergencyStatusApproved}
+	assert.True(t, view.Approved())
+	assert.False(t, view.Takeoverable())
+
+	requested := &EmergencyAccess{Mode: common.EmergencyModeTakeover, Status: common.EmergencyStatusRequested}
+	assert.False(t, requested.Approved())
+	assert.False(t, requested.Takeoverable())
+}
+
+func TestGRPCClient_GetEmergencyAccesses(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.EmergClient.EXPECT().GetEmergencyAccesses(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, _, err := ts.Client.GetEmergencyAccesses(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		approveAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		resp := &pb.GetEmergencyAccessesResponse{
+			Granted: []*pb.EmergencyAccess{{Id: 1, Grantor: "user", Grantee: "contact",
+				Mode: common.EmergencyModeView, Status: common.EmergencyStatusInvited, WaitDays: 2}},
+			Received: []*pb.EmergencyAccess{{Id: 2, Grantor: "friend", Grantee: "user",
+				Mode: common.EmergencyModeTakeover, Status: common.EmergencyStatusRequested, WaitDays: 7,
+				ApproveAfter: timestamppb.New(approveAfter)}},
+		}
+		ts.EmergClient.EXPECT().GetEmergencyAccesses(testGRPCctx, mockAnyVal).Return(resp, nil)
+
+		granted, received, err := ts.Client.GetEmergencyAccesses(testGRPCctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*EmergencyAccess{{ID: 1, Grantor: "user", Grantee: "contact",
+			Mode: common.EmergencyModeView, Status: common.EmergencyStatusInvited, WaitDays: 2}}, granted)
+		require.Len(t, received, 1)
+		assert.Equal(t, 7, received[0].WaitDays)
+		require.NotNil(t, received[0].ApproveAfter)
+		assert.True(t, approveAfter.Equal(*received[0].ApproveAfter))
+	})
+}
+
+func TestGRPCClient_InviteEmergencyContact(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Not logged in", func(t *testing.T) {
+		err := ts.Client.InviteEmergencyContact(testGRPCctx, "contact", common.EmergencyModeView, 2)
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+	})
+
+	ts.Client.encKey = testGRPCencKey
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	t.Run("Get public key error", func(t *testing.T) {
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.InviteEmergencyContact(testGRPCctx, "contact", common.EmergencyModeView, 2))
+	})
+
+	t.Run("Contact invited", func(t *testing.T) {
+		var req *pb.InviteEmergencyContactRequest
+
+		resp := &pb.GetPublicKeyResponse{PublicKey: publicKey}
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, &pb.GetPublicKeyRequest{Username: "contact"}).
+			Return(resp, nil)
+		ts.EmergClient.EXPECT().InviteEmergencyContact(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.InviteEmergencyContactRequest,
+				_ ...grpc.CallOption) (*pb.InviteEmergencyContactResponse, error) {
+				req = r
+				return &pb.InviteEmergencyContactResponse{}, nil
+			})
+		require.NoError(t, ts.Client.InviteEmergencyContact(testGRPCctx, "contact", common.EmergencyModeTakeover, 5))
+
+		assert.Equal(t, "contact", req.Access.Grantee)
+		assert.Equal(t, common.EmergencyModeTakeover, req.Access.Mode)
+		assert.Equal(t, int32(5), req.Access.WaitDays)
+
+		key, err := crypt.DecryptWithKeyPair(publicKey, privateKey, req.Access.Ekey)
+		require.NoError(t, err)
+		assert.Equal(t, testGRPCencKey, key)
+	})
+}
+
+func TestGRPCClient_ManageEmergencyAccess(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	access := &EmergencyAccess{ID: 1}
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.EmergClient.EXPECT().AcceptEmergencyInvite(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.AcceptEmergencyInvite(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().RequestEmergencyAccess(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RequestEmergencyAccess(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().ApproveEmergencyAccess(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.ApproveEmergencyAccess(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().RejectEmergencyAccess(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RejectEmergencyAccess(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().RevokeEmergencyAccess(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RevokeEmergencyAccess(testGRPCctx, access))
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		ts.EmergClient.EXPECT().AcceptEmergencyInvite(testGRPCctx, &pb.AcceptEmergencyInviteRequest{Id: 1}).
+			Return(&pb.AcceptEmergencyInviteResponse{}, nil)
+		require.NoError(t, ts.Client.AcceptEmergencyInvite(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().RequestEmergencyAccess(testGRPCctx, &pb.RequestEmergencyAccessRequest{Id: 1}).
+			Return(&pb.RequestEmergencyAccessResponse{}, nil)
+		require.NoError(t, ts.Client.RequestEmergencyAccess(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().ApproveEmergencyAccess(testGRPCctx, &pb.ApproveEmergencyAccessRequest{Id: 1}).
+			Return(&pb.ApproveEmergencyAccessResponse{}, nil)
+		require.NoError(t, ts.Client.ApproveEmergencyAccess(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().RejectEmergencyAccess(testGRPCctx, &pb.RejectEmergencyAccessRequest{Id: 1}).
+			Return(&pb.RejectEmergencyAccessResponse{}, nil)
+		require.NoError(t, ts.Client.RejectEmergencyAccess(testGRPCctx, access))
+
+		ts.EmergClient.EXPECT().RevokeEmergencyAccess(testGRPCctx, &pb.RevokeEmergencyAccessRequest{Id: 1}).
+			Return(&pb.RevokeEmergencyAccessResponse{}, nil)
+		require.NoError(t, ts.Client.RevokeEmergencyAccess(testGRPCctx, access))
+	})
+}
+
+func TestGRPCClient_GetEmergencyVault(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	access := &EmergencyAccess{ID: 1}
+
+	t.Run("Key pair missed", func(t *testing.T) {
+		_, err := ts.Client.GetEmergencyVault(testGRPCctx, access)
+		assert.ErrorIs(t, err, ErrKeyPairMissed)
+	})
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	ts.Client.publicKey, ts.Client.privateKey = publicKey, privateKey
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.EmergClient.EXPECT().GetEmergencyVault(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetEmergencyVault(testGRPCctx, access)
+		assert.Error(t, err)
+	})
+
+	t.Run("Key decryption error", func(t *testing.T) {
+		resp := &pb.GetEmergencyVaultResponse{Ekey: []byte("wrong")}
+		ts.EmergClient.EXPECT().GetEmergencyVault(testGRPCctx, mockAnyVal).Return(resp, nil)
+		_, err := ts.Client.GetEmergencyVault(testGRPCctx, access)
+		assert.ErrorIs(t, err, ErrEKeyDecryptionFailed)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		key := crypt.GenerateRandomKey32()
+		ekey, err := crypt.EncryptForPublicKey(publicKey, key)
+		require.NoError(t, err)
+
+		item := TestingNewLoginItem().ToPB()
+		require.NoError(t, encryptPbItem(key, item))
+
+		resp := &pb.GetEmergencyVaultResponse{Items: []*pb.Item{item}, Ekey: ekey}
+		ts.EmergClient.EXPECT().GetEmergencyVault(testGRPCctx, &pb.GetEmergencyVaultRequest{Id: 1}).Return(resp, nil)
+
+		items, err := ts.Client.GetEmergencyVault(testGRPCctx, access)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, TestingNewLoginItem().ToPB().Secrets, items[0].ToPB().Secrets)
+	})
+}
+
+func TestGRPCClient_TakeoverAccount(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	access := &EmergencyAccess{ID: 1}
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	ts.Client.publicKey, ts.Client.privateKey = publicKey, privateKey
+
+	key := crypt.GenerateRandomKey32()
+	ekey, err := crypt.EncryptForPublicKey(publicKey, key)
+	require.NoError(t, err)
+
+	vault := &pb.GetEmergencyVaultResponse{Ekey: ekey}
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.EmergClient.EXPECT().GetEmergencyVault(testGRPCctx, mockAnyVal).Return(vault, nil)
+		ts.EmergClient.EXPECT().TakeoverAccount(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.TakeoverAccount(testGRPCctx, access, "newpassword", "newsecret"))
+	})
+
+	t.Run("Account taken over", func(t *testing.T) {
+		var req *pb.TakeoverAccountRequest
+
+		ts.EmergClient.EXPECT().GetEmergencyVault(testGRPCctx, mockAnyVal).Return(vault, nil)
+		ts.EmergClient.EXPECT().TakeoverAccount(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.TakeoverAccountRequest,
+				_ ...grpc.CallOption) (*pb.TakeoverAccountResponse, error) {
+				req = r
+				return &pb.TakeoverAccountResponse{}, nil
+			})
+		require.NoError(t, ts.Client.TakeoverAccount(testGRPCctx, access, "newpassword", "newsecret"))
+
+		assert.Equal(t, int64(1), req.Id)
+		assert.NotEmpty(t, req.NewPwdhash)
+
+		gotKey, err := crypt.DecryptAESwithAD([]byte("newsecret"), req.Ekey)
+		require.NoError(t, err)
+		assert.Equal(t, key, gotKey)
+	})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"sort"
+
+	"github.com/artfuldog/gophkeeper/internal/client/config"
+	"github.com/artfuldog/gophkeeper/internal/client/storage"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// Folder represents folder for organizing items.
+type Folder struct {
+	ID   int64
+	Name string
+}
+
+// GetFolders returns user's folders sorted by name.
+//
+// Folders' names are stored encrypted and decrypted client-side.
+func (c *GRPCClient) GetFolders(ctx context.Context) ([]*Folder, error) {
+	var encrypted storage.Folders
+
+	switch c.config.GetMode() {
+	case config.ModeLocal:
+		storFolders, err := c.storage.GetFolders(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted = storFolders
+	default:
+		pbFolders, err := c.getFolders(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted = pbFolders
+	}
+
+	folders := make([]*Folder, 0, len(encrypted))
+
+	for _, f := range encrypted {
+		name, err := crypt.DecryptAES(c.encKey, f.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		folders = append(folders, &Folder{ID: f.ID, Name: string(name)})
+	}
+
+	sort.SliceStable(folders, func(i, j int) bool { return folders[i].Name < folders[j].Name })
+
+	return folders, nil
+}
+
+// getFolders returns user's folders with encrypted names from server in storage format.
+func (c *GRPCClient) getFolders(ctx context.Context) (storage.Folders, error) {
+	resp, err := c.itemsClient.GetFolders(ctx, &pb.GetFoldersRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	folders := make(storage.Folders, len(resp.Folders))
+	for i, f := range resp.Folders {
+		folders[i] = &storage.Folder{ID: f.Id, Name: f.Name}
+	}
+
+	return folders, nil
+}
+
+// SaveFolder creates new or renames existing folder.
+//
+// Which action to take - rename or create, based on folder ID - for new folder id is always 0,
+// for existing >0.
+func (c *GRPCClient) SaveFolder(ctx context.Context, folder *Folder) error {
+	name, err := crypt.EncryptAES(c.encKey, []byte(folder.Name))
+	if err != nil {
+		return err
+	}
+
+	pbFolder := &pb.Folder{
+		Id:   folder.ID,
+		Name: name,
+	}
+
+	if folder.ID > 0 {
+		_, err = c.itemsClient.UpdateFolder(ctx, &pb.UpdateFolderRequest{
+			Username: c.config.GetUser(),
+			Folder:   pbFolder,
+		})
+	} else {
+		_, err = c.itemsClient.CreateFolder(ctx, &pb.CreateFolderRequest{
+			Username: c.config.GetUser(),
+			Folder:   pbFolder,
+		})
+	}
+
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	c.ForceSyncWithWait()
+
+	return nil
+}
+
+// DeleteFolder deletes existing folder, folder's items are kept without folder.
+func (c *GRPCClient) DeleteFolder(ctx context.Context, folder *Folder) error {
+	request := &pb.DeleteFolderRequest{
+		Username: c.config.GetUser(),
+		Id:       folder.ID,
+	}
+
+	if _, err := c.itemsClient.DeleteFolder(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	c.ForceSyncWithWait()
+
+	return nil
+}
+
+// MoveItem moves existing item to folder, zero folder's ID removes item from folder.
+func (c *GRPCClient) MoveItem(ctx context.Context, item *Item, folderID int64) error {
+	if item.ID == 0 {
+		return ErrItemNotSaved
+	}
+
+	moved := *item
+	moved.FolderID = folderID
+
+	if err := c.SaveItem(ctx, &moved); err != nil {
+		return err
+	}
+
+	item.FolderID = folderID
+
+	return nil
+}
+
+// syncFolders replaces all folders in local storage with folders received from server.
+func (c *GRPCClient) syncFolders(ctx context.Context) error {
+	folders, err := c.getFolders(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.storage.SaveFolders(ctx, folders)
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/client/config"
+	"github.com/artfuldog/gophkeeper/internal/client/storage"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestGRPCClient_GetFolders(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.encKey = testGRPCencKey
+
+	encrypt := func(name string) []byte {
+		encrypted, err := crypt.EncryptAES(testGRPCencKey, []byte(name))
+		require.NoError(t, err)
+
+		return encrypted
+	}
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetFolders(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Decryption error", func(t *testing.T) {
+		resp := &pb.GetFoldersResponse{Folders: []*pb.Folder{{Id: 1, Name: []byte("notencrypted")}}}
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(resp, nil)
+		_, err := ts.Client.GetFolders(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		resp := &pb.GetFoldersResponse{Folders: []*pb.Folder{
+			{Id: 1, Name: encrypt("work")},
+			{Id: 2, Name: encrypt("home")},
+		}}
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(resp, nil)
+		folders, err := ts.Client.GetFolders(testGRPCctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*Folder{{ID: 2, Name: "home"}, {ID: 1, Name: "work"}}, folders)
+	})
+
+	ts.Client.config.SetMode(config.ModeLocal)
+
+	t.Run("Local storage error", func(t *testing.T) {
+		ts.Storage.EXPECT().GetFolders(testGRPCctx).Return(nil, assert.AnError)
+		_, err := ts.Client.GetFolders(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Local storage OK", func(t *testing.T) {
+		resp := storage.Folders{{ID: 1, Name: encrypt("work")}}
+		ts.Storage.EXPECT().GetFolders(testGRPCctx).Return(resp, nil)
+		folders, err := ts.Client.GetFolders(testGRPCctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*Folder{{ID: 1, Name: "work"}}, folders)
+	})
+}
+
+func TestGRPCClient_SaveFolder(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Encryption error", func(t *testing.T) {
+		assert.Error(t, ts.Client.SaveFolder(testGRPCctx, &Folder{Name: "folder"}))
+	})
+
+	ts.Client.encKey = testGRPCencKey
+
+	t.Run("Create folder error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().CreateFolder(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.SaveFolder(testGRPCctx, &Folder{Name: "folder"}))
+	})
+
+	t.Run("Create folder", func(t *testing.T) {
+		var req *pb.CreateFolderRequest
+
+		ts.ItemsClient.EXPECT().CreateFolder(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.CreateFolderRequest,
+				_ ...grpc.CallOption) (*pb.CreateFolderResponse, error) {
+				req = r
+				return &pb.CreateFolderResponse{Id: 1}, nil
+			})
+		require.NoError(t, ts.Client.SaveFolder(testGRPCctx, &Folder{Name: "folder"}))
+
+		name, err := crypt.DecryptAES(testGRPCencKey, req.Folder.Name)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("folder"), name)
+	})
+
+	t.Run("Rename folder error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().UpdateFolder(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.SaveFolder(testGRPCctx, &Folder{ID: 1, Name: "folder"}))
+	})
+
+	t.Run("Rename folder", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().UpdateFolder(testGRPCctx, mockAnyVal).Return(&pb.UpdateFolderResponse{}, nil)
+		assert.NoError(t, ts.Client.SaveFolder(testGRPCctx, &Folder{ID: 1, Name: "folder"}))
+	})
+}
+
+func TestGRPCClient_DeleteFolder(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Delete folder error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().DeleteFolder(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.DeleteFolder(testGRPCctx, &Folder{ID: 1}))
+	})
+
+	t.Run("Delete folder", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().DeleteFolder(testGRPCctx, &pb.DeleteFolderRequest{Id: 1}).
+			Return(&pb.DeleteFolderResponse{}, nil)
+		assert.NoError(t, ts.Client.DeleteFolder(testGRPCctx, &Folder{ID: 1}))
+	})
+}
+
+func TestGRPCClient_MoveItem(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.encKey = testGRPCencKey
+	ts.Client.MaxSecretSize = 1024 * 1024
+
+	t.Run("Item not saved", func(t *testing.T) {
+		assert.ErrorIs(t, ts.Client.MoveItem(testGRPCctx, TestingNewLoginItem(), 1), ErrItemNotSaved)
+	})
+
+	t.Run("Update item error", func(t *testing.T) {
+		item := TestingNewLoginItem()
+		item.ID = 100
+
+		ts.ItemsClient.EXPECT().UpdateItem(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.MoveItem(testGRPCctx, item, 1))
+		assert.Zero(t, item.FolderID, "folder mustn't be changed on failure")
+	})
+
+	t.Run("Item moved", func(t *testing.T) {
+		var req *pb.UpdateItemRequest
+
+		item := TestingNewLoginItem()
+		item.ID = 100
+
+		ts.ItemsClient.EXPECT().UpdateItem(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.UpdateItemRequest,
+				_ ...grpc.CallOption) (*pb.UpdateItemResponse, error) {
+				req = r
+				return &pb.UpdateItemResponse{}, nil
+			})
+		require.NoError(t, ts.Client.MoveItem(testGRPCctx, item, 5))
+		assert.Equal(t, int64(5), req.Item.GetFolderId())
+		assert.Equal(t, int64(5), item.FolderID)
+	})
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/artfuldog/gophkeeper/internal/common"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // List of methods which not required authorization.
@@ -15,6 +17,7 @@ import (
 var unAuthMethods = []string{
 	"UserLogin",
 	"UserRegister",
+	"RefreshToken",
 }
 
 // Metadata keys.
@@ -23,10 +26,26 @@ const (
 	authUsernameKey = "username"
 )
 
+// Message of server's error, returned for expired token.
+const expiredTokenMessage = "expired token"
+
+// TokenRefresher re-issues expired token.
+//
+// Token of failed request is passed to refresher, so token already refreshed by concurrent
+// request isn't refreshed again.
+type TokenRefresher func(ctx context.Context, expiredToken string) error
+
+// TokenGetter returns current auth token. Must be safe for concurrent use.
+type TokenGetter func() string
+
 // AuthInterceptor insert into egress requests authorization information - username and token.
 //
 // Methods, for which inserting authorizaion information is not required, described in unAuthMethods slice.
-func AuthInterceptor(username string, token *string) grpc.UnaryClientInterceptor {
+//
+// If server rejects request's token as expired, AuthInterceptor refreshes token with provided
+// refresher and retries request with new token once. Nil refresher disables refresh. If refresh
+// fails, original error is returned.
+func AuthInterceptor(username string, token TokenGetter, refresh TokenRefresher) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context,
 		method string,
 		req, reply interface{},
@@ -38,9 +57,82 @@ func AuthInterceptor(username string, token *string) grpc.UnaryClientInterceptor
 			return invoker(ctx, method, req, reply, cc, opts...)
 		}
 
-		authCtx := metadata.AppendToOutgoingContext(ctx, authUsernameKey, username)
-		authCtx = metadata.AppendToOutgoingContext(authCtx, authMetadataKey, *token)
+		usedToken := token()
 
-		return invoker(authCtx, method, req, reply, cc, opts...)
+		err := invoker(authContext(ctx, username, usedToken), method, req, reply, cc, opts...)
+		if refresh == nil || !isExpiredTokenError(err) {
+			return err
+		}
+
+		if errRefresh := refresh(ctx, usedToken); errRefresh != nil {
+			return err
+		}
+
+		return invoker(authContext(ctx, username, token()), method, req, reply, cc, opts...)
 	}
 }
+
+// AuthStreamInterceptor insert into egress streams authorization information - username and token.
+//
+// Methods, for which inserting authorizaion information is not required, described in unAuthMethods slice.
+//
+// Server sends stream's headers as soon as stream is authorized, so AuthStreamInterceptor waits for
+// headers before stream is returned to caller. If server rejects stream's token as expired,
+// AuthStreamInterceptor refreshes token with provided refresher and opens stream with new token once.
+// Nil refresher disables refresh and waiting for headers. If refresh fails, rejected stream is
+// returned and original error is reported by stream.
+func AuthStreamInterceptor(username string, token TokenGetter, refresh TokenRefresher) grpc.StreamClientInterceptor {
+	return func(ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+		if common.Contains(common.Last(strings.Split(method, "/")), unAuthMethods) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		usedToken := token()
+
+		stream, err := streamer(authContext(ctx, username, usedToken), desc, cc, method, opts...)
+		if err != nil || refresh == nil {
+			return stream, err
+		}
+
+		if err := streamHeaderError(stream); !isExpiredTokenError(err) {
+			return stream, nil
+		}
+
+		if errRefresh := refresh(ctx, usedToken); errRefresh != nil {
+			return stream, nil
+		}
+
+		return streamer(authContext(ctx, username, token()), desc, cc, method, opts...)
+	}
+}
+
+// authContext is a helper function, which appends authorization information to outgoing context.
+func authContext(ctx context.Context, username string, token string) context.Context {
+	authCtx := metadata.AppendToOutgoingContext(ctx, authUsernameKey, username)
+
+	return metadata.AppendToOutgoingContext(authCtx, authMetadataKey, token)
+}
+
+// streamHeaderError waits for stream's headers and returns error, if stream is rejected by server.
+func streamHeaderError(stream grpc.ClientStream) error {
+	md, err := stream.Header()
+	if err != nil || md != nil {
+		return err
+	}
+
+	// Rejected stream is finished without headers and any message, so only status is received.
+	return stream.RecvMsg(nil)
+}
+
+// isExpiredTokenError checks if server rejected request's token as expired.
+func isExpiredTokenError(err error) bool {
+	st, ok := status.FromError(err)
+
+	return ok && st.Code() == codes.PermissionDenied && st.Message() == expiredTokenMessage
+}
@@ -1,8 +1,22 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/client/config"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/artfuldog/gophkeeper/internal/server"
+	"github.com/artfuldog/gophkeeper/internal/server/authorizer"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestAuthInterceptor(t *testing.T) {
@@ -19,3 +33,276 @@ func TestAuthInterceptor(t *testing.T) {
 	ts.Client.UserLogin(ctx, "", "", "")
 	ts.Client.GetItem(ctx, "", "")
 }
+
+func TestAuthInterceptor_Refresh(t *testing.T) {
+	errExpired := status.Error(codes.PermissionDenied, expiredTokenMessage)
+	errRevoked := status.Error(codes.PermissionDenied, "revoked token")
+
+	// newInvoker returns invoker, which fails with provided error requests with expired token.
+	newInvoker := func(err error, usedTokens *[]string) grpc.UnaryInvoker {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+			opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			*usedTokens = append(*usedTokens, md.Get(authMetadataKey)...)
+
+			if len(md.Get(authMetadataKey)) > 0 && md.Get(authMetadataKey)[0] == "expired" {
+				return err
+			}
+
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		invokerErr error
+		refresh    TokenRefresher
+		wantErr    error
+		wantTokens []string
+	}{
+		{
+			name:       "Refresh and retry",
+			invokerErr: errExpired,
+			wantTokens: []string{"expired", "new"},
+		},
+		{
+			name:       "Refresh failed",
+			invokerErr: errExpired,
+			refresh: func(ctx context.Context, expiredToken string) error {
+				return assert.AnError
+			},
+			wantErr:    errExpired,
+			wantTokens: []string{"expired"},
+		},
+		{
+			name:       "Not expired token",
+			invokerErr: errRevoked,
+			wantErr:    errRevoked,
+			wantTokens: []string{"expired"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := "expired"
+			refresh := tt.refresh
+			if refresh == nil {
+				refresh = func(ctx context.Context, expiredToken string) error {
+					require.Equal(t, "expired", expiredToken)
+					token = "new"
+
+					return nil
+				}
+			}
+
+			var usedTokens []string
+			interceptor := AuthInterceptor("username", func() string { return token }, refresh)
+			err := interceptor(context.Background(), "/gophkeeper.Items/GetItem", nil, nil, nil,
+				newInvoker(tt.invokerErr, &usedTokens))
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantTokens, usedTokens)
+		})
+	}
+
+	t.Run("Refresh disabled", func(t *testing.T) {
+		token := "expired"
+		var usedTokens []string
+		err := AuthInterceptor("username", func() string { return token }, nil)(context.Background(),
+			"/gophkeeper.Items/GetItem", nil, nil, nil, newInvoker(errExpired, &usedTokens))
+		assert.ErrorIs(t, err, errExpired)
+		assert.Equal(t, []string{"expired"}, usedTokens)
+	})
+
+	t.Run("Unauthorized method", func(t *testing.T) {
+		token := "expired"
+		var usedTokens []string
+		err := AuthInterceptor("username", func() string { return token }, nil)(context.Background(),
+			"/gophkeeper.Users/RefreshToken", nil, nil, nil, newInvoker(errExpired, &usedTokens))
+		require.NoError(t, err)
+		assert.Empty(t, usedTokens)
+	})
+}
+
+// headerStream is a client stream, which fails to receive headers with provided error.
+type headerStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s *headerStream) Header() (metadata.MD, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return metadata.MD{}, nil
+}
+
+func TestAuthStreamInterceptor_Refresh(t *testing.T) {
+	errExpired := status.Error(codes.PermissionDenied, expiredTokenMessage)
+	errRevoked := status.Error(codes.PermissionDenied, "revoked token")
+
+	// newStreamer returns streamer, which opens streams failing with provided error for expired token.
+	newStreamer := func(err error, usedTokens *[]string) grpc.Streamer {
+		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+			opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			*usedTokens = append(*usedTokens, md.Get(authMetadataKey)...)
+
+			if len(md.Get(authMetadataKey)) > 0 && md.Get(authMetadataKey)[0] == "expired" {
+				return &headerStream{err: err}, nil
+			}
+
+			return &headerStream{}, nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		streamErr  error
+		refresh    TokenRefresher
+		wantErr    error
+		wantTokens []string
+	}{
+		{
+			name:       "Refresh and reopen",
+			streamErr:  errExpired,
+			wantTokens: []string{"expired", "new"},
+		},
+		{
+			name:      "Refresh failed",
+			streamErr: errExpired,
+			refresh: func(ctx context.Context, expiredToken string) error {
+				return assert.AnError
+			},
+			wantErr:    errExpired,
+			wantTokens: []string{"expired"},
+		},
+		{
+			name:       "Not expired token",
+			streamErr:  errRevoked,
+			wantErr:    errRevoked,
+			wantTokens: []string{"expired"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := "expired"
+			refresh := tt.refresh
+			if refresh == nil {
+				refresh = func(ctx context.Context, expiredToken string) error {
+					require.Equal(t, "expired", expiredToken)
+					token = "new"
+
+					return nil
+				}
+			}
+
+			var usedTokens []string
+			interceptor := AuthStreamInterceptor("username", func() string { return token }, refresh)
+			stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil,
+				"/gophkeeper.Items/DownloadSecretData", newStreamer(tt.streamErr, &usedTokens))
+			require.NoError(t, err)
+
+			_, err = stream.Header()
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantTokens, usedTokens)
+		})
+	}
+
+	t.Run("Refresh disabled", func(t *testing.T) {
+		token := "expired"
+		var usedTokens []string
+		_, err := AuthStreamInterceptor("username", func() string { return token }, nil)(context.Background(),
+			&grpc.StreamDesc{}, nil, "/gophkeeper.Items/DownloadSecretData", newStreamer(errExpired, &usedTokens))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"expired"}, usedTokens)
+	})
+}
+
+// newTestServerClient starts server with memory database on provided address and returns client
+// connected to it. Server's tokens expire in a second.
+func newTestServerClient(t *testing.T, address string) *GRPCClient {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Config{
+		Address:                 address,
+		DBType:                  db.TypeMemory,
+		LogLevel:                "fatal",
+		MaxSecretSize:           10 * 1024 * 1024,
+		Authorizer:              authorizer.TypePaseto,
+		ServerKey:               "123456789f123456789q123456789pQ1",
+		TokenValidPeriod:        1,
+		RefreshTokenValidPeriod: 60,
+		RateLimit:               "100:200",
+		TLSDisable:              true,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srvStatusCh := make(chan error, 1)
+	go srv.Run(ctx, srvStatusCh)
+
+	cfg, _ := config.NewConfiger(nil)
+	cfg.SetServer(address)
+	cfg.SetUser("streamUser")
+	cfg.SetSecretKey(testGRPCSecretKey)
+	cfg.SetMode(config.ModeServer)
+	cfg.SetTLSDisable(true)
+
+	client := NewGRPCClient(cfg, mocklogger.NewMockLogger())
+	clientStopCh := make(chan struct{})
+	require.NoError(t, client.Connect(ctx, clientStopCh))
+
+	t.Cleanup(func() {
+		cancel()
+		<-srvStatusCh
+		<-clientStopCh
+	})
+
+	time.Sleep(time.Second) // give server some time to start
+
+	user := &NewUser{
+		Username:        "streamUser",
+		Password:        "streamUserPassword",
+		PasswordConfirm: "streamUserPassword",
+		SecretKey:       testGRPCSecretKey,
+	}
+	_, err = client.UserRegister(ctx, user)
+	require.NoError(t, err)
+	require.NoError(t, client.UserLogin(ctx, user.Username, user.Password, ""))
+
+	return client
+}
+
+func TestAuthStreamInterceptor_Server(t *testing.T) {
+	client := newTestServerClient(t, "127.0.0.1:3204")
+	ctx := context.Background()
+
+	require.NoError(t, client.SaveItem(ctx, TestingNewSecDataItem()))
+	item, err := client.GetItem(ctx, TestingNewSecDataItem().Name, TestingNewSecDataItem().Type)
+	require.NoError(t, err)
+
+	data := bytes.Repeat([]byte("0123456789"), SecretDataChunkSize/4)
+
+	t.Run("Upload and download", func(t *testing.T) {
+		require.NoError(t, client.UploadSecretData(ctx, item, bytes.NewReader(data), int64(len(data)), nil))
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, client.DownloadSecretData(ctx, item, buf, nil))
+		assert.Equal(t, data, buf.Bytes())
+	})
+
+	t.Run("Upload and download with expired token", func(t *testing.T) {
+		expiredToken := client.getToken()
+		time.Sleep(2 * time.Second)
+
+		require.NoError(t, client.UploadSecretData(ctx, item, bytes.NewReader(data), int64(len(data)), nil))
+		assert.NotEqual(t, expiredToken, client.getToken(), "token must be refreshed")
+
+		time.Sleep(2 * time.Second)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, client.DownloadSecretData(ctx, item, buf, nil))
+		assert.Equal(t, data, buf.Bytes())
+	})
+}
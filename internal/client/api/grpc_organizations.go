@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// Organization represents organization, which user is member of or invited to.
+type Organization struct {
+	ID       int64
+	Name     string
+	Role     string
+	Accepted bool
+}
+
+// Manageable returns true if user is able to manage organization and its members.
+func (o *Organization) Manageable() bool {
+	return o.Accepted && o.Role == common.OrgRoleAdmin
+}
+
+// Editable returns true if user is able to change organization's items.
+func (o *Organization) Editable() bool {
+	return o.Accepted && (o.Role == common.OrgRoleAdmin || o.Role == common.OrgRoleMember)
+}
+
+// Member represents organization's member or invited user.
+type Member struct {
+	Username string
+	Role     string
+	Accepted bool
+}
+
+// GetOrganizations returns user's organizations including not accepted invites.
+//
+// Organizations' keys are decrypted with user's private key and kept by client for
+// encrypting and decrypting organizations' items.
+func (c *GRPCClient) GetOrganizations(ctx context.Context) ([]*Organization, error) {
+	if c.privateKey == nil {
+		return nil, ErrKeyPairMissed
+	}
+
+	resp, err := c.orgsClient.GetOrganizations(ctx, &pb.GetOrganizationsRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	orgs := make([]*Organization, 0, len(resp.Organizations))
+	keys := make(map[int64][]byte, len(resp.Organizations))
+
+	for _, org := range resp.Organizations {
+		key, err := crypt.DecryptWithKeyPair(c.publicKey, c.privateKey, org.Okey)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[org.Id] = key
+
+		orgs = append(orgs, &Organization{
+			ID:       org.Id,
+			Name:     org.Name,
+			Role:     org.Role,
+			Accepted: org.Accepted,
+		})
+	}
+
+	c.orgKeysMu.Lock()
+	c.orgKeys = keys
+	c.orgKeysMu.Unlock()
+
+	return orgs, nil
+}
+
+// CreateOrganization creates new organization, user becomes organization's administrator.
+//
+// Organization's key is generated randomly and encrypted with user's public key.
+func (c *GRPCClient) CreateOrganization(ctx context.Context, name string) error {
+	if c.publicKey == nil {
+		return ErrKeyPairMissed
+	}
+
+	okey, err := crypt.EncryptForPublicKey(c.publicKey, crypt.GenerateRandomKey32())
+	if err != nil {
+		return err
+	}
+
+	request := &pb.CreateOrganizationRequest{
+		Username: c.config.GetUser(),
+		Name:     name,
+		Okey:     okey,
+	}
+
+	if _, err := c.orgsClient.CreateOrganization(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// RenameOrganization renames organization.
+func (c *GRPCClient) RenameOrganization(ctx context.Context, org *Organization, name string) error {
+	request := &pb.UpdateOrganizationRequest{
+		Username: c.config.GetUser(),
+		Id:       org.ID,
+		Name:     name,
+	}
+
+	if _, err := c.orgsClient.UpdateOrganization(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// DeleteOrganization deletes organization with all its items.
+func (c *GRPCClient) DeleteOrganization(ctx context.Context, org *Organization) error {
+	request := &pb.DeleteOrganizationRequest{
+		Username: c.config.GetUser(),
+		Id:       org.ID,
+	}
+
+	if _, err := c.orgsClient.DeleteOrganization(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// GetMembers returns organization's members and invited users.
+func (c *GRPCClient) GetMembers(ctx context.Context, org *Organization) ([]*Member, error) {
+	request := &pb.GetMembersRequest{
+		Username: c.config.GetUser(),
+		OrgId:    org.ID,
+	}
+
+	resp, err := c.orgsClient.GetMembers(ctx, request)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	members := make([]*Member, 0, len(resp.Members))
+	for _, m := range resp.Members {
+		members = append(members, &Member{Username: m.Username, Role: m.Role, Accepted: m.Accepted})
+	}
+
+	return members, nil
+}
+
+// InviteMember invites user to organization with provided role.
+//
+// Organization's key is encrypted with invited user's public key, so invited user
+// gets access to organization's items after accepting invite.
+func (c *GRPCClient) InviteMember(ctx context.Context, org *Organization, username, role string) error {
+	key, err := c.orgKey(org.ID)
+	if err != nil {
+		return err
+	}
+
+	keyResp, err := c.usersClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Username: username})
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	okey, err := crypt.EncryptForPublicKey(keyResp.PublicKey, key)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.InviteMemberRequest{
+		Username: c.config.GetUser(),
+		OrgId:    org.ID,
+		Member: &pb.Member{
+			Username: username,
+			Role:     role,
+			Okey:     okey,
+		},
+	}
+
+	if _, err := c.orgsClient.InviteMember(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// AcceptInvite accepts user's invite to organization.
+func (c *GRPCClient) AcceptInvite(ctx context.Context, org *Organization) error {
+	request := &pb.AcceptInviteRequest{
+		Username: c.config.GetUser(),
+		OrgId:    org.ID,
+	}
+
+	if _, err := c.orgsClient.AcceptInvite(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// UpdateMember changes member's role.
+func (c *GRPCClient) UpdateMember(ctx context.Context, org *Organization, member *Member, role string) error {
+	request := &pb.UpdateMemberRequest{
+		Username: c.config.GetUser(),
+		OrgId:    org.ID,
+		Member:   member.Username,
+		Role:     role,
+	}
+
+	if _, err := c.orgsClient.UpdateMember(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes member or invite from organization.
+//
+// Removing user himself leaves organization or declines invite.
+func (c *GRPCClient) RemoveMember(ctx context.Context, org *Organization, username string) error {
+	request := &pb.RemoveMemberRequest{
+		Username: c.config.GetUser(),
+		OrgId:    org.ID,
+		Member:   username,
+	}
+
+	if _, err := c.orgsClient.RemoveMember(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// GetOrgItemsList returns list with short representation of organization's items.
+//
+// Organizations' items are always requested from server, local storage keeps only user's items.
+func (c *GRPCClient) GetOrgItemsList(ctx context.Context, org *Organization) ([]*pb.ItemShort, error) {
+	key, err := c.orgKey(org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &pb.GetItemListRequest{
+		Username: c.config.GetUser(),
+		OrgId:    org.ID,
+	}
+
+	resp, err := c.itemsClient.GetItemList(ctx, request)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	if err := decryptPbItemsTags(key, resp.Items); err != nil {
+		return nil, err
+	}
+
+	return resp.Items, nil
+}
+
+// GetOrgItem returns all organization's item content from server.
+func (c *GRPCClient) GetOrgItem(ctx context.Context, org *Organization, itemName, itemType string) (*Item, error) {
+	key, err := c.orgKey(org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &pb.GetItemRequest{
+		Username: c.config.GetUser(),
+		ItemName: itemName,
+		ItemType: itemType,
+		OrgId:    org.ID,
+	}
+
+	resp, err := c.itemsClient.GetItem(ctx, request)
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	if err := decryptPbItem(key, resp.Item); err != nil {
+		return nil, err
+	}
+
+	item := NewItemFromPB(resp.Item)
+	item.OrgID = org.ID
+
+	return item, nil
+}
+
+// orgKey returns organization's key, received with organizations' list.
+func (c *GRPCClient) orgKey(orgID int64) ([]byte, error) {
+	c.orgKeysMu.RLock()
+	defer c.orgKeysMu.RUnlock()
+
+	key, ok := c.orgKeys[orgID]
+	if !ok {
+		return nil, ErrOrgKeyMissed
+	}
+
+	return key, nil
+}
+
+// itemKey returns key, which item is encrypted with: user's encryption key for user's own
+// items or organization's key for organization's items.
+func (c *GRPCClient) itemKey(item *Item) ([]byte, error) {
+	if item.OrgID == 0 {
+		return c.encKey, nil
+	}
+
+	return c.orgKey(item.OrgID)
+}
+
+// forceSyncOwnVault forces synchronization with server after changes in user's own vault.
+//
+// Organizations' items are not kept in local storage, so their changes don't require synchronization.
+func (c *GRPCClient) forceSyncOwnVault(orgID int64) {
+	if orgID == 0 {
+		c.ForceSyncWithWait()
+	}
+}
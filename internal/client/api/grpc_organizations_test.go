@@ -0,0 +1,290 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestOrganization_Permissions(t *testing.T) {
+	admin := &Organization{Role: common.OrgRoleAdmin, Accepted: true}
+	assert.True(t, admin.Manageable())
+	assert.True(t, admin.Editable())
+
+	member := &Organization{Role: common.OrgRoleMember, Accepted: true}
+	assert.False(t, member.Manageable())
+	assert.True(t, member.Editable())
+
+	viewer := &Organization{Role: common.OrgRoleViewer, Accepted: true}
+	assert.False(t, viewer.Editable())
+
+	invited := &Organization{Role: common.OrgRoleAdmin}
+	assert.False(t, invited.Manageable())
+	assert.False(t, invited.Editable())
+}
+
+func TestGRPCClient_GetOrganizations(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Key pair missed", func(t *testing.T) {
+		_, err := ts.Client.GetOrganizations(testGRPCctx)
+		assert.ErrorIs(t, err, ErrKeyPairMissed)
+	})
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	ts.Client.publicKey, ts.Client.privateKey = publicKey, privateKey
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.OrgsClient.EXPECT().GetOrganizations(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetOrganizations(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Decryption error", func(t *testing.T) {
+		resp := &pb.GetOrganizationsResponse{Organizations: []*pb.Organization{{Id: 1, Okey: []byte("wrong")}}}
+		ts.OrgsClient.EXPECT().GetOrganizations(testGRPCctx, mockAnyVal).Return(resp, nil)
+		_, err := ts.Client.GetOrganizations(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		key := crypt.GenerateRandomKey32()
+		okey, err := crypt.EncryptForPublicKey(publicKey, key)
+		require.NoError(t, err)
+
+		resp := &pb.GetOrganizationsResponse{Organizations: []*pb.Organization{
+			{Id: 1, Name: "team", Role: common.OrgRoleMember, Accepted: true, Okey: okey},
+		}}
+		ts.OrgsClient.EXPECT().GetOrganizations(testGRPCctx, mockAnyVal).Return(resp, nil)
+		orgs, err := ts.Client.GetOrganizations(testGRPCctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*Organization{{ID: 1, Name: "team", Role: common.OrgRoleMember, Accepted: true}}, orgs)
+
+		gotKey, err := ts.Client.orgKey(1)
+		require.NoError(t, err)
+		assert.Equal(t, key, gotKey)
+
+		_, err = ts.Client.orgKey(2)
+		assert.ErrorIs(t, err, ErrOrgKeyMissed)
+	})
+}
+
+func TestGRPCClient_CreateOrganization(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Key pair missed", func(t *testing.T) {
+		assert.ErrorIs(t, ts.Client.CreateOrganization(testGRPCctx, "team"), ErrKeyPairMissed)
+	})
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	ts.Client.publicKey, ts.Client.privateKey = publicKey, privateKey
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.OrgsClient.EXPECT().CreateOrganization(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.CreateOrganization(testGRPCctx, "team"))
+	})
+
+	t.Run("Organization created", func(t *testing.T) {
+		var req *pb.CreateOrganizationRequest
+
+		ts.OrgsClient.EXPECT().CreateOrganization(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.CreateOrganizationRequest,
+				_ ...grpc.CallOption) (*pb.CreateOrganizationResponse, error) {
+				req = r
+				return &pb.CreateOrganizationResponse{Id: 1}, nil
+			})
+		require.NoError(t, ts.Client.CreateOrganization(testGRPCctx, "team"))
+
+		assert.Equal(t, "team", req.Name)
+		key, err := crypt.DecryptWithKeyPair(publicKey, privateKey, req.Okey)
+		require.NoError(t, err)
+		assert.Len(t, key, 32)
+	})
+}
+
+func TestGRPCClient_InviteMember(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	org := &Organization{ID: 1}
+	key := crypt.GenerateRandomKey32()
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	t.Run("Organization's key missed", func(t *testing.T) {
+		err := ts.Client.InviteMember(testGRPCctx, org, "invited", common.OrgRoleViewer)
+		assert.ErrorIs(t, err, ErrOrgKeyMissed)
+	})
+
+	ts.Client.orgKeys = map[int64][]byte{1: key}
+
+	t.Run("Get public key error", func(t *testing.T) {
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.InviteMember(testGRPCctx, org, "invited", common.OrgRoleViewer))
+	})
+
+	t.Run("Member invited", func(t *testing.T) {
+		var req *pb.InviteMemberRequest
+
+		resp := &pb.GetPublicKeyResponse{PublicKey: publicKey}
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, &pb.GetPublicKeyRequest{Username: "invited"}).
+			Return(resp, nil)
+		ts.OrgsClient.EXPECT().InviteMember(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.InviteMemberRequest,
+				_ ...grpc.CallOption) (*pb.InviteMemberResponse, error) {
+				req = r
+				return &pb.InviteMemberResponse{}, nil
+			})
+		require.NoError(t, ts.Client.InviteMember(testGRPCctx, org, "invited", common.OrgRoleViewer))
+
+		assert.Equal(t, int64(1), req.OrgId)
+		assert.Equal(t, "invited", req.Member.Username)
+		assert.Equal(t, common.OrgRoleViewer, req.Member.Role)
+
+		gotKey, err := crypt.DecryptWithKeyPair(publicKey, privateKey, req.Member.Okey)
+		require.NoError(t, err)
+		assert.Equal(t, key, gotKey)
+	})
+}
+
+func TestGRPCClient_ManageOrganization(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	org := &Organization{ID: 1}
+	member := &Member{Username: "member"}
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.OrgsClient.EXPECT().UpdateOrganization(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RenameOrganization(testGRPCctx, org, "renamed"))
+
+		ts.OrgsClient.EXPECT().DeleteOrganization(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.DeleteOrganization(testGRPCctx, org))
+
+		ts.OrgsClient.EXPECT().GetMembers(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetMembers(testGRPCctx, org)
+		assert.Error(t, err)
+
+		ts.OrgsClient.EXPECT().AcceptInvite(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.AcceptInvite(testGRPCctx, org))
+
+		ts.OrgsClient.EXPECT().UpdateMember(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.UpdateMember(testGRPCctx, org, member, common.OrgRoleAdmin))
+
+		ts.OrgsClient.EXPECT().RemoveMember(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RemoveMember(testGRPCctx, org, member.Username))
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		ts.OrgsClient.EXPECT().UpdateOrganization(testGRPCctx, &pb.UpdateOrganizationRequest{Id: 1, Name: "renamed"}).
+			Return(&pb.UpdateOrganizationResponse{}, nil)
+		require.NoError(t, ts.Client.RenameOrganization(testGRPCctx, org, "renamed"))
+
+		ts.OrgsClient.EXPECT().DeleteOrganization(testGRPCctx, &pb.DeleteOrganizationRequest{Id: 1}).
+			Return(&pb.DeleteOrganizationResponse{}, nil)
+		require.NoError(t, ts.Client.DeleteOrganization(testGRPCctx, org))
+
+		membersResp := &pb.GetMembersResponse{Members: []*pb.Member{
+			{Username: "member", Role: common.OrgRoleMember, Accepted: true},
+		}}
+		ts.OrgsClient.EXPECT().GetMembers(testGRPCctx, &pb.GetMembersRequest{OrgId: 1}).Return(membersResp, nil)
+		members, err := ts.Client.GetMembers(testGRPCctx, org)
+		require.NoError(t, err)
+		assert.Equal(t, []*Member{{Username: "member", Role: common.OrgRoleMember, Accepted: true}}, members)
+
+		ts.OrgsClient.EXPECT().AcceptInvite(testGRPCctx, &pb.AcceptInviteRequest{OrgId: 1}).
+			Return(&pb.AcceptInviteResponse{}, nil)
+		require.NoError(t, ts.Client.AcceptInvite(testGRPCctx, org))
+
+		updateReq := &pb.UpdateMemberRequest{OrgId: 1, Member: "member", Role: common.OrgRoleAdmin}
+		ts.OrgsClient.EXPECT().UpdateMember(testGRPCctx, updateReq).Return(&pb.UpdateMemberResponse{}, nil)
+		require.NoError(t, ts.Client.UpdateMember(testGRPCctx, org, member, common.OrgRoleAdmin))
+
+		ts.OrgsClient.EXPECT().RemoveMember(testGRPCctx, &pb.RemoveMemberRequest{OrgId: 1, Member: "member"}).
+			Return(&pb.RemoveMemberResponse{}, nil)
+		require.NoError(t, ts.Client.RemoveMember(testGRPCctx, org, member.Username))
+	})
+}
+
+func TestGRPCClient_OrgItems(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+	ts.Client.MaxSecretSize = 1024 * 1024
+
+	org := &Organization{ID: 1}
+	key := crypt.GenerateRandomKey32()
+
+	t.Run("Organization's key missed", func(t *testing.T) {
+		_, err := ts.Client.GetOrgItemsList(testGRPCctx, org)
+		assert.ErrorIs(t, err, ErrOrgKeyMissed)
+
+		_, err = ts.Client.GetOrgItem(testGRPCctx, org, "name", common.ItemTypeLogin)
+		assert.ErrorIs(t, err, ErrOrgKeyMissed)
+
+		item := TestingNewLoginItem()
+		item.OrgID = 1
+		assert.ErrorIs(t, ts.Client.SaveItem(testGRPCctx, item), ErrOrgKeyMissed)
+	})
+
+	ts.Client.orgKeys = map[int64][]byte{1: key}
+
+	t.Run("Save organization's item", func(t *testing.T) {
+		var req *pb.CreateItemRequest
+
+		item := TestingNewLoginItem()
+		item.OrgID = 1
+
+		ts.ItemsClient.EXPECT().CreateItem(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.CreateItemRequest,
+				_ ...grpc.CallOption) (*pb.CreateItemResponse, error) {
+				req = r
+				return &pb.CreateItemResponse{}, nil
+			})
+		require.NoError(t, ts.Client.SaveItem(testGRPCctx, item))
+		assert.Equal(t, int64(1), req.OrgId)
+		require.NoError(t, decryptPbItem(key, req.Item))
+		assert.Equal(t, item.GetLogin(), NewItemFromPB(req.Item).GetLogin())
+	})
+
+	t.Run("Get organization's items", func(t *testing.T) {
+		item := TestingNewLoginItem()
+		pbItem := item.ToPB()
+		require.NoError(t, encryptPbItem(key, pbItem))
+
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, &pb.GetItemListRequest{OrgId: 1}).
+			Return(&pb.GetItemListResponse{Items: []*pb.ItemShort{{Name: item.Name}}}, nil)
+		list, err := ts.Client.GetOrgItemsList(testGRPCctx, org)
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+
+		getReq := &pb.GetItemRequest{ItemName: item.Name, ItemType: item.Type, OrgId: 1}
+		ts.ItemsClient.EXPECT().GetItem(testGRPCctx, getReq).Return(&pb.GetItemResponse{Item: pbItem}, nil)
+		got, err := ts.Client.GetOrgItem(testGRPCctx, org, item.Name, item.Type)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), got.OrgID)
+		assert.Equal(t, item.GetLogin(), got.GetLogin())
+	})
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetOrgItemsList(testGRPCctx, org)
+		assert.Error(t, err)
+
+		ts.ItemsClient.EXPECT().GetItem(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err = ts.Client.GetOrgItem(testGRPCctx, org, "name", common.ItemTypeLogin)
+		assert.Error(t, err)
+	})
+}
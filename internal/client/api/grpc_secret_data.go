@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// SecretDataChunkSize is a size of data's chunk, which is encrypted and sent to server separately.
+const SecretDataChunkSize = 1024 * 1024
+
+// ProgressFunc is used for notify about progress of data transfer, done and total are in bytes.
+type ProgressFunc func(done, total int64)
+
+// UploadSecretData uploads data from r to server by stream, every chunk is encrypted separately
+// together with its index and flag of last chunk.
+//
+// Item must be already saved data item. Data's encryption key is generated on first upload
+// and stored in item's secret together with size of data, so item is updated after upload.
+// Data previously stored in item's secret is deleted. In case of failure item is not changed.
+func (c *GRPCClient) UploadSecretData(ctx context.Context, item *Item, r io.Reader, size int64,
+	progress ProgressFunc) error {
+	secret, err := item.GetSecDataSafe()
+	if err != nil {
+		return err
+	}
+
+	if item.ID == 0 {
+		return ErrItemNotSaved
+	}
+
+	if size > int64(c.MaxSecretSize) {
+		return c.errSecretTooBig(size)
+	}
+
+	key := secret.Key
+	if len(key) == 0 {
+		key = crypt.GenerateRandomKey32()
+	}
+
+	// Canceling context aborts stream, so server discards already received chunks.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.itemsClient.UploadSecretData(ctx)
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	first := &pb.UploadSecretDataRequest{Username: c.config.GetUser(), ItemId: item.ID, OrgId: item.OrgID}
+	if err := stream.Send(first); err != nil && !errors.Is(err, io.EOF) {
+		return c.wrapError(err)
+	}
+
+	done, err := c.sendSecretChunks(stream, r, key, size, progress)
+	if err != nil {
+		return err
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return c.wrapError(err)
+	}
+
+	c.forceSyncOwnVault(item.OrgID)
+
+	secret.Data = nil
+	secret.Key = key
+	secret.Size = done
+
+	return c.SaveItem(ctx, item)
+}
+
+// DownloadSecretData writes data of data item to w.
+//
+// Data uploaded by stream is downloaded from server and decrypted chunk by chunk, data stored
+// in item's secret is written as is. If chunks are missed, reordered, damaged or exceed size
+// of data returns ErrSecretDataCorrupted, already written data must be discarded.
+func (c *GRPCClient) DownloadSecretData(ctx context.Context, item *Item, w io.Writer,
+	progress ProgressFunc) error {
+	secret, err := item.GetSecDataSafe()
+	if err != nil {
+		return err
+	}
+
+	if !secret.Chunked() {
+		if _, err := w.Write(secret.Data); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(int64(len(secret.Data)), int64(len(secret.Data)))
+		}
+
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	request := &pb.DownloadSecretDataRequest{
+		Username: c.config.GetUser(),
+		ItemId:   item.ID,
+		OrgId:    item.OrgID,
+	}
+
+	stream, err := c.itemsClient.DownloadSecretData(ctx, request)
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	return c.receiveSecretChunks(stream, w, secret, progress)
+}
+
+// sendSecretChunks is a helper function which reads data from r and sends it to stream chunk by chunk.
+//
+// Every chunk is encrypted together with its index and flag of last chunk. Returns size of sent data.
+func (c *GRPCClient) sendSecretChunks(stream pb.Items_UploadSecretDataClient, r io.Reader, key []byte,
+	size int64, progress ProgressFunc) (int64, error) {
+	buf, next := make([]byte, SecretDataChunkSize), make([]byte, SecretDataChunkSize)
+
+	var index, done int64
+
+	n, readErr := io.ReadFull(r, buf)
+
+	for n > 0 {
+		// Next chunk is read before current one is sent, because last chunk is sealed as final.
+		var nextN int
+		if readErr == nil {
+			nextN, readErr = io.ReadFull(r, next)
+		}
+
+		if readErr != nil && !isReadEOF(readErr) {
+			return 0, readErr
+		}
+
+		chunk, err := crypt.EncryptAESWithData(key, buf[:n], secretChunkData(index, nextN == 0))
+		if err != nil {
+			return 0, err
+		}
+
+		// io.EOF means that server closed stream, error is returned by CloseAndRecv
+		if err := stream.Send(&pb.UploadSecretDataRequest{Chunk: chunk}); err != nil {
+			if errors.Is(err, io.EOF) {
+				return done, nil
+			}
+
+			return 0, c.wrapError(err)
+		}
+
+		index++
+		done += int64(n)
+
+		if progress != nil {
+			progress(done, size)
+		}
+
+		buf, next = next, buf
+		n = nextN
+	}
+
+	if readErr != nil && !isReadEOF(readErr) {
+		return 0, readErr
+	}
+
+	return done, nil
+}
+
+// receiveSecretChunks is a helper function which receives data's chunks from stream, decrypts
+// and writes them to w.
+//
+// Chunks must be received in order of upload, every chunk is decrypted together with its index
+// and flag of last chunk, so missed, reordered or excess chunks are detected.
+func (c *GRPCClient) receiveSecretChunks(stream pb.Items_DownloadSecretDataClient, w io.Writer,
+	secret *SecretData, progress ProgressFunc) error {
+	var index, done int64
+
+	resp, err := stream.Recv()
+
+	for !errors.Is(err, io.EOF) {
+		if err != nil {
+			return c.wrapError(err)
+		}
+
+		// Next chunk is received before current one is decrypted, because last chunk is sealed as final.
+		next, nextErr := stream.Recv()
+		if nextErr != nil && !errors.Is(nextErr, io.EOF) {
+			return c.wrapError(nextErr)
+		}
+
+		final := nextErr != nil
+
+		chunk, decryptErr := crypt.DecryptAESWithData(secret.Key, resp.Chunk, secretChunkData(index, final))
+		if decryptErr != nil {
+			return fmt.Errorf("%w: chunk %d is missed, reordered or damaged", ErrSecretDataCorrupted, index)
+		}
+
+		done += int64(len(chunk))
+		if done > secret.Size {
+			return fmt.Errorf("%w: received more than %d bytes", ErrSecretDataCorrupted, secret.Size)
+		}
+
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(done, secret.Size)
+		}
+
+		index++
+		resp, err = next, nextErr
+	}
+
+	if done != secret.Size {
+		return fmt.Errorf("%w: received %d of %d bytes", ErrSecretDataCorrupted, done, secret.Size)
+	}
+
+	return nil
+}
+
+// secretChunkData is a helper function which returns data authenticated together with data's chunk.
+//
+// Data contains chunk's index and flag of last chunk, so server can't reorder, duplicate, drop
+// or truncate chunks without detection.
+func secretChunkData(index int64, final bool) []byte {
+	data := make([]byte, 9)
+	binary.BigEndian.PutUint64(data, uint64(index))
+
+	if final {
+		data[8] = 1
+	}
+
+	return data
+}
+
+// isReadEOF is a helper function which checks whether error means end of read data.
+func isReadEOF(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
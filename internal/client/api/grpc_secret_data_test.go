@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mockgrpc"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCClient_UploadDownloadSecretData(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	ts.Client.encKey = testGRPCencKey
+	ts.Client.MaxSecretSize = 50 * 1024 * 1024
+
+	data := bytes.Repeat([]byte("0123456789"), SecretDataChunkSize/4)
+	size := int64(len(data))
+
+	t.Run("Wrong item type", func(t *testing.T) {
+		err := ts.Client.UploadSecretData(testGRPCctx, TestingNewLoginItem(), bytes.NewReader(data), size, nil)
+		assert.ErrorIs(t, err, ErrWrongItemType)
+	})
+
+	t.Run("Item isn't saved", func(t *testing.T) {
+		err := ts.Client.UploadSecretData(testGRPCctx, TestingNewSecDataItem(), bytes.NewReader(data), size, nil)
+		assert.ErrorIs(t, err, ErrItemNotSaved)
+	})
+
+	item := TestingNewSecDataItem()
+	item.ID = 100
+
+	t.Run("Data too big", func(t *testing.T) {
+		ts.Client.MaxSecretSize = 100
+		err := ts.Client.UploadSecretData(testGRPCctx, item, bytes.NewReader(data), size, nil)
+		assert.ErrorIs(t, err, ErrSecretTooBig)
+		ts.Client.MaxSecretSize = 50 * 1024 * 1024
+	})
+
+	t.Run("Server rejects upload", func(t *testing.T) {
+		stream := mockgrpc.NewMockItems_UploadSecretDataClient(ts.MockCtrl)
+		stream.EXPECT().Send(mockAnyVal).Return(io.EOF).AnyTimes()
+		stream.EXPECT().CloseAndRecv().Return(nil, assert.AnError)
+		ts.ItemsClient.EXPECT().UploadSecretData(mockAnyVal).Return(stream, nil)
+
+		err := ts.Client.UploadSecretData(testGRPCctx, item, bytes.NewReader(data), size, nil)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, item.GetSecData().Chunked(), "failed upload mustn't change item")
+	})
+
+	var chunks [][]byte
+
+	t.Run("Successful upload", func(t *testing.T) {
+		stream := mockgrpc.NewMockItems_UploadSecretDataClient(ts.MockCtrl)
+		stream.EXPECT().Send(mockAnyVal).DoAndReturn(func(req *pb.UploadSecretDataRequest) error {
+			if len(req.Chunk) > 0 {
+				chunks = append(chunks, req.Chunk)
+			}
+
+			return nil
+		}).Times(4)
+		stream.EXPECT().CloseAndRecv().Return(&pb.UploadSecretDataResponse{Size: size}, nil)
+		ts.ItemsClient.EXPECT().UploadSecretData(mockAnyVal).Return(stream, nil)
+		ts.ItemsClient.EXPECT().UpdateItem(mockAnyVal, mockAnyVal).Return(&pb.UpdateItemResponse{}, nil)
+
+		var progress []int64
+
+		err := ts.Client.UploadSecretData(testGRPCctx, item, bytes.NewReader(data), size,
+			func(done, total int64) {
+				assert.Equal(t, size, total)
+				progress = append(progress, done)
+			})
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{SecretDataChunkSize, 2 * SecretDataChunkSize, size}, progress)
+		require.Len(t, chunks, 3)
+		assert.NotContains(t, string(chunks[0]), "0123456789", "chunks must be encrypted")
+
+		secret := item.GetSecData()
+		assert.True(t, secret.Chunked())
+		assert.Equal(t, size, secret.GetSize())
+		assert.Empty(t, secret.Data)
+	})
+
+	t.Run("Successful download", func(t *testing.T) {
+		stream := mockgrpc.NewMockItems_DownloadSecretDataClient(ts.MockCtrl)
+		for _, c := range chunks {
+			stream.EXPECT().Recv().Return(&pb.DownloadSecretDataResponse{Chunk: c}, nil)
+		}
+		stream.EXPECT().Recv().Return(nil, io.EOF)
+		ts.ItemsClient.EXPECT().DownloadSecretData(mockAnyVal, mockAnyVal).Return(stream, nil)
+
+		var (
+			buf  bytes.Buffer
+			done int64
+		)
+
+		err := ts.Client.DownloadSecretData(testGRPCctx, item, &buf, func(d, total int64) { done = d })
+		require.NoError(t, err)
+		assert.Equal(t, data, buf.Bytes())
+		assert.Equal(t, size, done)
+	})
+
+	t.Run("Download of corrupted data", func(t *testing.T) {
+		other, err := crypt.EncryptAESWithData(item.GetSecData().Key, []byte("excess"), secretChunkData(3, true))
+		require.NoError(t, err)
+
+		tests := []struct {
+			name   string
+			chunks [][]byte
+		}{
+			{name: "Reordered chunks", chunks: [][]byte{chunks[1], chunks[0], chunks[2]}},
+			{name: "Missed chunk", chunks: [][]byte{chunks[0], chunks[2]}},
+			{name: "Duplicated chunk", chunks: [][]byte{chunks[0], chunks[0], chunks[1], chunks[2]}},
+			{name: "Truncated data", chunks: chunks[:2]},
+			{name: "No data", chunks: nil},
+			{name: "Excess chunk", chunks: append(append([][]byte(nil), chunks...), other)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				stream := mockgrpc.NewMockItems_DownloadSecretDataClient(ts.MockCtrl)
+				for _, c := range tt.chunks {
+					stream.EXPECT().Recv().Return(&pb.DownloadSecretDataResponse{Chunk: c}, nil).MaxTimes(1)
+				}
+				stream.EXPECT().Recv().Return(nil, io.EOF).MaxTimes(1)
+				ts.ItemsClient.EXPECT().DownloadSecretData(mockAnyVal, mockAnyVal).Return(stream, nil)
+
+				err := ts.Client.DownloadSecretData(testGRPCctx, item, io.Discard, nil)
+				assert.ErrorIs(t, err, ErrSecretDataCorrupted)
+			})
+		}
+	})
+
+	t.Run("Download of data exceeding size", func(t *testing.T) {
+		smaller := TestingNewSecDataItem()
+		smaller.ID = item.ID
+		smaller.Secret = &SecretData{Key: item.GetSecData().Key, Size: size - 1}
+
+		stream := mockgrpc.NewMockItems_DownloadSecretDataClient(ts.MockCtrl)
+		for _, c := range chunks {
+			stream.EXPECT().Recv().Return(&pb.DownloadSecretDataResponse{Chunk: c}, nil)
+		}
+		stream.EXPECT().Recv().Return(nil, io.EOF)
+		ts.ItemsClient.EXPECT().DownloadSecretData(mockAnyVal, mockAnyVal).Return(stream, nil)
+
+		err := ts.Client.DownloadSecretData(testGRPCctx, smaller, io.Discard, nil)
+		assert.ErrorIs(t, err, ErrSecretDataCorrupted)
+	})
+
+	t.Run("Download error", func(t *testing.T) {
+		stream := mockgrpc.NewMockItems_DownloadSecretDataClient(ts.MockCtrl)
+		stream.EXPECT().Recv().Return(nil, assert.AnError)
+		ts.ItemsClient.EXPECT().DownloadSecretData(mockAnyVal, mockAnyVal).Return(stream, nil)
+
+		err := ts.Client.DownloadSecretData(testGRPCctx, item, io.Discard, nil)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Download data stored in secret", func(t *testing.T) {
+		item := TestingNewSecDataItem()
+
+		var buf bytes.Buffer
+		require.NoError(t, ts.Client.DownloadSecretData(testGRPCctx, item, &buf, nil))
+		assert.Equal(t, item.GetSecData().Data, buf.Bytes())
+	})
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// Share represents share of item with another user.
+type Share struct {
+	ID        int64
+	ItemID    int64
+	Owner     string
+	Recipient string
+	// Time of sharing, shared item is owner's item at this moment.
+	Updated time.Time
+}
+
+// SharedItem represents item shared with user by another user.
+//
+// Shared item is a read-only snapshot of owner's item made at the moment of sharing.
+// Later changes of owner's item aren't visible to recipient until owner shares item again.
+type SharedItem struct {
+	Share
+	Item *Item
+}
+
+// ShareItem shares item with another user.
+//
+// Item is encrypted with random share's key, share's key is encrypted with recipient's
+// public key, so only recipient is able to decrypt item. Recipient gets read-only snapshot
+// of item, sharing item with the same recipient again replaces snapshot with current item.
+func (c *GRPCClient) ShareItem(ctx context.Context, item *Item, recipient string) error {
+	if item.ID == 0 {
+		return ErrItemNotSaved
+	}
+
+	if item.Type == common.ItemTypeSecData {
+		return ErrShareDataItem
+	}
+
+	keyResp, err := c.usersClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Username: recipient})
+	if err != nil {
+		return c.wrapError(err)
+	}
+
+	shareKey := crypt.GenerateRandomKey32()
+
+	skey, err := crypt.EncryptForPublicKey(keyResp.PublicKey, shareKey)
+	if err != nil {
+		return err
+	}
+
+	data, err := encryptSharedItem(shareKey, item)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.ShareItemRequest{
+		Username: c.config.GetUser(),
+		Share: &pb.Share{
+			ItemId:    item.ID,
+			Recipient: recipient,
+			Skey:      skey,
+			Data:      data,
+		},
+	}
+
+	if _, err := c.itemsClient.ShareItem(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// GetSharedItems returns items shared with user.
+//
+// Shared items are always requested from server, local storage keeps only user's items.
+func (c *GRPCClient) GetSharedItems(ctx context.Context) ([]*SharedItem, error) {
+	if c.privateKey == nil {
+		return nil, ErrKeyPairMissed
+	}
+
+	resp, err := c.itemsClient.GetShares(ctx, &pb.GetSharesRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	items := make([]*SharedItem, 0, len(resp.Received))
+
+	for _, share := range resp.Received {
+		key, err := crypt.DecryptWithKeyPair(c.publicKey, c.privateKey, share.Skey)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := decryptSharedItem(key, share.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		item.ID = share.ItemId
+		item.FolderID = 0
+
+		items = append(items, &SharedItem{
+			Share: newShareFromPB(share),
+			Item:  item,
+		})
+	}
+
+	return items, nil
+}
+
+// GetItemShares returns shares of user's item.
+func (c *GRPCClient) GetItemShares(ctx context.Context, item *Item) ([]*Share, error) {
+	resp, err := c.itemsClient.GetShares(ctx, &pb.GetSharesRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return nil, c.wrapError(err)
+	}
+
+	shares := make([]*Share, 0)
+
+	for _, share := range resp.Sent {
+		if share.ItemId == item.ID {
+			s := newShareFromPB(share)
+			shares = append(shares, &s)
+		}
+	}
+
+	return shares, nil
+}
+
+// RevokeShare revokes share of user's item.
+func (c *GRPCClient) RevokeShare(ctx context.Context, share *Share) error {
+	request := &pb.RevokeShareRequest{
+		Username: c.config.GetUser(),
+		Id:       share.ID,
+	}
+
+	if _, err := c.itemsClient.RevokeShare(ctx, request); err != nil {
+		return c.wrapError(err)
+	}
+
+	return nil
+}
+
+// setKeyPair decrypts and sets user's key pair received from server.
+//
+// Users registered before sharing was introduced haven't key pair, for them new key pair
+// is generated and sent to server.
+func (c *GRPCClient) setKeyPair(ctx context.Context, username string, publicKey, encPrivateKey []byte) error {
+	c.publicKey, c.privateKey = nil, nil
+
+	if len(encPrivateKey) == 0 {
+		return c.generateKeyPair(ctx, username)
+	}
+
+	privateKey, err := crypt.DecryptAES(c.encKey, encPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	c.publicKey, c.privateKey = publicKey, privateKey
+
+	return nil
+}
+
+// generateKeyPair generates new user's key pair and sends it to server.
+func (c *GRPCClient) generateKeyPair(ctx context.Context, username string) error {
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+
+	encPrivateKey, err := crypt.EncryptAES(c.encKey, privateKey)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.UpdateUserRequest{
+		User: &pb.User{
+			Username:   username,
+			PublicKey:  publicKey,
+			PrivateKey: encPrivateKey,
+		},
+	}
+
+	if _, err := c.usersClient.UpdateUser(ctx, request); err != nil {
+		return err
+	}
+
+	c.publicKey, c.privateKey = publicKey, privateKey
+
+	return nil
+}
+
+// newShareFromPB creates new Share based on protobuf format.
+func newShareFromPB(share *pb.Share) Share {
+	s := Share{
+		ID:        share.Id,
+		ItemID:    share.ItemId,
+		Owner:     share.Owner,
+		Recipient: share.Recipient,
+	}
+
+	if share.Updated != nil {
+		s.Updated = share.Updated.AsTime()
+	}
+
+	return s
+}
+
+// encryptSharedItem is a helper function which serializes item and encrypts it with share's key.
+func encryptSharedItem(key []byte, item *Item) ([]byte, error) {
+	b, err := item.ToBytesSafe()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypt.EncryptAES(key, b)
+}
+
+// decryptSharedItem is a helper function which decrypts shared item with share's key.
+func decryptSharedItem(key, data []byte) (*Item, error) {
+	b, err := crypt.DecryptAES(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewItemFromBytesSafe(b)
+}
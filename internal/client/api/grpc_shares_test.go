@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGRPCClient_ShareItem(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	item := TestingNewLoginItem()
+	item.ID = 100
+
+	t.Run("Item not saved", func(t *testing.T) {
+		err := ts.Client.ShareItem(testGRPCctx, TestingNewLoginItem(), "recipient")
+		assert.ErrorIs(t, err, ErrItemNotSaved)
+	})
+
+	t.Run("Data item", func(t *testing.T) {
+		err := ts.Client.ShareItem(testGRPCctx, &Item{ID: 1, Type: common.ItemTypeSecData}, "recipient")
+		assert.ErrorIs(t, err, ErrShareDataItem)
+	})
+
+	t.Run("Get public key error", func(t *testing.T) {
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.ShareItem(testGRPCctx, item, "recipient"))
+	})
+
+	t.Run("Wrong public key", func(t *testing.T) {
+		resp := &pb.GetPublicKeyResponse{PublicKey: []byte("wrong")}
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, mockAnyVal).Return(resp, nil)
+		assert.ErrorIs(t, ts.Client.ShareItem(testGRPCctx, item, "recipient"), crypt.ErrWrongKeyLength)
+	})
+
+	t.Run("Share item error", func(t *testing.T) {
+		resp := &pb.GetPublicKeyResponse{PublicKey: publicKey}
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, mockAnyVal).Return(resp, nil)
+		ts.ItemsClient.EXPECT().ShareItem(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.ShareItem(testGRPCctx, item, "recipient"))
+	})
+
+	t.Run("Item shared", func(t *testing.T) {
+		var req *pb.ShareItemRequest
+
+		resp := &pb.GetPublicKeyResponse{PublicKey: publicKey}
+		ts.UsersClient.EXPECT().GetPublicKey(testGRPCctx, &pb.GetPublicKeyRequest{Username: "recipient"}).
+			Return(resp, nil)
+		ts.ItemsClient.EXPECT().ShareItem(testGRPCctx, mockAnyVal).DoAndReturn(
+			func(_ context.Context, r *pb.ShareItemRequest,
+				_ ...grpc.CallOption) (*pb.ShareItemResponse, error) {
+				req = r
+				return &pb.ShareItemResponse{Id: 1}, nil
+			})
+		require.NoError(t, ts.Client.ShareItem(testGRPCctx, item, "recipient"))
+
+		assert.Equal(t, int64(100), req.Share.ItemId)
+		assert.Equal(t, "recipient", req.Share.Recipient)
+
+		key, err := crypt.DecryptWithKeyPair(publicKey, privateKey, req.Share.Skey)
+		require.NoError(t, err)
+		shared, err := decryptSharedItem(key, req.Share.Data)
+		require.NoError(t, err)
+		assert.Equal(t, item.Name, shared.Name)
+		assert.Equal(t, item.GetLogin(), shared.GetLogin())
+	})
+}
+
+func TestGRPCClient_GetSharedItems(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Key pair missed", func(t *testing.T) {
+		_, err := ts.Client.GetSharedItems(testGRPCctx)
+		assert.ErrorIs(t, err, ErrKeyPairMissed)
+	})
+
+	publicKey, privateKey, err := crypt.GenerateKeyPair()
+	require.NoError(t, err)
+
+	ts.Client.publicKey, ts.Client.privateKey = publicKey, privateKey
+	sharedAt := time.Now().Truncate(time.Second)
+
+	newShare := func(item *Item) *pb.Share {
+		key := crypt.GenerateRandomKey32()
+		skey, err := crypt.EncryptForPublicKey(publicKey, key)
+		require.NoError(t, err)
+		data, err := encryptSharedItem(key, item)
+		require.NoError(t, err)
+
+		return &pb.Share{Id: 1, ItemId: 100, Owner: "owner", Recipient: "user", Skey: skey, Data: data,
+			Updated: timestamppb.New(sharedAt)}
+	}
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().GetShares(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetSharedItems(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Decryption error", func(t *testing.T) {
+		resp := &pb.GetSharesResponse{Received: []*pb.Share{{Skey: []byte("notencrypted")}}}
+		ts.ItemsClient.EXPECT().GetShares(testGRPCctx, mockAnyVal).Return(resp, nil)
+		_, err := ts.Client.GetSharedItems(testGRPCctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		item := TestingNewLoginItem()
+		item.FolderID = 5
+
+		resp := &pb.GetSharesResponse{Received: []*pb.Share{newShare(item)}}
+		ts.ItemsClient.EXPECT().GetShares(testGRPCctx, mockAnyVal).Return(resp, nil)
+		items, err := ts.Client.GetSharedItems(testGRPCctx)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+
+		assert.Equal(t, "owner", items[0].Owner)
+		assert.True(t, sharedAt.Equal(items[0].Updated), "time of snapshot")
+		assert.Equal(t, item.Name, items[0].Item.Name)
+		assert.Equal(t, int64(100), items[0].Item.ID)
+		assert.Zero(t, items[0].Item.FolderID, "owner's folder mustn't be kept")
+	})
+}
+
+func TestGRPCClient_GetItemShares(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Server response error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().GetShares(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		_, err := ts.Client.GetItemShares(testGRPCctx, &Item{ID: 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("Server response OK", func(t *testing.T) {
+		resp := &pb.GetSharesResponse{Sent: []*pb.Share{
+			{Id: 1, ItemId: 1, Recipient: "first"},
+			{Id: 2, ItemId: 2, Recipient: "second"},
+		}}
+		ts.ItemsClient.EXPECT().GetShares(testGRPCctx, mockAnyVal).Return(resp, nil)
+		shares, err := ts.Client.GetItemShares(testGRPCctx, &Item{ID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []*Share{{ID: 1, ItemID: 1, Recipient: "first"}}, shares)
+	})
+}
+
+func TestGRPCClient_RevokeShare(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Revoke share error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().RevokeShare(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.RevokeShare(testGRPCctx, &Share{ID: 1}))
+	})
+
+	t.Run("Share revoked", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().RevokeShare(testGRPCctx, &pb.RevokeShareRequest{Id: 1}).
+			Return(&pb.RevokeShareResponse{}, nil)
+		assert.NoError(t, ts.Client.RevokeShare(testGRPCctx, &Share{ID: 1}))
+	})
+}
@@ -34,6 +34,8 @@ const (
 	SyncBackground SyncType = iota
 	// Force synchronization and wait for it finishes.
 	SyncWaitForComplete
+	// Clear local storage, fully synchronize it and wait for it finishes.
+	SyncRebuild
 )
 
 const (
@@ -57,6 +59,15 @@ func (c *GRPCClient) ForceSyncWithWait() {
 	}
 }
 
+// ForceRebuildWithWait clears local storage, forces full synchronization and block execution
+// until synchronization will stop.
+func (c *GRPCClient) ForceRebuildWithWait() {
+	if c.config.GetMode() == config.ModeLocal {
+		c.syncControlCh <- SyncRebuild
+		<-c.syncCompleteCh
+	}
+}
+
 // StorageInit intializes agent's storage.
 func (c *GRPCClient) StorageInit(ctx context.Context, statusCh chan<- string) (err error) {
 	if c.config.GetMode() == config.ModeServer {
@@ -81,15 +92,54 @@ func (c *GRPCClient) StorageInit(ctx context.Context, statusCh chan<- string) (e
 	return nil
 }
 
+// vaultEvent represents event received from vault's watch stream.
+type vaultEvent struct {
+	// New vault's revision
+	revision int64
+	// Error which dropped watch stream, nil for revision event
+	err error
+}
+
 // Sync maintains synchronization process.
+//
+// Synchronization is triggered by vault's revision changes pushed by server. While watch stream
+// is down, synchronization runs every sync interval.
 func (c *GRPCClient) Sync(ctx context.Context, statusCh chan<- string) {
 	ticker := time.NewTicker(c.config.GetSyncInterval())
+	watchCh := make(chan vaultEvent)
+	watching := false
+
+	go c.watchVault(ctx, watchCh)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case event := <-watchCh:
+			if event.err != nil {
+				watching = false
+				continue
+			}
+
+			watching = true
+
+			if revision, err := c.storage.GetRevision(ctx); err == nil && revision == event.revision {
+				continue
+			}
+
+			statusCh <- SyncStatusInProgress
+
+			if err := c.syncExec(ctx); err != nil {
+				statusCh <- SyncStatusFailed
+				continue
+			}
+
+			statusCh <- SyncStatusOK
 		case <-ticker.C:
+			if watching {
+				continue
+			}
+
 			statusCh <- SyncStatusInProgress
 
 			if err := c.syncExec(ctx); err != nil {
@@ -101,10 +151,15 @@ func (c *GRPCClient) Sync(ctx context.Context, statusCh chan<- string) {
 		case sync := <-c.syncControlCh:
 			statusCh <- SyncStatusInProgress
 
-			if err := c.syncExec(ctx); err != nil {
+			syncFunc := c.syncExec
+			if sync == SyncRebuild {
+				syncFunc = c.rebuildExec
+			}
+
+			if err := syncFunc(ctx); err != nil {
 				statusCh <- SyncStatusFailed
 
-				if sync == SyncWaitForComplete {
+				if sync != SyncBackground {
 					c.syncCompleteCh <- struct{}{}
 				}
 
@@ -113,54 +168,207 @@ func (c *GRPCClient) Sync(ctx context.Context, statusCh chan<- string) {
 
 			statusCh <- SyncStatusOK
 
-			if sync == SyncWaitForComplete {
+			if sync != SyncBackground {
 				c.syncCompleteCh <- struct{}{}
 			}
 		}
 	}
 }
 
+// watchVault receives vault's revisions from server and sends them to provided channel.
+//
+// Server sends current revision right after stream is opened, so changes made while stream
+// was down are not missed. When stream drops, error is sent to channel and stream is reopened
+// after sync interval.
+func (c *GRPCClient) watchVault(ctx context.Context, eventCh chan<- vaultEvent) {
+	for {
+		err := c.watchVaultStream(ctx, eventCh)
+
+		select {
+		case <-ctx.Done():
+			return
+		case eventCh <- vaultEvent{err: err}:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.config.GetSyncInterval()):
+		}
+	}
+}
+
+// watchVaultStream opens vault's watch stream and sends received revisions to provided channel
+// until stream drops.
+func (c *GRPCClient) watchVaultStream(ctx context.Context, eventCh chan<- vaultEvent) error {
+	stream, err := c.itemsClient.WatchVault(ctx, &pb.WatchVaultRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case eventCh <- vaultEvent{revision: resp.Revision}:
+		}
+	}
+}
+
 // syncExec runs synchronization process.
 //
 // Synchronization process contains following steps:
-//   - check current revision
-//   - if revision is different synchronize items with server (please watch syncItems)
+//   - check local storage's revision
+//   - if revision is unknown synchronize all items with server (please watch syncItems)
+//   - otherwise synchronize only items changed since revision (please watch syncChanges)
 func (c *GRPCClient) syncExec(ctx context.Context) error {
-	revEqual, srvRevision, err := c.revisionsIsEqual(ctx)
+	revision, err := c.storage.GetRevision(ctx)
 	if err != nil {
 		return err
 	}
 
-	if revEqual {
-		return nil
+	if revision != 0 {
+		return c.syncChanges(ctx, revision)
+	}
+
+	resp, err := c.usersClient.GetRevision(ctx, &pb.GetRevisionRequest{Username: c.config.GetUser()})
+	if err != nil {
+		return err
 	}
 
-	return c.syncItems(ctx, srvRevision)
+	return c.syncItems(ctx, resp.Revision)
+}
+
+// rebuildExec clears local storage and runs full synchronization.
+//
+// Used when all stored items become outdated at once, e.g. after encryption key rotation.
+func (c *GRPCClient) rebuildExec(ctx context.Context) error {
+	if err := c.storage.ClearItems(ctx); err != nil {
+		return err
+	}
+
+	if err := c.storage.SaveRevision(ctx, 0); err != nil {
+		return err
+	}
+
+	return c.syncExec(ctx)
 }
 
 // revisionsIsEqual compares server's and local storage's revisions.
 //
 // Returns true if equal, false if not. Also returns server's revision.
-func (c *GRPCClient) revisionsIsEqual(ctx context.Context) (bool, []byte, error) {
+func (c *GRPCClient) revisionsIsEqual(ctx context.Context) (bool, int64, error) {
 	resp, err := c.usersClient.GetRevision(ctx, &pb.GetRevisionRequest{Username: c.config.GetUser()})
 	if err != nil {
-		return false, nil, err
+		return false, 0, err
 	}
 
 	storRevision, err := c.storage.GetRevision(ctx)
 	if err != nil {
-		return false, nil, err
+		return false, 0, err
 	}
 
-	if bytes.Equal(resp.Revision, storRevision) {
-		return true, resp.Revision, nil
+	return resp.Revision == storRevision, resp.Revision, nil
+}
+
+// syncChanges synchronizes items changed on server since provided revision and updates
+// local storage. Folders are always synchronized fully.
+//
+// Created and updated items are requested from server, trashed items are not returned
+// by server, so they are deleted from storage. If server can't provide changes since
+// revision, all items are synchronized.
+func (c *GRPCClient) syncChanges(ctx context.Context, revision int64) error {
+	resp, err := c.itemsClient.GetChangesSince(ctx, &pb.GetChangesSinceRequest{
+		Username: c.config.GetUser(),
+		Revision: revision,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.FullSync {
+		return c.syncItems(ctx, resp.Revision)
 	}
 
-	return false, resp.Revision, nil
+	if resp.Revision == revision {
+		return nil
+	}
+
+	storItemsList, err := c.storage.GetItemsList(ctx)
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[int64]struct{}, len(storItemsList))
+	for _, item := range storItemsList {
+		stored[item.ID] = struct{}{}
+	}
+
+	deleteItems := append([]int64{}, resp.Deleted...)
+
+	if changed := append(append([]int64{}, resp.Created...), resp.Updated...); len(changed) > 0 {
+		items, err := c.GetItemsForStorage(ctx, changed)
+		if err != nil {
+			return err
+		}
+
+		var createItems, updateItems storage.Items
+
+		received := make(map[int64]struct{}, len(items))
+
+		for _, item := range items {
+			received[item.ID] = struct{}{}
+
+			if _, ok := stored[item.ID]; ok {
+				updateItems = append(updateItems, item)
+				continue
+			}
+
+			createItems = append(createItems, item)
+		}
+
+		for _, id := range changed {
+			if _, ok := received[id]; !ok {
+				deleteItems = append(deleteItems, id)
+			}
+		}
+
+		if len(createItems) > 0 {
+			if err := c.storage.CreateItems(ctx, createItems); err != nil {
+				return err
+			}
+		}
+
+		if len(updateItems) > 0 {
+			if err := c.storage.UpdateItems(ctx, updateItems); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(deleteItems) > 0 {
+		if err := c.storage.DeleteItems(ctx, deleteItems); err != nil {
+			return err
+		}
+	}
+
+	if err := c.syncFolders(ctx); err != nil {
+		return err
+	}
+
+	return c.storage.SaveRevision(ctx, resp.Revision)
 }
 
-// syncItems synchronizes items with server and update local storage.
-func (c *GRPCClient) syncItems(ctx context.Context, revision []byte) error {
+// syncItems synchronizes all items and folders with server and update local storage.
+//
+// Revision must be requested before items' list, so changes made during synchronization
+// are synchronized next time.
+func (c *GRPCClient) syncItems(ctx context.Context, revision int64) error {
 	resp, err := c.itemsClient.GetItemList(ctx, &pb.GetItemListRequest{Username: c.config.GetUser()})
 	if err != nil {
 		return err
@@ -204,6 +412,10 @@ func (c *GRPCClient) syncItems(ctx context.Context, revision []byte) error {
 		}
 	}
 
+	if err = c.syncFolders(ctx); err != nil {
+		return err
+	}
+
 	err = c.storage.SaveRevision(ctx, revision)
 	if err != nil {
 		return err
@@ -214,11 +426,18 @@ func (c *GRPCClient) syncItems(ctx context.Context, revision []byte) error {
 
 // prepareItemsToSync is a helper function which prepares list of items' IDs for update,
 // delete of create.
+//
+// Trashed items are not kept in local storage, so they are deleted from storage
+// and never created.
 func (c *GRPCClient) prepareItemsToSync(dbItemsList []*pb.ItemShort,
 	storItemsList storage.Items) *ItemsToSync {
 
 	dbItemsMap := map[int64]*pb.ItemShort{}
 	for _, item := range dbItemsList {
+		if item.Deleted != nil {
+			continue
+		}
+
 		dbItemsMap[item.Id] = item
 	}
 
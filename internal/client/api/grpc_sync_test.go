@@ -4,10 +4,13 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/config"
 	"github.com/artfuldog/gophkeeper/internal/client/storage"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mockgrpc"
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -36,32 +39,173 @@ func TestGRPCClient_StorageInit(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
+		ts.ItemsClient.EXPECT().WatchVault(gomock.Any(), gomock.Any()).Return(nil, assert.AnError).AnyTimes()
 		require.NoError(t, ts.Client.StorageInit(ctx, nil))
 	})
 }
 
+func TestGRPCClient_Sync(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	revisions := []int64{5, 6}
+	stream := mockgrpc.NewMockItems_WatchVaultClient(ts.MockCtrl)
+	stream.EXPECT().Recv().DoAndReturn(func() (*pb.WatchVaultResponse, error) {
+		if len(revisions) == 0 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		resp := &pb.WatchVaultResponse{Revision: revisions[0]}
+		revisions = revisions[1:]
+
+		return resp, nil
+	}).MinTimes(2)
+
+	ts.ItemsClient.EXPECT().WatchVault(gomock.Any(), &pb.WatchVaultRequest{
+		Username: ts.Client.config.GetUser(),
+	}).Return(stream, nil)
+
+	gomock.InOrder(
+		ts.Storage.EXPECT().GetRevision(gomock.Any()).Return(int64(5), nil),
+		ts.Storage.EXPECT().GetRevision(gomock.Any()).Return(int64(5), nil),
+		ts.Storage.EXPECT().GetRevision(gomock.Any()).Return(int64(0), assert.AnError),
+	)
+
+	statusCh := make(chan string)
+	go ts.Client.Sync(ctx, statusCh)
+
+	assert.Equal(t, SyncStatusInProgress, <-statusCh, "sync must be triggered by new revision only")
+	assert.Equal(t, SyncStatusFailed, <-statusCh)
+}
+
+func TestGRPCClient_watchVaultStream(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Open stream error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().WatchVault(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.ErrorIs(t, ts.Client.watchVaultStream(testGRPCctx, nil), assert.AnError)
+	})
+
+	t.Run("Revisions until stream drops", func(t *testing.T) {
+		stream := mockgrpc.NewMockItems_WatchVaultClient(ts.MockCtrl)
+		ts.ItemsClient.EXPECT().WatchVault(testGRPCctx, mockAnyVal).Return(stream, nil)
+		gomock.InOrder(
+			stream.EXPECT().Recv().Return(&pb.WatchVaultResponse{Revision: 1}, nil),
+			stream.EXPECT().Recv().Return(&pb.WatchVaultResponse{Revision: 2}, nil),
+			stream.EXPECT().Recv().Return(nil, assert.AnError),
+		)
+
+		eventCh := make(chan vaultEvent, 2)
+		assert.ErrorIs(t, ts.Client.watchVaultStream(testGRPCctx, eventCh), assert.AnError)
+		assert.Equal(t, vaultEvent{revision: 1}, <-eventCh)
+		assert.Equal(t, vaultEvent{revision: 2}, <-eventCh)
+	})
+}
+
+func TestGRPCClient_watchVaultStream_Server(t *testing.T) {
+	client := newTestServerClient(t, "127.0.0.1:3205")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventCh := make(chan vaultEvent)
+	errCh := make(chan error, 1)
+
+	receive := func() vaultEvent {
+		select {
+		case event := <-eventCh:
+			return event
+		case err := <-errCh:
+			require.FailNow(t, "watch stream dropped", err)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "vault's revision isn't received")
+		}
+
+		return vaultEvent{}
+	}
+
+	// Token is expired, so stream is opened after token's refresh.
+	time.Sleep(2 * time.Second)
+
+	go func() {
+		errCh <- client.watchVaultStream(ctx, eventCh)
+	}()
+
+	current := receive()
+
+	require.NoError(t, client.SaveItem(context.Background(), TestingNewSecNoteItem()))
+	assert.Greater(t, receive().revision, current.revision)
+
+	cancel()
+	assert.Error(t, <-errCh)
+}
+
 func TestGRPCClient_syncExec(t *testing.T) {
 	ts := NewTestSuiteGRPClient(t)
 	defer ts.Stop()
 
+	t.Run("Get local revision error", func(t *testing.T) {
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(0), assert.AnError)
+		assert.Error(t, ts.Client.syncExec(testGRPCctx))
+	})
+
 	t.Run("Get revision error", func(t *testing.T) {
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(0), nil)
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
 		assert.Error(t, ts.Client.syncExec(testGRPCctx))
 	})
 
-	t.Run("Equal revisions", func(t *testing.T) {
-		srvResp := &pb.GetRevisionResponse{Revision: []byte("revision1")}
+	t.Run("Unknown local revision", func(t *testing.T) {
+		srvResp := &pb.GetRevisionResponse{Revision: 1}
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(0), nil)
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(srvResp, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return([]byte("revision1"), nil)
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+		assert.Error(t, ts.Client.syncExec(testGRPCctx))
+	})
+
+	t.Run("Known local revision", func(t *testing.T) {
+		srvResp := &pb.GetChangesSinceResponse{Revision: 1}
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(1), nil)
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, &pb.GetChangesSinceRequest{
+			Username: ts.Client.config.GetUser(),
+			Revision: 1,
+		}).Return(srvResp, nil)
 		assert.NoError(t, ts.Client.syncExec(testGRPCctx))
 	})
+}
+
+func TestGRPCClient_rebuildExec(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Clear storage error", func(t *testing.T) {
+		ts.Storage.EXPECT().ClearItems(testGRPCctx).Return(assert.AnError)
+		assert.Error(t, ts.Client.rebuildExec(testGRPCctx))
+	})
+
+	t.Run("Reset revision error", func(t *testing.T) {
+		ts.Storage.EXPECT().ClearItems(testGRPCctx).Return(nil)
+		ts.Storage.EXPECT().SaveRevision(testGRPCctx, int64(0)).Return(assert.AnError)
+		assert.Error(t, ts.Client.rebuildExec(testGRPCctx))
+	})
 
-	t.Run("Unequal revisions", func(t *testing.T) {
-		srvResp := &pb.GetRevisionResponse{Revision: []byte("revision1")}
+	t.Run("Storage rebuilt", func(t *testing.T) {
+		srvResp := &pb.GetRevisionResponse{Revision: 5}
+		ts.Storage.EXPECT().ClearItems(testGRPCctx).Return(nil)
+		ts.Storage.EXPECT().SaveRevision(testGRPCctx, int64(0)).Return(nil)
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(0), nil)
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(srvResp, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return([]byte("revision2"), nil)
-		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
-		assert.Error(t, ts.Client.syncExec(testGRPCctx))
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(&pb.GetItemListResponse{}, nil)
+		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(nil, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
+		ts.Storage.EXPECT().SaveRevision(testGRPCctx, int64(5)).Return(nil)
+		assert.NoError(t, ts.Client.rebuildExec(testGRPCctx))
 	})
 }
 
@@ -78,34 +222,34 @@ func TestGRPCClient_revisionsIsEqual(t *testing.T) {
 
 	t.Run("Get revision from local storage error", func(t *testing.T) {
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(nil, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(nil, assert.AnError)
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(0), assert.AnError)
 
 		_, _, err := ts.Client.revisionsIsEqual(testGRPCctx)
 		assert.Error(t, err)
 	})
 
 	t.Run("Same revision", func(t *testing.T) {
-		srvResp := &pb.GetRevisionResponse{Revision: []byte("revision1")}
+		srvResp := &pb.GetRevisionResponse{Revision: 1}
 
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(srvResp, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return([]byte("revision1"), nil)
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(1), nil)
 
 		equal, revision, err := ts.Client.revisionsIsEqual(testGRPCctx)
 		require.NoError(t, err)
 		assert.Equal(t, true, equal)
-		assert.Equal(t, []byte("revision1"), revision)
+		assert.Equal(t, int64(1), revision)
 	})
 
 	t.Run("Different revisions", func(t *testing.T) {
-		srvResp := &pb.GetRevisionResponse{Revision: []byte("srvrevision")}
+		srvResp := &pb.GetRevisionResponse{Revision: 2}
 
 		ts.UsersClient.EXPECT().GetRevision(testGRPCctx, mockAnyVal).Return(srvResp, nil)
-		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return([]byte("localrevision"), nil)
+		ts.Storage.EXPECT().GetRevision(testGRPCctx).Return(int64(1), nil)
 
 		equal, revision, err := ts.Client.revisionsIsEqual(testGRPCctx)
 		require.NoError(t, err)
 		assert.Equal(t, false, equal)
-		assert.Equal(t, []byte("srvrevision"), revision)
+		assert.Equal(t, int64(2), revision)
 	})
 }
 
@@ -120,14 +264,14 @@ func TestGRPCClient_syncItems(t *testing.T) {
 	t.Run("Get item list from server error", func(t *testing.T) {
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 	})
 
 	t.Run("Get item list from local storage error", func(t *testing.T) {
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(nil, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(nil, assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 	})
 
 	t.Run("Save revision error", func(t *testing.T) {
@@ -138,9 +282,11 @@ func TestGRPCClient_syncItems(t *testing.T) {
 
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(srvResp, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
 		ts.Storage.EXPECT().SaveRevision(testGRPCctx, mockAnyVal).Return(assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 	})
 
 	t.Run("Empty sync list", func(t *testing.T) {
@@ -151,9 +297,11 @@ func TestGRPCClient_syncItems(t *testing.T) {
 
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(srvResp, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
 		ts.Storage.EXPECT().SaveRevision(testGRPCctx, mockAnyVal).Return(nil)
 
-		require.NoError(t, ts.Client.syncItems(testGRPCctx, nil))
+		require.NoError(t, ts.Client.syncItems(testGRPCctx, 0))
 	})
 
 	t.Run("Create new items", func(t *testing.T) {
@@ -177,22 +325,24 @@ func TestGRPCClient_syncItems(t *testing.T) {
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(srvRespList, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, mockAnyVal).Return(srvRespItem, nil)
 		ts.Storage.EXPECT().CreateItems(testGRPCctx, mockAnyVal).Return(assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(srvRespList, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, mockAnyVal).Return(srvRespItem, nil)
 		ts.Storage.EXPECT().CreateItems(testGRPCctx, mockAnyVal).Return(nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
 		ts.Storage.EXPECT().SaveRevision(testGRPCctx, mockAnyVal).Return(nil)
 
-		require.NoError(t, ts.Client.syncItems(testGRPCctx, nil))
+		require.NoError(t, ts.Client.syncItems(testGRPCctx, 0))
 	})
 
 	t.Run("Update items", func(t *testing.T) {
@@ -223,22 +373,24 @@ func TestGRPCClient_syncItems(t *testing.T) {
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(srvRespList, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, mockAnyVal).Return(srvRespItem, nil)
 		ts.Storage.EXPECT().UpdateItems(testGRPCctx, mockAnyVal).Return(assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(srvRespList, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, mockAnyVal).Return(srvRespItem, nil)
 		ts.Storage.EXPECT().UpdateItems(testGRPCctx, mockAnyVal).Return(nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
 		ts.Storage.EXPECT().SaveRevision(testGRPCctx, mockAnyVal).Return(nil)
 
-		require.NoError(t, ts.Client.syncItems(testGRPCctx, nil))
+		require.NoError(t, ts.Client.syncItems(testGRPCctx, 0))
 	})
 
 	t.Run("Delete items", func(t *testing.T) {
@@ -259,14 +411,107 @@ func TestGRPCClient_syncItems(t *testing.T) {
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.Storage.EXPECT().DeleteItems(testGRPCctx, mockAnyVal).Return(assert.AnError)
 
-		assert.Error(t, ts.Client.syncItems(testGRPCctx, nil))
+		assert.Error(t, ts.Client.syncItems(testGRPCctx, 0))
 
 		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(srvRespList, nil)
 		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
 		ts.Storage.EXPECT().DeleteItems(testGRPCctx, mockAnyVal).Return(nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
 		ts.Storage.EXPECT().SaveRevision(testGRPCctx, mockAnyVal).Return(nil)
 
-		require.NoError(t, ts.Client.syncItems(testGRPCctx, nil))
+		require.NoError(t, ts.Client.syncItems(testGRPCctx, 0))
+	})
+}
+
+func TestGRPCClient_syncChanges(t *testing.T) {
+	ts := NewTestSuiteGRPClient(t)
+	defer ts.Stop()
+
+	t.Run("Get changes error", func(t *testing.T) {
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+
+		assert.Error(t, ts.Client.syncChanges(testGRPCctx, 1))
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		srvResp := &pb.GetChangesSinceResponse{Revision: 1}
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(srvResp, nil)
+
+		assert.NoError(t, ts.Client.syncChanges(testGRPCctx, 1))
+	})
+
+	t.Run("Full synchronization is required", func(t *testing.T) {
+		srvResp := &pb.GetChangesSinceResponse{Revision: 1, FullSync: true}
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(srvResp, nil)
+		ts.ItemsClient.EXPECT().GetItemList(testGRPCctx, mockAnyVal).Return(&pb.GetItemListResponse{}, nil)
+		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(nil, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
+		ts.Storage.EXPECT().SaveRevision(testGRPCctx, int64(1)).Return(nil)
+
+		assert.NoError(t, ts.Client.syncChanges(testGRPCctx, 5))
+	})
+
+	t.Run("Get item list from local storage error", func(t *testing.T) {
+		srvResp := &pb.GetChangesSinceResponse{Revision: 2, Deleted: []int64{1}}
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(srvResp, nil)
+		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(nil, assert.AnError)
+
+		assert.Error(t, ts.Client.syncChanges(testGRPCctx, 1))
+	})
+
+	t.Run("Get changed items error", func(t *testing.T) {
+		srvResp := &pb.GetChangesSinceResponse{Revision: 2, Created: []int64{1}}
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(srvResp, nil)
+		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(nil, nil)
+		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+
+		assert.Error(t, ts.Client.syncChanges(testGRPCctx, 1))
+	})
+
+	t.Run("Synchronize folders error", func(t *testing.T) {
+		srvResp := &pb.GetChangesSinceResponse{Revision: 2}
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(srvResp, nil)
+		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(nil, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(nil, assert.AnError)
+
+		assert.Error(t, ts.Client.syncChanges(testGRPCctx, 1))
+
+		folders := &pb.GetFoldersResponse{Folders: []*pb.Folder{{Id: 1, Name: []byte("name")}}}
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(srvResp, nil)
+		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(nil, nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(folders, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, storage.Folders{{ID: 1, Name: []byte("name")}}).
+			Return(assert.AnError)
+
+		assert.Error(t, ts.Client.syncChanges(testGRPCctx, 1))
+	})
+
+	t.Run("Changes are synchronized", func(t *testing.T) {
+		srvResp := &pb.GetChangesSinceResponse{
+			Revision: 10,
+			Created:  []int64{100, 101},
+			Updated:  []int64{200, 201},
+			Deleted:  []int64{300},
+		}
+		storResp := storage.Items{{ID: 101}, {ID: 200}, {ID: 201}, {ID: 300}}
+		srvRespItems := &pb.GetItemsResponse{Items: []*pb.Item{{Id: 100}, {Id: 101}, {Id: 200}}}
+
+		ts.ItemsClient.EXPECT().GetChangesSince(testGRPCctx, mockAnyVal).Return(srvResp, nil)
+		ts.Storage.EXPECT().GetItemsList(testGRPCctx).Return(storResp, nil)
+		ts.ItemsClient.EXPECT().GetItems(testGRPCctx, &pb.GetItemsRequest{
+			Username: ts.Client.config.GetUser(),
+			Ids:      []int64{100, 101, 200, 201},
+		}).Return(srvRespItems, nil)
+		ts.Storage.EXPECT().CreateItems(testGRPCctx, gomock.Len(1)).Return(nil)
+		ts.Storage.EXPECT().UpdateItems(testGRPCctx, gomock.Len(2)).Return(nil)
+		ts.Storage.EXPECT().DeleteItems(testGRPCctx, []int64{300, 201}).Return(nil)
+		ts.ItemsClient.EXPECT().GetFolders(testGRPCctx, mockAnyVal).Return(&pb.GetFoldersResponse{}, nil)
+		ts.Storage.EXPECT().SaveFolders(testGRPCctx, mockAnyVal).Return(nil)
+		ts.Storage.EXPECT().SaveRevision(testGRPCctx, int64(10)).Return(nil)
+
+		assert.NoError(t, ts.Client.syncChanges(testGRPCctx, 1))
 	})
 }
 
@@ -303,6 +548,22 @@ func TestGRPCClient_PrepareItemsToSync(t *testing.T) {
 			Hash:    []byte("updated hash"),
 			Updated: timestamppb.Now(),
 		},
+		{
+			Id:      303,
+			Name:    "TrashedItem",
+			Type:    "l",
+			Hash:    []byte("item hash"),
+			Updated: timestamppb.Now(),
+			Deleted: timestamppb.Now(),
+		},
+		{
+			Id:      101,
+			Name:    "NewTrashedItem",
+			Type:    "l",
+			Hash:    []byte("item hash"),
+			Updated: timestamppb.Now(),
+			Deleted: timestamppb.Now(),
+		},
 	}
 	storeItems := []*storage.Item{
 		{
@@ -335,11 +596,17 @@ func TestGRPCClient_PrepareItemsToSync(t *testing.T) {
 			Type: "l",
 			Hash: []byte("item hash"),
 		},
+		{
+			ID:   303,
+			Name: "TrashedItem",
+			Type: "l",
+			Hash: []byte("item hash"),
+		},
 	}
 
 	items := ts.Client.prepareItemsToSync(dbItems, storeItems)
 
 	assert.Equal(t, items.Create, []int64{100})
 	assert.Equal(t, items.Update, []int64{201, 202})
-	assert.Equal(t, items.Delete, []int64{301, 302})
+	assert.Equal(t, items.Delete, []int64{301, 302, 303})
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/common"
@@ -28,6 +29,16 @@ type Item struct {
 	Secret       Secret       `yaml:"-"`
 	URIs         URIs         `yaml:"uris,omitempty"`
 	CustomFields CustomFields `yaml:"custom_fields,omitempty"`
+	Tags         Tags         `yaml:"tags,omitempty"`
+	FolderID     int64        `yaml:"folder_id,omitempty"`
+	// ID of organization, which owns item, zero for user's own items
+	OrgID int64 `yaml:"-"`
+}
+
+// ItemVersion represents item's previous version.
+type ItemVersion struct {
+	Version int64
+	Item    *Item
 }
 
 // NewItemFromPB creates new Item based on protobuf format.
@@ -43,6 +54,8 @@ func NewItemFromPB(pbItem *pb.Item) *Item {
 		Secret:       NewSecret(pbItem.Secrets.Secret, pbItem.Type),
 		URIs:         NewURIs(pbItem.Additions.Uris),
 		CustomFields: NewCustomFields(pbItem.Additions.CustomFields),
+		Tags:         NewTags(pbItem.Additions.Tags),
+		FolderID:     pbItem.GetFolderId(),
 	}
 }
 
@@ -55,6 +68,7 @@ func (i Item) ToPB() *pb.Item {
 	item.Type = i.Type
 	item.Reprompt = &i.Reprompt
 	item.Updated = timestamppb.New(i.Updated)
+	item.FolderId = &i.FolderID
 
 	secrets := new(pb.Secrets)
 	secrets.Notes = []byte(i.Notes)
@@ -72,6 +86,7 @@ func (i Item) ToPB() *pb.Item {
 	}
 
 	additions.CustomFields = i.CustomFields.ToBytes()
+	additions.Tags = i.Tags.ToBytes()
 	item.Additions = additions
 
 	return item
@@ -385,3 +400,79 @@ func (u URIs) String() string {
 
 	return output + "]"
 }
+
+// Tags represents list of item's tags.
+type Tags []string
+
+// NewTags serializes bytes into Tags structure.
+//
+// This is unsafe function, which means there is no errors checks.
+// If you want this please use NewTagsSafe function.
+//
+//nolint:wsl
+func NewTags(b []byte) Tags {
+	var t Tags
+	serializeUnsafe(&t, b)
+	return t
+}
+
+// NewTagsSafe serializes bytes into Tags structure.
+//
+// Unlike NewTags this is safe function and in case of serialization' failure returns error.
+func NewTagsSafe(b []byte) (Tags, error) {
+	var t Tags
+	if err := serializeSafe(&t, b); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ParseTags creates Tags from comma-separated string.
+//
+// Tags are trimmed, empty and duplicated tags are skipped.
+func ParseTags(s string) Tags {
+	var tags Tags
+
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tags.Contains(tag) {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// ToBytes serializes Tags structure to byte array.
+//
+// This is unsafe function, which means there is no errors checks.
+// If you want this please use ToBytesSafe function.
+func (t *Tags) ToBytes() []byte {
+	return toBytesUnsafe(t)
+}
+
+// ToBytesSafe serializes Tags structure to byte array.
+//
+// Unlike ToBytes this is safe function and in case of serialization' failure returns error.
+func (t *Tags) ToBytesSafe() ([]byte, error) {
+	return toBytesSafe(t)
+}
+
+// Contains returns true if tag is in list.
+func (t Tags) Contains(tag string) bool {
+	for _, v := range t {
+		if v == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String implements Stringer interface, returns comma-separated tags.
+func (t Tags) String() string {
+	return strings.Join(t, ", ")
+}
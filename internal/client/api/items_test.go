@@ -97,6 +97,24 @@ func TestItem_MarshallUnmarshallYAML(t *testing.T) {
 	}
 }
 
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, Tags{"prod", "customer-x"}, ParseTags(" prod, customer-x ,,prod"))
+	assert.Empty(t, ParseTags(" , "))
+	assert.Equal(t, "prod, customer-x", ParseTags("prod,customer-x").String())
+}
+
+func TestTags_Bytes(t *testing.T) {
+	tags := Tags{"prod", "expiring"}
+
+	gotTags, err := NewTagsSafe(tags.ToBytes())
+	require.NoError(t, err)
+	assert.Equal(t, tags, gotTags)
+	assert.Equal(t, tags, NewTags(tags.ToBytes()))
+
+	_, err = NewTagsSafe([]byte("notags"))
+	assert.Error(t, err)
+}
+
 func TestItem_GetSecrets(t *testing.T) {
 	t.Run("Login", func(t *testing.T) {
 		item := TestingNewLoginItem()
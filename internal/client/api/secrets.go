@@ -154,9 +154,14 @@ func (s SecretCard) String() string {
 		s.ChName, c.MaskLeft(s.Number, 4), c.MaskAll(2), c.MaskAll(2), c.MaskAll(3))
 }
 
-// SecretData represents secret for login item's type.
+// SecretData represents secret for data item's type.
+//
+// Data uploaded by stream is stored on server as separately encrypted chunks, in this case
+// secret contains only data's encryption key and size.
 type SecretData struct {
 	Data []byte `yaml:"data,omitempty"`
+	Key  []byte `yaml:"key,omitempty"`
+	Size int64  `yaml:"size,omitempty"`
 }
 
 var _ Secret = (*SecretData)(nil)
@@ -200,6 +205,20 @@ func (s SecretData) ToBytesSafe() ([]byte, error) {
 	return toBytesSafe(s)
 }
 
+// Chunked returns true if data is stored on server as separately encrypted chunks.
+func (s SecretData) Chunked() bool {
+	return len(s.Key) > 0
+}
+
+// GetSize returns size of data in bytes.
+func (s SecretData) GetSize() int64 {
+	if s.Chunked() {
+		return s.Size
+	}
+
+	return int64(len(s.Data))
+}
+
 // String is used for printing text representation of SecretData.
 func (s SecretData) String() string {
 	return "binary data"
@@ -46,11 +46,15 @@ func (s *SQLite) Connect(ctx context.Context, stopCh chan<- struct{}) (err error
 		return err
 	}
 
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
 	if err := s.prepareStatements(ctx); err != nil {
 		return err
 	}
 
-	if _, err = s.db.Exec(`INSERT INTO revision (id, revision) VALUES(?,?)`, 0, []byte("")); err != nil {
+	if _, err = s.db.Exec(`INSERT INTO revision (id, revision) VALUES(?,?)`, 0, 0); err != nil {
 		return err
 	}
 
@@ -59,6 +63,43 @@ func (s *SQLite) Connect(ctx context.Context, stopCh chan<- struct{}) (err error
 	return nil
 }
 
+// migrate is a helper function which upgrades schema of storage created by previous versions.
+//
+// If any vault's column is added, stored items are cleared and revision is reset, so items
+// are fully synchronized with server again.
+func (s *SQLite) migrate(ctx context.Context) error {
+	var migrated bool
+
+	for _, m := range vaultMigrations {
+		var columns int
+
+		row := s.db.QueryRowContext(ctx, stmtCountVaultColumn, m.column)
+		if err := row.Scan(&columns); err != nil {
+			return err
+		}
+
+		if columns > 0 {
+			continue
+		}
+
+		if _, err := s.db.ExecContext(ctx, m.stmt); err != nil {
+			return err
+		}
+
+		migrated = true
+	}
+
+	if !migrated {
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx, stmtResetVault); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // maintainConnectinon is a helper function for closing database connection and file.
 // After successfui stopping closes stopCh.
 func (s *SQLite) maintainConnectinon(ctx context.Context, stopCh chan<- struct{}) {
@@ -82,20 +123,26 @@ func (s *SQLite) Delete() {
 	}
 }
 
-// GetRevision returns current identificator of revision.
-func (s *SQLite) GetRevision(ctx context.Context) ([]byte, error) {
-	revision := []byte{}
+// GetRevision returns current revision.
+//
+// Storages created by previous versions keep opaque revision, which is returned as zero revision.
+func (s *SQLite) GetRevision(ctx context.Context) (int64, error) {
+	var revision interface{}
 
-	row := s.stmts["getRev"].QueryRow()
+	row := s.stmts["getRev"].QueryRowContext(ctx)
 	if err := row.Scan(&revision); err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return revision, nil
+	if r, ok := revision.(int64); ok {
+		return r, nil
+	}
+
+	return 0, nil
 }
 
-// GetRevision save new identificator of revision.
-func (s *SQLite) SaveRevision(ctx context.Context, revision []byte) error {
+// SaveRevision saves new revision.
+func (s *SQLite) SaveRevision(ctx context.Context, revision int64) error {
 	if _, err := s.stmts["updateRev"].ExecContext(ctx, revision); err != nil {
 		return err
 	}
@@ -117,7 +164,7 @@ func (s *SQLite) CreateItems(ctx context.Context, items Items) error {
 
 	for _, item := range items {
 		if _, err = txStmt.ExecContext(ctx, item.ID, item.Name, item.Type,
-			item.Hash, item.Data); err != nil {
+			item.Hash, item.Data, item.FolderID, item.Tags); err != nil {
 			return err
 		}
 	}
@@ -180,21 +227,25 @@ func (s *SQLite) GetItemsList(ctx context.Context) (Items, error) {
 
 	for rows.Next() {
 		var (
-			id    int64
-			name  string
-			iType string
-			hash  []byte
+			id       int64
+			name     string
+			iType    string
+			hash     []byte
+			folderID int64
+			tags     []byte
 		)
 
-		if err := rows.Scan(&id, &name, &iType, &hash); err != nil {
+		if err := rows.Scan(&id, &name, &iType, &hash, &folderID, &tags); err != nil {
 			return nil, err
 		}
 
 		items = append(items, &Item{
-			ID:   id,
-			Name: name,
-			Type: iType,
-			Hash: hash,
+			ID:       id,
+			Name:     name,
+			Type:     iType,
+			Hash:     hash,
+			FolderID: folderID,
+			Tags:     tags,
 		})
 	}
 
@@ -226,7 +277,7 @@ func (s *SQLite) UpdateItems(ctx context.Context, items Items) error {
 
 	for _, item := range items {
 		if _, err = txStmt.ExecContext(ctx, item.Name, item.Hash,
-			item.Data, item.ID); err != nil {
+			item.Data, item.FolderID, item.Tags, item.ID); err != nil {
 			return err
 		}
 	}
@@ -285,3 +336,59 @@ func (s *SQLite) ClearItems(ctx context.Context) error {
 
 	return nil
 }
+
+// SaveFolders replaces all stored folders with provided ones.
+func (s *SQLite) SaveFolders(ctx context.Context, folders Folders) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM folders;`); err != nil {
+		return err
+	}
+
+	txStmt := tx.StmtContext(ctx, s.stmts["createFolder"])
+	defer txStmt.Close()
+
+	for _, folder := range folders {
+		if _, err = txStmt.ExecContext(ctx, folder.ID, folder.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetFolders returns all stored folders.
+func (s *SQLite) GetFolders(ctx context.Context) (Folders, error) {
+	rows, err := s.stmts["getFolders"].QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var folders Folders
+
+	for rows.Next() {
+		folder := new(Folder)
+		if err := rows.Scan(&folder.ID, &folder.Name); err != nil {
+			return nil, err
+		}
+
+		folders = append(folders, folder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return folders, nil
+}
@@ -14,8 +14,14 @@ const (
 		type TEXT,
 		hash BLOB,
 		data BLOB,
+		folder_id INTEGER NOT NULL DEFAULT 0,
+		tags BLOB,
 		UNIQUE (name, type)
 	);
+	CREATE TABLE IF NOT EXISTS folders (
+		id INTEGER NOT NULL PRIMARY KEY,
+		name BLOB NOT NULL
+	);
 	CREATE TABLE IF NOT EXISTS revision (
 		id INTEGER NOT NULL,
 		revision BLOB NOT NULL
@@ -24,16 +30,33 @@ const (
 	CREATE INDEX IF NOT EXISTS item_name_type ON vault(name,type);
 	`
 
+	stmtCountVaultColumn = `SELECT count(*) FROM pragma_table_info('vault') WHERE name = ?`
+	stmtResetVault       = `DELETE FROM vault; UPDATE revision SET revision = 0;`
+
 	stmtGetRevision    = `SELECT revision FROM revision WHERE id=0`
 	stmtUpdateRevision = `UPDATE revision SET revision = ? WHERE id=0`
 
-	stmtCreateItem   = `INSERT INTO vault (id, name, type, hash, data) VALUES(?,?,?,?,?)`
+	stmtCreateItem   = `INSERT INTO vault (id, name, type, hash, data, folder_id, tags) VALUES(?,?,?,?,?,?,?)`
 	stmtGetItem      = `SELECT data FROM vault where name = ? AND type = ?`
-	stmtGetItemsList = `SELECT id, name, type, hash FROM vault ORDER BY name ASC`
-	stmtUpdateItems  = `UPDATE vault SET name = ?, hash = ? , data = ? WHERE id = ?`
+	stmtGetItemsList = `SELECT id, name, type, hash, folder_id, tags FROM vault ORDER BY name ASC`
+	stmtUpdateItems  = `UPDATE vault SET name = ?, hash = ? , data = ?, folder_id = ?, tags = ? WHERE id = ?`
 	stmtDeleteItems  = `DELETE FROM vault WHERE id = ?`
+
+	stmtCreateFolder = `INSERT INTO folders (id, name) VALUES(?,?)`
+	stmtGetFolders   = `SELECT id, name FROM folders ORDER BY id ASC`
 )
 
+// vaultMigrations contains vault's columns added by later versions and statements which add them.
+//
+//nolint:gochecknoglobals
+var vaultMigrations = []struct {
+	column string
+	stmt   string
+}{
+	{column: "folder_id", stmt: `ALTER TABLE vault ADD COLUMN folder_id INTEGER NOT NULL DEFAULT 0`},
+	{column: "tags", stmt: `ALTER TABLE vault ADD COLUMN tags BLOB`},
+}
+
 // prepareStatements is a helper which prepares SQL statements for database.
 func (s *SQLite) prepareStatements(ctx context.Context) (err error) {
 	stmtsDict := map[string]string{
@@ -44,6 +67,8 @@ func (s *SQLite) prepareStatements(ctx context.Context) (err error) {
 		"getItemsList": stmtGetItemsList,
 		"updateItems":  stmtUpdateItems,
 		"deleteItems":  stmtDeleteItems,
+		"createFolder": stmtCreateFolder,
+		"getFolders":   stmtGetFolders,
 	}
 
 	s.stmts = make(map[string]*sql.Stmt)
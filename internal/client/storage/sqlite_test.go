@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"os"
@@ -66,7 +67,7 @@ func TestSQLite_Connect(t *testing.T) {
 }
 
 func TestRevision(t *testing.T) {
-	revision := []byte("testREVISIONnumber12345")
+	revision := int64(12345)
 	err := testDB.SaveRevision(context.Background(), revision)
 	assert.NoError(t, err)
 
@@ -74,7 +75,7 @@ func TestRevision(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, revision, gotRevision)
 
-	newRevision := []byte("NEWtestREVISIONnumber!@#$%")
+	newRevision := int64(12346)
 	err = testDB.SaveRevision(context.Background(), newRevision)
 	assert.NoError(t, err)
 
@@ -82,8 +83,12 @@ func TestRevision(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, newRevision, gotNewRevision)
 
-	err = testDB.SaveRevision(context.Background(), nil)
-	assert.Error(t, err)
+	_, err = testDB.db.Exec(stmtUpdateRevision, []byte("legacyREVISION"))
+	assert.NoError(t, err)
+
+	gotLegacyRevision, err := testDB.GetRevision(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, gotLegacyRevision, "legacy revision must be returned as zero revision")
 }
 
 func TestSQLite_Items(t *testing.T) {
@@ -182,6 +187,8 @@ func TestSQLite_Items(t *testing.T) {
 
 		newItem4.Name = "updatedName4"
 		newItem4.Data = []byte("updated item data")
+		newItem4.FolderID = 7
+		newItem4.Tags = []byte("encrypted tags")
 
 		err := testDB.UpdateItems(context.Background(), Items{newItem1, newItem2})
 		assert.NoError(t, err)
@@ -202,6 +209,16 @@ func TestSQLite_Items(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, item.Data, data)
 		}
+
+		items, err := testDB.GetItemsList(context.Background())
+		require.NoError(t, err)
+
+		for _, item := range items {
+			if item.ID == newItem4.ID {
+				assert.Equal(t, newItem4.FolderID, item.FolderID)
+				assert.Equal(t, newItem4.Tags, item.Tags)
+			}
+		}
 	})
 
 	t.Run("Delete items", func(t *testing.T) {
@@ -237,6 +254,76 @@ func TestSQLite_Items(t *testing.T) {
 	})
 }
 
+func TestSQLite_Folders(t *testing.T) {
+	folders := Folders{
+		{ID: 1, Name: []byte("folder 1")},
+		{ID: 2, Name: []byte("folder 2")},
+	}
+
+	err := testDB.SaveFolders(context.Background(), folders)
+	require.NoError(t, err)
+
+	gotFolders, err := testDB.GetFolders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, folders, gotFolders)
+
+	err = testDB.SaveFolders(context.Background(), folders[1:])
+	require.NoError(t, err)
+
+	gotFolders, err = testDB.GetFolders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, folders[1:], gotFolders, "folders must be replaced")
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = testDB.SaveFolders(canceledCtx, folders)
+	assert.Error(t, err)
+
+	_, err = testDB.GetFolders(canceledCtx)
+	assert.Error(t, err)
+
+	err = testDB.SaveFolders(context.Background(), nil)
+	require.NoError(t, err)
+}
+
+func TestSQLite_ConnectLegacy(t *testing.T) {
+	db := newSQLite("legacy", testDBDir)
+	defer db.Delete()
+
+	legacy, err := sql.Open("sqlite3", db.filepath)
+	require.NoError(t, err)
+
+	_, err = legacy.Exec(`
+	CREATE TABLE vault (id INTEGER NOT NULL PRIMARY KEY, name TEXT NOT NULL, type TEXT,
+		hash BLOB, data BLOB, UNIQUE (name, type));
+	CREATE TABLE revision (id INTEGER NOT NULL, revision BLOB NOT NULL);
+	INSERT INTO vault (id, name, type) VALUES (1, 'legacy item', 'l');
+	INSERT INTO revision (id, revision) VALUES (0, 10);
+	`)
+	require.NoError(t, err)
+	require.NoError(t, legacy.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopCh := make(chan struct{})
+
+	err = db.Connect(ctx, stopCh)
+	require.NoError(t, err)
+
+	items, err := db.GetItemsList(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, items, "legacy storage must be cleared")
+
+	revision, err := db.GetRevision(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, revision, "legacy storage must be fully synchronized")
+
+	err = db.CreateItems(ctx, Items{{ID: 1, Name: "item", Type: "l", FolderID: 2}})
+	assert.NoError(t, err)
+
+	cancel()
+	<-stopCh
+}
+
 func BenchmarkSQLite(b *testing.B) {
 	b.Run("Create items - large batch of 20000 items", func(b *testing.B) {
 		items := []*Item{}
@@ -26,10 +26,10 @@ type Executor interface {
 
 // Storekeeper defines methods for get/set storage's data.
 type Storekeeper interface {
-	// Get current revision.
-	GetRevision(context.Context) ([]byte, error)
+	// Get current revision, zero revision means unknown.
+	GetRevision(context.Context) (int64, error)
 	// Set current revision.
-	SaveRevision(context.Context, []byte) error
+	SaveRevision(context.Context, int64) error
 	// Create new items.
 	CreateItems(context.Context, Items) error
 	// Get item's data.
@@ -42,6 +42,10 @@ type Storekeeper interface {
 	DeleteItems(ctx context.Context, ids []int64) error
 	// Clear all items from storage.
 	ClearItems(ctx context.Context) error
+	// Replace all folders with provided ones.
+	SaveFolders(ctx context.Context, folders Folders) error
+	// Get all folders.
+	GetFolders(ctx context.Context) (Folders, error)
 }
 
 // New is a fabric method for create storage with provided type.
@@ -61,7 +65,20 @@ type Item struct {
 	Type string
 	Hash []byte
 	Data []byte
+	// Zero folder's ID means item without folder
+	FolderID int64
+	// Encrypted item's tags
+	Tags []byte
 }
 
 // Items is a slice of pointers to items.
 type Items []*Item
+
+// Folder represents storage view of folder, folder's name stores encrypted.
+type Folder struct {
+	ID   int64
+	Name []byte
+}
+
+// Folders is a slice of pointers to folders.
+type Folders []*Folder
@@ -19,9 +19,12 @@ const (
 	pageOTPCode          = "OTP code page"
 	pageInitSettings     = "Settings page"
 	pageActiveSettings   = "Active Settings page"
+	pageChangePassword   = "Change password page"
 	pageAboutHelp        = "About Help page"
 	pageItemBrowser      = "Item browser"
 	pageItem             = "Item page"
+	pageItemHistory      = "Item history page"
+	pageTrash            = "Trash page"
 	pageUploadFile       = "Upload File"
 	pageDownloadFile     = "Download File"
 	pageCfBrowser        = "CF Browser"
@@ -29,12 +32,42 @@ const (
 	pageCreateCf         = "Create CF page"
 	pageURIBrowser       = "URI Browser"
 	pageURICreateUpdate  = "URI Create Update"
-
-	modalQuit     = "Quit modal"
-	modalItemType = "Item Type Modal"
-	modalCFType   = "CF type modal"
+	pageFolder           = "Folder page"
+	pageMoveItem         = "Move item page"
+	pageShareItem        = "Share item page"
+	pageSharedItem       = "Shared item page"
+	pageOrganizations    = "Organizations page"
+	pageOrganization     = "Organization page"
+	pageOrgVault         = "Organization vault page"
+	pageOrgMembers       = "Organization members page"
+	pageOrgItem          = "Organization item page"
+	pageEmergency        = "Emergency access page"
+	pageEmergencyContact = "Emergency contact page"
+	pageEmergencyVault   = "Emergency vault page"
+	pageEmergencyItem    = "Emergency item page"
+	pageTakeover         = "Takeover account page"
+	pageActivity         = "Activity page"
+	pageSessions         = "Sessions page"
+
+	modalQuit      = "Quit modal"
+	modalItemType  = "Item Type Modal"
+	modalCFType    = "CF type modal"
+	modalTrash     = "Trash item modal"
+	modalRotate    = "Rotate encryption key modal"
+	modalFolder    = "Delete folder modal"
+	modalShare     = "Revoke share modal"
+	modalOrg       = "Organization modal"
+	modalMember    = "Organization member modal"
+	modalEmergency = "Emergency access modal"
+	modalSession   = "Session modal"
 )
 
+// allTagsOption is vault browser's tag filter option for displaying all items.
+const allTagsOption = "All tags"
+
+// sharedFolderName is vault browser's folder name for items shared with user.
+const sharedFolderName = "Shared with me"
+
 // Primitives styles
 //
 //nolint:gochecknoglobals
@@ -62,6 +95,10 @@ type Gtui struct {
 	// Flag indicates that no configuration was found. Used when gtui starts for displayng
 	// setting page.
 	noConfigFile bool
+	// Tag currently used for filtering items in vault browser, empty for all items.
+	tagFilter string
+	// Stops countdown of login's lockout, nil if countdown isn't running.
+	stopLockoutCountdown context.CancelFunc
 }
 
 var _ UI = (*Gtui)(nil)
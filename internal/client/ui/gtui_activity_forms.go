@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// displayActivityPage displays page listed user's audit events from newest to oldest.
+//
+// Events are loaded by pages, next page is appended to list by "Load more" button.
+func (g *Gtui) displayActivityPage(ctx context.Context) {
+	selfPage := pageActivity
+
+	list := tview.NewList()
+	list.SetMainTextStyle(tcell.StyleDefault.Bold(true))
+	list.SetSecondaryTextStyle(tcell.StyleDefault.Italic(true)).
+		SetSecondaryTextColor(tcell.ColorDarkGreen)
+
+	list.SetDoneFunc(func() {
+		g.pages.RemovePage(selfPage)
+	})
+	list.SetBorder(true).SetTitle("  Activity ").SetTitleAlign(tview.AlignLeft).
+		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	var pageToken int64
+
+	loadMore := func() bool {
+		events, next, err := g.client.GetAuditEvents(ctx, pageToken)
+		if err != nil {
+			if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+				return false
+			}
+
+			g.setStatus(err.Error(), 5)
+
+			return false
+		}
+
+		r := rune(62)
+		for _, event := range events {
+			list.AddItem(auditEventText(event), auditEventDetails(event), r, nil)
+		}
+
+		pageToken = next
+
+		return true
+	}
+
+	if !loadMore() {
+		return
+	}
+
+	buttons := tview.NewForm().
+		AddButton("Load more", func() {
+			if pageToken == 0 {
+				g.setStatus("no more events", 2)
+				return
+			}
+
+			loadMore()
+		}).
+		AddButton("Back to menu", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	list.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(list, list, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+	if list.GetItemCount() == 0 {
+		g.setStatus("no activity yet", 3)
+	}
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// auditEventText returns audit event's main text: time and event's type.
+func auditEventText(event *pb.AuditEvent) string {
+	return fmt.Sprintf("%s  %s", event.GetCreated().AsTime().Local().Format(time.RFC822),
+		common.AuditEventText(event.Event))
+}
+
+// auditEventDetails returns audit event's details: method, item and client's address.
+func auditEventDetails(event *pb.AuditEvent) string {
+	details := event.Method
+
+	if event.ItemId != nil {
+		details += fmt.Sprintf(", item #%d", event.GetItemId())
+	}
+
+	if event.ClientIp != "" {
+		details += fmt.Sprintf(", from %s", event.ClientIp)
+	}
+
+	return details
+}
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/api"
 	"github.com/gdamore/tcell/v2"
@@ -28,6 +30,34 @@ func (g *Gtui) bgUpdateSyncStatus(ctx context.Context, ch <-chan string) {
 	}
 }
 
+// bgLoginLockoutCountdown is a background function, which displays remaining lockout period of
+// login in status field every second until lockout expires or context is done.
+func (g *Gtui) bgLoginLockoutCountdown(ctx context.Context, until time.Time) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		remaining := time.Until(until).Round(time.Second)
+		if remaining <= 0 {
+			g.app.QueueUpdateDraw(func() {
+				g.setStatus("login is unlocked, please try again", 3)
+			})
+
+			return
+		}
+
+		g.app.QueueUpdateDraw(func() {
+			g.status.SetText(fmt.Sprintf("too many failed login attempts, retry in %s", remaining))
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // clearSyncStatus is a helper function for clearing sync status field.
 func (g *Gtui) clearSyncStatus() {
 	g.syncStatus.SetBorder(true).SetBorderColor(tcell.ColorDarkGreen)
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/client/api"
+)
+
+// inviteEmergencyContact invites user as trusted contact.
+func (g *Gtui) inviteEmergencyContact(ctx context.Context, username, mode string, waitDays int, pageName string) {
+	if err := g.client.InviteEmergencyContact(ctx, username, mode, waitDays); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayEmergencyPage(ctx)
+	g.setStatus(fmt.Sprintf("'%s' was invited as trusted contact", username), 5)
+}
+
+// changeEmergencyAccess performs action with emergency access and refreshes emergency access page.
+func (g *Gtui) changeEmergencyAccess(ctx context.Context,
+	action func(context.Context, *api.EmergencyAccess) error, access *api.EmergencyAccess, message string) {
+	if err := action(ctx, access); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageEmergency) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.displayEmergencyPage(ctx)
+	g.setStatus(message, 5)
+}
+
+// takeoverAccount sets new password and secret key of grantor's account.
+func (g *Gtui) takeoverAccount(ctx context.Context, access *api.EmergencyAccess, change *api.PasswordChange,
+	pageName string) {
+	if change.NewPassword == "" || change.NewSecretKey == "" {
+		g.setStatus("password and secret key can not be empty", 5)
+		return
+	}
+
+	if change.NewPassword != change.NewPasswordConfirm {
+		g.setStatus("passwords do not match", 5)
+		return
+	}
+
+	g.setStatus("Taking over account...", 0)
+
+	if err := g.client.TakeoverAccount(ctx, access, change.NewPassword, change.NewSecretKey); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.pages.RemovePage(pageEmergencyVault)
+	g.displayEmergencyPage(ctx)
+	g.setStatus(fmt.Sprintf("account '%s' was taken over, use new password and secret key to login",
+		access.Grantor), 5)
+}
@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/artfuldog/gophkeeper/internal/client/api"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// defaultEmergencyWaitDays is a default waiting period for new trusted contact.
+const defaultEmergencyWaitDays = 7
+
+// displayEmergencyPage displays page listed user's trusted contacts and emergency accesses
+// granted to user.
+//
+// Selecting access offers actions available in access's status.
+func (g *Gtui) displayEmergencyPage(ctx context.Context) {
+	selfPage := pageEmergency
+
+	granted, received, err := g.client.GetEmergencyAccesses(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	list := tview.NewList()
+
+	r := rune(62)
+	for _, access := range granted {
+		list.AddItem(fmt.Sprintf("%s (your trusted contact)", access.Grantee), emergencyStatusText(access), r, nil)
+	}
+
+	for _, access := range received {
+		list.AddItem(fmt.Sprintf("%s (trusts you)", access.Grantor), emergencyStatusText(access), r, nil)
+	}
+
+	list.SetMainTextStyle(tcell.StyleDefault.Bold(true))
+
+	list.SetSecondaryTextStyle(tcell.StyleDefault.Italic(true)).
+		SetSecondaryTextColor(tcell.ColorDarkGreen)
+
+	list.SetDoneFunc(func() {
+		g.pages.RemovePage(selfPage)
+	})
+
+	list.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		if index < len(granted) {
+			g.displayGrantedEmergencyModal(ctx, granted[index])
+			return
+		}
+
+		g.displayReceivedEmergencyModal(ctx, received[index-len(granted)])
+	})
+	list.SetBorder(true).SetTitle("  Emergency access ").SetTitleAlign(tview.AlignLeft).
+		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	buttons := tview.NewForm().
+		AddButton("Invite trusted contact", func() { g.displayEmergencyContactDialog(ctx) }).
+		AddButton("Back to menu", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	list.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(list, list, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+	if len(granted)+len(received) == 0 {
+		g.setStatus("you have no trusted contacts and nobody trusts you", 3)
+	}
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// displayEmergencyContactDialog displays dialog for invite new trusted contact.
+func (g *Gtui) displayEmergencyContactDialog(ctx context.Context) {
+	selfPage := pageEmergencyContact
+
+	var username string
+
+	modes := common.ListEmergencyModes()
+	mode := common.EmergencyModeView
+	waitDays := strconv.Itoa(defaultEmergencyWaitDays)
+
+	form := tview.NewForm().
+		AddInputField("Username", username, 30, nil, func(v string) {
+			username = v
+		}).
+		AddDropDown("Mode", modes, 0, func(option string, optionIndex int) {
+			mode = option
+		}).
+		AddInputField("Waiting period, days", waitDays, 5, tview.InputFieldInteger, func(v string) {
+			waitDays = v
+		}).
+		AddButton("Invite", func() {
+			if username == "" {
+				g.setStatus("username is required", 3)
+				return
+			}
+
+			days, err := strconv.Atoi(waitDays)
+			if err != nil || days <= 0 {
+				g.setStatus("waiting period must be positive number of days", 3)
+				return
+			}
+
+			g.inviteEmergencyContact(ctx, username, mode, days, selfPage)
+		}).
+		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) })
+
+	form.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
+		SetTitle(" Invite trusted contact ").SetTitleAlign(tview.AlignCenter)
+	form.SetButtonsAlign(tview.AlignCenter).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive)
+	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	grid := tview.NewGrid().
+		SetColumns(0, 60, 0).SetRows(0, 11, 0).
+		AddItem(form, 1, 1, 1, 1, 0, 0, true)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// displayEmergencyVaultPage displays page listed grantor's items available by approved access.
+//
+// Items are displayed in read-only mode.
+func (g *Gtui) displayEmergencyVaultPage(ctx context.Context, access *api.EmergencyAccess) {
+	selfPage := pageEmergencyVault
+
+	items, err := g.client.GetEmergencyVault(ctx, access)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	browser := tview.NewList()
+
+	r := rune(62)
+	for _, item := range items {
+		browser.AddItem(item.Name, common.ItemTypeText(item.Type), r, nil)
+	}
+
+	browser.SetMainTextStyle(tcell.StyleDefault.Bold(true))
+
+	browser.SetSecondaryTextStyle(tcell.StyleDefault.Italic(true)).
+		SetSecondaryTextColor(tcell.ColorDarkGreen)
+
+	browser.SetDoneFunc(func() {
+		g.pages.RemovePage(selfPage)
+	})
+
+	browser.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		g.displayEmergencyItemPage(access, items[index], g.config.GetShowSensitive())
+	})
+	browser.SetBorder(true).SetTitle(fmt.Sprintf("  %s's vault (read-only) ", access.Grantor)).
+		SetTitleAlign(tview.AlignLeft).SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	buttons := tview.NewForm()
+
+	if access.Takeoverable() {
+		buttons.AddButton("Take over account", func() { g.displayTakeoverPage(ctx, access) })
+	}
+
+	buttons.AddButton("Back", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	browser.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(browser, browser, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(browser, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+	if len(items) == 0 {
+		g.setStatus(fmt.Sprintf("%s's vault is empty", access.Grantor), 3)
+	}
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// displayEmergencyItemPage displays grantor's item in read-only mode.
+func (g *Gtui) displayEmergencyItemPage(access *api.EmergencyAccess, item *api.Item, showSensitive bool) {
+	selfPage := pageEmergencyItem
+
+	itemForm := g.drawItemVersionForm(item, showSensitive)
+	itemForm.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	info := tview.NewForm().SetItemPadding(0).
+		AddTextView("Type", common.ItemTypeText(item.Type), 40, 1, true, false).
+		AddTextView("Owner", access.Grantor, 40, 1, true, false).
+		AddTextView("Updated", item.Updated.String(), 40, 1, true, false)
+	info.SetBorderPadding(0, 0, 0, 0)
+
+	buttons := tview.NewForm().
+		AddButton("Back to vault", func() { g.pages.RemovePage(selfPage) })
+
+	if showSensitive {
+		buttons.AddButton("Hide sensitive", func() { g.displayEmergencyItemPage(access, item, false) })
+	} else {
+		buttons.AddButton("Show sensitive", func() { g.displayEmergencyItemPage(access, item, true) })
+	}
+
+	buttons.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+	buttons.SetButtonsAlign(tview.AlignLeft).SetBorderPadding(0, 0, 0, 0)
+	buttons.SetButtonStyle(styleCtrButtonsInactive).SetButtonActivatedStyle(styleCtrButtonsActive)
+
+	itemForm.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(itemForm, itemForm, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	grid := tview.NewGrid().
+		SetRows(0, 1).SetColumns(0, 0).
+		SetBorders(true).SetBordersColor(tcell.ColorLightSkyBlue).
+		AddItem(itemForm, 0, 0, 1, 1, 0, 0, true).
+		AddItem(info, 0, 1, 1, 1, 0, 0, false).
+		AddItem(buttons, 1, 0, 1, 2, 0, 0, false)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// displayTakeoverPage displays page for set new password and secret key of grantor's account.
+func (g *Gtui) displayTakeoverPage(ctx context.Context, access *api.EmergencyAccess) {
+	selfPage := pageTakeover
+	change := new(api.PasswordChange)
+
+	form := tview.NewForm().
+		AddPasswordField("New password", change.NewPassword, 25, '*', func(v string) {
+			change.NewPassword = v
+		}).
+		AddPasswordField("Confirm new password", change.NewPasswordConfirm, 25, '*', func(v string) {
+			change.NewPasswordConfirm = v
+		}).
+		AddInputField("New secret key", change.NewSecretKey, 25, nil, func(v string) {
+			change.NewSecretKey = v
+		}).
+		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) }).
+		AddButton("Take over", func() { g.takeoverAccount(ctx, access, change, selfPage) })
+
+	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Take over account '%s' (2FA will be disabled) ", access.Grantor)).
+		SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive)
+
+	g.pages.AddPage(selfPage, form, true, true)
+}
+
+// emergencyStatusText returns text representation of emergency access's state.
+func emergencyStatusText(access *api.EmergencyAccess) string {
+	text := fmt.Sprintf("%s, %s mode, waiting period %d days", access.Status, access.Mode, access.WaitDays)
+
+	if access.Status == common.EmergencyStatusRequested && access.ApproveAfter != nil {
+		text += fmt.Sprintf(", approved automatically after %s", access.ApproveAfter.Format("2006-01-02 15:04"))
+	}
+
+	return text
+}
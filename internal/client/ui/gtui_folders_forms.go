@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/artfuldog/gophkeeper/internal/client/api"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// displayFolderDialog displays dialog for create new or rename existing folder.
+func (g *Gtui) displayFolderDialog(ctx context.Context, folder *api.Folder) {
+	selfPage := pageFolder
+
+	title := " New folder "
+	if folder.ID > 0 {
+		title = " Rename folder "
+	}
+
+	name := folder.Name
+
+	form := tview.NewForm().
+		AddInputField("Name", name, 40, nil, func(v string) {
+			name = v
+		}).
+		AddButton("Save", func() {
+			if name == "" {
+				g.setStatus("folder name is required", 3)
+				return
+			}
+
+			g.saveFolder(ctx, &api.Folder{ID: folder.ID, Name: name}, selfPage)
+		}).
+		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) })
+
+	form.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
+		SetTitle(title).SetTitleAlign(tview.AlignCenter)
+	form.SetButtonsAlign(tview.AlignCenter).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive)
+	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	grid := tview.NewGrid().
+		SetColumns(0, 56, 0).SetRows(0, 7, 0).
+		AddItem(form, 1, 1, 1, 1, 0, 0, true)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// displayMoveItemDialog displays dialog with user's folders for moving item.
+//
+// Item is saved together with all item's changes and item's page is closed.
+func (g *Gtui) displayMoveItemDialog(ctx context.Context, item *api.Item, parentPage string) {
+	selfPage := pageMoveItem
+
+	folders, err := g.client.GetFolders(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, parentPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	folders = append([]*api.Folder{{Name: "No folder"}}, folders...)
+
+	list := tview.NewList().ShowSecondaryText(false)
+
+	for i, folder := range folders {
+		list.AddItem(folder.Name, "", 0, nil)
+
+		if folder.ID == item.FolderID {
+			list.SetCurrentItem(i)
+		}
+	}
+
+	list.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		g.moveItem(ctx, item, folders[index], selfPage, parentPage)
+	})
+	list.SetDoneFunc(func() { g.pages.RemovePage(selfPage) })
+
+	list.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
+		SetTitle(" Move to folder ").SetTitleAlign(tview.AlignCenter).SetBorderPadding(1, 1, 2, 2)
+
+	grid := tview.NewGrid().
+		SetColumns(0, 50, 0).SetRows(0, 14, 0).
+		AddItem(list, 1, 1, 1, 1, 0, 0, true)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
@@ -3,9 +3,13 @@ package ui
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/api"
+	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -85,3 +89,69 @@ func (g *Gtui) captureAndSetFocus(next tview.Primitive, prev tview.Primitive,
 func checkFieldInt(textToCheck string, lastChar rune) bool {
 	return !(lastChar < '0' || lastChar > '9')
 }
+
+// formatUsage returns text representation of used resource and its limit.
+//
+// Zero limit is shown as unlimited.
+func formatUsage(used, limit int64, format func(int64) string) string {
+	if limit == 0 {
+		return fmt.Sprintf("%s (unlimited)", format(used))
+	}
+
+	return fmt.Sprintf("%s of %s", format(used), format(limit))
+}
+
+// formatCount returns text representation of number of entries.
+func formatCount(n int64) string {
+	return fmt.Sprint(n)
+}
+
+// formatSize returns text representation of size in megabytes.
+func formatSize(size int64) string {
+	return fmt.Sprintf("%.2f Mb", float64(size)/1024/1024)
+}
+
+// formatProgress returns text representation of data transfer's progress.
+func formatProgress(done, total int64) string {
+	if total <= 0 {
+		return formatSize(done)
+	}
+
+	return fmt.Sprintf("%s of %s (%d%%)", formatSize(done), formatSize(total), done*100/total)
+}
+
+// formatTagChips returns text representation of tags as colored chips.
+func formatTagChips(tags api.Tags) string {
+	chips := make([]string, len(tags))
+	for i, tag := range tags {
+		chips[i] = fmt.Sprintf("[black:lightskyblue] %s [-:-]", tview.Escape(tag))
+	}
+
+	return strings.Join(chips, " ")
+}
+
+// collectTags returns sorted list of unique tags of provided user's and shared items.
+func collectTags(items []*pb.ItemShort, sharedItems []*api.SharedItem) []string {
+	unique := make(map[string]struct{})
+
+	for _, item := range items {
+		for _, tag := range api.NewTags(item.Tags) {
+			unique[tag] = struct{}{}
+		}
+	}
+
+	for _, shared := range sharedItems {
+		for _, tag := range shared.Item.Tags {
+			unique[tag] = struct{}{}
+		}
+	}
+
+	tags := make([]string, 0, len(unique))
+	for tag := range unique {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
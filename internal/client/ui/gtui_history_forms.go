@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/client/api"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// displayItemHistory displays page listed item's previous versions from newest to oldest.
+//
+// Versions are decrypted by client, selected version can be restored.
+func (g *Gtui) displayItemHistory(ctx context.Context, item *api.Item, parentPage string, showSensitive bool) {
+	selfPage := pageItemHistory
+
+	versions, err := g.client.GetItemVersions(ctx, item)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, parentPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	if len(versions) == 0 {
+		g.setStatus(fmt.Sprintf("item '%s' has no previous versions", item.Name), 3)
+		return
+	}
+
+	browser := tview.NewList()
+
+	r := rune(62)
+	for _, v := range versions {
+		browser.AddItem(fmt.Sprintf("Version %d", v.Version), v.Item.Updated.Local().Format(time.RFC822), r, nil)
+	}
+
+	browser.SetMainTextStyle(tcell.StyleDefault.Bold(true))
+	browser.SetSecondaryTextStyle(tcell.StyleDefault.Italic(true)).
+		SetSecondaryTextColor(tcell.ColorDarkGreen)
+
+	title := fmt.Sprintf("  History of '%s'  ", item.Name)
+	browser.SetBorder(true).SetTitle(title).SetTitleAlign(tview.AlignLeft).
+		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	browser.SetDoneFunc(func() { g.pages.RemovePage(selfPage) })
+
+	details := tview.NewFlex()
+	showVersion := func(index int) {
+		details.Clear()
+		details.AddItem(g.drawItemVersionForm(versions[index].Item, showSensitive), 0, 1, false)
+	}
+
+	showVersion(0)
+	browser.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		showVersion(index)
+	})
+
+	buttons := tview.NewForm().
+		AddButton("Restore", func() {
+			version := versions[browser.GetCurrentItem()].Version
+			g.restoreItemVersion(ctx, item, version, selfPage, parentPage)
+		})
+
+	if showSensitive {
+		buttons.AddButton("Hide sensitive", func() { g.displayItemHistory(ctx, item, parentPage, false) })
+	} else {
+		buttons.AddButton("Show sensitive", func() { g.displayItemHistory(ctx, item, parentPage, true) })
+	}
+
+	buttons.AddButton("Back to item", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	browser.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(browser, browser, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	grid := tview.NewGrid().
+		SetRows(0, 1).SetColumns(40, 0).
+		SetBorders(true).SetBordersColor(tcell.ColorLightSkyBlue).
+		AddItem(browser, 0, 0, 1, 1, 0, 0, true).
+		AddItem(details, 0, 1, 1, 1, 0, 0, false).
+		AddItem(buttons, 1, 0, 1, 2, 0, 0, false)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// drawItemVersionForm creates read-only form for displaying item's previous version.
+func (g *Gtui) drawItemVersionForm(item *api.Item, showSensitive bool) *tview.Form {
+	form := tview.NewForm().SetItemPadding(0).
+		AddTextView("Name", item.Name, 40, 1, true, false).
+		AddTextView("Reprompt", fmt.Sprint(item.Reprompt), 40, 1, true, false)
+
+	mask := func(value string) string {
+		if showSensitive || value == "" {
+			return value
+		}
+
+		return common.MaskAll(8)
+	}
+
+	switch item.Type {
+	case common.ItemTypeLogin:
+		secret := item.GetLogin()
+		form.
+			AddTextView("Login", secret.Username, 40, 1, true, false).
+			AddTextView("Password", mask(secret.Password), 40, 1, true, false).
+			AddTextView("Authenticator key", mask(secret.Authkey), 40, 1, true, false)
+	case common.ItemTypeCard:
+		secret := item.GetCard()
+
+		if showSensitive {
+			form.
+				AddTextView("Card number", secret.Number, 40, 1, true, false).
+				AddTextView("Cardholder", secret.ChName, 40, 1, true, false).
+				AddTextView("Expiration", fmt.Sprintf("%02d/%02d", secret.ExpMonth, secret.ExpYear), 40, 1, true, false).
+				AddTextView("CVV", fmt.Sprint(secret.Cvv), 40, 1, true, false)
+		} else {
+			form.
+				AddTextView("Card number", common.MaskLeft(secret.Number, 4), 40, 1, true, false).
+				AddTextView("Cardholder", common.MaskLeft(secret.ChName, 5), 40, 1, true, false).
+				AddTextView("Expiration", common.MaskAll(5), 40, 1, true, false).
+				AddTextView("CVV", common.MaskAll(3), 40, 1, true, false)
+		}
+	case common.ItemTypeSecData:
+		secret := item.GetSecData()
+		form.AddTextView("Data", fmt.Sprintf("%d bytes", secret.GetSize()), 40, 1, true, false)
+	}
+
+	form.AddTextView("Notes", item.Notes, 40, 3, true, false)
+
+	for _, cf := range item.CustomFields {
+		switch cf.Type {
+		case common.CfTypeHidden:
+			form.AddTextView(cf.Name, mask(cf.ValueStr), 40, 1, true, false)
+		case common.CfTypeBool:
+			form.AddTextView(cf.Name, fmt.Sprint(cf.ValueBool), 40, 1, true, false)
+		default:
+			form.AddTextView(cf.Name, cf.ValueStr, 40, 1, true, false)
+		}
+	}
+
+	for i, uri := range item.URIs {
+		form.AddTextView(fmt.Sprintf("URI #%d", i), uri.URI, 40, 1, true, false)
+	}
+
+	form.SetBorderPadding(0, 0, 1, 1)
+
+	return form
+}
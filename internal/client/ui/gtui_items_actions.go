@@ -6,6 +6,7 @@ import (
 
 	"github.com/artfuldog/gophkeeper/internal/client/api"
 	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
 )
 
 // saveItem saves item.
@@ -38,5 +39,142 @@ func (g *Gtui) deleteItem(ctx context.Context, item *api.Item, pageName string)
 
 	g.pages.RemovePage(pageName)
 	g.displayItemBrowser(ctx)
-	g.setStatus(fmt.Sprintf("item '%s' (%s) was deleted", item.Name, common.ItemTypeText(item.Type)), 5)
+	g.setStatus(fmt.Sprintf("item '%s' (%s) was moved to trash", item.Name, common.ItemTypeText(item.Type)), 5)
+}
+
+// restoreItemVersion restores item's state from previous version.
+func (g *Gtui) restoreItemVersion(ctx context.Context, item *api.Item, version int64, pageName, parentPage string) {
+	if err := g.client.RestoreItemVersion(ctx, item, version); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.pages.RemovePage(parentPage)
+	g.displayItemBrowser(ctx)
+	g.setStatus(fmt.Sprintf("item '%s' (%s) was restored from version %d",
+		item.Name, common.ItemTypeText(item.Type), version), 5)
+}
+
+// restoreItem restores item from trash.
+func (g *Gtui) restoreItem(ctx context.Context, item *pb.ItemShort, pageName string) {
+	if err := g.client.RestoreItem(ctx, item.Id); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayItemBrowser(ctx)
+	g.setStatus(fmt.Sprintf("item '%s' (%s) was restored from trash", item.Name, common.ItemTypeText(item.Type)), 5)
+}
+
+// purgeItem permanently deletes trashed item.
+func (g *Gtui) purgeItem(ctx context.Context, item *pb.ItemShort, pageName string) {
+	if err := g.client.PurgeItem(ctx, item.Id); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayTrashBrowser(ctx)
+	g.setStatus(fmt.Sprintf("item '%s' (%s) was purged", item.Name, common.ItemTypeText(item.Type)), 5)
+}
+
+// saveFolder creates or renames folder.
+func (g *Gtui) saveFolder(ctx context.Context, folder *api.Folder, pageName string) {
+	if err := g.client.SaveFolder(ctx, folder); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 3)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayItemBrowser(ctx)
+	g.setStatus(fmt.Sprintf("folder '%s' was saved", folder.Name), 3)
+}
+
+// deleteFolder deletes folder, folder's items are kept without folder.
+func (g *Gtui) deleteFolder(ctx context.Context, folder *api.Folder, pageName string) {
+	if err := g.client.DeleteFolder(ctx, folder); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.displayItemBrowser(ctx)
+	g.setStatus(fmt.Sprintf("folder '%s' was deleted", folder.Name), 5)
+}
+
+// moveItem moves item to folder and saves all item's changes.
+func (g *Gtui) moveItem(ctx context.Context, item *api.Item, folder *api.Folder, pageName, parentPage string) {
+	if err := g.client.MoveItem(ctx, item, folder.ID); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.pages.RemovePage(parentPage)
+	g.displayItemBrowser(ctx)
+	g.setStatus(fmt.Sprintf("item '%s' (%s) was moved to '%s'",
+		item.Name, common.ItemTypeText(item.Type), folder.Name), 5)
+}
+
+// shareItem shares item with another user.
+func (g *Gtui) shareItem(ctx context.Context, item *api.Item, recipient string, pageName string) {
+	if err := g.client.ShareItem(ctx, item, recipient); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.setStatus(fmt.Sprintf("item '%s' was shared with '%s'", item.Name, recipient), 5)
+}
+
+// revokeShare revokes share of item.
+func (g *Gtui) revokeShare(ctx context.Context, item *api.Item, share *api.Share, pageName string) {
+	if err := g.client.RevokeShare(ctx, share); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.setStatus(fmt.Sprintf("share of item '%s' with '%s' was revoked", item.Name, share.Recipient), 5)
 }
@@ -2,18 +2,28 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/api"
 	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-// displayItemBrowser displays page listed all user's items sorted alphabetically.
+// displayItemBrowser displays page listed all user's items in collapsible folders' tree.
+//
+// Folders are sorted alphabetically and followed by items without folder, items in every
+// folder are sorted alphabetically too. Selecting folder collapses or expands it.
+//
+// Items could be filtered by tag, while filter is active folders without matching items are hidden.
+// Items shared with user by other users are listed in separate folder.
 func (g *Gtui) displayItemBrowser(ctx context.Context) {
 	selfPage := pageItemBrowser
 
@@ -28,11 +38,201 @@ func (g *Gtui) displayItemBrowser(ctx context.Context) {
 		return
 	}
 
+	folders, err := g.client.GetFolders(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	// Shared items are optional part of vault, failure of their fetching doesn't block browser.
+	sharedItems, err := g.client.GetSharedItems(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		if !errors.Is(err, api.ErrKeyPairMissed) {
+			g.setStatus(fmt.Sprintf("failed to get shared items: %s", err.Error()), 5)
+		}
+	}
+
+	root := tview.NewTreeNode("Vault")
+	browser := tview.NewTreeView().SetRoot(root).SetTopLevel(1).SetGraphicsColor(tcell.ColorDarkGreen)
+
+	fillTree := func() {
+		root.ClearChildren()
+
+		folderNodes := make(map[int64]*tview.TreeNode, len(folders))
+
+		for _, folder := range folders {
+			node := tview.NewTreeNode(folder.Name + "/").SetReference(folder).
+				SetColor(tcell.ColorLightSkyBlue).SetSelectable(true)
+			folderNodes[folder.ID] = node
+			root.AddChild(node)
+		}
+
+		for _, item := range items {
+			if g.tagFilter != "" && !api.NewTags(item.Tags).Contains(g.tagFilter) {
+				continue
+			}
+
+			node := tview.NewTreeNode(fmt.Sprintf("%s (%s)", item.Name, common.ItemTypeText(item.Type))).
+				SetReference(item).SetSelectable(true)
+
+			if folderNode, ok := folderNodes[item.GetFolderId()]; ok {
+				folderNode.AddChild(node)
+				continue
+			}
+
+			root.AddChild(node)
+		}
+
+		if g.tagFilter != "" {
+			for _, node := range folderNodes {
+				if len(node.GetChildren()) == 0 {
+					root.RemoveChild(node)
+				}
+			}
+		}
+
+		sharedNode := tview.NewTreeNode(sharedFolderName + "/").SetReference(sharedItems).
+			SetColor(tcell.ColorLightGreen).SetSelectable(true)
+
+		for _, shared := range sharedItems {
+			if g.tagFilter != "" && !shared.Item.Tags.Contains(g.tagFilter) {
+				continue
+			}
+
+			sharedNode.AddChild(tview.NewTreeNode(fmt.Sprintf("%s (%s)", shared.Item.Name,
+				common.ItemTypeText(shared.Item.Type))).SetReference(shared).SetSelectable(true))
+		}
+
+		if len(sharedNode.GetChildren()) > 0 {
+			root.AddChild(sharedNode)
+		}
+
+		browser.SetCurrentNode(nil)
+		if children := root.GetChildren(); len(children) > 0 {
+			browser.SetCurrentNode(children[0])
+		}
+	}
+
+	tags := collectTags(items, sharedItems)
+	if !common.Contains(g.tagFilter, tags) {
+		g.tagFilter = ""
+	}
+
+	tagOptions := append([]string{allTagsOption}, tags...)
+	curTagIndex := 0
+
+	for i, tag := range tags {
+		if tag == g.tagFilter {
+			curTagIndex = i + 1
+		}
+	}
+
+	// Tree is filled by drop down's handler, which is called on setting initial option.
+	filter := tview.NewForm().SetHorizontal(true).
+		AddDropDown("Tag filter", tagOptions, curTagIndex, func(option string, optionIndex int) {
+			g.tagFilter = ""
+			if optionIndex > 0 {
+				g.tagFilter = option
+			}
+
+			fillTree()
+		})
+	filter.SetBorderPadding(0, 0, 2, 2)
+
+	browser.SetDoneFunc(func(key tcell.Key) {
+		g.pages.RemovePage(selfPage)
+	})
+
+	browser.SetSelectedFunc(func(node *tview.TreeNode) {
+		switch ref := node.GetReference().(type) {
+		case *api.Folder, []*api.SharedItem:
+			node.SetExpanded(!node.IsExpanded())
+		case *pb.ItemShort:
+			g.displayEditItemPage(ctx, ref)
+		case *api.SharedItem:
+			g.displaySharedItemPage(ctx, ref, g.config.GetShowSensitive())
+		}
+	})
+	browser.SetBorder(true).SetTitle("  Vault ").SetTitleAlign(tview.AlignLeft).
+		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	selectedFolder := func() *api.Folder {
+		if node := browser.GetCurrentNode(); node != nil {
+			if folder, ok := node.GetReference().(*api.Folder); ok {
+				return folder
+			}
+		}
+
+		g.setStatus("please select folder", 3)
+
+		return nil
+	}
+
+	buttons := tview.NewForm().
+		AddButton("Add new Item", func() { g.displayItemCreateModal(ctx, nil) }).
+		AddButton("New folder", func() { g.displayFolderDialog(ctx, &api.Folder{}) }).
+		AddButton("Rename folder", func() {
+			if folder := selectedFolder(); folder != nil {
+				g.displayFolderDialog(ctx, folder)
+			}
+		}).
+		AddButton("Delete folder", func() {
+			if folder := selectedFolder(); folder != nil {
+				g.displayDeleteFolderModal(ctx, folder)
+			}
+		}).
+		AddButton("Trash", func() { g.displayTrashBrowser(ctx) }).
+		AddButton("Back to menu", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	browser.SetInputCapture(g.captureAndSetFocus(buttons, filter, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(filter, browser, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	filter.SetInputCapture(g.captureAndSetFocus(browser, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(filter, 1, 1, false).AddItem(browser, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// displayTrashBrowser displays page listed trashed items.
+//
+// Selected item can be restored or permanently purged.
+func (g *Gtui) displayTrashBrowser(ctx context.Context) {
+	selfPage := pageTrash
+
+	items, err := g.client.GetTrashList(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
 	browser := tview.NewList()
 
 	r := rune(62)
 	for _, item := range items {
-		browser.AddItem(item.Name, common.ItemTypeText(item.Type), r, nil)
+		secText := common.ItemTypeText(item.Type)
+		if item.Deleted != nil {
+			secText = fmt.Sprintf("%s, deleted %s", secText, item.Deleted.AsTime().Local().Format(time.RFC822))
+		}
+
+		browser.AddItem(item.Name, secText, r, nil)
 	}
 
 	browser.SetMainTextStyle(tcell.StyleDefault.Bold(true))
@@ -44,13 +244,14 @@ func (g *Gtui) displayItemBrowser(ctx context.Context) {
 		g.pages.RemovePage(selfPage)
 	})
 
-	browser.SetSelectedFunc(g.displayEditItemPage(ctx))
-	browser.SetBorder(true).SetTitle("  Vault ").SetTitleAlign(tview.AlignLeft).
+	browser.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		g.displayTrashItemModal(ctx, items[index])
+	})
+	browser.SetBorder(true).SetTitle("  Trash ").SetTitleAlign(tview.AlignLeft).
 		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
 
 	buttons := tview.NewForm().
-		AddButton("Add new Item", func() { g.displayItemCreateModal(ctx) }).
-		AddButton("Back to menu", func() { g.pages.RemovePage(selfPage) })
+		AddButton("Back to vault", func() { g.pages.RemovePage(selfPage) })
 
 	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
 		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
@@ -61,27 +262,29 @@ func (g *Gtui) displayItemBrowser(ctx context.Context) {
 	flex := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(browser, 0, 1, true).AddItem(buttons, 1, 1, false)
 
+	if len(items) == 0 {
+		g.setStatus("trash is empty", 3)
+	}
+
 	g.pages.AddPage(selfPage, flex, true, true)
 }
 
-// displayCreateItemPage displays page for editing existing item.
-func (g *Gtui) displayEditItemPage(ctx context.Context) func(index int, text, secText string, shortcut rune) {
-	return func(index int, text, secText string, shortcut rune) {
-		selfPage := pageItem
-
-		item, err := g.client.GetItem(ctx, text, common.ItemTypeFromText(secText))
-		if err != nil {
-			if g.checkClientErrorsAndStop(ctx, err, selfPage) {
-				return
-			}
-
-			g.setStatus(err.Error(), 5)
+// displayEditItemPage displays page for editing existing item.
+func (g *Gtui) displayEditItemPage(ctx context.Context, itemShort *pb.ItemShort) {
+	selfPage := pageItem
 
+	item, err := g.client.GetItem(ctx, itemShort.Name, itemShort.Type)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
 			return
 		}
 
-		g.pages.AddPage(selfPage, g.drawItemGrid(ctx, item, selfPage, false, g.config.GetShowSensitive()), true, true)
+		g.setStatus(err.Error(), 5)
+
+		return
 	}
+
+	g.pages.AddPage(selfPage, g.drawItemGrid(ctx, item, selfPage, false, g.config.GetShowSensitive()), true, true)
 }
 
 // displayCreateItemPage displays page for create new item.
@@ -103,7 +306,7 @@ func (g *Gtui) drawItemGrid(ctx context.Context, item *api.Item, pageName string
 	newItemFlag bool, showSensitive bool) *tview.Grid {
 
 	grid := tview.NewGrid()
-	itemMainForm := g.drawItemMainForm(item, pageName, newItemFlag, showSensitive)
+	itemMainForm := g.drawItemMainForm(ctx, item, pageName, newItemFlag, showSensitive)
 	itemInfoForm := g.drawItemInfoForm(item, pageName, newItemFlag)
 	itemAdditionsForm := g.drawItemAdditionsForm(ctx, item, pageName, newItemFlag, showSensitive)
 	itemButtonsForm := g.drawItemButtonsForm(ctx, item, pageName, newItemFlag, showSensitive)
@@ -123,7 +326,8 @@ func (g *Gtui) drawItemGrid(ctx context.Context, item *api.Item, pageName string
 }
 
 // drawItemMainForm creates form for displaying item's main editable information.
-func (g *Gtui) drawItemMainForm(item *api.Item, pageName string, newItemFlag bool, showSensitive bool) *tview.Form {
+func (g *Gtui) drawItemMainForm(ctx context.Context, item *api.Item, pageName string,
+	newItemFlag bool, showSensitive bool) *tview.Form {
 	form := tview.NewForm().SetItemPadding(1).
 		AddInputField("Name", item.Name, 40, nil, func(v string) {
 			item.Name = v
@@ -203,21 +407,36 @@ func (g *Gtui) drawItemMainForm(item *api.Item, pageName string, newItemFlag boo
 	case common.ItemTypeSecData:
 		secret := item.GetSecData()
 
+		if newItemFlag {
+			form.AddTextView("Data", "save item to upload file", 40, 1, true, false)
+			break
+		}
+
+		form.AddTextView("Data", formatSize(secret.GetSize()), 40, 1, true, false)
+
 		form.AddButton("Upload", func() {
-			g.displayUploadFileDialog(&secret.Data)
+			g.displayUploadFileDialog(ctx, item, pageName)
 		})
 
-		if !newItemFlag {
-			form.AddButton("Download", func() {
-				if len(secret.Data) == 0 {
-					g.setStatus("no data found", 3)
-					return
-				}
-				g.displayDownloadFileDialog(secret.Data, item.Name)
-			})
-		}
+		form.AddButton("Download", func() {
+			if secret.GetSize() == 0 {
+				g.setStatus("no data found", 3)
+				return
+			}
+			g.displayDownloadFileDialog(ctx, item, pageName)
+		})
 	}
 
+	tagChips := tview.NewTextView().SetLabel("").SetSize(1, 40).SetDynamicColors(true).
+		SetScrollable(false).SetText(formatTagChips(item.Tags))
+
+	form.
+		AddInputField("Tags", strings.Join(item.Tags, ", "), 40, nil, func(v string) {
+			item.Tags = api.ParseTags(v)
+			tagChips.SetText(formatTagChips(item.Tags))
+		}).
+		AddFormItem(tagChips)
+
 	form.AddTextArea("Notes", item.Notes, 40, 0, 0, func(v string) {
 		item.Notes = v
 	})
@@ -329,6 +548,13 @@ func (g Gtui) drawItemButtonsForm(ctx context.Context, item *api.Item,
 	}
 
 	if !newItemFlag {
+		form.AddButton("History", func() { g.displayItemHistory(ctx, item, pageName, showSensitive) })
+		form.AddButton("Move to folder", func() { g.displayMoveItemDialog(ctx, item, pageName) })
+
+		if item.Type != common.ItemTypeSecData {
+			form.AddButton("Share", func() { g.displayShareItemDialog(ctx, item, pageName) })
+		}
+
 		form.AddButton("Delete", func() { g.deleteItem(ctx, item, pageName) })
 	}
 
@@ -339,74 +565,181 @@ func (g Gtui) drawItemButtonsForm(ctx context.Context, item *api.Item,
 	return form
 }
 
-// displayOTPKey displays TOTP Secret key for setup 2-factor authentication.
-func (g *Gtui) displayUploadFileDialog(itemData *[]byte) {
+// displayUploadFileDialog displays dialog for upload file to data item.
+//
+// File is uploaded to server by stream, progress is displayed in dialog. Upload can be canceled,
+// in this case item's data is not changed. After successful upload item is saved together
+// with all item's changes and item's page is closed.
+func (g *Gtui) displayUploadFileDialog(ctx context.Context, item *api.Item, parentPage string) {
 	selfPage := pageUploadFile
 
-	var filepath string
+	var (
+		filepath  string
+		uploading bool
+	)
+
+	uploadCtx, cancel := context.WithCancel(ctx)
+	progress := tview.NewTextView().SetTextAlign(tview.AlignCenter)
+
+	closeDialog := func() {
+		cancel()
+		g.pages.RemovePage(selfPage)
+	}
 
 	form := tview.NewForm().
 		AddInputField("File path", "", 70, nil, func(v string) {
 			filepath = v
 		}).
 		AddButton("Upload", func() {
-			var err error
-			*itemData, err = os.ReadFile(filepath)
+			if uploading {
+				return
+			}
+
+			file, err := os.Open(filepath)
+			if err != nil {
+				closeDialog()
+				g.setStatus(err.Error(), 3)
+				return
+			}
+
+			info, err := file.Stat()
 			if err != nil {
-				g.pages.RemovePage(selfPage)
+				file.Close()
+				closeDialog()
 				g.setStatus(err.Error(), 3)
 				return
 			}
-			g.pages.RemovePage(selfPage)
-			g.setStatus(fmt.Sprintf("File '%s' is successfully uploaded", filepath), 3)
+
+			uploading = true
+			progress.SetText(formatProgress(0, info.Size()))
+
+			go func() {
+				defer file.Close()
+
+				err := g.client.UploadSecretData(uploadCtx, item, file, info.Size(), func(done, total int64) {
+					g.app.QueueUpdateDraw(func() { progress.SetText(formatProgress(done, total)) })
+				})
+
+				g.app.QueueUpdateDraw(func() {
+					closeDialog()
+
+					if err != nil {
+						if g.checkClientErrorsAndStop(ctx, err, parentPage) {
+							return
+						}
+
+						g.setStatus(err.Error(), 5)
+
+						return
+					}
+
+					g.pages.RemovePage(parentPage)
+					g.displayItemBrowser(ctx)
+					g.setStatus(fmt.Sprintf("File '%s' is successfully uploaded", filepath), 3)
+				})
+			}()
 		}).
-		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) })
+		AddButton("Cancel", closeDialog)
 
 	form.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
 		SetTitle(" Upload file ").SetTitleAlign(tview.AlignCenter)
 	form.SetButtonsAlign(tview.AlignCenter).SetButtonActivatedStyle(styleCtrButtonsActive).
 		SetButtonStyle(styleCtrButtonsInactive)
-	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+	form.SetCancelFunc(closeDialog)
+
+	progress.SetBackgroundColor(tcell.ColorDarkBlue)
 
 	grid := tview.NewGrid().
-		SetColumns(0, 60, 0).SetRows(0, 7, 0).
-		AddItem(form, 1, 1, 1, 1, 0, 0, true)
+		SetColumns(0, 60, 0).SetRows(0, 7, 1, 0).
+		AddItem(form, 1, 1, 1, 1, 0, 0, true).
+		AddItem(progress, 2, 1, 1, 1, 0, 0, false)
 
 	g.pages.AddPage(selfPage, grid, true, true)
 }
 
-// displayOTPKey displays TOTP Secret key for setup 2-factor authentication.
-func (g *Gtui) displayDownloadFileDialog(itemData []byte, itemName string) {
+// displayDownloadFileDialog displays dialog for download data item's data to file.
+//
+// Data is downloaded from server by stream, progress is displayed in dialog. In case of
+// failure or cancel partially written file is deleted.
+func (g *Gtui) displayDownloadFileDialog(ctx context.Context, item *api.Item, parentPage string) {
 	selfPage := pageDownloadFile
 
 	path, _ := os.Executable()
-	filepath := fmt.Sprintf("%s/%s", path, itemName)
+	filepath := fmt.Sprintf("%s/%s", path, item.Name)
+
+	var downloading bool
+
+	downloadCtx, cancel := context.WithCancel(ctx)
+	progress := tview.NewTextView().SetTextAlign(tview.AlignCenter)
+
+	closeDialog := func() {
+		cancel()
+		g.pages.RemovePage(selfPage)
+	}
 
 	form := tview.NewForm().
 		AddInputField("File path", filepath, 70, nil, func(v string) {
 			filepath = v
 		}).
 		AddButton("Download", func() {
-			err := os.WriteFile(filepath, itemData, 06)
+			if downloading {
+				return
+			}
+
+			file, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
 			if err != nil {
-				g.pages.RemovePage(selfPage)
+				closeDialog()
 				g.setStatus(err.Error(), 3)
 				return
 			}
-			g.pages.RemovePage(selfPage)
-			g.setStatus(fmt.Sprintf("File is successfully downloaded as '%s'", filepath), 3)
+
+			downloading = true
+			progress.SetText(formatProgress(0, item.GetSecData().GetSize()))
+
+			go func() {
+				err := g.client.DownloadSecretData(downloadCtx, item, file, func(done, total int64) {
+					g.app.QueueUpdateDraw(func() { progress.SetText(formatProgress(done, total)) })
+				})
+
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
+
+				if err != nil {
+					os.Remove(filepath)
+				}
+
+				g.app.QueueUpdateDraw(func() {
+					closeDialog()
+
+					if err != nil {
+						if g.checkClientErrorsAndStop(ctx, err, parentPage) {
+							return
+						}
+
+						g.setStatus(err.Error(), 5)
+
+						return
+					}
+
+					g.setStatus(fmt.Sprintf("File is successfully downloaded as '%s'", filepath), 3)
+				})
+			}()
 		}).
-		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) })
+		AddButton("Cancel", closeDialog)
 
 	form.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
 		SetTitle(" Download file ").SetTitleAlign(tview.AlignCenter)
 	form.SetButtonsAlign(tview.AlignCenter).SetButtonActivatedStyle(styleCtrButtonsActive).
 		SetButtonStyle(styleCtrButtonsInactive)
-	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+	form.SetCancelFunc(closeDialog)
+
+	progress.SetBackgroundColor(tcell.ColorDarkBlue)
 
 	grid := tview.NewGrid().
-		SetColumns(0, 60, 0).SetRows(0, 7, 0).
-		AddItem(form, 1, 1, 1, 1, 0, 0, true)
+		SetColumns(0, 60, 0).SetRows(0, 7, 1, 0).
+		AddItem(form, 1, 1, 1, 1, 0, 0, true).
+		AddItem(progress, 2, 1, 1, 1, 0, 0, false)
 
 	g.pages.AddPage(selfPage, grid, true, true)
 }
@@ -117,7 +117,8 @@ func (g *Gtui) displayInitSettingsPage(ctx context.Context) {
 // displayActiveSettingsPage display current settings.
 //
 // Displayed setting available only for read. For change setting log out required.
-func (g *Gtui) displayActiveSettingsPage() {
+// User's password can be changed from this page.
+func (g *Gtui) displayActiveSettingsPage(ctx context.Context) {
 	selfPage := pageActiveSettings
 
 	form := tview.NewForm().
@@ -136,6 +137,27 @@ func (g *Gtui) displayActiveSettingsPage() {
 		form.AddTextView("CA certificate path", fmt.Sprint(g.config.GetCACert()), 40, 1, true, false)
 	}
 
+	limits, err := g.client.GetServerLimits(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(fmt.Sprintf("failed to get usage: %v", err), 5)
+	} else {
+		form.
+			AddTextView("Items", formatUsage(limits.Items, limits.MaxItems, formatCount), 40, 1, true, false).
+			AddTextView("Storage", formatUsage(limits.Bytes, limits.MaxBytes, formatSize), 40, 1, true, false)
+	}
+
+	form.AddButton("Change password", func() {
+		g.displayChangePasswordPage(ctx)
+	})
+
+	form.AddButton("Rotate encryption key", func() {
+		g.displayRotateKeyModal(ctx)
+	})
+
 	form.AddButton("Back", func() {
 		g.pages.RemovePage(selfPage)
 	})
@@ -155,23 +177,44 @@ func (g *Gtui) displayActiveSettingsPage() {
 func (g *Gtui) displayMainMenu(selfCtx, clientCtx context.Context, stopClient context.CancelFunc) {
 	selfPage := pageMainMenu
 
+	logout := func(statusText string) {
+		stopClient()
+
+		clientClose := time.NewTimer(api.WaitForClosingInterval)
+		select {
+		case <-clientClose.C:
+		case <-g.clientStopCh:
+		}
+
+		g.displayUserLoginPage(selfCtx)
+		g.setStatus(statusText, 2)
+	}
+
 	list := tview.NewList().
 		AddItem("Vault", "Browse Vault", 'v', func() {
 			g.displayItemBrowser(clientCtx)
 		}).
-		AddItem("Setting", "Change configuration", 's', g.displayActiveSettingsPage).
+		AddItem("Organizations", "Browse organizations' vaults", 'o', func() {
+			g.displayOrganizationsPage(clientCtx)
+		}).
+		AddItem("Emergency access", "Manage trusted contacts and emergency accesses", 'e', func() {
+			g.displayEmergencyPage(clientCtx)
+		}).
+		AddItem("Activity", "Review account's activity", 'c', func() {
+			g.displayActivityPage(clientCtx)
+		}).
+		AddItem("Setting", "Change configuration", 's', func() {
+			g.displayActiveSettingsPage(clientCtx)
+		}).
 		AddItem("About/Help", "About this app", 'a', g.displayAboutHelpMenu).
+		AddItem("Sessions", "Review and revoke active sessions", 'n', func() {
+			g.displaySessionsPage(clientCtx, logout)
+		}).
 		AddItem("Log out", "Press to log out", 'l', func() {
-			stopClient()
-
-			clientClose := time.NewTimer(api.WaitForClosingInterval)
-			select {
-			case <-clientClose.C:
-			case <-g.clientStopCh:
-			}
+			// Session may be already expired or revoked, so local logout is performed anyway.
+			_ = g.client.Logout(clientCtx)
 
-			g.displayUserLoginPage(selfCtx)
-			g.setStatus("logged out", 2)
+			logout("logged out")
 		}).
 		AddItem("Quit", "Press to exit", 'q', g.displayQuitModal)
 
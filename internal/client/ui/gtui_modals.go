@@ -6,6 +6,7 @@ import (
 
 	"github.com/artfuldog/gophkeeper/internal/client/api"
 	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/rivo/tview"
 )
 
@@ -32,12 +33,20 @@ func (g *Gtui) displayQuitModal() {
 
 // displayItemCreateModal displays modal window with available item's types,
 // reads user input, creates and switches to item create page.
-func (g *Gtui) displayItemCreateModal(ctx context.Context) {
+//
+// If organization is provided item is created in organization's vault, data items are
+// not available in organizations' vaults.
+func (g *Gtui) displayItemCreateModal(ctx context.Context, org *api.Organization) {
 	selfPage := modalItemType
 
+	itemTypes := []string{"Login", "Card", "Note", "Data"}
+	if org != nil {
+		itemTypes = itemTypes[:3]
+	}
+
 	modal := tview.NewModal().
 		SetText("Choose item type").
-		AddButtons([]string{"Login", "Card", "Note", "Data"}).
+		AddButtons(itemTypes).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 			var itemType string
 
@@ -58,6 +67,12 @@ func (g *Gtui) displayItemCreateModal(ctx context.Context) {
 
 			g.pages.RemovePage(selfPage)
 			g.setStatus(fmt.Sprintf("creating new %s item", common.ItemTypeText(itemType)), 2)
+
+			if org != nil {
+				g.displayCreateOrgItemPage(ctx, org, itemType)
+				return
+			}
+
 			g.displayCreateItemPage(ctx, itemType)
 		})
 
@@ -97,3 +112,280 @@ func (g *Gtui) displayCfCreateModal(ctx context.Context, item *api.Item, imData
 	g.setStatus("Wait for user input...", 0)
 	g.pages.AddPage(selfPage, modal, true, true)
 }
+
+// displayTrashItemModal displays modal window with available actions for trashed item.
+func (g *Gtui) displayTrashItemModal(ctx context.Context, item *pb.ItemShort) {
+	selfPage := modalTrash
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Item '%s' (%s)", item.Name, common.ItemTypeText(item.Type))).
+		AddButtons([]string{"Restore", "Purge", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			switch buttonLabel {
+			case "Restore":
+				g.restoreItem(ctx, item, pageTrash)
+			case "Purge":
+				g.purgeItem(ctx, item, pageTrash)
+			default:
+				g.setStatus("canceled...", 2)
+			}
+		})
+
+	g.setStatus("Wait for user input...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayRotateKeyModal displays modal window for confirmation of encryption key rotation.
+func (g *Gtui) displayRotateKeyModal(ctx context.Context) {
+	selfPage := modalRotate
+
+	modal := tview.NewModal().
+		SetText("All items will be re-encrypted with new encryption key, items' history will be deleted. " +
+			"Do you want to continue?").
+		AddButtons([]string{"Rotate", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			if buttonLabel == "Rotate" {
+				g.rotateEncryptionKey(ctx, pageActiveSettings)
+				return
+			}
+
+			g.setStatus("canceled...", 2)
+		})
+
+	g.setStatus("Wait for user confirmation...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayDeleteFolderModal displays modal window for confirmation of folder deletion.
+func (g *Gtui) displayDeleteFolderModal(ctx context.Context, folder *api.Folder) {
+	selfPage := modalFolder
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Folder '%s' will be deleted, its items will be kept without folder. "+
+			"Do you want to continue?", folder.Name)).
+		AddButtons([]string{"Delete", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			if buttonLabel == "Delete" {
+				g.deleteFolder(ctx, folder, pageItemBrowser)
+				return
+			}
+
+			g.setStatus("canceled...", 2)
+		})
+
+	g.setStatus("Wait for user confirmation...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayRevokeShareModal displays modal window for confirmation of share revocation.
+func (g *Gtui) displayRevokeShareModal(ctx context.Context, item *api.Item, share *api.Share) {
+	selfPage := modalShare
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Item '%s' is shared with '%s' (%s). Do you want to revoke share?",
+			item.Name, share.Recipient, shareUpdatedText(share))).
+		AddButtons([]string{"Revoke", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			if buttonLabel == "Revoke" {
+				g.revokeShare(ctx, item, share, pageShareItem)
+				return
+			}
+
+			g.setStatus("canceled...", 2)
+		})
+
+	g.setStatus("Wait for user confirmation...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayInviteModal displays modal window for accepting or declining invite to organization.
+func (g *Gtui) displayInviteModal(ctx context.Context, org *api.Organization) {
+	selfPage := modalOrg
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("You are invited to organization '%s' as %s.", org.Name, org.Role)).
+		AddButtons([]string{"Accept", "Decline", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			switch buttonLabel {
+			case "Accept":
+				g.acceptInvite(ctx, org, pageOrganizations)
+			case "Decline":
+				g.leaveOrganization(ctx, org, pageOrganizations)
+			default:
+				g.setStatus("canceled...", 2)
+			}
+		})
+
+	g.setStatus("Wait for user input...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayDeleteOrgModal displays modal window for confirmation of organization deletion.
+func (g *Gtui) displayDeleteOrgModal(ctx context.Context, org *api.Organization) {
+	selfPage := modalOrg
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Organization '%s' will be deleted together with all its items. "+
+			"Do you want to continue?", org.Name)).
+		AddButtons([]string{"Delete", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			if buttonLabel == "Delete" {
+				g.deleteOrganization(ctx, org, pageOrgVault)
+				return
+			}
+
+			g.setStatus("canceled...", 2)
+		})
+
+	g.setStatus("Wait for user confirmation...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayLeaveOrgModal displays modal window for confirmation of leaving organization.
+func (g *Gtui) displayLeaveOrgModal(ctx context.Context, org *api.Organization) {
+	selfPage := modalOrg
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("You will lose access to items of organization '%s'. "+
+			"Do you want to leave organization?", org.Name)).
+		AddButtons([]string{"Leave", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			if buttonLabel == "Leave" {
+				g.leaveOrganization(ctx, org, pageOrgVault)
+				return
+			}
+
+			g.setStatus("canceled...", 2)
+		})
+
+	g.setStatus("Wait for user confirmation...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayMemberModal displays modal window with available actions for organization's member.
+//
+// Member's role could be changed to any of available roles or member could be removed.
+func (g *Gtui) displayMemberModal(ctx context.Context, org *api.Organization, member *api.Member) {
+	selfPage := modalMember
+
+	roles := common.ListOrgRoles()
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Member '%s' (%s). Choose new role or remove member.",
+			member.Username, orgRoleText(member.Role, member.Accepted))).
+		AddButtons(append(roles, "Remove", "Cancel")).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			switch {
+			case common.Contains(buttonLabel, roles):
+				g.updateMember(ctx, org, member, buttonLabel, pageOrgMembers)
+			case buttonLabel == "Remove":
+				g.removeMember(ctx, org, member, pageOrgMembers)
+			default:
+				g.setStatus("canceled...", 2)
+			}
+		})
+
+	g.setStatus("Wait for user input...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayGrantedEmergencyModal displays modal window with available actions for user's trusted contact.
+//
+// Requested access could be approved before waiting period is over or rejected, approved access
+// could be rejected, trusted contact could be removed in any status.
+func (g *Gtui) displayGrantedEmergencyModal(ctx context.Context, access *api.EmergencyAccess) {
+	selfPage := modalEmergency
+
+	var buttons []string
+
+	switch access.Status {
+	case common.EmergencyStatusRequested:
+		buttons = []string{"Approve", "Reject"}
+	case common.EmergencyStatusApproved:
+		buttons = []string{"Reject"}
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Trusted contact '%s' (%s).", access.Grantee, emergencyStatusText(access))).
+		AddButtons(append(buttons, "Remove", "Cancel")).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			switch buttonLabel {
+			case "Approve":
+				g.changeEmergencyAccess(ctx, g.client.ApproveEmergencyAccess, access,
+					fmt.Sprintf("'%s' got access to your vault", access.Grantee))
+			case "Reject":
+				g.changeEmergencyAccess(ctx, g.client.RejectEmergencyAccess, access,
+					fmt.Sprintf("access of '%s' was rejected", access.Grantee))
+			case "Remove":
+				g.changeEmergencyAccess(ctx, g.client.RevokeEmergencyAccess, access,
+					fmt.Sprintf("'%s' was removed from trusted contacts", access.Grantee))
+			default:
+				g.setStatus("canceled...", 2)
+			}
+		})
+
+	g.setStatus("Wait for user input...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// displayReceivedEmergencyModal displays modal window with available actions for emergency access
+// granted to user.
+func (g *Gtui) displayReceivedEmergencyModal(ctx context.Context, access *api.EmergencyAccess) {
+	selfPage := modalEmergency
+
+	var buttons []string
+
+	switch access.Status {
+	case common.EmergencyStatusInvited:
+		buttons = []string{"Accept"}
+	case common.EmergencyStatusAccepted:
+		buttons = []string{"Request access"}
+	case common.EmergencyStatusApproved:
+		buttons = []string{"Open vault"}
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("'%s' trusts you (%s).", access.Grantor, emergencyStatusText(access))).
+		AddButtons(append(buttons, "Remove", "Cancel")).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			switch buttonLabel {
+			case "Accept":
+				g.changeEmergencyAccess(ctx, g.client.AcceptEmergencyInvite, access,
+					fmt.Sprintf("you became trusted contact of '%s'", access.Grantor))
+			case "Request access":
+				g.changeEmergencyAccess(ctx, g.client.RequestEmergencyAccess, access,
+					fmt.Sprintf("access will be granted in %d days, if '%s' doesn't reject it",
+						access.WaitDays, access.Grantor))
+			case "Open vault":
+				g.displayEmergencyVaultPage(ctx, access)
+			case "Remove":
+				g.changeEmergencyAccess(ctx, g.client.RevokeEmergencyAccess, access,
+					fmt.Sprintf("access to vault of '%s' was removed", access.Grantor))
+			default:
+				g.setStatus("canceled...", 2)
+			}
+		})
+
+	g.setStatus("Wait for user input...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/client/api"
+	"github.com/artfuldog/gophkeeper/internal/common"
+)
+
+// saveOrganization creates new or renames existing organization.
+func (g *Gtui) saveOrganization(ctx context.Context, org *api.Organization, name string, pageName string) {
+	var err error
+	if org.ID > 0 {
+		err = g.client.RenameOrganization(ctx, org, name)
+	} else {
+		err = g.client.CreateOrganization(ctx, name)
+	}
+
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 3)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayOrganizationsPage(ctx)
+
+	if org.ID > 0 {
+		org.Name = name
+		g.displayOrgVaultPage(ctx, org)
+	}
+
+	g.setStatus(fmt.Sprintf("organization '%s' was saved", name), 3)
+}
+
+// deleteOrganization deletes organization together with its items.
+func (g *Gtui) deleteOrganization(ctx context.Context, org *api.Organization, pageName string) {
+	if err := g.client.DeleteOrganization(ctx, org); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayOrganizationsPage(ctx)
+	g.setStatus(fmt.Sprintf("organization '%s' was deleted", org.Name), 5)
+}
+
+// acceptInvite accepts invite to organization.
+func (g *Gtui) acceptInvite(ctx context.Context, org *api.Organization, pageName string) {
+	if err := g.client.AcceptInvite(ctx, org); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayOrganizationsPage(ctx)
+	g.setStatus(fmt.Sprintf("you joined organization '%s'", org.Name), 5)
+}
+
+// leaveOrganization leaves organization or declines invite to organization.
+func (g *Gtui) leaveOrganization(ctx context.Context, org *api.Organization, pageName string) {
+	if err := g.client.RemoveMember(ctx, org, g.config.GetUser()); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayOrganizationsPage(ctx)
+	g.setStatus(fmt.Sprintf("you left organization '%s'", org.Name), 5)
+}
+
+// inviteMember invites user to organization.
+func (g *Gtui) inviteMember(ctx context.Context, org *api.Organization, username, role string, pageName string) {
+	if err := g.client.InviteMember(ctx, org, username, role); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.displayOrgMembersPage(ctx, org)
+	g.setStatus(fmt.Sprintf("'%s' was invited as %s", username, role), 5)
+}
+
+// updateMember changes member's role.
+func (g *Gtui) updateMember(ctx context.Context, org *api.Organization, member *api.Member,
+	role string, pageName string) {
+	if err := g.client.UpdateMember(ctx, org, member, role); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.displayOrgMembersPage(ctx, org)
+	g.setStatus(fmt.Sprintf("role of '%s' was changed to %s", member.Username, role), 5)
+}
+
+// removeMember removes member or invite from organization.
+func (g *Gtui) removeMember(ctx context.Context, org *api.Organization, member *api.Member, pageName string) {
+	if err := g.client.RemoveMember(ctx, org, member.Username); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.displayOrgMembersPage(ctx, org)
+	g.setStatus(fmt.Sprintf("'%s' was removed from organization", member.Username), 5)
+}
+
+// saveOrgItem saves organization's item.
+func (g *Gtui) saveOrgItem(ctx context.Context, org *api.Organization, item *api.Item, pageName string) {
+	if err := g.client.SaveItem(ctx, item); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 3)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayOrgVaultPage(ctx, org)
+}
+
+// deleteOrgItem moves organization's item to trash.
+func (g *Gtui) deleteOrgItem(ctx context.Context, org *api.Organization, item *api.Item, pageName string) {
+	if err := g.client.DeleteItem(ctx, item); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageName) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageName)
+	g.displayOrgVaultPage(ctx, org)
+	g.setStatus(fmt.Sprintf("item '%s' (%s) was moved to trash", item.Name, common.ItemTypeText(item.Type)), 5)
+}
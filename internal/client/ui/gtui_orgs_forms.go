@@ -0,0 +1,378 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/client/api"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// displayOrganizationsPage displays page listed user's organizations and invites.
+//
+// Selecting organization opens its vault, selecting invite offers to accept or decline it.
+func (g *Gtui) displayOrganizationsPage(ctx context.Context) {
+	selfPage := pageOrganizations
+
+	orgs, err := g.client.GetOrganizations(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	list := tview.NewList()
+
+	r := rune(62)
+	for _, org := range orgs {
+		list.AddItem(org.Name, orgRoleText(org.Role, org.Accepted), r, nil)
+	}
+
+	list.SetMainTextStyle(tcell.StyleDefault.Bold(true))
+
+	list.SetSecondaryTextStyle(tcell.StyleDefault.Italic(true)).
+		SetSecondaryTextColor(tcell.ColorDarkGreen)
+
+	list.SetDoneFunc(func() {
+		g.pages.RemovePage(selfPage)
+	})
+
+	list.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		if !orgs[index].Accepted {
+			g.displayInviteModal(ctx, orgs[index])
+			return
+		}
+
+		g.displayOrgVaultPage(ctx, orgs[index])
+	})
+	list.SetBorder(true).SetTitle("  Organizations ").SetTitleAlign(tview.AlignLeft).
+		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	buttons := tview.NewForm().
+		AddButton("New organization", func() { g.displayOrganizationDialog(ctx, &api.Organization{}) }).
+		AddButton("Back to menu", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	list.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(list, list, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+	if len(orgs) == 0 {
+		g.setStatus("you are not a member of any organization", 3)
+	}
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// displayOrganizationDialog displays dialog for create new or rename existing organization.
+func (g *Gtui) displayOrganizationDialog(ctx context.Context, org *api.Organization) {
+	selfPage := pageOrganization
+
+	title := " New organization "
+	if org.ID > 0 {
+		title = " Rename organization "
+	}
+
+	name := org.Name
+
+	form := tview.NewForm().
+		AddInputField("Name", name, 40, nil, func(v string) {
+			name = v
+		}).
+		AddButton("Save", func() {
+			if name == "" {
+				g.setStatus("organization name is required", 3)
+				return
+			}
+
+			g.saveOrganization(ctx, org, name, selfPage)
+		}).
+		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) })
+
+	form.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
+		SetTitle(title).SetTitleAlign(tview.AlignCenter)
+	form.SetButtonsAlign(tview.AlignCenter).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive)
+	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	grid := tview.NewGrid().
+		SetColumns(0, 56, 0).SetRows(0, 7, 0).
+		AddItem(form, 1, 1, 1, 1, 0, 0, true)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// displayOrgVaultPage displays page listed organization's items.
+//
+// Control buttons are shown according to user's role in organization.
+func (g *Gtui) displayOrgVaultPage(ctx context.Context, org *api.Organization) {
+	selfPage := pageOrgVault
+
+	items, err := g.client.GetOrgItemsList(ctx, org)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	browser := tview.NewList()
+
+	r := rune(62)
+	for _, item := range items {
+		browser.AddItem(item.Name, common.ItemTypeText(item.Type), r, nil)
+	}
+
+	browser.SetMainTextStyle(tcell.StyleDefault.Bold(true))
+
+	browser.SetSecondaryTextStyle(tcell.StyleDefault.Italic(true)).
+		SetSecondaryTextColor(tcell.ColorDarkGreen)
+
+	browser.SetDoneFunc(func() {
+		g.pages.RemovePage(selfPage)
+	})
+
+	browser.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		g.displayEditOrgItemPage(ctx, org, items[index])
+	})
+	browser.SetBorder(true).SetTitle(fmt.Sprintf("  %s (%s) ", org.Name, org.Role)).
+		SetTitleAlign(tview.AlignLeft).SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	buttons := tview.NewForm()
+
+	if org.Editable() {
+		buttons.AddButton("Add new Item", func() { g.displayItemCreateModal(ctx, org) })
+	}
+
+	buttons.AddButton("Members", func() { g.displayOrgMembersPage(ctx, org) })
+
+	if org.Manageable() {
+		buttons.
+			AddButton("Rename", func() { g.displayOrganizationDialog(ctx, org) }).
+			AddButton("Delete", func() { g.displayDeleteOrgModal(ctx, org) })
+	}
+
+	buttons.
+		AddButton("Leave", func() { g.displayLeaveOrgModal(ctx, org) }).
+		AddButton("Back", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	browser.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(browser, browser, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(browser, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+	if len(items) == 0 {
+		g.setStatus("organization's vault is empty", 3)
+	}
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// displayOrgMembersPage displays page listed organization's members and invites.
+//
+// Organization's administrators could invite new members, change members' roles and remove them.
+func (g *Gtui) displayOrgMembersPage(ctx context.Context, org *api.Organization) {
+	selfPage := pageOrgMembers
+
+	members, err := g.client.GetMembers(ctx, org)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	list := tview.NewList()
+
+	for _, member := range members {
+		list.AddItem(member.Username, orgRoleText(member.Role, member.Accepted), 0, nil)
+	}
+
+	list.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		if !org.Manageable() {
+			g.setStatus("only organization's administrators can manage members", 3)
+			return
+		}
+
+		g.displayMemberModal(ctx, org, members[index])
+	})
+	list.SetDoneFunc(func() { g.pages.RemovePage(selfPage) })
+
+	list.SetSecondaryTextColor(tcell.ColorDarkGreen)
+	list.SetBorder(true).SetTitle(fmt.Sprintf("  %s members ", org.Name)).SetTitleAlign(tview.AlignLeft).
+		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	if !org.Manageable() {
+		buttons := tview.NewForm().
+			AddButton("Back", func() { g.pages.RemovePage(selfPage) })
+
+		buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+			SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+		list.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+		buttons.SetInputCapture(g.captureAndSetFocus(list, list, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+		flex := tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(list, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+		g.pages.AddPage(selfPage, flex, true, true)
+
+		return
+	}
+
+	var username string
+
+	roles := common.ListOrgRoles()
+	role := common.OrgRoleMember
+
+	form := tview.NewForm().SetHorizontal(true).
+		AddInputField("Invite", "", 30, nil, func(v string) {
+			username = v
+		}).
+		AddDropDown("Role", roles, 1, func(option string, optionIndex int) {
+			role = option
+		}).
+		AddButton("Invite", func() {
+			if username == "" {
+				g.setStatus("username is required", 3)
+				return
+			}
+
+			g.inviteMember(ctx, org, username, role, selfPage)
+		}).
+		AddButton("Back", func() { g.pages.RemovePage(selfPage) })
+
+	form.SetButtonActivatedStyle(styleCtrButtonsActive).SetButtonStyle(styleCtrButtonsInactive).
+		SetBorderPadding(0, 0, 2, 2)
+	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	list.SetInputCapture(g.captureAndSetFocus(form, form, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	form.SetInputCapture(g.captureAndSetFocus(list, list, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).AddItem(form, 1, 1, false)
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// displayEditOrgItemPage displays page for viewing and editing organization's item.
+func (g *Gtui) displayEditOrgItemPage(ctx context.Context, org *api.Organization, itemShort *pb.ItemShort) {
+	item, err := g.client.GetOrgItem(ctx, org, itemShort.Name, itemShort.Type)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageOrgItem) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.displayOrgItemPage(ctx, org, item, false, g.config.GetShowSensitive())
+}
+
+// displayCreateOrgItemPage displays page for create new organization's item.
+func (g *Gtui) displayCreateOrgItemPage(ctx context.Context, org *api.Organization, itemType string) {
+	item := &api.Item{
+		Type:         itemType,
+		OrgID:        org.ID,
+		Secret:       api.NewSecretEmpty(itemType),
+		URIs:         api.URIs{},
+		CustomFields: api.CustomFields{},
+	}
+
+	g.displayOrgItemPage(ctx, org, item, true, true)
+}
+
+// displayOrgItemPage displays page for organization's item.
+//
+// Item could be changed only if user's role allows to change organization's items,
+// otherwise item is displayed in read-only mode.
+func (g *Gtui) displayOrgItemPage(ctx context.Context, org *api.Organization, item *api.Item,
+	newItemFlag bool, showSensitive bool) {
+	selfPage := pageOrgItem
+
+	var itemForm *tview.Form
+	if org.Editable() {
+		itemForm = g.drawItemMainForm(ctx, item, selfPage, newItemFlag, showSensitive)
+	} else {
+		itemForm = g.drawItemVersionForm(item, showSensitive)
+		itemForm.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+	}
+
+	updated := ""
+	if !newItemFlag {
+		updated = item.Updated.String()
+	}
+
+	info := tview.NewForm().SetItemPadding(0).
+		AddTextView("Type", common.ItemTypeText(item.Type), 40, 1, true, false).
+		AddTextView("Organization", org.Name, 40, 1, true, false).
+		AddTextView("Updated", updated, 40, 1, true, false)
+	info.SetBorderPadding(0, 0, 0, 0)
+
+	buttons := tview.NewForm().
+		AddButton("Back to vault", func() { g.pages.RemovePage(selfPage) })
+
+	if org.Editable() {
+		buttons.AddButton("Save", func() { g.saveOrgItem(ctx, org, item, selfPage) })
+	}
+
+	if !newItemFlag {
+		if showSensitive {
+			buttons.AddButton("Hide sensitive", func() { g.displayOrgItemPage(ctx, org, item, newItemFlag, false) })
+		} else {
+			buttons.AddButton("Show sensitive", func() { g.displayOrgItemPage(ctx, org, item, newItemFlag, true) })
+		}
+	}
+
+	if org.Editable() && !newItemFlag {
+		buttons.AddButton("Delete", func() { g.deleteOrgItem(ctx, org, item, selfPage) })
+	}
+
+	buttons.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+	buttons.SetButtonsAlign(tview.AlignLeft).SetBorderPadding(0, 0, 0, 0)
+	buttons.SetButtonStyle(styleCtrButtonsInactive).SetButtonActivatedStyle(styleCtrButtonsActive)
+
+	itemForm.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(itemForm, itemForm, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	grid := tview.NewGrid().
+		SetRows(0, 1).SetColumns(0, 0).
+		SetBorders(true).SetBordersColor(tcell.ColorLightSkyBlue).
+		AddItem(itemForm, 0, 0, 1, 1, 0, 0, true).
+		AddItem(info, 0, 1, 1, 1, 0, 0, false).
+		AddItem(buttons, 1, 0, 1, 2, 0, 0, false)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// orgRoleText returns text representation of member's role.
+func orgRoleText(role string, accepted bool) string {
+	if accepted {
+		return role
+	}
+
+	return fmt.Sprintf("invited as %s", role)
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// displaySessionsPage displays page listed user's active sessions, last seen first.
+//
+// Selected session can be revoked, all sessions are revoked by "Sign out everywhere" button.
+// Revocation of current session logs user out with provided logout function.
+func (g *Gtui) displaySessionsPage(ctx context.Context, logout func(statusText string)) {
+	selfPage := pageSessions
+
+	sessions, err := g.client.GetSessions(ctx)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, selfPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	list := tview.NewList()
+
+	r := rune(62)
+	for _, session := range sessions {
+		list.AddItem(sessionText(session), sessionDetails(session), r, nil)
+	}
+
+	list.SetMainTextStyle(tcell.StyleDefault.Bold(true))
+	list.SetSecondaryTextStyle(tcell.StyleDefault.Italic(true)).
+		SetSecondaryTextColor(tcell.ColorDarkGreen)
+
+	list.SetDoneFunc(func() {
+		g.pages.RemovePage(selfPage)
+	})
+
+	list.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		g.displayRevokeSessionModal(ctx, sessions[index], logout)
+	})
+	list.SetBorder(true).SetTitle("  Sessions ").SetTitleAlign(tview.AlignLeft).
+		SetBorderPadding(1, 0, 2, 2).SetTitleColor(tcell.ColorTomato)
+
+	buttons := tview.NewForm().
+		AddButton("Sign out everywhere", func() { g.displayRevokeSessionModal(ctx, nil, logout) }).
+		AddButton("Back to menu", func() { g.pages.RemovePage(selfPage) })
+
+	buttons.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive).SetBorderPadding(0, 0, 0, 0)
+
+	list.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(list, list, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).AddItem(buttons, 1, 1, false)
+
+	g.pages.AddPage(selfPage, flex, true, true)
+}
+
+// displayRevokeSessionModal displays modal window for confirmation of session revocation.
+//
+// If session is nil all user's sessions are revoked.
+func (g *Gtui) displayRevokeSessionModal(ctx context.Context, session *pb.Session,
+	logout func(statusText string)) {
+	selfPage := modalSession
+
+	text := "All your sessions including current one will be revoked. Do you want to sign out everywhere?"
+	if session != nil {
+		text = fmt.Sprintf("Do you want to revoke session on '%s'?", session.Device)
+
+		if session.Current {
+			text = "This is your current session, you will be logged out. Do you want to revoke it?"
+		}
+	}
+
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Revoke", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			g.pages.RemovePage(selfPage)
+
+			if buttonLabel == "Revoke" {
+				g.revokeSession(ctx, session, logout)
+				return
+			}
+
+			g.setStatus("canceled...", 2)
+		})
+
+	g.setStatus("Wait for user confirmation...", 0)
+	g.pages.AddPage(selfPage, modal, true, true)
+}
+
+// revokeSession revokes user's session (all sessions if session is nil).
+//
+// After revocation of current session user is logged out, otherwise sessions' list is refreshed.
+func (g *Gtui) revokeSession(ctx context.Context, session *pb.Session, logout func(statusText string)) {
+	var err error
+
+	if session == nil {
+		err = g.client.RevokeAllSessions(ctx)
+	} else {
+		err = g.client.RevokeSession(ctx, session.Id)
+	}
+
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, pageSessions) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	g.pages.RemovePage(pageSessions)
+
+	if session == nil || session.Current {
+		logout("signed out, session was revoked")
+		return
+	}
+
+	g.displaySessionsPage(ctx, logout)
+	g.setStatus(fmt.Sprintf("session on '%s' was revoked", session.Device), 3)
+}
+
+// sessionText returns session's main text: device and current session's mark.
+func sessionText(session *pb.Session) string {
+	if session.Current {
+		return fmt.Sprintf("%s (current)", session.Device)
+	}
+
+	return session.Device
+}
+
+// sessionDetails returns session's details: client's address and last seen time.
+func sessionDetails(session *pb.Session) string {
+	return fmt.Sprintf("IP %s, last seen %s, expires %s", session.ClientIp,
+		session.GetLastSeen().AsTime().Local().Format(time.RFC822),
+		session.GetExpires().AsTime().Local().Format(time.RFC822))
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/client/api"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// displayShareItemDialog displays dialog for sharing item with another user and
+// listed item's current shares.
+//
+// Recipient gets read-only snapshot of item, sharing item with the same recipient again
+// updates snapshot. Selecting current share offers to revoke it.
+func (g *Gtui) displayShareItemDialog(ctx context.Context, item *api.Item, parentPage string) {
+	selfPage := pageShareItem
+
+	shares, err := g.client.GetItemShares(ctx, item)
+	if err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, parentPage) {
+			return
+		}
+
+		g.setStatus(err.Error(), 5)
+
+		return
+	}
+
+	var recipient string
+
+	form := tview.NewForm().
+		AddInputField("Recipient", "", 40, nil, func(v string) {
+			recipient = v
+		}).
+		AddButton("Share", func() {
+			if recipient == "" {
+				g.setStatus("recipient is required", 3)
+				return
+			}
+
+			g.shareItem(ctx, item, recipient, selfPage)
+		}).
+		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) })
+
+	form.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
+		SetTitle(fmt.Sprintf(" Share '%s' ", item.Name)).SetTitleAlign(tview.AlignCenter)
+	form.SetButtonsAlign(tview.AlignCenter).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive)
+	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	list := tview.NewList()
+
+	for _, share := range shares {
+		list.AddItem(share.Recipient, shareUpdatedText(share), 0, nil)
+	}
+
+	list.SetSelectedFunc(func(index int, text, secText string, shortcut rune) {
+		g.displayRevokeShareModal(ctx, item, shares[index])
+	})
+	list.SetDoneFunc(func() { g.pages.RemovePage(selfPage) })
+
+	list.SetSecondaryTextColor(tcell.ColorDarkGreen)
+	list.SetBorder(true).SetBackgroundColor(tcell.ColorDarkBlue).
+		SetTitle(" Shared with ").SetTitleAlign(tview.AlignCenter).SetBorderPadding(0, 0, 2, 2)
+
+	form.SetInputCapture(g.captureAndSetFocus(list, list, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	list.SetInputCapture(g.captureAndSetFocus(form, form, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	grid := tview.NewGrid().
+		SetColumns(0, 60, 0).SetRows(0, 9, 10, 0).
+		AddItem(form, 1, 1, 1, 1, 0, 0, true).
+		AddItem(list, 2, 1, 1, 1, 0, 0, false)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// displaySharedItemPage displays page for viewing item shared with user.
+//
+// Shared item is read-only snapshot of owner's item made at the moment of sharing.
+func (g *Gtui) displaySharedItemPage(ctx context.Context, shared *api.SharedItem, showSensitive bool) {
+	selfPage := pageSharedItem
+
+	itemForm := g.drawItemVersionForm(shared.Item, showSensitive)
+	itemForm.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	info := tview.NewForm().SetItemPadding(0).
+		AddTextView("Type", common.ItemTypeText(shared.Item.Type), 40, 1, true, false).
+		AddTextView("Shared by", shared.Owner, 40, 1, true, false).
+		AddTextView("Snapshot", shareUpdatedText(&shared.Share), 40, 1, true, false)
+	info.SetBorderPadding(0, 0, 0, 0)
+
+	buttons := tview.NewForm().
+		AddButton("Back to vault", func() { g.pages.RemovePage(selfPage) })
+
+	if showSensitive {
+		buttons.AddButton("Hide sensitive", func() { g.displaySharedItemPage(ctx, shared, false) })
+	} else {
+		buttons.AddButton("Show sensitive", func() { g.displaySharedItemPage(ctx, shared, true) })
+	}
+
+	buttons.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+	buttons.SetButtonsAlign(tview.AlignLeft).SetBorderPadding(0, 0, 0, 0)
+	buttons.SetButtonStyle(styleCtrButtonsInactive).SetButtonActivatedStyle(styleCtrButtonsActive)
+
+	itemForm.SetInputCapture(g.captureAndSetFocus(buttons, buttons, tcell.KeyCtrlT, tcell.KeyCtrlY))
+	buttons.SetInputCapture(g.captureAndSetFocus(itemForm, itemForm, tcell.KeyCtrlT, tcell.KeyCtrlY))
+
+	grid := tview.NewGrid().
+		SetRows(0, 1).SetColumns(0, 0).
+		SetBorders(true).SetBordersColor(tcell.ColorLightGreen).
+		AddItem(itemForm, 0, 0, 1, 1, 0, 0, true).
+		AddItem(info, 0, 1, 1, 1, 0, 0, false).
+		AddItem(buttons, 1, 0, 1, 2, 0, 0, false)
+
+	g.pages.AddPage(selfPage, grid, true, true)
+}
+
+// shareUpdatedText returns text representation of time, when shared snapshot of item was made.
+func shareUpdatedText(share *api.Share) string {
+	return "read-only snapshot of " + share.Updated.Local().Format(time.RFC822)
+}
@@ -4,12 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/client/api"
 )
 
 // userLogin performs user login and starts gRPC client.
+//
+// If server locks login after too many failed attempts, remaining lockout period is displayed
+// in status field until lockout expires.
 func (g *Gtui) userLogin(ctx context.Context, username, password, code string) {
+	if g.stopLockoutCountdown != nil {
+		g.stopLockoutCountdown()
+		g.stopLockoutCountdown = nil
+	}
+
 	g.setStatus("Logging in...", 3)
 
 	clientCtx, clientStop := context.WithCancel(ctx)
@@ -30,6 +39,18 @@ func (g *Gtui) userLogin(ctx context.Context, username, password, code string) {
 			return
 		}
 
+		var lockedErr *api.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			clientStop()
+
+			countdownCtx, countdownStop := context.WithCancel(ctx)
+			g.stopLockoutCountdown = countdownStop
+
+			go g.bgLoginLockoutCountdown(countdownCtx, time.Now().Add(lockedErr.RetryAfter))
+
+			return
+		}
+
 		g.setStatus(err.Error(), 5)
 		clientStop()
 
@@ -93,3 +114,55 @@ func (g *Gtui) userRegister(ctx context.Context, user *api.NewUser, parentPage s
 	g.pages.RemovePage(parentPage)
 	g.toPageWithStatus(pageUserLogin, "Registered", 2)
 }
+
+// changePassword changes user's password and performs basic checks of user's input.
+//
+// If secret key was changed, new secret key is saved to configuration file.
+func (g *Gtui) changePassword(ctx context.Context, change *api.PasswordChange, parentPage string) {
+	if change.NewPassword == "" {
+		g.setStatus("password can not be empty", 5)
+		return
+	}
+
+	if change.NewPassword != change.NewPasswordConfirm {
+		g.setStatus("passwords do not match", 5)
+		return
+	}
+
+	g.setStatus("Changing password...", 0)
+
+	if err := g.client.ChangePassword(ctx, change); err != nil {
+		g.setStatus(err.Error(), 5)
+		return
+	}
+
+	g.pages.RemovePage(parentPage)
+
+	if change.NewSecretKey != "" {
+		if err := g.config.WriteConfig(); err != nil {
+			g.setStatus(fmt.Sprintf("password changed, but failed to save new secret key to '%s': %v",
+				g.config.ConfigFileUsed(), err), 5)
+
+			return
+		}
+	}
+
+	g.setStatus("password successfully changed, other sessions are signed out", 3)
+}
+
+// rotateEncryptionKey replaces encryption key and re-encrypts all user's items.
+func (g *Gtui) rotateEncryptionKey(ctx context.Context, parentPage string) {
+	g.setStatus("Rotating encryption key...", 0)
+
+	if err := g.client.RotateEncryptionKey(ctx); err != nil {
+		if g.checkClientErrorsAndStop(ctx, err, parentPage) {
+			return
+		}
+
+		g.setStatus(fmt.Sprintf("encryption key is not changed: %v", err), 5)
+
+		return
+	}
+
+	g.setStatus("encryption key rotated, all items are re-encrypted", 3)
+}
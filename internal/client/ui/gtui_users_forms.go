@@ -111,6 +111,42 @@ func (g *Gtui) displayRegisterPage(ctx context.Context) {
 	g.pages.AddPage(selfPage, form, true, true)
 }
 
+// displayChangePasswordPage displays page for change user's password.
+//
+// Secret key is optional, if it is empty current secret key is kept.
+func (g *Gtui) displayChangePasswordPage(ctx context.Context) {
+	selfPage := pageChangePassword
+	change := new(api.PasswordChange)
+
+	form := tview.NewForm().
+		AddPasswordField("Current password", change.Password, 25, '*', func(v string) {
+			change.Password = v
+		}).
+		AddPasswordField("New password", change.NewPassword, 25, '*', func(v string) {
+			change.NewPassword = v
+		}).
+		AddPasswordField("Confirm new password", change.NewPasswordConfirm, 25, '*', func(v string) {
+			change.NewPasswordConfirm = v
+		}).
+		AddInputField("New secret key", change.NewSecretKey, 25, nil, func(v string) {
+			change.NewSecretKey = v
+		}).
+		AddInputField("Verification code", change.OTPCode, 15, nil, func(v string) {
+			change.OTPCode = v
+		}).
+		AddButton("Cancel", func() { g.pages.RemovePage(selfPage) }).
+		AddButton("Change", func() { g.changePassword(ctx, change, selfPage) })
+
+	form.SetCancelFunc(func() { g.pages.RemovePage(selfPage) })
+
+	form.SetBorder(true).SetTitle(" Change password (verification code only if 2FA is enabled) ").
+		SetTitleAlign(tview.AlignLeft)
+	form.SetButtonsAlign(tview.AlignLeft).SetButtonActivatedStyle(styleCtrButtonsActive).
+		SetButtonStyle(styleCtrButtonsInactive)
+
+	g.pages.AddPage(selfPage, form, true, true)
+}
+
 // displayQRcode displays QRCode for setup 2-factor authentication.
 func (g *Gtui) displayQRcode(otp *api.TOTPKey, parentPage string) {
 	selfPage := pageQRCode
@@ -0,0 +1,37 @@
+package common
+
+// Audit events' types.
+const (
+	AuditEventCreate          = "create"
+	AuditEventRead            = "read"
+	AuditEventUpdate          = "update"
+	AuditEventDelete          = "delete"
+	AuditEventLogin           = "login"
+	AuditEventLoginFailed     = "login_failed"
+	AuditEventTwoFactorFailed = "two_factor_failed"
+	AuditEventTwoFactorReset  = "two_factor_reset"
+)
+
+// AuditEventText returns audit event's type in human-readable format.
+func AuditEventText(event string) string {
+	switch event {
+	case AuditEventCreate:
+		return "created"
+	case AuditEventRead:
+		return "read"
+	case AuditEventUpdate:
+		return "updated"
+	case AuditEventDelete:
+		return "deleted"
+	case AuditEventLogin:
+		return "logged in"
+	case AuditEventLoginFailed:
+		return "failed login"
+	case AuditEventTwoFactorFailed:
+		return "wrong verification code"
+	case AuditEventTwoFactorReset:
+		return "two-factor authentication reset"
+	default:
+		return "unknown"
+	}
+}
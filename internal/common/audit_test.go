@@ -0,0 +1,14 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEventText(t *testing.T) {
+	assert.Equal(t, "created", AuditEventText(AuditEventCreate))
+	assert.Equal(t, "logged in", AuditEventText(AuditEventLogin))
+	assert.Equal(t, "wrong verification code", AuditEventText(AuditEventTwoFactorFailed))
+	assert.Equal(t, "unknown", AuditEventText("asdasd"))
+}
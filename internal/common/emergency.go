@@ -0,0 +1,26 @@
+package common
+
+// Emergency access's modes.
+const (
+	// Trusted contact reads grantor's items only.
+	EmergencyModeView = "view"
+	// Trusted contact reads grantor's items and can take over grantor's account.
+	EmergencyModeTakeover = "takeover"
+)
+
+// Emergency access's statuses.
+const (
+	// Trusted contact is invited, but hasn't accepted invite yet.
+	EmergencyStatusInvited = "invited"
+	// Trusted contact accepted invite and can request access.
+	EmergencyStatusAccepted = "accepted"
+	// Trusted contact requested access, access is approved after waiting period.
+	EmergencyStatusRequested = "requested"
+	// Access is approved by grantor or after waiting period.
+	EmergencyStatusApproved = "approved"
+)
+
+// ListEmergencyModes returns available emergency access's modes.
+func ListEmergencyModes() []string {
+	return []string{EmergencyModeView, EmergencyModeTakeover}
+}
@@ -0,0 +1,11 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListEmergencyModes(t *testing.T) {
+	assert.Equal(t, []string{"view", "takeover"}, ListEmergencyModes())
+}
@@ -0,0 +1,16 @@
+package common
+
+// Organization members' roles.
+const (
+	// Manages organization, its members and items.
+	OrgRoleAdmin = "admin"
+	// Reads and changes organization's items.
+	OrgRoleMember = "member"
+	// Reads organization's items only.
+	OrgRoleViewer = "viewer"
+)
+
+// ListOrgRoles returns available organization members' roles.
+func ListOrgRoles() []string {
+	return []string{OrgRoleAdmin, OrgRoleMember, OrgRoleViewer}
+}
@@ -0,0 +1,11 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOrgRoles(t *testing.T) {
+	assert.Equal(t, []string{"admin", "member", "viewer"}, ListOrgRoles())
+}
@@ -19,6 +19,13 @@ const (
 // EncryptAES encrypts message with AES GCM.
 // The key argument should be the AES key, either 16, 24, or 32 bytes to select AES-128, AES-192, or AES-256.
 func EncryptAES(key, message []byte) ([]byte, error) {
+	return EncryptAESWithData(key, message, nil)
+}
+
+// EncryptAESWithData encrypts message with AES GCM and authenticates it together with data.
+// Data isn't encrypted and isn't included in result, so the same data must be provided for decryption.
+// The key argument should be the AES key, either 16, 24, or 32 bytes to select AES-128, AES-192, or AES-256.
+func EncryptAESWithData(key, message, data []byte) ([]byte, error) {
 	gcm, err := getGCM(key)
 	if err != nil {
 		return nil, err
@@ -29,7 +36,7 @@ func EncryptAES(key, message []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	return gcm.Seal(nonce, nonce, message, nil), nil
+	return gcm.Seal(nonce, nonce, message, data), nil
 }
 
 // EncryptAES encrypts message with AES GCM and additional information.
@@ -60,6 +67,13 @@ func EncryptAESwithAD(key, message []byte) ([]byte, error) {
 // DecryptAES decrypts encrypted with AES GCM message.
 // The key argument should be the AES key, either 16, 24, or 32 bytes to select AES-128, AES-192, or AES-256.
 func DecryptAES(key, encrypted []byte) ([]byte, error) {
+	return DecryptAESWithData(key, encrypted, nil)
+}
+
+// DecryptAESWithData decrypts encrypted with AES GCM message and authenticates it together with data.
+// Returns error if data differs from data provided for encryption.
+// The key argument should be the AES key, either 16, 24, or 32 bytes to select AES-128, AES-192, or AES-256.
+func DecryptAESWithData(key, encrypted, data []byte) ([]byte, error) {
 	gcm, err := getGCM(key)
 	if err != nil {
 		return nil, err
@@ -72,7 +86,7 @@ func DecryptAES(key, encrypted []byte) ([]byte, error) {
 
 	nonce, ciphertext := encrypted[:nonceSize], encrypted[nonceSize:]
 
-	decrypted, err := gcm.Open(nil, nonce, ciphertext, nil)
+	decrypted, err := gcm.Open(nil, nonce, ciphertext, data)
 	if err != nil {
 		return nil, err
 	}
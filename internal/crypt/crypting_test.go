@@ -65,6 +65,26 @@ func TestEncryptDecryptAES(t *testing.T) {
 	}
 }
 
+func TestEncryptDecryptAESWithData(t *testing.T) {
+	key := make([]byte, AESKeyLength)
+	rand.Read(key)
+
+	message := []byte("sample message")
+
+	encr, err := EncryptAESWithData(key, message, []byte("chunk 1"))
+	assert.NoError(t, err)
+
+	decr, err := DecryptAESWithData(key, encr, []byte("chunk 1"))
+	assert.NoError(t, err)
+	assert.Equal(t, message, decr)
+
+	_, err = DecryptAESWithData(key, encr, []byte("chunk 2"))
+	assert.Error(t, err, "message mustn't be decrypted with other data")
+
+	_, err = DecryptAES(key, encr)
+	assert.Error(t, err, "message mustn't be decrypted without data")
+}
+
 func TestEncryptDecryptAESwithAD(t *testing.T) {
 	messageM := make([]byte, 1111)
 	rand.Read(messageM)
@@ -0,0 +1,68 @@
+package crypt
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// X25519KeyLength is a length of X25519 public and private keys.
+const X25519KeyLength = 32
+
+// ErrWrongKeyLength is returned when key's length doesn't match X25519 key length.
+var ErrWrongKeyLength = errors.New("wrong key length")
+
+// GenerateKeyPair generates X25519 key pair, used for encrypting messages for particular user.
+func GenerateKeyPair() (publicKey []byte, privateKey []byte, err error) {
+	public, private, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return public[:], private[:], nil
+}
+
+// EncryptForPublicKey encrypts message with X25519 public key of recipient.
+//
+// Message is sealed with ephemeral key pair, so sender can't decrypt message after encryption.
+func EncryptForPublicKey(publicKey, message []byte) ([]byte, error) {
+	public, err := toX25519Key(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return box.SealAnonymous(nil, message, public, rand.Reader)
+}
+
+// DecryptWithKeyPair decrypts message encrypted with EncryptForPublicKey.
+func DecryptWithKeyPair(publicKey, privateKey, encrypted []byte) ([]byte, error) {
+	public, err := toX25519Key(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	private, err := toX25519Key(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, ok := box.OpenAnonymous(nil, encrypted, public, private)
+	if !ok {
+		return nil, errors.New("failed to decrypt message")
+	}
+
+	return decrypted, nil
+}
+
+// toX25519Key is a helper function which converts key to fixed-size array.
+func toX25519Key(key []byte) (*[X25519KeyLength]byte, error) {
+	if len(key) != X25519KeyLength {
+		return nil, ErrWrongKeyLength
+	}
+
+	var k [X25519KeyLength]byte
+	copy(k[:], key)
+
+	return &k, nil
+}
@@ -0,0 +1,46 @@
+package crypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecryptWithKeyPair(t *testing.T) {
+	public, private, err := GenerateKeyPair()
+	require.NoError(t, err)
+	assert.Len(t, public, X25519KeyLength)
+	assert.Len(t, private, X25519KeyLength)
+
+	otherPublic, otherPrivate, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	message := []byte("shared secret")
+
+	t.Run("Encrypt/decrypt message", func(t *testing.T) {
+		encrypted, err := EncryptForPublicKey(public, message)
+		require.NoError(t, err)
+		assert.NotEqual(t, message, encrypted)
+
+		decrypted, err := DecryptWithKeyPair(public, private, encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, message, decrypted)
+	})
+
+	t.Run("Decrypt with another key pair", func(t *testing.T) {
+		encrypted, err := EncryptForPublicKey(public, message)
+		require.NoError(t, err)
+
+		_, err = DecryptWithKeyPair(otherPublic, otherPrivate, encrypted)
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong key length", func(t *testing.T) {
+		_, err := EncryptForPublicKey([]byte("short"), message)
+		assert.ErrorIs(t, err, ErrWrongKeyLength)
+
+		_, err = DecryptWithKeyPair(public, []byte("short"), message)
+		assert.ErrorIs(t, err, ErrWrongKeyLength)
+	})
+}
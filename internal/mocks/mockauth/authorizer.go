@@ -35,12 +35,13 @@ func (m *MockA) EXPECT() *MockAMockRecorder {
 }
 
 // CreateToken mocks base method.
-func (m *MockA) CreateToken(fields authorizer.AuthFields) (string, error) {
+func (m *MockA) CreateToken(fields authorizer.AuthFields) (string, *authorizer.Payload, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateToken", fields)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*authorizer.Payload)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // CreateToken indicates an expected call of CreateToken.
@@ -50,11 +51,12 @@ func (mr *MockAMockRecorder) CreateToken(fields interface{}) *gomock.Call {
 }
 
 // VerifyToken mocks base method.
-func (m *MockA) VerifyToken(token string, fields authorizer.AuthFields) error {
+func (m *MockA) VerifyToken(token string, fields authorizer.AuthFields) (*authorizer.Payload, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "VerifyToken", token, fields)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*authorizer.Payload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // VerifyToken indicates an expected call of VerifyToken.
@@ -62,3 +64,40 @@ func (mr *MockAMockRecorder) VerifyToken(token, fields interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyToken", reflect.TypeOf((*MockA)(nil).VerifyToken), token, fields)
 }
+
+// MockReloader is a mock of Reloader interface.
+type MockReloader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReloaderMockRecorder
+}
+
+// MockReloaderMockRecorder is the mock recorder for MockReloader.
+type MockReloaderMockRecorder struct {
+	mock *MockReloader
+}
+
+// NewMockReloader creates a new mock instance.
+func NewMockReloader(ctrl *gomock.Controller) *MockReloader {
+	mock := &MockReloader{ctrl: ctrl}
+	mock.recorder = &MockReloaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReloader) EXPECT() *MockReloaderMockRecorder {
+	return m.recorder
+}
+
+// Reload mocks base method.
+func (m *MockReloader) Reload() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockReloaderMockRecorder) Reload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockReloader)(nil).Reload))
+}
@@ -7,6 +7,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	pb "github.com/artfuldog/gophkeeper/internal/pb"
 	db "github.com/artfuldog/gophkeeper/internal/server/db"
@@ -36,6 +37,62 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// AcceptEmergencyInvite mocks base method.
+func (m *MockDB) AcceptEmergencyInvite(ctx context.Context, grantee db.Username, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptEmergencyInvite", ctx, grantee, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptEmergencyInvite indicates an expected call of AcceptEmergencyInvite.
+func (mr *MockDBMockRecorder) AcceptEmergencyInvite(ctx, grantee, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptEmergencyInvite", reflect.TypeOf((*MockDB)(nil).AcceptEmergencyInvite), ctx, grantee, id)
+}
+
+// AcceptInvite mocks base method.
+func (m *MockDB) AcceptInvite(ctx context.Context, orgID int64, username db.Username) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvite", ctx, orgID, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvite indicates an expected call of AcceptInvite.
+func (mr *MockDBMockRecorder) AcceptInvite(ctx, orgID, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvite", reflect.TypeOf((*MockDB)(nil).AcceptInvite), ctx, orgID, username)
+}
+
+// AddMember mocks base method.
+func (m *MockDB) AddMember(ctx context.Context, orgID int64, member *pb.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, orgID, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockDBMockRecorder) AddMember(ctx, orgID, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockDB)(nil).AddMember), ctx, orgID, member)
+}
+
+// ApproveEmergencyAccess mocks base method.
+func (m *MockDB) ApproveEmergencyAccess(ctx context.Context, grantor db.Username, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveEmergencyAccess", ctx, grantor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveEmergencyAccess indicates an expected call of ApproveEmergencyAccess.
+func (mr *MockDBMockRecorder) ApproveEmergencyAccess(ctx, grantor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveEmergencyAccess", reflect.TypeOf((*MockDB)(nil).ApproveEmergencyAccess), ctx, grantor, id)
+}
+
 // Clear mocks base method.
 func (m *MockDB) Clear(arg0 context.Context) {
 	m.ctrl.T.Helper()
@@ -48,6 +105,20 @@ func (mr *MockDBMockRecorder) Clear(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockDB)(nil).Clear), arg0)
 }
 
+// Close mocks base method.
+func (m *MockDB) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDBMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDB)(nil).Close))
+}
+
 // Connect mocks base method.
 func (m *MockDB) Connect(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -76,6 +147,50 @@ func (mr *MockDBMockRecorder) ConnectAndSetup(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectAndSetup", reflect.TypeOf((*MockDB)(nil).ConnectAndSetup), arg0)
 }
 
+// CreateAuditEvents mocks base method.
+func (m *MockDB) CreateAuditEvents(arg0 context.Context, arg1 []*pb.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEvents indicates an expected call of CreateAuditEvents.
+func (mr *MockDBMockRecorder) CreateAuditEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvents", reflect.TypeOf((*MockDB)(nil).CreateAuditEvents), arg0, arg1)
+}
+
+// CreateEmergencyAccess mocks base method.
+func (m *MockDB) CreateEmergencyAccess(ctx context.Context, grantor db.Username, access *pb.EmergencyAccess) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmergencyAccess", ctx, grantor, access)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmergencyAccess indicates an expected call of CreateEmergencyAccess.
+func (mr *MockDBMockRecorder) CreateEmergencyAccess(ctx, grantor, access interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmergencyAccess", reflect.TypeOf((*MockDB)(nil).CreateEmergencyAccess), ctx, grantor, access)
+}
+
+// CreateFolder mocks base method.
+func (m *MockDB) CreateFolder(arg0 context.Context, arg1 db.Username, arg2 *pb.Folder) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockDBMockRecorder) CreateFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockDB)(nil).CreateFolder), arg0, arg1, arg2)
+}
+
 // CreateItem mocks base method.
 func (m *MockDB) CreateItem(arg0 context.Context, arg1 db.Username, arg2 *pb.Item) error {
 	m.ctrl.T.Helper()
@@ -90,6 +205,35 @@ func (mr *MockDBMockRecorder) CreateItem(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockDB)(nil).CreateItem), arg0, arg1, arg2)
 }
 
+// CreateOrganization mocks base method.
+func (m *MockDB) CreateOrganization(ctx context.Context, creator db.Username, org *pb.Organization) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, creator, org)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockDBMockRecorder) CreateOrganization(ctx, creator, org interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockDB)(nil).CreateOrganization), ctx, creator, org)
+}
+
+// CreateSession mocks base method.
+func (m *MockDB) CreateSession(arg0 context.Context, arg1 db.Username, arg2 *pb.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockDBMockRecorder) CreateSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockDB)(nil).CreateSession), arg0, arg1, arg2)
+}
+
 // CreateUser mocks base method.
 func (m *MockDB) CreateUser(arg0 context.Context, arg1 *pb.User) error {
 	m.ctrl.T.Helper()
@@ -104,6 +248,34 @@ func (mr *MockDBMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockDB)(nil).CreateUser), arg0, arg1)
 }
 
+// DeleteEmergencyAccess mocks base method.
+func (m *MockDB) DeleteEmergencyAccess(ctx context.Context, username db.Username, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmergencyAccess", ctx, username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmergencyAccess indicates an expected call of DeleteEmergencyAccess.
+func (mr *MockDBMockRecorder) DeleteEmergencyAccess(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmergencyAccess", reflect.TypeOf((*MockDB)(nil).DeleteEmergencyAccess), ctx, username, id)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockDB) DeleteFolder(ctx context.Context, username db.Username, folderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, username, folderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockDBMockRecorder) DeleteFolder(ctx, username, folderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockDB)(nil).DeleteFolder), ctx, username, folderID)
+}
+
 // DeleteItem mocks base method.
 func (m *MockDB) DeleteItem(ctx context.Context, username db.Username, itemID int64) error {
 	m.ctrl.T.Helper()
@@ -118,6 +290,62 @@ func (mr *MockDBMockRecorder) DeleteItem(ctx, username, itemID interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockDB)(nil).DeleteItem), ctx, username, itemID)
 }
 
+// DeleteLoginAttempts mocks base method.
+func (m *MockDB) DeleteLoginAttempts(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginAttempts", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginAttempts indicates an expected call of DeleteLoginAttempts.
+func (mr *MockDBMockRecorder) DeleteLoginAttempts(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginAttempts", reflect.TypeOf((*MockDB)(nil).DeleteLoginAttempts), ctx, key)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockDB) DeleteOrganization(ctx context.Context, orgID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockDBMockRecorder) DeleteOrganization(ctx, orgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockDB)(nil).DeleteOrganization), ctx, orgID)
+}
+
+// DeleteSession mocks base method.
+func (m *MockDB) DeleteSession(ctx context.Context, username db.Username, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockDBMockRecorder) DeleteSession(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockDB)(nil).DeleteSession), ctx, username, id)
+}
+
+// DeleteSessions mocks base method.
+func (m *MockDB) DeleteSessions(arg0 context.Context, arg1 db.Username) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessions indicates an expected call of DeleteSessions.
+func (mr *MockDBMockRecorder) DeleteSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessions", reflect.TypeOf((*MockDB)(nil).DeleteSessions), arg0, arg1)
+}
+
 // DeleteUserByName mocks base method.
 func (m *MockDB) DeleteUserByName(arg0 context.Context, arg1 db.Username) error {
 	m.ctrl.T.Helper()
@@ -132,6 +360,96 @@ func (mr *MockDBMockRecorder) DeleteUserByName(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserByName", reflect.TypeOf((*MockDB)(nil).DeleteUserByName), arg0, arg1)
 }
 
+// GetAllItems mocks base method.
+func (m *MockDB) GetAllItems(arg0 context.Context, arg1 db.Username) ([]*pb.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllItems", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllItems indicates an expected call of GetAllItems.
+func (mr *MockDBMockRecorder) GetAllItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllItems", reflect.TypeOf((*MockDB)(nil).GetAllItems), arg0, arg1)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockDB) GetAuditEvents(ctx context.Context, username db.Username, beforeID int64, limit uint32) ([]*pb.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, username, beforeID, limit)
+	ret0, _ := ret[0].([]*pb.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockDBMockRecorder) GetAuditEvents(ctx, username, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockDB)(nil).GetAuditEvents), ctx, username, beforeID, limit)
+}
+
+// GetChangesSince mocks base method.
+func (m *MockDB) GetChangesSince(ctx context.Context, username db.Username, revision int64) (*db.Changes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangesSince", ctx, username, revision)
+	ret0, _ := ret[0].(*db.Changes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangesSince indicates an expected call of GetChangesSince.
+func (mr *MockDBMockRecorder) GetChangesSince(ctx, username, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangesSince", reflect.TypeOf((*MockDB)(nil).GetChangesSince), ctx, username, revision)
+}
+
+// GetEmergencyAccess mocks base method.
+func (m *MockDB) GetEmergencyAccess(ctx context.Context, id int64) (*pb.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmergencyAccess", ctx, id)
+	ret0, _ := ret[0].(*pb.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmergencyAccess indicates an expected call of GetEmergencyAccess.
+func (mr *MockDBMockRecorder) GetEmergencyAccess(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmergencyAccess", reflect.TypeOf((*MockDB)(nil).GetEmergencyAccess), ctx, id)
+}
+
+// GetFolders mocks base method.
+func (m *MockDB) GetFolders(arg0 context.Context, arg1 db.Username) ([]*pb.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolders", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockDBMockRecorder) GetFolders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockDB)(nil).GetFolders), arg0, arg1)
+}
+
+// GetGrantedEmergencyAccesses mocks base method.
+func (m *MockDB) GetGrantedEmergencyAccesses(arg0 context.Context, arg1 db.Username) ([]*pb.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrantedEmergencyAccesses", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrantedEmergencyAccesses indicates an expected call of GetGrantedEmergencyAccesses.
+func (mr *MockDBMockRecorder) GetGrantedEmergencyAccesses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrantedEmergencyAccesses", reflect.TypeOf((*MockDB)(nil).GetGrantedEmergencyAccesses), arg0, arg1)
+}
+
 // GetItemByNameAndType mocks base method.
 func (m *MockDB) GetItemByNameAndType(arg0 context.Context, arg1 db.Username, arg2 db.ItemName, arg3 db.ItemType) (*pb.Item, error) {
 	m.ctrl.T.Helper()
@@ -148,18 +466,18 @@ func (mr *MockDBMockRecorder) GetItemByNameAndType(arg0, arg1, arg2, arg3 interf
 }
 
 // GetItemHashByID mocks base method.
-func (m *MockDB) GetItemHashByID(arg0 context.Context, arg1 int64) ([]byte, error) {
+func (m *MockDB) GetItemHashByID(ctx context.Context, username db.Username, itemID int64) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItemHashByID", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetItemHashByID", ctx, username, itemID)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItemHashByID indicates an expected call of GetItemHashByID.
-func (mr *MockDBMockRecorder) GetItemHashByID(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) GetItemHashByID(ctx, username, itemID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemHashByID", reflect.TypeOf((*MockDB)(nil).GetItemHashByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemHashByID", reflect.TypeOf((*MockDB)(nil).GetItemHashByID), ctx, username, itemID)
 }
 
 // GetItemList mocks base method.
@@ -177,6 +495,21 @@ func (mr *MockDBMockRecorder) GetItemList(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemList", reflect.TypeOf((*MockDB)(nil).GetItemList), arg0, arg1)
 }
 
+// GetItemVersions mocks base method.
+func (m *MockDB) GetItemVersions(ctx context.Context, username db.Username, itemID int64) ([]*pb.ItemVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemVersions", ctx, username, itemID)
+	ret0, _ := ret[0].([]*pb.ItemVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemVersions indicates an expected call of GetItemVersions.
+func (mr *MockDBMockRecorder) GetItemVersions(ctx, username, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemVersions", reflect.TypeOf((*MockDB)(nil).GetItemVersions), ctx, username, itemID)
+}
+
 // GetItemsByID mocks base method.
 func (m *MockDB) GetItemsByID(arg0 context.Context, arg1 db.Username, arg2 []int64) ([]*pb.Item, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +525,21 @@ func (mr *MockDBMockRecorder) GetItemsByID(arg0, arg1, arg2 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByID", reflect.TypeOf((*MockDB)(nil).GetItemsByID), arg0, arg1, arg2)
 }
 
+// GetLoginAttempts mocks base method.
+func (m *MockDB) GetLoginAttempts(ctx context.Context, key string) (*db.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempts", ctx, key)
+	ret0, _ := ret[0].(*db.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempts indicates an expected call of GetLoginAttempts.
+func (mr *MockDBMockRecorder) GetLoginAttempts(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempts", reflect.TypeOf((*MockDB)(nil).GetLoginAttempts), ctx, key)
+}
+
 // GetMaxSecretSize mocks base method.
 func (m *MockDB) GetMaxSecretSize() uint32 {
 	m.ctrl.T.Helper()
@@ -206,6 +554,170 @@ func (mr *MockDBMockRecorder) GetMaxSecretSize() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxSecretSize", reflect.TypeOf((*MockDB)(nil).GetMaxSecretSize))
 }
 
+// GetMembers mocks base method.
+func (m *MockDB) GetMembers(ctx context.Context, orgID int64) ([]*pb.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, orgID)
+	ret0, _ := ret[0].([]*pb.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockDBMockRecorder) GetMembers(ctx, orgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockDB)(nil).GetMembers), ctx, orgID)
+}
+
+// GetMembership mocks base method.
+func (m *MockDB) GetMembership(ctx context.Context, orgID int64, username db.Username) (*db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembership", ctx, orgID, username)
+	ret0, _ := ret[0].(*db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockDBMockRecorder) GetMembership(ctx, orgID, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockDB)(nil).GetMembership), ctx, orgID, username)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockDB) GetOrganizations(arg0 context.Context, arg1 db.Username) ([]*pb.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockDBMockRecorder) GetOrganizations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockDB)(nil).GetOrganizations), arg0, arg1)
+}
+
+// GetReceivedEmergencyAccesses mocks base method.
+func (m *MockDB) GetReceivedEmergencyAccesses(arg0 context.Context, arg1 db.Username) ([]*pb.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedEmergencyAccesses", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedEmergencyAccesses indicates an expected call of GetReceivedEmergencyAccesses.
+func (mr *MockDBMockRecorder) GetReceivedEmergencyAccesses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedEmergencyAccesses", reflect.TypeOf((*MockDB)(nil).GetReceivedEmergencyAccesses), arg0, arg1)
+}
+
+// GetReceivedShares mocks base method.
+func (m *MockDB) GetReceivedShares(arg0 context.Context, arg1 db.Username) ([]*pb.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedShares", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedShares indicates an expected call of GetReceivedShares.
+func (mr *MockDBMockRecorder) GetReceivedShares(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedShares", reflect.TypeOf((*MockDB)(nil).GetReceivedShares), arg0, arg1)
+}
+
+// GetSecretData mocks base method.
+func (m *MockDB) GetSecretData(ctx context.Context, username db.Username, itemID int64, send db.ChunkWriter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretData", ctx, username, itemID, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetSecretData indicates an expected call of GetSecretData.
+func (mr *MockDBMockRecorder) GetSecretData(ctx, username, itemID, send interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretData", reflect.TypeOf((*MockDB)(nil).GetSecretData), ctx, username, itemID, send)
+}
+
+// GetSentShares mocks base method.
+func (m *MockDB) GetSentShares(arg0 context.Context, arg1 db.Username) ([]*pb.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentShares", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentShares indicates an expected call of GetSentShares.
+func (mr *MockDBMockRecorder) GetSentShares(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentShares", reflect.TypeOf((*MockDB)(nil).GetSentShares), arg0, arg1)
+}
+
+// GetServerStats mocks base method.
+func (m *MockDB) GetServerStats(arg0 context.Context) (*pb.ServerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServerStats", arg0)
+	ret0, _ := ret[0].(*pb.ServerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServerStats indicates an expected call of GetServerStats.
+func (mr *MockDBMockRecorder) GetServerStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerStats", reflect.TypeOf((*MockDB)(nil).GetServerStats), arg0)
+}
+
+// GetSessionByRefreshHash mocks base method.
+func (m *MockDB) GetSessionByRefreshHash(ctx context.Context, username db.Username, refreshHash string) (*pb.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByRefreshHash", ctx, username, refreshHash)
+	ret0, _ := ret[0].(*pb.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByRefreshHash indicates an expected call of GetSessionByRefreshHash.
+func (mr *MockDBMockRecorder) GetSessionByRefreshHash(ctx, username, refreshHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByRefreshHash", reflect.TypeOf((*MockDB)(nil).GetSessionByRefreshHash), ctx, username, refreshHash)
+}
+
+// GetSessions mocks base method.
+func (m *MockDB) GetSessions(arg0 context.Context, arg1 db.Username) ([]*pb.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockDBMockRecorder) GetSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockDB)(nil).GetSessions), arg0, arg1)
+}
+
+// GetTrashList mocks base method.
+func (m *MockDB) GetTrashList(arg0 context.Context, arg1 db.Username) ([]*pb.ItemShort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashList", arg0, arg1)
+	ret0, _ := ret[0].([]*pb.ItemShort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashList indicates an expected call of GetTrashList.
+func (mr *MockDBMockRecorder) GetTrashList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashList", reflect.TypeOf((*MockDB)(nil).GetTrashList), arg0, arg1)
+}
+
 // GetUserAuthData mocks base method.
 func (m *MockDB) GetUserAuthData(arg0 context.Context, arg1 db.Username) (db.Password, db.OTPKey, error) {
 	m.ctrl.T.Helper()
@@ -252,11 +764,41 @@ func (mr *MockDBMockRecorder) GetUserEKey(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEKey", reflect.TypeOf((*MockDB)(nil).GetUserEKey), arg0, arg1)
 }
 
+// GetUserKeys mocks base method.
+func (m *MockDB) GetUserKeys(arg0 context.Context, arg1 db.Username) ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserKeys", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserKeys indicates an expected call of GetUserKeys.
+func (mr *MockDBMockRecorder) GetUserKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKeys", reflect.TypeOf((*MockDB)(nil).GetUserKeys), arg0, arg1)
+}
+
+// GetUserQuota mocks base method.
+func (m *MockDB) GetUserQuota() db.Quota {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserQuota")
+	ret0, _ := ret[0].(db.Quota)
+	return ret0
+}
+
+// GetUserQuota indicates an expected call of GetUserQuota.
+func (mr *MockDBMockRecorder) GetUserQuota() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserQuota", reflect.TypeOf((*MockDB)(nil).GetUserQuota))
+}
+
 // GetUserRevision mocks base method.
-func (m *MockDB) GetUserRevision(arg0 context.Context, arg1 db.Username) ([]byte, error) {
+func (m *MockDB) GetUserRevision(arg0 context.Context, arg1 db.Username) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserRevision", arg0, arg1)
-	ret0, _ := ret[0].([]byte)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -267,6 +809,236 @@ func (mr *MockDBMockRecorder) GetUserRevision(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRevision", reflect.TypeOf((*MockDB)(nil).GetUserRevision), arg0, arg1)
 }
 
+// GetUserState mocks base method.
+func (m *MockDB) GetUserState(arg0 context.Context, arg1 db.Username) (*db.UserState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserState", arg0, arg1)
+	ret0, _ := ret[0].(*db.UserState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserState indicates an expected call of GetUserState.
+func (mr *MockDBMockRecorder) GetUserState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserState", reflect.TypeOf((*MockDB)(nil).GetUserState), arg0, arg1)
+}
+
+// GetUserTokensRevoked mocks base method.
+func (m *MockDB) GetUserTokensRevoked(arg0 context.Context, arg1 db.Username) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTokensRevoked", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTokensRevoked indicates an expected call of GetUserTokensRevoked.
+func (mr *MockDBMockRecorder) GetUserTokensRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokensRevoked", reflect.TypeOf((*MockDB)(nil).GetUserTokensRevoked), arg0, arg1)
+}
+
+// GetUserUsage mocks base method.
+func (m *MockDB) GetUserUsage(arg0 context.Context, arg1 db.Username) (*db.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserUsage", arg0, arg1)
+	ret0, _ := ret[0].(*db.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserUsage indicates an expected call of GetUserUsage.
+func (mr *MockDBMockRecorder) GetUserUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUsage", reflect.TypeOf((*MockDB)(nil).GetUserUsage), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockDB) ListUsers(arg0 context.Context) ([]*pb.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0)
+	ret0, _ := ret[0].([]*pb.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockDBMockRecorder) ListUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockDB)(nil).ListUsers), arg0)
+}
+
+// PurgeItem mocks base method.
+func (m *MockDB) PurgeItem(ctx context.Context, username db.Username, itemID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeItem", ctx, username, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeItem indicates an expected call of PurgeItem.
+func (mr *MockDBMockRecorder) PurgeItem(ctx, username, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeItem", reflect.TypeOf((*MockDB)(nil).PurgeItem), ctx, username, itemID)
+}
+
+// RejectEmergencyAccess mocks base method.
+func (m *MockDB) RejectEmergencyAccess(ctx context.Context, grantor db.Username, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectEmergencyAccess", ctx, grantor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectEmergencyAccess indicates an expected call of RejectEmergencyAccess.
+func (mr *MockDBMockRecorder) RejectEmergencyAccess(ctx, grantor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectEmergencyAccess", reflect.TypeOf((*MockDB)(nil).RejectEmergencyAccess), ctx, grantor, id)
+}
+
+// ReleaseLoginAttempt mocks base method.
+func (m *MockDB) ReleaseLoginAttempt(ctx context.Context, key string, unlock bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLoginAttempt", ctx, key, unlock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLoginAttempt indicates an expected call of ReleaseLoginAttempt.
+func (mr *MockDBMockRecorder) ReleaseLoginAttempt(ctx, key, unlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLoginAttempt", reflect.TypeOf((*MockDB)(nil).ReleaseLoginAttempt), ctx, key, unlock)
+}
+
+// RemoveMember mocks base method.
+func (m *MockDB) RemoveMember(ctx context.Context, orgID int64, username db.Username) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, orgID, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockDBMockRecorder) RemoveMember(ctx, orgID, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockDB)(nil).RemoveMember), ctx, orgID, username)
+}
+
+// RequestEmergencyAccess mocks base method.
+func (m *MockDB) RequestEmergencyAccess(ctx context.Context, grantee db.Username, id int64, approveAfter time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmergencyAccess", ctx, grantee, id, approveAfter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmergencyAccess indicates an expected call of RequestEmergencyAccess.
+func (mr *MockDBMockRecorder) RequestEmergencyAccess(ctx, grantee, id, approveAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmergencyAccess", reflect.TypeOf((*MockDB)(nil).RequestEmergencyAccess), ctx, grantee, id, approveAfter)
+}
+
+// ReserveLoginAttempt mocks base method.
+func (m *MockDB) ReserveLoginAttempt(ctx context.Context, key string, resetBefore time.Time, lockout db.LoginLockout) (*db.LoginAttempts, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveLoginAttempt", ctx, key, resetBefore, lockout)
+	ret0, _ := ret[0].(*db.LoginAttempts)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReserveLoginAttempt indicates an expected call of ReserveLoginAttempt.
+func (mr *MockDBMockRecorder) ReserveLoginAttempt(ctx, key, resetBefore, lockout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveLoginAttempt", reflect.TypeOf((*MockDB)(nil).ReserveLoginAttempt), ctx, key, resetBefore, lockout)
+}
+
+// ResetTwoFactor mocks base method.
+func (m *MockDB) ResetTwoFactor(arg0 context.Context, arg1 db.Username) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTwoFactor indicates an expected call of ResetTwoFactor.
+func (mr *MockDBMockRecorder) ResetTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTwoFactor", reflect.TypeOf((*MockDB)(nil).ResetTwoFactor), arg0, arg1)
+}
+
+// RestoreItem mocks base method.
+func (m *MockDB) RestoreItem(ctx context.Context, username db.Username, itemID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreItem", ctx, username, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreItem indicates an expected call of RestoreItem.
+func (mr *MockDBMockRecorder) RestoreItem(ctx, username, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItem", reflect.TypeOf((*MockDB)(nil).RestoreItem), ctx, username, itemID)
+}
+
+// RestoreItemVersion mocks base method.
+func (m *MockDB) RestoreItemVersion(ctx context.Context, username db.Username, itemID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreItemVersion", ctx, username, itemID, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreItemVersion indicates an expected call of RestoreItemVersion.
+func (mr *MockDBMockRecorder) RestoreItemVersion(ctx, username, itemID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItemVersion", reflect.TypeOf((*MockDB)(nil).RestoreItemVersion), ctx, username, itemID, version)
+}
+
+// RevokeShare mocks base method.
+func (m *MockDB) RevokeShare(ctx context.Context, owner db.Username, shareID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", ctx, owner, shareID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockDBMockRecorder) RevokeShare(ctx, owner, shareID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockDB)(nil).RevokeShare), ctx, owner, shareID)
+}
+
+// RotateEncryptionKey mocks base method.
+func (m *MockDB) RotateEncryptionKey(ctx context.Context, username db.Username, ekey, privateKey []byte, items []*pb.Item, folders []*pb.Folder, emergencyKeys []*pb.EmergencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEncryptionKey", ctx, username, ekey, privateKey, items, folders, emergencyKeys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateEncryptionKey indicates an expected call of RotateEncryptionKey.
+func (mr *MockDBMockRecorder) RotateEncryptionKey(ctx, username, ekey, privateKey, items, folders, emergencyKeys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryptionKey", reflect.TypeOf((*MockDB)(nil).RotateEncryptionKey), ctx, username, ekey, privateKey, items, folders, emergencyKeys)
+}
+
+// RotateSession mocks base method.
+func (m *MockDB) RotateSession(ctx context.Context, username db.Username, id string, session *pb.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", ctx, username, id, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockDBMockRecorder) RotateSession(ctx, username, id, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockDB)(nil).RotateSession), ctx, username, id, session)
+}
+
 // Run mocks base method.
 func (m *MockDB) Run(arg0 context.Context, arg1 db.CloseChannel) {
 	m.ctrl.T.Helper()
@@ -279,6 +1051,49 @@ func (mr *MockDBMockRecorder) Run(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDB)(nil).Run), arg0, arg1)
 }
 
+// SaveSecretData mocks base method.
+func (m *MockDB) SaveSecretData(ctx context.Context, username db.Username, itemID int64, next db.ChunkReader) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSecretData", ctx, username, itemID, next)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveSecretData indicates an expected call of SaveSecretData.
+func (mr *MockDBMockRecorder) SaveSecretData(ctx, username, itemID, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSecretData", reflect.TypeOf((*MockDB)(nil).SaveSecretData), ctx, username, itemID, next)
+}
+
+// SetUserAdmin mocks base method.
+func (m *MockDB) SetUserAdmin(ctx context.Context, username db.Username, isAdmin bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserAdmin", ctx, username, isAdmin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserAdmin indicates an expected call of SetUserAdmin.
+func (mr *MockDBMockRecorder) SetUserAdmin(ctx, username, isAdmin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAdmin", reflect.TypeOf((*MockDB)(nil).SetUserAdmin), ctx, username, isAdmin)
+}
+
+// SetUserLocked mocks base method.
+func (m *MockDB) SetUserLocked(ctx context.Context, username db.Username, locked bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLocked", ctx, username, locked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserLocked indicates an expected call of SetUserLocked.
+func (mr *MockDBMockRecorder) SetUserLocked(ctx, username, locked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLocked", reflect.TypeOf((*MockDB)(nil).SetUserLocked), ctx, username, locked)
+}
+
 // Setup mocks base method.
 func (m *MockDB) Setup(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -293,6 +1108,63 @@ func (mr *MockDBMockRecorder) Setup(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockDB)(nil).Setup), arg0)
 }
 
+// ShareItem mocks base method.
+func (m *MockDB) ShareItem(ctx context.Context, owner db.Username, share *pb.Share) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareItem", ctx, owner, share)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareItem indicates an expected call of ShareItem.
+func (mr *MockDBMockRecorder) ShareItem(ctx, owner, share interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareItem", reflect.TypeOf((*MockDB)(nil).ShareItem), ctx, owner, share)
+}
+
+// TakeoverAccount mocks base method.
+func (m *MockDB) TakeoverAccount(ctx context.Context, grantee db.Username, id int64, user *pb.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeoverAccount", ctx, grantee, id, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeoverAccount indicates an expected call of TakeoverAccount.
+func (mr *MockDBMockRecorder) TakeoverAccount(ctx, grantee, id, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeoverAccount", reflect.TypeOf((*MockDB)(nil).TakeoverAccount), ctx, grantee, id, user)
+}
+
+// TouchSession mocks base method.
+func (m *MockDB) TouchSession(ctx context.Context, username db.Username, id, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, username, id, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockDBMockRecorder) TouchSession(ctx, username, id, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockDB)(nil).TouchSession), ctx, username, id, clientIP)
+}
+
+// UpdateFolder mocks base method.
+func (m *MockDB) UpdateFolder(arg0 context.Context, arg1 db.Username, arg2 *pb.Folder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFolder indicates an expected call of UpdateFolder.
+func (mr *MockDBMockRecorder) UpdateFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFolder", reflect.TypeOf((*MockDB)(nil).UpdateFolder), arg0, arg1, arg2)
+}
+
 // UpdateItem mocks base method.
 func (m *MockDB) UpdateItem(arg0 context.Context, arg1 db.Username, arg2 *pb.Item) error {
 	m.ctrl.T.Helper()
@@ -307,6 +1179,34 @@ func (mr *MockDBMockRecorder) UpdateItem(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockDB)(nil).UpdateItem), arg0, arg1, arg2)
 }
 
+// UpdateMemberRole mocks base method.
+func (m *MockDB) UpdateMemberRole(ctx context.Context, orgID int64, username db.Username, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, orgID, username, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockDBMockRecorder) UpdateMemberRole(ctx, orgID, username, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockDB)(nil).UpdateMemberRole), ctx, orgID, username, role)
+}
+
+// UpdateOrganization mocks base method.
+func (m *MockDB) UpdateOrganization(arg0 context.Context, arg1 *pb.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockDBMockRecorder) UpdateOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockDB)(nil).UpdateOrganization), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockDB) UpdateUser(arg0 context.Context, arg1 *pb.User) error {
 	m.ctrl.T.Helper()
@@ -334,3 +1234,18 @@ func (mr *MockDBMockRecorder) UpdateUserSecrets(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSecrets", reflect.TypeOf((*MockDB)(nil).UpdateUserSecrets), arg0, arg1)
 }
+
+// WatchRevision mocks base method.
+func (m *MockDB) WatchRevision(ctx context.Context, username db.Username) (<-chan int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchRevision", ctx, username)
+	ret0, _ := ret[0].(<-chan int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchRevision indicates an expected call of WatchRevision.
+func (mr *MockDBMockRecorder) WatchRevision(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRevision", reflect.TypeOf((*MockDB)(nil).WatchRevision), ctx, username)
+}
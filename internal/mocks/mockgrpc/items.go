@@ -11,6 +11,7 @@ import (
 	pb "github.com/artfuldog/gophkeeper/internal/pb"
 	gomock "github.com/golang/mock/gomock"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockItemsClient is a mock of ItemsClient interface.
@@ -36,6 +37,26 @@ func (m *MockItemsClient) EXPECT() *MockItemsClientMockRecorder {
 	return m.recorder
 }
 
+// CreateFolder mocks base method.
+func (m *MockItemsClient) CreateFolder(ctx context.Context, in *pb.CreateFolderRequest, opts ...grpc.CallOption) (*pb.CreateFolderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateFolder", varargs...)
+	ret0, _ := ret[0].(*pb.CreateFolderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockItemsClientMockRecorder) CreateFolder(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockItemsClient)(nil).CreateFolder), varargs...)
+}
+
 // CreateItem mocks base method.
 func (m *MockItemsClient) CreateItem(ctx context.Context, in *pb.CreateItemRequest, opts ...grpc.CallOption) (*pb.CreateItemResponse, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +77,26 @@ func (mr *MockItemsClientMockRecorder) CreateItem(ctx, in interface{}, opts ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemsClient)(nil).CreateItem), varargs...)
 }
 
+// DeleteFolder mocks base method.
+func (m *MockItemsClient) DeleteFolder(ctx context.Context, in *pb.DeleteFolderRequest, opts ...grpc.CallOption) (*pb.DeleteFolderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFolder", varargs...)
+	ret0, _ := ret[0].(*pb.DeleteFolderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockItemsClientMockRecorder) DeleteFolder(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockItemsClient)(nil).DeleteFolder), varargs...)
+}
+
 // DeleteItem mocks base method.
 func (m *MockItemsClient) DeleteItem(ctx context.Context, in *pb.DeleteItemRequest, opts ...grpc.CallOption) (*pb.DeleteItemResponse, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +117,86 @@ func (mr *MockItemsClientMockRecorder) DeleteItem(ctx, in interface{}, opts ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItemsClient)(nil).DeleteItem), varargs...)
 }
 
+// DownloadSecretData mocks base method.
+func (m *MockItemsClient) DownloadSecretData(ctx context.Context, in *pb.DownloadSecretDataRequest, opts ...grpc.CallOption) (pb.Items_DownloadSecretDataClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadSecretData", varargs...)
+	ret0, _ := ret[0].(pb.Items_DownloadSecretDataClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadSecretData indicates an expected call of DownloadSecretData.
+func (mr *MockItemsClientMockRecorder) DownloadSecretData(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSecretData", reflect.TypeOf((*MockItemsClient)(nil).DownloadSecretData), varargs...)
+}
+
+// GetAllItems mocks base method.
+func (m *MockItemsClient) GetAllItems(ctx context.Context, in *pb.GetAllItemsRequest, opts ...grpc.CallOption) (*pb.GetAllItemsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAllItems", varargs...)
+	ret0, _ := ret[0].(*pb.GetAllItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllItems indicates an expected call of GetAllItems.
+func (mr *MockItemsClientMockRecorder) GetAllItems(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllItems", reflect.TypeOf((*MockItemsClient)(nil).GetAllItems), varargs...)
+}
+
+// GetChangesSince mocks base method.
+func (m *MockItemsClient) GetChangesSince(ctx context.Context, in *pb.GetChangesSinceRequest, opts ...grpc.CallOption) (*pb.GetChangesSinceResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetChangesSince", varargs...)
+	ret0, _ := ret[0].(*pb.GetChangesSinceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangesSince indicates an expected call of GetChangesSince.
+func (mr *MockItemsClientMockRecorder) GetChangesSince(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangesSince", reflect.TypeOf((*MockItemsClient)(nil).GetChangesSince), varargs...)
+}
+
+// GetFolders mocks base method.
+func (m *MockItemsClient) GetFolders(ctx context.Context, in *pb.GetFoldersRequest, opts ...grpc.CallOption) (*pb.GetFoldersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFolders", varargs...)
+	ret0, _ := ret[0].(*pb.GetFoldersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockItemsClientMockRecorder) GetFolders(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockItemsClient)(nil).GetFolders), varargs...)
+}
+
 // GetItem mocks base method.
 func (m *MockItemsClient) GetItem(ctx context.Context, in *pb.GetItemRequest, opts ...grpc.CallOption) (*pb.GetItemResponse, error) {
 	m.ctrl.T.Helper()
@@ -156,193 +277,1083 @@ func (mr *MockItemsClientMockRecorder) GetItems(ctx, in interface{}, opts ...int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemsClient)(nil).GetItems), varargs...)
 }
 
-// UpdateItem mocks base method.
-func (m *MockItemsClient) UpdateItem(ctx context.Context, in *pb.UpdateItemRequest, opts ...grpc.CallOption) (*pb.UpdateItemResponse, error) {
+// GetShares mocks base method.
+func (m *MockItemsClient) GetShares(ctx context.Context, in *pb.GetSharesRequest, opts ...grpc.CallOption) (*pb.GetSharesResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "UpdateItem", varargs...)
-	ret0, _ := ret[0].(*pb.UpdateItemResponse)
+	ret := m.ctrl.Call(m, "GetShares", varargs...)
+	ret0, _ := ret[0].(*pb.GetSharesResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateItem indicates an expected call of UpdateItem.
-func (mr *MockItemsClientMockRecorder) UpdateItem(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// GetShares indicates an expected call of GetShares.
+func (mr *MockItemsClientMockRecorder) GetShares(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemsClient)(nil).UpdateItem), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockItemsClient)(nil).GetShares), varargs...)
 }
 
-// MockItemsServer is a mock of ItemsServer interface.
-type MockItemsServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockItemsServerMockRecorder
+// ListItemVersions mocks base method.
+func (m *MockItemsClient) ListItemVersions(ctx context.Context, in *pb.ListItemVersionsRequest, opts ...grpc.CallOption) (*pb.ListItemVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListItemVersions", varargs...)
+	ret0, _ := ret[0].(*pb.ListItemVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockItemsServerMockRecorder is the mock recorder for MockItemsServer.
-type MockItemsServerMockRecorder struct {
-	mock *MockItemsServer
+// ListItemVersions indicates an expected call of ListItemVersions.
+func (mr *MockItemsClientMockRecorder) ListItemVersions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemVersions", reflect.TypeOf((*MockItemsClient)(nil).ListItemVersions), varargs...)
 }
 
-// NewMockItemsServer creates a new mock instance.
-func NewMockItemsServer(ctrl *gomock.Controller) *MockItemsServer {
-	mock := &MockItemsServer{ctrl: ctrl}
-	mock.recorder = &MockItemsServerMockRecorder{mock}
-	return mock
+// ListTrash mocks base method.
+func (m *MockItemsClient) ListTrash(ctx context.Context, in *pb.ListTrashRequest, opts ...grpc.CallOption) (*pb.ListTrashResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrash", varargs...)
+	ret0, _ := ret[0].(*pb.ListTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockItemsServer) EXPECT() *MockItemsServerMockRecorder {
-	return m.recorder
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockItemsClientMockRecorder) ListTrash(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockItemsClient)(nil).ListTrash), varargs...)
 }
 
-// CreateItem mocks base method.
-func (m *MockItemsServer) CreateItem(arg0 context.Context, arg1 *pb.CreateItemRequest) (*pb.CreateItemResponse, error) {
+// PurgeItem mocks base method.
+func (m *MockItemsClient) PurgeItem(ctx context.Context, in *pb.PurgeItemRequest, opts ...grpc.CallOption) (*pb.PurgeItemResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateItem", arg0, arg1)
-	ret0, _ := ret[0].(*pb.CreateItemResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PurgeItem", varargs...)
+	ret0, _ := ret[0].(*pb.PurgeItemResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateItem indicates an expected call of CreateItem.
-func (mr *MockItemsServerMockRecorder) CreateItem(arg0, arg1 interface{}) *gomock.Call {
+// PurgeItem indicates an expected call of PurgeItem.
+func (mr *MockItemsClientMockRecorder) PurgeItem(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemsServer)(nil).CreateItem), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeItem", reflect.TypeOf((*MockItemsClient)(nil).PurgeItem), varargs...)
 }
 
-// DeleteItem mocks base method.
-func (m *MockItemsServer) DeleteItem(arg0 context.Context, arg1 *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
+// RestoreItem mocks base method.
+func (m *MockItemsClient) RestoreItem(ctx context.Context, in *pb.RestoreItemRequest, opts ...grpc.CallOption) (*pb.RestoreItemResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteItem", arg0, arg1)
-	ret0, _ := ret[0].(*pb.DeleteItemResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreItem", varargs...)
+	ret0, _ := ret[0].(*pb.RestoreItemResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteItem indicates an expected call of DeleteItem.
-func (mr *MockItemsServerMockRecorder) DeleteItem(arg0, arg1 interface{}) *gomock.Call {
+// RestoreItem indicates an expected call of RestoreItem.
+func (mr *MockItemsClientMockRecorder) RestoreItem(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItemsServer)(nil).DeleteItem), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItem", reflect.TypeOf((*MockItemsClient)(nil).RestoreItem), varargs...)
 }
 
-// GetItem mocks base method.
-func (m *MockItemsServer) GetItem(arg0 context.Context, arg1 *pb.GetItemRequest) (*pb.GetItemResponse, error) {
+// RestoreItemVersion mocks base method.
+func (m *MockItemsClient) RestoreItemVersion(ctx context.Context, in *pb.RestoreItemVersionRequest, opts ...grpc.CallOption) (*pb.RestoreItemVersionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItem", arg0, arg1)
-	ret0, _ := ret[0].(*pb.GetItemResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreItemVersion", varargs...)
+	ret0, _ := ret[0].(*pb.RestoreItemVersionResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetItem indicates an expected call of GetItem.
-func (mr *MockItemsServerMockRecorder) GetItem(arg0, arg1 interface{}) *gomock.Call {
+// RestoreItemVersion indicates an expected call of RestoreItemVersion.
+func (mr *MockItemsClientMockRecorder) RestoreItemVersion(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockItemsServer)(nil).GetItem), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItemVersion", reflect.TypeOf((*MockItemsClient)(nil).RestoreItemVersion), varargs...)
 }
 
-// GetItemHash mocks base method.
-func (m *MockItemsServer) GetItemHash(arg0 context.Context, arg1 *pb.GetItemHashRequest) (*pb.GetItemHashResponse, error) {
+// RevokeShare mocks base method.
+func (m *MockItemsClient) RevokeShare(ctx context.Context, in *pb.RevokeShareRequest, opts ...grpc.CallOption) (*pb.RevokeShareResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItemHash", arg0, arg1)
-	ret0, _ := ret[0].(*pb.GetItemHashResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeShare", varargs...)
+	ret0, _ := ret[0].(*pb.RevokeShareResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetItemHash indicates an expected call of GetItemHash.
-func (mr *MockItemsServerMockRecorder) GetItemHash(arg0, arg1 interface{}) *gomock.Call {
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockItemsClientMockRecorder) RevokeShare(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemHash", reflect.TypeOf((*MockItemsServer)(nil).GetItemHash), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockItemsClient)(nil).RevokeShare), varargs...)
 }
 
-// GetItemList mocks base method.
-func (m *MockItemsServer) GetItemList(arg0 context.Context, arg1 *pb.GetItemListRequest) (*pb.GetItemListResponse, error) {
+// RotateEncryptionKey mocks base method.
+func (m *MockItemsClient) RotateEncryptionKey(ctx context.Context, in *pb.RotateEncryptionKeyRequest, opts ...grpc.CallOption) (*pb.RotateEncryptionKeyResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItemList", arg0, arg1)
-	ret0, _ := ret[0].(*pb.GetItemListResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RotateEncryptionKey", varargs...)
+	ret0, _ := ret[0].(*pb.RotateEncryptionKeyResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetItemList indicates an expected call of GetItemList.
-func (mr *MockItemsServerMockRecorder) GetItemList(arg0, arg1 interface{}) *gomock.Call {
+// RotateEncryptionKey indicates an expected call of RotateEncryptionKey.
+func (mr *MockItemsClientMockRecorder) RotateEncryptionKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemList", reflect.TypeOf((*MockItemsServer)(nil).GetItemList), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryptionKey", reflect.TypeOf((*MockItemsClient)(nil).RotateEncryptionKey), varargs...)
 }
 
-// GetItems mocks base method.
-func (m *MockItemsServer) GetItems(arg0 context.Context, arg1 *pb.GetItemsRequest) (*pb.GetItemsResponse, error) {
+// ShareItem mocks base method.
+func (m *MockItemsClient) ShareItem(ctx context.Context, in *pb.ShareItemRequest, opts ...grpc.CallOption) (*pb.ShareItemResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItems", arg0, arg1)
-	ret0, _ := ret[0].(*pb.GetItemsResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ShareItem", varargs...)
+	ret0, _ := ret[0].(*pb.ShareItemResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetItems indicates an expected call of GetItems.
-func (mr *MockItemsServerMockRecorder) GetItems(arg0, arg1 interface{}) *gomock.Call {
+// ShareItem indicates an expected call of ShareItem.
+func (mr *MockItemsClientMockRecorder) ShareItem(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemsServer)(nil).GetItems), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareItem", reflect.TypeOf((*MockItemsClient)(nil).ShareItem), varargs...)
+}
+
+// UpdateFolder mocks base method.
+func (m *MockItemsClient) UpdateFolder(ctx context.Context, in *pb.UpdateFolderRequest, opts ...grpc.CallOption) (*pb.UpdateFolderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFolder", varargs...)
+	ret0, _ := ret[0].(*pb.UpdateFolderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFolder indicates an expected call of UpdateFolder.
+func (mr *MockItemsClientMockRecorder) UpdateFolder(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFolder", reflect.TypeOf((*MockItemsClient)(nil).UpdateFolder), varargs...)
 }
 
 // UpdateItem mocks base method.
-func (m *MockItemsServer) UpdateItem(arg0 context.Context, arg1 *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+func (m *MockItemsClient) UpdateItem(ctx context.Context, in *pb.UpdateItemRequest, opts ...grpc.CallOption) (*pb.UpdateItemResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItem", arg0, arg1)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateItem", varargs...)
 	ret0, _ := ret[0].(*pb.UpdateItemResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateItem indicates an expected call of UpdateItem.
-func (mr *MockItemsServerMockRecorder) UpdateItem(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockItemsClientMockRecorder) UpdateItem(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemsServer)(nil).UpdateItem), arg0, arg1)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemsClient)(nil).UpdateItem), varargs...)
 }
 
-// mustEmbedUnimplementedItemsServer mocks base method.
-func (m *MockItemsServer) mustEmbedUnimplementedItemsServer() {
+// UploadSecretData mocks base method.
+func (m *MockItemsClient) UploadSecretData(ctx context.Context, opts ...grpc.CallOption) (pb.Items_UploadSecretDataClient, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedItemsServer")
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadSecretData", varargs...)
+	ret0, _ := ret[0].(pb.Items_UploadSecretDataClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// mustEmbedUnimplementedItemsServer indicates an expected call of mustEmbedUnimplementedItemsServer.
-func (mr *MockItemsServerMockRecorder) mustEmbedUnimplementedItemsServer() *gomock.Call {
+// UploadSecretData indicates an expected call of UploadSecretData.
+func (mr *MockItemsClientMockRecorder) UploadSecretData(ctx interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedItemsServer", reflect.TypeOf((*MockItemsServer)(nil).mustEmbedUnimplementedItemsServer))
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadSecretData", reflect.TypeOf((*MockItemsClient)(nil).UploadSecretData), varargs...)
 }
 
-// MockUnsafeItemsServer is a mock of UnsafeItemsServer interface.
-type MockUnsafeItemsServer struct {
+// WatchVault mocks base method.
+func (m *MockItemsClient) WatchVault(ctx context.Context, in *pb.WatchVaultRequest, opts ...grpc.CallOption) (pb.Items_WatchVaultClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchVault", varargs...)
+	ret0, _ := ret[0].(pb.Items_WatchVaultClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchVault indicates an expected call of WatchVault.
+func (mr *MockItemsClientMockRecorder) WatchVault(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchVault", reflect.TypeOf((*MockItemsClient)(nil).WatchVault), varargs...)
+}
+
+// MockItems_UploadSecretDataClient is a mock of Items_UploadSecretDataClient interface.
+type MockItems_UploadSecretDataClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockUnsafeItemsServerMockRecorder
+	recorder *MockItems_UploadSecretDataClientMockRecorder
 }
 
-// MockUnsafeItemsServerMockRecorder is the mock recorder for MockUnsafeItemsServer.
-type MockUnsafeItemsServerMockRecorder struct {
-	mock *MockUnsafeItemsServer
+// MockItems_UploadSecretDataClientMockRecorder is the mock recorder for MockItems_UploadSecretDataClient.
+type MockItems_UploadSecretDataClientMockRecorder struct {
+	mock *MockItems_UploadSecretDataClient
 }
 
-// NewMockUnsafeItemsServer creates a new mock instance.
-func NewMockUnsafeItemsServer(ctrl *gomock.Controller) *MockUnsafeItemsServer {
-	mock := &MockUnsafeItemsServer{ctrl: ctrl}
-	mock.recorder = &MockUnsafeItemsServerMockRecorder{mock}
+// NewMockItems_UploadSecretDataClient creates a new mock instance.
+func NewMockItems_UploadSecretDataClient(ctrl *gomock.Controller) *MockItems_UploadSecretDataClient {
+	mock := &MockItems_UploadSecretDataClient{ctrl: ctrl}
+	mock.recorder = &MockItems_UploadSecretDataClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUnsafeItemsServer) EXPECT() *MockUnsafeItemsServerMockRecorder {
+func (m *MockItems_UploadSecretDataClient) EXPECT() *MockItems_UploadSecretDataClientMockRecorder {
 	return m.recorder
 }
 
-// mustEmbedUnimplementedItemsServer mocks base method.
-func (m *MockUnsafeItemsServer) mustEmbedUnimplementedItemsServer() {
+// CloseAndRecv mocks base method.
+func (m *MockItems_UploadSecretDataClient) CloseAndRecv() (*pb.UploadSecretDataResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedItemsServer")
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*pb.UploadSecretDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockItems_UploadSecretDataClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockItems_UploadSecretDataClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockItems_UploadSecretDataClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockItems_UploadSecretDataClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockItems_UploadSecretDataClient) Send(arg0 *pb.UploadSecretDataRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockItems_UploadSecretDataClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockItems_UploadSecretDataClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockItems_UploadSecretDataClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockItems_UploadSecretDataClient)(nil).Trailer))
+}
+
+// MockItems_DownloadSecretDataClient is a mock of Items_DownloadSecretDataClient interface.
+type MockItems_DownloadSecretDataClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockItems_DownloadSecretDataClientMockRecorder
+}
+
+// MockItems_DownloadSecretDataClientMockRecorder is the mock recorder for MockItems_DownloadSecretDataClient.
+type MockItems_DownloadSecretDataClientMockRecorder struct {
+	mock *MockItems_DownloadSecretDataClient
+}
+
+// NewMockItems_DownloadSecretDataClient creates a new mock instance.
+func NewMockItems_DownloadSecretDataClient(ctrl *gomock.Controller) *MockItems_DownloadSecretDataClient {
+	mock := &MockItems_DownloadSecretDataClient{ctrl: ctrl}
+	mock.recorder = &MockItems_DownloadSecretDataClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItems_DownloadSecretDataClient) EXPECT() *MockItems_DownloadSecretDataClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockItems_DownloadSecretDataClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockItems_DownloadSecretDataClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockItems_DownloadSecretDataClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockItems_DownloadSecretDataClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockItems_DownloadSecretDataClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockItems_DownloadSecretDataClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockItems_DownloadSecretDataClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockItems_DownloadSecretDataClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockItems_DownloadSecretDataClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockItems_DownloadSecretDataClient) Recv() (*pb.DownloadSecretDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*pb.DownloadSecretDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockItems_DownloadSecretDataClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockItems_DownloadSecretDataClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockItems_DownloadSecretDataClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockItems_DownloadSecretDataClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockItems_DownloadSecretDataClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockItems_DownloadSecretDataClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockItems_DownloadSecretDataClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockItems_DownloadSecretDataClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockItems_DownloadSecretDataClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockItems_DownloadSecretDataClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockItems_DownloadSecretDataClient)(nil).Trailer))
+}
+
+// MockItems_WatchVaultClient is a mock of Items_WatchVaultClient interface.
+type MockItems_WatchVaultClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockItems_WatchVaultClientMockRecorder
+}
+
+// MockItems_WatchVaultClientMockRecorder is the mock recorder for MockItems_WatchVaultClient.
+type MockItems_WatchVaultClientMockRecorder struct {
+	mock *MockItems_WatchVaultClient
+}
+
+// NewMockItems_WatchVaultClient creates a new mock instance.
+func NewMockItems_WatchVaultClient(ctrl *gomock.Controller) *MockItems_WatchVaultClient {
+	mock := &MockItems_WatchVaultClient{ctrl: ctrl}
+	mock.recorder = &MockItems_WatchVaultClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItems_WatchVaultClient) EXPECT() *MockItems_WatchVaultClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockItems_WatchVaultClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockItems_WatchVaultClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockItems_WatchVaultClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockItems_WatchVaultClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockItems_WatchVaultClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockItems_WatchVaultClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockItems_WatchVaultClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockItems_WatchVaultClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockItems_WatchVaultClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockItems_WatchVaultClient) Recv() (*pb.WatchVaultResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*pb.WatchVaultResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockItems_WatchVaultClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockItems_WatchVaultClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockItems_WatchVaultClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockItems_WatchVaultClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockItems_WatchVaultClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockItems_WatchVaultClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockItems_WatchVaultClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockItems_WatchVaultClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockItems_WatchVaultClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockItems_WatchVaultClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockItems_WatchVaultClient)(nil).Trailer))
+}
+
+// MockItemsServer is a mock of ItemsServer interface.
+type MockItemsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemsServerMockRecorder
+}
+
+// MockItemsServerMockRecorder is the mock recorder for MockItemsServer.
+type MockItemsServerMockRecorder struct {
+	mock *MockItemsServer
+}
+
+// NewMockItemsServer creates a new mock instance.
+func NewMockItemsServer(ctrl *gomock.Controller) *MockItemsServer {
+	mock := &MockItemsServer{ctrl: ctrl}
+	mock.recorder = &MockItemsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemsServer) EXPECT() *MockItemsServerMockRecorder {
+	return m.recorder
+}
+
+// CreateFolder mocks base method.
+func (m *MockItemsServer) CreateFolder(arg0 context.Context, arg1 *pb.CreateFolderRequest) (*pb.CreateFolderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", arg0, arg1)
+	ret0, _ := ret[0].(*pb.CreateFolderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockItemsServerMockRecorder) CreateFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockItemsServer)(nil).CreateFolder), arg0, arg1)
+}
+
+// CreateItem mocks base method.
+func (m *MockItemsServer) CreateItem(arg0 context.Context, arg1 *pb.CreateItemRequest) (*pb.CreateItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", arg0, arg1)
+	ret0, _ := ret[0].(*pb.CreateItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockItemsServerMockRecorder) CreateItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemsServer)(nil).CreateItem), arg0, arg1)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockItemsServer) DeleteFolder(arg0 context.Context, arg1 *pb.DeleteFolderRequest) (*pb.DeleteFolderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", arg0, arg1)
+	ret0, _ := ret[0].(*pb.DeleteFolderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockItemsServerMockRecorder) DeleteFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockItemsServer)(nil).DeleteFolder), arg0, arg1)
+}
+
+// DeleteItem mocks base method.
+func (m *MockItemsServer) DeleteItem(arg0 context.Context, arg1 *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", arg0, arg1)
+	ret0, _ := ret[0].(*pb.DeleteItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockItemsServerMockRecorder) DeleteItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItemsServer)(nil).DeleteItem), arg0, arg1)
+}
+
+// DownloadSecretData mocks base method.
+func (m *MockItemsServer) DownloadSecretData(arg0 *pb.DownloadSecretDataRequest, arg1 pb.Items_DownloadSecretDataServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadSecretData", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadSecretData indicates an expected call of DownloadSecretData.
+func (mr *MockItemsServerMockRecorder) DownloadSecretData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSecretData", reflect.TypeOf((*MockItemsServer)(nil).DownloadSecretData), arg0, arg1)
+}
+
+// GetAllItems mocks base method.
+func (m *MockItemsServer) GetAllItems(arg0 context.Context, arg1 *pb.GetAllItemsRequest) (*pb.GetAllItemsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllItems", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetAllItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllItems indicates an expected call of GetAllItems.
+func (mr *MockItemsServerMockRecorder) GetAllItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllItems", reflect.TypeOf((*MockItemsServer)(nil).GetAllItems), arg0, arg1)
+}
+
+// GetChangesSince mocks base method.
+func (m *MockItemsServer) GetChangesSince(arg0 context.Context, arg1 *pb.GetChangesSinceRequest) (*pb.GetChangesSinceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangesSince", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetChangesSinceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangesSince indicates an expected call of GetChangesSince.
+func (mr *MockItemsServerMockRecorder) GetChangesSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangesSince", reflect.TypeOf((*MockItemsServer)(nil).GetChangesSince), arg0, arg1)
+}
+
+// GetFolders mocks base method.
+func (m *MockItemsServer) GetFolders(arg0 context.Context, arg1 *pb.GetFoldersRequest) (*pb.GetFoldersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolders", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetFoldersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockItemsServerMockRecorder) GetFolders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockItemsServer)(nil).GetFolders), arg0, arg1)
+}
+
+// GetItem mocks base method.
+func (m *MockItemsServer) GetItem(arg0 context.Context, arg1 *pb.GetItemRequest) (*pb.GetItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItem", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItem indicates an expected call of GetItem.
+func (mr *MockItemsServerMockRecorder) GetItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockItemsServer)(nil).GetItem), arg0, arg1)
+}
+
+// GetItemHash mocks base method.
+func (m *MockItemsServer) GetItemHash(arg0 context.Context, arg1 *pb.GetItemHashRequest) (*pb.GetItemHashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemHash", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetItemHashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemHash indicates an expected call of GetItemHash.
+func (mr *MockItemsServerMockRecorder) GetItemHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemHash", reflect.TypeOf((*MockItemsServer)(nil).GetItemHash), arg0, arg1)
+}
+
+// GetItemList mocks base method.
+func (m *MockItemsServer) GetItemList(arg0 context.Context, arg1 *pb.GetItemListRequest) (*pb.GetItemListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemList", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetItemListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemList indicates an expected call of GetItemList.
+func (mr *MockItemsServerMockRecorder) GetItemList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemList", reflect.TypeOf((*MockItemsServer)(nil).GetItemList), arg0, arg1)
+}
+
+// GetItems mocks base method.
+func (m *MockItemsServer) GetItems(arg0 context.Context, arg1 *pb.GetItemsRequest) (*pb.GetItemsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockItemsServerMockRecorder) GetItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemsServer)(nil).GetItems), arg0, arg1)
+}
+
+// GetShares mocks base method.
+func (m *MockItemsServer) GetShares(arg0 context.Context, arg1 *pb.GetSharesRequest) (*pb.GetSharesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetSharesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShares indicates an expected call of GetShares.
+func (mr *MockItemsServerMockRecorder) GetShares(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockItemsServer)(nil).GetShares), arg0, arg1)
+}
+
+// ListItemVersions mocks base method.
+func (m *MockItemsServer) ListItemVersions(arg0 context.Context, arg1 *pb.ListItemVersionsRequest) (*pb.ListItemVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItemVersions", arg0, arg1)
+	ret0, _ := ret[0].(*pb.ListItemVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItemVersions indicates an expected call of ListItemVersions.
+func (mr *MockItemsServerMockRecorder) ListItemVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemVersions", reflect.TypeOf((*MockItemsServer)(nil).ListItemVersions), arg0, arg1)
+}
+
+// ListTrash mocks base method.
+func (m *MockItemsServer) ListTrash(arg0 context.Context, arg1 *pb.ListTrashRequest) (*pb.ListTrashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", arg0, arg1)
+	ret0, _ := ret[0].(*pb.ListTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockItemsServerMockRecorder) ListTrash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockItemsServer)(nil).ListTrash), arg0, arg1)
+}
+
+// PurgeItem mocks base method.
+func (m *MockItemsServer) PurgeItem(arg0 context.Context, arg1 *pb.PurgeItemRequest) (*pb.PurgeItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeItem", arg0, arg1)
+	ret0, _ := ret[0].(*pb.PurgeItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeItem indicates an expected call of PurgeItem.
+func (mr *MockItemsServerMockRecorder) PurgeItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeItem", reflect.TypeOf((*MockItemsServer)(nil).PurgeItem), arg0, arg1)
+}
+
+// RestoreItem mocks base method.
+func (m *MockItemsServer) RestoreItem(arg0 context.Context, arg1 *pb.RestoreItemRequest) (*pb.RestoreItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreItem", arg0, arg1)
+	ret0, _ := ret[0].(*pb.RestoreItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreItem indicates an expected call of RestoreItem.
+func (mr *MockItemsServerMockRecorder) RestoreItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItem", reflect.TypeOf((*MockItemsServer)(nil).RestoreItem), arg0, arg1)
+}
+
+// RestoreItemVersion mocks base method.
+func (m *MockItemsServer) RestoreItemVersion(arg0 context.Context, arg1 *pb.RestoreItemVersionRequest) (*pb.RestoreItemVersionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreItemVersion", arg0, arg1)
+	ret0, _ := ret[0].(*pb.RestoreItemVersionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreItemVersion indicates an expected call of RestoreItemVersion.
+func (mr *MockItemsServerMockRecorder) RestoreItemVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItemVersion", reflect.TypeOf((*MockItemsServer)(nil).RestoreItemVersion), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockItemsServer) RevokeShare(arg0 context.Context, arg1 *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1)
+	ret0, _ := ret[0].(*pb.RevokeShareResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockItemsServerMockRecorder) RevokeShare(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockItemsServer)(nil).RevokeShare), arg0, arg1)
+}
+
+// RotateEncryptionKey mocks base method.
+func (m *MockItemsServer) RotateEncryptionKey(arg0 context.Context, arg1 *pb.RotateEncryptionKeyRequest) (*pb.RotateEncryptionKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEncryptionKey", arg0, arg1)
+	ret0, _ := ret[0].(*pb.RotateEncryptionKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEncryptionKey indicates an expected call of RotateEncryptionKey.
+func (mr *MockItemsServerMockRecorder) RotateEncryptionKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryptionKey", reflect.TypeOf((*MockItemsServer)(nil).RotateEncryptionKey), arg0, arg1)
+}
+
+// ShareItem mocks base method.
+func (m *MockItemsServer) ShareItem(arg0 context.Context, arg1 *pb.ShareItemRequest) (*pb.ShareItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareItem", arg0, arg1)
+	ret0, _ := ret[0].(*pb.ShareItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareItem indicates an expected call of ShareItem.
+func (mr *MockItemsServerMockRecorder) ShareItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareItem", reflect.TypeOf((*MockItemsServer)(nil).ShareItem), arg0, arg1)
+}
+
+// UpdateFolder mocks base method.
+func (m *MockItemsServer) UpdateFolder(arg0 context.Context, arg1 *pb.UpdateFolderRequest) (*pb.UpdateFolderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFolder", arg0, arg1)
+	ret0, _ := ret[0].(*pb.UpdateFolderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFolder indicates an expected call of UpdateFolder.
+func (mr *MockItemsServerMockRecorder) UpdateFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFolder", reflect.TypeOf((*MockItemsServer)(nil).UpdateFolder), arg0, arg1)
+}
+
+// UpdateItem mocks base method.
+func (m *MockItemsServer) UpdateItem(arg0 context.Context, arg1 *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", arg0, arg1)
+	ret0, _ := ret[0].(*pb.UpdateItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockItemsServerMockRecorder) UpdateItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemsServer)(nil).UpdateItem), arg0, arg1)
+}
+
+// UploadSecretData mocks base method.
+func (m *MockItemsServer) UploadSecretData(arg0 pb.Items_UploadSecretDataServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadSecretData", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadSecretData indicates an expected call of UploadSecretData.
+func (mr *MockItemsServerMockRecorder) UploadSecretData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadSecretData", reflect.TypeOf((*MockItemsServer)(nil).UploadSecretData), arg0)
+}
+
+// WatchVault mocks base method.
+func (m *MockItemsServer) WatchVault(arg0 *pb.WatchVaultRequest, arg1 pb.Items_WatchVaultServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchVault", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchVault indicates an expected call of WatchVault.
+func (mr *MockItemsServerMockRecorder) WatchVault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchVault", reflect.TypeOf((*MockItemsServer)(nil).WatchVault), arg0, arg1)
+}
+
+// mustEmbedUnimplementedItemsServer mocks base method.
+func (m *MockItemsServer) mustEmbedUnimplementedItemsServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedItemsServer")
+}
+
+// mustEmbedUnimplementedItemsServer indicates an expected call of mustEmbedUnimplementedItemsServer.
+func (mr *MockItemsServerMockRecorder) mustEmbedUnimplementedItemsServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedItemsServer", reflect.TypeOf((*MockItemsServer)(nil).mustEmbedUnimplementedItemsServer))
+}
+
+// MockUnsafeItemsServer is a mock of UnsafeItemsServer interface.
+type MockUnsafeItemsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeItemsServerMockRecorder
+}
+
+// MockUnsafeItemsServerMockRecorder is the mock recorder for MockUnsafeItemsServer.
+type MockUnsafeItemsServerMockRecorder struct {
+	mock *MockUnsafeItemsServer
+}
+
+// NewMockUnsafeItemsServer creates a new mock instance.
+func NewMockUnsafeItemsServer(ctrl *gomock.Controller) *MockUnsafeItemsServer {
+	mock := &MockUnsafeItemsServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeItemsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeItemsServer) EXPECT() *MockUnsafeItemsServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedItemsServer mocks base method.
+func (m *MockUnsafeItemsServer) mustEmbedUnimplementedItemsServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedItemsServer")
 }
 
 // mustEmbedUnimplementedItemsServer indicates an expected call of mustEmbedUnimplementedItemsServer.
@@ -350,3 +1361,375 @@ func (mr *MockUnsafeItemsServerMockRecorder) mustEmbedUnimplementedItemsServer()
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedItemsServer", reflect.TypeOf((*MockUnsafeItemsServer)(nil).mustEmbedUnimplementedItemsServer))
 }
+
+// MockItems_UploadSecretDataServer is a mock of Items_UploadSecretDataServer interface.
+type MockItems_UploadSecretDataServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockItems_UploadSecretDataServerMockRecorder
+}
+
+// MockItems_UploadSecretDataServerMockRecorder is the mock recorder for MockItems_UploadSecretDataServer.
+type MockItems_UploadSecretDataServerMockRecorder struct {
+	mock *MockItems_UploadSecretDataServer
+}
+
+// NewMockItems_UploadSecretDataServer creates a new mock instance.
+func NewMockItems_UploadSecretDataServer(ctrl *gomock.Controller) *MockItems_UploadSecretDataServer {
+	mock := &MockItems_UploadSecretDataServer{ctrl: ctrl}
+	mock.recorder = &MockItems_UploadSecretDataServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItems_UploadSecretDataServer) EXPECT() *MockItems_UploadSecretDataServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockItems_UploadSecretDataServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockItems_UploadSecretDataServer) Recv() (*pb.UploadSecretDataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*pb.UploadSecretDataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockItems_UploadSecretDataServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockItems_UploadSecretDataServer) SendAndClose(arg0 *pb.UploadSecretDataResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockItems_UploadSecretDataServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockItems_UploadSecretDataServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockItems_UploadSecretDataServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockItems_UploadSecretDataServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockItems_UploadSecretDataServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockItems_UploadSecretDataServer)(nil).SetTrailer), arg0)
+}
+
+// MockItems_DownloadSecretDataServer is a mock of Items_DownloadSecretDataServer interface.
+type MockItems_DownloadSecretDataServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockItems_DownloadSecretDataServerMockRecorder
+}
+
+// MockItems_DownloadSecretDataServerMockRecorder is the mock recorder for MockItems_DownloadSecretDataServer.
+type MockItems_DownloadSecretDataServerMockRecorder struct {
+	mock *MockItems_DownloadSecretDataServer
+}
+
+// NewMockItems_DownloadSecretDataServer creates a new mock instance.
+func NewMockItems_DownloadSecretDataServer(ctrl *gomock.Controller) *MockItems_DownloadSecretDataServer {
+	mock := &MockItems_DownloadSecretDataServer{ctrl: ctrl}
+	mock.recorder = &MockItems_DownloadSecretDataServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItems_DownloadSecretDataServer) EXPECT() *MockItems_DownloadSecretDataServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockItems_DownloadSecretDataServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockItems_DownloadSecretDataServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockItems_DownloadSecretDataServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockItems_DownloadSecretDataServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockItems_DownloadSecretDataServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockItems_DownloadSecretDataServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockItems_DownloadSecretDataServer) Send(arg0 *pb.DownloadSecretDataResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockItems_DownloadSecretDataServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockItems_DownloadSecretDataServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockItems_DownloadSecretDataServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockItems_DownloadSecretDataServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockItems_DownloadSecretDataServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockItems_DownloadSecretDataServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockItems_DownloadSecretDataServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockItems_DownloadSecretDataServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockItems_DownloadSecretDataServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockItems_DownloadSecretDataServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockItems_DownloadSecretDataServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockItems_DownloadSecretDataServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockItems_DownloadSecretDataServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockItems_DownloadSecretDataServer)(nil).SetTrailer), arg0)
+}
+
+// MockItems_WatchVaultServer is a mock of Items_WatchVaultServer interface.
+type MockItems_WatchVaultServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockItems_WatchVaultServerMockRecorder
+}
+
+// MockItems_WatchVaultServerMockRecorder is the mock recorder for MockItems_WatchVaultServer.
+type MockItems_WatchVaultServerMockRecorder struct {
+	mock *MockItems_WatchVaultServer
+}
+
+// NewMockItems_WatchVaultServer creates a new mock instance.
+func NewMockItems_WatchVaultServer(ctrl *gomock.Controller) *MockItems_WatchVaultServer {
+	mock := &MockItems_WatchVaultServer{ctrl: ctrl}
+	mock.recorder = &MockItems_WatchVaultServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItems_WatchVaultServer) EXPECT() *MockItems_WatchVaultServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockItems_WatchVaultServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockItems_WatchVaultServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockItems_WatchVaultServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockItems_WatchVaultServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockItems_WatchVaultServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockItems_WatchVaultServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockItems_WatchVaultServer) Send(arg0 *pb.WatchVaultResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockItems_WatchVaultServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockItems_WatchVaultServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockItems_WatchVaultServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockItems_WatchVaultServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockItems_WatchVaultServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockItems_WatchVaultServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockItems_WatchVaultServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockItems_WatchVaultServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockItems_WatchVaultServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockItems_WatchVaultServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockItems_WatchVaultServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockItems_WatchVaultServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockItems_WatchVaultServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockItems_WatchVaultServer)(nil).SetTrailer), arg0)
+}
@@ -36,6 +36,26 @@ func (m *MockUsersClient) EXPECT() *MockUsersClientMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUsersClient) ChangePassword(ctx context.Context, in *pb.ChangePasswordRequest, opts ...grpc.CallOption) (*pb.ChangePasswordResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangePassword", varargs...)
+	ret0, _ := ret[0].(*pb.ChangePasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUsersClientMockRecorder) ChangePassword(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUsersClient)(nil).ChangePassword), varargs...)
+}
+
 // CreateUser mocks base method.
 func (m *MockUsersClient) CreateUser(ctx context.Context, in *pb.CreateUserRequest, opts ...grpc.CallOption) (*pb.CreateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +96,26 @@ func (mr *MockUsersClientMockRecorder) DeleteUser(ctx, in interface{}, opts ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUsersClient)(nil).DeleteUser), varargs...)
 }
 
+// GetPublicKey mocks base method.
+func (m *MockUsersClient) GetPublicKey(ctx context.Context, in *pb.GetPublicKeyRequest, opts ...grpc.CallOption) (*pb.GetPublicKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPublicKey", varargs...)
+	ret0, _ := ret[0].(*pb.GetPublicKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockUsersClientMockRecorder) GetPublicKey(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockUsersClient)(nil).GetPublicKey), varargs...)
+}
+
 // GetRevision mocks base method.
 func (m *MockUsersClient) GetRevision(ctx context.Context, in *pb.GetRevisionRequest, opts ...grpc.CallOption) (*pb.GetRevisionResponse, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +136,26 @@ func (mr *MockUsersClientMockRecorder) GetRevision(ctx, in interface{}, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockUsersClient)(nil).GetRevision), varargs...)
 }
 
+// GetServerLimits mocks base method.
+func (m *MockUsersClient) GetServerLimits(ctx context.Context, in *pb.GetServerLimitsRequest, opts ...grpc.CallOption) (*pb.GetServerLimitsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetServerLimits", varargs...)
+	ret0, _ := ret[0].(*pb.GetServerLimitsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServerLimits indicates an expected call of GetServerLimits.
+func (mr *MockUsersClientMockRecorder) GetServerLimits(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerLimits", reflect.TypeOf((*MockUsersClient)(nil).GetServerLimits), varargs...)
+}
+
 // GetUser mocks base method.
 func (m *MockUsersClient) GetUser(ctx context.Context, in *pb.GetUserRequest, opts ...grpc.CallOption) (*pb.GetUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +176,106 @@ func (mr *MockUsersClientMockRecorder) GetUser(ctx, in interface{}, opts ...inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUsersClient)(nil).GetUser), varargs...)
 }
 
+// ListAuditEvents mocks base method.
+func (m *MockUsersClient) ListAuditEvents(ctx context.Context, in *pb.ListAuditEventsRequest, opts ...grpc.CallOption) (*pb.ListAuditEventsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAuditEvents", varargs...)
+	ret0, _ := ret[0].(*pb.ListAuditEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockUsersClientMockRecorder) ListAuditEvents(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockUsersClient)(nil).ListAuditEvents), varargs...)
+}
+
+// ListSessions mocks base method.
+func (m *MockUsersClient) ListSessions(ctx context.Context, in *pb.ListSessionsRequest, opts ...grpc.CallOption) (*pb.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*pb.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUsersClientMockRecorder) ListSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUsersClient)(nil).ListSessions), varargs...)
+}
+
+// Logout mocks base method.
+func (m *MockUsersClient) Logout(ctx context.Context, in *pb.LogoutRequest, opts ...grpc.CallOption) (*pb.LogoutResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Logout", varargs...)
+	ret0, _ := ret[0].(*pb.LogoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUsersClientMockRecorder) Logout(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUsersClient)(nil).Logout), varargs...)
+}
+
+// RefreshToken mocks base method.
+func (m *MockUsersClient) RefreshToken(ctx context.Context, in *pb.RefreshTokenRequest, opts ...grpc.CallOption) (*pb.RefreshTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RefreshToken", varargs...)
+	ret0, _ := ret[0].(*pb.RefreshTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockUsersClientMockRecorder) RefreshToken(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUsersClient)(nil).RefreshToken), varargs...)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUsersClient) RevokeSession(ctx context.Context, in *pb.RevokeSessionRequest, opts ...grpc.CallOption) (*pb.RevokeSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSession", varargs...)
+	ret0, _ := ret[0].(*pb.RevokeSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUsersClientMockRecorder) RevokeSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUsersClient)(nil).RevokeSession), varargs...)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUsersClient) UpdateUser(ctx context.Context, in *pb.UpdateUserRequest, opts ...grpc.CallOption) (*pb.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -179,6 +339,21 @@ func (m *MockUsersServer) EXPECT() *MockUsersServerMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUsersServer) ChangePassword(arg0 context.Context, arg1 *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1)
+	ret0, _ := ret[0].(*pb.ChangePasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUsersServerMockRecorder) ChangePassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUsersServer)(nil).ChangePassword), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockUsersServer) CreateUser(arg0 context.Context, arg1 *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -209,6 +384,21 @@ func (mr *MockUsersServerMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUsersServer)(nil).DeleteUser), arg0, arg1)
 }
 
+// GetPublicKey mocks base method.
+func (m *MockUsersServer) GetPublicKey(arg0 context.Context, arg1 *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetPublicKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockUsersServerMockRecorder) GetPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockUsersServer)(nil).GetPublicKey), arg0, arg1)
+}
+
 // GetRevision mocks base method.
 func (m *MockUsersServer) GetRevision(arg0 context.Context, arg1 *pb.GetRevisionRequest) (*pb.GetRevisionResponse, error) {
 	m.ctrl.T.Helper()
@@ -224,6 +414,21 @@ func (mr *MockUsersServerMockRecorder) GetRevision(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockUsersServer)(nil).GetRevision), arg0, arg1)
 }
 
+// GetServerLimits mocks base method.
+func (m *MockUsersServer) GetServerLimits(arg0 context.Context, arg1 *pb.GetServerLimitsRequest) (*pb.GetServerLimitsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServerLimits", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetServerLimitsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServerLimits indicates an expected call of GetServerLimits.
+func (mr *MockUsersServerMockRecorder) GetServerLimits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerLimits", reflect.TypeOf((*MockUsersServer)(nil).GetServerLimits), arg0, arg1)
+}
+
 // GetUser mocks base method.
 func (m *MockUsersServer) GetUser(arg0 context.Context, arg1 *pb.GetUserRequest) (*pb.GetUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -239,6 +444,81 @@ func (mr *MockUsersServerMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUsersServer)(nil).GetUser), arg0, arg1)
 }
 
+// ListAuditEvents mocks base method.
+func (m *MockUsersServer) ListAuditEvents(arg0 context.Context, arg1 *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", arg0, arg1)
+	ret0, _ := ret[0].(*pb.ListAuditEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockUsersServerMockRecorder) ListAuditEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockUsersServer)(nil).ListAuditEvents), arg0, arg1)
+}
+
+// ListSessions mocks base method.
+func (m *MockUsersServer) ListSessions(arg0 context.Context, arg1 *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", arg0, arg1)
+	ret0, _ := ret[0].(*pb.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUsersServerMockRecorder) ListSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUsersServer)(nil).ListSessions), arg0, arg1)
+}
+
+// Logout mocks base method.
+func (m *MockUsersServer) Logout(arg0 context.Context, arg1 *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0, arg1)
+	ret0, _ := ret[0].(*pb.LogoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUsersServerMockRecorder) Logout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUsersServer)(nil).Logout), arg0, arg1)
+}
+
+// RefreshToken mocks base method.
+func (m *MockUsersServer) RefreshToken(arg0 context.Context, arg1 *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(*pb.RefreshTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockUsersServerMockRecorder) RefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUsersServer)(nil).RefreshToken), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUsersServer) RevokeSession(arg0 context.Context, arg1 *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(*pb.RevokeSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUsersServerMockRecorder) RevokeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUsersServer)(nil).RevokeSession), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUsersServer) UpdateUser(arg0 context.Context, arg1 *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +103,21 @@ func (mr *MockSMockRecorder) DeleteItems(ctx, ids interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItems", reflect.TypeOf((*MockS)(nil).DeleteItems), ctx, ids)
 }
 
+// GetFolders mocks base method.
+func (m *MockS) GetFolders(ctx context.Context) (storage.Folders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolders", ctx)
+	ret0, _ := ret[0].(storage.Folders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockSMockRecorder) GetFolders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockS)(nil).GetFolders), ctx)
+}
+
 // GetItem mocks base method.
 func (m *MockS) GetItem(ctx context.Context, itemName, itemType string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -134,10 +149,10 @@ func (mr *MockSMockRecorder) GetItemsList(ctx interface{}) *gomock.Call {
 }
 
 // GetRevision mocks base method.
-func (m *MockS) GetRevision(arg0 context.Context) ([]byte, error) {
+func (m *MockS) GetRevision(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRevision", arg0)
-	ret0, _ := ret[0].([]byte)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -148,8 +163,22 @@ func (mr *MockSMockRecorder) GetRevision(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockS)(nil).GetRevision), arg0)
 }
 
+// SaveFolders mocks base method.
+func (m *MockS) SaveFolders(ctx context.Context, folders storage.Folders) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFolders", ctx, folders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFolders indicates an expected call of SaveFolders.
+func (mr *MockSMockRecorder) SaveFolders(ctx, folders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFolders", reflect.TypeOf((*MockS)(nil).SaveFolders), ctx, folders)
+}
+
 // SaveRevision mocks base method.
-func (m *MockS) SaveRevision(arg0 context.Context, arg1 []byte) error {
+func (m *MockS) SaveRevision(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveRevision", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -290,6 +319,21 @@ func (mr *MockStorekeeperMockRecorder) DeleteItems(ctx, ids interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItems", reflect.TypeOf((*MockStorekeeper)(nil).DeleteItems), ctx, ids)
 }
 
+// GetFolders mocks base method.
+func (m *MockStorekeeper) GetFolders(ctx context.Context) (storage.Folders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolders", ctx)
+	ret0, _ := ret[0].(storage.Folders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockStorekeeperMockRecorder) GetFolders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockStorekeeper)(nil).GetFolders), ctx)
+}
+
 // GetItem mocks base method.
 func (m *MockStorekeeper) GetItem(ctx context.Context, itemName, itemType string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -321,10 +365,10 @@ func (mr *MockStorekeeperMockRecorder) GetItemsList(ctx interface{}) *gomock.Cal
 }
 
 // GetRevision mocks base method.
-func (m *MockStorekeeper) GetRevision(arg0 context.Context) ([]byte, error) {
+func (m *MockStorekeeper) GetRevision(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRevision", arg0)
-	ret0, _ := ret[0].([]byte)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -335,8 +379,22 @@ func (mr *MockStorekeeperMockRecorder) GetRevision(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockStorekeeper)(nil).GetRevision), arg0)
 }
 
+// SaveFolders mocks base method.
+func (m *MockStorekeeper) SaveFolders(ctx context.Context, folders storage.Folders) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFolders", ctx, folders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFolders indicates an expected call of SaveFolders.
+func (mr *MockStorekeeperMockRecorder) SaveFolders(ctx, folders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFolders", reflect.TypeOf((*MockStorekeeper)(nil).SaveFolders), ctx, folders)
+}
+
 // SaveRevision mocks base method.
-func (m *MockStorekeeper) SaveRevision(arg0 context.Context, arg1 []byte) error {
+func (m *MockStorekeeper) SaveRevision(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveRevision", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,393 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.15.8
+// source: internal/proto/emergency.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EmergencyAccessesClient is the client API for EmergencyAccesses service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmergencyAccessesClient interface {
+	InviteEmergencyContact(ctx context.Context, in *InviteEmergencyContactRequest, opts ...grpc.CallOption) (*InviteEmergencyContactResponse, error)
+	GetEmergencyAccesses(ctx context.Context, in *GetEmergencyAccessesRequest, opts ...grpc.CallOption) (*GetEmergencyAccessesResponse, error)
+	AcceptEmergencyInvite(ctx context.Context, in *AcceptEmergencyInviteRequest, opts ...grpc.CallOption) (*AcceptEmergencyInviteResponse, error)
+	RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error)
+	ApproveEmergencyAccess(ctx context.Context, in *ApproveEmergencyAccessRequest, opts ...grpc.CallOption) (*ApproveEmergencyAccessResponse, error)
+	RejectEmergencyAccess(ctx context.Context, in *RejectEmergencyAccessRequest, opts ...grpc.CallOption) (*RejectEmergencyAccessResponse, error)
+	RevokeEmergencyAccess(ctx context.Context, in *RevokeEmergencyAccessRequest, opts ...grpc.CallOption) (*RevokeEmergencyAccessResponse, error)
+	GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error)
+	TakeoverAccount(ctx context.Context, in *TakeoverAccountRequest, opts ...grpc.CallOption) (*TakeoverAccountResponse, error)
+}
+
+type emergencyAccessesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmergencyAccessesClient(cc grpc.ClientConnInterface) EmergencyAccessesClient {
+	return &emergencyAccessesClient{cc}
+}
+
+func (c *emergencyAccessesClient) InviteEmergencyContact(ctx context.Context, in *InviteEmergencyContactRequest, opts ...grpc.CallOption) (*InviteEmergencyContactResponse, error) {
+	out := new(InviteEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/InviteEmergencyContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) GetEmergencyAccesses(ctx context.Context, in *GetEmergencyAccessesRequest, opts ...grpc.CallOption) (*GetEmergencyAccessesResponse, error) {
+	out := new(GetEmergencyAccessesResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/GetEmergencyAccesses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) AcceptEmergencyInvite(ctx context.Context, in *AcceptEmergencyInviteRequest, opts ...grpc.CallOption) (*AcceptEmergencyInviteResponse, error) {
+	out := new(AcceptEmergencyInviteResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/AcceptEmergencyInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error) {
+	out := new(RequestEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/RequestEmergencyAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) ApproveEmergencyAccess(ctx context.Context, in *ApproveEmergencyAccessRequest, opts ...grpc.CallOption) (*ApproveEmergencyAccessResponse, error) {
+	out := new(ApproveEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/ApproveEmergencyAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) RejectEmergencyAccess(ctx context.Context, in *RejectEmergencyAccessRequest, opts ...grpc.CallOption) (*RejectEmergencyAccessResponse, error) {
+	out := new(RejectEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/RejectEmergencyAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) RevokeEmergencyAccess(ctx context.Context, in *RevokeEmergencyAccessRequest, opts ...grpc.CallOption) (*RevokeEmergencyAccessResponse, error) {
+	out := new(RevokeEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/RevokeEmergencyAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error) {
+	out := new(GetEmergencyVaultResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/GetEmergencyVault", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessesClient) TakeoverAccount(ctx context.Context, in *TakeoverAccountRequest, opts ...grpc.CallOption) (*TakeoverAccountResponse, error) {
+	out := new(TakeoverAccountResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.EmergencyAccesses/TakeoverAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmergencyAccessesServer is the server API for EmergencyAccesses service.
+// All implementations must embed UnimplementedEmergencyAccessesServer
+// for forward compatibility
+type EmergencyAccessesServer interface {
+	InviteEmergencyContact(context.Context, *InviteEmergencyContactRequest) (*InviteEmergencyContactResponse, error)
+	GetEmergencyAccesses(context.Context, *GetEmergencyAccessesRequest) (*GetEmergencyAccessesResponse, error)
+	AcceptEmergencyInvite(context.Context, *AcceptEmergencyInviteRequest) (*AcceptEmergencyInviteResponse, error)
+	RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error)
+	ApproveEmergencyAccess(context.Context, *ApproveEmergencyAccessRequest) (*ApproveEmergencyAccessResponse, error)
+	RejectEmergencyAccess(context.Context, *RejectEmergencyAccessRequest) (*RejectEmergencyAccessResponse, error)
+	RevokeEmergencyAccess(context.Context, *RevokeEmergencyAccessRequest) (*RevokeEmergencyAccessResponse, error)
+	GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error)
+	TakeoverAccount(context.Context, *TakeoverAccountRequest) (*TakeoverAccountResponse, error)
+	mustEmbedUnimplementedEmergencyAccessesServer()
+}
+
+// UnimplementedEmergencyAccessesServer must be embedded to have forward compatible implementations.
+type UnimplementedEmergencyAccessesServer struct {
+}
+
+func (UnimplementedEmergencyAccessesServer) InviteEmergencyContact(context.Context, *InviteEmergencyContactRequest) (*InviteEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteEmergencyContact not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) GetEmergencyAccesses(context.Context, *GetEmergencyAccessesRequest) (*GetEmergencyAccessesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyAccesses not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) AcceptEmergencyInvite(context.Context, *AcceptEmergencyInviteRequest) (*AcceptEmergencyInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptEmergencyInvite not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) ApproveEmergencyAccess(context.Context, *ApproveEmergencyAccessRequest) (*ApproveEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) RejectEmergencyAccess(context.Context, *RejectEmergencyAccessRequest) (*RejectEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) RevokeEmergencyAccess(context.Context, *RevokeEmergencyAccessRequest) (*RevokeEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyVault not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) TakeoverAccount(context.Context, *TakeoverAccountRequest) (*TakeoverAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeoverAccount not implemented")
+}
+func (UnimplementedEmergencyAccessesServer) mustEmbedUnimplementedEmergencyAccessesServer() {}
+
+// UnsafeEmergencyAccessesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmergencyAccessesServer will
+// result in compilation errors.
+type UnsafeEmergencyAccessesServer interface {
+	mustEmbedUnimplementedEmergencyAccessesServer()
+}
+
+func RegisterEmergencyAccessesServer(s grpc.ServiceRegistrar, srv EmergencyAccessesServer) {
+	s.RegisterService(&EmergencyAccesses_ServiceDesc, srv)
+}
+
+func _EmergencyAccesses_InviteEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteEmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).InviteEmergencyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/InviteEmergencyContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).InviteEmergencyContact(ctx, req.(*InviteEmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_GetEmergencyAccesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyAccessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).GetEmergencyAccesses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/GetEmergencyAccesses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).GetEmergencyAccesses(ctx, req.(*GetEmergencyAccessesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_AcceptEmergencyInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptEmergencyInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).AcceptEmergencyInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/AcceptEmergencyInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).AcceptEmergencyInvite(ctx, req.(*AcceptEmergencyInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_RequestEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).RequestEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/RequestEmergencyAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).RequestEmergencyAccess(ctx, req.(*RequestEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_ApproveEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).ApproveEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/ApproveEmergencyAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).ApproveEmergencyAccess(ctx, req.(*ApproveEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_RejectEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).RejectEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/RejectEmergencyAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).RejectEmergencyAccess(ctx, req.(*RejectEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_RevokeEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).RevokeEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/RevokeEmergencyAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).RevokeEmergencyAccess(ctx, req.(*RevokeEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_GetEmergencyVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).GetEmergencyVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/GetEmergencyVault",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).GetEmergencyVault(ctx, req.(*GetEmergencyVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccesses_TakeoverAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeoverAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessesServer).TakeoverAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.EmergencyAccesses/TakeoverAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessesServer).TakeoverAccount(ctx, req.(*TakeoverAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmergencyAccesses_ServiceDesc is the grpc.ServiceDesc for EmergencyAccesses service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmergencyAccesses_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.EmergencyAccesses",
+	HandlerType: (*EmergencyAccessesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InviteEmergencyContact",
+			Handler:    _EmergencyAccesses_InviteEmergencyContact_Handler,
+		},
+		{
+			MethodName: "GetEmergencyAccesses",
+			Handler:    _EmergencyAccesses_GetEmergencyAccesses_Handler,
+		},
+		{
+			MethodName: "AcceptEmergencyInvite",
+			Handler:    _EmergencyAccesses_AcceptEmergencyInvite_Handler,
+		},
+		{
+			MethodName: "RequestEmergencyAccess",
+			Handler:    _EmergencyAccesses_RequestEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "ApproveEmergencyAccess",
+			Handler:    _EmergencyAccesses_ApproveEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "RejectEmergencyAccess",
+			Handler:    _EmergencyAccesses_RejectEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "RevokeEmergencyAccess",
+			Handler:    _EmergencyAccesses_RevokeEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "GetEmergencyVault",
+			Handler:    _EmergencyAccesses_GetEmergencyVault_Handler,
+		},
+		{
+			MethodName: "TakeoverAccount",
+			Handler:    _EmergencyAccesses_TakeoverAccount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/emergency.proto",
+}
@@ -29,6 +29,24 @@ type ItemsClient interface {
 	GetItemHash(ctx context.Context, in *GetItemHashRequest, opts ...grpc.CallOption) (*GetItemHashResponse, error)
 	UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*UpdateItemResponse, error)
 	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*DeleteItemResponse, error)
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	RestoreItem(ctx context.Context, in *RestoreItemRequest, opts ...grpc.CallOption) (*RestoreItemResponse, error)
+	PurgeItem(ctx context.Context, in *PurgeItemRequest, opts ...grpc.CallOption) (*PurgeItemResponse, error)
+	ListItemVersions(ctx context.Context, in *ListItemVersionsRequest, opts ...grpc.CallOption) (*ListItemVersionsResponse, error)
+	RestoreItemVersion(ctx context.Context, in *RestoreItemVersionRequest, opts ...grpc.CallOption) (*RestoreItemVersionResponse, error)
+	GetAllItems(ctx context.Context, in *GetAllItemsRequest, opts ...grpc.CallOption) (*GetAllItemsResponse, error)
+	RotateEncryptionKey(ctx context.Context, in *RotateEncryptionKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error)
+	UploadSecretData(ctx context.Context, opts ...grpc.CallOption) (Items_UploadSecretDataClient, error)
+	DownloadSecretData(ctx context.Context, in *DownloadSecretDataRequest, opts ...grpc.CallOption) (Items_DownloadSecretDataClient, error)
+	GetChangesSince(ctx context.Context, in *GetChangesSinceRequest, opts ...grpc.CallOption) (*GetChangesSinceResponse, error)
+	WatchVault(ctx context.Context, in *WatchVaultRequest, opts ...grpc.CallOption) (Items_WatchVaultClient, error)
+	CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error)
+	GetFolders(ctx context.Context, in *GetFoldersRequest, opts ...grpc.CallOption) (*GetFoldersResponse, error)
+	UpdateFolder(ctx context.Context, in *UpdateFolderRequest, opts ...grpc.CallOption) (*UpdateFolderResponse, error)
+	DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error)
+	ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error)
+	GetShares(ctx context.Context, in *GetSharesRequest, opts ...grpc.CallOption) (*GetSharesResponse, error)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error)
 }
 
 type itemsClient struct {
@@ -102,6 +120,239 @@ func (c *itemsClient) DeleteItem(ctx context.Context, in *DeleteItemRequest, opt
 	return out, nil
 }
 
+func (c *itemsClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/ListTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) RestoreItem(ctx context.Context, in *RestoreItemRequest, opts ...grpc.CallOption) (*RestoreItemResponse, error) {
+	out := new(RestoreItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/RestoreItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) PurgeItem(ctx context.Context, in *PurgeItemRequest, opts ...grpc.CallOption) (*PurgeItemResponse, error) {
+	out := new(PurgeItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/PurgeItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) ListItemVersions(ctx context.Context, in *ListItemVersionsRequest, opts ...grpc.CallOption) (*ListItemVersionsResponse, error) {
+	out := new(ListItemVersionsResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/ListItemVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) RestoreItemVersion(ctx context.Context, in *RestoreItemVersionRequest, opts ...grpc.CallOption) (*RestoreItemVersionResponse, error) {
+	out := new(RestoreItemVersionResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/RestoreItemVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) GetAllItems(ctx context.Context, in *GetAllItemsRequest, opts ...grpc.CallOption) (*GetAllItemsResponse, error) {
+	out := new(GetAllItemsResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/GetAllItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) RotateEncryptionKey(ctx context.Context, in *RotateEncryptionKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error) {
+	out := new(RotateEncryptionKeyResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/RotateEncryptionKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) UploadSecretData(ctx context.Context, opts ...grpc.CallOption) (Items_UploadSecretDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Items_ServiceDesc.Streams[0], "/gophkeeper.Items/UploadSecretData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &itemsUploadSecretDataClient{stream}
+	return x, nil
+}
+
+type Items_UploadSecretDataClient interface {
+	Send(*UploadSecretDataRequest) error
+	CloseAndRecv() (*UploadSecretDataResponse, error)
+	grpc.ClientStream
+}
+
+type itemsUploadSecretDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *itemsUploadSecretDataClient) Send(m *UploadSecretDataRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *itemsUploadSecretDataClient) CloseAndRecv() (*UploadSecretDataResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadSecretDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *itemsClient) DownloadSecretData(ctx context.Context, in *DownloadSecretDataRequest, opts ...grpc.CallOption) (Items_DownloadSecretDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Items_ServiceDesc.Streams[1], "/gophkeeper.Items/DownloadSecretData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &itemsDownloadSecretDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Items_DownloadSecretDataClient interface {
+	Recv() (*DownloadSecretDataResponse, error)
+	grpc.ClientStream
+}
+
+type itemsDownloadSecretDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *itemsDownloadSecretDataClient) Recv() (*DownloadSecretDataResponse, error) {
+	m := new(DownloadSecretDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *itemsClient) GetChangesSince(ctx context.Context, in *GetChangesSinceRequest, opts ...grpc.CallOption) (*GetChangesSinceResponse, error) {
+	out := new(GetChangesSinceResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/GetChangesSince", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) WatchVault(ctx context.Context, in *WatchVaultRequest, opts ...grpc.CallOption) (Items_WatchVaultClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Items_ServiceDesc.Streams[2], "/gophkeeper.Items/WatchVault", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &itemsWatchVaultClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Items_WatchVaultClient interface {
+	Recv() (*WatchVaultResponse, error)
+	grpc.ClientStream
+}
+
+type itemsWatchVaultClient struct {
+	grpc.ClientStream
+}
+
+func (x *itemsWatchVaultClient) Recv() (*WatchVaultResponse, error) {
+	m := new(WatchVaultResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *itemsClient) CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error) {
+	out := new(CreateFolderResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/CreateFolder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) GetFolders(ctx context.Context, in *GetFoldersRequest, opts ...grpc.CallOption) (*GetFoldersResponse, error) {
+	out := new(GetFoldersResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/GetFolders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) UpdateFolder(ctx context.Context, in *UpdateFolderRequest, opts ...grpc.CallOption) (*UpdateFolderResponse, error) {
+	out := new(UpdateFolderResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/UpdateFolder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error) {
+	out := new(DeleteFolderResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/DeleteFolder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error) {
+	out := new(ShareItemResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/ShareItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) GetShares(ctx context.Context, in *GetSharesRequest, opts ...grpc.CallOption) (*GetSharesResponse, error) {
+	out := new(GetSharesResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/GetShares", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error) {
+	out := new(RevokeShareResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Items/RevokeShare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ItemsServer is the server API for Items service.
 // All implementations must embed UnimplementedItemsServer
 // for forward compatibility
@@ -113,6 +364,24 @@ type ItemsServer interface {
 	GetItemHash(context.Context, *GetItemHashRequest) (*GetItemHashResponse, error)
 	UpdateItem(context.Context, *UpdateItemRequest) (*UpdateItemResponse, error)
 	DeleteItem(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error)
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	RestoreItem(context.Context, *RestoreItemRequest) (*RestoreItemResponse, error)
+	PurgeItem(context.Context, *PurgeItemRequest) (*PurgeItemResponse, error)
+	ListItemVersions(context.Context, *ListItemVersionsRequest) (*ListItemVersionsResponse, error)
+	RestoreItemVersion(context.Context, *RestoreItemVersionRequest) (*RestoreItemVersionResponse, error)
+	GetAllItems(context.Context, *GetAllItemsRequest) (*GetAllItemsResponse, error)
+	RotateEncryptionKey(context.Context, *RotateEncryptionKeyRequest) (*RotateEncryptionKeyResponse, error)
+	UploadSecretData(Items_UploadSecretDataServer) error
+	DownloadSecretData(*DownloadSecretDataRequest, Items_DownloadSecretDataServer) error
+	GetChangesSince(context.Context, *GetChangesSinceRequest) (*GetChangesSinceResponse, error)
+	WatchVault(*WatchVaultRequest, Items_WatchVaultServer) error
+	CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error)
+	GetFolders(context.Context, *GetFoldersRequest) (*GetFoldersResponse, error)
+	UpdateFolder(context.Context, *UpdateFolderRequest) (*UpdateFolderResponse, error)
+	DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error)
+	ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error)
+	GetShares(context.Context, *GetSharesRequest) (*GetSharesResponse, error)
+	RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error)
 	mustEmbedUnimplementedItemsServer()
 }
 
@@ -141,6 +410,60 @@ func (UnimplementedItemsServer) UpdateItem(context.Context, *UpdateItemRequest)
 func (UnimplementedItemsServer) DeleteItem(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteItem not implemented")
 }
+func (UnimplementedItemsServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedItemsServer) RestoreItem(context.Context, *RestoreItemRequest) (*RestoreItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreItem not implemented")
+}
+func (UnimplementedItemsServer) PurgeItem(context.Context, *PurgeItemRequest) (*PurgeItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeItem not implemented")
+}
+func (UnimplementedItemsServer) ListItemVersions(context.Context, *ListItemVersionsRequest) (*ListItemVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItemVersions not implemented")
+}
+func (UnimplementedItemsServer) RestoreItemVersion(context.Context, *RestoreItemVersionRequest) (*RestoreItemVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreItemVersion not implemented")
+}
+func (UnimplementedItemsServer) GetAllItems(context.Context, *GetAllItemsRequest) (*GetAllItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllItems not implemented")
+}
+func (UnimplementedItemsServer) RotateEncryptionKey(context.Context, *RotateEncryptionKeyRequest) (*RotateEncryptionKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateEncryptionKey not implemented")
+}
+func (UnimplementedItemsServer) UploadSecretData(Items_UploadSecretDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadSecretData not implemented")
+}
+func (UnimplementedItemsServer) DownloadSecretData(*DownloadSecretDataRequest, Items_DownloadSecretDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadSecretData not implemented")
+}
+func (UnimplementedItemsServer) GetChangesSince(context.Context, *GetChangesSinceRequest) (*GetChangesSinceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChangesSince not implemented")
+}
+func (UnimplementedItemsServer) WatchVault(*WatchVaultRequest, Items_WatchVaultServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchVault not implemented")
+}
+func (UnimplementedItemsServer) CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedItemsServer) GetFolders(context.Context, *GetFoldersRequest) (*GetFoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFolders not implemented")
+}
+func (UnimplementedItemsServer) UpdateFolder(context.Context, *UpdateFolderRequest) (*UpdateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFolder not implemented")
+}
+func (UnimplementedItemsServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFolder not implemented")
+}
+func (UnimplementedItemsServer) ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareItem not implemented")
+}
+func (UnimplementedItemsServer) GetShares(context.Context, *GetSharesRequest) (*GetSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShares not implemented")
+}
+func (UnimplementedItemsServer) RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
 func (UnimplementedItemsServer) mustEmbedUnimplementedItemsServer() {}
 
 // UnsafeItemsServer may be embedded to opt out of forward compatibility for this service.
@@ -280,6 +603,344 @@ func _Items_DeleteItem_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Items_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/ListTrash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_RestoreItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).RestoreItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/RestoreItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).RestoreItem(ctx, req.(*RestoreItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_PurgeItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).PurgeItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/PurgeItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).PurgeItem(ctx, req.(*PurgeItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_ListItemVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).ListItemVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/ListItemVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).ListItemVersions(ctx, req.(*ListItemVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_RestoreItemVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreItemVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).RestoreItemVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/RestoreItemVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).RestoreItemVersion(ctx, req.(*RestoreItemVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_GetAllItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).GetAllItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/GetAllItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).GetAllItems(ctx, req.(*GetAllItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_RotateEncryptionKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateEncryptionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).RotateEncryptionKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/RotateEncryptionKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).RotateEncryptionKey(ctx, req.(*RotateEncryptionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_UploadSecretData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ItemsServer).UploadSecretData(&itemsUploadSecretDataServer{stream})
+}
+
+type Items_UploadSecretDataServer interface {
+	SendAndClose(*UploadSecretDataResponse) error
+	Recv() (*UploadSecretDataRequest, error)
+	grpc.ServerStream
+}
+
+type itemsUploadSecretDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *itemsUploadSecretDataServer) SendAndClose(m *UploadSecretDataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *itemsUploadSecretDataServer) Recv() (*UploadSecretDataRequest, error) {
+	m := new(UploadSecretDataRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Items_DownloadSecretData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadSecretDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ItemsServer).DownloadSecretData(m, &itemsDownloadSecretDataServer{stream})
+}
+
+type Items_DownloadSecretDataServer interface {
+	Send(*DownloadSecretDataResponse) error
+	grpc.ServerStream
+}
+
+type itemsDownloadSecretDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *itemsDownloadSecretDataServer) Send(m *DownloadSecretDataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Items_GetChangesSince_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChangesSinceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).GetChangesSince(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/GetChangesSince",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).GetChangesSince(ctx, req.(*GetChangesSinceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_WatchVault_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVaultRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ItemsServer).WatchVault(m, &itemsWatchVaultServer{stream})
+}
+
+type Items_WatchVaultServer interface {
+	Send(*WatchVaultResponse) error
+	grpc.ServerStream
+}
+
+type itemsWatchVaultServer struct {
+	grpc.ServerStream
+}
+
+func (x *itemsWatchVaultServer) Send(m *WatchVaultResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Items_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/CreateFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_GetFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).GetFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/GetFolders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).GetFolders(ctx, req.(*GetFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_UpdateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).UpdateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/UpdateFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).UpdateFolder(ctx, req.(*UpdateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).DeleteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/DeleteFolder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_ShareItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).ShareItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/ShareItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).ShareItem(ctx, req.(*ShareItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_GetShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).GetShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/GetShares",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).GetShares(ctx, req.(*GetSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Items_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Items/RevokeShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Items_ServiceDesc is the grpc.ServiceDesc for Items service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -315,7 +976,83 @@ var Items_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteItem",
 			Handler:    _Items_DeleteItem_Handler,
 		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _Items_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreItem",
+			Handler:    _Items_RestoreItem_Handler,
+		},
+		{
+			MethodName: "PurgeItem",
+			Handler:    _Items_PurgeItem_Handler,
+		},
+		{
+			MethodName: "ListItemVersions",
+			Handler:    _Items_ListItemVersions_Handler,
+		},
+		{
+			MethodName: "RestoreItemVersion",
+			Handler:    _Items_RestoreItemVersion_Handler,
+		},
+		{
+			MethodName: "GetAllItems",
+			Handler:    _Items_GetAllItems_Handler,
+		},
+		{
+			MethodName: "RotateEncryptionKey",
+			Handler:    _Items_RotateEncryptionKey_Handler,
+		},
+		{
+			MethodName: "GetChangesSince",
+			Handler:    _Items_GetChangesSince_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _Items_CreateFolder_Handler,
+		},
+		{
+			MethodName: "GetFolders",
+			Handler:    _Items_GetFolders_Handler,
+		},
+		{
+			MethodName: "UpdateFolder",
+			Handler:    _Items_UpdateFolder_Handler,
+		},
+		{
+			MethodName: "DeleteFolder",
+			Handler:    _Items_DeleteFolder_Handler,
+		},
+		{
+			MethodName: "ShareItem",
+			Handler:    _Items_ShareItem_Handler,
+		},
+		{
+			MethodName: "GetShares",
+			Handler:    _Items_GetShares_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _Items_RevokeShare_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadSecretData",
+			Handler:       _Items_UploadSecretData_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadSecretData",
+			Handler:       _Items_DownloadSecretData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchVault",
+			Handler:       _Items_WatchVault_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/items.proto",
 }
@@ -4,9 +4,11 @@ package authorizer
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/google/uuid"
 )
 
 // Supported authorization methods.
@@ -20,26 +22,41 @@ const (
 var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("expired token")
+	ErrRevokedToken = errors.New("revoked token")
 )
 
 // A represents general authorizer interface.
 type A interface {
-	// Creates new token
-	CreateToken(fields AuthFields) (string, error)
-	// Verify token
-	VerifyToken(token string, fields AuthFields) error
+	// Creates new token, returns token and its payload
+	CreateToken(fields AuthFields) (string, *Payload, error)
+	// Verify token, returns token's payload
+	VerifyToken(token string, fields AuthFields) (*Payload, error)
+}
+
+// Reloader represents authorizer, which keys can be reloaded without restart.
+type Reloader interface {
+	// Reload keys. Current keys are kept if reload fails.
+	Reload() error
 }
 
 // AuthorizeItems contains possible parameters for authorization.
 type AuthFields struct {
 	Username string
+	// User's administrator role, carried in token. Ignored by verification.
+	IsAdmin bool
+	// Tokens issued before this time are revoked. Zero time disables check.
+	TokensRevoked time.Time
+	// Checks that token's session (by token's ID) is active. Nil function disables check.
+	CheckSession func(id uuid.UUID) error
 }
 
 // New is a fabric method for create Authorizer with provided type.
+//
+// For JWT authorizer key is comma-separated list of PEM files of key ring, first one is signing key.
 func New(aType string, key string, tokenDuration time.Duration, logger logger.L) (A, error) {
 	switch aType {
 	case TypeJWT:
-		return nil, errors.New("unimplemented")
+		return NewJWTAuthorizerFromFiles(strings.Split(key, ","), tokenDuration)
 	case TypePaseto:
 		return NewPasetoAuthorizer(key, tokenDuration)
 	case TypeYesMan:
@@ -1,6 +1,8 @@
 package authorizer
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,9 +16,19 @@ func TestNew(t *testing.T) {
 	tokenDur := 5 * time.Minute
 
 	a, err := New(TypeJWT, "123456789a123456789a123456789abc", tokenDur, logger)
-	require.Error(t, err)
+	require.Error(t, err, "key ring's file doesn't exist")
 	assert.Empty(t, a)
 
+	private, public := newTestEd25519Key(t)
+	privatePath := filepath.Join(t.TempDir(), "private.pem")
+	publicPath := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(privatePath, private, 0o600))
+	require.NoError(t, os.WriteFile(publicPath, public, 0o600))
+
+	a, err = New(TypeJWT, privatePath+","+publicPath, tokenDur, logger)
+	require.NoError(t, err)
+	assert.NotEmpty(t, a)
+
 	a, err = New(TypePaseto, "123456789a123456789a123456789abc", tokenDur, logger)
 	require.NoError(t, err)
 	assert.NotEmpty(t, a)
@@ -0,0 +1,382 @@
+package authorizer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supported JWT signing algorithms.
+const (
+	jwtAlgEdDSA = "EdDSA"
+	jwtAlgES256 = "ES256"
+)
+
+// Issuer of JWT tokens.
+const jwtIssuer = "gophkeeper"
+
+// Size of ES256 signature's parts (r and s).
+const es256PartSize = 32
+
+// Number of digits of nanoseconds in NumericDate's fractional part.
+const nanoDigits = 9
+
+// JWTAuthorizer represents implementation of Authorizer based on JWT tokens signed with
+// asymmetric keys (Ed25519 or ECDSA P-256).
+//
+// Authorizer holds key ring: tokens are signed with first key only, while all keys are used
+// for verification, so signing key can be rotated without invalidating issued tokens. Key is
+// chosen by "kid" header of token, key's ID is derived from its public key (see jwtKeyID).
+type JWTAuthorizer struct {
+	signingKey    *jwtKey
+	keys          map[string]*jwtKey
+	tokenDuration time.Duration
+}
+
+var _ A = (*JWTAuthorizer)(nil)
+
+// jwtKey represents key of JWT authorizer's key ring. Private key is nil for verification-only key.
+type jwtKey struct {
+	id         string
+	alg        string
+	publicKey  crypto.PublicKey
+	privateKey crypto.Signer
+}
+
+// jwtHeader represents JOSE header of JWT token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// jwtClaims represents claims of JWT token.
+//
+// Times are encoded as NumericDate with nanoseconds in fractional part, so token's issue time is
+// kept exactly for check of tokens' revocation.
+type jwtClaims struct {
+	ID        string      `json:"jti"`
+	Issuer    string      `json:"iss"`
+	Subject   string      `json:"sub"`
+	IssuedAt  json.Number `json:"iat"`
+	ExpiresAt json.Number `json:"exp"`
+	IsAdmin   bool        `json:"adm,omitempty"`
+}
+
+// NewJWTAuthorizer creates new JWT Authorizer with key ring of PEM encoded keys.
+//
+// First key must be private key, it is used for signing tokens. Other keys could be private
+// or public ones, they are used only for verification.
+func NewJWTAuthorizer(pemKeys [][]byte, tokenDuration time.Duration) (*JWTAuthorizer, error) {
+	if len(pemKeys) == 0 {
+		return nil, errors.New("empty key ring")
+	}
+
+	a := &JWTAuthorizer{
+		keys:          make(map[string]*jwtKey, len(pemKeys)),
+		tokenDuration: tokenDuration,
+	}
+
+	for i, pemKey := range pemKeys {
+		key, err := parseJWTKey(pemKey)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %w", i+1, err)
+		}
+
+		if i == 0 {
+			if key.privateKey == nil {
+				return nil, errors.New("signing key must be private key")
+			}
+
+			a.signingKey = key
+		}
+
+		if _, ok := a.keys[key.id]; !ok {
+			a.keys[key.id] = key
+		}
+	}
+
+	return a, nil
+}
+
+// NewJWTAuthorizerFromFiles creates new JWT Authorizer with key ring of PEM files.
+//
+// Please see NewJWTAuthorizer for key ring's details.
+func NewJWTAuthorizerFromFiles(paths []string, tokenDuration time.Duration) (*JWTAuthorizer, error) {
+	pemKeys := make([][]byte, 0, len(paths))
+
+	for _, path := range paths {
+		pemKey, err := os.ReadFile(strings.TrimSpace(path))
+		if err != nil {
+			return nil, err
+		}
+
+		pemKeys = append(pemKeys, pemKey)
+	}
+
+	return NewJWTAuthorizer(pemKeys, tokenDuration)
+}
+
+// CreateToken creates a token for a specific authorization fields and duration.
+func (a *JWTAuthorizer) CreateToken(fields AuthFields) (string, *Payload, error) {
+	payload, err := NewPayload(fields.Username, fields.IsAdmin, a.tokenDuration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	header, err := json.Marshal(jwtHeader{Alg: a.signingKey.alg, Typ: "JWT", Kid: a.signingKey.id})
+	if err != nil {
+		return "", nil, err
+	}
+
+	claims, err := json.Marshal(jwtClaims{
+		ID:        payload.ID.String(),
+		Issuer:    jwtIssuer,
+		Subject:   payload.Username,
+		IssuedAt:  toNumericDate(payload.IssuedAt),
+		ExpiresAt: toNumericDate(payload.ExpiredAt),
+		IsAdmin:   payload.IsAdmin,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	signingInput := jwtEncode(header) + "." + jwtEncode(claims)
+
+	signature, err := a.signingKey.sign([]byte(signingInput))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signingInput + "." + jwtEncode(signature), payload, nil
+}
+
+// VerifyToken checks if the token is valid or not, returns token's payload.
+//
+// Token must be signed with key from key ring, key is found by token's "kid" header.
+func (a *JWTAuthorizer) VerifyToken(token string, fields AuthFields) (*Payload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	header := new(jwtHeader)
+	if err := jwtDecodeJSON(parts[0], header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, ok := a.keys[header.Kid]
+	if !ok || key.alg != header.Alg {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	claims := new(jwtClaims)
+	if err := jwtDecodeJSON(parts[1], claims); err != nil || claims.Issuer != jwtIssuer {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{
+		Username: claims.Subject,
+		IsAdmin:  claims.IsAdmin,
+	}
+
+	if payload.ID, err = uuid.Parse(claims.ID); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if payload.IssuedAt, err = fromNumericDate(claims.IssuedAt); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if payload.ExpiredAt, err = fromNumericDate(claims.ExpiresAt); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := payload.Valid(fields); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// parseJWTKey is a helper function, which parses PEM encoded Ed25519 or ECDSA P-256 key.
+//
+// Supported PEM blocks: "PRIVATE KEY" (PKCS #8), "EC PRIVATE KEY" (SEC 1) and "PUBLIC KEY" (PKIX).
+func parseJWTKey(pemKey []byte) (*jwtKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block: %s", block.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := new(jwtKey)
+
+	switch k := parsed.(type) {
+	case ed25519.PrivateKey:
+		key.alg, key.publicKey, key.privateKey = jwtAlgEdDSA, k.Public(), k
+	case ed25519.PublicKey:
+		key.alg, key.publicKey = jwtAlgEdDSA, k
+	case *ecdsa.PrivateKey:
+		key.alg, key.publicKey, key.privateKey = jwtAlgES256, &k.PublicKey, k
+	case *ecdsa.PublicKey:
+		key.alg, key.publicKey = jwtAlgES256, k
+	default:
+		return nil, errors.New("unsupported key type, must be Ed25519 or ECDSA P-256")
+	}
+
+	if ecKey, ok := key.publicKey.(*ecdsa.PublicKey); ok && ecKey.Curve != elliptic.P256() {
+		return nil, errors.New("unsupported ECDSA curve, must be P-256")
+	}
+
+	if key.id, err = jwtKeyID(key.publicKey); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// jwtKeyID returns key's ID - first 8 bytes of SHA-256 hash of public key in PKIX form, hex encoded.
+//
+// ID depends only on public key, so services verifying tokens can find key without configuration.
+func jwtKeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(der)
+
+	return hex.EncodeToString(hash[:8]), nil
+}
+
+// sign signs message with key's private key.
+//
+// ES256 signature is encoded as concatenation of r and s (RFC 7518), not in ASN.1 form.
+func (k *jwtKey) sign(message []byte) ([]byte, error) {
+	switch k.alg {
+	case jwtAlgEdDSA:
+		return k.privateKey.Sign(rand.Reader, message, crypto.Hash(0))
+	case jwtAlgES256:
+		hash := sha256.Sum256(message)
+
+		r, s, err := ecdsa.Sign(rand.Reader, k.privateKey.(*ecdsa.PrivateKey), hash[:]) //nolint:forcetypeassert
+		if err != nil {
+			return nil, err
+		}
+
+		signature := make([]byte, 2*es256PartSize)
+		r.FillBytes(signature[:es256PartSize])
+		s.FillBytes(signature[es256PartSize:])
+
+		return signature, nil
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", k.alg)
+	}
+}
+
+// verify checks message's signature with key's public key.
+func (k *jwtKey) verify(message, signature []byte) bool {
+	switch k.alg {
+	case jwtAlgEdDSA:
+		return ed25519.Verify(k.publicKey.(ed25519.PublicKey), message, signature) //nolint:forcetypeassert
+	case jwtAlgES256:
+		if len(signature) != 2*es256PartSize {
+			return false
+		}
+
+		hash := sha256.Sum256(message)
+		r := new(big.Int).SetBytes(signature[:es256PartSize])
+		s := new(big.Int).SetBytes(signature[es256PartSize:])
+
+		return ecdsa.Verify(k.publicKey.(*ecdsa.PublicKey), hash[:], r, s) //nolint:forcetypeassert
+	default:
+		return false
+	}
+}
+
+// jwtEncode is a helper function, which encodes token's part with base64url without padding.
+func jwtEncode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// jwtDecodeJSON is a helper function, which decodes base64url encoded JSON token's part.
+func jwtDecodeJSON(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// toNumericDate converts time to NumericDate with nanoseconds in fractional part.
+func toNumericDate(t time.Time) json.Number {
+	return json.Number(fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond()))
+}
+
+// fromNumericDate converts NumericDate in decimal form to time. Fractional part is cut
+// to nanoseconds.
+func fromNumericDate(date json.Number) (time.Time, error) {
+	secPart, fracPart, _ := strings.Cut(date.String(), ".")
+
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var nsec int64
+
+	if fracPart != "" {
+		if len(fracPart) > nanoDigits {
+			fracPart = fracPart[:nanoDigits]
+		}
+
+		if nsec, err = strconv.ParseInt(fracPart+strings.Repeat("0", nanoDigits-len(fracPart)), 10, 64); err != nil ||
+			nsec < 0 {
+			return time.Time{}, errors.New("invalid fractional part")
+		}
+	}
+
+	return time.Unix(sec, nsec), nil
+}
@@ -0,0 +1,337 @@
+package authorizer
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestEd25519Key generates Ed25519 key pair, returns PEM encoded private and public keys.
+func newTestEd25519Key(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return encodeTestKeys(t, private, public)
+}
+
+// newTestECDSAKey generates ECDSA key pair, returns PEM encoded private and public keys.
+func newTestECDSAKey(t *testing.T, curve elliptic.Curve) ([]byte, []byte) {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+
+	return encodeTestKeys(t, private, &private.PublicKey)
+}
+
+func encodeTestKeys(t *testing.T, private, public interface{}) ([]byte, []byte) {
+	t.Helper()
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func TestNewJWTAuthorizer(t *testing.T) {
+	edPrivate, edPublic := newTestEd25519Key(t)
+	ecPrivate, ecPublic := newTestECDSAKey(t, elliptic.P256())
+	_, otherPublic := newTestECDSAKey(t, elliptic.P256())
+	p384Private, _ := newTestECDSAKey(t, elliptic.P384())
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	sec1DER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	sec1Private := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1DER})
+
+	tests := []struct {
+		name     string
+		keys     [][]byte
+		wantAlg  string
+		wantKeys int
+		wantErr  bool
+	}{
+		{
+			name:     "Ed25519 key",
+			keys:     [][]byte{edPrivate},
+			wantAlg:  jwtAlgEdDSA,
+			wantKeys: 1,
+		},
+		{
+			name:     "ECDSA key",
+			keys:     [][]byte{ecPrivate},
+			wantAlg:  jwtAlgES256,
+			wantKeys: 1,
+		},
+		{
+			name:     "ECDSA key in SEC 1 form",
+			keys:     [][]byte{sec1Private},
+			wantAlg:  jwtAlgES256,
+			wantKeys: 1,
+		},
+		{
+			name:     "Key ring",
+			keys:     [][]byte{ecPrivate, edPublic, otherPublic},
+			wantAlg:  jwtAlgES256,
+			wantKeys: 3,
+		},
+		{
+			name:     "Duplicated key",
+			keys:     [][]byte{ecPrivate, ecPublic},
+			wantAlg:  jwtAlgES256,
+			wantKeys: 1,
+		},
+		{
+			name:    "Empty key ring",
+			wantErr: true,
+		},
+		{
+			name:    "Public signing key",
+			keys:    [][]byte{edPublic, edPrivate},
+			wantErr: true,
+		},
+		{
+			name:    "Not PEM key",
+			keys:    [][]byte{edPrivate, []byte("not a key")},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported curve",
+			keys:    [][]byte{p384Private},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported PEM block",
+			keys:    [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("cert")})},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewJWTAuthorizer(tt.keys, 5*time.Minute)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlg, a.signingKey.alg)
+			assert.Len(t, a.keys, tt.wantKeys)
+			assert.NotNil(t, a.keys[a.signingKey.id].privateKey, "signing key isn't replaced by public one")
+		})
+	}
+}
+
+func TestNewJWTAuthorizerFromFiles(t *testing.T) {
+	private, public := newTestEd25519Key(t)
+
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "private.pem")
+	publicPath := filepath.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(privatePath, private, 0o600))
+	require.NoError(t, os.WriteFile(publicPath, public, 0o600))
+
+	a, err := NewJWTAuthorizerFromFiles([]string{privatePath, " " + publicPath}, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, a.keys, 1, "same key is added to key ring once")
+
+	_, err = NewJWTAuthorizerFromFiles([]string{filepath.Join(dir, "missed.pem")}, 5*time.Minute)
+	assert.Error(t, err)
+}
+
+func TestJWTAuthorizer_CreateAndVerify(t *testing.T) {
+	edPrivate, _ := newTestEd25519Key(t)
+	ecPrivate, _ := newTestECDSAKey(t, elliptic.P256())
+
+	for name, key := range map[string][]byte{"EdDSA": edPrivate, "ES256": ecPrivate} {
+		t.Run(name, func(t *testing.T) {
+			a, err := NewJWTAuthorizer([][]byte{key}, 5*time.Minute)
+			require.NoError(t, err)
+
+			revoked := time.Now()
+			token, created, err := a.CreateToken(AuthFields{Username: "user123", IsAdmin: true})
+			require.NoError(t, err)
+
+			payload, err := a.VerifyToken(token, AuthFields{Username: "user123", TokensRevoked: revoked})
+			require.NoError(t, err, "token issued right after revocation is valid")
+			assert.Equal(t, created.ID, payload.ID)
+			assert.Equal(t, "user123", payload.Username)
+			assert.True(t, payload.IsAdmin)
+			assert.True(t, created.IssuedAt.Equal(payload.IssuedAt))
+			assert.True(t, created.ExpiredAt.Equal(payload.ExpiredAt))
+
+			_, err = a.VerifyToken(token, AuthFields{Username: "wrong_user"})
+			assert.ErrorIs(t, err, ErrInvalidToken)
+
+			_, err = a.VerifyToken(token, AuthFields{Username: "user123", TokensRevoked: time.Now().Add(time.Second)})
+			assert.ErrorIs(t, err, ErrRevokedToken)
+		})
+	}
+}
+
+func TestJWTAuthorizer_Expired(t *testing.T) {
+	key, _ := newTestEd25519Key(t)
+	a, err := NewJWTAuthorizer([][]byte{key}, -time.Minute)
+	require.NoError(t, err)
+
+	token, _, err := a.CreateToken(AuthFields{Username: "user123"})
+	require.NoError(t, err)
+
+	_, err = a.VerifyToken(token, AuthFields{Username: "user123"})
+	assert.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestJWTAuthorizer_KeyRotation(t *testing.T) {
+	oldPrivate, oldPublic := newTestEd25519Key(t)
+	newPrivate, _ := newTestECDSAKey(t, elliptic.P256())
+
+	oldAuthorizer, err := NewJWTAuthorizer([][]byte{oldPrivate}, 5*time.Minute)
+	require.NoError(t, err)
+	oldToken, _, err := oldAuthorizer.CreateToken(AuthFields{Username: "user123"})
+	require.NoError(t, err)
+
+	rotated, err := NewJWTAuthorizer([][]byte{newPrivate, oldPublic}, 5*time.Minute)
+	require.NoError(t, err)
+
+	_, err = rotated.VerifyToken(oldToken, AuthFields{Username: "user123"})
+	require.NoError(t, err, "token signed with previous key is valid")
+
+	newToken, _, err := rotated.CreateToken(AuthFields{Username: "user123"})
+	require.NoError(t, err)
+
+	header := new(jwtHeader)
+	require.NoError(t, jwtDecodeJSON(strings.Split(newToken, ".")[0], header))
+	assert.Equal(t, jwtAlgES256, header.Alg)
+	assert.Equal(t, rotated.signingKey.id, header.Kid)
+
+	_, err = oldAuthorizer.VerifyToken(newToken, AuthFields{Username: "user123"})
+	assert.ErrorIs(t, err, ErrInvalidToken, "key of new token is unknown")
+
+	withoutOld, err := NewJWTAuthorizer([][]byte{newPrivate}, 5*time.Minute)
+	require.NoError(t, err)
+
+	_, err = withoutOld.VerifyToken(oldToken, AuthFields{Username: "user123"})
+	assert.ErrorIs(t, err, ErrInvalidToken, "previous key is removed from key ring")
+}
+
+func TestJWTAuthorizer_InvalidTokens(t *testing.T) {
+	key, _ := newTestEd25519Key(t)
+	a, err := NewJWTAuthorizer([][]byte{key}, 5*time.Minute)
+	require.NoError(t, err)
+
+	token, _, err := a.CreateToken(AuthFields{Username: "user123"})
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	encodeJSON := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	claims := new(jwtClaims)
+	require.NoError(t, jwtDecodeJSON(parts[1], claims))
+	claims.IsAdmin = true
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Empty token", token: ""},
+		{name: "Malformed token", token: "a.b"},
+		{name: "Malformed header", token: "header." + parts[1] + "." + parts[2]},
+		{
+			name:  "Unknown key",
+			token: encodeJSON(jwtHeader{Alg: jwtAlgEdDSA, Typ: "JWT", Kid: "unknown"}) + "." + parts[1] + "." + parts[2],
+		},
+		{
+			name:  "Other algorithm",
+			token: encodeJSON(jwtHeader{Alg: "none", Typ: "JWT", Kid: a.signingKey.id}) + "." + parts[1] + ".",
+		},
+		{name: "Tampered claims", token: parts[0] + "." + encodeJSON(claims) + "." + parts[2]},
+		{name: "Wrong signature", token: parts[0] + "." + parts[1] + "." + parts[0]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.VerifyToken(tt.token, AuthFields{Username: "user123"})
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestJWTAuthorizer_VerifyWithPublicKey(t *testing.T) {
+	private, public := newTestEd25519Key(t)
+	a, err := NewJWTAuthorizer([][]byte{private}, 5*time.Minute)
+	require.NoError(t, err)
+
+	token, created, err := a.CreateToken(AuthFields{Username: "user123"})
+	require.NoError(t, err)
+
+	// Token is verified by third-party service, which has only public key.
+	block, _ := pem.Decode(public)
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey.(ed25519.PublicKey), []byte(parts[0]+"."+parts[1]), signature))
+
+	claims := make(map[string]interface{})
+	require.NoError(t, jwtDecodeJSON(parts[1], &claims))
+	assert.Equal(t, "user123", claims["sub"])
+	assert.Equal(t, created.ID.String(), claims["jti"])
+	assert.Equal(t, jwtIssuer, claims["iss"])
+	assert.InDelta(t, created.ExpiredAt.Unix(), claims["exp"], 1)
+}
+
+func TestNumericDate(t *testing.T) {
+	now := time.Now()
+	got, err := fromNumericDate(toNumericDate(now))
+	require.NoError(t, err)
+	assert.True(t, now.Equal(got))
+
+	tests := []struct {
+		date    json.Number
+		want    time.Time
+		wantErr bool
+	}{
+		{date: "1700000000", want: time.Unix(1700000000, 0)},
+		{date: "1700000000.5", want: time.Unix(1700000000, 500000000)},
+		{date: "1700000000.1234567891", want: time.Unix(1700000000, 123456789)},
+		{date: "1.7e9", wantErr: true},
+		{date: "1700000000.-5", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := fromNumericDate(tt.date)
+		if tt.wantErr {
+			assert.Error(t, err, tt.date)
+			continue
+		}
+
+		require.NoError(t, err, tt.date)
+		assert.True(t, tt.want.Equal(got), tt.date)
+	}
+}
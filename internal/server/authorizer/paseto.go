@@ -1,7 +1,13 @@
 package authorizer
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aead/chacha20poly1305"
@@ -9,52 +15,215 @@ import (
 )
 
 // PasetoAuthorizer represents implementation of Authorizer based on Paseto Tokens.
+//
+// Authorizer holds ordered key ring: tokens are encrypted with first key only, while all keys
+// are used for decryption, so key can be rotated without invalidating issued tokens. Key's ID is
+// carried in token's footer. Key with empty ID produces tokens without key's ID, such tokens
+// are decrypted with key with empty ID only.
 type PasetoAuthorizer struct {
 	paseto        *paseto.V2
-	key           []byte
 	tokenDuration time.Duration
+	// File of key ring, empty if key ring isn't loaded from file.
+	keysFile string
+
+	mu   sync.RWMutex
+	keys []pasetoKey
 }
 
-var _ A = (*PasetoAuthorizer)(nil)
+var (
+	_ A        = (*PasetoAuthorizer)(nil)
+	_ Reloader = (*PasetoAuthorizer)(nil)
+)
 
-// NewPasetoAuthorizer creates new Paseto Authorizer.
+// pasetoKey represents key of Paseto authorizer's key ring.
+type pasetoKey struct {
+	id  string
+	key []byte
+}
+
+// pasetoFooter represents footer of Paseto token.
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// NewPasetoAuthorizer creates new Paseto Authorizer with single key with empty ID.
 func NewPasetoAuthorizer(key string, tokenDuration time.Duration) (*PasetoAuthorizer, error) {
-	if len(key) != chacha20poly1305.KeySize {
-		return nil, fmt.Errorf("invalid key size: got %d, must be exactly %d characters",
-			len(key), chacha20poly1305.KeySize)
+	if err := checkPasetoKey(key); err != nil {
+		return nil, err
+	}
+
+	a := &PasetoAuthorizer{
+		paseto:        paseto.NewV2(),
+		tokenDuration: tokenDuration,
+		keys:          []pasetoKey{{key: []byte(key)}},
 	}
 
+	return a, nil
+}
+
+// NewPasetoAuthorizerFromFile creates new Paseto Authorizer with key ring loaded from file.
+// Key ring can be reloaded from same file with Reload.
+//
+// Every non-empty line of file, except comments started with '#', contains key's ID and key
+// separated by spaces, ex. "2023-01 123456789a123456789a123456789a32". Line with key only
+// means key with empty ID. First key is used for encryption.
+func NewPasetoAuthorizerFromFile(path string, tokenDuration time.Duration) (*PasetoAuthorizer, error) {
 	a := &PasetoAuthorizer{
 		paseto:        paseto.NewV2(),
-		key:           []byte(key),
 		tokenDuration: tokenDuration,
+		keysFile:      path,
+	}
+
+	if err := a.Reload(); err != nil {
+		return nil, err
 	}
 
 	return a, nil
 }
 
+// Reload reloads key ring from authorizer's file. If new key ring is invalid, current one is kept.
+func (a *PasetoAuthorizer) Reload() error {
+	if a.keysFile == "" {
+		return errors.New("key ring isn't loaded from file")
+	}
+
+	data, err := os.ReadFile(a.keysFile)
+	if err != nil {
+		return err
+	}
+
+	keys, err := parsePasetoKeys(data)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.mu.Unlock()
+
+	return nil
+}
+
 // CreateToken creates a token for a specific authorization fields and duration.
-func (a *PasetoAuthorizer) CreateToken(fields AuthFields) (string, error) {
-	payload, err := NewPayload(fields.Username, a.tokenDuration)
+func (a *PasetoAuthorizer) CreateToken(fields AuthFields) (string, *Payload, error) {
+	payload, err := NewPayload(fields.Username, fields.IsAdmin, a.tokenDuration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	a.mu.RLock()
+	key := a.keys[0]
+	a.mu.RUnlock()
+
+	var footer interface{}
+	if key.id != "" {
+		footer = pasetoFooter{KeyID: key.id}
+	}
+
+	token, err := a.paseto.Encrypt(key.key, payload, footer)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return a.paseto.Encrypt(a.key, payload, nil)
+	return token, payload, nil
 }
 
-// VerifyToken checks if the token is valid or not.
-func (a *PasetoAuthorizer) VerifyToken(token string, fields AuthFields) error {
+// VerifyToken checks if the token is valid or not, returns token's payload.
+//
+// Token is decrypted with key from key ring, key is found by ID in token's footer.
+func (a *PasetoAuthorizer) VerifyToken(token string, fields AuthFields) (*Payload, error) {
+	footer := new(pasetoFooter)
+	if err := paseto.ParseFooter(token, footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, ok := a.findKey(footer.KeyID)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
 	payload := new(Payload)
 
-	err := a.paseto.Decrypt(token, a.key, payload, nil)
+	err := a.paseto.Decrypt(token, key, payload, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = payload.Valid(fields)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// findKey returns key of key ring with provided ID.
+func (a *PasetoAuthorizer) findKey(id string) ([]byte, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, k := range a.keys {
+		if k.id == id {
+			return k.key, true
+		}
+	}
+
+	return nil, false
+}
+
+// parsePasetoKeys is a helper function, which parses key ring's file. Please see
+// NewPasetoAuthorizerFromFile for file's format.
+func parsePasetoKeys(data []byte) ([]pasetoKey, error) {
+	var keys []pasetoKey
+
+	ids := make(map[string]struct{})
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		var k pasetoKey
+
+		switch fields := strings.Fields(text); len(fields) {
+		case 1:
+			k.key = []byte(fields[0])
+		case 2:
+			k.id, k.key = fields[0], []byte(fields[1])
+		default:
+			return nil, fmt.Errorf("line %d: expected key's ID and key", line)
+		}
+
+		if err := checkPasetoKey(string(k.key)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if _, ok := ids[k.id]; ok {
+			return nil, fmt.Errorf("line %d: duplicated key's ID '%s'", line, k.id)
+		}
+
+		ids[k.id] = struct{}{}
+		keys = append(keys, k)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("empty key ring")
+	}
+
+	return keys, nil
+}
+
+// checkPasetoKey is a helper function, which checks key's size.
+func checkPasetoKey(key string) error {
+	if len(key) != chacha20poly1305.KeySize {
+		return fmt.Errorf("invalid key size: got %d, must be exactly %d characters",
+			len(key), chacha20poly1305.KeySize)
 	}
 
 	return nil
@@ -1,12 +1,17 @@
 package authorizer
 
 import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/o1egl/paseto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewPasetoAuthorizer(t *testing.T) {
@@ -29,7 +34,7 @@ func TestNewPasetoAuthorizer(t *testing.T) {
 			},
 			want: &PasetoAuthorizer{
 				paseto:        paseto.NewV2(),
-				key:           []byte("123456789a123456789a123456789a32"),
+				keys:          []pasetoKey{{key: []byte("123456789a123456789a123456789a32")}},
 				tokenDuration: 5 * time.Minute,
 			},
 			wantErr: false,
@@ -62,17 +67,167 @@ func TestPasetoAuthorizer_CreateAndVerify(t *testing.T) {
 	a, err := NewPasetoAuthorizer("123456789a123456789a123456789a32", 5*time.Minute)
 	assert.NoError(t, err)
 
-	fields := AuthFields{Username: "user123"}
-	token, err := a.CreateToken(fields)
+	fields := AuthFields{Username: "user123", IsAdmin: true}
+	token, created, err := a.CreateToken(fields)
 	assert.NoError(t, err)
 
-	err = a.VerifyToken(token, fields)
+	payload, err := a.VerifyToken(token, AuthFields{Username: "user123"})
 	assert.NoError(t, err)
+	assert.Equal(t, "user123", payload.Username)
+	assert.True(t, payload.IsAdmin)
+	assert.Equal(t, created.ID, payload.ID)
 
-	err = a.VerifyToken(token, AuthFields{Username: "wrong_user"})
+	_, err = a.VerifyToken(token, AuthFields{Username: "wrong_user"})
 	assert.Error(t, err)
 
-	a.key = []byte("asdasdasd")
-	err = a.VerifyToken(token, fields)
+	a.keys[0].key = []byte("asdasdasd")
+	_, err = a.VerifyToken(token, fields)
 	assert.Error(t, err)
 }
+
+// writeTestKeysFile writes key ring's file and returns its path.
+func writeTestKeysFile(t *testing.T, path string, lines ...string) string {
+	t.Helper()
+
+	if path == "" {
+		path = filepath.Join(t.TempDir(), "keys")
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+
+	return path
+}
+
+func TestNewPasetoAuthorizerFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name: "Key ring",
+			lines: []string{
+				"# current key",
+				"  2023-02   123456789b123456789b123456789b32  ",
+				"",
+				"2023-01 123456789a123456789a123456789a32",
+				"123456789c123456789c123456789c32",
+			},
+			wantIDs: []string{"2023-02", "2023-01", ""},
+		},
+		{
+			name:    "Empty key ring",
+			lines:   []string{"# no keys"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid key size",
+			lines:   []string{"2023-01 123456789a"},
+			wantErr: true,
+		},
+		{
+			name:    "Too many fields",
+			lines:   []string{"2023-01 123456789a123456789a123456789a32 extra"},
+			wantErr: true,
+		},
+		{
+			name: "Duplicated ID",
+			lines: []string{
+				"2023-01 123456789a123456789a123456789a32",
+				"2023-01 123456789b123456789b123456789b32",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewPasetoAuthorizerFromFile(writeTestKeysFile(t, "", tt.lines...), 5*time.Minute)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(a.keys))
+			for _, k := range a.keys {
+				ids = append(ids, k.id)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+
+	_, err := NewPasetoAuthorizerFromFile(filepath.Join(t.TempDir(), "missed"), 5*time.Minute)
+	assert.Error(t, err)
+}
+
+func TestPasetoAuthorizer_KeyRotation(t *testing.T) {
+	const (
+		oldKey = "123456789a123456789a123456789a32"
+		newKey = "123456789b123456789b123456789b32"
+	)
+
+	legacy, err := NewPasetoAuthorizer(oldKey, 5*time.Minute)
+	require.NoError(t, err)
+	legacyToken, _, err := legacy.CreateToken(AuthFields{Username: "user123"})
+	require.NoError(t, err)
+
+	path := writeTestKeysFile(t, "", "2023-01 "+oldKey)
+	a, err := NewPasetoAuthorizerFromFile(path, 5*time.Minute)
+	require.NoError(t, err)
+
+	oldToken, _, err := a.CreateToken(AuthFields{Username: "user123"})
+	require.NoError(t, err)
+
+	footer := new(pasetoFooter)
+	require.NoError(t, paseto.ParseFooter(oldToken, footer))
+	assert.Equal(t, "2023-01", footer.KeyID)
+
+	_, err = a.VerifyToken(legacyToken, AuthFields{Username: "user123"})
+	assert.ErrorIs(t, err, ErrInvalidToken, "token without key's ID requires key with empty ID")
+
+	t.Run("Rotate key", func(t *testing.T) {
+		writeTestKeysFile(t, path, "2023-02 "+newKey, "2023-01 "+oldKey, oldKey)
+		require.NoError(t, a.Reload())
+
+		_, err := a.VerifyToken(oldToken, AuthFields{Username: "user123"})
+		require.NoError(t, err, "token encrypted with previous key is valid")
+		_, err = a.VerifyToken(legacyToken, AuthFields{Username: "user123"})
+		require.NoError(t, err, "token without key's ID is valid")
+
+		newToken, _, err := a.CreateToken(AuthFields{Username: "user123"})
+		require.NoError(t, err)
+		require.NoError(t, paseto.ParseFooter(newToken, footer))
+		assert.Equal(t, "2023-02", footer.KeyID)
+	})
+
+	t.Run("Invalid key ring is not loaded", func(t *testing.T) {
+		writeTestKeysFile(t, path, "2023-03 short")
+		assert.Error(t, a.Reload())
+
+		_, err := a.VerifyToken(oldToken, AuthFields{Username: "user123"})
+		require.NoError(t, err, "current key ring is kept")
+	})
+
+	t.Run("Remove previous key", func(t *testing.T) {
+		writeTestKeysFile(t, path, "2023-02 "+newKey)
+		require.NoError(t, a.Reload())
+
+		_, err := a.VerifyToken(oldToken, AuthFields{Username: "user123"})
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Tampered key's ID", func(t *testing.T) {
+		newToken, _, err := a.CreateToken(AuthFields{Username: "user123"})
+		require.NoError(t, err)
+
+		parts := strings.Split(newToken, ".")
+		parts[3] = base64.RawURLEncoding.EncodeToString([]byte(`{"kid":"2023-01"}`))
+		_, err = a.VerifyToken(strings.Join(parts, "."), AuthFields{Username: "user123"})
+		assert.Error(t, err)
+	})
+
+	assert.Error(t, legacy.Reload(), "key ring isn't loaded from file")
+}
@@ -9,12 +9,13 @@ import (
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
+	IsAdmin   bool      `json:"is_admin"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-// NewPayload creates a new token payload with a specific username and duration.
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+// NewPayload creates a new token payload with a specific username, administrator role and duration.
+func NewPayload(username string, isAdmin bool, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -23,6 +24,7 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:        tokenID,
 		Username:  username,
+		IsAdmin:   isAdmin,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
@@ -31,6 +33,8 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 }
 
 // Valid checks if the token payload is valid or not.
+//
+// Session is checked last, so check isn't performed for invalid, expired or revoked token.
 func (p *Payload) Valid(fields AuthFields) error {
 	if fields.Username != p.Username {
 		return ErrInvalidToken
@@ -40,5 +44,13 @@ func (p *Payload) Valid(fields AuthFields) error {
 		return ErrExpiredToken
 	}
 
+	if p.IssuedAt.Before(fields.TokensRevoked) {
+		return ErrRevokedToken
+	}
+
+	if fields.CheckSession != nil {
+		return fields.CheckSession(p.ID)
+	}
+
 	return nil
 }
@@ -4,11 +4,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 func TestPayloadValid(t *testing.T) {
-	p, err := NewPayload("username", 5*time.Second)
+	p, err := NewPayload("username", false, 5*time.Second)
 	require.NoError(t, err)
 
 	p.ExpiredAt = time.Now().Add(-10 * time.Minute)
@@ -17,3 +18,33 @@ func TestPayloadValid(t *testing.T) {
 	err = p.Valid(fields)
 	require.ErrorIs(t, err, ErrExpiredToken)
 }
+
+func TestPayloadValid_Revoked(t *testing.T) {
+	p, err := NewPayload("username", false, 5*time.Second)
+	require.NoError(t, err)
+
+	fields := AuthFields{Username: "username", TokensRevoked: p.IssuedAt.Add(-time.Second)}
+	require.NoError(t, p.Valid(fields))
+
+	fields.TokensRevoked = p.IssuedAt.Add(time.Second)
+	require.ErrorIs(t, p.Valid(fields), ErrRevokedToken)
+}
+
+func TestPayloadValid_Session(t *testing.T) {
+	p, err := NewPayload("username", false, 5*time.Second)
+	require.NoError(t, err)
+
+	var checked uuid.UUID
+	fields := AuthFields{Username: "username", CheckSession: func(id uuid.UUID) error {
+		checked = id
+		return nil
+	}}
+	require.NoError(t, p.Valid(fields))
+	require.Equal(t, p.ID, checked)
+
+	fields.CheckSession = func(uuid.UUID) error { return ErrRevokedToken }
+	require.ErrorIs(t, p.Valid(fields), ErrRevokedToken)
+
+	fields.Username = "other"
+	require.ErrorIs(t, p.Valid(fields), ErrInvalidToken, "session isn't checked for invalid token")
+}
@@ -1,9 +1,14 @@
 package authorizer
 
 import (
+	"time"
+
 	"github.com/artfuldog/gophkeeper/internal/logger"
 )
 
+// yesManTokenDuration is a duration of YesManAuthorizer's tokens.
+const yesManTokenDuration = 24 * time.Hour
+
 // YesManAuthorizer represents test implementation of Authorizer.
 //
 // Should be used only for tested purposes. Always successfully authorizes user.
@@ -20,14 +25,22 @@ func NewYesManAuthorizer(logger logger.L) *YesManAuthorizer {
 	}
 }
 
-// CreateToken is a dummy function for create token. Always return nil-error.
-func (a YesManAuthorizer) CreateToken(fields AuthFields) (string, error) {
+// CreateToken is a dummy function for create token. Returns empty token and new payload.
+func (a YesManAuthorizer) CreateToken(fields AuthFields) (string, *Payload, error) {
 	a.logger.Info("yes", "createtoken")
-	return "", nil
+
+	payload, err := NewPayload(fields.Username, fields.IsAdmin, yesManTokenDuration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "", payload, nil
 }
 
 // VerifyToken is a dummy function for verifying token. Always return nil-error.
-func (a YesManAuthorizer) VerifyToken(token string, fields AuthFields) error {
+//
+// Returned payload grants administrator role to any user.
+func (a YesManAuthorizer) VerifyToken(token string, fields AuthFields) (*Payload, error) {
 	a.logger.Info("yes", "VerifyToken")
-	return nil
+	return &Payload{Username: fields.Username, IsAdmin: true}, nil
 }
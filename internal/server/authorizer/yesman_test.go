@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,9 +13,12 @@ func TestYesMan(t *testing.T) {
 	a := NewYesManAuthorizer(logger)
 	fields := AuthFields{Username: "username"}
 
-	_, err := a.CreateToken(fields)
+	_, created, err := a.CreateToken(fields)
 	require.NoError(t, err)
+	require.Equal(t, "username", created.Username)
+	require.NotEqual(t, uuid.Nil, created.ID)
 
-	err = a.VerifyToken("", fields)
+	payload, err := a.VerifyToken("", fields)
 	require.NoError(t, err)
+	require.Equal(t, "username", payload.Username)
 }
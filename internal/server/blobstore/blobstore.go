@@ -0,0 +1,81 @@
+// Package blobstore represents interface for content-addressed storage of large binary objects.
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Supported blob stores.
+const (
+	TypeFS = "fs"
+)
+
+// Errors.
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+	ErrCorrupted  = errors.New("blob is corrupted")
+)
+
+// keyLength is a length of blob's key - hex-encoded SHA256 hash.
+const keyLength = sha256.Size * 2
+
+// Store represents general blob store interface.
+//
+// Blobs are keyed by hash of their content, so same content is stored only once.
+// Store doesn't encrypt blobs, callers must store already encrypted data.
+type Store interface {
+	// Stores blob and returns its key. Storing already existing blob refreshes its modification time.
+	Put(ctx context.Context, data []byte) (string, error)
+	// Returns blob's content, returns ErrNotFound if blob doesn't exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Deletes blob, deleting of not existing blob is not an error.
+	Delete(ctx context.Context, key string) error
+	// Returns information about all stored blobs.
+	List(ctx context.Context) ([]Info, error)
+}
+
+// Info contains information about stored blob.
+type Info struct {
+	Key     string
+	ModTime time.Time
+}
+
+// New is a fabric method for create Store with provided type.
+//
+// Address is a store specific location of blobs, for filesystem store it is a path to directory.
+func New(storeType string, address string) (Store, error) {
+	switch storeType {
+	case TypeFS:
+		return NewFS(address)
+	default:
+		return nil, fmt.Errorf("unknown blob store type: %s", storeType)
+	}
+}
+
+// Key returns key of blob with provided content.
+func Key(data []byte) string {
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// checkKey is a helper function which checks that key is a valid hex-encoded SHA256 hash,
+// so key can be safely used as part of blob's location.
+func checkKey(key string) error {
+	if len(key) != keyLength {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	if _, err := hex.DecodeString(key); err != nil || strings.ToLower(key) != key {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package blobstore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	s, err := New(TypeFS, t.TempDir())
+	require.NoError(t, err)
+	assert.NotEmpty(t, s)
+
+	s, err = New(TypeFS, "")
+	require.Error(t, err)
+	assert.Empty(t, s)
+
+	s, err = New("notype", t.TempDir())
+	require.Error(t, err)
+	assert.Empty(t, s)
+}
+
+func TestKey(t *testing.T) {
+	key := Key([]byte("data"))
+	assert.Len(t, key, keyLength)
+	assert.NoError(t, checkKey(key))
+	assert.Equal(t, key, Key([]byte("data")))
+	assert.NotEqual(t, key, Key([]byte("other data")))
+}
+
+func TestCheckKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "Valid key", key: Key([]byte("data")), wantErr: false},
+		{name: "Empty key", key: "", wantErr: true},
+		{name: "Short key", key: "abcdef", wantErr: true},
+		{name: "Not hex key", key: strings.Repeat("z", keyLength), wantErr: true},
+		{name: "Upper case key", key: strings.ToUpper(Key([]byte("data"))), wantErr: true},
+		{name: "Path traversal", key: "../" + Key([]byte("data"))[3:], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkKey(tt.key)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidKey)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
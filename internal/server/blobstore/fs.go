@@ -0,0 +1,185 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// tempFilePrefix is a prefix of temporary files, which are written before renamed to blob's file.
+const tempFilePrefix = ".tmp-"
+
+// FS represents blob store in local filesystem.
+//
+// Every blob is stored in separate file named by its key. Files are spread by subdirectories
+// named by first two symbols of key, so directories don't grow too big.
+type FS struct {
+	dir string
+}
+
+var _ Store = (*FS)(nil)
+
+// NewFS creates new filesystem blob store, directory is created if not exists.
+func NewFS(dir string) (*FS, error) {
+	if dir == "" {
+		return nil, errors.New("missed blob store directory")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &FS{dir: dir}, nil
+}
+
+// Put stores blob and returns its key.
+//
+// Blob is written to temporary file first and then renamed, so partially written blob is never
+// visible. If blob already exists its modification time is refreshed.
+func (s *FS) Put(ctx context.Context, data []byte) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	key := Key(data)
+	path := s.path(key)
+
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err == nil {
+		return key, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), tempFilePrefix+"*")
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeAndClose(f, data); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+
+		return "", err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+
+		return "", err
+	}
+
+	return key, nil
+}
+
+// Get returns blob's content.
+//
+// Content is checked against key, so corrupted blob is never returned.
+func (s *FS) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+
+		return nil, err
+	}
+
+	if Key(data) != key {
+		return nil, fmt.Errorf("%w: %s", ErrCorrupted, key)
+	}
+
+	return data, nil
+}
+
+// Delete deletes blob.
+func (s *FS) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns information about all stored blobs.
+//
+// Temporary and unknown files are skipped.
+func (s *FS) List(ctx context.Context) ([]Info, error) {
+	var blobs []Info
+
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if d.IsDir() || strings.HasPrefix(d.Name(), tempFilePrefix) || checkKey(d.Name()) != nil {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		blobs = append(blobs, Info{Key: d.Name(), ModTime: info.ModTime()})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blobs, nil
+}
+
+// path returns path to blob's file.
+func (s *FS) path(key string) string {
+	return filepath.Join(s.dir, key[:2], key)
+}
+
+// writeAndClose is a helper function which writes data to file, syncs and closes it.
+func writeAndClose(f *os.File, data []byte) error {
+	if _, err := f.Write(data); err != nil {
+		f.Close() //nolint:errcheck
+
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close() //nolint:errcheck
+
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,100 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFS(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "blobs")
+
+	s, err := NewFS(dir)
+	require.NoError(t, err)
+
+	data := []byte("encrypted blob")
+
+	t.Run("Put and get blob", func(t *testing.T) {
+		key, err := s.Put(ctx, data)
+		require.NoError(t, err)
+		assert.Equal(t, Key(data), key)
+		assert.FileExists(t, filepath.Join(dir, key[:2], key))
+
+		got, err := s.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+	})
+
+	t.Run("Put existing blob refreshes modification time", func(t *testing.T) {
+		key := Key(data)
+		old := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(s.path(key), old, old))
+
+		gotKey, err := s.Put(ctx, data)
+		require.NoError(t, err)
+		assert.Equal(t, key, gotKey)
+
+		blobs, err := s.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, blobs, 1)
+		assert.Equal(t, key, blobs[0].Key)
+		assert.True(t, blobs[0].ModTime.After(old.Add(time.Minute)))
+	})
+
+	t.Run("List skips temporary and unknown files", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "unknown"), []byte("1"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, Key(data)[:2], tempFilePrefix+"1"), []byte("1"), 0o600))
+
+		blobs, err := s.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, blobs, 1)
+	})
+
+	t.Run("Get not existing blob", func(t *testing.T) {
+		_, err := s.Get(ctx, Key([]byte("unknown")))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Get corrupted blob", func(t *testing.T) {
+		key, err := s.Put(ctx, []byte("to be corrupted"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(s.path(key), []byte("corrupted"), 0o600))
+
+		_, err = s.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrCorrupted)
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		_, err := s.Get(ctx, "../../etc/passwd")
+		assert.ErrorIs(t, err, ErrInvalidKey)
+		assert.ErrorIs(t, s.Delete(ctx, "../../etc/passwd"), ErrInvalidKey)
+	})
+
+	t.Run("Delete blob", func(t *testing.T) {
+		key := Key(data)
+		require.NoError(t, s.Delete(ctx, key))
+
+		_, err := s.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		assert.NoError(t, s.Delete(ctx, key), "deleting of not existing blob is not an error")
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := s.Put(ctx, data)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = s.Get(ctx, Key(data))
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = s.List(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
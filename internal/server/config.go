@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/artfuldog/gophkeeper/internal/server/authorizer"
 	"github.com/artfuldog/gophkeeper/internal/server/db"
 	"github.com/caarlos0/env/v6"
 	flag "github.com/spf13/pflag"
@@ -12,16 +13,26 @@ import (
 
 // Default configuration parameters.
 const (
-	defAddress  = "127.0.0.1:3200"
-	defDBType   = db.TypePostgres
-	defSyncType = "postgres"
+	defAddress    = "127.0.0.1:3200"
+	defDBType     = db.TypePostgres
+	defSyncType   = "postgres"
+	defAuthorizer = authorizer.TypePaseto
+	defRateLimit  = "100:200" // 100 requests per second with bursts up to 200 requests
 )
 
 //nolint:gochecknoglobals
 var (
-	defLogLevel         = fmt.Sprint(logger.WarnLevel)
-	defMaxSecretSize    = uint32(50 * 1024 * 1024) // 50 Mb
-	defTokenValidPeriod = uint32(30 * 60)          // 30 minutes
+	defLogLevel                = fmt.Sprint(logger.WarnLevel)
+	defMaxSecretSize           = uint32(50 * 1024 * 1024)  // 50 Mb
+	defTokenValidPeriod        = uint32(15 * 60)           // 15 minutes
+	defRefreshTokenValidPeriod = uint32(30 * 24 * 60 * 60) // 30 days
+	defItemVersions            = uint32(10)
+	defTrashRetention          = uint32(30)          // 30 days
+	defBlobThreshold           = uint32(1024 * 1024) // 1 Mb
+	defLoginMaxUserFailures    = uint32(5)
+	defLoginMaxIPFailures      = uint32(20)
+	defLoginLockout            = uint32(30)      // 30 seconds
+	defLoginMaxLockout         = uint32(60 * 60) // 1 hour
 )
 
 // Config represents server's configurations parameters.
@@ -30,9 +41,9 @@ type Config struct {
 	// Supported format: <ip-address/fqdn/hostname>:<port>, ex. 10.20.30.40:3200, my.host.com:3333
 	Address string `env:"GK_ADDRESS"`
 
-	// Database type (postrgres).
+	// Database type (postgres/sqlite/memory).
 	DBType string `env:"GK_DB_TYPE"`
-	// Database dsn in format address:port/db_name.
+	// Database dsn in format address:port/db_name, for SQLite - path to database file.
 	DBDSN string `env:"GK_DB_DSN"`
 	// Database user.
 	DBUser string `env:"GK_DB_USER"`
@@ -50,10 +61,55 @@ type Config struct {
 	LogLevel string `env:"GK_LOG_LEVEL"`
 	// Maximum secret size in bytes.
 	MaxSecretSize uint32 `env:"GK_MAX_SECRET"`
-	// Server key. Used for generated tokens. Must be 32-byte length.
+	// Authorizer type (paseto/jwt).
+	Authorizer string `env:"GK_AUTHORIZER"`
+	// Server key. Used for generated tokens by paseto authorizer. Must be 32-byte length.
 	ServerKey string `env:"GK_SERVER_KEY"`
+	// File with key ring of paseto authorizer, overrides server key. Every line contains key's ID
+	// and 32-byte key, first key encrypts tokens. Key ring is reloaded on SIGHUP.
+	ServerKeysFile string `env:"GK_SERVER_KEYS_FILE"`
+	// Comma-separated list of PEM files with Ed25519 or ECDSA P-256 keys of jwt authorizer.
+	// First key must be private key, it signs tokens. All keys verify tokens.
+	JWTKeys string `env:"GK_JWT_KEYS"`
 	// Token valid period in seconds.
 	TokenValidPeriod uint32 `env:"GK_TOKEN_EXP"`
+	// Refresh token valid period in seconds. Refresh token is used for re-issue of expired token.
+	RefreshTokenValidPeriod uint32 `env:"GK_REFRESH_TOKEN_EXP"`
+	// Number of items' previous versions, kept in history. Zero disables history.
+	ItemVersions uint32 `env:"GK_ITEM_VERSIONS"`
+	// Trashed items' retention period in days. Zero disables purge of trash.
+	TrashRetention uint32 `env:"GK_TRASH_RETENTION"`
+	// Maximum number of user's items. Zero means no limit.
+	MaxItems uint32 `env:"GK_MAX_ITEMS"`
+	// Maximum size of user's stored data in bytes. Zero means no limit.
+	MaxUserSize uint64 `env:"GK_MAX_USER_SIZE"`
+	// Directory of blob store for large secrets. Empty value keeps all secrets in database.
+	BlobDir string `env:"GK_BLOB_DIR"`
+	// Size of secret in bytes, above which secret is stored in blob store.
+	BlobThreshold uint32 `env:"GK_BLOB_THRESHOLD"`
+
+	// Number of failed logins per username, after which logins are locked. Zero disables check.
+	LoginMaxUserFailures uint32 `env:"GK_LOGIN_MAX_USER_FAILURES"`
+	// Number of failed logins per client's address, after which logins are locked. Zero disables check.
+	LoginMaxIPFailures uint32 `env:"GK_LOGIN_MAX_IP_FAILURES"`
+	// First lockout period of logins in seconds, every next failure doubles it. Zero disables throttling.
+	LoginLockout uint32 `env:"GK_LOGIN_LOCKOUT"`
+	// Maximum lockout period of logins in seconds.
+	LoginMaxLockout uint32 `env:"GK_LOGIN_MAX_LOCKOUT"`
+
+	// Rate limit of user's requests to all methods in format "rate:burst" (requests per second and
+	// maximum burst). Requests without authorization are limited per client's address. Empty value
+	// disables limit.
+	RateLimit string `env:"GK_RATE_LIMIT"`
+	// Comma-separated list of rate limits of user's requests to particular methods in format
+	// "Service/Method=rate:burst", ex. "Items/GetItems=10:20,Items/CreateItem=2:5".
+	RateLimitMethods string `env:"GK_RATE_LIMIT_METHODS"`
+
+	// Username of user, which is granted administrator role on start. User must be registered.
+	Admin string `env:"GK_ADMIN"`
+
+	// Apply database migrations and exit.
+	MigrateOnly bool
 }
 
 // NewConfig a helper function for reading cli arguments and environmental variables and
@@ -66,8 +122,8 @@ func NewConfig() (*Config, error) {
 	// Read cli arguments
 	flag.StringVarP(&cfg.Address, "address", "a", defAddress, "address and port of server in format ip:port")
 
-	flag.StringVarP(&cfg.DBType, "dbtype", "D", defDBType, "database type (postrgres)")
-	flag.StringVarP(&cfg.DBDSN, "dbdsn", "d", "", "database dsn in format address:port")
+	flag.StringVarP(&cfg.DBType, "dbtype", "D", defDBType, "database type (postgres/sqlite/memory)")
+	flag.StringVarP(&cfg.DBDSN, "dbdsn", "d", "", "database dsn in format address:port (path to file for sqlite)")
 	flag.StringVar(&cfg.DBUser, "db_user", "", "database user (should be set via cli only for testing)")
 	flag.StringVar(&cfg.DBPassword, "db_password", "", "database password (should be set via cli only for testing)")
 
@@ -77,8 +133,43 @@ func NewConfig() (*Config, error) {
 
 	flag.StringVarP(&cfg.LogLevel, "loglevel", "l", defLogLevel, "log level (debug/info/warn/error/fatal/panic)")
 	flag.Uint32VarP(&cfg.MaxSecretSize, "max_size", "m", defMaxSecretSize, "maximum secret size in bytes")
+	flag.StringVar(&cfg.Authorizer, "authorizer", defAuthorizer, "authorizer type (paseto/jwt)")
 	flag.StringVarP(&cfg.ServerKey, "server_key", "k", "", "server key(should be set via cli only for testing)")
+	flag.StringVar(&cfg.ServerKeysFile, "server_keys_file", "",
+		"file with key ring of paseto authorizer, overrides server key (reloaded on SIGHUP)")
+	flag.StringVar(&cfg.JWTKeys, "jwt_keys", "",
+		"comma-separated list of PEM files with jwt keys, first one is private signing key")
 	flag.Uint32VarP(&cfg.TokenValidPeriod, "token_exp", "t", defTokenValidPeriod, "token valid period in seconds")
+	flag.Uint32Var(&cfg.RefreshTokenValidPeriod, "refresh_token_exp", defRefreshTokenValidPeriod,
+		"refresh token valid period in seconds")
+	flag.Uint32Var(&cfg.ItemVersions, "item_versions", defItemVersions,
+		"number of items' previous versions kept in history (0 - disable history)")
+	flag.Uint32Var(&cfg.TrashRetention, "trash_retention", defTrashRetention,
+		"number of days trashed items are kept before purge (0 - never purge)")
+	flag.Uint32Var(&cfg.MaxItems, "max_items", 0, "maximum number of user's items (0 - unlimited)")
+	flag.Uint64Var(&cfg.MaxUserSize, "max_user_size", 0,
+		"maximum size of user's stored data in bytes (0 - unlimited)")
+	flag.StringVar(&cfg.BlobDir, "blob_dir", "", "directory for store large secrets outside database (empty - disabled)")
+	flag.Uint32Var(&cfg.BlobThreshold, "blob_threshold", defBlobThreshold,
+		"size of secret in bytes, above which secret is stored in blob directory")
+
+	flag.Uint32Var(&cfg.LoginMaxUserFailures, "login_max_user_failures", defLoginMaxUserFailures,
+		"number of failed logins per username, after which logins are locked (0 - disable check)")
+	flag.Uint32Var(&cfg.LoginMaxIPFailures, "login_max_ip_failures", defLoginMaxIPFailures,
+		"number of failed logins per client's address, after which logins are locked (0 - disable check)")
+	flag.Uint32Var(&cfg.LoginLockout, "login_lockout", defLoginLockout,
+		"first lockout period of logins in seconds, doubled by every next failure (0 - disable throttling)")
+	flag.Uint32Var(&cfg.LoginMaxLockout, "login_max_lockout", defLoginMaxLockout,
+		"maximum lockout period of logins in seconds")
+
+	flag.StringVar(&cfg.RateLimit, "rate_limit", defRateLimit,
+		"rate limit of user's requests in format rate:burst (empty - disable limit)")
+	flag.StringVar(&cfg.RateLimitMethods, "rate_limit_methods", "",
+		"comma-separated list of methods' rate limits in format Service/Method=rate:burst")
+
+	flag.StringVar(&cfg.Admin, "admin", "", "username of registered user, which is granted administrator role on start")
+
+	flag.BoolVar(&cfg.MigrateOnly, "migrate-only", false, "apply database migrations and exit")
 
 	flag.Parse()
 
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UserState represents user's administrator role and lock state.
+type UserState struct {
+	IsAdmin bool `db:"is_admin"`
+	Locked  bool `db:"locked"`
+}
+
+// newUsersInfoSelect is a helper function for construct statement, which selects all users'
+// information without organizations' vaults sorted by username.
+func newUsersInfoSelect(psql sq.StatementBuilderType) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("username, email, is_admin, locked, otpkey is not null as two_factor, regdate, updated").
+		From("users").
+		Where("id not in (select vault_id from organizations)").
+		OrderBy("username").ToSql()
+}
+
+// newUserLockUpdateStmt is a helper function for construct statement, which locks or unlocks user.
+//
+// Locking revokes all user's tokens. Statement doesn't affect organizations' vaults.
+func newUserLockUpdateStmt(psql sq.StatementBuilderType, username Username,
+	locked bool) (SQLStatement, []interface{}, error) {
+	stmt := psql.
+		Update("users").
+		Set("locked", locked).
+		Where(sq.Eq{"username": username}).
+		Where("id not in (select vault_id from organizations)")
+
+	if locked {
+		stmt = stmt.Set("tokens_revoked", time.Now())
+	}
+
+	return stmt.ToSql()
+}
+
+// newUserAdminUpdateStmt is a helper function for construct statement, which grants or revokes
+// user's administrator role.
+//
+// Revoking role revokes all user's tokens, because tokens carry user's role. Statement doesn't
+// affect organizations' vaults.
+func newUserAdminUpdateStmt(psql sq.StatementBuilderType, username Username,
+	isAdmin bool) (SQLStatement, []interface{}, error) {
+	stmt := psql.
+		Update("users").
+		Set("is_admin", isAdmin).
+		Where(sq.Eq{"username": username}).
+		Where("id not in (select vault_id from organizations)")
+
+	if !isAdmin {
+		stmt = stmt.Set("tokens_revoked", time.Now())
+	}
+
+	return stmt.ToSql()
+}
+
+// newTwoFactorResetStmt is a helper function for construct statement, which disables user's
+// two-factor authentication.
+func newTwoFactorResetStmt(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("users").
+		Set("otpkey", nil).
+		Set("updated", time.Now().Truncate(time.Second)).
+		Where(sq.Eq{"username": username}).
+		Where("id not in (select vault_id from organizations)").ToSql()
+}
+
+// newServerStatsSelect is a helper function for construct statement, which counts users,
+// organizations and items.
+func newServerStatsSelect(psql sq.StatementBuilderType) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("(select count(*) from users where id not in (select vault_id from organizations))").
+		Column("(select count(*) from users where is_admin)").
+		Column("(select count(*) from users where locked)").
+		Column("(select count(*) from organizations)").
+		Column("(select count(*) from items)").
+		Column("(select count(*) from items where deleted_at is not null)").ToSql()
+}
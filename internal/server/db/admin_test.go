@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAdmin checks users' administration and server's statistics.
+func testAdmin(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	_, err := db.CreateOrganization(ctx, testUser1.Username, &pb.Organization{Name: "team", Okey: []byte("okey")})
+	require.NoError(t, err)
+
+	t.Run("List users", func(t *testing.T) {
+		users, err := db.ListUsers(ctx)
+		require.NoError(t, err)
+		require.Len(t, users, 2, "organization's vault isn't listed")
+		assert.Equal(t, testUser1.Username, users[0].Username)
+		assert.Equal(t, testUser1.Email, users[0].Email)
+		assert.True(t, users[0].TwoFactor)
+		assert.False(t, users[0].IsAdmin)
+		assert.False(t, users[0].Locked)
+		assert.Equal(t, testUser2.Username, users[1].Username)
+		assert.False(t, users[1].TwoFactor)
+	})
+
+	t.Run("Grant and revoke administrator role", func(t *testing.T) {
+		require.NoError(t, db.SetUserAdmin(ctx, testUser1.Username, true))
+
+		state, err := db.GetUserState(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.True(t, state.IsAdmin)
+
+		revoked, err := db.GetUserTokensRevoked(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.True(t, revoked.IsZero(), "granting role keeps tokens")
+
+		before := time.Now().Add(-time.Second)
+		require.NoError(t, db.SetUserAdmin(ctx, testUser1.Username, false))
+
+		state, err = db.GetUserState(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.False(t, state.IsAdmin)
+
+		revoked, err = db.GetUserTokensRevoked(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.True(t, revoked.After(before))
+
+		assert.ErrorIs(t, db.SetUserAdmin(ctx, "unknown", true), ErrNotFound)
+	})
+
+	t.Run("Lock and unlock user", func(t *testing.T) {
+		before := time.Now().Add(-time.Second)
+		require.NoError(t, db.SetUserLocked(ctx, testUser2.Username, true))
+
+		state, err := db.GetUserState(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.True(t, state.Locked)
+
+		revoked, err := db.GetUserTokensRevoked(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.True(t, revoked.After(before))
+
+		stats, err := db.GetServerStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats.LockedUsers)
+
+		require.NoError(t, db.SetUserLocked(ctx, testUser2.Username, false))
+
+		state, err = db.GetUserState(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.False(t, state.Locked)
+
+		assert.ErrorIs(t, db.SetUserLocked(ctx, "unknown", true), ErrNotFound)
+
+		_, err = db.GetUserState(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Reset two-factor authentication", func(t *testing.T) {
+		require.NoError(t, db.ResetTwoFactor(ctx, testUser1.Username))
+
+		_, otpKey, err := db.GetUserAuthData(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, otpKey)
+
+		assert.ErrorIs(t, db.ResetTwoFactor(ctx, "unknown"), ErrNotFound)
+	})
+
+	t.Run("Get server's statistics", func(t *testing.T) {
+		require.NoError(t, db.SetUserAdmin(ctx, testUser2.Username, true))
+
+		item := getTestItem(t, db, testItemLogin)
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, item.GetId()))
+
+		stats, err := db.GetServerStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), stats.Users)
+		assert.Equal(t, int64(1), stats.Admins)
+		assert.Equal(t, int64(0), stats.LockedUsers)
+		assert.Equal(t, int64(1), stats.Organizations)
+		assert.Equal(t, int64(len(testItems)), stats.Items)
+		assert.Equal(t, int64(1), stats.TrashedItems)
+	})
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// newAuditEventsInsertStmt is a helper function for construct statement, which records audit events.
+//
+// Events without creation time are recorded with current time.
+func newAuditEventsInsertStmt(psql sq.StatementBuilderType,
+	events []*pb.AuditEvent) (SQLStatement, []interface{}, error) {
+	if len(events) == 0 {
+		return "", nil, errors.New("no audit events")
+	}
+
+	now := time.Now().Truncate(time.Second)
+
+	stmt := psql.
+		Insert("audit_events").
+		Columns("username, event, method, item_id, client_ip, created")
+
+	for _, event := range events {
+		created := now
+		if event.Created != nil {
+			created = event.Created.AsTime()
+		}
+
+		stmt = stmt.Values(event.Username, event.Event, event.Method, event.ItemId, event.ClientIp, created)
+	}
+
+	return stmt.ToSql()
+}
+
+// newAuditEventsSelect is a helper function for construct statement, which selects user's audit
+// events newest first.
+func newAuditEventsSelect(psql sq.StatementBuilderType, username Username, beforeID int64,
+	limit uint32) (SQLStatement, []interface{}, error) {
+	stmt := psql.
+		Select("id, username, event, method, item_id, client_ip, created").
+		From("audit_events").
+		Where(sq.Eq{"username": username}).
+		OrderBy("id desc").
+		Limit(uint64(limit))
+
+	if beforeID > 0 {
+		stmt = stmt.Where(sq.Lt{"id": beforeID})
+	}
+
+	return stmt.ToSql()
+}
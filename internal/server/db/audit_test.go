@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAudit checks recording and paginated listing of audit events.
+func testAudit(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	itemID := int64(7)
+
+	events := []*pb.AuditEvent{
+		{Username: testUser1.Username, Event: common.AuditEventLogin, Method: "Users/UserLogin", ClientIp: "10.0.0.1"},
+		{Username: testUser2.Username, Event: common.AuditEventLoginFailed, Method: "Users/UserLogin"},
+		{Username: testUser1.Username, Event: common.AuditEventRead, Method: "Items/GetItem", ItemId: &itemID},
+		{Username: testUser1.Username, Event: common.AuditEventDelete, Method: "Items/DeleteItem", ItemId: &itemID},
+	}
+
+	require.NoError(t, db.CreateAuditEvents(ctx, events))
+	require.NoError(t, db.CreateAuditEvents(ctx, nil), "no events is not an error")
+
+	t.Run("Latest events", func(t *testing.T) {
+		got, err := db.GetAuditEvents(ctx, testUser1.Username, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, common.AuditEventDelete, got[0].Event)
+		assert.Equal(t, itemID, got[0].GetItemId())
+		assert.NotNil(t, got[0].Created)
+		assert.Equal(t, common.AuditEventLogin, got[2].Event)
+		assert.Equal(t, "10.0.0.1", got[2].ClientIp)
+		assert.Nil(t, got[2].ItemId)
+		assert.Greater(t, got[0].Id, got[1].Id)
+	})
+
+	t.Run("Paginated events", func(t *testing.T) {
+		first, err := db.GetAuditEvents(ctx, testUser1.Username, 0, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		next, err := db.GetAuditEvents(ctx, testUser1.Username, first[1].Id, 2)
+		require.NoError(t, err)
+		require.Len(t, next, 1)
+		assert.Equal(t, common.AuditEventLogin, next[0].Event)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		got, err := db.GetAuditEvents(ctx, "unknown", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
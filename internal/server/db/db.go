@@ -7,14 +7,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/logger"
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/blobstore"
 )
 
 // Supported databases.
 const (
 	TypePostgres = "postgres"
+	TypeMemory   = "memory"
+	TypeSQLite   = "sqlite"
 )
 
 // Database fields' constraints.
@@ -53,6 +57,14 @@ type DB interface {
 	Executor
 	UsersManager
 	ItemsManager
+	FoldersManager
+	SharesManager
+	OrganizationsManager
+	EmergencyAccessManager
+	AdminManager
+	AuditManager
+	LoginAttemptsManager
+	VaultWatcher
 }
 
 // Executor defines methods for DB's configure, execution and setup operations.
@@ -65,10 +77,15 @@ type Executor interface {
 	ConnectAndSetup(context.Context) error
 	// Start interactions with database and control connections.
 	Run(context.Context, CloseChannel)
+	// Close database's connections without running database (ex. after migrations).
+	// Run closes connections itself on stop.
+	Close() error
 	// Delete all database's tables and records.
 	Clear(context.Context)
 	// Returns maximum available size of secret.
 	GetMaxSecretSize() uint32
+	// Returns per-user storage quota.
+	GetUserQuota() Quota
 }
 
 // UserManager defines methods for CRUD operations with Users.
@@ -81,14 +98,39 @@ type UsersManager interface {
 	GetUserAuthData(context.Context, Username) (Password, OTPKey, error)
 	// Return user's encryption key.
 	GetUserEKey(context.Context, Username) ([]byte, error)
+	// Return user's public key and private key, encrypted with user's encryption key.
+	GetUserKeys(context.Context, Username) ([]byte, []byte, error)
 	// Return user's vault revision.
-	GetUserRevision(context.Context, Username) ([]byte, error)
+	GetUserRevision(context.Context, Username) (int64, error)
 	// Update user's information. Empty fields are ignored.
 	UpdateUser(context.Context, *pb.User) error
-	// Update user's password and encryption key. Empty fields are ignored.
+	// Update user's password hash and encryption key at once and revoke all user's tokens.
 	UpdateUserSecrets(context.Context, *pb.User) error
+	// Return time, before which all user's tokens are revoked. Zero time means no revocation.
+	GetUserTokensRevoked(context.Context, Username) (time.Time, error)
+	// Return user's current storage usage.
+	GetUserUsage(context.Context, Username) (*Usage, error)
+	// Return user's administrator role and lock state.
+	GetUserState(context.Context, Username) (*UserState, error)
 	// Delete user.
 	DeleteUserByName(context.Context, Username) error
+	// Record user's session of issued token and delete user's expired sessions.
+	CreateSession(context.Context, Username, *pb.Session) error
+	// Update last seen time and client's address of user's active session.
+	// Returns ErrNotFound for revoked or expired session.
+	TouchSession(ctx context.Context, username Username, id string, clientIP string) error
+	// Return user's active sessions, last seen first.
+	GetSessions(context.Context, Username) ([]*pb.Session, error)
+	// Return user's active session with provided refresh token's hash.
+	// Returns ErrNotFound if there is no such session.
+	GetSessionByRefreshHash(ctx context.Context, username Username, refreshHash string) (*pb.Session, error)
+	// Replace ID, refresh token's hash and expiration time of user's active session, update last
+	// seen time and client's address. Returns ErrNotFound for revoked or expired session.
+	RotateSession(ctx context.Context, username Username, id string, session *pb.Session) error
+	// Delete user's session. Returns ErrNotFound if session doesn't exist.
+	DeleteSession(ctx context.Context, username Username, id string) error
+	// Delete all user's sessions.
+	DeleteSessions(context.Context, Username) error
 }
 
 // ItemsManager defines methods for CRUD operations with Items.
@@ -97,16 +139,159 @@ type ItemsManager interface {
 	CreateItem(context.Context, Username, *pb.Item) error
 	// Read secured item with provided name and type.
 	GetItemByNameAndType(context.Context, Username, ItemName, ItemType) (*pb.Item, error)
-	// Returns short representation of all user's items
+	// Returns short representation of all user's items, including trashed.
 	GetItemList(context.Context, Username) ([]*pb.ItemShort, error)
 	// Returns items with provided IDs.
 	GetItemsByID(context.Context, Username, []int64) ([]*pb.Item, error)
-	// Returns item's hash.
-	GetItemHashByID(context.Context, int64) ([]byte, error)
+	// Returns all user's items, including trashed.
+	GetAllItems(context.Context, Username) ([]*pb.Item, error)
+	// Returns changes of user's items made since provided vault revision.
+	GetChangesSince(ctx context.Context, username Username, revision int64) (*Changes, error)
+	// Returns hash of user's item.
+	GetItemHashByID(ctx context.Context, username Username, itemID int64) ([]byte, error)
 	// Updates existing item.
 	UpdateItem(context.Context, Username, *pb.Item) error
-	// Delete item.
+	// Move item to trash.
 	DeleteItem(ctx context.Context, username Username, itemID int64) error
+	// Returns short representation of all user's trashed items.
+	GetTrashList(context.Context, Username) ([]*pb.ItemShort, error)
+	// Restore item from trash.
+	RestoreItem(ctx context.Context, username Username, itemID int64) error
+	// Permanently delete trashed item.
+	PurgeItem(ctx context.Context, username Username, itemID int64) error
+	// Returns item's previous versions.
+	GetItemVersions(ctx context.Context, username Username, itemID int64) ([]*pb.ItemVersion, error)
+	// Restores item's state from previous version.
+	RestoreItemVersion(ctx context.Context, username Username, itemID int64, version int64) error
+	// Replaces user's encryption key, private key, all user's items, folders and emergency accesses' keys
+	// with re-encrypted ones at once.
+	RotateEncryptionKey(ctx context.Context, username Username, ekey []byte, privateKey []byte,
+		items []*pb.Item, folders []*pb.Folder, emergencyKeys []*pb.EmergencyKey) error
+	// Replaces data item's content with received chunks, returns total size of stored chunks.
+	SaveSecretData(ctx context.Context, username Username, itemID int64, next ChunkReader) (int64, error)
+	// Sends data item's content chunk by chunk.
+	GetSecretData(ctx context.Context, username Username, itemID int64, send ChunkWriter) error
+}
+
+// FoldersManager defines methods for CRUD operations with Folders.
+type FoldersManager interface {
+	// Create new folder, returns folder's ID.
+	CreateFolder(context.Context, Username, *pb.Folder) (int64, error)
+	// Returns all user's folders.
+	GetFolders(context.Context, Username) ([]*pb.Folder, error)
+	// Rename existing folder.
+	UpdateFolder(context.Context, Username, *pb.Folder) error
+	// Delete folder, folder's items are kept without folder.
+	DeleteFolder(ctx context.Context, username Username, folderID int64) error
+}
+
+// SharesManager defines methods for sharing items between users.
+type SharesManager interface {
+	// Create new or replace existing share of owner's item with recipient, returns share's ID.
+	ShareItem(ctx context.Context, owner Username, share *pb.Share) (int64, error)
+	// Returns items shared with user.
+	GetReceivedShares(context.Context, Username) ([]*pb.Share, error)
+	// Returns shares of user's items without keys and data.
+	GetSentShares(context.Context, Username) ([]*pb.Share, error)
+	// Delete share of owner's item.
+	RevokeShare(ctx context.Context, owner Username, shareID int64) error
+}
+
+// OrganizationsManager defines methods for managing organizations and their members.
+type OrganizationsManager interface {
+	// Create new organization with creator as administrator, returns organization's ID.
+	CreateOrganization(ctx context.Context, creator Username, org *pb.Organization) (int64, error)
+	// Returns user's organizations including not accepted invites, with user's role and organization's key.
+	GetOrganizations(context.Context, Username) ([]*pb.Organization, error)
+	// Rename organization.
+	UpdateOrganization(context.Context, *pb.Organization) error
+	// Delete organization with its members and items.
+	DeleteOrganization(ctx context.Context, orgID int64) error
+	// Returns organization's members without keys.
+	GetMembers(ctx context.Context, orgID int64) ([]*pb.Member, error)
+	// Returns user's membership in organization.
+	GetMembership(ctx context.Context, orgID int64, username Username) (*Membership, error)
+	// Invite user to organization.
+	AddMember(ctx context.Context, orgID int64, member *pb.Member) error
+	// Accept user's invite to organization.
+	AcceptInvite(ctx context.Context, orgID int64, username Username) error
+	// Change member's role.
+	UpdateMemberRole(ctx context.Context, orgID int64, username Username, role string) error
+	// Remove member from organization.
+	RemoveMember(ctx context.Context, orgID int64, username Username) error
+}
+
+// EmergencyAccessManager defines methods for managing emergency access of users' trusted contacts.
+type EmergencyAccessManager interface {
+	// Invite grantee as grantor's trusted contact, returns emergency access's ID.
+	CreateEmergencyAccess(ctx context.Context, grantor Username, access *pb.EmergencyAccess) (int64, error)
+	// Returns emergency accesses granted by user without keys.
+	GetGrantedEmergencyAccesses(context.Context, Username) ([]*pb.EmergencyAccess, error)
+	// Returns emergency accesses granted to user without keys.
+	GetReceivedEmergencyAccesses(context.Context, Username) ([]*pb.EmergencyAccess, error)
+	// Returns emergency access, grantor's key is released only for approved access.
+	GetEmergencyAccess(ctx context.Context, id int64) (*pb.EmergencyAccess, error)
+	// Accept invite by grantee.
+	AcceptEmergencyInvite(ctx context.Context, grantee Username, id int64) error
+	// Request access by grantee, access is approved automatically after provided time.
+	RequestEmergencyAccess(ctx context.Context, grantee Username, id int64, approveAfter time.Time) error
+	// Approve requested access by grantor.
+	ApproveEmergencyAccess(ctx context.Context, grantor Username, id int64) error
+	// Reject requested or approved access by grantor.
+	RejectEmergencyAccess(ctx context.Context, grantor Username, id int64) error
+	// Delete emergency access by grantor or grantee.
+	DeleteEmergencyAccess(ctx context.Context, username Username, id int64) error
+	// Replace grantor's password hash and encryption key by grantee of approved takeover access.
+	TakeoverAccount(ctx context.Context, grantee Username, id int64, user *pb.User) error
+}
+
+// AdminManager defines methods for users' administration.
+type AdminManager interface {
+	// Returns all users without organizations' vaults.
+	ListUsers(context.Context) ([]*pb.UserInfo, error)
+	// Lock or unlock user. Locking revokes all user's tokens.
+	SetUserLocked(ctx context.Context, username Username, locked bool) error
+	// Grant or revoke user's administrator role. Revoking role revokes all user's tokens.
+	SetUserAdmin(ctx context.Context, username Username, isAdmin bool) error
+	// Disable user's two-factor authentication.
+	ResetTwoFactor(context.Context, Username) error
+	// Returns server's statistics.
+	GetServerStats(context.Context) (*pb.ServerStats, error)
+}
+
+// AuditManager defines methods for recording and reading users' audit events.
+type AuditManager interface {
+	// Record audit events.
+	CreateAuditEvents(context.Context, []*pb.AuditEvent) error
+	// Returns at most limit user's audit events newest first, which are older than event with
+	// provided ID. Zero ID means latest events.
+	GetAuditEvents(ctx context.Context, username Username, beforeID int64, limit uint32) ([]*pb.AuditEvent, error)
+}
+
+// LoginAttemptsManager defines methods for tracking failed login attempts.
+//
+// Attempts are counted by key, ex. username or client's address.
+type LoginAttemptsManager interface {
+	// Atomically reserve login attempt with key, which is counted as failed until it's released,
+	// and delete stale unlocked attempts. If logins with key are locked, attempt isn't reserved
+	// and false is returned. Otherwise logins with key are locked for period returned by lockout
+	// for new number of failures. Failures recorded before resetBefore are forgotten.
+	// Returns key's attempts.
+	ReserveLoginAttempt(ctx context.Context, key string, resetBefore time.Time,
+		lockout LoginLockout) (*LoginAttempts, bool, error)
+	// Forget reserved login attempt with key, which isn't failed. If unlock is true, logins
+	// with key are unlocked.
+	ReleaseLoginAttempt(ctx context.Context, key string, unlock bool) error
+	// Return key's failed login attempts. Returns ErrNotFound if there are no recorded attempts.
+	GetLoginAttempts(ctx context.Context, key string) (*LoginAttempts, error)
+	// Delete key's failed login attempts.
+	DeleteLoginAttempts(ctx context.Context, key string) error
+}
+
+// VaultWatcher defines methods for watching changes of users' vaults.
+type VaultWatcher interface {
+	// Returns channel, which receives new revisions of user's vault until context is done.
+	WatchRevision(ctx context.Context, username Username) (<-chan int64, error)
 }
 
 // New is a fabric method for create DB with provided type.
@@ -114,6 +299,10 @@ func New(dbType string, params *Parameters, logger logger.L) (DB, error) {
 	switch dbType {
 	case TypePostgres:
 		return newPosgtre(params, logger)
+	case TypeMemory:
+		return newMemory(params, logger)
+	case TypeSQLite:
+		return newSQLite(params, logger)
 	default:
 		return nil, fmt.Errorf("undefined database type: %s", dbType)
 	}
@@ -121,10 +310,15 @@ func New(dbType string, params *Parameters, logger logger.L) (DB, error) {
 
 // Parameters contains parameters for connection to database.
 type Parameters struct {
-	address       string
-	user          string
-	password      string
-	maxSecretSize uint32
+	address        string
+	user           string
+	password       string
+	maxSecretSize  uint32
+	itemVersions   uint32
+	trashRetention time.Duration
+	quota          Quota
+	blobStore      blobstore.Store
+	blobThreshold  uint32
 }
 
 // NewParameters creates new database connection parameters.
@@ -136,3 +330,40 @@ func NewParameters(address string, user string, password string, maxSecret uint3
 		maxSecretSize: maxSecret,
 	}
 }
+
+// SetItemVersions sets number of items' previous versions, kept in history.
+//
+// Zero value disables items' history.
+func (p *Parameters) SetItemVersions(n uint32) *Parameters {
+	p.itemVersions = n
+
+	return p
+}
+
+// SetTrashRetention sets period, after which trashed items are purged.
+//
+// Zero value disables purge, trashed items are kept until purged by user.
+func (p *Parameters) SetTrashRetention(d time.Duration) *Parameters {
+	p.trashRetention = d
+
+	return p
+}
+
+// SetUserQuota sets per-user storage quota.
+//
+// Zero value of quota's limit disables this limit.
+func (p *Parameters) SetUserQuota(q Quota) *Parameters {
+	p.quota = q
+
+	return p
+}
+
+// SetBlobStore sets store for secrets, which size exceeds threshold in bytes.
+//
+// Nil store keeps all secrets in database. Blob store is not used by in-memory database.
+func (p *Parameters) SetBlobStore(store blobstore.Store, threshold uint32) *Parameters {
+	p.blobStore = store
+	p.blobThreshold = threshold
+
+	return p
+}
@@ -1,13 +1,45 @@
 package db
 
 import (
+	"context"
 	"testing"
 
 	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// fillTestDB is a helper function which creates testing users and items, all testing items
+// belong to testUser1.
+//
+// Functionality, which is common for all DB's implementations, is tested once by conformance
+// suites (test<Feature> functions). Suites receive database filled by fillTestDB and are run
+// by every implementation's Test function.
+func fillTestDB(t *testing.T, db DB) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	for _, user := range []*pb.User{testUser1, testUser2} {
+		require.NoError(t, db.CreateUser(ctx, user))
+	}
+
+	for _, item := range testItems {
+		require.NoError(t, db.CreateItem(ctx, testUser1.Username, item))
+	}
+}
+
+// getTestItem is a helper function which returns stored item of testUser1.
+func getTestItem(t *testing.T, db DB, item *pb.Item) *pb.Item {
+	t.Helper()
+
+	stored, err := db.GetItemByNameAndType(context.Background(), testUser1.Username, item.Name, item.Type)
+	require.NoError(t, err)
+
+	return stored
+}
+
 func TestNew(t *testing.T) {
 	logger := mocklogger.NewMockLogger()
 
@@ -17,6 +49,18 @@ func TestNew(t *testing.T) {
 		assert.NotEmpty(t, db)
 	})
 
+	t.Run("New memory DB", func(t *testing.T) {
+		db, err := New(TypeMemory, &testDBConnParams, logger)
+		require.NoError(t, err)
+		assert.NotEmpty(t, db)
+	})
+
+	t.Run("New SQLite DB", func(t *testing.T) {
+		db, err := New(TypeSQLite, &testDBConnParams, logger)
+		require.NoError(t, err)
+		assert.NotEmpty(t, db)
+	})
+
 	t.Run("wrong DB type", func(t *testing.T) {
 		_, err := New("wrong type", &testDBConnParams, logger)
 		assert.Error(t, err)
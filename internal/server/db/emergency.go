@@ -0,0 +1,195 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// emergencyApproveInterval defines how often requested emergency accesses are checked for
+// automatic approval.
+const emergencyApproveInterval = 10 * time.Minute
+
+// Limits of emergency access's waiting period in days, same as in schema.
+const (
+	emergencyMinWaitDays = 1
+	emergencyMaxWaitDays = 90
+)
+
+// checkNewEmergencyAccess is a helper function which checks emergency access before creation.
+func checkNewEmergencyAccess(access *pb.EmergencyAccess) error {
+	if access == nil || access.Grantee == "" || len(access.Ekey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("grantee and grantor's key are required"))
+	}
+
+	if !common.Contains(access.Mode, common.ListEmergencyModes()) {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("unknown mode '%s'", access.Mode))
+	}
+
+	if access.WaitDays < emergencyMinWaitDays || access.WaitDays > emergencyMaxWaitDays {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("waiting period must be from %d to %d days",
+			emergencyMinWaitDays, emergencyMaxWaitDays))
+	}
+
+	return nil
+}
+
+// checkRotationEmergencyKeys is a helper function which checks that every emergency access's key
+// is passed for rotation only once.
+func checkRotationEmergencyKeys(keys []*pb.EmergencyKey) error {
+	ids := make(map[int64]struct{}, len(keys))
+
+	for _, key := range keys {
+		if len(key.Ekey) == 0 {
+			return stackErrors(ErrConstraintViolation, fmt.Errorf("emergency access %d: key is required", key.Id))
+		}
+
+		if _, ok := ids[key.Id]; ok {
+			return stackErrors(ErrConstraintViolation, fmt.Errorf("duplicate emergency access id %d", key.Id))
+		}
+
+		ids[key.Id] = struct{}{}
+	}
+
+	return nil
+}
+
+// formatEmergencyTime is a helper function for generate emergency access's time field value.
+//
+// Time is stored as RFC3339 string in UTC, same as trashed items' deletion time, so it's
+// compared correctly by all databases.
+func formatEmergencyTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// newEmergencyInsertStmt is a helper function for construct statement, which invites grantee
+// as grantor's trusted contact.
+//
+// Statement doesn't affect any rows if grantor or grantee doesn't exist, grantee is grantor or
+// any of them is organization's vault.
+func newEmergencyInsertStmt(psql sq.StatementBuilderType, grantor Username,
+	access *pb.EmergencyAccess) (SQLStatement, []interface{}, error) {
+	accessSQ := psql.
+		Select("g.id, e.id").
+		Column(sq.Placeholders(5), access.Mode, common.EmergencyStatusInvited, access.WaitDays, access.Ekey,
+			time.Now().Truncate(time.Second)).
+		From("users g").
+		Join("users e on e.username = ?", access.Grantee).
+		Where(sq.Eq{"g.username": grantor}).
+		Where("e.id <> g.id").
+		Where("g.id not in (select vault_id from organizations)").
+		Where("e.id not in (select vault_id from organizations)")
+
+	return psql.
+		Insert("emergency_access").
+		Columns("grantor_id, grantee_id, mode, status, wait_days, ekey, created").
+		Select(accessSQ).
+		Suffix("returning id").ToSql()
+}
+
+// newEmergencyAccessesSelect is a helper function for construct query, which selects emergency
+// accesses with grantors' and grantees' usernames without keys.
+func newEmergencyAccessesSelect(psql sq.StatementBuilderType) sq.SelectBuilder {
+	return psql.
+		Select("a.id, g.username as grantor, e.username as grantee, a.mode, a.status, a.wait_days, " +
+			"a.requested, a.approve_after, a.created").
+		From("emergency_access a").
+		Join("users g on a.grantor_id = g.id").
+		Join("users e on a.grantee_id = e.id").
+		OrderBy("a.id")
+}
+
+// newGrantedEmergencySelect is a helper function for construct statement, which selects
+// emergency accesses granted by user.
+func newGrantedEmergencySelect(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return newEmergencyAccessesSelect(psql).
+		Where(sq.Eq{"g.username": username}).
+		ToSql()
+}
+
+// newReceivedEmergencySelect is a helper function for construct statement, which selects
+// emergency accesses granted to user.
+func newReceivedEmergencySelect(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return newEmergencyAccessesSelect(psql).
+		Where(sq.Eq{"e.username": username}).
+		ToSql()
+}
+
+// newEmergencyAccessSelect is a helper function for construct statement, which selects
+// emergency access with grantor's key. Key is selected only for approved access.
+func newEmergencyAccessSelect(psql sq.StatementBuilderType, id int64) (SQLStatement, []interface{}, error) {
+	return newEmergencyAccessesSelect(psql).
+		Column("case when a.status = ? then a.ekey end as ekey", common.EmergencyStatusApproved).
+		Where(sq.Eq{"a.id": id}).
+		ToSql()
+}
+
+// newEmergencyUpdateStmt is a helper function for construct statement, which updates emergency
+// access with provided values.
+//
+// Statement affects access only if it's in one of provided statuses and user is its grantor
+// or grantee, which is defined by userColumn.
+func newEmergencyUpdateStmt(psql sq.StatementBuilderType, id int64, userColumn string, username Username,
+	statuses []string, values map[string]interface{}) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("emergency_access").
+		SetMap(values).
+		Where(sq.Eq{"id": id, "status": statuses}).
+		Where(userColumn+" = (select id from users where username = ?)", username).ToSql()
+}
+
+// newEmergencyDeleteStmt is a helper function for construct statement, which deletes emergency
+// access by its grantor or grantee.
+func newEmergencyDeleteStmt(psql sq.StatementBuilderType, username Username,
+	id int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("emergency_access").
+		Where(sq.Eq{"id": id}).
+		Where("(select id from users where username = ?) in (grantor_id, grantee_id)", username).ToSql()
+}
+
+// newEmergencyApproveStmt is a helper function for construct statement, which approves all
+// requested emergency accesses with waiting period elapsed before provided time.
+func newEmergencyApproveStmt(psql sq.StatementBuilderType, now time.Time) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("emergency_access").
+		Set("status", common.EmergencyStatusApproved).
+		Where(sq.Eq{"status": common.EmergencyStatusRequested}).
+		Where("approve_after <= ?", formatEmergencyTime(now)).ToSql()
+}
+
+// newTakeoverUserUpdateStmt is a helper function for construct statement, which replaces grantor's
+// password hash and encryption key, disables grantor's two-factor authentication and revokes
+// grantor's tokens.
+//
+// Statement doesn't affect any rows if access isn't approved takeover access of grantee.
+func newTakeoverUserUpdateStmt(psql sq.StatementBuilderType, grantee Username, id int64,
+	user *pb.User) (SQLStatement, []interface{}, error) {
+	now := time.Now()
+
+	return psql.
+		Update("users").
+		Set("pwdhash", user.GetPwdhash()).
+		Set("ekey", user.Ekey).
+		Set("otpkey", nil).
+		Set("updated", now.Truncate(time.Second)).
+		Set("tokens_revoked", now).
+		Where("id = (select a.grantor_id from emergency_access a join users e on a.grantee_id = e.id "+
+			"where a.id = ? and e.username = ? and a.status = ? and a.mode = ?)",
+			id, grantee, common.EmergencyStatusApproved, common.EmergencyModeTakeover).ToSql()
+}
+
+// newEmergencyKeyUpdateStmt is a helper function for construct statement, which replaces key of
+// grantor's emergency access during encryption key rotation.
+func newEmergencyKeyUpdateStmt(psql sq.StatementBuilderType, grantor Username,
+	key *pb.EmergencyKey) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("emergency_access").
+		Set("ekey", key.Ekey).
+		Where(sq.Eq{"id": key.Id}).
+		Where("grantor_id = (select id from users where username = ?)", grantor).ToSql()
+}
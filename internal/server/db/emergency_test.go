@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEmergencyAccess checks emergency access's lifecycle. Approve must run implementation's
+// automatic approval of accesses with waiting period elapsed before now.
+func testEmergencyAccess(t *testing.T, db DB, approve func(now time.Time)) {
+	ctx := context.Background()
+
+	access := &pb.EmergencyAccess{
+		Grantee:  testUser2.Username,
+		Mode:     common.EmergencyModeTakeover,
+		WaitDays: 3,
+		Ekey:     []byte("wrapped"),
+	}
+
+	var id int64
+
+	t.Run("Invite contact", func(t *testing.T) {
+		_, err := db.CreateEmergencyAccess(ctx, testUser1.Username, &pb.EmergencyAccess{Grantee: testUser2.Username,
+			Mode: "owner", WaitDays: 3, Ekey: []byte("k")})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		_, err = db.CreateEmergencyAccess(ctx, testUser1.Username, &pb.EmergencyAccess{Grantee: testUser2.Username,
+			Mode: common.EmergencyModeView, WaitDays: 0, Ekey: []byte("k")})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		_, err = db.CreateEmergencyAccess(ctx, testUser1.Username, &pb.EmergencyAccess{Grantee: "unknown",
+			Mode: common.EmergencyModeView, WaitDays: 1, Ekey: []byte("k")})
+		assert.ErrorIs(t, err, ErrOperationFailed, "unknown user")
+
+		_, err = db.CreateEmergencyAccess(ctx, testUser1.Username, &pb.EmergencyAccess{Grantee: testUser1.Username,
+			Mode: common.EmergencyModeView, WaitDays: 1, Ekey: []byte("k")})
+		assert.ErrorIs(t, err, ErrOperationFailed, "self")
+
+		id, err = db.CreateEmergencyAccess(ctx, testUser1.Username, access)
+		require.NoError(t, err)
+		assert.NotZero(t, id)
+
+		_, err = db.CreateEmergencyAccess(ctx, testUser1.Username, access)
+		assert.ErrorIs(t, err, ErrDuplicateEntry)
+
+		granted, err := db.GetGrantedEmergencyAccesses(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, granted, 1)
+		assert.Equal(t, id, granted[0].Id)
+		assert.Equal(t, testUser2.Username, granted[0].Grantee)
+		assert.Equal(t, common.EmergencyStatusInvited, granted[0].Status)
+		assert.Equal(t, int32(3), granted[0].WaitDays)
+		assert.Empty(t, granted[0].Ekey)
+
+		received, err := db.GetReceivedEmergencyAccesses(ctx, testUser2.Username)
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		assert.Equal(t, testUser1.Username, received[0].Grantor)
+
+		got, err := db.GetEmergencyAccess(ctx, id)
+		require.NoError(t, err)
+		assert.Empty(t, got.Ekey, "key isn't released before approval")
+
+		_, err = db.GetEmergencyAccess(ctx, 100)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Accept and request access", func(t *testing.T) {
+		assert.ErrorIs(t, db.RequestEmergencyAccess(ctx, testUser2.Username, id, time.Now()), ErrOperationFailed,
+			"not accepted")
+		assert.ErrorIs(t, db.AcceptEmergencyInvite(ctx, testUser1.Username, id), ErrOperationFailed, "by grantor")
+		require.NoError(t, db.AcceptEmergencyInvite(ctx, testUser2.Username, id))
+
+		approveAfter := time.Now().Add(time.Hour)
+		require.NoError(t, db.RequestEmergencyAccess(ctx, testUser2.Username, id, approveAfter))
+
+		got, err := db.GetEmergencyAccess(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, common.EmergencyStatusRequested, got.Status)
+		assert.Empty(t, got.Ekey, "key isn't released before approval")
+		assert.NotNil(t, got.Requested)
+		assert.Equal(t, approveAfter.Unix(), got.ApproveAfter.AsTime().Unix())
+	})
+
+	t.Run("Reject and approve access", func(t *testing.T) {
+		assert.ErrorIs(t, db.ApproveEmergencyAccess(ctx, testUser2.Username, id), ErrOperationFailed, "by grantee")
+		require.NoError(t, db.RejectEmergencyAccess(ctx, testUser1.Username, id))
+
+		got, err := db.GetEmergencyAccess(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, common.EmergencyStatusAccepted, got.Status)
+		assert.Nil(t, got.ApproveAfter)
+
+		require.NoError(t, db.RequestEmergencyAccess(ctx, testUser2.Username, id, time.Now().Add(time.Hour)))
+		require.NoError(t, db.ApproveEmergencyAccess(ctx, testUser1.Username, id))
+
+		got, err = db.GetEmergencyAccess(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, common.EmergencyStatusApproved, got.Status)
+		assert.Equal(t, []byte("wrapped"), got.Ekey)
+	})
+
+	t.Run("Approve after waiting period", func(t *testing.T) {
+		require.NoError(t, db.RejectEmergencyAccess(ctx, testUser1.Username, id))
+		require.NoError(t, db.RequestEmergencyAccess(ctx, testUser2.Username, id, time.Now().Add(time.Hour)))
+
+		approve(time.Now())
+
+		got, err := db.GetEmergencyAccess(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, common.EmergencyStatusRequested, got.Status)
+
+		approve(time.Now().Add(2 * time.Hour))
+
+		got, err = db.GetEmergencyAccess(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, common.EmergencyStatusApproved, got.Status)
+	})
+
+	t.Run("Rotate encryption key", func(t *testing.T) {
+		items, err := db.GetAllItems(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		err = db.RotateEncryptionKey(ctx, testUser1.Username, []byte("newkey"), nil, items, nil, nil)
+		assert.ErrorIs(t, err, ErrOperationFailed, "missed emergency key")
+
+		keys := []*pb.EmergencyKey{{Id: id, Ekey: []byte("rewrapped")}}
+		require.NoError(t, db.RotateEncryptionKey(ctx, testUser1.Username, []byte("newkey"), nil, items, nil, keys))
+
+		got, err := db.GetEmergencyAccess(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("rewrapped"), got.Ekey)
+	})
+
+	t.Run("Takeover account", func(t *testing.T) {
+		newUser := &pb.User{Pwdhash: common.PtrTo("newpwdhash"), Ekey: []byte("newekey")}
+
+		assert.ErrorIs(t, db.TakeoverAccount(ctx, testUser2.Username, id, &pb.User{}), ErrConstraintViolation)
+		assert.ErrorIs(t, db.TakeoverAccount(ctx, testUser1.Username, id, newUser), ErrOperationFailed, "by grantor")
+		require.NoError(t, db.TakeoverAccount(ctx, testUser2.Username, id, newUser))
+
+		user, err := db.GetUserByName(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, "newpwdhash", user.GetPwdhash())
+		assert.Equal(t, []byte("newekey"), user.Ekey)
+		assert.Nil(t, user.OtpKey)
+
+		_, err = db.GetEmergencyAccess(ctx, id)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Delete access", func(t *testing.T) {
+		access.Mode = common.EmergencyModeView
+
+		id, err := db.CreateEmergencyAccess(ctx, testUser1.Username, access)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, db.DeleteEmergencyAccess(ctx, "unknown", id), ErrOperationFailed)
+		require.NoError(t, db.DeleteEmergencyAccess(ctx, testUser2.Username, id))
+		assert.ErrorIs(t, db.DeleteEmergencyAccess(ctx, testUser1.Username, id), ErrOperationFailed)
+
+		granted, err := db.GetGrantedEmergencyAccesses(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, granted)
+	})
+}
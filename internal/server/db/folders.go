@@ -0,0 +1,146 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// checkFolder is a helper function which checks folder before creation or update.
+func checkFolder(folder *pb.Folder) error {
+	if folder == nil || len(folder.Name) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("folder name is required"))
+	}
+
+	return nil
+}
+
+// folderOwnedBy returns condition, which is true if folder with provided ID belongs to user.
+func folderOwnedBy(folderID int64, username Username) sq.Sqlizer {
+	return sq.Expr("exists (select 1 from folders join users on folders.user_id = users.id "+
+		"where folders.id = ? and users.username = ?)", folderID, username)
+}
+
+// itemFolderID returns value of item's folder_id column.
+//
+// Zero or not set folder ID means item without folder.
+func itemFolderID(item *pb.Item) *int64 {
+	if item.GetFolderId() == 0 {
+		return nil
+	}
+
+	return item.FolderId
+}
+
+// newFolderInsertStmt is a helper function for construct statement, which creates user's folder.
+func newFolderInsertStmt(psql sq.StatementBuilderType, username Username,
+	folder *pb.Folder) (SQLStatement, []interface{}, error) {
+	folderSQ := psql.
+		Select("id").
+		Column(sq.Placeholders(2), folder.Name, time.Now().Truncate(time.Second)).
+		From("users").Where(sq.Eq{"username": username})
+
+	return psql.
+		Insert("folders").
+		Columns("user_id, name, updated").
+		Select(folderSQ).
+		Suffix("returning id").ToSql()
+}
+
+// newFoldersSelect is a helper function for construct statement, which selects all user's folders.
+func newFoldersSelect(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("folders.id, folders.name, folders.updated").
+		From("folders").
+		Join("users on folders.user_id = users.id").
+		Where(sq.Eq{"users.username": username}).
+		OrderBy("folders.id").
+		ToSql()
+}
+
+// newFolderUpdateStmt is a helper function for construct statement, which renames user's folder.
+func newFolderUpdateStmt(psql sq.StatementBuilderType, username Username,
+	folder *pb.Folder) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("folders").
+		Set("name", folder.Name).
+		Set("updated", time.Now().Truncate(time.Second)).
+		Where(sq.Eq{"id": folder.Id}).
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+}
+
+// newFolderDeleteStmt is a helper function for construct statement, which deletes user's folder.
+//
+// Folder's items are kept without folder by foreign key's action.
+func newFolderDeleteStmt(psql sq.StatementBuilderType, username Username,
+	folderID int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("folders").
+		Where(sq.Eq{"id": folderID}).
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+}
+
+// queueDeleteFolder is a helper function, which queues to batch statements for delete user's folder.
+//
+// Folder's items are recorded as updated in change log before folder is deleted. Folder can be empty,
+// so only last statement, which deletes folder, must affect exactly one row.
+func queueDeleteFolder(b batchQueuer, psql sq.StatementBuilderType, username Username, folderID int64) error {
+	stmtRevision, argsRevision, err := newRevisionStmt(psql, username)
+	if err != nil {
+		return err
+	}
+
+	b.Queue(stmtRevision, argsRevision...)
+
+	stmtChanges, argsChanges, err := newItemChangeStmt(psql, username, sq.Eq{"items.folder_id": folderID}, itemUpdated)
+	if err != nil {
+		return err
+	}
+
+	b.Queue(stmtChanges, argsChanges...)
+
+	stmtFolder, argsFolder, err := newFolderDeleteStmt(psql, username, folderID)
+	if err != nil {
+		return err
+	}
+
+	b.Queue(stmtFolder, argsFolder...)
+
+	return nil
+}
+
+// newItemFolderColumn is a helper function, which adds item's folder to select statement used
+// in item's creation.
+//
+// If folder is set, item is created only if folder belongs to user.
+func newItemFolderColumn(stmt sq.SelectBuilder, username Username, item *pb.Item) sq.SelectBuilder {
+	folderID := itemFolderID(item)
+
+	stmt = stmt.Column(sq.Expr("cast(? as integer)", folderID))
+	if folderID != nil {
+		stmt = stmt.Where(folderOwnedBy(*folderID, username))
+	}
+
+	return stmt
+}
+
+// setItemFolder is a helper function, which sets item's folder in items' update statement.
+//
+// Not set folder keeps current one, zero folder ID removes item from folder. If folder is set,
+// item is updated only if folder belongs to user.
+func setItemFolder(stmt sq.UpdateBuilder, username Username, item *pb.Item) sq.UpdateBuilder {
+	if item.FolderId == nil {
+		return stmt
+	}
+
+	folderID := itemFolderID(item)
+
+	stmt = stmt.Set("folder_id", folderID)
+	if folderID != nil {
+		stmt = stmt.Where(folderOwnedBy(*folderID, username))
+	}
+
+	return stmt
+}
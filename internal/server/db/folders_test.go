@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// testFolders checks folders and assignment of items to folders.
+func testFolders(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	login := getTestItem(t, db, testItemLogin)
+
+	revision, err := db.GetUserRevision(ctx, testUser1.Username)
+	require.NoError(t, err)
+
+	var folderID int64
+
+	t.Run("Create folder", func(t *testing.T) {
+		_, err := db.CreateFolder(ctx, testUser1.Username, &pb.Folder{})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		_, err = db.CreateFolder(ctx, "unknown", &pb.Folder{Name: []byte("folder")})
+		assert.ErrorIs(t, err, ErrOperationFailed)
+
+		folderID, err = db.CreateFolder(ctx, testUser1.Username, &pb.Folder{Name: []byte("folder")})
+		require.NoError(t, err)
+		assert.NotZero(t, folderID)
+
+		folders, err := db.GetFolders(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, folders, 1)
+		assert.Equal(t, folderID, folders[0].Id)
+		assert.Equal(t, []byte("folder"), folders[0].Name)
+
+		folders, err = db.GetFolders(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Empty(t, folders)
+	})
+
+	t.Run("Rename folder", func(t *testing.T) {
+		err := db.UpdateFolder(ctx, testUser2.Username, &pb.Folder{Id: folderID, Name: []byte("other")})
+		assert.ErrorIs(t, err, ErrOperationFailed, "folder of another user")
+
+		err = db.UpdateFolder(ctx, testUser1.Username, &pb.Folder{Id: folderID, Name: []byte("renamed")})
+		require.NoError(t, err)
+
+		folders, err := db.GetFolders(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, folders, 1)
+		assert.Equal(t, []byte("renamed"), folders[0].Name)
+	})
+
+	t.Run("Move item to folder", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{Id: login.Id, Name: login.Name, FolderId: proto.Int64(folderID + 1)})
+		assert.ErrorIs(t, err, ErrOperationFailed, "not existing folder")
+
+		err = db.UpdateItem(ctx, testUser1.Username, &pb.Item{Id: login.Id, Name: login.Name, FolderId: proto.Int64(folderID)})
+		require.NoError(t, err)
+		assert.Equal(t, folderID, getTestItem(t, db, login).GetFolderId())
+
+		err = db.UpdateItem(ctx, testUser1.Username, &pb.Item{Id: login.Id, Name: login.Name})
+		require.NoError(t, err)
+		assert.Equal(t, folderID, getTestItem(t, db, login).GetFolderId(), "not set folder is kept")
+
+		items, err := db.GetItemList(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		for _, item := range items {
+			if item.Id == login.Id {
+				assert.Equal(t, folderID, item.GetFolderId())
+			}
+		}
+	})
+
+	t.Run("Create item in folder", func(t *testing.T) {
+		item := &pb.Item{Name: "folder item", Type: testItemLogin.Type, FolderId: proto.Int64(folderID)}
+
+		assert.ErrorIs(t, db.CreateItem(ctx, testUser2.Username, item), ErrOperationFailed, "folder of another user")
+
+		require.NoError(t, db.CreateItem(ctx, testUser1.Username, item))
+		assert.Equal(t, folderID, getTestItem(t, db, item).GetFolderId())
+	})
+
+	t.Run("Delete folder", func(t *testing.T) {
+		assert.ErrorIs(t, db.DeleteFolder(ctx, testUser2.Username, folderID), ErrOperationFailed)
+
+		current, err := db.GetUserRevision(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		require.NoError(t, db.DeleteFolder(ctx, testUser1.Username, folderID))
+		assert.Nil(t, getTestItem(t, db, login).FolderId)
+
+		changes, err := db.GetChangesSince(ctx, testUser1.Username, current)
+		require.NoError(t, err)
+		assert.Equal(t, current+1, changes.Revision)
+		assert.Contains(t, changes.Updated, login.Id)
+
+		folders, err := db.GetFolders(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, folders)
+
+		assert.ErrorIs(t, db.DeleteFolder(ctx, testUser1.Username, folderID), ErrOperationFailed)
+	})
+
+	t.Run("Folders' changes increase revision", func(t *testing.T) {
+		current, err := db.GetUserRevision(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Greater(t, current, revision)
+
+		id, err := db.CreateFolder(ctx, testUser1.Username, &pb.Folder{Name: []byte("empty")})
+		require.NoError(t, err)
+		require.NoError(t, db.DeleteFolder(ctx, testUser1.Username, id))
+
+		newRevision, err := db.GetUserRevision(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, current+2, newRevision)
+	})
+
+	t.Run("Rotation of encryption key requires all folders", func(t *testing.T) {
+		id, err := db.CreateFolder(ctx, testUser1.Username, &pb.Folder{Name: []byte("folder")})
+		require.NoError(t, err)
+
+		items, err := db.GetAllItems(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		err = db.RotateEncryptionKey(ctx, testUser1.Username, []byte("newkey"), nil, items, nil, nil)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+
+		rotated := []*pb.Folder{{Id: id, Name: []byte("rotated")}}
+		require.NoError(t, db.RotateEncryptionKey(ctx, testUser1.Username, []byte("newkey"), nil, items, rotated, nil))
+
+		folders, err := db.GetFolders(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, folders, 1)
+		assert.Equal(t, []byte("rotated"), folders[0].Name)
+	})
+}
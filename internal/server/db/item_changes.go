@@ -0,0 +1,161 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// itemChange defines type of item's change recorded in change log.
+type itemChange uint8
+
+// Types of item's changes.
+const (
+	itemCreated itemChange = iota
+	itemUpdated
+	itemDeleted
+)
+
+// Changes represents changes of user's items since particular revision.
+//
+// Every item is listed only once. Item created and then deleted since revision is not listed.
+type Changes struct {
+	// Current user's vault revision
+	Revision int64
+	// IDs of items created since revision
+	Created []int64
+	// IDs of items updated since revision, including trashed and restored items
+	Updated []int64
+	// IDs of items permanently deleted since revision
+	Deleted []int64
+}
+
+// newChanges is a helper function, which classifies items' last changes made since revision.
+func newChanges(revision int64, since int64, log []*ItemChange) *Changes {
+	changes := &Changes{Revision: revision}
+
+	for _, c := range log {
+		created := c.CreatedRevision > since
+
+		switch {
+		case c.Deleted && created:
+			continue
+		case c.Deleted:
+			changes.Deleted = append(changes.Deleted, c.ItemID)
+		case created:
+			changes.Created = append(changes.Created, c.ItemID)
+		default:
+			changes.Updated = append(changes.Updated, c.ItemID)
+		}
+	}
+
+	return changes
+}
+
+// queueItemChange is a helper function, which queues to batch statements for increase user's
+// vault revision and record item's change in change log.
+//
+// Filter must match exactly one user's item, so change of item must be queued after item's
+// creation and before item's purge.
+func queueItemChange(b batchQueuer, psql sq.StatementBuilderType, username Username,
+	itemFilter sq.Sqlizer, change itemChange) error {
+	stmtRevision, argsRevision, err := newRevisionStmt(psql, username)
+	if err != nil {
+		return err
+	}
+
+	b.Queue(stmtRevision, argsRevision...)
+
+	stmtChange, argsChange, err := newItemChangeStmt(psql, username, itemFilter, change)
+	if err != nil {
+		return err
+	}
+
+	b.Queue(stmtChange, argsChange...)
+
+	return nil
+}
+
+// newRevisionStmt is a helper function for construct statement, which increases user's vault revision.
+func newRevisionStmt(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("users").
+		Set("revision", sq.Expr("revision + 1")).
+		Where(sq.Eq{"username": username}).ToSql()
+}
+
+// newItemChangeStmt is a helper function for construct statement, which records change of all
+// user's items matched by filter in change log with current user's vault revision.
+func newItemChangeStmt(psql sq.StatementBuilderType, username Username,
+	itemFilter sq.Sqlizer, change itemChange) (SQLStatement, []interface{}, error) {
+	createdRevision, deleted := "0", "false"
+	onConflict := "on conflict (user_id, item_id) do update set revision = excluded.revision, deleted = excluded.deleted"
+
+	if change == itemCreated {
+		createdRevision = "users.revision"
+		onConflict += ", created_revision = excluded.created_revision"
+	}
+
+	if change == itemDeleted {
+		deleted = "true"
+	}
+
+	changeSQ := psql.
+		Select("users.id, items.id").
+		Column(createdRevision).
+		Column("users.revision").
+		Column(deleted).
+		From("items").
+		Join("users on items.user_id = users.id").
+		Where(sq.Eq{"users.username": username}).
+		Where(itemFilter)
+
+	return psql.
+		Insert("item_changes").
+		Columns("user_id, item_id, created_revision, revision, deleted").
+		Select(changeSQ).
+		Suffix(onConflict).ToSql()
+}
+
+// newPurgeTrashRevisionStmt is a helper function for construct statement, which increases
+// vault revision of users, who have items trashed before provided time.
+func newPurgeTrashRevisionStmt(psql sq.StatementBuilderType, before time.Time) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("users").
+		Set("revision", sq.Expr("revision + 1")).
+		Where("id in (select user_id from items where deleted_at is not null and deleted_at < ?)",
+			before.UTC().Format(time.RFC3339)).
+		ToSql()
+}
+
+// newPurgeTrashChangesStmt is a helper function for construct statement, which records
+// deletion of all users' items trashed before provided time in change log.
+func newPurgeTrashChangesStmt(psql sq.StatementBuilderType, before time.Time) (SQLStatement, []interface{}, error) {
+	changeSQ := psql.
+		Select("users.id, items.id, 0, users.revision, true").
+		From("items").
+		Join("users on items.user_id = users.id").
+		Where("items.deleted_at is not null").
+		Where("items.deleted_at < ?", before.UTC().Format(time.RFC3339))
+
+	return psql.
+		Insert("item_changes").
+		Columns("user_id, item_id, created_revision, revision, deleted").
+		Select(changeSQ).
+		Suffix("on conflict (user_id, item_id) do update set revision = excluded.revision, deleted = excluded.deleted").
+		ToSql()
+}
+
+// newItemChangesSelect is a helper function for construct statement, which selects last changes
+// of user's items made since provided revision.
+func newItemChangesSelect(psql sq.StatementBuilderType, username Username,
+	since int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("c.item_id, c.created_revision, c.revision, c.deleted").
+		From("item_changes c").
+		Join("users on c.user_id = users.id").
+		Where(sq.Eq{"users.username": username}).
+		Where("c.revision > ?", since).
+		OrderBy("c.item_id").
+		ToSql()
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// itemVersionsColumns is a list of item_versions' columns, which keep item's state.
+const itemVersionsColumns = "item_id, version, name, reprompt, updated, hash, notes, secret, uris, custom_fields, tags, blob_key, blob_size"
+
+// newItemVersionSnapshotStmt is a helper function for construct statement, which stores
+// current state of user's item as new item's version.
+//
+// Statement doesn't affect any rows if user hasn't item with provided ID.
+func newItemVersionSnapshotStmt(psql sq.StatementBuilderType, username Username,
+	itemID int64) (SQLStatement, []interface{}, error) {
+
+	snapshotSQ := psql.
+		Select("items.id").
+		Column("coalesce((select max(v.version) from item_versions v where v.item_id = items.id), 0) + 1").
+		Column("items.name, items.reprompt, items.updated, items.hash").
+		Column("s.notes, s.secret, a.uris, a.custom_fields, a.tags, s.blob_key, coalesce(s.blob_size, 0)").
+		From("items").
+		Join("users on items.user_id=users.id").
+		LeftJoin("secrets s on items.id=s.item_id").
+		LeftJoin("additions a on items.id=a.item_id").
+		Where(sq.Eq{"items.id": itemID}).
+		Where(sq.Eq{"users.username": username})
+
+	return psql.
+		Insert("item_versions").
+		Columns(itemVersionsColumns).
+		Select(snapshotSQ).ToSql()
+}
+
+// newItemVersionsTrimStmt is a helper function for construct statement, which deletes
+// item's oldest versions, exceeding retention count.
+func newItemVersionsTrimStmt(psql sq.StatementBuilderType, itemID int64,
+	keep uint32) (SQLStatement, []interface{}, error) {
+
+	return psql.
+		Delete("item_versions").
+		Where(sq.Eq{"item_id": itemID}).
+		Where("version <= (select max(version) from item_versions where item_id = ?) - ?", itemID, keep).
+		ToSql()
+}
+
+// newItemVersionsSelect is a helper function for construct query, which returns user's item
+// versions sorted from newest to oldest.
+func newItemVersionsSelect(psql sq.StatementBuilderType, username Username, itemID int64) sq.SelectBuilder {
+	return psql.
+		Select("v.version, v.item_id, v.name, items.type, v.reprompt, v.updated, v.hash").
+		Column("v.notes, v.secret, v.uris, v.custom_fields, v.tags, v.blob_key, v.blob_size").
+		From("item_versions v").
+		Join("items on v.item_id=items.id").
+		Join("users on items.user_id=users.id").
+		Where(sq.Eq{"v.item_id": itemID}).
+		Where(sq.Eq{"users.username": username}).
+		OrderBy("v.version desc")
+}
@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// batchQueuer defines batch of SQL statements, implemented by pgx.Batch and sqliteBatch.
+type batchQueuer interface {
+	Queue(stmt SQLStatement, args ...interface{})
+}
+
+// checkRotationItems is a helper function which checks encryption key and that every item, folder
+// and emergency access's key is passed for rotation only once.
+func checkRotationItems(ekey []byte, items []*pb.Item, folders []*pb.Folder, emergencyKeys []*pb.EmergencyKey) error {
+	if len(ekey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("encryption key is required"))
+	}
+
+	ids := make(map[int64]struct{}, len(items))
+
+	for _, item := range items {
+		if _, ok := ids[item.Id]; ok {
+			return stackErrors(ErrConstraintViolation, fmt.Errorf("duplicate item id %d", item.Id))
+		}
+
+		ids[item.Id] = struct{}{}
+	}
+
+	folderIDs := make(map[int64]struct{}, len(folders))
+
+	for _, folder := range folders {
+		if _, ok := folderIDs[folder.Id]; ok {
+			return stackErrors(ErrConstraintViolation, fmt.Errorf("duplicate folder id %d", folder.Id))
+		}
+
+		if err := checkFolder(folder); err != nil {
+			return err
+		}
+
+		folderIDs[folder.Id] = struct{}{}
+	}
+
+	return checkRotationEmergencyKeys(emergencyKeys)
+}
+
+// queueRotateEncryptionKey is a helper function, which queues to batch statements for replace user's
+// encryption key, all user's items (including trashed), folders and emergency accesses' keys with
+// re-encrypted ones.
+//
+// Every queued statement affects exactly one row, item is matched by ID, name and type, so hash is
+// consistent with stored item. Encryption key is updated only if numbers of user's items, folders
+// and emergency accesses equal numbers of passed ones and private key is passed only if user has
+// key pair, so batch fails if any user's item, folder, emergency access's key or private key was missed.
+// Items' secrets are replaced with prepared secrets, which are matched by items' ID.
+func queueRotateEncryptionKey(b batchQueuer, psql sq.StatementBuilderType, username Username,
+	ekey []byte, privateKey []byte, items []*pb.Item, folders []*pb.Folder, emergencyKeys []*pb.EmergencyKey,
+	secrets map[int64]storedSecret) error {
+
+	for _, item := range items {
+		updated, hash := getHashUpdatedItem(item.Name, item.Type)
+
+		stmtItem, argsItem, err := psql.
+			Update("items").
+			Set("updated", updated).
+			Set("hash", hash).
+			Where(sq.Eq{"id": item.Id, "name": item.Name, "type": item.Type}).
+			Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+		if err != nil {
+			return err
+		}
+
+		b.Queue(stmtItem, argsItem...)
+
+		if item.Secrets == nil {
+			item.Secrets = new(pb.Secrets)
+		}
+
+		secret := secrets[item.Id]
+
+		stmtSecret, argsSecret, err := setStoredSecret(psql.
+			Update("secrets").
+			Set("notes", item.Secrets.Notes), &secret).
+			Where(sq.Eq{"item_id": item.Id}).ToSql()
+		if err != nil {
+			return err
+		}
+
+		b.Queue(stmtSecret, argsSecret...)
+
+		if item.Additions == nil {
+			item.Additions = new(pb.Additions)
+		}
+
+		// Only login item can contain URIs' fields
+		if item.Type != common.ItemTypeLogin {
+			item.Additions.Uris = nil
+		}
+
+		stmtAdds, argsAdds, err := psql.
+			Update("additions").
+			Set("uris", item.Additions.Uris).
+			Set("custom_fields", item.Additions.CustomFields).
+			Set("tags", item.Additions.Tags).
+			Where(sq.Eq{"item_id": item.Id}).ToSql()
+		if err != nil {
+			return err
+		}
+
+		b.Queue(stmtAdds, argsAdds...)
+
+		if err := queueItemChange(b, psql, username, sq.Eq{"items.id": item.Id}, itemUpdated); err != nil {
+			return err
+		}
+	}
+
+	for _, folder := range folders {
+		stmtFolder, argsFolder, err := newFolderUpdateStmt(psql, username, folder)
+		if err != nil {
+			return err
+		}
+
+		b.Queue(stmtFolder, argsFolder...)
+	}
+
+	for _, key := range emergencyKeys {
+		stmtKey, argsKey, err := newEmergencyKeyUpdateStmt(psql, username, key)
+		if err != nil {
+			return err
+		}
+
+		b.Queue(stmtKey, argsKey...)
+	}
+
+	if len(privateKey) == 0 {
+		privateKey = nil
+	}
+
+	stmtUser, argsUser, err := psql.
+		Update("users").
+		Set("ekey", ekey).
+		Set("private_key", privateKey).
+		Set("updated", time.Now().Truncate(time.Second)).
+		Where(sq.Eq{"username": username}).
+		Where("(private_key is null) = ?", len(privateKey) == 0).
+		Where("(select count(*) from items where items.user_id = users.id) = ?", len(items)).
+		Where("(select count(*) from folders where folders.user_id = users.id) = ?", len(folders)).
+		Where("(select count(*) from emergency_access where emergency_access.grantor_id = users.id) = ?",
+			len(emergencyKeys)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	b.Queue(stmtUser, argsUser...)
+
+	return nil
+}
+
+// newUserItemVersionsDeleteStmt is a helper function for construct statement, which deletes
+// history of all user's items.
+func newUserItemVersionsDeleteStmt(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("item_versions").
+		Where(sq.Expr("item_id in (select items.id from items join users on items.user_id = users.id "+
+			"where users.username = ?)", username)).
+		ToSql()
+}
+
+// prepareRotationSecrets is a helper function which prepares secrets of re-encrypted items
+// for storing.
+func prepareRotationSecrets(ctx context.Context, blobs *secretBlobs, items []*pb.Item) (map[int64]storedSecret, error) {
+	secrets := make(map[int64]storedSecret, len(items))
+
+	for _, item := range items {
+		secret, err := blobs.prepare(ctx, item.GetSecrets().GetSecret())
+		if err != nil {
+			return nil, err
+		}
+
+		secrets[item.Id] = secret
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// LoginAttempts represents failed login attempts with same key (username or client's address).
+type LoginAttempts struct {
+	Key string `db:"login_key"`
+	// Number of failed attempts since last reset.
+	Failures uint32 `db:"failures"`
+	// Time of last failed attempt.
+	LastFailure time.Time `db:"last_failure"`
+	// Logins with key are rejected until this time.
+	LockedUntil time.Time `db:"locked_until"`
+}
+
+// newStaleLoginAttemptsDeleteStmt is a helper function for construct statement, which deletes
+// unlocked attempts with last failure before resetBefore.
+func newStaleLoginAttemptsDeleteStmt(psql sq.StatementBuilderType, resetBefore time.Time) (SQLStatement,
+	[]interface{}, error) {
+	return psql.
+		Delete("login_attempts").
+		Where(sq.Lt{"last_failure": resetBefore}).
+		Where(sq.LtOrEq{"locked_until": time.Now()}).ToSql()
+}
+
+// LoginLockout returns period, for which logins are locked after provided number of failures.
+// Zero period means that logins aren't locked.
+type LoginLockout func(failures uint32) time.Duration
+
+// newLoginAttemptReserveStmt is a helper function for construct statement, which records login
+// attempt with key as failed one, if logins with key aren't locked at now, and returns key's attempts.
+//
+// Failures recorded before resetBefore are forgotten, so counter starts again.
+func newLoginAttemptReserveStmt(psql sq.StatementBuilderType, key string, resetBefore time.Time,
+	now time.Time) (SQLStatement, []interface{}, error) {
+	return psql.
+		Insert("login_attempts").
+		Columns("login_key, failures, last_failure, locked_until").
+		Values(key, 1, now, now).
+		Suffix(`on conflict (login_key) do update set
+			failures = case when login_attempts.last_failure < ? then 1 else login_attempts.failures + 1 end,
+			last_failure = excluded.last_failure
+			where login_attempts.locked_until <= ?
+			returning login_key, failures, last_failure, locked_until`, resetBefore, now).ToSql()
+}
+
+// newLoginAttemptReleaseStmt is a helper function for construct statement, which forgets reserved
+// login attempt with key. If unlock is true, logins with key are unlocked.
+func newLoginAttemptReleaseStmt(psql sq.StatementBuilderType, key string, unlock bool) (SQLStatement,
+	[]interface{}, error) {
+	stmt := psql.
+		Update("login_attempts").
+		Set("failures", sq.Expr("case when failures > 0 then failures - 1 else 0 end")).
+		Where(sq.Eq{"login_key": key})
+
+	if unlock {
+		stmt = stmt.Set("locked_until", time.Now())
+	}
+
+	return stmt.ToSql()
+}
+
+// newLoginLockStmt is a helper function for construct statement, which locks logins with key
+// until provided time.
+func newLoginLockStmt(psql sq.StatementBuilderType, key string, until time.Time) (SQLStatement,
+	[]interface{}, error) {
+	return psql.
+		Update("login_attempts").
+		Set("locked_until", until).
+		Where(sq.Eq{"login_key": key}).ToSql()
+}
+
+// newLoginAttemptsSelect is a helper function for construct statement, which selects key's attempts.
+func newLoginAttemptsSelect(psql sq.StatementBuilderType, key string) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("login_key, failures, last_failure, locked_until").
+		From("login_attempts").
+		Where(sq.Eq{"login_key": key}).ToSql()
+}
+
+// newLoginAttemptsDeleteStmt is a helper function for construct statement, which deletes key's attempts.
+func newLoginAttemptsDeleteStmt(psql sq.StatementBuilderType, key string) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("login_attempts").
+		Where(sq.Eq{"login_key": key}).ToSql()
+}
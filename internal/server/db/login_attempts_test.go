@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLoginAttempts checks reservation and release of login attempts, including parallel ones.
+func testLoginAttempts(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	resetBefore := time.Now().Add(-time.Hour)
+	lockout := func(failures uint32) time.Duration {
+		if failures < 3 {
+			return 0
+		}
+
+		return time.Minute
+	}
+
+	t.Run("Reserve attempts", func(t *testing.T) {
+		for want := uint32(1); want <= 3; want++ {
+			attempts, ok, err := db.ReserveLoginAttempt(ctx, "user:alice", resetBefore, lockout)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, want, attempts.Failures)
+		}
+
+		attempts, err := db.GetLoginAttempts(ctx, "user:alice")
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), attempts.LockedUntil, time.Second,
+			"attempt, which reached threshold, locks logins")
+
+		attempts, ok, err := db.ReserveLoginAttempt(ctx, "user:alice", resetBefore, lockout)
+		require.NoError(t, err)
+		assert.False(t, ok, "locked attempt isn't reserved")
+		assert.Equal(t, uint32(3), attempts.Failures)
+
+		attempts, ok, err = db.ReserveLoginAttempt(ctx, "ip:10.0.0.1", resetBefore, lockout)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, uint32(1), attempts.Failures, "attempts are counted by key")
+	})
+
+	t.Run("Release attempt", func(t *testing.T) {
+		require.NoError(t, db.ReleaseLoginAttempt(ctx, "ip:10.0.0.1", false))
+		require.NoError(t, db.ReleaseLoginAttempt(ctx, "user:unknown", false))
+
+		attempts, err := db.GetLoginAttempts(ctx, "ip:10.0.0.1")
+		require.NoError(t, err)
+		assert.Zero(t, attempts.Failures)
+
+		require.NoError(t, db.ReleaseLoginAttempt(ctx, "user:alice", true))
+
+		attempts, ok, err := db.ReserveLoginAttempt(ctx, "user:alice", resetBefore, lockout)
+		require.NoError(t, err)
+		assert.True(t, ok, "released attempt unlocks logins")
+		assert.Equal(t, uint32(3), attempts.Failures)
+	})
+
+	t.Run("Reset failures", func(t *testing.T) {
+		attempts, ok, err := db.ReserveLoginAttempt(ctx, "ip:10.0.0.1", time.Now().Add(time.Second), lockout)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, uint32(1), attempts.Failures, "stale failures are forgotten")
+
+		attempts, err = db.GetLoginAttempts(ctx, "user:alice")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), attempts.Failures, "locked attempts are kept")
+	})
+
+	t.Run("Parallel attempts", func(t *testing.T) {
+		var (
+			wg       sync.WaitGroup
+			reserved int32
+		)
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, ok, err := db.ReserveLoginAttempt(ctx, "user:bob", resetBefore, lockout)
+				assert.NoError(t, err)
+
+				if ok {
+					atomic.AddInt32(&reserved, 1)
+				}
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, int32(3), reserved, "parallel attempts mustn't exceed threshold")
+	})
+
+	t.Run("Delete attempts", func(t *testing.T) {
+		require.NoError(t, db.DeleteLoginAttempts(ctx, "user:alice"))
+		require.NoError(t, db.DeleteLoginAttempts(ctx, "user:unknown"))
+
+		_, err := db.GetLoginAttempts(ctx, "user:alice")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
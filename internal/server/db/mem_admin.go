@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListUsers returns all users' information without organizations' vaults sorted by username.
+func (db *Memory) ListUsers(ctx context.Context) ([]*pb.UserInfo, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	users := make([]*pb.UserInfo, 0, len(db.users))
+
+	for username, u := range db.users {
+		if db.isOrgVault(username) {
+			continue
+		}
+
+		users = append(users, &pb.UserInfo{
+			Username:  username,
+			Email:     u.user.Email,
+			IsAdmin:   u.isAdmin,
+			Locked:    u.locked,
+			TwoFactor: u.user.OtpKey != nil,
+			Regdate:   u.user.Regdate,
+			Updated:   u.user.Updated,
+		})
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	return users, nil
+}
+
+// SetUserLocked locks or unlocks user. Locking revokes all user's tokens.
+//
+// If no users were found SetUserLocked returns error (ErrNotFound).
+func (db *Memory) SetUserLocked(ctx context.Context, username Username, locked bool) error {
+	return db.updateUserState(ctx, username, func(u *memUser) {
+		u.locked = locked
+
+		if locked {
+			u.tokensRevoked = time.Now()
+		}
+	})
+}
+
+// SetUserAdmin grants or revokes user's administrator role. Revoking role revokes all user's tokens.
+//
+// If no users were found SetUserAdmin returns error (ErrNotFound).
+func (db *Memory) SetUserAdmin(ctx context.Context, username Username, isAdmin bool) error {
+	return db.updateUserState(ctx, username, func(u *memUser) {
+		u.isAdmin = isAdmin
+
+		if !isAdmin {
+			u.tokensRevoked = time.Now()
+		}
+	})
+}
+
+// ResetTwoFactor disables user's two-factor authentication.
+//
+// If no users were found ResetTwoFactor returns error (ErrNotFound).
+func (db *Memory) ResetTwoFactor(ctx context.Context, username Username) error {
+	return db.updateUserState(ctx, username, func(u *memUser) {
+		u.user.OtpKey = nil
+		u.user.Updated = timestamppb.New(time.Now().Truncate(time.Second))
+	})
+}
+
+// updateUserState is a helper function, which applies update to user, organizations' vaults
+// are not updated.
+func (db *Memory) updateUserState(ctx context.Context, username Username, update func(*memUser)) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok || db.isOrgVault(username) {
+		return stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	update(u)
+
+	return nil
+}
+
+// GetServerStats returns numbers of users, organizations and items.
+func (db *Memory) GetServerStats(ctx context.Context) (*pb.ServerStats, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	stats := &pb.ServerStats{
+		Organizations: int64(len(db.orgs)),
+		Items:         int64(len(db.items)),
+	}
+
+	for username, u := range db.users {
+		if !db.isOrgVault(username) {
+			stats.Users++
+		}
+
+		if u.isAdmin {
+			stats.Admins++
+		}
+
+		if u.locked {
+			stats.LockedUsers++
+		}
+	}
+
+	for _, item := range db.items {
+		if item.deleted != nil {
+			stats.TrashedItems++
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestMemory_Admin(t *testing.T) {
+	testAdmin(t, newTestMemory(t))
+}
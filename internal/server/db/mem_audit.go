@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateAuditEvents records audit events.
+func (db *Memory) CreateAuditEvents(ctx context.Context, events []*pb.AuditEvent) error {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := timestamppb.New(time.Now().Truncate(time.Second))
+
+	for _, event := range events {
+		recorded := proto.Clone(event).(*pb.AuditEvent) //nolint:forcetypeassert
+		recorded.Id = int64(len(db.audit) + 1)
+
+		if recorded.Created == nil {
+			recorded.Created = now
+		}
+
+		db.audit = append(db.audit, recorded)
+	}
+
+	return nil
+}
+
+// GetAuditEvents returns at most limit user's audit events newest first, which are older than event
+// with provided ID. Zero ID means latest events.
+func (db *Memory) GetAuditEvents(ctx context.Context, username Username, beforeID int64,
+	limit uint32) ([]*pb.AuditEvent, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	last := int64(len(db.audit))
+	if beforeID > 0 && beforeID <= last {
+		last = beforeID - 1
+	}
+
+	events := make([]*pb.AuditEvent, 0)
+
+	for i := last - 1; i >= 0 && uint32(len(events)) < limit; i-- {
+		if db.audit[i].Username == username {
+			events = append(events, proto.Clone(db.audit[i]).(*pb.AuditEvent)) //nolint:forcetypeassert
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestMemory_Audit(t *testing.T) {
+	testAudit(t, newTestMemory(t))
+}
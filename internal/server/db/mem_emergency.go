@@ -0,0 +1,296 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateEmergencyAccess invites grantee as grantor's trusted contact and returns emergency access's ID.
+func (db *Memory) CreateEmergencyAccess(ctx context.Context, grantor Username,
+	access *pb.EmergencyAccess) (int64, error) {
+	if grantor == "" {
+		return 0, ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return 0, err
+	}
+
+	if err := checkNewEmergencyAccess(access); err != nil {
+		return 0, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, username := range []Username{grantor, access.Grantee} {
+		if _, ok := db.users[username]; !ok || db.isOrgVault(username) {
+			return 0, errNoRowsAffected()
+		}
+	}
+
+	if grantor == access.Grantee {
+		return 0, errNoRowsAffected()
+	}
+
+	for _, a := range db.emergency {
+		if a.Grantor == grantor && a.Grantee == access.Grantee {
+			return 0, stackErrors(ErrDuplicateEntry, fmt.Errorf("emergency access for %s", access.Grantee))
+		}
+	}
+
+	db.lastEmergencyID++
+	db.emergency[db.lastEmergencyID] = &pb.EmergencyAccess{
+		Id:       db.lastEmergencyID,
+		Grantor:  grantor,
+		Grantee:  access.Grantee,
+		Mode:     access.Mode,
+		Status:   common.EmergencyStatusInvited,
+		WaitDays: access.WaitDays,
+		Ekey:     append([]byte(nil), access.Ekey...),
+		Created:  timestamppb.New(time.Now().Truncate(time.Second)),
+	}
+
+	return db.lastEmergencyID, nil
+}
+
+// GetGrantedEmergencyAccesses returns emergency accesses granted by user without keys.
+func (db *Memory) GetGrantedEmergencyAccesses(ctx context.Context, username Username) ([]*pb.EmergencyAccess, error) {
+	return db.getEmergencyAccesses(ctx, func(a *pb.EmergencyAccess) bool { return a.Grantor == username })
+}
+
+// GetReceivedEmergencyAccesses returns emergency accesses granted to user without keys.
+func (db *Memory) GetReceivedEmergencyAccesses(ctx context.Context, username Username) ([]*pb.EmergencyAccess, error) {
+	return db.getEmergencyAccesses(ctx, func(a *pb.EmergencyAccess) bool { return a.Grantee == username })
+}
+
+// getEmergencyAccesses is a helper function, which returns emergency accesses matched by filter
+// sorted by ID.
+func (db *Memory) getEmergencyAccesses(ctx context.Context,
+	filter func(*pb.EmergencyAccess) bool) ([]*pb.EmergencyAccess, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var accesses []*pb.EmergencyAccess
+
+	for id := int64(1); id <= db.lastEmergencyID; id++ {
+		a, ok := db.emergency[id]
+		if !ok || !filter(a) {
+			continue
+		}
+
+		access := proto.Clone(a).(*pb.EmergencyAccess) //nolint:forcetypeassert
+		access.Ekey = nil
+
+		accesses = append(accesses, access)
+	}
+
+	return accesses, nil
+}
+
+// GetEmergencyAccess returns emergency access. Grantor's key is returned only for approved access,
+// so it's never released to grantee before approval.
+//
+// If access doesn't exist GetEmergencyAccess returns ErrNotFound.
+func (db *Memory) GetEmergencyAccess(ctx context.Context, id int64) (*pb.EmergencyAccess, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	a, ok := db.emergency[id]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, fmt.Errorf("emergency access %d", id))
+	}
+
+	access := proto.Clone(a).(*pb.EmergencyAccess) //nolint:forcetypeassert
+	if access.Status != common.EmergencyStatusApproved {
+		access.Ekey = nil
+	}
+
+	return access, nil
+}
+
+// AcceptEmergencyInvite accepts invite by grantee.
+func (db *Memory) AcceptEmergencyInvite(ctx context.Context, grantee Username, id int64) error {
+	return db.updateEmergencyAccess(ctx, id, grantee, false, []string{common.EmergencyStatusInvited},
+		func(a *pb.EmergencyAccess) {
+			a.Status = common.EmergencyStatusAccepted
+		})
+}
+
+// RequestEmergencyAccess requests access by grantee, access is approved automatically after provided time,
+// if it isn't rejected by grantor.
+func (db *Memory) RequestEmergencyAccess(ctx context.Context, grantee Username, id int64,
+	approveAfter time.Time) error {
+	return db.updateEmergencyAccess(ctx, id, grantee, false, []string{common.EmergencyStatusAccepted},
+		func(a *pb.EmergencyAccess) {
+			a.Status = common.EmergencyStatusRequested
+			a.Requested = timestamppb.New(time.Now().Truncate(time.Second))
+			a.ApproveAfter = timestamppb.New(approveAfter.Truncate(time.Second))
+		})
+}
+
+// ApproveEmergencyAccess approves requested access by grantor.
+func (db *Memory) ApproveEmergencyAccess(ctx context.Context, grantor Username, id int64) error {
+	return db.updateEmergencyAccess(ctx, id, grantor, true, []string{common.EmergencyStatusRequested},
+		func(a *pb.EmergencyAccess) {
+			a.Status = common.EmergencyStatusApproved
+		})
+}
+
+// RejectEmergencyAccess rejects requested or approved access by grantor.
+//
+// Grantee can request access again.
+func (db *Memory) RejectEmergencyAccess(ctx context.Context, grantor Username, id int64) error {
+	return db.updateEmergencyAccess(ctx, id, grantor, true,
+		[]string{common.EmergencyStatusRequested, common.EmergencyStatusApproved},
+		func(a *pb.EmergencyAccess) {
+			a.Status = common.EmergencyStatusAccepted
+			a.Requested, a.ApproveAfter = nil, nil
+		})
+}
+
+// updateEmergencyAccess is a helper function, which applies update to emergency access in one of
+// provided statuses, if user is access's grantor (byGrantor is true) or grantee.
+func (db *Memory) updateEmergencyAccess(ctx context.Context, id int64, username Username, byGrantor bool,
+	statuses []string, update func(*pb.EmergencyAccess)) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	a, ok := db.emergency[id]
+	if !ok || !common.Contains(a.Status, statuses) {
+		return errNoRowsAffected()
+	}
+
+	if (byGrantor && a.Grantor != username) || (!byGrantor && a.Grantee != username) {
+		return errNoRowsAffected()
+	}
+
+	update(a)
+
+	return nil
+}
+
+// DeleteEmergencyAccess deletes emergency access by its grantor or grantee.
+func (db *Memory) DeleteEmergencyAccess(ctx context.Context, username Username, id int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	a, ok := db.emergency[id]
+	if !ok || (a.Grantor != username && a.Grantee != username) {
+		return errNoRowsAffected()
+	}
+
+	delete(db.emergency, id)
+
+	return nil
+}
+
+// TakeoverAccount replaces grantor's password hash and encryption key by grantee of approved
+// takeover access.
+//
+// Grantor's two-factor authentication is disabled and all grantor's tokens are revoked.
+// Emergency access is deleted after takeover.
+func (db *Memory) TakeoverAccount(ctx context.Context, grantee Username, id int64, user *pb.User) error {
+	if grantee == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	if user == nil || user.GetPwdhash() == "" || len(user.Ekey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("password hash and encryption key are required"))
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	a, ok := db.emergency[id]
+	if !ok || a.Grantee != grantee || a.Status != common.EmergencyStatusApproved ||
+		a.Mode != common.EmergencyModeTakeover {
+		return errNoRowsAffected()
+	}
+
+	u, ok := db.users[a.Grantor]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	now := time.Now()
+
+	u.user.Pwdhash = common.PtrTo(user.GetPwdhash())
+	u.user.Ekey = append([]byte(nil), user.Ekey...)
+	u.user.OtpKey = nil
+	u.user.Updated = timestamppb.New(now.Truncate(time.Second))
+	u.tokensRevoked = now
+
+	delete(db.emergency, id)
+
+	return nil
+}
+
+// approveEmergencyAccesses approves all requested emergency accesses, which weren't rejected
+// by grantors during waiting period.
+func (db *Memory) approveEmergencyAccesses(now time.Time, componentName string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	approved := 0
+
+	for _, a := range db.emergency {
+		if a.Status == common.EmergencyStatusRequested && !a.ApproveAfter.AsTime().After(now) {
+			a.Status = common.EmergencyStatusApproved
+			approved++
+		}
+	}
+
+	if approved > 0 {
+		db.logger.Info(fmt.Sprintf("approved %d emergency accesses", approved), componentName)
+	}
+}
+
+// grantedEmergencyCount is a helper function which returns number of emergency accesses
+// granted by user. Caller must hold the lock.
+func (db *Memory) grantedEmergencyCount(username Username) int {
+	count := 0
+
+	for _, a := range db.emergency {
+		if a.Grantor == username {
+			count++
+		}
+	}
+
+	return count
+}
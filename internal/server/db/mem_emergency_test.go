@@ -0,0 +1,12 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemory_EmergencyAccess(t *testing.T) {
+	db := newTestMemory(t)
+
+	testEmergencyAccess(t, db, func(now time.Time) { db.approveEmergencyAccesses(now, "test") })
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateFolder creates new user's folder and returns its ID.
+func (db *Memory) CreateFolder(ctx context.Context, username Username, folder *pb.Folder) (int64, error) {
+	if username == "" {
+		return 0, ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return 0, err
+	}
+
+	if err := checkFolder(folder); err != nil {
+		return 0, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return 0, errNoRowsAffected()
+	}
+
+	db.lastFolderID++
+
+	f := &memFolder{
+		userID: u.id,
+		folder: &pb.Folder{Id: db.lastFolderID},
+	}
+	f.setName(folder.Name)
+
+	db.folders[f.folder.Id] = f
+	db.bumpRevision(u)
+
+	return f.folder.Id, nil
+}
+
+// GetFolders returns all user's folders.
+func (db *Memory) GetFolders(ctx context.Context, username Username) ([]*pb.Folder, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, nil
+	}
+
+	var folders []*pb.Folder
+
+	for _, f := range db.folders {
+		if f.userID == u.id {
+			folders = append(folders, proto.Clone(f.folder).(*pb.Folder)) //nolint:forcetypeassert
+		}
+	}
+
+	sort.Slice(folders, func(i, j int) bool { return folders[i].Id < folders[j].Id })
+
+	return folders, nil
+}
+
+// UpdateFolder renames existing user's folder.
+func (db *Memory) UpdateFolder(ctx context.Context, username Username, folder *pb.Folder) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	if err := checkFolder(folder); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok || !db.folderOwnedBy(folder.Id, u.id) {
+		return errNoRowsAffected()
+	}
+
+	db.folders[folder.Id].setName(folder.Name)
+	db.bumpRevision(u)
+
+	return nil
+}
+
+// DeleteFolder deletes user's folder, folder's items are kept without folder.
+func (db *Memory) DeleteFolder(ctx context.Context, username Username, folderID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok || !db.folderOwnedBy(folderID, u.id) {
+		return errNoRowsAffected()
+	}
+
+	revision := db.bumpRevision(u)
+
+	for _, i := range db.items {
+		if i.userID == u.id && i.item.GetFolderId() == folderID {
+			i.item.FolderId = nil
+			db.logChange(u, i.item.Id, itemUpdated, revision)
+		}
+	}
+
+	delete(db.folders, folderID)
+
+	return nil
+}
+
+// folderOwnedBy is a helper function which checks that folder exists and belongs to user.
+// Caller must hold the lock.
+func (db *Memory) folderOwnedBy(folderID int64, userID int64) bool {
+	f, ok := db.folders[folderID]
+
+	return ok && f.userID == userID
+}
+
+// userFoldersCount is a helper function which returns number of user's folders.
+// Caller must hold the lock.
+func (db *Memory) userFoldersCount(userID int64) int {
+	var n int
+
+	for _, f := range db.folders {
+		if f.userID == userID {
+			n++
+		}
+	}
+
+	return n
+}
+
+// setName sets folder's name and updated time.
+func (f *memFolder) setName(name []byte) {
+	f.folder.Name = append([]byte(nil), name...)
+	f.folder.Updated = timestamppb.New(time.Now().Truncate(time.Second))
+}
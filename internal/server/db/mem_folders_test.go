@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestMemory_Folders(t *testing.T) {
+	testFolders(t, newTestMemory(t))
+}
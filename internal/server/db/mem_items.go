@@ -0,0 +1,989 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateItem creates new item for particular user.
+//
+// CreateItem generates updated time field during creation.
+// Returns nil error only on successfully creation.
+// If user's quota is exceeded returns ErrQuotaExceeded.
+func (db *Memory) CreateItem(ctx context.Context, username string, item *pb.Item) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	newItem := proto.Clone(item).(*pb.Item) //nolint:forcetypeassert
+
+	if newItem.Secrets == nil {
+		newItem.Secrets = new(pb.Secrets)
+	}
+
+	if newItem.Additions == nil {
+		newItem.Additions = new(pb.Additions)
+	}
+
+	// Only login item can contain URIs' fields
+	if newItem.Type != common.ItemTypeLogin {
+		newItem.Additions.Uris = nil
+	}
+
+	if err := db.validateItem(newItem); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	if db.itemExists(u.id, newItem.Name, newItem.Type, 0) {
+		return stackErrors(ErrDuplicateEntry, fmt.Errorf("item %s:%s", newItem.Name, newItem.Type))
+	}
+
+	newItem.FolderId = itemFolderID(newItem)
+	if newItem.FolderId != nil && !db.folderOwnedBy(*newItem.FolderId, u.id) {
+		return errNoRowsAffected()
+	}
+
+	usage := db.userUsage(u.id)
+	usage.Items++
+	usage.Bytes += itemSize(newItem)
+
+	if err := db.quota.check(usage, true); err != nil {
+		return err
+	}
+
+	db.lastItemID++
+	newItem.Id = db.lastItemID
+	setMemItemHashUpdated(newItem)
+
+	db.items[newItem.Id] = &memItem{
+		userID: u.id,
+		item:   newItem,
+	}
+
+	db.recordChange(u, newItem.Id, itemCreated)
+
+	return nil
+}
+
+// GetItemByNameAndType gets item's information.
+func (db *Memory) GetItemByNameAndType(ctx context.Context, username Username,
+	itemName string, itemType string) (*pb.Item, error) {
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	for _, i := range db.items {
+		if i.userID == u.id && i.deleted == nil && i.item.Name == itemName && i.item.Type == itemType {
+			return proto.Clone(i.item).(*pb.Item), nil //nolint:forcetypeassert
+		}
+	}
+
+	return nil, stackErrors(ErrNotFound, fmt.Errorf("item %s:%s", itemName, itemType))
+}
+
+// GetItemList returns short representation of all user's items sorted by name, including trashed.
+func (db *Memory) GetItemList(ctx context.Context, username Username) ([]*pb.ItemShort, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, nil
+	}
+
+	var items []*pb.ItemShort
+
+	for _, i := range db.items {
+		if i.userID != u.id {
+			continue
+		}
+
+		items = append(items, i.toShort())
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	return items, nil
+}
+
+// GetChangesSince returns changes of user's items made since provided vault revision.
+//
+// If no users were found GetChangesSince returns nil and error (ErrNotFound).
+func (db *Memory) GetChangesSince(ctx context.Context, username Username, revision int64) (*Changes, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	var log []*ItemChange
+
+	for _, c := range u.changes {
+		if c.Revision > revision {
+			log = append(log, c)
+		}
+	}
+
+	sort.Slice(log, func(i, j int) bool {
+		return log[i].ItemID < log[j].ItemID
+	})
+
+	return newChanges(u.user.GetRevision(), revision, log), nil
+}
+
+// GetItemsByID returns user's items with provided IDs sorted by ID.
+//
+// Unexisting IDs are ignored.
+func (db *Memory) GetItemsByID(ctx context.Context, username Username, ids []int64) ([]*pb.Item, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, nil
+	}
+
+	var items []*pb.Item
+
+	for _, id := range ids {
+		i, ok := db.items[id]
+		if !ok || i.userID != u.id || i.deleted != nil {
+			continue
+		}
+
+		items = append(items, proto.Clone(i.item).(*pb.Item)) //nolint:forcetypeassert
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Id < items[j].Id
+	})
+
+	return items, nil
+}
+
+// GetAllItems returns all user's items sorted by ID, including trashed.
+func (db *Memory) GetAllItems(ctx context.Context, username Username) ([]*pb.Item, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, nil
+	}
+
+	var items []*pb.Item
+
+	for _, i := range db.items {
+		if i.userID != u.id {
+			continue
+		}
+
+		items = append(items, proto.Clone(i.item).(*pb.Item)) //nolint:forcetypeassert
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Id < items[j].Id
+	})
+
+	return items, nil
+}
+
+// GetItemHashByID returns hash of user's item.
+//
+// If user doesn't own item GetItemHashByID returns ErrNotFound.
+func (db *Memory) GetItemHashByID(ctx context.Context, username Username, id int64) ([]byte, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	i, ok := db.items[id]
+	if !ok || i.userID != u.id {
+		return nil, stackErrors(ErrNotFound, fmt.Errorf("item id %d", id))
+	}
+
+	return append([]byte(nil), i.item.Hash...), nil
+}
+
+// UpdateItem updates existing item.
+//
+// Empty fields of secrets and additions are ignored. Item's type cannot be updated.
+// Returns nil error only on successful update.
+// If user's quota of stored data is exceeded returns ErrQuotaExceeded.
+func (db *Memory) UpdateItem(ctx context.Context, username string, item *pb.Item) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	stored, ok := db.items[item.Id]
+	if !ok || stored.userID != u.id || stored.deleted != nil {
+		return errNoRowsAffected()
+	}
+
+	updated := proto.Clone(stored.item).(*pb.Item) //nolint:forcetypeassert
+	updated.Name = item.Name
+
+	if item.Reprompt != nil {
+		updated.Reprompt = proto.Bool(*item.Reprompt)
+	}
+
+	if item.FolderId != nil {
+		updated.FolderId = itemFolderID(item)
+		if updated.FolderId != nil && !db.folderOwnedBy(*updated.FolderId, u.id) {
+			return errNoRowsAffected()
+		}
+	}
+
+	if item.Secrets != nil {
+		if item.Secrets.Notes != nil {
+			updated.Secrets.Notes = append([]byte(nil), item.Secrets.Notes...)
+		}
+
+		if item.Secrets.Secret != nil {
+			updated.Secrets.Secret = append([]byte(nil), item.Secrets.Secret...)
+		}
+	}
+
+	// Only login item can contain URIs' fields
+	if item.Additions != nil {
+		if item.Additions.Uris != nil && updated.Type == common.ItemTypeLogin {
+			updated.Additions.Uris = append([]byte(nil), item.Additions.Uris...)
+		}
+
+		if item.Additions.CustomFields != nil {
+			updated.Additions.CustomFields = append([]byte(nil), item.Additions.CustomFields...)
+		}
+
+		if item.Additions.Tags != nil {
+			updated.Additions.Tags = append([]byte(nil), item.Additions.Tags...)
+		}
+	}
+
+	if err := db.validateItem(updated); err != nil {
+		return err
+	}
+
+	if db.itemExists(u.id, updated.Name, updated.Type, updated.Id) {
+		return stackErrors(ErrDuplicateEntry, fmt.Errorf("item %s:%s", updated.Name, updated.Type))
+	}
+
+	usage := db.userUsage(u.id)
+	usage.Bytes += itemSize(updated) - itemSize(stored.item) + db.archivedSize(stored)
+
+	if err := db.quota.check(usage, false); err != nil {
+		return err
+	}
+
+	setMemItemHashUpdated(updated)
+	db.archiveItemVersion(stored)
+	stored.item = updated
+
+	db.recordChange(u, updated.Id, itemUpdated)
+
+	return nil
+}
+
+// DeleteItem deletes user's item.
+func (db *Memory) DeleteItem(ctx context.Context, username Username, itemID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	stored, ok := db.items[itemID]
+	if !ok || stored.userID != u.id || stored.deleted != nil {
+		return errNoRowsAffected()
+	}
+
+	stored.deleted = timestamppb.Now()
+
+	db.recordChange(u, itemID, itemUpdated)
+
+	return nil
+}
+
+// GetTrashList returns short representation of all user's trashed items sorted by name.
+func (db *Memory) GetTrashList(ctx context.Context, username Username) ([]*pb.ItemShort, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, nil
+	}
+
+	var items []*pb.ItemShort
+
+	for _, i := range db.items {
+		if i.userID == u.id && i.deleted != nil {
+			items = append(items, i.toShort())
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	return items, nil
+}
+
+// RestoreItem restores user's item from trash.
+func (db *Memory) RestoreItem(ctx context.Context, username Username, itemID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	stored, ok := db.items[itemID]
+	if !ok || stored.userID != u.id || stored.deleted == nil {
+		return errNoRowsAffected()
+	}
+
+	stored.deleted = nil
+
+	db.recordChange(u, itemID, itemUpdated)
+
+	return nil
+}
+
+// PurgeItem permanently deletes user's trashed item.
+func (db *Memory) PurgeItem(ctx context.Context, username Username, itemID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	stored, ok := db.items[itemID]
+	if !ok || stored.userID != u.id || stored.deleted == nil {
+		return errNoRowsAffected()
+	}
+
+	delete(db.items, itemID)
+	db.deleteShares(func(s *pb.Share) bool { return s.ItemId == itemID })
+
+	db.recordChange(u, itemID, itemDeleted)
+
+	return nil
+}
+
+// purgeTrash permanently deletes all users' items, which were trashed before provided time.
+func (db *Memory) purgeTrash(before time.Time, componentName string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	users := make(map[int64]*memUser, len(db.users))
+	for _, u := range db.users {
+		users[u.id] = u
+	}
+
+	purged := 0
+
+	for id, i := range db.items {
+		if i.deleted != nil && i.deleted.AsTime().Before(before) {
+			delete(db.items, id)
+			db.deleteShares(func(s *pb.Share) bool { return s.ItemId == id })
+			db.recordChange(users[i.userID], id, itemDeleted)
+			purged++
+		}
+	}
+
+	if purged > 0 {
+		db.logger.Info(fmt.Sprintf("purged %d trashed items", purged), componentName)
+	}
+}
+
+// GetItemVersions returns item's previous versions sorted from newest to oldest.
+func (db *Memory) GetItemVersions(ctx context.Context, username Username, itemID int64) ([]*pb.ItemVersion, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, nil
+	}
+
+	stored, ok := db.items[itemID]
+	if !ok || stored.userID != u.id {
+		return nil, nil
+	}
+
+	versions := make([]*pb.ItemVersion, 0, len(stored.versions))
+	for i := len(stored.versions) - 1; i >= 0; i-- {
+		versions = append(versions, proto.Clone(stored.versions[i]).(*pb.ItemVersion)) //nolint:forcetypeassert
+	}
+
+	return versions, nil
+}
+
+// RestoreItemVersion restores item's state from previous version.
+//
+// Current item's state is stored as new version, so restore can be reverted.
+// Returns nil error only on successful restore.
+func (db *Memory) RestoreItemVersion(ctx context.Context, username Username, itemID int64, version int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	stored, ok := db.items[itemID]
+	if !ok || stored.userID != u.id {
+		return stackErrors(ErrNotFound, fmt.Errorf("item id %d", itemID))
+	}
+
+	if stored.deleted != nil {
+		return errNoRowsAffected()
+	}
+
+	var restored *pb.Item
+
+	for _, v := range stored.versions {
+		if v.Version == version {
+			restored = proto.Clone(v.Item).(*pb.Item) //nolint:forcetypeassert
+			break
+		}
+	}
+
+	if restored == nil {
+		return stackErrors(ErrNotFound, fmt.Errorf("item id %d version %d", itemID, version))
+	}
+
+	if db.itemExists(u.id, restored.Name, restored.Type, restored.Id) {
+		return stackErrors(ErrDuplicateEntry, fmt.Errorf("item %s:%s", restored.Name, restored.Type))
+	}
+
+	usage := db.userUsage(u.id)
+	usage.Bytes += itemSize(restored) - itemSize(stored.item) + db.archivedSize(stored)
+
+	if err := db.quota.check(usage, false); err != nil {
+		return err
+	}
+
+	setMemItemHashUpdated(restored)
+	db.archiveItemVersion(stored)
+	stored.item = restored
+
+	db.recordChange(u, itemID, itemUpdated)
+
+	return nil
+}
+
+// RotateEncryptionKey replaces user's encryption key, private key, all user's items (including trashed),
+// folders and keys of user's emergency accesses with re-encrypted ones at once.
+//
+// All user's items, folders and emergency accesses' keys must be passed, private key must be passed
+// if user has key pair, otherwise nothing is changed and ErrOperationFailed is returned.
+// Items' history is deleted, because it is encrypted with previous key.
+//
+//nolint:cyclop
+func (db *Memory) RotateEncryptionKey(ctx context.Context, username Username, ekey []byte,
+	privateKey []byte, items []*pb.Item, folders []*pb.Folder, emergencyKeys []*pb.EmergencyKey) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	if err := checkRotationItems(ekey, items, folders, emergencyKeys); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	var userItems int
+
+	for _, i := range db.items {
+		if i.userID == u.id {
+			userItems++
+		}
+	}
+
+	if userItems != len(items) || db.userFoldersCount(u.id) != len(folders) {
+		return errNoRowsAffected()
+	}
+
+	if (len(u.user.PrivateKey) == 0) != (len(privateKey) == 0) {
+		return errNoRowsAffected()
+	}
+
+	for _, folder := range folders {
+		if !db.folderOwnedBy(folder.Id, u.id) {
+			return errNoRowsAffected()
+		}
+	}
+
+	if db.grantedEmergencyCount(username) != len(emergencyKeys) {
+		return errNoRowsAffected()
+	}
+
+	for _, key := range emergencyKeys {
+		if a, ok := db.emergency[key.Id]; !ok || a.Grantor != username {
+			return errNoRowsAffected()
+		}
+	}
+
+	updated := make([]*pb.Item, 0, len(items))
+
+	for _, item := range items {
+		stored, ok := db.items[item.Id]
+		if !ok || stored.userID != u.id || stored.item.Name != item.Name || stored.item.Type != item.Type {
+			return errNoRowsAffected()
+		}
+
+		newItem := proto.Clone(stored.item).(*pb.Item) //nolint:forcetypeassert
+		newItem.Secrets = &pb.Secrets{
+			Notes:  append([]byte(nil), item.GetSecrets().GetNotes()...),
+			Secret: append([]byte(nil), item.GetSecrets().GetSecret()...),
+		}
+		newItem.Additions = &pb.Additions{
+			CustomFields: append([]byte(nil), item.GetAdditions().GetCustomFields()...),
+			Tags:         append([]byte(nil), item.GetAdditions().GetTags()...),
+		}
+
+		// Only login item can contain URIs' fields
+		if newItem.Type == common.ItemTypeLogin {
+			newItem.Additions.Uris = append([]byte(nil), item.GetAdditions().GetUris()...)
+		}
+
+		if err := db.validateItem(newItem); err != nil {
+			return err
+		}
+
+		setMemItemHashUpdated(newItem)
+		updated = append(updated, newItem)
+	}
+
+	for _, item := range updated {
+		stored := db.items[item.Id]
+		stored.item = item
+		stored.versions = nil
+
+		db.recordChange(u, item.Id, itemUpdated)
+	}
+
+	for _, folder := range folders {
+		db.folders[folder.Id].setName(folder.Name)
+	}
+
+	for _, key := range emergencyKeys {
+		db.emergency[key.Id].Ekey = append([]byte(nil), key.Ekey...)
+	}
+
+	u.user.Ekey = append([]byte(nil), ekey...)
+	u.user.PrivateKey = append([]byte(nil), privateKey...)
+	u.user.Updated = timestamppb.New(time.Now().Truncate(time.Second))
+
+	return nil
+}
+
+// SaveSecretData replaces data item's content with chunks, received one by one from next.
+//
+// Chunks are received without lock and replace previous content only after all chunks are
+// received, so in case of any failure previous content is kept. Returns total size of stored chunks.
+// If total size exceeds maximum size of secret returns ErrSecretTooBig.
+// If user's quota of stored data is exceeded returns ErrQuotaExceeded.
+func (db *Memory) SaveSecretData(ctx context.Context, username Username, itemID int64,
+	next ChunkReader) (int64, error) {
+	if username == "" {
+		return 0, ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return 0, err
+	}
+
+	db.mu.RLock()
+	_, err := db.getSecretDataItem(username, itemID)
+	db.mu.RUnlock()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var chunks [][]byte
+
+	_, size, err := receiveSecretChunks(db.maxSecretSize, next, func(_ int64, chunk []byte) error {
+		chunks = append(chunks, append([]byte(nil), chunk...))
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return 0, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	stored, err := db.getSecretDataItem(username, itemID)
+	if err != nil {
+		return 0, err
+	}
+
+	usage := db.userUsage(stored.userID)
+	usage.Bytes += size - stored.chunksSize()
+
+	if err := db.quota.check(usage, false); err != nil {
+		return 0, err
+	}
+
+	stored.chunks = chunks
+	setMemItemHashUpdated(stored.item)
+
+	db.recordChange(db.users[username], itemID, itemUpdated)
+
+	return size, nil
+}
+
+// GetSecretData sends data item's content to send chunk by chunk in order of upload.
+//
+// Chunks are sent without lock, so upload during download doesn't affect sent content.
+func (db *Memory) GetSecretData(ctx context.Context, username Username, itemID int64, send ChunkWriter) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.RLock()
+	stored, err := db.getSecretDataItem(username, itemID)
+
+	var chunks [][]byte
+	if err == nil {
+		chunks = stored.chunks
+	}
+	db.mu.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range chunks {
+		if err := send(append([]byte(nil), chunk...)); err != nil {
+			return stackErrors(ErrOperationFailed, err)
+		}
+	}
+
+	return nil
+}
+
+// getSecretDataItem is a helper function which returns user's not trashed data item.
+// Caller must hold the lock.
+//
+// If item is not found returns ErrNotFound.
+func (db *Memory) getSecretDataItem(username Username, itemID int64) (*memItem, error) {
+	u, ok := db.users[username]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	stored, ok := db.items[itemID]
+	if !ok || stored.userID != u.id || stored.deleted != nil || stored.item.Type != common.ItemTypeSecData {
+		return nil, stackErrors(ErrNotFound, fmt.Errorf("data item id %d", itemID))
+	}
+
+	return stored, nil
+}
+
+// userUsage is a helper function which calculates user's usage, including items' versions
+// and data of shares of user's items.
+func (db *Memory) userUsage(userID int64) *Usage {
+	usage := new(Usage)
+
+	for _, i := range db.items {
+		if i.userID != userID {
+			continue
+		}
+
+		usage.Items++
+		usage.Bytes += itemSize(i.item) + i.chunksSize()
+
+		for _, v := range i.versions {
+			usage.Bytes += itemSize(v.Item)
+		}
+	}
+
+	for _, s := range db.shares {
+		if i, ok := db.items[s.ItemId]; ok && i.userID == userID {
+			usage.Bytes += int64(len(s.Data))
+		}
+	}
+
+	return usage
+}
+
+// archivedSize is a helper function which returns change of item's history size
+// after archiving of current item's state (see archiveItemVersion).
+func (db *Memory) archivedSize(stored *memItem) int64 {
+	if db.itemVersions == 0 {
+		return 0
+	}
+
+	size := itemSize(stored.item)
+	for i := 0; i <= len(stored.versions)-int(db.itemVersions); i++ {
+		size -= itemSize(stored.versions[i].Item)
+	}
+
+	return size
+}
+
+// archiveItemVersion is a helper function which stores current item's state as new version
+// and deletes oldest versions, exceeding retention count. Caller must hold the lock.
+func (db *Memory) archiveItemVersion(stored *memItem) {
+	if db.itemVersions == 0 {
+		return
+	}
+
+	var last int64
+	if n := len(stored.versions); n > 0 {
+		last = stored.versions[n-1].Version
+	}
+
+	stored.versions = append(stored.versions, &pb.ItemVersion{
+		Version: last + 1,
+		Item:    proto.Clone(stored.item).(*pb.Item), //nolint:forcetypeassert
+	})
+
+	if n := len(stored.versions); n > int(db.itemVersions) {
+		stored.versions = stored.versions[n-int(db.itemVersions):]
+	}
+}
+
+// validateItem is a helper function which checks item's fields constraints.
+func (db *Memory) validateItem(item *pb.Item) error {
+	if item.Name == "" || len(item.Name) > memMaxItemNameLen {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("item name '%s'", item.Name))
+	}
+
+	if !common.Contains(item.Type, common.ListItemTypes()) {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("item type '%s'", item.Type))
+	}
+
+	if item.Secrets != nil && len(item.Secrets.Secret) > int(db.maxSecretSize+4) {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("secret size %d", len(item.Secrets.Secret)))
+	}
+
+	return nil
+}
+
+// itemExists is a helper function which checks if user already has item with provided name
+// and type. Item with exceptID is skipped. Caller must hold the lock.
+func (db *Memory) itemExists(userID int64, name, itemType string, exceptID int64) bool {
+	for id, i := range db.items {
+		if id != exceptID && i.userID == userID && i.item.Name == name && i.item.Type == itemType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordChange is a helper function which increases user's vault revision, records
+// item's change in change log and notifies user's watchers. Caller must hold the lock.
+func (db *Memory) recordChange(u *memUser, itemID int64, change itemChange) {
+	db.logChange(u, itemID, change, db.bumpRevision(u))
+}
+
+// bumpRevision is a helper function which increases user's vault revision and notifies watchers.
+// Returns new revision. Caller must hold the lock.
+func (db *Memory) bumpRevision(u *memUser) int64 {
+	revision := u.user.GetRevision() + 1
+	u.user.Revision = &revision
+
+	db.watchers.publish(u.user.Username, revision)
+
+	return revision
+}
+
+// logChange is a helper function which records item's change in user's change log with provided
+// revision. Caller must hold the lock.
+func (db *Memory) logChange(u *memUser, itemID int64, change itemChange, revision int64) {
+	c, ok := u.changes[itemID]
+	if !ok {
+		c = &ItemChange{ItemID: itemID}
+		u.changes[itemID] = c
+	}
+
+	if change == itemCreated {
+		c.CreatedRevision = revision
+	}
+
+	c.Revision = revision
+	c.Deleted = change == itemDeleted
+}
+
+// setMemItemHashUpdated is a helper function which sets new hash and updated time for item.
+func setMemItemHashUpdated(item *pb.Item) {
+	updated, hash := getHashUpdatedItem(item.Name, item.Type)
+
+	updatedTime, err := time.Parse(time.RFC3339, updated)
+	if err != nil {
+		updatedTime = time.Now()
+	}
+
+	item.Updated = timestamppb.New(updatedTime)
+	item.Hash = hash
+}
+
+// chunksSize is a helper function which returns size of item's data chunks in bytes.
+func (i *memItem) chunksSize() (size int64) {
+	for _, c := range i.chunks {
+		size += int64(len(c))
+	}
+
+	return
+}
+
+// toShort is a helper function which returns short representation of item.
+func (i *memItem) toShort() *pb.ItemShort {
+	item := &pb.ItemShort{
+		Id:      i.item.Id,
+		Name:    i.item.Name,
+		Type:    i.item.Type,
+		Updated: proto.Clone(i.item.Updated).(*timestamppb.Timestamp), //nolint:forcetypeassert
+		Hash:    append([]byte(nil), i.item.Hash...),
+		Tags:    append([]byte(nil), i.item.GetAdditions().GetTags()...),
+	}
+
+	if i.item.FolderId != nil {
+		item.FolderId = proto.Int64(*i.item.FolderId)
+	}
+
+	if i.deleted != nil {
+		item.Deleted = proto.Clone(i.deleted).(*timestamppb.Timestamp) //nolint:forcetypeassert
+	}
+
+	return item
+}
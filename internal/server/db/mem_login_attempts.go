@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ReserveLoginAttempt atomically reserves login attempt with key, which is counted as failed until
+// it's released, and deletes stale unlocked attempts.
+//
+// If logins with key are locked, attempt isn't reserved and false is returned. Otherwise logins
+// with key are locked for period returned by lockout for new number of failures.
+// Failures recorded before resetBefore are forgotten.
+func (db *Memory) ReserveLoginAttempt(ctx context.Context, key string, resetBefore time.Time,
+	lockout LoginLockout) (*LoginAttempts, bool, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, false, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+
+	for k, a := range db.loginAttempts {
+		if a.LastFailure.Before(resetBefore) && !a.LockedUntil.After(now) {
+			delete(db.loginAttempts, k)
+		}
+	}
+
+	attempts, ok := db.loginAttempts[key]
+	if !ok {
+		attempts = &LoginAttempts{Key: key, LockedUntil: now}
+		db.loginAttempts[key] = attempts
+	}
+
+	if attempts.LockedUntil.After(now) {
+		copied := *attempts
+		return &copied, false, nil
+	}
+
+	if attempts.LastFailure.Before(resetBefore) {
+		attempts.Failures = 0
+	}
+
+	attempts.Failures++
+	attempts.LastFailure = now
+
+	if period := lockout(attempts.Failures); period > 0 {
+		attempts.LockedUntil = now.Add(period)
+	}
+
+	copied := *attempts
+
+	return &copied, true, nil
+}
+
+// ReleaseLoginAttempt forgets reserved login attempt with key, which isn't failed.
+//
+// If unlock is true, logins with key are unlocked.
+func (db *Memory) ReleaseLoginAttempt(ctx context.Context, key string, unlock bool) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	attempts, ok := db.loginAttempts[key]
+	if !ok {
+		return nil
+	}
+
+	if attempts.Failures > 0 {
+		attempts.Failures--
+	}
+
+	if unlock {
+		attempts.LockedUntil = time.Now()
+	}
+
+	return nil
+}
+
+// GetLoginAttempts returns key's failed login attempts.
+//
+// If there are no recorded attempts GetLoginAttempts returns error (ErrNotFound).
+func (db *Memory) GetLoginAttempts(ctx context.Context, key string) (*LoginAttempts, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	attempts, ok := db.loginAttempts[key]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(key))
+	}
+
+	copied := *attempts
+
+	return &copied, nil
+}
+
+// DeleteLoginAttempts deletes key's failed login attempts.
+func (db *Memory) DeleteLoginAttempts(ctx context.Context, key string) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	delete(db.loginAttempts, key)
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestMemory_LoginAttempts(t *testing.T) {
+	testLoginAttempts(t, newTestMemory(t))
+}
@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Memory represents in-memory implementation of DB.
+//
+// Memory keeps all records in process memory, so all data is lost after server stop.
+// Intended for development and testing purposes, when no PostgreSQL is available.
+// Memory follows same constraints and errors as Posgtre.
+type Memory struct {
+	// Users' records, indexed by username
+	users map[Username]*memUser
+	// Items' records, indexed by item ID
+	items map[int64]*memItem
+	// Last issued user's ID
+	lastUserID int64
+	// Last issued item's ID
+	lastItemID int64
+	// Folders' records, indexed by folder ID
+	folders map[int64]*memFolder
+	// Last issued folder's ID
+	lastFolderID int64
+	// Shares' records, indexed by share ID
+	shares map[int64]*pb.Share
+	// Last issued share's ID
+	lastShareID int64
+	// Organizations' records, indexed by organization ID
+	orgs map[int64]*memOrg
+	// Last issued organization's ID
+	lastOrgID int64
+	// Emergency accesses' records, indexed by access ID
+	emergency map[int64]*pb.EmergencyAccess
+	// Last issued emergency access's ID
+	lastEmergencyID int64
+	// Audit events' records in order of recording, event's ID is its position plus one
+	audit []*pb.AuditEvent
+	// Failed login attempts' records, indexed by attempts' key
+	loginAttempts map[string]*LoginAttempts
+	// Logger
+	logger logger.L
+	// Maximum size of secret in bytes
+	maxSecretSize uint32
+	// Number of items' previous versions, kept in history
+	itemVersions uint32
+	// Period, after which trashed items are purged
+	trashRetention time.Duration
+	// Per-user storage quota
+	quota Quota
+	// Subscriptions to changes of users' vaults
+	watchers *revisionWatchers
+	// Mutex for sync access to records
+	mu sync.RWMutex
+}
+
+// memUser represents user's record in Memory.
+type memUser struct {
+	id            int64
+	user          *pb.User
+	tokensRevoked time.Time
+	isAdmin       bool
+	locked        bool
+	// User's sessions by sessions' IDs
+	sessions map[string]*pb.Session
+	// Last changes of user's items by items' IDs
+	changes map[int64]*ItemChange
+}
+
+// memItem represents item's record in Memory.
+type memItem struct {
+	userID   int64
+	item     *pb.Item
+	versions []*pb.ItemVersion
+	deleted  *timestamppb.Timestamp
+	chunks   [][]byte
+}
+
+// memFolder represents folder's record in Memory.
+type memFolder struct {
+	userID int64
+	folder *pb.Folder
+}
+
+var _ DB = (*Memory)(nil)
+
+// Compiled fields' constraints.
+//
+//nolint:gochecknoglobals
+var (
+	memRegexUsername = regexp.MustCompile(FRegexUsername)
+	memRegexEmail    = regexp.MustCompile(FRegexEmail)
+)
+
+// Fields' length constraints, same as in PostgreSQL schema.
+const (
+	memMaxUsernameLen = 50
+	memMaxItemNameLen = 128
+)
+
+// newMemory is used to create new Memory instance.
+func newMemory(params *Parameters, logger logger.L) (*Memory, error) {
+	db := &Memory{
+		logger:         logger,
+		maxSecretSize:  params.maxSecretSize,
+		itemVersions:   params.itemVersions,
+		trashRetention: params.trashRetention,
+		quota:          params.quota,
+		watchers:       newRevisionWatchers(true),
+	}
+	db.reset()
+
+	return db, nil
+}
+
+// Connect does nothing and exists for compatibility with DB interface.
+func (db *Memory) Connect(ctx context.Context) error {
+	return nil
+}
+
+// Setup prepares empty storages for records.
+func (db *Memory) Setup(ctx context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.users == nil || db.items == nil {
+		db.reset()
+	}
+
+	db.logger.Info("in-memory storage is ready", "Memory:setup")
+
+	return nil
+}
+
+// ConnectAndSetup does same as sequentially calling Connect and Setup function.
+func (db *Memory) ConnectAndSetup(ctx context.Context) error {
+	if err := db.Connect(ctx); err != nil {
+		return err
+	}
+
+	return db.Setup(ctx)
+}
+
+// Close does nothing, because Memory has no connections. Records are kept.
+func (db *Memory) Close() error {
+	return nil
+}
+
+// Run is used for control database lifecycle.
+//
+// After context expired or cancel function Run closes channel.
+// If trash retention period is set Run periodically purges expired trashed items.
+// Run periodically approves requested emergency accesses with elapsed waiting period.
+func (db *Memory) Run(ctx context.Context, closeCh CloseChannel) {
+	componentName := "Memory:run"
+	db.logger.Info("DB is running", componentName)
+
+	var purgeCh <-chan time.Time
+
+	if db.trashRetention > 0 {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		purgeCh = ticker.C
+	}
+
+	approveTicker := time.NewTicker(emergencyApproveInterval)
+	defer approveTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			db.logger.Info("DB is stopped", componentName)
+			close(closeCh)
+
+			return
+		case <-purgeCh:
+			db.purgeTrash(time.Now().Add(-db.trashRetention), componentName)
+		case <-approveTicker.C:
+			db.approveEmergencyAccesses(time.Now(), componentName)
+		}
+	}
+}
+
+// WatchRevision returns channel, which receives new revisions of user's vault until context is done.
+func (db *Memory) WatchRevision(ctx context.Context, username Username) (<-chan int64, error) {
+	return db.watchers.subscribe(ctx, username)
+}
+
+// Clear is used to delete all records.
+func (db *Memory) Clear(ctx context.Context) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.reset()
+	db.logger.Info("all records are deleted", "Memory:clear")
+}
+
+// GetMaxSecretSize returns maximum available size of secret.
+func (db *Memory) GetMaxSecretSize() uint32 {
+	return db.maxSecretSize
+}
+
+// GetUserQuota returns per-user storage quota.
+func (db *Memory) GetUserQuota() Quota {
+	return db.quota
+}
+
+// reset is a helper function which drops all records. Caller must hold the lock.
+func (db *Memory) reset() {
+	db.users = make(map[Username]*memUser)
+	db.items = make(map[int64]*memItem)
+	db.lastUserID = 0
+	db.lastItemID = 0
+	db.folders = make(map[int64]*memFolder)
+	db.lastFolderID = 0
+	db.shares = make(map[int64]*pb.Share)
+	db.lastShareID = 0
+	db.orgs = make(map[int64]*memOrg)
+	db.lastOrgID = 0
+	db.emergency = make(map[int64]*pb.EmergencyAccess)
+	db.lastEmergencyID = 0
+	db.audit = nil
+	db.loginAttempts = make(map[string]*LoginAttempts)
+}
+
+// checkCtx is a helper function which returns provided database error stacked with
+// context's error, if context is already done.
+func checkCtx(ctx context.Context, dbErr error) error {
+	if err := ctx.Err(); err != nil {
+		return stackErrors(dbErr, err)
+	}
+
+	return nil
+}
+
+// errNoRowsAffected returns same error as Posgtre returns when batch statement doesn't
+// affect any row.
+func errNoRowsAffected() error {
+	return stackErrors(ErrOperationFailed, errors.New("no rows affected"))
+}
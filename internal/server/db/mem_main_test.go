@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemory is a helper function which creates Memory filled with testing users and
+// items. All testing items belong to testUser1.
+func newTestMemory(t *testing.T) *Memory {
+	t.Helper()
+
+	ctx := context.Background()
+
+	db, err := newMemory(&testDBConnParams, mocklogger.NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, db.ConnectAndSetup(ctx))
+
+	fillTestDB(t, db)
+
+	return db
+}
+
+func TestNewMemory(t *testing.T) {
+	db, err := newMemory(NewParameters("", "", "", 10000000), mocklogger.NewMockLogger())
+	require.NoError(t, err)
+	assert.NotEmpty(t, db)
+	assert.Equal(t, uint32(10000000), db.GetMaxSecretSize())
+}
+
+func TestMemory_ConnectAndSetupRun(t *testing.T) {
+	db, err := newMemory(&testDBConnParams, mocklogger.NewMockLogger())
+	require.NoError(t, err)
+
+	testCtx, cancel := context.WithCancel(context.Background())
+
+	err = db.ConnectAndSetup(testCtx)
+	require.NoError(t, err)
+
+	ch := make(chan struct{})
+	go db.Run(testCtx, ch)
+
+	cancel()
+	<-ch
+}
+
+func TestMemory_Clear(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMemory(t)
+
+	db.Clear(ctx)
+
+	_, err := db.GetUserByName(ctx, testUser1.Username)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	items, err := db.GetItemList(ctx, testUser1.Username)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
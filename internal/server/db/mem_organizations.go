@@ -0,0 +1,316 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memOrg represents organization's record in Memory.
+type memOrg struct {
+	org   *pb.Organization
+	vault Username
+	// Organization's members, indexed by username
+	members map[Username]*pb.Member
+}
+
+// CreateOrganization creates new organization with its vault, adds creator as organization's
+// administrator and returns organization's ID.
+func (db *Memory) CreateOrganization(ctx context.Context, creator Username, org *pb.Organization) (int64, error) {
+	if creator == "" {
+		return 0, ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return 0, err
+	}
+
+	if err := checkNewOrganization(org); err != nil {
+		return 0, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.users[creator]; !ok || db.isOrgVault(creator) {
+		return 0, errNoRowsAffected()
+	}
+
+	vault := newOrgVaultName()
+	if _, ok := db.users[vault]; ok {
+		return 0, stackErrors(ErrDuplicateEntry, fmt.Errorf("username %s", vault))
+	}
+
+	now := timestamppb.New(time.Now().Truncate(time.Second))
+	pwdhash := orgVaultPwdhash
+
+	db.lastUserID++
+	db.users[vault] = &memUser{
+		id:      db.lastUserID,
+		user:    &pb.User{Username: vault, Pwdhash: &pwdhash, Ekey: []byte{}, Updated: now, Regdate: now},
+		changes: make(map[int64]*ItemChange),
+	}
+
+	db.lastOrgID++
+	db.orgs[db.lastOrgID] = &memOrg{
+		org:   &pb.Organization{Id: db.lastOrgID, Name: org.Name, Created: now},
+		vault: vault,
+		members: map[Username]*pb.Member{
+			creator: {
+				Username: creator,
+				Role:     common.OrgRoleAdmin,
+				Accepted: true,
+				Okey:     append([]byte(nil), org.Okey...),
+			},
+		},
+	}
+
+	return db.lastOrgID, nil
+}
+
+// GetOrganizations returns user's organizations including not accepted invites.
+func (db *Memory) GetOrganizations(ctx context.Context, username Username) ([]*pb.Organization, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var orgs []*pb.Organization
+
+	for _, o := range db.orgs {
+		m, ok := o.members[username]
+		if !ok {
+			continue
+		}
+
+		org := proto.Clone(o.org).(*pb.Organization) //nolint:forcetypeassert
+		org.Role, org.Accepted, org.Okey = m.Role, m.Accepted, append([]byte(nil), m.Okey...)
+
+		orgs = append(orgs, org)
+	}
+
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Name < orgs[j].Name })
+
+	return orgs, nil
+}
+
+// UpdateOrganization renames organization.
+func (db *Memory) UpdateOrganization(ctx context.Context, org *pb.Organization) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	if err := checkOrganization(org); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	o, ok := db.orgs[org.Id]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	o.org.Name = org.Name
+
+	return nil
+}
+
+// DeleteOrganization deletes organization with its vault, members and items.
+func (db *Memory) DeleteOrganization(ctx context.Context, orgID int64) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	o, ok := db.orgs[orgID]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	if u, ok := db.users[o.vault]; ok {
+		db.deleteUser(u)
+	}
+
+	delete(db.orgs, orgID)
+
+	return nil
+}
+
+// GetMembers returns organization's members without keys.
+func (db *Memory) GetMembers(ctx context.Context, orgID int64) ([]*pb.Member, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var members []*pb.Member
+
+	if o, ok := db.orgs[orgID]; ok {
+		for _, m := range o.members {
+			members = append(members, &pb.Member{Username: m.Username, Role: m.Role, Accepted: m.Accepted})
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].Username < members[j].Username })
+
+	return members, nil
+}
+
+// GetMembership returns user's membership in organization.
+//
+// If user isn't organization's member GetMembership returns ErrNotFound.
+func (db *Memory) GetMembership(ctx context.Context, orgID int64, username Username) (*Membership, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	m, o := db.findMember(orgID, username)
+	if m == nil {
+		return nil, stackErrors(ErrNotFound, fmt.Errorf("member %s", username))
+	}
+
+	return &Membership{Role: m.Role, Accepted: m.Accepted, Vault: o.vault}, nil
+}
+
+// AddMember invites user to organization.
+//
+// Invited user becomes organization's member after accepting invite.
+func (db *Memory) AddMember(ctx context.Context, orgID int64, member *pb.Member) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	if err := checkMember(member); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	o, ok := db.orgs[orgID]
+	if !ok {
+		return errNoRowsAffected()
+	}
+
+	if _, ok := db.users[member.Username]; !ok || db.isOrgVault(member.Username) {
+		return errNoRowsAffected()
+	}
+
+	if _, ok := o.members[member.Username]; ok {
+		return stackErrors(ErrDuplicateEntry, fmt.Errorf("member %s", member.Username))
+	}
+
+	o.members[member.Username] = &pb.Member{
+		Username: member.Username,
+		Role:     member.Role,
+		Okey:     append([]byte(nil), member.Okey...),
+	}
+
+	return nil
+}
+
+// AcceptInvite accepts user's invite to organization.
+func (db *Memory) AcceptInvite(ctx context.Context, orgID int64, username Username) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	m, _ := db.findMember(orgID, username)
+	if m == nil {
+		return errNoRowsAffected()
+	}
+
+	m.Accepted = true
+
+	return nil
+}
+
+// UpdateMemberRole changes member's role.
+func (db *Memory) UpdateMemberRole(ctx context.Context, orgID int64, username Username, role string) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	if err := checkOrgRole(role); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	m, _ := db.findMember(orgID, username)
+	if m == nil {
+		return errNoRowsAffected()
+	}
+
+	m.Role = role
+
+	return nil
+}
+
+// RemoveMember removes member or not accepted invite from organization.
+func (db *Memory) RemoveMember(ctx context.Context, orgID int64, username Username) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	m, o := db.findMember(orgID, username)
+	if m == nil {
+		return errNoRowsAffected()
+	}
+
+	delete(o.members, username)
+
+	return nil
+}
+
+// findMember is a helper function which returns organization's member and organization,
+// if both exist. Caller must hold the lock.
+func (db *Memory) findMember(orgID int64, username Username) (*pb.Member, *memOrg) {
+	o, ok := db.orgs[orgID]
+	if !ok {
+		return nil, nil
+	}
+
+	m, ok := o.members[username]
+	if !ok {
+		return nil, nil
+	}
+
+	return m, o
+}
+
+// isOrgVault is a helper function which checks whether user is organization's vault.
+// Caller must hold the lock.
+func (db *Memory) isOrgVault(username Username) bool {
+	for _, o := range db.orgs {
+		if o.vault == username {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestMemory_Organizations(t *testing.T) {
+	testOrganizations(t, newTestMemory(t))
+}
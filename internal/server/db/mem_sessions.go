@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateSession records user's session of issued token and deletes user's expired sessions.
+//
+// If no users were found CreateSession returns error (ErrNotFound).
+func (db *Memory) CreateSession(ctx context.Context, username Username, session *pb.Session) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	now := time.Now()
+
+	if u.sessions == nil {
+		u.sessions = make(map[string]*pb.Session)
+	}
+
+	for id, s := range u.sessions {
+		if !s.GetExpires().AsTime().After(now) {
+			delete(u.sessions, id)
+		}
+	}
+
+	if _, ok := u.sessions[session.Id]; ok {
+		return stackErrors(ErrDuplicateEntry, errors.New(session.Id))
+	}
+
+	recorded := proto.Clone(session).(*pb.Session) //nolint:forcetypeassert
+	recorded.Current = false
+
+	if recorded.Created == nil {
+		recorded.Created = timestamppb.New(now.Truncate(time.Second))
+	}
+
+	recorded.LastSeen = recorded.Created
+	u.sessions[session.Id] = recorded
+
+	return nil
+}
+
+// TouchSession updates last seen time and client's address of user's active session.
+//
+// If session is revoked or expired TouchSession returns error (ErrNotFound).
+func (db *Memory) TouchSession(ctx context.Context, username Username, id string, clientIP string) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+
+	session := db.userSession(username, id)
+	if session == nil || !session.GetExpires().AsTime().After(now) {
+		return stackErrors(ErrNotFound, errors.New(id))
+	}
+
+	session.LastSeen = timestamppb.New(now.Truncate(time.Second))
+	session.ClientIp = clientIP
+
+	return nil
+}
+
+// GetSessions returns user's active sessions, last seen first.
+func (db *Memory) GetSessions(ctx context.Context, username Username) ([]*pb.Session, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	sessions := make([]*pb.Session, 0)
+	now := time.Now()
+
+	if u, ok := db.users[username]; ok {
+		for _, s := range u.sessions {
+			if s.GetExpires().AsTime().After(now) {
+				session := proto.Clone(s).(*pb.Session) //nolint:forcetypeassert
+				session.RefreshHash = ""
+				sessions = append(sessions, session)
+			}
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].LastSeen.AsTime().Equal(sessions[j].LastSeen.AsTime()) {
+			return sessions[i].LastSeen.AsTime().After(sessions[j].LastSeen.AsTime())
+		}
+
+		return sessions[i].Created.AsTime().After(sessions[j].Created.AsTime())
+	})
+
+	return sessions, nil
+}
+
+// GetSessionByRefreshHash returns user's active session with provided refresh token's hash.
+//
+// If there is no such session GetSessionByRefreshHash returns error (ErrNotFound).
+func (db *Memory) GetSessionByRefreshHash(ctx context.Context, username Username,
+	refreshHash string) (*pb.Session, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	now := time.Now()
+
+	if u, ok := db.users[username]; ok && refreshHash != "" {
+		for _, s := range u.sessions {
+			if s.RefreshHash == refreshHash && s.GetExpires().AsTime().After(now) {
+				return proto.Clone(s).(*pb.Session), nil //nolint:forcetypeassert
+			}
+		}
+	}
+
+	return nil, stackErrors(ErrNotFound, errors.New("refresh token"))
+}
+
+// RotateSession replaces ID, refresh token's hash and expiration time of user's active session,
+// last seen time and client's address are updated too.
+//
+// If session is revoked or expired RotateSession returns error (ErrNotFound).
+func (db *Memory) RotateSession(ctx context.Context, username Username, id string, session *pb.Session) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+
+	rotated := db.userSession(username, id)
+	if rotated == nil || !rotated.GetExpires().AsTime().After(now) {
+		return stackErrors(ErrNotFound, errors.New(id))
+	}
+
+	if _, ok := db.users[username].sessions[session.Id]; ok && session.Id != id {
+		return stackErrors(ErrDuplicateEntry, errors.New(session.Id))
+	}
+
+	delete(db.users[username].sessions, id)
+
+	rotated.Id = session.Id
+	rotated.RefreshHash = session.RefreshHash
+	rotated.Expires = session.Expires
+	rotated.LastSeen = timestamppb.New(now.Truncate(time.Second))
+	rotated.ClientIp = session.ClientIp
+	db.users[username].sessions[rotated.Id] = rotated
+
+	return nil
+}
+
+// DeleteSession deletes user's session, so session's token is revoked.
+//
+// If session doesn't exist DeleteSession returns error (ErrNotFound).
+func (db *Memory) DeleteSession(ctx context.Context, username Username, id string) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.userSession(username, id) == nil {
+		return stackErrors(ErrNotFound, errors.New(id))
+	}
+
+	delete(db.users[username].sessions, id)
+
+	return nil
+}
+
+// DeleteSessions deletes all user's sessions, so all user's tokens are revoked.
+func (db *Memory) DeleteSessions(ctx context.Context, username Username) error {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if u, ok := db.users[username]; ok {
+		u.sessions = nil
+	}
+
+	return nil
+}
+
+// userSession is a helper function, which returns user's session or nil if session doesn't exist.
+// Caller must hold the lock.
+func (db *Memory) userSession(username Username, id string) *pb.Session {
+	u, ok := db.users[username]
+	if !ok {
+		return nil
+	}
+
+	return u.sessions[id]
+}
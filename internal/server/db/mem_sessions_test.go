@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestMemory_Sessions(t *testing.T) {
+	testSessions(t, newTestMemory(t))
+}
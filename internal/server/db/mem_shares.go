@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ShareItem shares owner's item with recipient and returns share's ID.
+//
+// If item is already shared with recipient, share is replaced.
+// Share's data is counted in owner's usage, if owner's quota of stored data is exceeded
+// returns ErrQuotaExceeded.
+func (db *Memory) ShareItem(ctx context.Context, owner Username, share *pb.Share) (int64, error) {
+	if owner == "" {
+		return 0, ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return 0, err
+	}
+
+	if err := checkNewShare(share); err != nil {
+		return 0, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	o, ok := db.users[owner]
+	if !ok {
+		return 0, errNoRowsAffected()
+	}
+
+	r, ok := db.users[share.Recipient]
+	if !ok || r.id == o.id {
+		return 0, errNoRowsAffected()
+	}
+
+	if i, ok := db.items[share.ItemId]; !ok || i.userID != o.id {
+		return 0, errNoRowsAffected()
+	}
+
+	s := db.findShare(share.ItemId, share.Recipient)
+
+	usage := db.userUsage(o.id)
+	usage.Bytes += int64(len(share.Data) - len(s.GetData()))
+
+	if err := db.quota.check(usage, false); err != nil {
+		return 0, err
+	}
+
+	if s == nil {
+		db.lastShareID++
+		s = &pb.Share{Id: db.lastShareID, ItemId: share.ItemId, Owner: owner, Recipient: share.Recipient}
+		db.shares[s.Id] = s
+	}
+
+	s.Skey = append([]byte(nil), share.Skey...)
+	s.Data = append([]byte(nil), share.Data...)
+	s.Updated = timestamppb.New(time.Now().Truncate(time.Second))
+
+	return s.Id, nil
+}
+
+// GetReceivedShares returns items shared with user.
+//
+// Shares of trashed items are skipped.
+func (db *Memory) GetReceivedShares(ctx context.Context, username Username) ([]*pb.Share, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.selectShares(func(s *pb.Share) bool {
+		i, ok := db.items[s.ItemId]
+
+		return ok && s.Recipient == username && i.deleted == nil
+	}, true), nil
+}
+
+// GetSentShares returns shares of user's items without keys and data.
+func (db *Memory) GetSentShares(ctx context.Context, username Username) ([]*pb.Share, error) {
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.selectShares(func(s *pb.Share) bool { return s.Owner == username }, false), nil
+}
+
+// RevokeShare deletes share of owner's item.
+func (db *Memory) RevokeShare(ctx context.Context, owner Username, shareID int64) error {
+	if owner == "" {
+		return ErrNotFound
+	}
+
+	if err := checkCtx(ctx, ErrTransactionFailed); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	s, ok := db.shares[shareID]
+	if !ok || s.Owner != owner {
+		return errNoRowsAffected()
+	}
+
+	delete(db.shares, shareID)
+
+	return nil
+}
+
+// findShare is a helper function which returns share of item with recipient, if exists.
+// Caller must hold the lock.
+func (db *Memory) findShare(itemID int64, recipient Username) *pb.Share {
+	for _, s := range db.shares {
+		if s.ItemId == itemID && s.Recipient == recipient {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// selectShares is a helper function which returns copies of shares matched by filter sorted by ID.
+// Keys and data are returned only if withData is set. Caller must hold the lock.
+func (db *Memory) selectShares(filter func(*pb.Share) bool, withData bool) []*pb.Share {
+	var shares []*pb.Share
+
+	for _, s := range db.shares {
+		if !filter(s) {
+			continue
+		}
+
+		share := proto.Clone(s).(*pb.Share) //nolint:forcetypeassert
+		if !withData {
+			share.Skey, share.Data = nil, nil
+		}
+
+		shares = append(shares, share)
+	}
+
+	sort.Slice(shares, func(i, j int) bool { return shares[i].Id < shares[j].Id })
+
+	return shares
+}
+
+// deleteShares is a helper function which deletes shares matched by filter.
+// Caller must hold the lock.
+func (db *Memory) deleteShares(filter func(*pb.Share) bool) {
+	for id, s := range db.shares {
+		if filter(s) {
+			delete(db.shares, id)
+		}
+	}
+}
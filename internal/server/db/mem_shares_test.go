@@ -0,0 +1,11 @@
+package db
+
+import "testing"
+
+func TestMemory_Shares(t *testing.T) {
+	testShares(t, newTestMemory(t))
+}
+
+func TestMemory_UserKeys(t *testing.T) {
+	testUserKeys(t, newTestMemory(t))
+}
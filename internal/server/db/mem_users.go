@@ -0,0 +1,399 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateUser creates new user.
+//
+// CreateUser generates regdate and updated fields during creation.
+// In case of error during creation returns error, returns nil error only on successfully creation.
+func (db *Memory) CreateUser(ctx context.Context, user *pb.User) error {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return err
+	}
+
+	if err := memValidateUser(user); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.users[user.Username]; ok {
+		return stackErrors(ErrDuplicateEntry, fmt.Errorf("username %s", user.Username))
+	}
+
+	if err := db.checkEmailUnique(user.Username, user.Email); err != nil {
+		return err
+	}
+
+	now := timestamppb.New(time.Now().Truncate(time.Second))
+
+	newUser := &pb.User{
+		Username:   user.Username,
+		Email:      user.Email,
+		Pwdhash:    user.Pwdhash,
+		OtpKey:     user.OtpKey,
+		Ekey:       user.Ekey,
+		PublicKey:  user.PublicKey,
+		PrivateKey: user.PrivateKey,
+		Updated:    now,
+		Regdate:    now,
+	}
+
+	db.lastUserID++
+	db.users[user.Username] = &memUser{
+		id:      db.lastUserID,
+		user:    proto.Clone(newUser).(*pb.User), //nolint:forcetypeassert
+		changes: make(map[int64]*ItemChange),
+	}
+
+	return nil
+}
+
+// GetUserByName returns user data by provided user login.
+//
+// If no users were found GetUserByName returns nil and error (ErrNotFound).
+func (db *Memory) GetUserByName(ctx context.Context, username Username) (*pb.User, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return proto.Clone(u.user).(*pb.User), nil //nolint:forcetypeassert
+}
+
+// GetUserAuthData returns password hash and OTP secret key for particular user.
+//
+// If no users were found GetUserAuthData returns empty strings and error (ErrNotFound).
+func (db *Memory) GetUserAuthData(ctx context.Context, username Username) (Password, OTPKey, error) {
+	none := ""
+
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return none, none, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return none, none, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return u.user.GetPwdhash(), u.user.GetOtpKey(), nil
+}
+
+// GetUserEKey returns user encryption key.
+//
+// If no users were found GetUserEKey returns nil and error (ErrNotFound).
+func (db *Memory) GetUserEKey(ctx context.Context, username Username) ([]byte, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return append([]byte(nil), u.user.Ekey...), nil
+}
+
+// GetUserKeys returns user's public key and private key, encrypted with user's encryption key.
+//
+// If user hasn't key pair GetUserKeys returns nil keys and nil error.
+// If no users were found GetUserKeys returns nil keys and error (ErrNotFound).
+func (db *Memory) GetUserKeys(ctx context.Context, username Username) ([]byte, []byte, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return append([]byte(nil), u.user.PublicKey...), append([]byte(nil), u.user.PrivateKey...), nil
+}
+
+// GetUserRevision returns configuration revision for particular user.
+//
+// If no users were found GetUserRevision returns zero revision and error (ErrNotFound).
+func (db *Memory) GetUserRevision(ctx context.Context, username Username) (int64, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return 0, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return 0, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return u.user.GetRevision(), nil
+}
+
+// UpdateUser updates current user information.
+//
+// Empty fields are ignored. Username and regdate cannot be updated.
+func (db *Memory) UpdateUser(ctx context.Context, user *pb.User) error {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return err
+	}
+
+	if user.Email != nil && !memRegexEmail.MatchString(*user.Email) {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("email %s", *user.Email))
+	}
+
+	if user.Pwdhash != nil && *user.Pwdhash == "" {
+		return stackErrors(ErrConstraintViolation, errors.New("empty password hash"))
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[user.Username]
+	if !ok {
+		return stackErrors(ErrNotFound, errors.New(user.Username))
+	}
+
+	if err := db.checkEmailUnique(user.Username, user.Email); err != nil {
+		return err
+	}
+
+	if user.Email != nil {
+		u.user.Email = user.Email
+	}
+
+	if user.Pwdhash != nil {
+		u.user.Pwdhash = user.Pwdhash
+	}
+
+	if user.OtpKey != nil {
+		u.user.OtpKey = user.OtpKey
+	}
+
+	if user.Ekey != nil {
+		u.user.Ekey = append([]byte(nil), user.Ekey...)
+	}
+
+	if user.PublicKey != nil {
+		u.user.PublicKey = append([]byte(nil), user.PublicKey...)
+	}
+
+	if user.PrivateKey != nil {
+		u.user.PrivateKey = append([]byte(nil), user.PrivateKey...)
+	}
+
+	u.user.Updated = timestamppb.New(time.Now().Truncate(time.Second))
+
+	return nil
+}
+
+// UpdateUserSecrets updates user's password hash and encryption key.
+//
+// Both password hash and encryption key are required.
+// All tokens issued to user before update are revoked.
+func (db *Memory) UpdateUserSecrets(ctx context.Context, user *pb.User) error {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return err
+	}
+
+	if user == nil || user.GetPwdhash() == "" || len(user.Ekey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("password hash and encryption key are required"))
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[user.Username]
+	if !ok {
+		return stackErrors(ErrNotFound, errors.New(user.Username))
+	}
+
+	now := time.Now()
+
+	u.user.Pwdhash = common.PtrTo(user.GetPwdhash())
+	u.user.Ekey = append([]byte(nil), user.Ekey...)
+	u.user.Updated = timestamppb.New(now.Truncate(time.Second))
+	u.tokensRevoked = now
+
+	return nil
+}
+
+// GetUserTokensRevoked returns time, before which all user's tokens are revoked.
+//
+// If user's tokens were never revoked GetUserTokensRevoked returns zero time.
+// If no users were found GetUserTokensRevoked returns error (ErrNotFound).
+func (db *Memory) GetUserTokensRevoked(ctx context.Context, username Username) (time.Time, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return time.Time{}, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return time.Time{}, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return u.tokensRevoked, nil
+}
+
+// GetUserUsage returns user's current storage usage, trashed items are included.
+func (db *Memory) GetUserUsage(ctx context.Context, username Username) (*Usage, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return new(Usage), nil
+	}
+
+	return db.userUsage(u.id), nil
+}
+
+// GetUserState returns user's administrator role and lock state.
+//
+// If no users were found GetUserState returns error (ErrNotFound).
+func (db *Memory) GetUserState(ctx context.Context, username Username) (*UserState, error) {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		return nil, stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return &UserState{IsAdmin: u.isAdmin, Locked: u.locked}, nil
+}
+
+// DeleteUserByName deletes user by username and all user's items. Organizations' vaults are not deleted.
+//
+// In case of error during deletion DeleteUserByName returns error,
+// returns nil error only on successfully deletion.
+func (db *Memory) DeleteUserByName(ctx context.Context, username Username) error {
+	if err := checkCtx(ctx, ErrUndefinedError); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok || db.isOrgVault(username) {
+		return stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	db.deleteUser(u)
+
+	return nil
+}
+
+// deleteUser is a helper function which deletes user with all user's items, folders, shares
+// and memberships. Caller must hold the lock.
+func (db *Memory) deleteUser(u *memUser) {
+	username := u.user.Username
+
+	for id, item := range db.items {
+		if item.userID == u.id {
+			delete(db.items, id)
+		}
+	}
+
+	for id, folder := range db.folders {
+		if folder.userID == u.id {
+			delete(db.folders, id)
+		}
+	}
+
+	db.deleteShares(func(s *pb.Share) bool { return s.Owner == username || s.Recipient == username })
+
+	for id, o := range db.orgs {
+		if o.vault == username {
+			delete(db.orgs, id)
+			continue
+		}
+
+		delete(o.members, username)
+	}
+
+	for id, a := range db.emergency {
+		if a.Grantor == username || a.Grantee == username {
+			delete(db.emergency, id)
+		}
+	}
+
+	delete(db.users, username)
+}
+
+// checkEmailUnique is a helper function which checks that email is not used by another user.
+// Caller must hold the lock.
+func (db *Memory) checkEmailUnique(username Username, email *string) error {
+	if email == nil {
+		return nil
+	}
+
+	for _, u := range db.users {
+		if u.user.Username != username && u.user.Email != nil && *u.user.Email == *email {
+			return stackErrors(ErrDuplicateEntry, fmt.Errorf("email %s", *email))
+		}
+	}
+
+	return nil
+}
+
+// memValidateUser is a helper function which checks new user's fields constraints.
+func memValidateUser(user *pb.User) error {
+	if len(user.Username) > memMaxUsernameLen || !memRegexUsername.MatchString(user.Username) {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("username '%s'", user.Username))
+	}
+
+	if user.Email != nil && !memRegexEmail.MatchString(*user.Email) {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("email %s", *user.Email))
+	}
+
+	if user.Pwdhash == nil || *user.Pwdhash == "" {
+		return stackErrors(ErrConstraintViolation, errors.New("empty password hash"))
+	}
+
+	if user.Ekey == nil {
+		return stackErrors(ErrConstraintViolation, errors.New("empty encryption key"))
+	}
+
+	return nil
+}
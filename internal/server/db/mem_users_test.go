@@ -0,0 +1,267 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_CreateUser(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	db := newTestMemory(t)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		user    *pb.User
+		wantErr bool
+		err     error
+	}{
+		{
+			name: "Create new user",
+			ctx:  context.Background(),
+			user: &pb.User{
+				Username: "newuser",
+				Email:    common.PtrTo("newuser@example.com"),
+				Pwdhash:  common.PtrTo("pwdhash"),
+				Ekey:     []byte("ekey"),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Duplicate username",
+			ctx:     context.Background(),
+			user:    testUser1,
+			wantErr: true,
+			err:     ErrDuplicateEntry,
+		},
+		{
+			name: "Duplicate email",
+			ctx:  context.Background(),
+			user: &pb.User{
+				Username: "anotheruser",
+				Email:    testUser1.Email,
+				Pwdhash:  common.PtrTo("pwdhash"),
+				Ekey:     []byte("ekey"),
+			},
+			wantErr: true,
+			err:     ErrDuplicateEntry,
+		},
+		{
+			name: "Wrong username",
+			ctx:  context.Background(),
+			user: &pb.User{
+				Username: "wrong user",
+				Pwdhash:  common.PtrTo("pwdhash"),
+				Ekey:     []byte("ekey"),
+			},
+			wantErr: true,
+			err:     ErrConstraintViolation,
+		},
+		{
+			name: "Wrong email",
+			ctx:  context.Background(),
+			user: &pb.User{
+				Username: "wrongemail",
+				Email:    common.PtrTo("wrongemail"),
+				Pwdhash:  common.PtrTo("pwdhash"),
+				Ekey:     []byte("ekey"),
+			},
+			wantErr: true,
+			err:     ErrConstraintViolation,
+		},
+		{
+			name: "Missed password hash",
+			ctx:  context.Background(),
+			user: &pb.User{
+				Username: "nopwd",
+				Ekey:     []byte("ekey"),
+			},
+			wantErr: true,
+			err:     ErrConstraintViolation,
+		},
+		{
+			name: "Canceled context",
+			ctx:  canceledCtx,
+			user: &pb.User{
+				Username: "canceled",
+				Pwdhash:  common.PtrTo("pwdhash"),
+				Ekey:     []byte("ekey"),
+			},
+			wantErr: true,
+			err:     ErrUndefinedError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := db.CreateUser(tt.ctx, tt.user)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			user, err := db.GetUserByName(context.Background(), tt.user.Username)
+			require.NoError(t, err)
+			assert.Equal(t, tt.user.Email, user.Email)
+			assert.NotNil(t, user.Regdate)
+			assert.NotNil(t, user.Updated)
+		})
+	}
+}
+
+func TestMemory_GetUserData(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMemory(t)
+
+	pwd, otp, err := db.GetUserAuthData(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, *testUser1.Pwdhash, pwd)
+	assert.Equal(t, *testUser1.OtpKey, otp)
+
+	ekey, err := db.GetUserEKey(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, testUser1.Ekey, ekey)
+
+	revision, err := db.GetUserRevision(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.NotEmpty(t, revision)
+
+	revision, err = db.GetUserRevision(ctx, testUser2.Username)
+	require.NoError(t, err)
+	assert.Empty(t, revision)
+
+	_, _, err = db.GetUserAuthData(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = db.GetUserEKey(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = db.GetUserRevision(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemory_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMemory(t)
+
+	t.Run("Update email", func(t *testing.T) {
+		err := db.UpdateUser(ctx, &pb.User{
+			Username: testUser2.Username,
+			Email:    common.PtrTo("updated@example.com"),
+		})
+		require.NoError(t, err)
+
+		user, err := db.GetUserByName(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Equal(t, "updated@example.com", user.GetEmail())
+		assert.Equal(t, testUser2.GetPwdhash(), user.GetPwdhash())
+	})
+
+	t.Run("Duplicate email", func(t *testing.T) {
+		err := db.UpdateUser(ctx, &pb.User{
+			Username: testUser2.Username,
+			Email:    testUser1.Email,
+		})
+		assert.ErrorIs(t, err, ErrDuplicateEntry)
+	})
+
+	t.Run("Unexisting user", func(t *testing.T) {
+		err := db.UpdateUser(ctx, &pb.User{
+			Username: "unknown",
+			Email:    common.PtrTo("unknown@example.com"),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestMemory_UpdateUserSecrets(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMemory(t)
+
+	t.Run("Tokens are not revoked", func(t *testing.T) {
+		revoked, err := db.GetUserTokensRevoked(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.True(t, revoked.IsZero())
+	})
+
+	t.Run("Update secrets", func(t *testing.T) {
+		before := time.Now().Add(-time.Second)
+
+		err := db.UpdateUserSecrets(ctx, &pb.User{
+			Username: testUser2.Username,
+			Pwdhash:  common.PtrTo("newpwdhash"),
+			Ekey:     []byte("newekey"),
+		})
+		require.NoError(t, err)
+
+		pwd, _, err := db.GetUserAuthData(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Equal(t, "newpwdhash", pwd)
+
+		ekey, err := db.GetUserEKey(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("newekey"), ekey)
+
+		revoked, err := db.GetUserTokensRevoked(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.True(t, revoked.After(before))
+	})
+
+	t.Run("Missed encryption key", func(t *testing.T) {
+		err := db.UpdateUserSecrets(ctx, &pb.User{
+			Username: testUser2.Username,
+			Pwdhash:  common.PtrTo("newpwdhash"),
+		})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+	})
+
+	t.Run("Unexisting user", func(t *testing.T) {
+		err := db.UpdateUserSecrets(ctx, &pb.User{
+			Username: "unknown",
+			Pwdhash:  common.PtrTo("newpwdhash"),
+			Ekey:     []byte("newekey"),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = db.GetUserTokensRevoked(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestMemory_DeleteUserByName(t *testing.T) {
+	ctx := context.Background()
+	db := newTestMemory(t)
+
+	err := db.DeleteUserByName(ctx, testUser1.Username)
+	require.NoError(t, err)
+
+	_, err = db.GetUserByName(ctx, testUser1.Username)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, db.items)
+
+	err = db.DeleteUserByName(ctx, testUser1.Username)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	t.Run("Organization's vault", func(t *testing.T) {
+		orgID, err := db.CreateOrganization(ctx, testUser2.Username, &pb.Organization{Name: "team", Okey: []byte("okey")})
+		require.NoError(t, err)
+		membership, err := db.GetMembership(ctx, orgID, testUser2.Username)
+		require.NoError(t, err)
+		require.NoError(t, db.CreateItem(ctx, membership.Vault, &pb.Item{Name: "org item", Type: common.ItemTypeSecNote}))
+
+		err = db.DeleteUserByName(ctx, membership.Vault)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		items, err := db.GetItemList(ctx, membership.Vault)
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+	})
+}
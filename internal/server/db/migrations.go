@@ -0,0 +1,116 @@
+package db
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"text/template"
+)
+
+// Directories with embedded migrations for every supported SQL database.
+const (
+	migrationsDirPostgres = "migrations/postgres"
+	migrationsDirSQLite   = "migrations/sqlite"
+)
+
+// migrationsTable is a name of table, which keeps applied migrations' versions.
+const migrationsTable = "schema_migrations"
+
+// Embedded migrations' files.
+//
+// Migration file must be named as <version>_<name>.up.sql, where version is a positive
+// number, which defines order of migrations. Applied migrations must never be changed,
+// every schema change must be added as new migration.
+//
+//go:embed migrations
+var migrationsFS embed.FS //nolint:gochecknoglobals
+
+// regexMigrationFile is used to parse migration's version and name from file name.
+var regexMigrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.up\.sql$`) //nolint:gochecknoglobals
+
+// Migration represents single versioned up-migration of database schema.
+type Migration struct {
+	Version   int64        // migration version, defines order of migrations
+	Name      string       // migration name
+	Statement SQLStatement // SQL statements of migration
+}
+
+// migrationParams contains parameters, which can be used in migrations' templates.
+type migrationParams struct {
+	MaxSecretLength uint32 // maximum length of secret's field in bytes
+	RegexUsername   string // username constraint
+	RegexEmail      string // email constraint
+}
+
+// loadMigrations reads migrations from directory, sorted by version.
+//
+// Migration's statements are executed as text/template with migrationParams.
+func loadMigrations(fsys fs.FS, dir string, params *Parameters) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tmplParams := migrationParams{
+		MaxSecretLength: params.maxSecretSize + 4,
+		RegexUsername:   FRegexUsername,
+		RegexEmail:      FRegexEmail,
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	versions := make(map[int64]string, len(entries))
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		match := regexMigrationFile.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("wrong migration file name: %s", e.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("wrong migration version: %s", e.Name())
+		}
+
+		if dup, ok := versions[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s, %s", version, dup, e.Name())
+		}
+
+		versions[version] = e.Name()
+
+		raw, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		tmpl, err := template.New(e.Name()).Parse(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", e.Name(), err)
+		}
+
+		stmt := new(bytes.Buffer)
+		if err := tmpl.Execute(stmt, tmplParams); err != nil {
+			return nil, fmt.Errorf("migration %s: %w", e.Name(), err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version:   version,
+			Name:      match[2],
+			Statement: stmt.String(),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
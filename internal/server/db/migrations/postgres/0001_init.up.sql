@@ -0,0 +1,47 @@
+-- Initial schema.
+--
+-- Statements are idempotent, so migration can be safely applied to databases
+-- created before versioned migrations were introduced.
+--
+-- item_types constrains:
+--   - l - login item
+--   - c - card item
+--   - n - secured note item
+--   - d - secured data item
+
+create table if not exists users (
+	id int generated always as identity primary key,
+	username varchar(50) not null unique check (username ~ '{{ .RegexUsername }}'),
+	email varchar unique check (email ~ '{{ .RegexEmail }}'),
+	pwdhash varchar not null check (pwdhash <> ''),
+	otpkey varchar,
+	ekey bytea not null,
+	revision bytea,
+	updated timestamptz,
+	regdate timestamptz
+);
+
+create table if not exists items (
+	id int generated always as identity primary key,
+	user_id integer not null references users (id) on delete cascade,
+	name varchar(128) check (name <> ''),
+	type char(1) not null check(type in ('l', 'c', 'n', 'd')),
+	reprompt boolean,
+	updated timestamptz,
+	hash bytea,
+	unique (user_id, name, type)
+);
+
+create table if not exists secrets (
+	id int generated always as identity primary key,
+	item_id integer not null unique references items (id) on delete cascade,
+	notes bytea,
+	secret bytea check (length(secret) <= {{ .MaxSecretLength }})
+);
+
+create table if not exists additions (
+	id int generated always as identity primary key,
+	item_id integer not null unique references items (id) on delete cascade,
+	uris bytea,
+	custom_fields bytea
+);
@@ -0,0 +1,19 @@
+-- Items' version history.
+--
+-- Every item's update stores previous state of item as new version. Secrets are
+-- kept encrypted same way as in secrets and additions tables.
+
+create table if not exists item_versions (
+	id int generated always as identity primary key,
+	item_id integer not null references items (id) on delete cascade,
+	version integer not null,
+	name varchar(128),
+	reprompt boolean,
+	updated timestamptz,
+	hash bytea,
+	notes bytea,
+	secret bytea,
+	uris bytea,
+	custom_fields bytea,
+	unique (item_id, version)
+);
@@ -0,0 +1,9 @@
+-- Items' trash bin.
+--
+-- Deleted items are marked with deletion time and purged after retention period.
+-- Trashed item keeps its name, so new item with same name and type can't be
+-- created until trashed item is restored or purged.
+
+alter table items add column if not exists deleted_at timestamptz;
+
+create index if not exists items_deleted_at_idx on items (deleted_at) where deleted_at is not null;
@@ -0,0 +1,6 @@
+-- Users' tokens revocation.
+--
+-- All tokens issued to user before this time are considered as invalid,
+-- column is updated on every change of user's secrets.
+
+alter table users add column if not exists tokens_revoked timestamptz;
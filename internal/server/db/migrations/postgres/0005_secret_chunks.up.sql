@@ -0,0 +1,11 @@
+-- Data items' content, stored as sequence of chunks.
+--
+-- Every chunk is encrypted by client separately, so data can be uploaded and
+-- downloaded by streams without loading whole file in memory.
+
+create table if not exists secret_chunks (
+	item_id integer not null references items (id) on delete cascade,
+	seq integer not null,
+	data bytea not null,
+	primary key (item_id, seq)
+);
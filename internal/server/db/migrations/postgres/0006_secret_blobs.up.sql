@@ -0,0 +1,11 @@
+-- References from secrets to blob store.
+--
+-- Large secrets are stored outside database in content-addressed blob store,
+-- in this case secret is null and row keeps blob's key (hash of encrypted
+-- secret) and size, which is counted in user's quota.
+
+alter table secrets add column if not exists blob_key varchar(64);
+alter table secrets add column if not exists blob_size integer not null default 0;
+
+alter table item_versions add column if not exists blob_key varchar(64);
+alter table item_versions add column if not exists blob_size integer not null default 0;
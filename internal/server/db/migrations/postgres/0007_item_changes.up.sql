@@ -0,0 +1,28 @@
+-- Vault revision number and log of items' changes.
+--
+-- Opaque revision hash is replaced with revision number, which is increased on
+-- every change of user's items. Log keeps only last change of every item
+-- (including purged items), so client can request IDs of items changed since
+-- known revision instead of comparing all items. Existing items are logged as
+-- changed in first revision, so clients with previous revision perform full
+-- synchronization.
+
+alter table users drop column if exists revision;
+alter table users add column if not exists revision bigint not null default 0;
+
+update users set revision = 1 where exists (select 1 from items where items.user_id = users.id);
+
+create table if not exists item_changes (
+	user_id integer not null references users (id) on delete cascade,
+	item_id integer not null,
+	created_revision bigint not null,
+	revision bigint not null,
+	deleted boolean not null default false,
+	primary key (user_id, item_id)
+);
+
+create index if not exists item_changes_revision_idx on item_changes (user_id, revision);
+
+insert into item_changes (user_id, item_id, created_revision, revision, deleted)
+	select user_id, id, 0, 1, false from items
+	on conflict do nothing;
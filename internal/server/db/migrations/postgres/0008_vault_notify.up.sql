@@ -0,0 +1,20 @@
+-- Notifications about new vaults' revisions.
+--
+-- Every change of user's vault revision is sent to vault_revision channel with payload
+-- "<revision>:<username>", so server instances can push changes to watching clients.
+-- Notifications are delivered only after transaction is committed.
+
+create or replace function notify_vault_revision() returns trigger as $$
+begin
+	perform pg_notify('vault_revision', new.revision || ':' || new.username);
+	return null;
+end;
+$$ language plpgsql;
+
+drop trigger if exists users_vault_revision_notify on users;
+
+create trigger users_vault_revision_notify
+	after update of revision on users
+	for each row
+	when (old.revision is distinct from new.revision)
+	execute procedure notify_vault_revision();
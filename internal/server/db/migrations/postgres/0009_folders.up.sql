@@ -0,0 +1,17 @@
+-- Folders for organizing items.
+--
+-- Folder's name is encrypted by client, so names are not unique. Items of
+-- deleted folder are kept without folder.
+
+create table if not exists folders (
+	id int generated always as identity primary key,
+	user_id integer not null references users (id) on delete cascade,
+	name bytea not null check (length(name) > 0),
+	updated timestamptz
+);
+
+create index if not exists folders_user_id_idx on folders (user_id);
+
+alter table items add column if not exists folder_id integer references folders (id) on delete set null;
+
+create index if not exists items_folder_id_idx on items (folder_id) where folder_id is not null;
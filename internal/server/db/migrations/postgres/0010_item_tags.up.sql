@@ -0,0 +1,7 @@
+-- Item's tags.
+--
+-- Tags are encrypted by client as single value, same way as item's additions.
+
+alter table additions add column if not exists tags bytea;
+
+alter table item_versions add column if not exists tags bytea;
@@ -0,0 +1,22 @@
+-- Sharing of items between users.
+--
+-- Every user has X25519 key pair, private key is encrypted by client with user's
+-- encryption key. Shared item is stored as copy, encrypted with share's key, which
+-- is encrypted with recipient's public key. Item can be shared with user only once.
+
+alter table users add column if not exists public_key bytea;
+
+alter table users add column if not exists private_key bytea;
+
+create table if not exists item_shares (
+	id int generated always as identity primary key,
+	item_id integer not null references items (id) on delete cascade,
+	recipient_id integer not null references users (id) on delete cascade,
+	editable boolean not null default false,
+	skey bytea not null check (length(skey) > 0),
+	data bytea not null check (length(data) > 0),
+	updated timestamptz,
+	unique (item_id, recipient_id)
+);
+
+create index if not exists item_shares_recipient_id_idx on item_shares (recipient_id);
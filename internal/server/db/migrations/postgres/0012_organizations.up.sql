@@ -0,0 +1,25 @@
+-- Organizations with shared vaults.
+--
+-- Organization's items are owned by organization's vault, which is a user's record
+-- without credentials, so vault, its items and organization are deleted at once.
+-- Organization's key is encrypted by client with every member's public key. Invited
+-- user becomes member after accepting invite.
+
+create table if not exists organizations (
+	id int generated always as identity primary key,
+	name varchar(128) not null check (name <> ''),
+	vault_id integer not null unique references users (id) on delete cascade,
+	created timestamptz
+);
+
+create table if not exists org_members (
+	id int generated always as identity primary key,
+	org_id integer not null references organizations (id) on delete cascade,
+	user_id integer not null references users (id) on delete cascade,
+	role varchar(16) not null check (role in ('admin', 'member', 'viewer')),
+	okey bytea not null check (length(okey) > 0),
+	accepted boolean not null default false,
+	unique (org_id, user_id)
+);
+
+create index if not exists org_members_user_id_idx on org_members (user_id);
@@ -0,0 +1,26 @@
+-- Emergency access for trusted contacts.
+--
+-- Grantor's encryption key is encrypted by client with grantee's public key. Requested
+-- access is approved by grantor or automatically after waiting period, which starts
+-- at request. Approved access allows grantee to read grantor's items or to take over
+-- grantor's account, depending on access's mode.
+
+create table if not exists emergency_access (
+	id int generated always as identity primary key,
+	grantor_id integer not null references users (id) on delete cascade,
+	grantee_id integer not null references users (id) on delete cascade,
+	mode varchar(16) not null check (mode in ('view', 'takeover')),
+	status varchar(16) not null check (status in ('invited', 'accepted', 'requested', 'approved')),
+	wait_days integer not null check (wait_days between 1 and 90),
+	ekey bytea not null check (length(ekey) > 0),
+	requested timestamptz,
+	approve_after timestamptz,
+	created timestamptz,
+	unique (grantor_id, grantee_id),
+	check (grantor_id <> grantee_id)
+);
+
+create index if not exists emergency_access_grantee_id_idx on emergency_access (grantee_id);
+
+create index if not exists emergency_access_approve_after_idx on emergency_access (approve_after)
+	where status = 'requested';
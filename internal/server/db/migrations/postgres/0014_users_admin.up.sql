@@ -0,0 +1,8 @@
+-- Users' administrator role and account lock.
+--
+-- Administrators manage other users via Admin service. Locked user can't log in
+-- and all tokens issued to user are revoked at lock.
+
+alter table users add column if not exists is_admin boolean not null default false;
+
+alter table users add column if not exists locked boolean not null default false;
@@ -0,0 +1,16 @@
+-- Users' audit events.
+--
+-- Events are written by server for users' vault and account operations. Events are
+-- kept after deletion of user or item, so username and item ID aren't foreign keys.
+
+create table if not exists audit_events (
+	id bigint generated always as identity primary key,
+	username varchar(50) not null,
+	event varchar(32) not null,
+	method varchar(64) not null,
+	item_id integer,
+	client_ip varchar(64) not null,
+	created timestamptz not null
+);
+
+create index if not exists audit_events_username_id_idx on audit_events (username, id);
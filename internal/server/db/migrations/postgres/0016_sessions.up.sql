@@ -0,0 +1,16 @@
+-- Users' sessions, one for every issued token.
+--
+-- Session's ID is token's ID. Token is accepted only while its session exists, so
+-- deletion of session revokes token before its expiration.
+
+create table if not exists sessions (
+	id varchar(36) primary key,
+	user_id integer not null references users (id) on delete cascade,
+	device varchar(256) not null,
+	client_ip varchar(64) not null,
+	created timestamptz not null,
+	last_seen timestamptz not null,
+	expires timestamptz not null
+);
+
+create index if not exists sessions_user_id_idx on sessions (user_id);
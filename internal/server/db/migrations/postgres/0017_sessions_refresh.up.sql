@@ -0,0 +1,9 @@
+-- Sessions' refresh tokens.
+--
+-- Only hash of refresh token is stored. Refresh replaces session's ID (ID of new access
+-- token) and refresh token's hash, so every refresh token can be used only once.
+-- Session expires with its refresh token.
+
+alter table sessions add column if not exists refresh_hash varchar(64) not null default '';
+
+create index if not exists sessions_refresh_hash_idx on sessions (refresh_hash);
@@ -0,0 +1,11 @@
+-- Failed login attempts, shared by all server's instances for login throttling.
+--
+-- Attempts are counted by key: username ("user:" prefix) or client's address ("ip:" prefix).
+-- Login with key is rejected until locked_until.
+
+create table if not exists login_attempts (
+	login_key varchar(128) primary key,
+	failures integer not null,
+	last_failure timestamptz not null,
+	locked_until timestamptz not null
+);
@@ -0,0 +1,16 @@
+-- Chunks of data items' content, which are being uploaded.
+--
+-- Every chunk is stored as soon as it's received, so server doesn't keep uploaded file in memory.
+-- After all chunks are received they replace item's content in secret_chunks in one transaction.
+-- Chunks of interrupted uploads are deleted after a day.
+
+create table if not exists secret_chunk_uploads (
+	upload_id varchar(36) not null,
+	item_id integer not null references items (id) on delete cascade,
+	seq integer not null,
+	data bytea not null,
+	created timestamptz not null,
+	primary key (upload_id, seq)
+);
+
+create index if not exists secret_chunk_uploads_created_idx on secret_chunk_uploads (created);
@@ -0,0 +1,6 @@
+-- Shared items are snapshots of owner's items made at sharing, so recipients can't edit them.
+--
+-- Changes of snapshot couldn't reach owner's item, which is encrypted with owner's key,
+-- so editable shares are dropped. Owner shares item again to update snapshot.
+
+alter table item_shares drop column if exists editable;
@@ -0,0 +1,49 @@
+-- Initial schema, equivalent to PostgreSQL's one.
+--
+-- SQLite doesn't check varchar's length and doesn't support regular expressions
+-- natively, so length checks are declared explicitly and regexp function is
+-- registered for every connection.
+--
+-- item_types constrains:
+--   - l - login item
+--   - c - card item
+--   - n - secured note item
+--   - d - secured data item
+
+create table if not exists users (
+	id integer primary key autoincrement,
+	username varchar(50) not null unique check (
+		length(username) <= 50 and username regexp '{{ .RegexUsername }}'),
+	email varchar unique check (email is null or email regexp '{{ .RegexEmail }}'),
+	pwdhash varchar not null check (pwdhash <> ''),
+	otpkey varchar,
+	ekey blob not null,
+	revision blob,
+	updated timestamp,
+	regdate timestamp
+);
+
+create table if not exists items (
+	id integer primary key autoincrement,
+	user_id integer not null references users (id) on delete cascade,
+	name varchar(128) check (name <> '' and length(name) <= 128),
+	type char(1) not null check(type in ('l', 'c', 'n', 'd')),
+	reprompt boolean,
+	updated timestamp,
+	hash blob,
+	unique (user_id, name, type)
+);
+
+create table if not exists secrets (
+	id integer primary key autoincrement,
+	item_id integer not null unique references items (id) on delete cascade,
+	notes blob,
+	secret blob check (length(secret) <= {{ .MaxSecretLength }})
+);
+
+create table if not exists additions (
+	id integer primary key autoincrement,
+	item_id integer not null unique references items (id) on delete cascade,
+	uris blob,
+	custom_fields blob
+);
@@ -0,0 +1,16 @@
+-- Items' version history, equivalent to PostgreSQL's one.
+
+create table if not exists item_versions (
+	id integer primary key autoincrement,
+	item_id integer not null references items (id) on delete cascade,
+	version integer not null,
+	name varchar(128),
+	reprompt boolean,
+	updated timestamp,
+	hash blob,
+	notes blob,
+	secret blob,
+	uris blob,
+	custom_fields blob,
+	unique (item_id, version)
+);
@@ -0,0 +1,5 @@
+-- Items' trash bin, equivalent to PostgreSQL's one.
+
+alter table items add column deleted_at timestamp;
+
+create index if not exists items_deleted_at_idx on items (deleted_at) where deleted_at is not null;
@@ -0,0 +1,3 @@
+-- Users' tokens revocation, equivalent to PostgreSQL's one.
+
+alter table users add column tokens_revoked timestamp;
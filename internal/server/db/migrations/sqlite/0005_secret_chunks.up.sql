@@ -0,0 +1,8 @@
+-- Data items' content, equivalent to PostgreSQL's one.
+
+create table if not exists secret_chunks (
+	item_id integer not null references items (id) on delete cascade,
+	seq integer not null,
+	data blob not null,
+	primary key (item_id, seq)
+);
@@ -0,0 +1,7 @@
+-- References from secrets to blob store, equivalent to PostgreSQL's one.
+
+alter table secrets add column blob_key varchar(64);
+alter table secrets add column blob_size integer not null default 0;
+
+alter table item_versions add column blob_key varchar(64);
+alter table item_versions add column blob_size integer not null default 0;
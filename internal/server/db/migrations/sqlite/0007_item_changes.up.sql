@@ -0,0 +1,21 @@
+-- Vault revision number and log of items' changes, equivalent to PostgreSQL's one.
+
+alter table users drop column revision;
+alter table users add column revision integer not null default 0;
+
+update users set revision = 1 where exists (select 1 from items where items.user_id = users.id);
+
+create table if not exists item_changes (
+	user_id integer not null references users (id) on delete cascade,
+	item_id integer not null,
+	created_revision integer not null,
+	revision integer not null,
+	deleted boolean not null default false,
+	primary key (user_id, item_id)
+);
+
+create index if not exists item_changes_revision_idx on item_changes (user_id, revision);
+
+insert into item_changes (user_id, item_id, created_revision, revision, deleted)
+	select user_id, id, 0, 1, false from items where true
+	on conflict do nothing;
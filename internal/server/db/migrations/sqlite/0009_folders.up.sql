@@ -0,0 +1,14 @@
+-- Folders for organizing items, equivalent to PostgreSQL's one.
+
+create table if not exists folders (
+	id integer primary key autoincrement,
+	user_id integer not null references users (id) on delete cascade,
+	name blob not null check (length(name) > 0),
+	updated timestamp
+);
+
+create index if not exists folders_user_id_idx on folders (user_id);
+
+alter table items add column folder_id integer references folders (id) on delete set null;
+
+create index if not exists items_folder_id_idx on items (folder_id) where folder_id is not null;
@@ -0,0 +1,5 @@
+-- Item's tags, equivalent to PostgreSQL's one.
+
+alter table additions add column tags blob;
+
+alter table item_versions add column tags blob;
@@ -0,0 +1,18 @@
+-- Sharing of items between users, equivalent to PostgreSQL's one.
+
+alter table users add column public_key blob;
+
+alter table users add column private_key blob;
+
+create table if not exists item_shares (
+	id integer primary key autoincrement,
+	item_id integer not null references items (id) on delete cascade,
+	recipient_id integer not null references users (id) on delete cascade,
+	editable boolean not null default false,
+	skey blob not null check (length(skey) > 0),
+	data blob not null check (length(data) > 0),
+	updated timestamp,
+	unique (item_id, recipient_id)
+);
+
+create index if not exists item_shares_recipient_id_idx on item_shares (recipient_id);
@@ -0,0 +1,20 @@
+-- Organizations with shared vaults, equivalent to PostgreSQL's one.
+
+create table if not exists organizations (
+	id integer primary key autoincrement,
+	name varchar(128) not null check (name <> ''),
+	vault_id integer not null unique references users (id) on delete cascade,
+	created timestamp
+);
+
+create table if not exists org_members (
+	id integer primary key autoincrement,
+	org_id integer not null references organizations (id) on delete cascade,
+	user_id integer not null references users (id) on delete cascade,
+	role varchar(16) not null check (role in ('admin', 'member', 'viewer')),
+	okey blob not null check (length(okey) > 0),
+	accepted boolean not null default false,
+	unique (org_id, user_id)
+);
+
+create index if not exists org_members_user_id_idx on org_members (user_id);
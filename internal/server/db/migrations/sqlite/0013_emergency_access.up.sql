@@ -0,0 +1,21 @@
+-- Emergency access for trusted contacts, equivalent to PostgreSQL's one.
+
+create table if not exists emergency_access (
+	id integer primary key autoincrement,
+	grantor_id integer not null references users (id) on delete cascade,
+	grantee_id integer not null references users (id) on delete cascade,
+	mode varchar(16) not null check (mode in ('view', 'takeover')),
+	status varchar(16) not null check (status in ('invited', 'accepted', 'requested', 'approved')),
+	wait_days integer not null check (wait_days between 1 and 90),
+	ekey blob not null check (length(ekey) > 0),
+	requested timestamp,
+	approve_after timestamp,
+	created timestamp,
+	unique (grantor_id, grantee_id),
+	check (grantor_id <> grantee_id)
+);
+
+create index if not exists emergency_access_grantee_id_idx on emergency_access (grantee_id);
+
+create index if not exists emergency_access_approve_after_idx on emergency_access (approve_after)
+	where status = 'requested';
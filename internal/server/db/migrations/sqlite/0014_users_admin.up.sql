@@ -0,0 +1,5 @@
+-- Users' administrator role and account lock, equivalent to PostgreSQL's one.
+
+alter table users add column is_admin boolean not null default false;
+
+alter table users add column locked boolean not null default false;
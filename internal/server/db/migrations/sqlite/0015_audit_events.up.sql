@@ -0,0 +1,13 @@
+-- Users' audit events, equivalent to PostgreSQL's one.
+
+create table if not exists audit_events (
+	id integer primary key autoincrement,
+	username varchar(50) not null,
+	event varchar(32) not null,
+	method varchar(64) not null,
+	item_id integer,
+	client_ip varchar(64) not null,
+	created timestamp not null
+);
+
+create index if not exists audit_events_username_id_idx on audit_events (username, id);
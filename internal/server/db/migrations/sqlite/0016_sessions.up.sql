@@ -0,0 +1,13 @@
+-- Users' sessions, equivalent to PostgreSQL's one.
+
+create table if not exists sessions (
+	id varchar(36) primary key,
+	user_id integer not null references users (id) on delete cascade,
+	device varchar(256) not null,
+	client_ip varchar(64) not null,
+	created timestamp not null,
+	last_seen timestamp not null,
+	expires timestamp not null
+);
+
+create index if not exists sessions_user_id_idx on sessions (user_id);
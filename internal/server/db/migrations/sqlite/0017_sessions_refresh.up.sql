@@ -0,0 +1,5 @@
+-- Sessions' refresh tokens, equivalent to PostgreSQL's one.
+
+alter table sessions add column refresh_hash varchar(64) not null default '';
+
+create index if not exists sessions_refresh_hash_idx on sessions (refresh_hash);
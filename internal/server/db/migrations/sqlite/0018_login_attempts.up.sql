@@ -0,0 +1,8 @@
+-- Failed login attempts, equivalent to PostgreSQL's one.
+
+create table if not exists login_attempts (
+	login_key varchar(128) primary key,
+	failures integer not null,
+	last_failure timestamp not null,
+	locked_until timestamp not null
+);
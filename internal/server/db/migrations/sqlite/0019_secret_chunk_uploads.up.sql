@@ -0,0 +1,12 @@
+-- Chunks of data items' content, which are being uploaded, equivalent to PostgreSQL's one.
+
+create table if not exists secret_chunk_uploads (
+	upload_id varchar(36) not null,
+	item_id integer not null references items (id) on delete cascade,
+	seq integer not null,
+	data blob not null,
+	created timestamp not null,
+	primary key (upload_id, seq)
+);
+
+create index if not exists secret_chunk_uploads_created_idx on secret_chunk_uploads (created);
@@ -0,0 +1,3 @@
+-- Shared items are snapshots of owner's items, equivalent to PostgreSQL's one.
+
+alter table item_shares drop column editable;
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	params := NewParameters("", "", "", 1000)
+
+	t.Run("Embedded migrations", func(t *testing.T) {
+		for _, dir := range []string{migrationsDirPostgres, migrationsDirSQLite} {
+			migrations, err := loadMigrations(migrationsFS, dir, params)
+			require.NoError(t, err)
+			require.NotEmpty(t, migrations)
+			assert.Equal(t, int64(1), migrations[0].Version)
+			assert.Contains(t, migrations[0].Statement, "<= 1004")
+			assert.Contains(t, migrations[0].Statement, FRegexUsername)
+		}
+	})
+
+	t.Run("Migrations are sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/0010_third.up.sql":  {Data: []byte("select 3")},
+			"m/0002_second.up.sql": {Data: []byte("select 2")},
+			"m/1_first.up.sql":     {Data: []byte("select 1")},
+		}
+
+		migrations, err := loadMigrations(fsys, "m", params)
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 1, Name: "first", Statement: "select 1"},
+			{Version: 2, Name: "second", Statement: "select 2"},
+			{Version: 10, Name: "third", Statement: "select 3"},
+		}, migrations)
+	})
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "Wrong file name",
+			fsys: fstest.MapFS{"m/first.sql": {Data: []byte("select 1")}},
+		},
+		{
+			name: "Zero version",
+			fsys: fstest.MapFS{"m/0_first.up.sql": {Data: []byte("select 1")}},
+		},
+		{
+			name: "Duplicate version",
+			fsys: fstest.MapFS{
+				"m/1_first.up.sql":   {Data: []byte("select 1")},
+				"m/01_second.up.sql": {Data: []byte("select 2")},
+			},
+		},
+		{
+			name: "Wrong template",
+			fsys: fstest.MapFS{"m/1_first.up.sql": {Data: []byte("select {{ .Unknown }}")}},
+		},
+		{
+			name: "Missed directory",
+			fsys: fstest.MapFS{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadMigrations(tt.fsys, "m", params)
+			assert.Error(t, err)
+		})
+	}
+}
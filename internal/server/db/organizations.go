@@ -0,0 +1,205 @@
+package db
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// Organization's vault parameters.
+//
+// Vault is a user's record without credentials, which owns organization's items. Vault's password
+// hash is not a valid bcrypt hash, so nobody can log in as vault.
+const (
+	orgVaultPrefix  = "org-"
+	orgVaultPwdhash = "-"
+	// Maximum length of organization's name, same as in PostgreSQL schema.
+	orgMaxNameLen = 128
+)
+
+// Membership represents user's membership in organization.
+type Membership struct {
+	Role     string `db:"role"`
+	Accepted bool   `db:"accepted"`
+	// Name of organization's vault, which owns organization's items.
+	Vault Username `db:"vault"`
+}
+
+// newOrgVaultName is a helper function which generates random name of organization's vault.
+func newOrgVaultName() Username {
+	return orgVaultPrefix + hex.EncodeToString(crypt.GenerateRandomKey32()[:8])
+}
+
+// IsOrgVault checks whether username is reserved for organizations' vaults.
+func IsOrgVault(username Username) bool {
+	return strings.HasPrefix(username, orgVaultPrefix)
+}
+
+// checkOrganization is a helper function which checks organization before creation or update.
+func checkOrganization(org *pb.Organization) error {
+	if org == nil || org.Name == "" || len(org.Name) > orgMaxNameLen {
+		return stackErrors(ErrConstraintViolation, errors.New("organization's name is required"))
+	}
+
+	return nil
+}
+
+// checkNewOrganization is a helper function which checks organization before creation.
+func checkNewOrganization(org *pb.Organization) error {
+	if err := checkOrganization(org); err != nil {
+		return err
+	}
+
+	if len(org.Okey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("organization's key is required"))
+	}
+
+	return nil
+}
+
+// checkOrgRole is a helper function which checks member's role.
+func checkOrgRole(role string) error {
+	if !common.Contains(role, common.ListOrgRoles()) {
+		return stackErrors(ErrConstraintViolation, fmt.Errorf("unknown role '%s'", role))
+	}
+
+	return nil
+}
+
+// checkMember is a helper function which checks member before adding to organization.
+func checkMember(member *pb.Member) error {
+	if member == nil || member.Username == "" || len(member.Okey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("member's username and key are required"))
+	}
+
+	return checkOrgRole(member.Role)
+}
+
+// newOrgVaultInsertStmt is a helper function for construct statement, which creates organization's vault.
+func newOrgVaultInsertStmt(psql sq.StatementBuilderType, vault Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Insert("users").
+		Columns("username, pwdhash, ekey, regdate").
+		Values(vault, orgVaultPwdhash, []byte{}, time.Now().Truncate(time.Second)).ToSql()
+}
+
+// newOrgInsertStmt is a helper function for construct statement, which creates organization
+// owning provided vault.
+func newOrgInsertStmt(psql sq.StatementBuilderType, vault Username,
+	org *pb.Organization) (SQLStatement, []interface{}, error) {
+	orgSQ := psql.
+		Select("id").
+		Column(sq.Placeholders(2), org.Name, time.Now().Truncate(time.Second)).
+		From("users").Where(sq.Eq{"username": vault})
+
+	return psql.
+		Insert("organizations").
+		Columns("vault_id, name, created").
+		Select(orgSQ).
+		Suffix("returning id").ToSql()
+}
+
+// newMemberInsertStmt is a helper function for construct statement, which adds user to organization.
+//
+// Statement doesn't affect any rows if user doesn't exist or user is organization's vault.
+func newMemberInsertStmt(psql sq.StatementBuilderType, orgID int64, member *pb.Member,
+	accepted bool) (SQLStatement, []interface{}, error) {
+	memberSQ := psql.
+		Select().
+		Column(sq.Placeholders(1), orgID).
+		Column("id").
+		Column(sq.Placeholders(3), member.Role, member.Okey, accepted).
+		From("users").
+		Where(sq.Eq{"username": member.Username}).
+		Where("id not in (select vault_id from organizations)")
+
+	return psql.
+		Insert("org_members").
+		Columns("org_id, user_id, role, okey, accepted").
+		Select(memberSQ).ToSql()
+}
+
+// newOrganizationsSelect is a helper function for construct statement, which selects user's
+// organizations with user's role and key.
+func newOrganizationsSelect(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("o.id, o.name, m.role, m.accepted, m.okey, o.created").
+		From("org_members m").
+		Join("organizations o on m.org_id = o.id").
+		Join("users u on m.user_id = u.id").
+		Where(sq.Eq{"u.username": username}).
+		OrderBy("o.name").
+		ToSql()
+}
+
+// newOrgUpdateStmt is a helper function for construct statement, which renames organization.
+func newOrgUpdateStmt(psql sq.StatementBuilderType, org *pb.Organization) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("organizations").
+		Set("name", org.Name).
+		Where(sq.Eq{"id": org.Id}).ToSql()
+}
+
+// newOrgDeleteStmt is a helper function for construct statement, which deletes organization's vault.
+//
+// Organization, its members and items are deleted together with vault.
+func newOrgDeleteStmt(psql sq.StatementBuilderType, orgID int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("users").
+		Where("id = (select vault_id from organizations where id = ?)", orgID).ToSql()
+}
+
+// newMembersSelect is a helper function for construct statement, which selects organization's
+// members without keys.
+func newMembersSelect(psql sq.StatementBuilderType, orgID int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("u.username, m.role, m.accepted").
+		From("org_members m").
+		Join("users u on m.user_id = u.id").
+		Where(sq.Eq{"m.org_id": orgID}).
+		OrderBy("u.username").
+		ToSql()
+}
+
+// newMembershipSelect is a helper function for construct statement, which selects user's
+// membership in organization.
+func newMembershipSelect(psql sq.StatementBuilderType, orgID int64,
+	username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("m.role, m.accepted, v.username as vault").
+		From("org_members m").
+		Join("users u on m.user_id = u.id").
+		Join("organizations o on m.org_id = o.id").
+		Join("users v on o.vault_id = v.id").
+		Where(sq.Eq{"m.org_id": orgID}).
+		Where(sq.Eq{"u.username": username}).
+		ToSql()
+}
+
+// newMemberUpdateStmt is a helper function for construct statement, which updates member's
+// record with provided values.
+func newMemberUpdateStmt(psql sq.StatementBuilderType, orgID int64, username Username,
+	values map[string]interface{}) (SQLStatement, []interface{}, error) {
+	return psql.
+		Update("org_members").
+		SetMap(values).
+		Where(sq.Eq{"org_id": orgID}).
+		Where("user_id = (select id from users where username = ?)", username).ToSql()
+}
+
+// newMemberDeleteStmt is a helper function for construct statement, which removes member
+// from organization.
+func newMemberDeleteStmt(psql sq.StatementBuilderType, orgID int64,
+	username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("org_members").
+		Where(sq.Eq{"org_id": orgID}).
+		Where("user_id = (select id from users where username = ?)", username).ToSql()
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOrganizations checks organizations, their members and items.
+func testOrganizations(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	org := &pb.Organization{Name: "team", Okey: []byte("okey1")}
+	member := &pb.Member{Username: testUser2.Username, Role: common.OrgRoleMember, Okey: []byte("okey2")}
+
+	var (
+		orgID int64
+		vault Username
+	)
+
+	t.Run("Create organization", func(t *testing.T) {
+		_, err := db.CreateOrganization(ctx, testUser1.Username, &pb.Organization{Okey: []byte("okey1")})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		_, err = db.CreateOrganization(ctx, testUser1.Username, &pb.Organization{Name: "team"})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		_, err = db.CreateOrganization(ctx, "unknown", org)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+
+		orgID, err = db.CreateOrganization(ctx, testUser1.Username, org)
+		require.NoError(t, err)
+		assert.NotZero(t, orgID)
+
+		orgs, err := db.GetOrganizations(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, orgs, 1)
+		assert.Equal(t, orgID, orgs[0].Id)
+		assert.Equal(t, "team", orgs[0].Name)
+		assert.Equal(t, common.OrgRoleAdmin, orgs[0].Role)
+		assert.True(t, orgs[0].Accepted)
+		assert.Equal(t, []byte("okey1"), orgs[0].Okey)
+
+		membership, err := db.GetMembership(ctx, orgID, testUser1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, common.OrgRoleAdmin, membership.Role)
+		assert.True(t, strings.HasPrefix(membership.Vault, orgVaultPrefix))
+
+		vault = membership.Vault
+
+		_, err = db.GetMembership(ctx, orgID, testUser2.Username)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Organization's vault", func(t *testing.T) {
+		require.NoError(t, db.CreateItem(ctx, vault, &pb.Item{Name: "org item", Type: common.ItemTypeSecNote}))
+
+		items, err := db.GetItemList(ctx, vault)
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+
+		_, err = db.GetItemByNameAndType(ctx, testUser1.Username, "org item", common.ItemTypeSecNote)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Invite member", func(t *testing.T) {
+		err := db.AddMember(ctx, orgID, &pb.Member{Username: testUser2.Username, Role: "owner", Okey: []byte("okey2")})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		err = db.AddMember(ctx, orgID, &pb.Member{Username: "unknown", Role: common.OrgRoleMember, Okey: []byte("k")})
+		assert.ErrorIs(t, err, ErrOperationFailed, "unknown user")
+
+		err = db.AddMember(ctx, orgID, &pb.Member{Username: vault, Role: common.OrgRoleMember, Okey: []byte("k")})
+		assert.ErrorIs(t, err, ErrOperationFailed, "organization's vault")
+
+		require.NoError(t, db.AddMember(ctx, orgID, member))
+		assert.ErrorIs(t, db.AddMember(ctx, orgID, member), ErrDuplicateEntry)
+
+		orgs, err := db.GetOrganizations(ctx, testUser2.Username)
+		require.NoError(t, err)
+		require.Len(t, orgs, 1)
+		assert.False(t, orgs[0].Accepted)
+		assert.Equal(t, common.OrgRoleMember, orgs[0].Role)
+		assert.Equal(t, []byte("okey2"), orgs[0].Okey)
+
+		members, err := db.GetMembers(ctx, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, []*pb.Member{
+			{Username: testUser1.Username, Role: common.OrgRoleAdmin, Accepted: true},
+			{Username: testUser2.Username, Role: common.OrgRoleMember},
+		}, members)
+	})
+
+	t.Run("Accept invite", func(t *testing.T) {
+		assert.ErrorIs(t, db.AcceptInvite(ctx, orgID, "unknown"), ErrOperationFailed)
+		require.NoError(t, db.AcceptInvite(ctx, orgID, testUser2.Username))
+
+		membership, err := db.GetMembership(ctx, orgID, testUser2.Username)
+		require.NoError(t, err)
+		assert.True(t, membership.Accepted)
+		assert.Equal(t, vault, membership.Vault)
+	})
+
+	t.Run("Update member's role", func(t *testing.T) {
+		err := db.UpdateMemberRole(ctx, orgID, testUser2.Username, "owner")
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		err = db.UpdateMemberRole(ctx, orgID, "unknown", common.OrgRoleViewer)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+
+		require.NoError(t, db.UpdateMemberRole(ctx, orgID, testUser2.Username, common.OrgRoleViewer))
+
+		membership, err := db.GetMembership(ctx, orgID, testUser2.Username)
+		require.NoError(t, err)
+		assert.Equal(t, common.OrgRoleViewer, membership.Role)
+	})
+
+	t.Run("Update organization", func(t *testing.T) {
+		assert.ErrorIs(t, db.UpdateOrganization(ctx, &pb.Organization{Id: orgID}), ErrConstraintViolation)
+		assert.ErrorIs(t, db.UpdateOrganization(ctx, &pb.Organization{Id: 100, Name: "x"}), ErrOperationFailed)
+		require.NoError(t, db.UpdateOrganization(ctx, &pb.Organization{Id: orgID, Name: "renamed"}))
+
+		orgs, err := db.GetOrganizations(ctx, testUser2.Username)
+		require.NoError(t, err)
+		require.Len(t, orgs, 1)
+		assert.Equal(t, "renamed", orgs[0].Name)
+	})
+
+	t.Run("Remove member", func(t *testing.T) {
+		require.NoError(t, db.RemoveMember(ctx, orgID, testUser2.Username))
+		assert.ErrorIs(t, db.RemoveMember(ctx, orgID, testUser2.Username), ErrOperationFailed)
+
+		orgs, err := db.GetOrganizations(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Empty(t, orgs)
+	})
+
+	t.Run("Delete organization", func(t *testing.T) {
+		require.NoError(t, db.DeleteOrganization(ctx, orgID))
+		assert.ErrorIs(t, db.DeleteOrganization(ctx, orgID), ErrOperationFailed)
+
+		orgs, err := db.GetOrganizations(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, orgs)
+
+		_, err = db.GetUserByName(ctx, vault)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// ListUsers returns all users' information without organizations' vaults sorted by username.
+func (db *Posgtre) ListUsers(ctx context.Context) ([]*pb.UserInfo, error) {
+	componentName := "Postgre:ListUsers"
+
+	stmtUsers, argsUsers, err := newUsersInfoSelect(db.psql)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtUsers, argsUsers), componentName)
+
+	var dbUsers []*UserInfo
+	if err := pgxscan.Select(ctx, db.pool, &dbUsers, stmtUsers, argsUsers...); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	users := make([]*pb.UserInfo, 0, len(dbUsers))
+	for _, user := range dbUsers {
+		users = append(users, user.toPB())
+	}
+
+	return users, nil
+}
+
+// SetUserLocked locks or unlocks user. Locking revokes all user's tokens.
+//
+// If no users were found SetUserLocked returns error (ErrNotFound).
+func (db *Posgtre) SetUserLocked(ctx context.Context, username Username, locked bool) error {
+	componentName := "Postgre:SetUserLocked"
+
+	stmtUser, argsUser, err := newUserLockUpdateStmt(db.psql, username, locked)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.updateUserState(ctx, username, stmtUser, argsUser, componentName)
+}
+
+// SetUserAdmin grants or revokes user's administrator role. Revoking role revokes all user's tokens.
+//
+// If no users were found SetUserAdmin returns error (ErrNotFound).
+func (db *Posgtre) SetUserAdmin(ctx context.Context, username Username, isAdmin bool) error {
+	componentName := "Postgre:SetUserAdmin"
+
+	stmtUser, argsUser, err := newUserAdminUpdateStmt(db.psql, username, isAdmin)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.updateUserState(ctx, username, stmtUser, argsUser, componentName)
+}
+
+// ResetTwoFactor disables user's two-factor authentication.
+//
+// If no users were found ResetTwoFactor returns error (ErrNotFound).
+func (db *Posgtre) ResetTwoFactor(ctx context.Context, username Username) error {
+	componentName := "Postgre:ResetTwoFactor"
+
+	stmtUser, argsUser, err := newTwoFactorResetStmt(db.psql, username)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.updateUserState(ctx, username, stmtUser, argsUser, componentName)
+}
+
+// updateUserState is a helper function, which runs user's update statement and returns
+// ErrNotFound if user wasn't updated.
+func (db *Posgtre) updateUserState(ctx context.Context, username Username, stmtUser SQLStatement,
+	argsUser []interface{}, componentName string) error {
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtUser, argsUser), componentName)
+
+	ct, err := db.pool.Exec(ctx, stmtUser, argsUser...)
+	if wrappedErr := wrapPgError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if ct.RowsAffected() < 1 {
+		return stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return nil
+}
+
+// GetServerStats returns numbers of users, organizations and items.
+func (db *Posgtre) GetServerStats(ctx context.Context) (*pb.ServerStats, error) {
+	componentName := "Postgre:GetServerStats"
+
+	stmtStats, argsStats, err := newServerStatsSelect(db.psql)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtStats, argsStats), componentName)
+
+	stats := new(pb.ServerStats)
+	if err := db.pool.QueryRow(ctx, stmtStats, argsStats...).Scan(&stats.Users, &stats.Admins,
+		&stats.LockedUsers, &stats.Organizations, &stats.Items, &stats.TrashedItems); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	return stats, nil
+}
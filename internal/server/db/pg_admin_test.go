@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestPosgtre_Admin(t *testing.T) {
+	testAdmin(t, newTestPostgres(t))
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// CreateAuditEvents records audit events.
+func (db *Posgtre) CreateAuditEvents(ctx context.Context, events []*pb.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	componentName := "Postgre:CreateAuditEvents"
+
+	stmtEvents, argsEvents, err := newAuditEventsInsertStmt(db.psql, events)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtEvents, argsEvents), componentName)
+
+	_, err = db.pool.Exec(ctx, stmtEvents, argsEvents...)
+
+	return wrapPgError(err)
+}
+
+// GetAuditEvents returns at most limit user's audit events newest first, which are older than event
+// with provided ID. Zero ID means latest events.
+func (db *Posgtre) GetAuditEvents(ctx context.Context, username Username, beforeID int64,
+	limit uint32) ([]*pb.AuditEvent, error) {
+	componentName := "Postgre:GetAuditEvents"
+
+	stmtEvents, argsEvents, err := newAuditEventsSelect(db.psql, username, beforeID, limit)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtEvents, argsEvents), componentName)
+
+	var dbEvents []*AuditEvent
+	if err := pgxscan.Select(ctx, db.pool, &dbEvents, stmtEvents, argsEvents...); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	events := make([]*pb.AuditEvent, 0, len(dbEvents))
+	for _, event := range dbEvents {
+		events = append(events, event.toPB())
+	}
+
+	return events, nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestPosgtre_Audit(t *testing.T) {
+	testAudit(t, newTestPostgres(t))
+}
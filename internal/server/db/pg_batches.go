@@ -2,17 +2,17 @@ package db
 
 import (
 	"fmt"
-	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/artfuldog/gophkeeper/internal/common"
-	"github.com/artfuldog/gophkeeper/internal/crypt"
 	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/jackc/pgx/v4"
 )
 
 // newCreateItemBatch is a helper function for construct pgx.Batch, used in item creation.
-func (db *Posgtre) newCreateItemBatch(username string, item *pb.Item) (*pgx.Batch, error) {
+//
+// Item's secret is stored as provided prepared secret.
+func (db *Posgtre) newCreateItemBatch(username string, item *pb.Item, secret storedSecret) (*pgx.Batch, error) {
 	componentName := "Postgre:newCreateItemBatch"
 
 	b := new(pgx.Batch)
@@ -20,14 +20,14 @@ func (db *Posgtre) newCreateItemBatch(username string, item *pb.Item) (*pgx.Batc
 
 	updated, hash := getHashUpdatedItem(item.Name, item.Type)
 
-	itemSQ := psql.
+	itemSQ := newItemFolderColumn(psql.
 		Select("id").
 		Column(sq.Placeholders(5), item.Name, item.Type, item.Reprompt, hash, updated).
-		From("users").Where(sq.Eq{"username": username})
+		From("users").Where(sq.Eq{"username": username}), username, item)
 
 	stmtItem, argsItem, err := psql.
 		Insert("items").
-		Columns("user_id, name, type, reprompt, hash, updated").
+		Columns("user_id, name, type, reprompt, hash, updated, folder_id").
 		Select(itemSQ).ToSql()
 
 	if err != nil {
@@ -43,14 +43,14 @@ func (db *Posgtre) newCreateItemBatch(username string, item *pb.Item) (*pgx.Batc
 
 	secretSQ := psql.
 		Select("items.id").
-		Column(sq.Placeholders(2), item.Secrets.Notes, item.Secrets.Secret).
+		Column(sq.Placeholders(4), item.Secrets.Notes, secret.Secret, secret.BlobKey, secret.BlobSize).
 		From("items").LeftJoin("users on items.user_id=users.id").
 		Where(sq.Eq{"username": username}).
 		Where(sq.Eq{"items.name": item.Name})
 
 	stmtSecret, argsSecret, err := psql.
 		Insert("secrets").
-		Columns("item_id, notes, secret").
+		Columns("item_id, notes, secret, blob_key, blob_size").
 		Select(secretSQ).ToSql()
 
 	if err != nil {
@@ -71,14 +71,14 @@ func (db *Posgtre) newCreateItemBatch(username string, item *pb.Item) (*pgx.Batc
 
 	addsSQ := psql.
 		Select("items.id").
-		Column(sq.Placeholders(2), item.Additions.Uris, item.Additions.CustomFields).
+		Column(sq.Placeholders(3), item.Additions.Uris, item.Additions.CustomFields, item.Additions.Tags).
 		From("items").LeftJoin("users on items.user_id=users.id").
 		Where(sq.Eq{"username": username}).
 		Where(sq.Eq{"items.name": item.Name})
 
 	stmtAdds, argsAdds, err := psql.
 		Insert("additions").
-		Columns("item_id, uris, custom_fields").
+		Columns("item_id, uris, custom_fields, tags").
 		Select(addsSQ).ToSql()
 
 	if err != nil {
@@ -88,37 +88,45 @@ func (db *Posgtre) newCreateItemBatch(username string, item *pb.Item) (*pgx.Batc
 	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtAdds, argsAdds), componentName)
 	b.Queue(stmtAdds, argsAdds...)
 
-	newRevision := crypt.GetSHA256hash(username + item.Name + item.Type + item.Updated.String())
-	stmtRevision, argsRevision, err := psql.
-		Update("users").Set("revision", newRevision).Where(sq.Eq{"username": username}).ToSql()
-
-	if err != nil {
+	if err := queueItemChange(b, psql, username, sq.Eq{"items.name": item.Name, "items.type": item.Type}, itemCreated); err != nil {
 		return nil, err
 	}
 
-	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtRevision, argsRevision), componentName)
-	b.Queue(stmtRevision, argsRevision...)
-
 	return b, nil
 }
 
 // newUpdateItemBatch is a helper function for construct pgx.Batch, used for update item.
-func (db *Posgtre) newUpdateItemBatch(username string, item *pb.Item) (*pgx.Batch, error) {
+//
+// Item's secret is replaced with provided prepared secret, nil secret keeps stored one.
+func (db *Posgtre) newUpdateItemBatch(username string, item *pb.Item, secret *storedSecret) (*pgx.Batch, error) {
 	componentName := "Postgre:newUpdateItemBatch"
 
 	b := new(pgx.Batch)
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
+	if db.itemVersions > 0 {
+		stmtVersion, argsVersion, err := newItemVersionSnapshotStmt(psql, username, item.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtVersion, argsVersion), componentName)
+		b.Queue(stmtVersion, argsVersion...)
+	}
+
 	updated, hash := getHashUpdatedItem(item.Name, item.Type)
 
-	stmtItem, argsItem, err := psql.
+	itemStmt := psql.
 		Update("items").
 		Set("name", sq.Expr("coalesce(?, name)", item.Name)).
 		Set("reprompt", sq.Expr("coalesce(?, reprompt)", item.Reprompt)).
 		Set("updated", updated).
 		Set("hash", hash).
 		Where(sq.Eq{"id": item.Id}).
-		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+		Where("deleted_at is null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username))
+
+	stmtItem, argsItem, err := setItemFolder(itemStmt, username, item).ToSql()
 
 	if err != nil {
 		return nil, err
@@ -128,10 +136,9 @@ func (db *Posgtre) newUpdateItemBatch(username string, item *pb.Item) (*pgx.Batc
 	b.Queue(stmtItem, argsItem...)
 
 	if item.Secrets != nil {
-		stmtSecret, argsSecret, err := psql.
+		stmtSecret, argsSecret, err := setStoredSecret(psql.
 			Update("secrets").
-			Set("notes", sq.Expr("coalesce(?, notes)", item.Secrets.Notes)).
-			Set("secret", sq.Expr("coalesce(?, secret)", item.Secrets.Secret)).
+			Set("notes", sq.Expr("coalesce(?, notes)", item.Secrets.Notes)), secret).
 			Where(sq.Eq{"item_id": item.Id}).ToSql()
 
 		if err != nil {
@@ -152,6 +159,7 @@ func (db *Posgtre) newUpdateItemBatch(username string, item *pb.Item) (*pgx.Batc
 			Update("additions").
 			Set("uris", sq.Expr("coalesce(?, uris)", item.Additions.Uris)).
 			Set("custom_fields", sq.Expr("coalesce(?, custom_fields)", item.Additions.CustomFields)).
+			Set("tags", sq.Expr("coalesce(?, tags)", item.Additions.Tags)).
 			Where(sq.Eq{"item_id": item.Id}).ToSql()
 
 		if err != nil {
@@ -162,29 +170,126 @@ func (db *Posgtre) newUpdateItemBatch(username string, item *pb.Item) (*pgx.Batc
 		b.Queue(stmtAdds, argsAdds...)
 	}
 
-	newRevision := crypt.GetSHA256hash(username + item.Name + item.Type + item.Updated.String())
-	stmtRevision, argsRevision, err := psql.
-		Update("users").Set("revision", newRevision).Where(sq.Eq{"username": username}).ToSql()
+	if err := queueItemChange(b, psql, username, sq.Eq{"items.id": item.Id}, itemUpdated); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// newDeleteItemBatch is a helper function for construct pgx.Batch, used for move item to trash.
+func (db *Posgtre) newDeleteItemBatch(username string, itemID int64) (*pgx.Batch, error) {
+	componentName := "Postgre:newDeleteItemBatch"
+
+	b := new(pgx.Batch)
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	stmtItem, argsItem, err := psql.
+		Update("items").Set("deleted_at", getDeletedAt()).
+		Where(sq.Eq{"id": itemID}).
+		Where("deleted_at is null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
 
 	if err != nil {
 		return nil, err
 	}
 
-	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtRevision, argsRevision), componentName)
-	b.Queue(stmtRevision, argsRevision...)
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	if err := queueItemChange(b, psql, username, sq.Eq{"items.id": itemID}, itemUpdated); err != nil {
+		return nil, err
+	}
 
 	return b, nil
 }
 
-// newDeleteItemBatch is a helper function for construct pgx.Batch, used for deleteitem.
-func (db *Posgtre) newDeleteItemBatch(username string, itemID int64) (*pgx.Batch, error) {
-	componentName := "Postgre:newDeleteItemBatch"
+// newRestoreItemBatch is a helper function for construct pgx.Batch, used for restore item from trash.
+func (db *Posgtre) newRestoreItemBatch(username string, itemID int64) (*pgx.Batch, error) {
+	componentName := "Postgre:newRestoreItemBatch"
+
+	b := new(pgx.Batch)
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	stmtItem, argsItem, err := psql.
+		Update("items").Set("deleted_at", nil).
+		Where(sq.Eq{"id": itemID}).
+		Where("deleted_at is not null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	if err := queueItemChange(b, psql, username, sq.Eq{"items.id": itemID}, itemUpdated); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// newPurgeItemBatch is a helper function for construct pgx.Batch, used for permanently delete trashed item.
+func (db *Posgtre) newPurgeItemBatch(username string, itemID int64) (*pgx.Batch, error) {
+	componentName := "Postgre:newPurgeItemBatch"
 
 	b := new(pgx.Batch)
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
+	// Change is recorded before purge, while item still exists
+	if err := queueItemChange(b, psql, username, sq.Eq{"items.id": itemID}, itemDeleted); err != nil {
+		return nil, err
+	}
+
 	stmtItem, argsItem, err := psql.
 		Delete("items").Where(sq.Eq{"id": itemID}).
+		Where("deleted_at is not null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	return b, nil
+}
+
+// newRestoreItemVersionBatch is a helper function for construct pgx.Batch, used for restore
+// item's state from previous version.
+//
+// Current item's state is stored as new version before restore, if items' history is enabled.
+// Item's secret is replaced with provided prepared secret.
+func (db *Posgtre) newRestoreItemVersionBatch(username string, version *pb.ItemVersion,
+	secret storedSecret) (*pgx.Batch, error) {
+	componentName := "Postgre:newRestoreItemVersionBatch"
+
+	b := new(pgx.Batch)
+	item := version.Item
+
+	if db.itemVersions > 0 {
+		stmtVersion, argsVersion, err := newItemVersionSnapshotStmt(db.psql, username, item.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtVersion, argsVersion), componentName)
+		b.Queue(stmtVersion, argsVersion...)
+	}
+
+	updated, hash := getHashUpdatedItem(item.Name, item.Type)
+
+	stmtItem, argsItem, err := db.psql.
+		Update("items").
+		Set("name", item.Name).
+		Set("reprompt", item.Reprompt).
+		Set("updated", updated).
+		Set("hash", hash).
+		Where(sq.Eq{"id": item.Id}).
+		Where("deleted_at is null").
 		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
 
 	if err != nil {
@@ -194,16 +299,35 @@ func (db *Posgtre) newDeleteItemBatch(username string, itemID int64) (*pgx.Batch
 	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
 	b.Queue(stmtItem, argsItem...)
 
-	newRevision := crypt.GetSHA256hash(fmt.Sprintf("%s|%d<>%v", username, itemID, time.Now()))
-	stmtRevision, argsRevision, err := psql.
-		Update("users").Set("revision", newRevision).Where(sq.Eq{"username": username}).ToSql()
+	stmtSecret, argsSecret, err := setStoredSecret(db.psql.
+		Update("secrets").
+		Set("notes", item.Secrets.GetNotes()), &secret).
+		Where(sq.Eq{"item_id": item.Id}).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtSecret, argsSecret), componentName)
+	b.Queue(stmtSecret, argsSecret...)
+
+	stmtAdds, argsAdds, err := db.psql.
+		Update("additions").
+		Set("uris", item.Additions.GetUris()).
+		Set("custom_fields", item.Additions.GetCustomFields()).
+		Set("tags", item.Additions.GetTags()).
+		Where(sq.Eq{"item_id": item.Id}).ToSql()
 
 	if err != nil {
 		return nil, err
 	}
 
-	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtRevision, argsRevision), componentName)
-	b.Queue(stmtRevision, argsRevision...)
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtAdds, argsAdds), componentName)
+	b.Queue(stmtAdds, argsAdds...)
+
+	if err := queueItemChange(b, db.psql, username, sq.Eq{"items.id": item.Id}, itemUpdated); err != nil {
+		return nil, err
+	}
 
 	return b, nil
 }
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+// CreateFolder creates new user's folder and returns its ID.
+func (db *Posgtre) CreateFolder(ctx context.Context, username Username, folder *pb.Folder) (int64, error) {
+	if username == "" {
+		return 0, ErrNotFound
+	}
+
+	componentName := "Postgre:CreateFolder"
+
+	if err := checkFolder(folder); err != nil {
+		return 0, err
+	}
+
+	stmtFolder, argsFolder, err := newFolderInsertStmt(db.psql, username, folder)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtRevision, argsRevision, err := newRevisionStmt(db.psql, username)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return 0, err
+	}
+	defer db.deferTxRollback(ctx, tx) //nolint:wsl
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtFolder, argsFolder), componentName)
+
+	var id int64
+	if err := tx.QueryRow(ctx, stmtFolder, argsFolder...).Scan(&id); err != nil {
+		if pgxscan.NotFound(err) {
+			return 0, errNoRowsAffected()
+		}
+
+		return 0, wrapPgError(err)
+	}
+
+	b := new(pgx.Batch)
+	b.Queue(stmtRevision, argsRevision...)
+
+	if err := db.execBatch(ctx, tx, b); err != nil {
+		return 0, err
+	}
+
+	if err := db.commitTx(ctx, tx, componentName); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetFolders returns all user's folders.
+func (db *Posgtre) GetFolders(ctx context.Context, username Username) ([]*pb.Folder, error) {
+	componentName := "Postgre:GetFolders"
+
+	stmtFolders, argsFolders, err := newFoldersSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtFolders, argsFolders), componentName)
+
+	var dbFolders []*Folder
+	if err := pgxscan.Select(ctx, db.pool, &dbFolders, stmtFolders, argsFolders...); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	folders := make([]*pb.Folder, 0, len(dbFolders))
+	for _, folder := range dbFolders {
+		folders = append(folders, folder.toPB())
+	}
+
+	return folders, nil
+}
+
+// UpdateFolder renames existing user's folder.
+func (db *Posgtre) UpdateFolder(ctx context.Context, username Username, folder *pb.Folder) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	componentName := "Postgre:UpdateFolder"
+
+	if err := checkFolder(folder); err != nil {
+		return err
+	}
+
+	stmtFolder, argsFolder, err := newFolderUpdateStmt(db.psql, username, folder)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtRevision, argsRevision, err := newRevisionStmt(db.psql, username)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(pgx.Batch)
+	b.Queue(stmtFolder, argsFolder...)
+	b.Queue(stmtRevision, argsRevision...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// DeleteFolder deletes user's folder, folder's items are kept without folder.
+func (db *Posgtre) DeleteFolder(ctx context.Context, username Username, folderID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	componentName := "Postgre:DeleteFolder"
+
+	b := new(pgx.Batch)
+	if err := queueDeleteFolder(b, db.psql, username, folderID); err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	defer db.deferTxRollback(ctx, tx) //nolint:wsl
+
+	batchRes := tx.SendBatch(ctx, b)
+
+	var affected int64
+
+	for i := 0; i < b.Len(); i++ {
+		ct, err := batchRes.Exec()
+		if wrappedErr := wrapPgError(err); wrappedErr != nil {
+			batchRes.Close()
+			return wrappedErr
+		}
+
+		affected = ct.RowsAffected()
+	}
+
+	if err := batchRes.Close(); err != nil {
+		return wrapPgError(err)
+	}
+
+	if affected < 1 {
+		return errNoRowsAffected()
+	}
+
+	return db.commitTx(ctx, tx, componentName)
+}
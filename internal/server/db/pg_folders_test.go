@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestPosgtre_Folders(t *testing.T) {
+	testFolders(t, newTestPostgres(t))
+}
@@ -21,6 +21,54 @@ func (db *Posgtre) runBatch(ctx context.Context, batch *pgx.Batch, componentName
 
 	defer db.deferTxRollback(ctx, tx)
 
+	if err := db.execBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+
+	if err := db.commitTx(ctx, tx, componentName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runBatchInQuota is helper function to run sql requests in batches, same as runBatch,
+// but additionally checks user's usage against quota before commit.
+//
+// If quota is exceeded transaction is rolled back and ErrQuotaExceeded is returned.
+func (db *Posgtre) runBatchInQuota(ctx context.Context, batch *pgx.Batch, username Username,
+	checkItems bool, componentName string) error {
+	if !db.quota.Enabled() {
+		return db.runBatch(ctx, batch, componentName)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return err
+	}
+
+	defer db.deferTxRollback(ctx, tx)
+
+	if err := db.execBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+
+	usage, err := db.getUserUsage(ctx, tx, username, componentName)
+	if err != nil {
+		return err
+	}
+
+	if err := db.quota.check(usage, checkItems); err != nil {
+		return err
+	}
+
+	return db.commitTx(ctx, tx, componentName)
+}
+
+// execBatch is helper function to run sql requests in batch within provided transaction.
+//
+// Every request must affect at least one row, otherwise ErrOperationFailed is returned.
+func (db *Posgtre) execBatch(ctx context.Context, tx pgx.Tx, batch *pgx.Batch) error {
 	batchRes := tx.SendBatch(ctx, batch)
 	defer batchRes.Close()
 
@@ -35,13 +83,7 @@ func (db *Posgtre) runBatch(ctx context.Context, batch *pgx.Batch, componentName
 		}
 	}
 
-	batchRes.Close()
-
-	if err := db.commitTx(ctx, tx, componentName); err != nil {
-		return err
-	}
-
-	return nil
+	return batchRes.Close()
 }
 
 // stackErrors is helper function to wrap database error.
@@ -1,17 +1,22 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/blobstore"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestPosgtre_CreateItem(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -141,11 +146,17 @@ func TestPosgtre_CreateItem(t *testing.T) {
 			if err := testDB.DeleteItem(context.Background(), testUser2.Username, newItem.Id); err != nil {
 				t.Errorf("Postgre.CreateUser() - failed delete test item: %v", err)
 			}
+
+			if err := testDB.PurgeItem(context.Background(), testUser2.Username, newItem.Id); err != nil {
+				t.Errorf("Postgre.CreateUser() - failed purge test item: %v", err)
+			}
 		})
 	}
 }
 
 func TestPosgtre_GetItemByNameAndType(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 	itemUsername := testUser1.Username
@@ -301,6 +312,8 @@ func TestPosgtre_GetItemByNameAndType(t *testing.T) {
 }
 
 func TestPosgtre_GetItemList(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -374,6 +387,8 @@ func TestPosgtre_GetItemList(t *testing.T) {
 }
 
 func TestPosgtre_GetItemsByID(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -462,12 +477,15 @@ func TestPosgtre_GetItemsByID(t *testing.T) {
 }
 
 func TestPosgtre_GetItemsByHash(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
 	type args struct {
-		ctx context.Context
-		id  int64
+		ctx      context.Context
+		username Username
+		id       int64
 	}
 	tests := []struct {
 		name    string
@@ -479,8 +497,9 @@ func TestPosgtre_GetItemsByHash(t *testing.T) {
 		{
 			name: "Get existing item's hash",
 			args: args{
-				ctx: context.Background(),
-				id:  testItemCard.Id,
+				ctx:      context.Background(),
+				username: testUser1.Username,
+				id:       testItemCard.Id,
 			},
 			want:    testItemCard.Hash,
 			wantErr: false,
@@ -488,8 +507,19 @@ func TestPosgtre_GetItemsByHash(t *testing.T) {
 		{
 			name: "Get hash of unexisting item",
 			args: args{
-				ctx: context.Background(),
-				id:  99999999,
+				ctx:      context.Background(),
+				username: testUser1.Username,
+				id:       99999999,
+			},
+			wantErr: true,
+			err:     ErrNotFound,
+		},
+		{
+			name: "Get hash of another user's item",
+			args: args{
+				ctx:      context.Background(),
+				username: testUser2.Username,
+				id:       testItemCard.Id,
 			},
 			wantErr: true,
 			err:     ErrNotFound,
@@ -497,8 +527,9 @@ func TestPosgtre_GetItemsByHash(t *testing.T) {
 		{
 			name: "Canceled context",
 			args: args{
-				ctx: canceledCtx,
-				id:  testItemCard.Id,
+				ctx:      canceledCtx,
+				username: testUser1.Username,
+				id:       testItemCard.Id,
 			},
 			wantErr: true,
 			err:     ErrTransactionFailed,
@@ -506,7 +537,7 @@ func TestPosgtre_GetItemsByHash(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := testDB.GetItemHashByID(tt.args.ctx, tt.args.id)
+			got, err := testDB.GetItemHashByID(tt.args.ctx, tt.args.username, tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Postgre.GetItemsByHash() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -526,6 +557,8 @@ func TestPosgtre_GetItemsByHash(t *testing.T) {
 }
 
 func TestPosgtre_UpdateItem(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	err := testDB.CreateItem(context.Background(), testUser2.Username, testItemEmptyNotesSecrets)
 	if err != nil {
 		t.Errorf("Failed to create test item: %v", err)
@@ -653,9 +686,16 @@ func TestPosgtre_UpdateItem(t *testing.T) {
 	if err != nil {
 		t.Errorf("Failed to delete test item: %v", err)
 	}
+
+	err = testDB.PurgeItem(context.Background(), itemUsername, newItem.Id)
+	if err != nil {
+		t.Errorf("Failed to purge test item: %v", err)
+	}
 }
 
 func TestPosgtre_DeleteItem(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	err := testDB.CreateItem(context.Background(), testUser2.Username, testItemEmptyNotesSecrets)
 	if err != nil {
 		t.Errorf("Failed to create test item: %v", err)
@@ -719,4 +759,406 @@ func TestPosgtre_DeleteItem(t *testing.T) {
 			}
 		})
 	}
+
+	if err := testDB.PurgeItem(context.Background(), itemUsername, newItem.Id); err != nil {
+		t.Errorf("Failed to purge test item: %v", err)
+	}
+}
+
+func TestPosgtre_Trash(t *testing.T) {
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+	username := testUser2.Username
+
+	item := &pb.Item{Name: "trashed item", Type: testItemCard.Type}
+	if err := testDB.CreateItem(ctx, username, item); err != nil {
+		t.Errorf("Failed to create test item: %v", err)
+	}
+
+	newItem, err := testDB.GetItemByNameAndType(ctx, username, item.Name, item.Type)
+	if err != nil {
+		t.Fatalf("Failed to get test item: %v", err)
+	}
+
+	err = testDB.PurgeItem(ctx, username, newItem.Id)
+	assert.ErrorIs(t, err, ErrOperationFailed, "only trashed item can be purged")
+
+	err = testDB.DeleteItem(ctx, username, newItem.Id)
+	assert.NoError(t, err)
+
+	_, err = testDB.GetItemByNameAndType(ctx, username, item.Name, item.Type)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = testDB.CreateItem(ctx, username, item)
+	assert.ErrorIs(t, err, ErrDuplicateEntry, "trashed item's name is reserved")
+
+	trash, err := testDB.GetTrashList(ctx, username)
+	assert.NoError(t, err)
+	if assert.Len(t, trash, 1) {
+		assert.Equal(t, newItem.Id, trash[0].Id)
+		assert.NotNil(t, trash[0].Deleted)
+	}
+
+	err = testDB.RestoreItem(ctx, testUser1.Username, newItem.Id)
+	assert.ErrorIs(t, err, ErrOperationFailed)
+
+	err = testDB.RestoreItem(ctx, username, newItem.Id)
+	assert.NoError(t, err)
+
+	_, err = testDB.GetItemByNameAndType(ctx, username, item.Name, item.Type)
+	assert.NoError(t, err)
+
+	err = testDB.DeleteItem(ctx, username, newItem.Id)
+	assert.NoError(t, err)
+
+	testDB.purgeTrash(ctx, time.Now().Add(-time.Hour), "test")
+
+	trash, err = testDB.GetTrashList(ctx, username)
+	assert.NoError(t, err)
+	assert.Len(t, trash, 1, "item isn't expired yet")
+
+	testDB.purgeTrash(ctx, time.Now().Add(time.Second), "test")
+
+	trash, err = testDB.GetTrashList(ctx, username)
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+}
+
+func TestPosgtre_ItemVersions(t *testing.T) {
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+	username := testUser2.Username
+
+	testDB.itemVersions = 2
+	defer func() { testDB.itemVersions = 0 }()
+
+	item := &pb.Item{Name: "versioned item", Type: testItemLogin.Type}
+	if err := testDB.CreateItem(ctx, username, item); err != nil {
+		t.Errorf("Failed to create test item: %v", err)
+	}
+
+	newItem, err := testDB.GetItemByNameAndType(ctx, username, item.Name, item.Type)
+	if err != nil {
+		t.Fatalf("Failed to get test item: %v", err)
+	}
+
+	for _, secret := range []string{"v1", "v2", "v3"} {
+		err := testDB.UpdateItem(ctx, username, &pb.Item{
+			Id:      newItem.Id,
+			Name:    newItem.Name,
+			Secrets: &pb.Secrets{Secret: []byte(secret)},
+		})
+		if err != nil {
+			t.Errorf("Failed to update test item: %v", err)
+		}
+	}
+
+	versions, err := testDB.GetItemVersions(ctx, username, newItem.Id)
+	assert.NoError(t, err)
+	if assert.Len(t, versions, 2) {
+		assert.Equal(t, int64(3), versions[0].Version)
+		assert.Equal(t, []byte("v2"), versions[0].Item.Secrets.Secret)
+		assert.Equal(t, int64(2), versions[1].Version)
+		assert.Equal(t, []byte("v1"), versions[1].Item.Secrets.Secret)
+	}
+
+	versions, err = testDB.GetItemVersions(ctx, testUser1.Username, newItem.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+
+	err = testDB.RestoreItemVersion(ctx, testUser1.Username, newItem.Id, 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = testDB.RestoreItemVersion(ctx, username, newItem.Id, 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = testDB.RestoreItemVersion(ctx, username, newItem.Id, 2)
+	assert.NoError(t, err)
+
+	restored, err := testDB.GetItemByNameAndType(ctx, username, item.Name, item.Type)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), restored.Secrets.Secret)
+
+	if err := testDB.DeleteItem(ctx, username, newItem.Id); err != nil {
+		t.Errorf("Failed to delete test item: %v", err)
+	}
+
+	if err := testDB.PurgeItem(ctx, username, newItem.Id); err != nil {
+		t.Errorf("Failed to purge test item: %v", err)
+	}
+
+	versions, err = testDB.GetItemVersions(ctx, username, newItem.Id)
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestPosgtre_RotateEncryptionKey(t *testing.T) {
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+	username := testUser2.Username
+
+	item := &pb.Item{Name: "rotated item", Type: testItemLogin.Type}
+	if err := testDB.CreateItem(ctx, username, item); err != nil {
+		t.Errorf("Failed to create test item: %v", err)
+	}
+
+	items, err := testDB.GetAllItems(ctx, username)
+	if err != nil {
+		t.Fatalf("Failed to get all items: %v", err)
+	}
+
+	for _, i := range items {
+		i.Secrets = &pb.Secrets{Notes: []byte("rotated notes"), Secret: []byte("rotated secret")}
+		i.Additions = &pb.Additions{CustomFields: []byte("rotated cf")}
+	}
+
+	newKey := []byte("rotatedkey")
+
+	err = testDB.RotateEncryptionKey(ctx, username, nil, nil, items, nil, nil)
+	assert.ErrorIs(t, err, ErrConstraintViolation)
+
+	err = testDB.RotateEncryptionKey(ctx, username, newKey, nil, append(items, items[0]), nil, nil)
+	assert.ErrorIs(t, err, ErrConstraintViolation)
+
+	err = testDB.RotateEncryptionKey(ctx, username, newKey, nil, items[1:], nil, nil)
+	assert.ErrorIs(t, err, ErrOperationFailed, "all user's items must be passed")
+
+	err = testDB.RotateEncryptionKey(ctx, testUser1.Username, newKey, nil, items, nil, nil)
+	assert.ErrorIs(t, err, ErrOperationFailed)
+
+	ekey, err := testDB.GetUserEKey(ctx, username)
+	assert.NoError(t, err)
+	assert.Equal(t, testUser2.Ekey, ekey, "failed rotation mustn't change anything")
+
+	err = testDB.RotateEncryptionKey(ctx, username, newKey, nil, items, nil, nil)
+	assert.NoError(t, err)
+
+	ekey, err = testDB.GetUserEKey(ctx, username)
+	assert.NoError(t, err)
+	assert.Equal(t, newKey, ekey)
+
+	rotated, err := testDB.GetAllItems(ctx, username)
+	assert.NoError(t, err)
+	if assert.Len(t, rotated, len(items)) {
+		for _, i := range rotated {
+			assert.Equal(t, []byte("rotated secret"), i.Secrets.Secret)
+		}
+	}
+
+	err = testDB.RotateEncryptionKey(ctx, username, testUser2.Ekey, nil, rotated, nil, nil)
+	assert.NoError(t, err, "restore user's key for other tests")
+}
+
+func TestPosgtre_Quota(t *testing.T) {
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+	username := testUser2.Username
+
+	defer func() { testDB.quota = Quota{} }()
+
+	usage, err := testDB.GetUserUsage(ctx, username)
+	if err != nil {
+		t.Fatalf("Failed to get user's usage: %v", err)
+	}
+
+	testDB.quota = Quota{MaxItems: uint32(usage.Items)}
+
+	err = testDB.CreateItem(ctx, username, &pb.Item{Name: "overlimit", Type: testItemCard.Type})
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	testDB.quota = Quota{MaxBytes: uint64(usage.Bytes) + 10}
+
+	err = testDB.CreateItem(ctx, username, &pb.Item{
+		Name:    "overlimit",
+		Type:    testItemCard.Type,
+		Secrets: &pb.Secrets{Secret: make([]byte, 11)},
+	})
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	err = testDB.CreateItem(ctx, username, &pb.Item{
+		Name:    "inlimit",
+		Type:    testItemCard.Type,
+		Secrets: &pb.Secrets{Secret: make([]byte, 10)},
+	})
+	assert.NoError(t, err)
+
+	newUsage, err := testDB.GetUserUsage(ctx, username)
+	assert.NoError(t, err)
+	assert.Equal(t, usage.Items+1, newUsage.Items)
+	assert.Equal(t, usage.Bytes+10, newUsage.Bytes)
+
+	testDB.itemVersions = 2
+	defer func() { testDB.itemVersions = 0 }()
+
+	item, err := testDB.GetItemByNameAndType(ctx, username, "inlimit", testItemCard.Type)
+	if err != nil {
+		t.Fatalf("Failed to get item: %v", err)
+	}
+
+	update := func() error {
+		return testDB.UpdateItem(ctx, username, &pb.Item{
+			Id:      item.Id,
+			Name:    item.Name,
+			Secrets: &pb.Secrets{Secret: make([]byte, 10)},
+		})
+	}
+
+	testDB.quota = Quota{MaxBytes: uint64(newUsage.Bytes) + 15}
+
+	assert.NoError(t, update())
+	assert.ErrorIs(t, update(), ErrQuotaExceeded, "item's history is counted")
+
+	share := &pb.Share{ItemId: item.Id, Recipient: testUser1.Username, Skey: []byte("skey"), Data: make([]byte, 6)}
+	_, err = testDB.ShareItem(ctx, username, share)
+	assert.ErrorIs(t, err, ErrQuotaExceeded, "share's data is counted")
+}
+
+func TestPosgtre_SecretData(t *testing.T) {
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+	username := testUser2.Username
+
+	data, err := testDB.GetItemByNameAndType(ctx, username, testItemData.Name, testItemData.Type)
+	if err != nil {
+		t.Fatalf("Failed to get data item: %v", err)
+	}
+
+	chunks := [][]byte{[]byte("first chunk"), []byte("second chunk")}
+
+	size, err := testDB.SaveSecretData(ctx, username, data.Id, newTestChunkReader(chunks, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(23), size)
+
+	_, err = testDB.SaveSecretData(ctx, username, data.Id,
+		newTestChunkReader([][]byte{[]byte("lost")}, errors.New("stream closed")))
+	assert.ErrorIs(t, err, ErrOperationFailed)
+
+	_, err = testDB.SaveSecretData(ctx, testUser1.Username, data.Id, newTestChunkReader(chunks, nil))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var got [][]byte
+	err = testDB.GetSecretData(ctx, username, data.Id, func(c []byte) error {
+		got = append(got, c)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, chunks, got, "failed upload mustn't change content")
+
+	var uploads int
+	err = testDB.pool.QueryRow(ctx, `select count(*) from secret_chunk_uploads`).Scan(&uploads)
+	assert.NoError(t, err)
+	assert.Zero(t, uploads, "upload's chunks are deleted after upload")
+
+	got = nil
+	err = testDB.GetSecretData(ctx, username, data.Id, func(c []byte) error {
+		got = append(got, c)
+		if len(got) > 1 {
+			return nil
+		}
+
+		_, err := testDB.SaveSecretData(ctx, username, data.Id,
+			newTestChunkReader([][]byte{[]byte("other"), []byte("content")}, nil))
+
+		return err
+	})
+	assert.ErrorIs(t, err, ErrOperationFailed)
+	assert.Equal(t, chunks[:1], got, "chunks of new content mustn't be mixed with previous one")
+}
+
+func TestPosgtre_SecretBlobs(t *testing.T) {
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+	username := testUser2.Username
+
+	store, err := blobstore.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	params := testDBConnParams
+	params.SetBlobStore(store, 16)
+
+	blobs := testDB.blobs
+	testDB.blobs = newSecretBlobs(&params)
+	testDB.itemVersions = 2
+	defer func() {
+		testDB.blobs = blobs
+		testDB.itemVersions = 0
+	}()
+
+	bigSecret := bytes.Repeat([]byte("big secret "), 10)
+	item := &pb.Item{Name: "blob item", Type: testItemData.Type, Secrets: &pb.Secrets{Secret: bigSecret}}
+
+	assert.NoError(t, testDB.CreateItem(ctx, username, item))
+
+	stored, err := testDB.GetItemByNameAndType(ctx, username, item.Name, item.Type)
+	if err != nil {
+		t.Fatalf("Failed to get blob item: %v", err)
+	}
+	assert.Equal(t, bigSecret, stored.Secrets.Secret)
+
+	var key *string
+	err = testDB.pool.QueryRow(ctx, `select blob_key from secrets where item_id = $1`, stored.Id).Scan(&key)
+	assert.NoError(t, err)
+	if assert.NotNil(t, key) {
+		assert.Equal(t, blobstore.Key(bigSecret), *key)
+	}
+
+	newSecret := bytes.Repeat([]byte("new big secret "), 10)
+	err = testDB.UpdateItem(ctx, username, &pb.Item{Id: stored.Id, Name: item.Name, Secrets: &pb.Secrets{Secret: newSecret}})
+	assert.NoError(t, err)
+
+	items, err := testDB.GetItemsByID(ctx, username, []int64{stored.Id})
+	assert.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, newSecret, items[0].Secrets.Secret)
+	}
+
+	versions, err := testDB.GetItemVersions(ctx, username, stored.Id)
+	assert.NoError(t, err)
+	if assert.Len(t, versions, 1) {
+		assert.Equal(t, bigSecret, versions[0].Item.Secrets.Secret)
+	}
+
+	assert.NoError(t, testDB.DeleteItem(ctx, username, stored.Id))
+	assert.NoError(t, testDB.PurgeItem(ctx, username, stored.Id))
+
+	orphan, err := store.Put(ctx, []byte("orphan blob"))
+	assert.NoError(t, err)
+
+	testDB.collectBlobs(ctx, "test")
+
+	_, err = store.Get(ctx, orphan)
+	assert.NoError(t, err, "fresh blob must be kept")
+}
+
+func TestPosgtre_GetChangesSince(t *testing.T) {
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+
+	revision, err := testDB.GetUserRevision(ctx, testUser1.Username)
+	assert.NoError(t, err)
+
+	changes, err := testDB.GetChangesSince(ctx, testUser1.Username, 0)
+	assert.NoError(t, err)
+	if assert.NotNil(t, changes) {
+		assert.Equal(t, revision, changes.Revision)
+		for _, item := range testItems {
+			assert.Contains(t, append(changes.Created, changes.Updated...), item.Id)
+		}
+	}
+
+	changes, err = testDB.GetChangesSince(ctx, testUser1.Username, revision)
+	assert.NoError(t, err)
+	assert.Equal(t, &Changes{Revision: revision}, changes)
+
+	_, err = testDB.GetChangesSince(ctx, "unknown", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
 }
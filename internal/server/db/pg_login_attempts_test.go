@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestPosgtre_LoginAttempts(t *testing.T) {
+	testLoginAttempts(t, newTestPostgres(t))
+}
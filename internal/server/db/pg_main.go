@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -17,8 +19,8 @@ type Posgtre struct {
 	config *pgxpool.Config
 	// PGX connections pool
 	pool *pgxpool.Pool
-	// DB tables statements (schema)
-	tables []PGTable
+	// Schema migrations
+	migrations []Migration
 	// Logger
 	logger logger.L
 	// DSN string
@@ -27,6 +29,16 @@ type Posgtre struct {
 	psql sq.StatementBuilderType
 	// Maximum size of secret in bytes
 	maxSecretSize uint32
+	// Number of items' previous versions, kept in history
+	itemVersions uint32
+	// Period, after which trashed items are purged
+	trashRetention time.Duration
+	// Per-user storage quota
+	quota Quota
+	// Store for large secrets
+	blobs *secretBlobs
+	// Subscriptions to changes of users' vaults
+	watchers *revisionWatchers
 	// Mutex for sync connection and clear operations, which may concrutently use structure fields
 	mu sync.Mutex
 }
@@ -61,10 +73,20 @@ func newPosgtre(params *Parameters, logger logger.L) (*Posgtre, error) {
 		db.DSN = fmt.Sprintf("postgres://%s:%s@%s", params.user, params.password, params.address)
 	}
 
-	db.tables = createDBSchema(params)
+	migrations, err := loadMigrations(migrationsFS, migrationsDirPostgres, params)
+	if err != nil {
+		return nil, err
+	}
+
+	db.migrations = migrations
 
 	db.psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 	db.maxSecretSize = params.maxSecretSize
+	db.itemVersions = params.itemVersions
+	db.trashRetention = params.trashRetention
+	db.quota = params.quota
+	db.blobs = newSecretBlobs(params)
+	db.watchers = newRevisionWatchers(false)
 
 	return db, nil
 }
@@ -87,23 +109,12 @@ func (db *Posgtre) Connect(ctx context.Context) (err error) {
 	return
 }
 
-// Setup builds required tables in database.
-func (db *Posgtre) Setup(ctx context.Context) (err error) {
+// Setup applies all pending schema migrations.
+func (db *Posgtre) Setup(ctx context.Context) error {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	componentName := "Postgre:setup"
-
-	for _, t := range db.tables {
-		ct, execErr := db.pool.Exec(ctx, t.Statement)
-		if execErr != nil {
-			return execErr
-		}
-
-		db.logger.Info(fmt.Sprintf("table '%s': %s", t.Name, ct.String()), componentName)
-	}
-
-	return
+	return db.migrate(ctx)
 }
 
 // ConnectAndSetup does same as sequentially calling Connect and Setup function.
@@ -119,20 +130,132 @@ func (db *Posgtre) ConnectAndSetup(ctx context.Context) (err error) {
 	return nil
 }
 
+// Close closes all connections of pool.
+func (db *Posgtre) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.pool != nil {
+		db.pool.Close()
+	}
+
+	return nil
+}
+
 // Run is used for controls database connections.
 //
 // Run uses context and closing channel for gracefully shutdown database's connections.
 // After context expired or cancel function Run will close opened connections
 // and close channel.
+// If trash retention period is set Run periodically purges expired trashed items.
+// If blob store is set Run periodically and on request collects unreferenced blobs.
+// Run periodically approves requested emergency accesses with elapsed waiting period.
+// Run also listens notifications about new vaults' revisions for vaults' watchers.
 func (db *Posgtre) Run(ctx context.Context, closeCh CloseChannel) {
 	componentName := "Postgre:run"
 	db.logger.Info("DB is running", componentName)
 
-	<-ctx.Done()
+	go db.listenRevisions(ctx, componentName)
+
+	var purgeCh <-chan time.Time
+
+	if db.trashRetention > 0 {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		purgeCh = ticker.C
+	}
+
+	var gcCh <-chan time.Time
 
-	db.pool.Close()
-	db.logger.Info("DB is stopped", componentName)
-	close(closeCh)
+	if db.blobs.store != nil {
+		ticker := time.NewTicker(blobGCInterval)
+		defer ticker.Stop()
+
+		gcCh = ticker.C
+	}
+
+	approveTicker := time.NewTicker(emergencyApproveInterval)
+	defer approveTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			db.Close() //nolint:errcheck
+			db.logger.Info("DB is stopped", componentName)
+			close(closeCh)
+
+			return
+		case <-purgeCh:
+			db.purgeTrash(ctx, time.Now().Add(-db.trashRetention), componentName)
+		case <-approveTicker.C:
+			db.approveEmergencyAccesses(ctx, time.Now(), componentName)
+		case <-gcCh:
+			db.collectBlobs(ctx, componentName)
+		case <-db.blobs.gcCh:
+			db.collectBlobs(ctx, componentName)
+		}
+	}
+}
+
+// listenRevisions receives notifications about new vaults' revisions and sends them to watchers.
+//
+// Notifications are sent by all server instances, so watchers receive changes made through any
+// instance. Listening connection is reestablished after failure, watchers are unavailable until then.
+func (db *Posgtre) listenRevisions(ctx context.Context, componentName string) {
+	for {
+		if err := db.listen(ctx, componentName); err != nil && ctx.Err() == nil {
+			db.logger.Error(err, "listen vaults' revisions", componentName)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// listen is a helper function, which listens notifications about new vaults' revisions on
+// dedicated connection until error occurs.
+func (db *Posgtre) listen(ctx context.Context, componentName string) error {
+	poolConn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background()) //nolint:errcheck
+
+	if _, err := conn.Exec(ctx, "listen "+vaultRevisionChannel); err != nil {
+		return err
+	}
+
+	db.watchers.setAvailable(true)
+	defer db.watchers.setAvailable(false)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		username, revision, err := parseRevisionNotification(notification.Payload)
+		if err != nil {
+			db.logger.Warn(err, "skip notification", componentName)
+			continue
+		}
+
+		db.watchers.publish(username, revision)
+	}
+}
+
+// WatchRevision returns channel, which receives new revisions of user's vault until context is done.
+//
+// If notifications are not listened at the moment returns ErrWatchUnavailable. Channel is closed
+// when listening is interrupted, so changes made since may be missed.
+func (db *Posgtre) WatchRevision(ctx context.Context, username Username) (<-chan int64, error) {
+	return db.watchers.subscribe(ctx, username)
 }
 
 // Clear is used to delete all database's tables and records.
@@ -142,23 +265,33 @@ func (db *Posgtre) Clear(ctx context.Context) {
 
 	componentName := "Postgre:clear"
 
-	rTables := make([]PGTable, (len(db.tables)))
-	for i := 0; i < len(rTables); i++ {
-		rTables[i] = db.tables[len(rTables)-1-i]
+	var tables []string
+
+	sqlStmt := `select tablename from pg_tables where schemaname = current_schema()`
+	if err := pgxscan.Select(ctx, db.pool, &tables, sqlStmt); err != nil {
+		db.logger.Error(err, "list tables", componentName)
+
+		return
 	}
 
-	for _, t := range rTables {
-		ct, err := db.pool.Exec(ctx, fmt.Sprintf("drop table if exists %s cascade", t.Name))
+	for _, t := range tables {
+		ct, err := db.pool.Exec(ctx, fmt.Sprintf("drop table if exists %s cascade", t))
 		if err != nil {
-			db.logger.Error(err, fmt.Sprintf("table '%s': %s", t.Name, ct.String()), componentName)
+			db.logger.Error(err, fmt.Sprintf("table '%s': %s", t, ct.String()), componentName)
 
 			continue
 		}
 
-		db.logger.Info(fmt.Sprintf("table '%s': %s", t.Name, ct.String()), "componentName")
+		db.logger.Info(fmt.Sprintf("table '%s': %s", t, ct.String()), componentName)
 	}
 }
 
+// GetMaxSecretSize returns maximum available size of secret.
 func (db *Posgtre) GetMaxSecretSize() uint32 {
 	return db.maxSecretSize
 }
+
+// GetUserQuota returns per-user storage quota.
+func (db *Posgtre) GetUserQuota() Quota {
+	return db.quota
+}
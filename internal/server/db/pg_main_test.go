@@ -3,13 +3,16 @@ package db
 
 import (
 	"context"
+	"io"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -50,6 +53,7 @@ var (
 		Additions: &pb.Additions{
 			Uris:         []byte("uris"),
 			CustomFields: []byte("custom_fields"),
+			Tags:         []byte("tags"),
 		},
 	}
 
@@ -123,11 +127,13 @@ func TestMain(m *testing.M) {
 
 	if testDB, err = newPosgtre(&testDBConnParams, testLogger); err != nil {
 		log.Printf("No testing database is available: %v\nSkikipping DB tests", err)
-		os.Exit(0)
+		testDB = nil
+		os.Exit(m.Run())
 	}
 	if err = testDB.Connect(ctx); err != nil {
 		log.Printf("No testing database is available: %v\nSkikipping DB tests", err)
-		os.Exit(0)
+		testDB = nil
+		os.Exit(m.Run())
 	}
 	testDB.Clear(ctx)
 	testDB.Setup(ctx)
@@ -161,6 +167,69 @@ func TestMain(m *testing.M) {
 	os.Exit(exitCode)
 }
 
+// newTestChunkReader is a helper function which returns ChunkReader, returning provided
+// chunks one by one. If failErr is not nil it's returned after all chunks instead of io.EOF.
+func newTestChunkReader(chunks [][]byte, failErr error) ChunkReader {
+	i := 0
+
+	return func() ([]byte, error) {
+		if i == len(chunks) {
+			if failErr != nil {
+				return nil, failErr
+			}
+
+			return nil, io.EOF
+		}
+
+		i++
+
+		return chunks[i-1], nil
+	}
+}
+
+// skipWithoutPostgres is a helper function, which skips test, if no testing database is available.
+func skipWithoutPostgres(t *testing.T) {
+	t.Helper()
+
+	if testDB == nil {
+		t.Skip("no testing database is available")
+	}
+}
+
+// newTestPostgres is a helper function which creates Posgtre in new schema of testing database
+// filled with testing users and items, so tests don't depend on records of shared testDB.
+// Test is skipped if no testing database is available. Schema is dropped after test.
+func newTestPostgres(t *testing.T) *Posgtre {
+	t.Helper()
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	_, err := testDB.pool.Exec(ctx, "create schema "+schema)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, err := testDB.pool.Exec(ctx, "drop schema "+schema+" cascade")
+		assert.NoError(t, err)
+	})
+
+	params := testDBConnParams
+	params.address += "?search_path=" + schema
+
+	db, err := newPosgtre(&params, mocklogger.NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, db.Connect(ctx))
+
+	t.Cleanup(db.pool.Close)
+
+	require.NoError(t, db.Setup(ctx))
+
+	fillTestDB(t, db)
+
+	return db
+}
+
 // Tests.
 
 func TestNewPostgre(t *testing.T) {
@@ -203,6 +272,8 @@ func TestPostgre_Connect(t *testing.T) {
 }
 
 func TestPostgre_ConnectAndSetupRun(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	logger := mocklogger.NewMockLogger()
 	db, err := newPosgtre(&testDBConnParams, logger)
 	require.NoError(t, err)
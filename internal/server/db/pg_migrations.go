@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// pgMigrationsLockID is a key of PostgreSQL advisory lock, which prevents concurrent
+// migrations from several server instances.
+const pgMigrationsLockID = int64(0x676b5f6d6967)
+
+// migrate applies all pending migrations.
+//
+// All server instances take the same advisory lock before migrate, so only one
+// instance applies migrations, others wait and find them already applied.
+// Every migration is applied in separate transaction.
+func (db *Posgtre) migrate(ctx context.Context) error {
+	componentName := "Postgre:migrate"
+
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return stackErrors(ErrTransactionFailed, err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `select pg_advisory_lock($1)`, pgMigrationsLockID); err != nil {
+		return wrapPgError(err)
+	}
+
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `select pg_advisory_unlock($1)`,
+			pgMigrationsLockID); err != nil {
+			db.logger.Error(err, "release migrations lock", componentName)
+		}
+	}()
+
+	sqlStmt := `
+		create table if not exists ` + migrationsTable + ` (
+			version bigint primary key,
+			name varchar not null,
+			applied timestamptz not null default now()
+		)`
+
+	if _, err := conn.Exec(ctx, sqlStmt); err != nil {
+		return wrapPgError(err)
+	}
+
+	applied, err := db.getAppliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range db.migrations {
+		if applied[m.Version] {
+			continue
+		}
+
+		if err := db.applyMigration(ctx, conn, m); err != nil {
+			db.logger.Error(err, fmt.Sprintf("migration %d_%s", m.Version, m.Name), componentName)
+			return err
+		}
+
+		db.logger.Info(fmt.Sprintf("migration %d_%s is applied", m.Version, m.Name), componentName)
+	}
+
+	return nil
+}
+
+// getAppliedMigrations returns set of already applied migrations' versions.
+func (db *Posgtre) getAppliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int64]bool, error) {
+	rows, err := conn.Query(ctx, `select version from `+migrationsTable)
+	if err != nil {
+		return nil, wrapPgError(err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, stackErrors(ErrInternalDBError, err)
+		}
+
+		applied[version] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	return applied, nil
+}
+
+// applyMigration applies migration and saves it's version in one transaction.
+func (db *Posgtre) applyMigration(ctx context.Context, conn *pgxpool.Conn, m Migration) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return stackErrors(ErrTransactionFailed, err)
+	}
+	defer db.deferTxRollback(ctx, tx)
+
+	if _, err := tx.Exec(ctx, m.Statement); err != nil {
+		return wrapPgError(err)
+	}
+
+	if _, err := tx.Exec(ctx, `insert into `+migrationsTable+` (version, name) values ($1, $2)`,
+		m.Version, m.Name); err != nil {
+		return wrapPgError(err)
+	}
+
+	return db.commitTx(ctx, tx, "Postgre:applyMigration")
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestPosgtre_Organizations(t *testing.T) {
+	testOrganizations(t, newTestPostgres(t))
+}
@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// CreateSession records user's session of issued token and deletes user's expired sessions.
+//
+// If no users were found CreateSession returns error (ErrNotFound).
+func (db *Posgtre) CreateSession(ctx context.Context, username Username, session *pb.Session) error {
+	componentName := "Posgtre:CreateSession"
+
+	stmtExpired, argsExpired, err := newExpiredSessionsDeleteStmt(db.psql, username)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtSession, argsSession, err := newSessionInsertStmt(db.psql, username, session)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtExpired, argsExpired), componentName)
+
+	if _, err := db.pool.Exec(ctx, stmtExpired, argsExpired...); err != nil {
+		return wrapPgError(err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtSession, argsSession), componentName)
+
+	ct, err := db.pool.Exec(ctx, stmtSession, argsSession...)
+	if wrappedErr := wrapPgError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if ct.RowsAffected() < 1 {
+		return stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return nil
+}
+
+// TouchSession updates last seen time and client's address of user's active session.
+//
+// If session is revoked or expired TouchSession returns error (ErrNotFound).
+func (db *Posgtre) TouchSession(ctx context.Context, username Username, id string, clientIP string) error {
+	componentName := "Posgtre:TouchSession"
+
+	stmtSession, argsSession, err := newSessionTouchStmt(db.psql, username, id, clientIP)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtSession, argsSession), componentName)
+
+	ct, err := db.pool.Exec(ctx, stmtSession, argsSession...)
+	if wrappedErr := wrapPgError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if ct.RowsAffected() < 1 {
+		return stackErrors(ErrNotFound, errors.New(id))
+	}
+
+	return nil
+}
+
+// GetSessions returns user's active sessions, last seen first.
+func (db *Posgtre) GetSessions(ctx context.Context, username Username) ([]*pb.Session, error) {
+	componentName := "Posgtre:GetSessions"
+
+	stmtSessions, argsSessions, err := newSessionsSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtSessions, argsSessions), componentName)
+
+	var dbSessions []*Session
+	if err := pgxscan.Select(ctx, db.pool, &dbSessions, stmtSessions, argsSessions...); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	sessions := make([]*pb.Session, 0, len(dbSessions))
+	for _, session := range dbSessions {
+		sessions = append(sessions, session.toPB())
+	}
+
+	return sessions, nil
+}
+
+// GetSessionByRefreshHash returns user's active session with provided refresh token's hash.
+//
+// If there is no such session GetSessionByRefreshHash returns error (ErrNotFound).
+func (db *Posgtre) GetSessionByRefreshHash(ctx context.Context, username Username,
+	refreshHash string) (*pb.Session, error) {
+	componentName := "Posgtre:GetSessionByRefreshHash"
+
+	stmtSession, argsSession, err := newSessionByRefreshHashSelect(db.psql, username, refreshHash)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtSession, argsSession), componentName)
+
+	session := new(Session)
+	if err := pgxscan.Get(ctx, db.pool, session, stmtSession, argsSession...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapPgError(err)
+	}
+
+	return session.toPB(), nil
+}
+
+// RotateSession replaces ID, refresh token's hash and expiration time of user's active session,
+// last seen time and client's address are updated too.
+//
+// If session is revoked or expired RotateSession returns error (ErrNotFound).
+func (db *Posgtre) RotateSession(ctx context.Context, username Username, id string, session *pb.Session) error {
+	componentName := "Posgtre:RotateSession"
+
+	stmtSession, argsSession, err := newSessionRotateStmt(db.psql, username, id, session)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtSession, argsSession), componentName)
+
+	ct, err := db.pool.Exec(ctx, stmtSession, argsSession...)
+	if wrappedErr := wrapPgError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if ct.RowsAffected() < 1 {
+		return stackErrors(ErrNotFound, errors.New(id))
+	}
+
+	return nil
+}
+
+// DeleteSession deletes user's session, so session's token is revoked.
+//
+// If session doesn't exist DeleteSession returns error (ErrNotFound).
+func (db *Posgtre) DeleteSession(ctx context.Context, username Username, id string) error {
+	if id == "" {
+		return stackErrors(ErrNotFound, errors.New("empty session's ID"))
+	}
+
+	n, err := db.deleteSessions(ctx, username, id, "Posgtre:DeleteSession")
+	if err != nil {
+		return err
+	}
+
+	if n < 1 {
+		return stackErrors(ErrNotFound, errors.New(id))
+	}
+
+	return nil
+}
+
+// DeleteSessions deletes all user's sessions, so all user's tokens are revoked.
+func (db *Posgtre) DeleteSessions(ctx context.Context, username Username) error {
+	_, err := db.deleteSessions(ctx, username, "", "Posgtre:DeleteSessions")
+
+	return err
+}
+
+// deleteSessions is a helper function, which deletes user's session (all sessions for empty ID)
+// and returns number of deleted sessions.
+func (db *Posgtre) deleteSessions(ctx context.Context, username Username, id string,
+	componentName string) (int64, error) {
+	stmtSessions, argsSessions, err := newSessionDeleteStmt(db.psql, username, id)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtSessions, argsSessions), componentName)
+
+	ct, err := db.pool.Exec(ctx, stmtSessions, argsSessions...)
+	if wrappedErr := wrapPgError(err); wrappedErr != nil {
+		return 0, wrappedErr
+	}
+
+	return ct.RowsAffected(), nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestPosgtre_Sessions(t *testing.T) {
+	testSessions(t, newTestPostgres(t))
+}
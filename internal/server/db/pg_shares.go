@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+// ShareItem shares owner's item with recipient and returns share's ID.
+//
+// If item is already shared with recipient, share is replaced.
+// Share's data is counted in owner's usage, if owner's quota of stored data is exceeded
+// returns ErrQuotaExceeded.
+func (db *Posgtre) ShareItem(ctx context.Context, owner Username, share *pb.Share) (int64, error) {
+	if owner == "" {
+		return 0, ErrNotFound
+	}
+
+	componentName := "Postgre:ShareItem"
+
+	if err := checkNewShare(share); err != nil {
+		return 0, err
+	}
+
+	stmtShare, argsShare, err := newShareUpsertStmt(db.psql, owner, share)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtShare, argsShare), componentName)
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.deferTxRollback(ctx, tx)
+
+	var id int64
+	if err := tx.QueryRow(ctx, stmtShare, argsShare...).Scan(&id); err != nil {
+		if pgxscan.NotFound(err) {
+			return 0, errNoRowsAffected()
+		}
+
+		return 0, wrapPgError(err)
+	}
+
+	if db.quota.Enabled() {
+		usage, err := db.getUserUsage(ctx, tx, owner, componentName)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := db.quota.check(usage, false); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := db.commitTx(ctx, tx, componentName); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetReceivedShares returns items shared with user.
+func (db *Posgtre) GetReceivedShares(ctx context.Context, username Username) ([]*pb.Share, error) {
+	componentName := "Postgre:GetReceivedShares"
+
+	stmtShares, argsShares, err := newReceivedSharesSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.getShares(ctx, stmtShares, argsShares, componentName)
+}
+
+// GetSentShares returns shares of user's items without keys and data.
+func (db *Posgtre) GetSentShares(ctx context.Context, username Username) ([]*pb.Share, error) {
+	componentName := "Postgre:GetSentShares"
+
+	stmtShares, argsShares, err := newSentSharesSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.getShares(ctx, stmtShares, argsShares, componentName)
+}
+
+// getShares is a helper function, which selects shares with provided query.
+func (db *Posgtre) getShares(ctx context.Context, stmtShares SQLStatement, argsShares []interface{},
+	componentName string) ([]*pb.Share, error) {
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtShares, argsShares), componentName)
+
+	var dbShares []*Share
+	if err := pgxscan.Select(ctx, db.pool, &dbShares, stmtShares, argsShares...); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	shares := make([]*pb.Share, 0, len(dbShares))
+	for _, share := range dbShares {
+		shares = append(shares, share.toPB())
+	}
+
+	return shares, nil
+}
+
+// RevokeShare deletes share of owner's item.
+func (db *Posgtre) RevokeShare(ctx context.Context, owner Username, shareID int64) error {
+	if owner == "" {
+		return ErrNotFound
+	}
+
+	componentName := "Postgre:RevokeShare"
+
+	stmtShare, argsShare, err := newShareDeleteStmt(db.psql, owner, shareID)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(pgx.Batch)
+	b.Queue(stmtShare, argsShare...)
+
+	return db.runBatch(ctx, b, componentName)
+}
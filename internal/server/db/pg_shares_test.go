@@ -0,0 +1,11 @@
+package db
+
+import "testing"
+
+func TestPosgtre_Shares(t *testing.T) {
+	testShares(t, newTestPostgres(t))
+}
+
+func TestPosgtre_UserKeys(t *testing.T) {
+	testUserKeys(t, newTestPostgres(t))
+}
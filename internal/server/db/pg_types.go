@@ -11,47 +11,308 @@ import (
 
 // User represents database's user record (raw from users table).
 type User struct {
-	ID       int       `db:"id"`
-	Username string    `db:"username"`
-	Email    *string   `db:"email"`
-	Pwdhash  *string   `db:"pwdhash"`
-	OTPKey   *string   `db:"otpkey"`
-	Ekey     []byte    `db:"ekey"`
-	Revision []byte    `db:"revision"`
-	Updated  time.Time `db:"updated"`
-	Regdate  time.Time `db:"regdate"`
+	ID         int       `db:"id"`
+	Username   string    `db:"username"`
+	Email      *string   `db:"email"`
+	Pwdhash    *string   `db:"pwdhash"`
+	OTPKey     *string   `db:"otpkey"`
+	Ekey       []byte    `db:"ekey"`
+	Revision   int64     `db:"revision"`
+	Updated    time.Time `db:"updated"`
+	Regdate    time.Time `db:"regdate"`
+	PublicKey  []byte    `db:"public_key"`
+	PrivateKey []byte    `db:"private_key"`
 }
 
 // toPB converts User to protobuf format.
 func (u User) toPB() *pb.User {
 	return &pb.User{
-		Username: u.Username,
-		Email:    u.Email,
-		Pwdhash:  u.Pwdhash,
-		OtpKey:   u.OTPKey,
-		Ekey:     u.Ekey,
-		Revision: u.Revision,
-		Updated:  timestamppb.New(u.Updated),
-		Regdate:  timestamppb.New(u.Regdate),
+		Username:   u.Username,
+		Email:      u.Email,
+		Pwdhash:    u.Pwdhash,
+		OtpKey:     u.OTPKey,
+		Ekey:       u.Ekey,
+		Revision:   &u.Revision,
+		Updated:    timestamppb.New(u.Updated),
+		Regdate:    timestamppb.New(u.Regdate),
+		PublicKey:  u.PublicKey,
+		PrivateKey: u.PrivateKey,
 	}
 }
 
+// ItemChange represents last change of item recorded in change log (raw from item_changes table).
+type ItemChange struct {
+	ItemID          int64 `db:"item_id"`
+	CreatedRevision int64 `db:"created_revision"`
+	Revision        int64 `db:"revision"`
+	Deleted         bool  `db:"deleted"`
+}
+
 // ItemShort represents short message information from database.
 type ItemShort struct {
-	ID      int64     `db:"id"`
-	Name    string    `db:"name"`
-	Type    string    `db:"type"`
-	Updated time.Time `db:"updated"`
-	Hash    []byte    `db:"hash"`
+	ID       int64      `db:"id"`
+	Name     string     `db:"name"`
+	Type     string     `db:"type"`
+	Updated  time.Time  `db:"updated"`
+	Hash     []byte     `db:"hash"`
+	Deleted  *time.Time `db:"deleted_at"`
+	FolderID *int64     `db:"folder_id"`
+	Tags     []byte     `db:"tags"`
 }
 
 // toPB converts ItemShort to protobuf format.
 func (i ItemShort) toPB() *pb.ItemShort {
-	return &pb.ItemShort{
-		Id:      i.ID,
-		Name:    i.Name,
-		Type:    i.Type,
-		Updated: timestamppb.New(i.Updated),
-		Hash:    i.Hash,
+	item := &pb.ItemShort{
+		Id:       i.ID,
+		Name:     i.Name,
+		Type:     i.Type,
+		Updated:  timestamppb.New(i.Updated),
+		Hash:     i.Hash,
+		FolderId: i.FolderID,
+		Tags:     i.Tags,
+	}
+
+	if i.Deleted != nil {
+		item.Deleted = timestamppb.New(*i.Deleted)
+	}
+
+	return item
+}
+
+// Folder represents folder's record from database (raw from folders table).
+type Folder struct {
+	ID      int64     `db:"id"`
+	Name    []byte    `db:"name"`
+	Updated time.Time `db:"updated"`
+}
+
+// toPB converts Folder to protobuf format.
+func (f Folder) toPB() *pb.Folder {
+	return &pb.Folder{
+		Id:      f.ID,
+		Name:    f.Name,
+		Updated: timestamppb.New(f.Updated),
+	}
+}
+
+// Share represents share of item from database (raw from item_shares table).
+type Share struct {
+	ID        int64     `db:"id"`
+	ItemID    int64     `db:"item_id"`
+	Owner     string    `db:"owner"`
+	Recipient string    `db:"recipient"`
+	Skey      []byte    `db:"skey"`
+	Data      []byte    `db:"data"`
+	Updated   time.Time `db:"updated"`
+}
+
+// toPB converts Share to protobuf format.
+func (s Share) toPB() *pb.Share {
+	return &pb.Share{
+		Id:        s.ID,
+		ItemId:    s.ItemID,
+		Owner:     s.Owner,
+		Recipient: s.Recipient,
+		Skey:      s.Skey,
+		Data:      s.Data,
+		Updated:   timestamppb.New(s.Updated),
+	}
+}
+
+// Organization represents user's organization from database (raw from organizations
+// and org_members tables).
+type Organization struct {
+	ID       int64     `db:"id"`
+	Name     string    `db:"name"`
+	Role     string    `db:"role"`
+	Accepted bool      `db:"accepted"`
+	Okey     []byte    `db:"okey"`
+	Created  time.Time `db:"created"`
+}
+
+// toPB converts Organization to protobuf format.
+func (o Organization) toPB() *pb.Organization {
+	return &pb.Organization{
+		Id:       o.ID,
+		Name:     o.Name,
+		Role:     o.Role,
+		Accepted: o.Accepted,
+		Okey:     o.Okey,
+		Created:  timestamppb.New(o.Created),
+	}
+}
+
+// Member represents organization's member from database (raw from org_members table).
+type Member struct {
+	Username string `db:"username"`
+	Role     string `db:"role"`
+	Accepted bool   `db:"accepted"`
+}
+
+// toPB converts Member to protobuf format.
+func (m Member) toPB() *pb.Member {
+	return &pb.Member{
+		Username: m.Username,
+		Role:     m.Role,
+		Accepted: m.Accepted,
+	}
+}
+
+// EmergencyAccess represents emergency access from database (raw from emergency_access table).
+type EmergencyAccess struct {
+	ID           int64      `db:"id"`
+	Grantor      string     `db:"grantor"`
+	Grantee      string     `db:"grantee"`
+	Mode         string     `db:"mode"`
+	Status       string     `db:"status"`
+	WaitDays     int32      `db:"wait_days"`
+	Ekey         []byte     `db:"ekey"`
+	Requested    *time.Time `db:"requested"`
+	ApproveAfter *time.Time `db:"approve_after"`
+	Created      time.Time  `db:"created"`
+}
+
+// toPB converts EmergencyAccess to protobuf format.
+func (a EmergencyAccess) toPB() *pb.EmergencyAccess {
+	access := &pb.EmergencyAccess{
+		Id:       a.ID,
+		Grantor:  a.Grantor,
+		Grantee:  a.Grantee,
+		Mode:     a.Mode,
+		Status:   a.Status,
+		WaitDays: a.WaitDays,
+		Ekey:     a.Ekey,
+		Created:  timestamppb.New(a.Created),
+	}
+
+	if a.Requested != nil {
+		access.Requested = timestamppb.New(*a.Requested)
+	}
+
+	if a.ApproveAfter != nil {
+		access.ApproveAfter = timestamppb.New(*a.ApproveAfter)
+	}
+
+	return access
+}
+
+// ItemVersion represents item's previous version from database (raw from item_versions table).
+type ItemVersion struct {
+	Version      int64     `db:"version"`
+	ItemID       int64     `db:"item_id"`
+	Name         string    `db:"name"`
+	Type         string    `db:"type"`
+	Reprompt     *bool     `db:"reprompt"`
+	Updated      time.Time `db:"updated"`
+	Hash         []byte    `db:"hash"`
+	Notes        []byte    `db:"notes"`
+	Secret       []byte    `db:"secret"`
+	URIs         []byte    `db:"uris"`
+	CustomFields []byte    `db:"custom_fields"`
+	Tags         []byte    `db:"tags"`
+	BlobKey      *string   `db:"blob_key"`
+	BlobSize     int64     `db:"blob_size"`
+}
+
+// storedSecret returns version's secret in format of secrets table.
+func (v ItemVersion) storedSecret() storedSecret {
+	return storedSecret{Secret: v.Secret, BlobKey: v.BlobKey, BlobSize: v.BlobSize}
+}
+
+// toPB converts ItemVersion to protobuf format.
+//
+// Secret stored in blob store is not set.
+func (v ItemVersion) toPB() *pb.ItemVersion {
+	return &pb.ItemVersion{
+		Version: v.Version,
+		Item: &pb.Item{
+			Id:       v.ItemID,
+			Name:     v.Name,
+			Type:     v.Type,
+			Reprompt: v.Reprompt,
+			Updated:  timestamppb.New(v.Updated),
+			Hash:     v.Hash,
+			Secrets: &pb.Secrets{
+				Notes:  v.Notes,
+				Secret: v.Secret,
+			},
+			Additions: &pb.Additions{
+				Uris:         v.URIs,
+				CustomFields: v.CustomFields,
+				Tags:         v.Tags,
+			},
+		},
+	}
+}
+
+// UserInfo represents user's account information for administrators (raw from users table).
+type UserInfo struct {
+	Username  string    `db:"username"`
+	Email     *string   `db:"email"`
+	IsAdmin   bool      `db:"is_admin"`
+	Locked    bool      `db:"locked"`
+	TwoFactor bool      `db:"two_factor"`
+	Regdate   time.Time `db:"regdate"`
+	Updated   time.Time `db:"updated"`
+}
+
+// toPB converts UserInfo to protobuf format.
+func (u UserInfo) toPB() *pb.UserInfo {
+	return &pb.UserInfo{
+		Username:  u.Username,
+		Email:     u.Email,
+		IsAdmin:   u.IsAdmin,
+		Locked:    u.Locked,
+		TwoFactor: u.TwoFactor,
+		Regdate:   timestamppb.New(u.Regdate),
+		Updated:   timestamppb.New(u.Updated),
+	}
+}
+
+// AuditEvent represents user's audit event (raw from audit_events table).
+type AuditEvent struct {
+	ID       int64     `db:"id"`
+	Username string    `db:"username"`
+	Event    string    `db:"event"`
+	Method   string    `db:"method"`
+	ItemID   *int64    `db:"item_id"`
+	ClientIP string    `db:"client_ip"`
+	Created  time.Time `db:"created"`
+}
+
+// toPB converts AuditEvent to protobuf format.
+func (e AuditEvent) toPB() *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Id:       e.ID,
+		Username: e.Username,
+		Event:    e.Event,
+		Method:   e.Method,
+		ItemId:   e.ItemID,
+		ClientIp: e.ClientIP,
+		Created:  timestamppb.New(e.Created),
+	}
+}
+
+// Session represents user's session (raw from sessions table).
+type Session struct {
+	ID          string    `db:"id"`
+	Device      string    `db:"device"`
+	ClientIP    string    `db:"client_ip"`
+	Created     time.Time `db:"created"`
+	LastSeen    time.Time `db:"last_seen"`
+	Expires     time.Time `db:"expires"`
+	RefreshHash string    `db:"refresh_hash"`
+}
+
+// toPB converts Session to protobuf format.
+func (s Session) toPB() *pb.Session {
+	return &pb.Session{
+		Id:          s.ID,
+		Device:      s.Device,
+		ClientIp:    s.ClientIP,
+		Created:     timestamppb.New(s.Created),
+		LastSeen:    timestamppb.New(s.LastSeen),
+		Expires:     timestamppb.New(s.Expires),
+		RefreshHash: s.RefreshHash,
 	}
 }
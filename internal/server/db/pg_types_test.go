@@ -17,7 +17,7 @@ func TestUser_toPB(t *testing.T) {
 		Pwdhash:  common.PtrTo("pwdhash"),
 		OtpKey:   common.PtrTo("SDJAWMAASIDQ<:DFNCZASD"),
 		Ekey:     []byte("ekey"),
-		Revision: []byte("revision"),
+		Revision: common.PtrTo(int64(7)),
 		Updated:  timestamppb.New(time.Now()),
 		Regdate:  timestamppb.New(time.Now()),
 	}
@@ -29,7 +29,7 @@ func TestUser_toPB(t *testing.T) {
 		Pwdhash:  pbUser.Pwdhash,
 		OTPKey:   pbUser.OtpKey,
 		Ekey:     pbUser.Ekey,
-		Revision: pbUser.Revision,
+		Revision: *pbUser.Revision,
 		Updated:  pbUser.Updated.AsTime(),
 		Regdate:  pbUser.Regdate.AsTime(),
 	}
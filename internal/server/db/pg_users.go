@@ -22,8 +22,9 @@ func (db *Posgtre) CreateUser(ctx context.Context, user *pb.User) error {
 
 	stmtUser, argsUser, err := db.psql.
 		Insert("users").
-		Columns("username, email, pwdhash, otpkey, ekey, updated, regdate").
-		Values(user.Username, user.Email, user.Pwdhash, user.OtpKey, user.Ekey, regdate, regdate).ToSql()
+		Columns("username, email, pwdhash, otpkey, ekey, public_key, private_key, updated, regdate").
+		Values(user.Username, user.Email, user.Pwdhash, user.OtpKey, user.Ekey,
+			user.PublicKey, user.PrivateKey, regdate, regdate).ToSql()
 	if err != nil {
 		return stackErrors(ErrInternalDBError, err)
 	}
@@ -45,7 +46,7 @@ func (db *Posgtre) GetUserByName(ctx context.Context, username Username) (*pb.Us
 	componentName := "Posgtre:GetUserByName"
 
 	stmtUser, argsUser, err := db.psql.
-		Select("username, email, revision, pwdhash, otpkey, ekey, updated, regdate").
+		Select("username, email, revision, pwdhash, otpkey, ekey, public_key, private_key, updated, regdate").
 		From("users").Where(sq.Eq{"username": username}).ToSql()
 	if err != nil {
 		return nil, stackErrors(ErrInternalDBError, err)
@@ -112,24 +113,47 @@ func (db *Posgtre) GetUserEKey(ctx context.Context, username Username) ([]byte,
 	return ekey, nil
 }
 
+// GetUserKeys returns user's public key and private key, encrypted with user's encryption key.
+//
+// If user hasn't key pair GetUserKeys returns nil keys and nil error.
+// If no users were found GetUserKeys returns nil keys and error (ErrNotFound).
+func (db *Posgtre) GetUserKeys(ctx context.Context, username Username) ([]byte, []byte, error) {
+	componentName := "Posgtre:GetUserKeys"
+
+	sqlStmt := `select public_key, private_key from users where username = $1`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
+
+	var publicKey, privateKey []byte
+	if err := db.pool.QueryRow(ctx, sqlStmt, username).Scan(&publicKey, &privateKey); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, nil, wrapPgError(err)
+	}
+
+	return publicKey, privateKey, nil
+}
+
 // GetUserRevision returns configuration revision for particular user.
 //
-// If no users were found GetUserRevision returns empty string and error (ErrUserNotFound).
-// In case of processing error returns empty string and original error.
-func (db *Posgtre) GetUserRevision(ctx context.Context, username Username) ([]byte, error) {
+// If no users were found GetUserRevision returns zero revision and error (ErrUserNotFound).
+// In case of processing error returns zero revision and original error.
+func (db *Posgtre) GetUserRevision(ctx context.Context, username Username) (int64, error) {
 	componentName := "Posgtre:GetUserRevision"
 
 	sqlStmt := `select revision from users where username = $1`
 
 	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
 
-	var revision []byte
+	var revision int64
 	if err := db.pool.QueryRow(ctx, sqlStmt, username).Scan(&revision); err != nil {
 		if pgxscan.NotFound(err) {
-			return nil, stackErrors(ErrNotFound, err)
+			return 0, stackErrors(ErrNotFound, err)
 		}
 
-		return nil, wrapPgError(err)
+		return 0, wrapPgError(err)
 	}
 
 	return revision, nil
@@ -149,16 +173,17 @@ func (db *Posgtre) UpdateUser(ctx context.Context, user *pb.User) error {
 			pwdhash = coalesce($2, pwdhash),
 			otpkey = coalesce($3, otpkey),
 			ekey = coalesce($4, ekey),
-			revision = coalesce($5, revision),
-			updated = coalesce($6, updated)
-		WHERE username = $7`
+			public_key = coalesce($5, public_key),
+			private_key = coalesce($6, private_key),
+			updated = coalesce($7, updated)
+		WHERE username = $8`
 
 	updated := time.Now().Format(time.RFC3339)
 
 	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
 
 	ct, err := db.pool.Exec(ctx, sqlStmt, user.Email, user.Pwdhash, user.OtpKey,
-		user.Ekey, user.Revision, updated, user.Username)
+		user.Ekey, user.PublicKey, user.PrivateKey, updated, user.Username)
 	if wrappedErr := wrapPgError(err); wrappedErr != nil {
 		return wrappedErr
 	}
@@ -170,19 +195,125 @@ func (db *Posgtre) UpdateUser(ctx context.Context, user *pb.User) error {
 	return nil
 }
 
-// TODO UpdateUserSecrets updates user's password and encryption key.
+// UpdateUserSecrets updates user's password hash and encryption key.
+//
+// Both password hash and encryption key are required and updated in single statement.
+// All tokens issued to user before update are revoked.
 func (db *Posgtre) UpdateUserSecrets(ctx context.Context, user *pb.User) error {
+	componentName := "Posgtre:UpdateUserSecrets"
+
+	if user == nil || user.GetPwdhash() == "" || len(user.Ekey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("password hash and encryption key are required"))
+	}
+
+	sqlStmt := `
+		update users set
+			pwdhash = $1,
+			ekey = $2,
+			updated = $3,
+			tokens_revoked = $4
+		where username = $5`
+
+	now := time.Now()
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
+
+	ct, err := db.pool.Exec(ctx, sqlStmt, user.Pwdhash, user.Ekey,
+		now.Format(time.RFC3339), now, user.Username)
+	if wrappedErr := wrapPgError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if ct.RowsAffected() < 1 {
+		return stackErrors(ErrNotFound, errors.New(user.Username))
+	}
+
 	return nil
 }
 
-// DeleteUserByName deletes user by username.
+// GetUserTokensRevoked returns time, before which all user's tokens are revoked.
+//
+// If user's tokens were never revoked GetUserTokensRevoked returns zero time.
+// If no users were found GetUserTokensRevoked returns error (ErrNotFound).
+func (db *Posgtre) GetUserTokensRevoked(ctx context.Context, username Username) (time.Time, error) {
+	componentName := "Posgtre:GetUserTokensRevoked"
+
+	sqlStmt := `select tokens_revoked from users where username = $1`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
+
+	var revoked *time.Time
+	if err := db.pool.QueryRow(ctx, sqlStmt, username).Scan(&revoked); err != nil {
+		if pgxscan.NotFound(err) {
+			return time.Time{}, stackErrors(ErrNotFound, err)
+		}
+
+		return time.Time{}, wrapPgError(err)
+	}
+
+	if revoked == nil {
+		return time.Time{}, nil
+	}
+
+	return *revoked, nil
+}
+
+// GetUserUsage returns user's current storage usage, trashed items are included.
+func (db *Posgtre) GetUserUsage(ctx context.Context, username Username) (*Usage, error) {
+	componentName := "Posgtre:GetUserUsage"
+
+	return db.getUserUsage(ctx, db.pool, username, componentName)
+}
+
+// getUserUsage is a helper function, which calculates user's usage with provided querier
+// (connections' pool or transaction).
+func (db *Posgtre) getUserUsage(ctx context.Context, q pgxscan.Querier, username Username,
+	componentName string) (*Usage, error) {
+	stmtUsage, argsUsage, err := newUserUsageSelect(db.psql, username, db.itemVersions)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtUsage, argsUsage), componentName)
+
+	usage := new(Usage)
+	if err := pgxscan.Get(ctx, q, usage, stmtUsage, argsUsage...); err != nil {
+		return nil, wrapPgError(err)
+	}
+
+	return usage, nil
+}
+
+// GetUserState returns user's administrator role and lock state.
+//
+// If no users were found GetUserState returns error (ErrNotFound).
+func (db *Posgtre) GetUserState(ctx context.Context, username Username) (*UserState, error) {
+	componentName := "Posgtre:GetUserState"
+
+	sqlStmt := `select is_admin, locked from users where username = $1`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
+
+	state := new(UserState)
+	if err := db.pool.QueryRow(ctx, sqlStmt, username).Scan(&state.IsAdmin, &state.Locked); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapPgError(err)
+	}
+
+	return state, nil
+}
+
+// DeleteUserByName deletes user by username. Organizations' vaults are not deleted.
 //
 // In case of error during deletion DeleteUserByLogin returns error,
 // returns nil error only on successfully deletion.
 func (db *Posgtre) DeleteUserByName(ctx context.Context, username Username) error {
 	componentName := "Posgtre:DeleteUserByName"
 
-	sqlStmt := `delete from users cascade where username=$1`
+	sqlStmt := `delete from users cascade where username=$1 and id not in (select vault_id from organizations)`
 
 	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
 
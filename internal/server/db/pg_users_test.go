@@ -5,13 +5,17 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPosgtre_CreateUser(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	newUser1 := &pb.User{
 		Username: "newuser1",
 		Pwdhash:  common.PtrTo("newuser1pwdhash"),
@@ -154,6 +158,8 @@ func TestPosgtre_CreateUser(t *testing.T) {
 }
 
 func TestPosgtre_GetUserByName(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -228,6 +234,8 @@ func TestPosgtre_GetUserByName(t *testing.T) {
 }
 
 func TestPosgtre_GetUserAuthData(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -309,6 +317,8 @@ func TestPosgtre_GetUserAuthData(t *testing.T) {
 }
 
 func TestPosgtre_GetUserEKey(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -378,6 +388,8 @@ func TestPosgtre_GetUserEKey(t *testing.T) {
 }
 
 func TestPosgtre_GetUserRevision(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -388,7 +400,7 @@ func TestPosgtre_GetUserRevision(t *testing.T) {
 	tests := []struct {
 		name         string
 		args         args
-		wantRevision []byte
+		wantRevision int64
 		wantErr      bool
 		err          error
 	}{
@@ -398,7 +410,7 @@ func TestPosgtre_GetUserRevision(t *testing.T) {
 				ctx:      context.Background(),
 				username: testUser1.Username,
 			},
-			wantRevision: testUser1.Revision,
+			wantRevision: testUser1.GetRevision(),
 			wantErr:      false,
 		},
 		{
@@ -447,6 +459,8 @@ func TestPosgtre_GetUserRevision(t *testing.T) {
 }
 
 func TestPosgtre_UpdateUser(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	newEmail := common.PtrTo("newemail@mail.com")
 	newPwdHash := common.PtrTo("newupdatepwdhash")
 	newOtpKey := common.PtrTo("neasda123")
@@ -550,12 +564,79 @@ func TestPosgtre_UpdateUser(t *testing.T) {
 	})
 }
 
-// TODO UpdateUserSecrets updates user's password and encryption key.
 func TestPosgtre_UpdateUserSecrets(t *testing.T) {
-	testDB.UpdateUserSecrets(context.Background(), nil)
+	skipWithoutPostgres(t)
+
+	ctx := context.Background()
+
+	newUser := &pb.User{
+		Username: "newuserforsecrets",
+		Pwdhash:  common.PtrTo("newuserpwdhash"),
+		Ekey:     []byte("somekey"),
+	}
+
+	if err := testDB.CreateUser(ctx, newUser); err != nil {
+		t.Errorf("Postgre.UpdateUserSecrets() - failed create test user: %v", err)
+	}
+	defer func() {
+		if err := testDB.DeleteUserByName(ctx, newUser.Username); err != nil {
+			t.Errorf("Postgre.UpdateUserSecrets() - failed delete test user: %v", err)
+		}
+	}()
+
+	t.Run("Tokens are not revoked", func(t *testing.T) {
+		revoked, err := testDB.GetUserTokensRevoked(ctx, newUser.Username)
+		require.NoError(t, err)
+		assert.True(t, revoked.IsZero())
+	})
+
+	t.Run("Update secrets", func(t *testing.T) {
+		before := time.Now().Add(-time.Second)
+
+		err := testDB.UpdateUserSecrets(ctx, &pb.User{
+			Username: newUser.Username,
+			Pwdhash:  common.PtrTo("newpwdhash"),
+			Ekey:     []byte("newekey"),
+		})
+		require.NoError(t, err)
+
+		pwd, _, err := testDB.GetUserAuthData(ctx, newUser.Username)
+		require.NoError(t, err)
+		assert.Equal(t, "newpwdhash", pwd)
+
+		ekey, err := testDB.GetUserEKey(ctx, newUser.Username)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("newekey"), ekey)
+
+		revoked, err := testDB.GetUserTokensRevoked(ctx, newUser.Username)
+		require.NoError(t, err)
+		assert.True(t, revoked.After(before))
+	})
+
+	t.Run("Missed encryption key", func(t *testing.T) {
+		err := testDB.UpdateUserSecrets(ctx, &pb.User{
+			Username: newUser.Username,
+			Pwdhash:  common.PtrTo("newpwdhash"),
+		})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+	})
+
+	t.Run("Update unexisted user", func(t *testing.T) {
+		err := testDB.UpdateUserSecrets(ctx, &pb.User{
+			Username: "unexisteduser",
+			Pwdhash:  common.PtrTo("newpwdhash"),
+			Ekey:     []byte("newekey"),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = testDB.GetUserTokensRevoked(ctx, "unexisteduser")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
 }
 
 func TestPosgtre_DeleteUserByName(t *testing.T) {
+	skipWithoutPostgres(t)
+
 	newUserUsername := "newuser1fordelete"
 
 	canceledCtx, cancel := context.WithCancel(context.Background())
@@ -630,4 +711,18 @@ func TestPosgtre_DeleteUserByName(t *testing.T) {
 			t.Errorf("Postgre.DeleteUserByName() error = %v, wantErr %v", err, ErrNotFound)
 		}
 	})
+
+	t.Run("Organization's vault isn't deleted", func(t *testing.T) {
+		ctx := context.Background()
+		orgID, err := testDB.CreateOrganization(ctx, testUser2.Username, &pb.Organization{Name: "delete team",
+			Okey: []byte("okey")})
+		require.NoError(t, err)
+		defer testDB.DeleteOrganization(ctx, orgID) //nolint:errcheck
+
+		membership, err := testDB.GetMembership(ctx, orgID, testUser2.Username)
+		require.NoError(t, err)
+
+		err = testDB.DeleteUserByName(ctx, membership.Vault)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
 }
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// ErrQuotaExceeded is returned when user's item can't be stored because of user's quota.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Quota represents per-user storage limits. Zero value of limit means no limit.
+type Quota struct {
+	// Maximum number of user's items, including trashed.
+	MaxItems uint32
+	// Maximum total size of user's items' encrypted data in bytes, including trashed items,
+	// items' history and shares.
+	MaxBytes uint64
+}
+
+// Usage represents user's current storage usage.
+type Usage struct {
+	// Number of user's items, including trashed.
+	Items int64 `db:"items"`
+	// Total size of user's items' encrypted data in bytes, including items' history and shares.
+	Bytes int64 `db:"bytes"`
+}
+
+// Enabled returns true if any of quota's limits is set.
+func (q Quota) Enabled() bool {
+	return q.MaxItems > 0 || q.MaxBytes > 0
+}
+
+// check is a helper function which checks usage against quota.
+//
+// Items' number is checked only if checkItems is set, so already stored items
+// can be updated after limit has been decreased.
+func (q Quota) check(u *Usage, checkItems bool) error {
+	if checkItems && q.MaxItems > 0 && u.Items > int64(q.MaxItems) {
+		return fmt.Errorf("%w: maximum number of items is %d", ErrQuotaExceeded, q.MaxItems)
+	}
+
+	if q.MaxBytes > 0 && u.Bytes > int64(q.MaxBytes) {
+		return fmt.Errorf("%w: maximum size of stored data is %d bytes, required %d bytes",
+			ErrQuotaExceeded, q.MaxBytes, u.Bytes)
+	}
+
+	return nil
+}
+
+// newUserUsageSelect is a helper function for construct statement, which calculates user's usage.
+//
+// Both PostgreSQL and SQLite return length of binary data in bytes.
+// Data items' content is stored separately and counted by subquery, secrets stored in blob store
+// are counted by stored size. Item's versions, which are kept in history (keep newest ones, zero
+// value means all), and data of item's shares are counted too. Blob referenced by several versions
+// or by item itself is counted only once.
+func newUserUsageSelect(psql sq.StatementBuilderType, username Username,
+	keep uint32) (SQLStatement, []interface{}, error) {
+	versionsSQ := psql.
+		Select("sum(coalesce(length(v.secret), 0) + coalesce(length(v.notes), 0) + coalesce(length(v.uris), 0) + " +
+			"coalesce(length(v.custom_fields), 0) + coalesce(length(v.tags), 0) + " +
+			"case when v.blob_key is null or v.blob_key = s.blob_key or exists (select 1 from item_versions n " +
+			"where n.item_id = v.item_id and n.blob_key = v.blob_key and n.version > v.version) " +
+			"then 0 else v.blob_size end)").
+		From("item_versions v").
+		Where("v.item_id = items.id")
+
+	if keep > 0 {
+		versionsSQ = versionsSQ.
+			Where("v.version > (select max(m.version) from item_versions m where m.item_id = items.id) - ?", int64(keep))
+	}
+
+	versionsSQL, versionsArgs, err := versionsSQ.PlaceholderFormat(sq.Question).ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return psql.
+		Select("count(items.id) as items").
+		Column("coalesce(sum(coalesce(length(s.secret), 0) + coalesce(s.blob_size, 0) + coalesce(length(s.notes), 0) + "+
+			"coalesce(length(a.uris), 0) + coalesce(length(a.custom_fields), 0) + coalesce(length(a.tags), 0) + "+
+			"coalesce((select sum(length(c.data)) from secret_chunks c where c.item_id = items.id), 0) + "+
+			"coalesce(("+versionsSQL+"), 0) + "+
+			"coalesce((select sum(length(sh.data)) from item_shares sh where sh.item_id = items.id), 0)), 0) as bytes",
+			versionsArgs...).
+		From("items").
+		Join("users on items.user_id = users.id").
+		LeftJoin("secrets s on items.id = s.item_id").
+		LeftJoin("additions a on items.id = a.item_id").
+		Where(sq.Eq{"users.username": username}).
+		ToSql()
+}
+
+// itemSize is a helper function which returns size of item's encrypted data in bytes.
+func itemSize(item *pb.Item) int64 {
+	return int64(len(item.GetSecrets().GetSecret()) + len(item.GetSecrets().GetNotes()) +
+		len(item.GetAdditions().GetUris()) + len(item.GetAdditions().GetCustomFields()) +
+		len(item.GetAdditions().GetTags()))
+}
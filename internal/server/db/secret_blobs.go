@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/blobstore"
+)
+
+const (
+	// blobGCInterval defines how often unreferenced blobs are collected.
+	blobGCInterval = time.Hour
+	// blobGCGracePeriod defines age of unreferenced blob, after which blob can be collected.
+	// Blobs are stored before referencing transaction is committed, so fresh blobs are always kept.
+	blobGCGracePeriod = 10 * time.Minute
+)
+
+// storedSecret represents item's secret prepared for storing in secrets table.
+//
+// Secret is stored either in database or in blob store, in latter case Secret is nil
+// and BlobKey references blob with secret.
+type storedSecret struct {
+	Secret   []byte
+	BlobKey  *string
+	BlobSize int64
+}
+
+// secretBlobRef represents reference from item's secret to blob.
+type secretBlobRef struct {
+	ItemID  int64  `db:"item_id"`
+	BlobKey string `db:"blob_key"`
+}
+
+// secretBlobs stores secrets, which size exceeds threshold, in blob store.
+//
+// Without blob store all secrets are stored in database, but already stored blobs are still
+// required for read.
+type secretBlobs struct {
+	store         blobstore.Store
+	threshold     uint32
+	maxSecretSize uint32
+	// Channel for request garbage collection of unreferenced blobs
+	gcCh chan struct{}
+}
+
+// newSecretBlobs creates secretBlobs with provided parameters.
+func newSecretBlobs(params *Parameters) *secretBlobs {
+	return &secretBlobs{
+		store:         params.blobStore,
+		threshold:     params.blobThreshold,
+		maxSecretSize: params.maxSecretSize,
+		gcCh:          make(chan struct{}, 1),
+	}
+}
+
+// prepare returns secret prepared for storing in secrets table.
+//
+// If blob store is set and size of secret exceeds threshold, secret is stored in blob store.
+// Size of inline secret is checked by database's constraint, size of blob is checked here.
+func (b *secretBlobs) prepare(ctx context.Context, secret []byte) (storedSecret, error) {
+	if b.store == nil || len(secret) <= int(b.threshold) {
+		return storedSecret{Secret: secret}, nil
+	}
+
+	if len(secret) > int(b.maxSecretSize+4) {
+		return storedSecret{}, stackErrors(ErrConstraintViolation, fmt.Errorf("secret size %d", len(secret)))
+	}
+
+	key, err := b.store.Put(ctx, secret)
+	if err != nil {
+		return storedSecret{}, stackErrors(ErrInternalDBError, err)
+	}
+
+	return storedSecret{BlobKey: &key, BlobSize: int64(len(secret))}, nil
+}
+
+// prepareItemSecret returns item's secret prepared for storing, nil is returned if item
+// hasn't secret.
+func (b *secretBlobs) prepareItemSecret(ctx context.Context, item *pb.Item) (*storedSecret, error) {
+	if item.Secrets == nil || item.Secrets.Secret == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	secret, err := b.prepare(ctx, item.Secrets.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+// get returns content of blob with provided key.
+func (b *secretBlobs) get(ctx context.Context, key string) ([]byte, error) {
+	if b.store == nil {
+		return nil, stackErrors(ErrInternalDBError, errors.New("blob store is not configured"))
+	}
+
+	data, err := b.store.Get(ctx, key)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	return data, nil
+}
+
+// resolveItems sets items' secrets stored in blob store.
+func (b *secretBlobs) resolveItems(ctx context.Context, items []*pb.Item, refs []secretBlobRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*pb.Item, len(items))
+	for _, item := range items {
+		byID[item.Id] = item
+	}
+
+	for _, ref := range refs {
+		item, ok := byID[ref.ItemID]
+		if !ok {
+			continue
+		}
+
+		secret, err := b.get(ctx, ref.BlobKey)
+		if err != nil {
+			return err
+		}
+
+		if item.Secrets == nil {
+			item.Secrets = new(pb.Secrets)
+		}
+
+		item.Secrets.Secret = secret
+	}
+
+	return nil
+}
+
+// versionsToPB converts item's versions to protobuf format, secrets stored in blob store are
+// set from blobs.
+func (b *secretBlobs) versionsToPB(ctx context.Context, dbVersions []*ItemVersion) ([]*pb.ItemVersion, error) {
+	versions := make([]*pb.ItemVersion, 0, len(dbVersions))
+
+	for _, v := range dbVersions {
+		version := v.toPB()
+
+		if v.BlobKey != nil {
+			secret, err := b.get(ctx, *v.BlobKey)
+			if err != nil {
+				return nil, err
+			}
+
+			version.Item.Secrets.Secret = secret
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// triggerGC requests garbage collection of unreferenced blobs, request is skipped if
+// blob store is not set or collection is already requested.
+func (b *secretBlobs) triggerGC() {
+	if b.store == nil {
+		return
+	}
+
+	select {
+	case b.gcCh <- struct{}{}:
+	default:
+	}
+}
+
+// sweep deletes all blobs, which are not referenced and are older than grace period.
+//
+// Garbage collection is auxiliary, so failures are only logged.
+func (b *secretBlobs) sweep(ctx context.Context, referenced []string, logger logger.L, componentName string) {
+	refs := make(map[string]struct{}, len(referenced))
+	for _, key := range referenced {
+		refs[key] = struct{}{}
+	}
+
+	blobs, err := b.store.List(ctx)
+	if err != nil {
+		logger.Error(err, "list blobs", componentName)
+		return
+	}
+
+	expired := time.Now().Add(-blobGCGracePeriod)
+	deleted := 0
+
+	for _, blob := range blobs {
+		if _, ok := refs[blob.Key]; ok || blob.ModTime.After(expired) {
+			continue
+		}
+
+		if err := b.store.Delete(ctx, blob.Key); err != nil {
+			logger.Error(err, fmt.Sprintf("delete blob %s", blob.Key), componentName)
+			continue
+		}
+
+		deleted++
+	}
+
+	if deleted > 0 {
+		logger.Info(fmt.Sprintf("collected %d unreferenced blobs", deleted), componentName)
+	}
+}
+
+// setStoredSecret is a helper function, which sets secret's columns in secrets' update statement.
+//
+// Nil secret keeps stored one.
+func setStoredSecret(stmt sq.UpdateBuilder, secret *storedSecret) sq.UpdateBuilder {
+	if secret == nil {
+		return stmt
+	}
+
+	return stmt.
+		Set("secret", secret.Secret).
+		Set("blob_key", secret.BlobKey).
+		Set("blob_size", secret.BlobSize)
+}
+
+// newSecretBlobRefsSelect is a helper function for construct statement, which selects
+// references to blobs of user's items filtered with provided filter.
+func newSecretBlobRefsSelect(psql sq.StatementBuilderType, username Username,
+	filter sq.Sqlizer) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("s.item_id, s.blob_key").
+		From("secrets s").
+		Join("items on s.item_id = items.id").
+		Join("users on items.user_id = users.id").
+		Where(sq.Eq{"users.username": username}).
+		Where(filter).
+		Where("s.blob_key is not null").
+		ToSql()
+}
+
+// referencedBlobsSelect is a statement, which selects keys of all blobs referenced
+// by items and items' versions.
+const referencedBlobsSelect SQLStatement = `select blob_key from secrets where blob_key is not null
+	union select blob_key from item_versions where blob_key is not null`
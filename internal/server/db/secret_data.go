@@ -0,0 +1,231 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/common"
+)
+
+// ErrSecretTooBig is returned when uploaded secret data exceeds maximum size of secret.
+var ErrSecretTooBig = errors.New("secret data is too big")
+
+// errSecretDataReplaced is returned when data item's content is replaced during download or
+// upload's chunks are lost before they replaced item's content.
+var errSecretDataReplaced = errors.New("secret data was replaced by another upload")
+
+// secretUploadStalePeriod is a period, after which chunks of interrupted uploads are deleted.
+const secretUploadStalePeriod = 24 * time.Hour
+
+// secretChunkOverhead is a maximum size of chunk's encryption overhead (nonce and tag),
+// which is not counted in maximum size of secret data.
+const secretChunkOverhead = 64
+
+// ChunkReader is used by DB to receive secret data's chunks one by one.
+//
+// ChunkReader must return io.EOF after all chunks are received.
+type ChunkReader func() ([]byte, error)
+
+// ChunkWriter is used by DB to send secret data's chunks one by one in order of upload.
+type ChunkWriter func([]byte) error
+
+// checkSecretDataSize is a helper function which checks size of uploaded secret data.
+//
+// Size of every chunk includes encryption overhead, so limit is increased by overhead
+// of each received chunk.
+func checkSecretDataSize(maxSecretSize uint32, size int64, chunks int64) error {
+	if size > int64(maxSecretSize)+chunks*secretChunkOverhead {
+		return fmt.Errorf("%w: maximum size is %d bytes", ErrSecretTooBig, maxSecretSize)
+	}
+
+	return nil
+}
+
+// receiveSecretChunks is a helper function which receives secret data's chunks from next and
+// passes every chunk to store with its sequence number as soon as it's received.
+//
+// Received data is limited by maximum size of secret. Returns number and total size of chunks.
+func receiveSecretChunks(maxSecretSize uint32, next ChunkReader,
+	store func(seq int64, chunk []byte) error) (int64, int64, error) {
+	var chunks, size int64
+
+	for {
+		chunk, err := next()
+		if errors.Is(err, io.EOF) {
+			return chunks, size, nil
+		}
+
+		if err != nil {
+			return 0, 0, stackErrors(ErrOperationFailed, err)
+		}
+
+		chunks++
+		size += int64(len(chunk))
+
+		if err := checkSecretDataSize(maxSecretSize, size, chunks); err != nil {
+			return 0, 0, err
+		}
+
+		if err := store(chunks, chunk); err != nil {
+			return 0, 0, err
+		}
+	}
+}
+
+// newSecretDataItemSelect is a helper function for construct statement, which selects name
+// of user's not trashed data item.
+func newSecretDataItemSelect(psql sq.StatementBuilderType, username Username,
+	itemID int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("items.name").
+		From("items").
+		Join("users on items.user_id = users.id").
+		Where(sq.Eq{"items.id": itemID, "items.type": common.ItemTypeSecData, "users.username": username}).
+		Where("items.deleted_at is null").
+		ToSql()
+}
+
+// newSecretChunksDeleteStmt is a helper function for construct statement, which deletes
+// all item's chunks.
+func newSecretChunksDeleteStmt(psql sq.StatementBuilderType, itemID int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("secret_chunks").
+		Where(sq.Eq{"item_id": itemID}).
+		ToSql()
+}
+
+// newSecretUploadChunkInsertStmt is a helper function for construct statement, which stores
+// received chunk of upload with provided sequence number.
+func newSecretUploadChunkInsertStmt(psql sq.StatementBuilderType, uploadID string, itemID int64, seq int64,
+	data []byte) (SQLStatement, []interface{}, error) {
+	return psql.
+		Insert("secret_chunk_uploads").
+		Columns("upload_id", "item_id", "seq", "data", "created").
+		Values(uploadID, itemID, seq, data, time.Now()).
+		ToSql()
+}
+
+// newSecretUploadDeleteStmt is a helper function for construct statement, which deletes
+// all chunks of upload.
+func newSecretUploadDeleteStmt(psql sq.StatementBuilderType, uploadID string) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("secret_chunk_uploads").
+		Where(sq.Eq{"upload_id": uploadID}).
+		ToSql()
+}
+
+// newStaleSecretUploadsDeleteStmt is a helper function for construct statement, which deletes
+// chunks of interrupted uploads.
+func newStaleSecretUploadsDeleteStmt(psql sq.StatementBuilderType) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("secret_chunk_uploads").
+		Where(sq.Lt{"created": time.Now().Add(-secretUploadStalePeriod)}).
+		ToSql()
+}
+
+// newSecretUploadApplyStmt is a helper function for construct statement, which moves all chunks
+// of upload to item's content.
+//
+// Sequence numbers of chunks are shifted by offset, so new content never reuses sequence numbers
+// of previous content and download of previous content detects replacement.
+func newSecretUploadApplyStmt(psql sq.StatementBuilderType, uploadID string,
+	offset int64) (SQLStatement, []interface{}, error) {
+	chunksSQ := psql.
+		Select("item_id").
+		Column("seq + ?", offset).
+		Column("data").
+		From("secret_chunk_uploads").
+		Where(sq.Eq{"upload_id": uploadID})
+
+	return psql.
+		Insert("secret_chunks").
+		Columns("item_id", "seq", "data").
+		Select(chunksSQ).
+		ToSql()
+}
+
+// newSecretChunksRangeSelect is a helper function for construct statement, which selects
+// first and last sequence numbers of item's content. Empty content has first number greater
+// than last one.
+func newSecretChunksRangeSelect(psql sq.StatementBuilderType, itemID int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("coalesce(min(seq), 1) as first, coalesce(max(seq), 0) as last").
+		From("secret_chunks").
+		Where(sq.Eq{"item_id": itemID}).
+		ToSql()
+}
+
+// newSecretChunkSelect is a helper function for construct statement, which selects item's chunk
+// following chunk with provided sequence number, but not after last one.
+func newSecretChunkSelect(psql sq.StatementBuilderType, itemID int64, after int64,
+	last int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("seq, data").
+		From("secret_chunks").
+		Where(sq.Eq{"item_id": itemID}).
+		Where(sq.Gt{"seq": after}).
+		Where(sq.LtOrEq{"seq": last}).
+		OrderBy("seq").
+		Limit(1).
+		ToSql()
+}
+
+// secretChunksRange represents sequence numbers of first and last chunks of item's content.
+type secretChunksRange struct {
+	First int64 `db:"first"`
+	Last  int64 `db:"last"`
+}
+
+// secretChunk represents stored chunk of item's content.
+type secretChunk struct {
+	Seq  int64  `db:"seq"`
+	Data []byte `db:"data"`
+}
+
+// sendSecretChunks is a helper function, which sends item's content chunk by chunk.
+//
+// Every chunk is read by separate query just before it's sent, so neither database's connection
+// is held nor whole content is loaded while client receives data. Content is pinned by range of
+// sequence numbers, if it's replaced during download errSecretDataReplaced is returned.
+func sendSecretChunks(chunks secretChunksRange, read func(after int64) (*secretChunk, error),
+	send ChunkWriter) error {
+	for seq := chunks.First; seq <= chunks.Last; seq++ {
+		chunk, err := read(seq - 1)
+		if errors.Is(err, ErrNotFound) || (err == nil && chunk.Seq != seq) {
+			return stackErrors(ErrOperationFailed, errSecretDataReplaced)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := send(chunk.Data); err != nil {
+			return stackErrors(ErrOperationFailed, err)
+		}
+	}
+
+	return nil
+}
+
+// queueSecretDataUpdated is a helper function, which queues to batch statements for update
+// data item's hash and updated time and user's vault revision after secret data upload.
+func queueSecretDataUpdated(b batchQueuer, psql sq.StatementBuilderType, username Username,
+	itemID int64, itemName string) error {
+	updated, hash := getHashUpdatedItem(itemName, common.ItemTypeSecData)
+
+	stmtItem, argsItem, err := psql.
+		Update("items").
+		Set("updated", updated).
+		Set("hash", hash).
+		Where(sq.Eq{"id": itemID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	b.Queue(stmtItem, argsItem...)
+
+	return queueItemChange(b, psql, username, sq.Eq{"items.id": itemID}, itemUpdated)
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// sessionUserCond returns condition, which limits sessions with user's ones.
+func sessionUserCond(username Username) sq.Sqlizer {
+	return sq.Expr("user_id = (select users.id from users where username = ?)", username)
+}
+
+// newSessionInsertStmt is a helper function for construct statement, which records user's session.
+//
+// Session without creation time is recorded with current time, last seen time equals creation time.
+func newSessionInsertStmt(psql sq.StatementBuilderType, username Username,
+	session *pb.Session) (SQLStatement, []interface{}, error) {
+	created := time.Now().Truncate(time.Second)
+	if session.Created != nil {
+		created = session.Created.AsTime()
+	}
+
+	sessionSQ := psql.
+		Select("id").
+		Column(sq.Placeholders(7), session.Id, session.Device, session.ClientIp,
+			created, created, session.GetExpires().AsTime(), session.RefreshHash).
+		From("users").Where(sq.Eq{"username": username})
+
+	return psql.
+		Insert("sessions").
+		Columns("user_id, id, device, client_ip, created, last_seen, expires, refresh_hash").
+		Select(sessionSQ).ToSql()
+}
+
+// newExpiredSessionsDeleteStmt is a helper function for construct statement, which deletes
+// user's expired sessions.
+func newExpiredSessionsDeleteStmt(psql sq.StatementBuilderType, username Username) (SQLStatement,
+	[]interface{}, error) {
+	return psql.
+		Delete("sessions").
+		Where(sessionUserCond(username)).
+		Where(sq.LtOrEq{"expires": time.Now()}).ToSql()
+}
+
+// newSessionTouchStmt is a helper function for construct statement, which updates last seen time
+// and client's address of user's active session.
+func newSessionTouchStmt(psql sq.StatementBuilderType, username Username, id string,
+	clientIP string) (SQLStatement, []interface{}, error) {
+	now := time.Now()
+
+	return psql.
+		Update("sessions").
+		Set("last_seen", now.Truncate(time.Second)).
+		Set("client_ip", clientIP).
+		Where(sq.Eq{"id": id}).
+		Where(sessionUserCond(username)).
+		Where(sq.Gt{"expires": now}).ToSql()
+}
+
+// newSessionsSelect is a helper function for construct statement, which selects user's active
+// sessions last seen first.
+func newSessionsSelect(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("id, device, client_ip, created, last_seen, expires").
+		From("sessions").
+		Where(sessionUserCond(username)).
+		Where(sq.Gt{"expires": time.Now()}).
+		OrderBy("last_seen desc", "created desc").ToSql()
+}
+
+// newSessionByRefreshHashSelect is a helper function for construct statement, which selects user's
+// active session with provided refresh token's hash.
+func newSessionByRefreshHashSelect(psql sq.StatementBuilderType, username Username,
+	refreshHash string) (SQLStatement, []interface{}, error) {
+	return psql.
+		Select("id, device, client_ip, created, last_seen, expires, refresh_hash").
+		From("sessions").
+		Where(sessionUserCond(username)).
+		Where(sq.Eq{"refresh_hash": refreshHash}).
+		Where(sq.Gt{"expires": time.Now()}).ToSql()
+}
+
+// newSessionRotateStmt is a helper function for construct statement, which replaces ID, refresh
+// token's hash and expiration time of user's active session and updates its last seen time and
+// client's address.
+func newSessionRotateStmt(psql sq.StatementBuilderType, username Username, id string,
+	session *pb.Session) (SQLStatement, []interface{}, error) {
+	now := time.Now()
+
+	return psql.
+		Update("sessions").
+		Set("id", session.Id).
+		Set("refresh_hash", session.RefreshHash).
+		Set("expires", session.GetExpires().AsTime()).
+		Set("last_seen", now.Truncate(time.Second)).
+		Set("client_ip", session.ClientIp).
+		Where(sq.Eq{"id": id}).
+		Where(sessionUserCond(username)).
+		Where(sq.Gt{"expires": now}).ToSql()
+}
+
+// newSessionDeleteStmt is a helper function for construct statement, which deletes user's session.
+//
+// Empty session's ID means all user's sessions.
+func newSessionDeleteStmt(psql sq.StatementBuilderType, username Username, id string) (SQLStatement,
+	[]interface{}, error) {
+	stmt := psql.
+		Delete("sessions").
+		Where(sessionUserCond(username))
+
+	if id != "" {
+		stmt = stmt.Where(sq.Eq{"id": id})
+	}
+
+	return stmt.ToSql()
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testSessions checks users' sessions and rotation of refresh tokens.
+func testSessions(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Second)
+	newSession := func(id string, created time.Time, expires time.Time) *pb.Session {
+		return &pb.Session{
+			Id:       id,
+			Device:   "device " + id,
+			ClientIp: "10.0.0.1",
+			Created:  timestamppb.New(created),
+			Expires:  timestamppb.New(expires),
+		}
+	}
+
+	t.Run("Create sessions", func(t *testing.T) {
+		require.NoError(t, db.CreateSession(ctx, testUser1.Username,
+			newSession("first", now.Add(-time.Hour), now.Add(time.Hour))))
+		require.NoError(t, db.CreateSession(ctx, testUser1.Username,
+			newSession("second", now.Add(-time.Minute), now.Add(time.Hour))))
+		require.NoError(t, db.CreateSession(ctx, testUser2.Username,
+			newSession("other", now, now.Add(time.Hour))))
+
+		assert.ErrorIs(t, db.CreateSession(ctx, "unknown", newSession("unknown", now, now.Add(time.Hour))),
+			ErrNotFound)
+	})
+
+	t.Run("Get sessions", func(t *testing.T) {
+		sessions, err := db.GetSessions(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "second", sessions[0].Id)
+		assert.Equal(t, "device second", sessions[0].Device)
+		assert.Equal(t, "10.0.0.1", sessions[0].ClientIp)
+		assert.True(t, sessions[0].LastSeen.AsTime().Equal(sessions[0].Created.AsTime()))
+		assert.Equal(t, "first", sessions[1].Id)
+	})
+
+	t.Run("Touch session", func(t *testing.T) {
+		require.NoError(t, db.TouchSession(ctx, testUser1.Username, "first", "10.0.0.2"))
+
+		sessions, err := db.GetSessions(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "first", sessions[0].Id, "last seen session is first")
+		assert.Equal(t, "10.0.0.2", sessions[0].ClientIp)
+
+		assert.ErrorIs(t, db.TouchSession(ctx, testUser2.Username, "first", ""), ErrNotFound,
+			"other user's session")
+		assert.ErrorIs(t, db.TouchSession(ctx, testUser1.Username, "unknown", ""), ErrNotFound)
+	})
+
+	t.Run("Expired sessions", func(t *testing.T) {
+		require.NoError(t, db.CreateSession(ctx, testUser1.Username,
+			newSession("expired", now.Add(-2*time.Hour), now.Add(-time.Hour))))
+
+		assert.ErrorIs(t, db.TouchSession(ctx, testUser1.Username, "expired", ""), ErrNotFound)
+
+		sessions, err := db.GetSessions(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 2)
+	})
+
+	t.Run("Rotate session", func(t *testing.T) {
+		session := newSession("refreshed", now, now.Add(time.Hour))
+		session.RefreshHash = "hash1"
+		require.NoError(t, db.CreateSession(ctx, testUser1.Username, session))
+
+		got, err := db.GetSessionByRefreshHash(ctx, testUser1.Username, "hash1")
+		require.NoError(t, err)
+		assert.Equal(t, "refreshed", got.Id)
+		assert.Equal(t, "hash1", got.RefreshHash)
+
+		_, err = db.GetSessionByRefreshHash(ctx, testUser2.Username, "hash1")
+		assert.ErrorIs(t, err, ErrNotFound, "other user's session")
+
+		rotated := &pb.Session{
+			Id:          "rotated",
+			ClientIp:    "10.0.0.3",
+			Expires:     timestamppb.New(now.Add(2 * time.Hour)),
+			RefreshHash: "hash2",
+		}
+		require.NoError(t, db.RotateSession(ctx, testUser1.Username, "refreshed", rotated))
+		assert.ErrorIs(t, db.RotateSession(ctx, testUser1.Username, "refreshed", rotated), ErrNotFound,
+			"session is already rotated")
+		assert.ErrorIs(t, db.RotateSession(ctx, testUser1.Username, "expired", rotated), ErrNotFound)
+
+		_, err = db.GetSessionByRefreshHash(ctx, testUser1.Username, "hash1")
+		assert.ErrorIs(t, err, ErrNotFound, "previous refresh token")
+
+		got, err = db.GetSessionByRefreshHash(ctx, testUser1.Username, "hash2")
+		require.NoError(t, err)
+		assert.Equal(t, "rotated", got.Id)
+		assert.Equal(t, "device refreshed", got.Device)
+		assert.Equal(t, "10.0.0.3", got.ClientIp)
+		assert.WithinDuration(t, now, got.Created.AsTime(), time.Second)
+		assert.WithinDuration(t, now.Add(2*time.Hour), got.Expires.AsTime(), time.Second)
+
+		sessions, err := db.GetSessions(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, sessions, 3)
+
+		for _, s := range sessions {
+			assert.Empty(t, s.RefreshHash, "refresh token's hash isn't returned")
+		}
+
+		require.NoError(t, db.DeleteSession(ctx, testUser1.Username, "rotated"))
+	})
+
+	t.Run("Delete session", func(t *testing.T) {
+		require.NoError(t, db.DeleteSession(ctx, testUser1.Username, "first"))
+		assert.ErrorIs(t, db.DeleteSession(ctx, testUser1.Username, "first"), ErrNotFound)
+		assert.ErrorIs(t, db.DeleteSession(ctx, testUser1.Username, "other"), ErrNotFound,
+			"other user's session")
+		assert.ErrorIs(t, db.TouchSession(ctx, testUser1.Username, "first", ""), ErrNotFound)
+	})
+
+	t.Run("Delete all sessions", func(t *testing.T) {
+		require.NoError(t, db.DeleteSessions(ctx, testUser1.Username))
+
+		sessions, err := db.GetSessions(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+
+		sessions, err = db.GetSessions(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 1, "other user's sessions are kept")
+	})
+
+	t.Run("Sessions are deleted with user", func(t *testing.T) {
+		require.NoError(t, db.DeleteUserByName(ctx, testUser2.Username))
+		assert.ErrorIs(t, db.TouchSession(ctx, testUser2.Username, "other", ""), ErrNotFound)
+	})
+}
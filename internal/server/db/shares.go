@@ -0,0 +1,99 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// checkShare is a helper function which checks share before creation or update.
+func checkShare(share *pb.Share) error {
+	if share == nil || len(share.Data) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("shared item's data is required"))
+	}
+
+	return nil
+}
+
+// checkNewShare is a helper function which checks share before creation.
+func checkNewShare(share *pb.Share) error {
+	if err := checkShare(share); err != nil {
+		return err
+	}
+
+	if share.Recipient == "" || len(share.Skey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("recipient and share's key are required"))
+	}
+
+	return nil
+}
+
+// newShareUpsertStmt is a helper function for construct statement, which shares owner's item
+// with recipient.
+//
+// If item is already shared with recipient, share is replaced. Statement doesn't affect any rows,
+// if owner hasn't item with provided ID or recipient doesn't exist or recipient is owner.
+func newShareUpsertStmt(psql sq.StatementBuilderType, owner Username,
+	share *pb.Share) (SQLStatement, []interface{}, error) {
+	shareSQ := psql.
+		Select("items.id, r.id").
+		Column(sq.Placeholders(3), share.Skey, share.Data, time.Now().Truncate(time.Second)).
+		From("items").
+		Join("users o on items.user_id = o.id").
+		Join("users r on r.username = ?", share.Recipient).
+		Where(sq.Eq{"items.id": share.ItemId}).
+		Where(sq.Eq{"o.username": owner}).
+		Where("r.id <> o.id")
+
+	return psql.
+		Insert("item_shares").
+		Columns("item_id, recipient_id, skey, data, updated").
+		Select(shareSQ).
+		Suffix("on conflict (item_id, recipient_id) do update set " +
+			"skey = excluded.skey, data = excluded.data, updated = excluded.updated returning id").ToSql()
+}
+
+// newSharesSelect is a helper function for construct query, which selects shares with owners'
+// and recipients' usernames.
+func newSharesSelect(psql sq.StatementBuilderType) sq.SelectBuilder {
+	return psql.
+		Select("s.id, s.item_id, o.username as owner, r.username as recipient, s.updated").
+		From("item_shares s").
+		Join("items on s.item_id = items.id").
+		Join("users o on items.user_id = o.id").
+		Join("users r on s.recipient_id = r.id").
+		OrderBy("s.id")
+}
+
+// newReceivedSharesSelect is a helper function for construct statement, which selects items
+// shared with user.
+//
+// Shares of trashed items are skipped.
+func newReceivedSharesSelect(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return newSharesSelect(psql).
+		Column("s.skey, s.data").
+		Where(sq.Eq{"r.username": username}).
+		Where("items.deleted_at is null").
+		ToSql()
+}
+
+// newSentSharesSelect is a helper function for construct statement, which selects shares of
+// user's items without keys and data.
+func newSentSharesSelect(psql sq.StatementBuilderType, username Username) (SQLStatement, []interface{}, error) {
+	return newSharesSelect(psql).
+		Where(sq.Eq{"o.username": username}).
+		ToSql()
+}
+
+// newShareDeleteStmt is a helper function for construct statement, which deletes share of
+// owner's item.
+func newShareDeleteStmt(psql sq.StatementBuilderType, owner Username,
+	shareID int64) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("item_shares").
+		Where(sq.Eq{"id": shareID}).
+		Where(sq.Expr("item_id in (select items.id from items join users on items.user_id = users.id "+
+			"where users.username = ?)", owner)).ToSql()
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testShares checks sharing of items with other users.
+func testShares(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	login := getTestItem(t, db, testItemLogin)
+
+	share := &pb.Share{
+		ItemId:    login.Id,
+		Recipient: testUser2.Username,
+		Skey:      []byte("skey"),
+		Data:      []byte("data"),
+	}
+
+	var shareID int64
+
+	t.Run("Share item", func(t *testing.T) {
+		_, err := db.ShareItem(ctx, testUser1.Username, &pb.Share{ItemId: login.Id, Recipient: testUser2.Username})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+
+		_, err = db.ShareItem(ctx, testUser2.Username, share)
+		assert.ErrorIs(t, err, ErrOperationFailed, "item of another user")
+
+		_, err = db.ShareItem(ctx, testUser1.Username, &pb.Share{ItemId: login.Id, Recipient: testUser1.Username,
+			Skey: []byte("skey"), Data: []byte("data")})
+		assert.ErrorIs(t, err, ErrOperationFailed, "share with self")
+
+		_, err = db.ShareItem(ctx, testUser1.Username, &pb.Share{ItemId: login.Id, Recipient: "unknown",
+			Skey: []byte("skey"), Data: []byte("data")})
+		assert.ErrorIs(t, err, ErrOperationFailed, "unknown recipient")
+
+		shareID, err = db.ShareItem(ctx, testUser1.Username, share)
+		require.NoError(t, err)
+		assert.NotZero(t, shareID)
+
+		received, err := db.GetReceivedShares(ctx, testUser2.Username)
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		assert.Equal(t, shareID, received[0].Id)
+		assert.Equal(t, testUser1.Username, received[0].Owner)
+		assert.Equal(t, []byte("skey"), received[0].Skey)
+		assert.Equal(t, []byte("data"), received[0].Data)
+
+		sent, err := db.GetSentShares(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, sent, 1)
+		assert.Equal(t, testUser2.Username, sent[0].Recipient)
+		assert.Equal(t, login.Id, sent[0].ItemId)
+		assert.Empty(t, sent[0].Data)
+
+		received, err = db.GetReceivedShares(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, received)
+	})
+
+	t.Run("Share item again", func(t *testing.T) {
+		id, err := db.ShareItem(ctx, testUser1.Username, &pb.Share{ItemId: login.Id, Recipient: testUser2.Username,
+			Skey: []byte("newskey"), Data: []byte("newdata")})
+		require.NoError(t, err)
+		assert.Equal(t, shareID, id)
+
+		received, err := db.GetReceivedShares(ctx, testUser2.Username)
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		assert.Equal(t, []byte("newskey"), received[0].Skey)
+		assert.Equal(t, []byte("newdata"), received[0].Data, "snapshot is replaced")
+	})
+
+	t.Run("Shares of trashed item", func(t *testing.T) {
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, login.Id))
+
+		received, err := db.GetReceivedShares(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Empty(t, received)
+
+		require.NoError(t, db.RestoreItem(ctx, testUser1.Username, login.Id))
+
+		received, err = db.GetReceivedShares(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Len(t, received, 1)
+	})
+
+	t.Run("Revoke share", func(t *testing.T) {
+		err := db.RevokeShare(ctx, testUser2.Username, shareID)
+		assert.ErrorIs(t, err, ErrOperationFailed, "only owner revokes share")
+
+		require.NoError(t, db.RevokeShare(ctx, testUser1.Username, shareID))
+
+		received, err := db.GetReceivedShares(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Empty(t, received)
+
+		sent, err := db.GetSentShares(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, sent)
+
+		err = db.RevokeShare(ctx, testUser1.Username, shareID)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+
+	t.Run("Shares of purged item", func(t *testing.T) {
+		_, err := db.ShareItem(ctx, testUser1.Username, share)
+		require.NoError(t, err)
+
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, login.Id))
+		require.NoError(t, db.PurgeItem(ctx, testUser1.Username, login.Id))
+
+		sent, err := db.GetSentShares(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, sent)
+	})
+}
+
+// testUserKeys checks users' key pairs, which are used for sharing.
+func testUserKeys(t *testing.T, db DB) {
+	ctx := context.Background()
+
+	publicKey, privateKey, err := db.GetUserKeys(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Empty(t, publicKey)
+	assert.Empty(t, privateKey)
+
+	_, _, err = db.GetUserKeys(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = db.UpdateUser(ctx, &pb.User{Username: testUser1.Username, PublicKey: []byte("public"),
+		PrivateKey: []byte("private")})
+	require.NoError(t, err)
+
+	publicKey, privateKey, err = db.GetUserKeys(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("public"), publicKey)
+	assert.Equal(t, []byte("private"), privateKey)
+
+	user, err := db.GetUserByName(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("public"), user.PublicKey)
+
+	items, err := db.GetAllItems(ctx, testUser1.Username)
+	require.NoError(t, err)
+
+	err = db.RotateEncryptionKey(ctx, testUser1.Username, []byte("newkey"), nil, items, nil, nil)
+	assert.ErrorIs(t, err, ErrOperationFailed, "private key is required")
+
+	err = db.RotateEncryptionKey(ctx, testUser1.Username, []byte("newkey"), []byte("newprivate"), items, nil, nil)
+	require.NoError(t, err)
+
+	_, privateKey, err = db.GetUserKeys(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("newprivate"), privateKey)
+
+	err = db.RotateEncryptionKey(ctx, testUser2.Username, []byte("newkey"), []byte("private"), nil, nil, nil)
+	assert.ErrorIs(t, err, ErrOperationFailed, "user hasn't key pair")
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestSQLite_Admin(t *testing.T) {
+	testAdmin(t, newTestSQLite(t))
+}
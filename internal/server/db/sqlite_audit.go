@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// CreateAuditEvents records audit events.
+func (db *SQLite) CreateAuditEvents(ctx context.Context, events []*pb.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	componentName := "SQLite:CreateAuditEvents"
+
+	stmtEvents, argsEvents, err := newAuditEventsInsertStmt(db.psql, events)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtEvents, argsEvents), componentName)
+
+	_, err = db.db.ExecContext(ctx, stmtEvents, argsEvents...)
+
+	return wrapSQLiteError(err)
+}
+
+// GetAuditEvents returns at most limit user's audit events newest first, which are older than event
+// with provided ID. Zero ID means latest events.
+func (db *SQLite) GetAuditEvents(ctx context.Context, username Username, beforeID int64,
+	limit uint32) ([]*pb.AuditEvent, error) {
+	componentName := "SQLite:GetAuditEvents"
+
+	stmtEvents, argsEvents, err := newAuditEventsSelect(db.psql, username, beforeID, limit)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtEvents, argsEvents), componentName)
+
+	var dbEvents []*AuditEvent
+	if err := sqlscan.Select(ctx, db.db, &dbEvents, stmtEvents, argsEvents...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	events := make([]*pb.AuditEvent, 0, len(dbEvents))
+	for _, event := range dbEvents {
+		events = append(events, event.toPB())
+	}
+
+	return events, nil
+}
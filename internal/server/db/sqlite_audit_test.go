@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestSQLite_Audit(t *testing.T) {
+	testAudit(t, newTestSQLite(t))
+}
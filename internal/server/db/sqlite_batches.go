@@ -0,0 +1,329 @@
+package db
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+)
+
+// newCreateItemBatch is a helper function for construct sqliteBatch, used in item creation.
+//
+// Item's secret is stored as provided prepared secret.
+func (db *SQLite) newCreateItemBatch(username string, item *pb.Item, secret storedSecret) (*sqliteBatch, error) {
+	componentName := "SQLite:newCreateItemBatch"
+
+	b := new(sqliteBatch)
+
+	updated, hash := getHashUpdatedItem(item.Name, item.Type)
+
+	itemSQ := newItemFolderColumn(db.psql.
+		Select("id").
+		Column(sq.Placeholders(5), item.Name, item.Type, item.Reprompt, hash, updated).
+		From("users").Where(sq.Eq{"username": username}), username, item)
+
+	stmtItem, argsItem, err := db.psql.
+		Insert("items").
+		Columns("user_id, name, type, reprompt, hash, updated, folder_id").
+		Select(itemSQ).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	if item.Secrets == nil {
+		item.Secrets = new(pb.Secrets)
+	}
+
+	secretSQ := db.psql.
+		Select("items.id").
+		Column(sq.Placeholders(4), item.Secrets.Notes, secret.Secret, secret.BlobKey, secret.BlobSize).
+		From("items").LeftJoin("users on items.user_id=users.id").
+		Where(sq.Eq{"username": username}).
+		Where(sq.Eq{"items.name": item.Name}).
+		Where(sq.Eq{"items.type": item.Type})
+
+	stmtSecret, argsSecret, err := db.psql.
+		Insert("secrets").
+		Columns("item_id, notes, secret, blob_key, blob_size").
+		Select(secretSQ).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtSecret, argsSecret), componentName)
+	b.Queue(stmtSecret, argsSecret...)
+
+	if item.Additions == nil {
+		item.Additions = new(pb.Additions)
+	}
+
+	// Only login item can contain URIs' fields
+	if item.Type != common.ItemTypeLogin {
+		item.Additions.Uris = nil
+	}
+
+	addsSQ := db.psql.
+		Select("items.id").
+		Column(sq.Placeholders(3), item.Additions.Uris, item.Additions.CustomFields, item.Additions.Tags).
+		From("items").LeftJoin("users on items.user_id=users.id").
+		Where(sq.Eq{"username": username}).
+		Where(sq.Eq{"items.name": item.Name}).
+		Where(sq.Eq{"items.type": item.Type})
+
+	stmtAdds, argsAdds, err := db.psql.
+		Insert("additions").
+		Columns("item_id, uris, custom_fields, tags").
+		Select(addsSQ).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtAdds, argsAdds), componentName)
+	b.Queue(stmtAdds, argsAdds...)
+
+	if err := queueItemChange(b, db.psql, username, sq.Eq{"items.name": item.Name, "items.type": item.Type}, itemCreated); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// newUpdateItemBatch is a helper function for construct sqliteBatch, used for update item.
+//
+// Item's secret is replaced with provided prepared secret, nil secret keeps stored one.
+func (db *SQLite) newUpdateItemBatch(username string, item *pb.Item, secret *storedSecret) (*sqliteBatch, error) {
+	componentName := "SQLite:newUpdateItemBatch"
+
+	b := new(sqliteBatch)
+
+	if db.itemVersions > 0 {
+		stmtVersion, argsVersion, err := newItemVersionSnapshotStmt(db.psql, username, item.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtVersion, argsVersion), componentName)
+		b.Queue(stmtVersion, argsVersion...)
+	}
+
+	updated, hash := getHashUpdatedItem(item.Name, item.Type)
+
+	itemStmt := db.psql.
+		Update("items").
+		Set("name", sq.Expr("coalesce(?, name)", item.Name)).
+		Set("reprompt", sq.Expr("coalesce(?, reprompt)", item.Reprompt)).
+		Set("updated", updated).
+		Set("hash", hash).
+		Where(sq.Eq{"id": item.Id}).
+		Where("deleted_at is null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username))
+
+	stmtItem, argsItem, err := setItemFolder(itemStmt, username, item).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	if item.Secrets != nil {
+		stmtSecret, argsSecret, err := setStoredSecret(db.psql.
+			Update("secrets").
+			Set("notes", sq.Expr("coalesce(?, notes)", item.Secrets.Notes)), secret).
+			Where(sq.Eq{"item_id": item.Id}).ToSql()
+
+		if err != nil {
+			return nil, err
+		}
+
+		db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtSecret, argsSecret), componentName)
+		b.Queue(stmtSecret, argsSecret...)
+	}
+
+	if item.Additions != nil {
+		// Only login item can contain URIs' fields
+		if item.Type != common.ItemTypeLogin {
+			item.Additions.Uris = nil
+		}
+
+		stmtAdds, argsAdds, err := db.psql.
+			Update("additions").
+			Set("uris", sq.Expr("coalesce(?, uris)", item.Additions.Uris)).
+			Set("custom_fields", sq.Expr("coalesce(?, custom_fields)", item.Additions.CustomFields)).
+			Set("tags", sq.Expr("coalesce(?, tags)", item.Additions.Tags)).
+			Where(sq.Eq{"item_id": item.Id}).ToSql()
+
+		if err != nil {
+			return nil, err
+		}
+
+		db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtAdds, argsAdds), componentName)
+		b.Queue(stmtAdds, argsAdds...)
+	}
+
+	if err := queueItemChange(b, db.psql, username, sq.Eq{"items.id": item.Id}, itemUpdated); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// newDeleteItemBatch is a helper function for construct sqliteBatch, used for move item to trash.
+func (db *SQLite) newDeleteItemBatch(username string, itemID int64) (*sqliteBatch, error) {
+	componentName := "SQLite:newDeleteItemBatch"
+
+	b := new(sqliteBatch)
+
+	stmtItem, argsItem, err := db.psql.
+		Update("items").Set("deleted_at", getDeletedAt()).
+		Where(sq.Eq{"id": itemID}).
+		Where("deleted_at is null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	if err := queueItemChange(b, db.psql, username, sq.Eq{"items.id": itemID}, itemUpdated); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// newRestoreItemBatch is a helper function for construct sqliteBatch, used for restore item from trash.
+func (db *SQLite) newRestoreItemBatch(username string, itemID int64) (*sqliteBatch, error) {
+	componentName := "SQLite:newRestoreItemBatch"
+
+	b := new(sqliteBatch)
+
+	stmtItem, argsItem, err := db.psql.
+		Update("items").Set("deleted_at", nil).
+		Where(sq.Eq{"id": itemID}).
+		Where("deleted_at is not null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	if err := queueItemChange(b, db.psql, username, sq.Eq{"items.id": itemID}, itemUpdated); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// newPurgeItemBatch is a helper function for construct sqliteBatch, used for permanently delete trashed item.
+func (db *SQLite) newPurgeItemBatch(username string, itemID int64) (*sqliteBatch, error) {
+	componentName := "SQLite:newPurgeItemBatch"
+
+	b := new(sqliteBatch)
+
+	// Change is recorded before purge, while item still exists
+	if err := queueItemChange(b, db.psql, username, sq.Eq{"items.id": itemID}, itemDeleted); err != nil {
+		return nil, err
+	}
+
+	stmtItem, argsItem, err := db.psql.
+		Delete("items").Where(sq.Eq{"id": itemID}).
+		Where("deleted_at is not null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	return b, nil
+}
+
+// newRestoreItemVersionBatch is a helper function for construct sqliteBatch, used for restore
+// item's state from previous version.
+//
+// Current item's state is stored as new version before restore, if items' history is enabled.
+// Item's secret is replaced with provided prepared secret.
+func (db *SQLite) newRestoreItemVersionBatch(username string, version *pb.ItemVersion,
+	secret storedSecret) (*sqliteBatch, error) {
+	componentName := "SQLite:newRestoreItemVersionBatch"
+
+	b := new(sqliteBatch)
+	item := version.Item
+
+	if db.itemVersions > 0 {
+		stmtVersion, argsVersion, err := newItemVersionSnapshotStmt(db.psql, username, item.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtVersion, argsVersion), componentName)
+		b.Queue(stmtVersion, argsVersion...)
+	}
+
+	updated, hash := getHashUpdatedItem(item.Name, item.Type)
+
+	stmtItem, argsItem, err := db.psql.
+		Update("items").
+		Set("name", item.Name).
+		Set("reprompt", item.Reprompt).
+		Set("updated", updated).
+		Set("hash", hash).
+		Where(sq.Eq{"id": item.Id}).
+		Where("deleted_at is null").
+		Where(sq.Expr("user_id = (select users.id from users where username = ?)", username)).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtItem, argsItem), componentName)
+	b.Queue(stmtItem, argsItem...)
+
+	stmtSecret, argsSecret, err := setStoredSecret(db.psql.
+		Update("secrets").
+		Set("notes", item.Secrets.GetNotes()), &secret).
+		Where(sq.Eq{"item_id": item.Id}).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtSecret, argsSecret), componentName)
+	b.Queue(stmtSecret, argsSecret...)
+
+	stmtAdds, argsAdds, err := db.psql.
+		Update("additions").
+		Set("uris", item.Additions.GetUris()).
+		Set("custom_fields", item.Additions.GetCustomFields()).
+		Set("tags", item.Additions.GetTags()).
+		Where(sq.Eq{"item_id": item.Id}).ToSql()
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("queue SQL: %s , args: %v", stmtAdds, argsAdds), componentName)
+	b.Queue(stmtAdds, argsAdds...)
+
+	if err := queueItemChange(b, db.psql, username, sq.Eq{"items.id": item.Id}, itemUpdated); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
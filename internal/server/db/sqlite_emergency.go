@@ -0,0 +1,254 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// CreateEmergencyAccess invites grantee as grantor's trusted contact and returns emergency access's ID.
+func (db *SQLite) CreateEmergencyAccess(ctx context.Context, grantor Username,
+	access *pb.EmergencyAccess) (int64, error) {
+	if grantor == "" {
+		return 0, ErrNotFound
+	}
+
+	componentName := "SQLite:CreateEmergencyAccess"
+
+	if err := checkNewEmergencyAccess(access); err != nil {
+		return 0, err
+	}
+
+	stmtAccess, argsAccess, err := newEmergencyInsertStmt(db.psql, grantor, access)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtAccess, argsAccess), componentName)
+
+	var id int64
+	if err := db.db.QueryRowContext(ctx, stmtAccess, argsAccess...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errNoRowsAffected()
+		}
+
+		return 0, wrapSQLiteError(err)
+	}
+
+	return id, nil
+}
+
+// GetGrantedEmergencyAccesses returns emergency accesses granted by user without keys.
+func (db *SQLite) GetGrantedEmergencyAccesses(ctx context.Context, username Username) ([]*pb.EmergencyAccess, error) {
+	componentName := "SQLite:GetGrantedEmergencyAccesses"
+
+	stmtAccesses, argsAccesses, err := newGrantedEmergencySelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.getEmergencyAccesses(ctx, stmtAccesses, argsAccesses, componentName)
+}
+
+// GetReceivedEmergencyAccesses returns emergency accesses granted to user without keys.
+func (db *SQLite) GetReceivedEmergencyAccesses(ctx context.Context, username Username) ([]*pb.EmergencyAccess, error) {
+	componentName := "SQLite:GetReceivedEmergencyAccesses"
+
+	stmtAccesses, argsAccesses, err := newReceivedEmergencySelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.getEmergencyAccesses(ctx, stmtAccesses, argsAccesses, componentName)
+}
+
+// getEmergencyAccesses is a helper function, which selects emergency accesses with provided query.
+func (db *SQLite) getEmergencyAccesses(ctx context.Context, stmtAccesses SQLStatement, argsAccesses []interface{},
+	componentName string) ([]*pb.EmergencyAccess, error) {
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtAccesses, argsAccesses), componentName)
+
+	var dbAccesses []*EmergencyAccess
+	if err := sqlscan.Select(ctx, db.db, &dbAccesses, stmtAccesses, argsAccesses...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	accesses := make([]*pb.EmergencyAccess, 0, len(dbAccesses))
+	for _, access := range dbAccesses {
+		accesses = append(accesses, access.toPB())
+	}
+
+	return accesses, nil
+}
+
+// GetEmergencyAccess returns emergency access. Grantor's key is returned only for approved access,
+// so it's never released to grantee before approval.
+//
+// If access doesn't exist GetEmergencyAccess returns ErrNotFound.
+func (db *SQLite) GetEmergencyAccess(ctx context.Context, id int64) (*pb.EmergencyAccess, error) {
+	componentName := "SQLite:GetEmergencyAccess"
+
+	stmtAccess, argsAccess, err := newEmergencyAccessSelect(db.psql, id)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtAccess, argsAccess), componentName)
+
+	access := new(EmergencyAccess)
+	if err := sqlscan.Get(ctx, db.db, access, stmtAccess, argsAccess...); err != nil {
+		if sqlscan.NotFound(err) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	return access.toPB(), nil
+}
+
+// AcceptEmergencyInvite accepts invite by grantee.
+func (db *SQLite) AcceptEmergencyInvite(ctx context.Context, grantee Username, id int64) error {
+	componentName := "SQLite:AcceptEmergencyInvite"
+
+	return db.updateEmergencyAccess(ctx, id, "grantee_id", grantee,
+		[]string{common.EmergencyStatusInvited},
+		map[string]interface{}{"status": common.EmergencyStatusAccepted}, componentName)
+}
+
+// RequestEmergencyAccess requests access by grantee, access is approved automatically after provided time,
+// if it isn't rejected by grantor.
+func (db *SQLite) RequestEmergencyAccess(ctx context.Context, grantee Username, id int64,
+	approveAfter time.Time) error {
+	componentName := "SQLite:RequestEmergencyAccess"
+
+	return db.updateEmergencyAccess(ctx, id, "grantee_id", grantee,
+		[]string{common.EmergencyStatusAccepted},
+		map[string]interface{}{
+			"status":        common.EmergencyStatusRequested,
+			"requested":     formatEmergencyTime(time.Now()),
+			"approve_after": formatEmergencyTime(approveAfter),
+		}, componentName)
+}
+
+// ApproveEmergencyAccess approves requested access by grantor.
+func (db *SQLite) ApproveEmergencyAccess(ctx context.Context, grantor Username, id int64) error {
+	componentName := "SQLite:ApproveEmergencyAccess"
+
+	return db.updateEmergencyAccess(ctx, id, "grantor_id", grantor,
+		[]string{common.EmergencyStatusRequested},
+		map[string]interface{}{"status": common.EmergencyStatusApproved}, componentName)
+}
+
+// RejectEmergencyAccess rejects requested or approved access by grantor.
+//
+// Grantee can request access again.
+func (db *SQLite) RejectEmergencyAccess(ctx context.Context, grantor Username, id int64) error {
+	componentName := "SQLite:RejectEmergencyAccess"
+
+	return db.updateEmergencyAccess(ctx, id, "grantor_id", grantor,
+		[]string{common.EmergencyStatusRequested, common.EmergencyStatusApproved},
+		map[string]interface{}{
+			"status":        common.EmergencyStatusAccepted,
+			"requested":     nil,
+			"approve_after": nil,
+		}, componentName)
+}
+
+// updateEmergencyAccess is a helper function, which updates emergency access in one of
+// provided statuses with provided values.
+func (db *SQLite) updateEmergencyAccess(ctx context.Context, id int64, userColumn string, username Username,
+	statuses []string, values map[string]interface{}, componentName string) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	stmtAccess, argsAccess, err := newEmergencyUpdateStmt(db.psql, id, userColumn, username, statuses, values)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtAccess, argsAccess...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// DeleteEmergencyAccess deletes emergency access by its grantor or grantee.
+func (db *SQLite) DeleteEmergencyAccess(ctx context.Context, username Username, id int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	componentName := "SQLite:DeleteEmergencyAccess"
+
+	stmtAccess, argsAccess, err := newEmergencyDeleteStmt(db.psql, username, id)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtAccess, argsAccess...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// TakeoverAccount replaces grantor's password hash and encryption key by grantee of approved
+// takeover access.
+//
+// Grantor's two-factor authentication is disabled and all grantor's tokens are revoked.
+// Emergency access is deleted after takeover.
+func (db *SQLite) TakeoverAccount(ctx context.Context, grantee Username, id int64, user *pb.User) error {
+	if grantee == "" {
+		return ErrNotFound
+	}
+
+	componentName := "SQLite:TakeoverAccount"
+
+	if user == nil || user.GetPwdhash() == "" || len(user.Ekey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("password hash and encryption key are required"))
+	}
+
+	stmtUser, argsUser, err := newTakeoverUserUpdateStmt(db.psql, grantee, id, user)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtAccess, argsAccess, err := newEmergencyDeleteStmt(db.psql, grantee, id)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtUser, argsUser...)
+	b.Queue(stmtAccess, argsAccess...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// approveEmergencyAccesses approves all requested emergency accesses, which weren't rejected
+// by grantors during waiting period.
+func (db *SQLite) approveEmergencyAccesses(ctx context.Context, now time.Time, componentName string) {
+	stmtApprove, argsApprove, err := newEmergencyApproveStmt(db.psql, now)
+	if err != nil {
+		db.logger.Error(err, "approve emergency accesses", componentName)
+		return
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtApprove, argsApprove), componentName)
+
+	res, err := db.db.ExecContext(ctx, stmtApprove, argsApprove...)
+	if err != nil {
+		db.logger.Error(wrapSQLiteError(err), "approve emergency accesses", componentName)
+		return
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		db.logger.Info(fmt.Sprintf("approved %d emergency accesses", n), componentName)
+	}
+}
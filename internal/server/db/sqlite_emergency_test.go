@@ -0,0 +1,13 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSQLite_EmergencyAccess(t *testing.T) {
+	db := newTestSQLite(t)
+
+	testEmergencyAccess(t, db, func(now time.Time) { db.approveEmergencyAccesses(context.Background(), now, "test") })
+}
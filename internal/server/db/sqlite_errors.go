@@ -0,0 +1,40 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteErrorsMap is a map listed SQLite extended errors codes and corresponds DB Errors.
+//
+//nolint:gochecknoglobals
+var sqliteErrorsMap = map[sqlite3.ErrNoExtended]error{
+	sqlite3.ErrConstraintUnique:     ErrDuplicateEntry,
+	sqlite3.ErrConstraintPrimaryKey: ErrDuplicateEntry,
+	sqlite3.ErrConstraintNotNull:    ErrConstraintViolation,
+	sqlite3.ErrConstraintCheck:      ErrConstraintViolation,
+}
+
+// wrapSQLiteError is helper function to wrap SQLite's error.
+//
+// Wraps known type of errors with defined in package errors.
+func wrapSQLiteError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		if dbErr, ok := sqliteErrorsMap[sqliteErr.ExtendedCode]; ok {
+			return fmt.Errorf("%w::%v", dbErr, err)
+		}
+
+		if sqliteErr.Code == sqlite3.ErrError {
+			return fmt.Errorf("%w::%v", ErrBadSQLQuery, err)
+		}
+	}
+
+	return stackErrors(ErrUndefinedError, err)
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapSQLiteError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{
+			name: "Nil error",
+		},
+		{
+			name:    "Undefined error",
+			err:     assert.AnError,
+			wantErr: ErrUndefinedError,
+		},
+		{
+			name: "Known SQLite error",
+			err: sqlite3.Error{
+				Code:         sqlite3.ErrConstraint,
+				ExtendedCode: sqlite3.ErrConstraintUnique,
+			},
+			wantErr: ErrDuplicateEntry,
+		},
+		{
+			name: "SQL logic error",
+			err: sqlite3.Error{
+				Code: sqlite3.ErrError,
+			},
+			wantErr: ErrBadSQLQuery,
+		},
+		{
+			name: "Unknown SQLite error",
+			err: sqlite3.Error{
+				Code: sqlite3.ErrBusy,
+			},
+			wantErr: ErrUndefinedError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, wrapSQLiteError(tt.err), tt.wantErr)
+		})
+	}
+}
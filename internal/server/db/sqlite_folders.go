@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// CreateFolder creates new user's folder and returns its ID.
+func (db *SQLite) CreateFolder(ctx context.Context, username Username, folder *pb.Folder) (int64, error) {
+	if username == "" {
+		return 0, ErrNotFound
+	}
+
+	componentName := "SQLite:CreateFolder"
+
+	if err := checkFolder(folder); err != nil {
+		return 0, err
+	}
+
+	stmtFolder, argsFolder, err := newFolderInsertStmt(db.psql, username, folder)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtRevision, argsRevision, err := newRevisionStmt(db.psql, username)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return 0, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtFolder, argsFolder), componentName)
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, stmtFolder, argsFolder...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errNoRowsAffected()
+		}
+
+		return 0, wrapSQLiteError(err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtRevision, argsRevision...)
+
+	if err := db.execBatch(ctx, tx, b); err != nil {
+		return 0, err
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return 0, err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	return id, nil
+}
+
+// GetFolders returns all user's folders.
+func (db *SQLite) GetFolders(ctx context.Context, username Username) ([]*pb.Folder, error) {
+	componentName := "SQLite:GetFolders"
+
+	stmtFolders, argsFolders, err := newFoldersSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtFolders, argsFolders), componentName)
+
+	var dbFolders []*Folder
+	if err := sqlscan.Select(ctx, db.db, &dbFolders, stmtFolders, argsFolders...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	folders := make([]*pb.Folder, 0, len(dbFolders))
+	for _, folder := range dbFolders {
+		folders = append(folders, folder.toPB())
+	}
+
+	return folders, nil
+}
+
+// UpdateFolder renames existing user's folder.
+func (db *SQLite) UpdateFolder(ctx context.Context, username Username, folder *pb.Folder) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	componentName := "SQLite:UpdateFolder"
+
+	if err := checkFolder(folder); err != nil {
+		return err
+	}
+
+	stmtFolder, argsFolder, err := newFolderUpdateStmt(db.psql, username, folder)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtRevision, argsRevision, err := newRevisionStmt(db.psql, username)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtFolder, argsFolder...)
+	b.Queue(stmtRevision, argsRevision...)
+
+	if err := db.runBatch(ctx, b, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	return nil
+}
+
+// DeleteFolder deletes user's folder, folder's items are kept without folder.
+func (db *SQLite) DeleteFolder(ctx context.Context, username Username, folderID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	componentName := "SQLite:DeleteFolder"
+
+	b := new(sqliteBatch)
+	if err := queueDeleteFolder(b, db.psql, username, folderID); err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	var affected int64
+
+	for _, q := range b.queries {
+		db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", q.stmt, q.args), componentName)
+
+		res, err := tx.ExecContext(ctx, q.stmt, q.args...)
+		if wrappedErr := wrapSQLiteError(err); wrappedErr != nil {
+			return wrappedErr
+		}
+
+		if affected, err = res.RowsAffected(); err != nil {
+			return stackErrors(ErrInternalDBError, err)
+		}
+	}
+
+	if affected < 1 {
+		return errNoRowsAffected()
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestSQLite_Folders(t *testing.T) {
+	testFolders(t, newTestSQLite(t))
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName is a name of sqlite3 driver with registered custom functions.
+const sqliteDriverName = "sqlite3_gophkeeper"
+
+//nolint:gochecknoinits
+func init() {
+	sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("regexp", sqliteRegexp, true)
+		},
+	})
+}
+
+// sqliteRegexp implements SQLite's regexp function, used in schema constraints.
+func sqliteRegexp(re, s string) (bool, error) {
+	return regexp.MatchString(re, s)
+}
+
+// sqliteBatch represents queue of SQL statements, which are run in one transaction.
+//
+// sqliteBatch is an analogue of pgx.Batch for database/sql.
+type sqliteBatch struct {
+	queries []sqliteQuery
+}
+
+// sqliteQuery represents single queued SQL statement.
+type sqliteQuery struct {
+	stmt SQLStatement
+	args []interface{}
+}
+
+// Queue queues a query to batch.
+func (b *sqliteBatch) Queue(stmt SQLStatement, args ...interface{}) {
+	b.queries = append(b.queries, sqliteQuery{stmt: stmt, args: args})
+}
+
+// Len returns number of queries that have been queued so far.
+func (b *sqliteBatch) Len() int {
+	return len(b.queries)
+}
+
+// runBatch is helper function to run sql requests in batches.
+//
+// runBatch does it job in one transaction and checks results of every request.
+func (db *SQLite) runBatch(ctx context.Context, batch *sqliteBatch, componentName string) error {
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return err
+	}
+
+	defer db.deferTxRollback(tx)
+
+	if err := db.execBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+
+	return db.commitTx(tx, componentName)
+}
+
+// runBatchInQuota is helper function to run sql requests in batches, same as runBatch,
+// but additionally checks user's usage against quota before commit.
+//
+// If quota is exceeded transaction is rolled back and ErrQuotaExceeded is returned.
+func (db *SQLite) runBatchInQuota(ctx context.Context, batch *sqliteBatch, username Username,
+	checkItems bool, componentName string) error {
+	if !db.quota.Enabled() {
+		return db.runBatch(ctx, batch, componentName)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return err
+	}
+
+	defer db.deferTxRollback(tx)
+
+	if err := db.execBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+
+	usage, err := db.getUserUsage(ctx, tx, username, componentName)
+	if err != nil {
+		return err
+	}
+
+	if err := db.quota.check(usage, checkItems); err != nil {
+		return err
+	}
+
+	return db.commitTx(tx, componentName)
+}
+
+// execBatch is helper function to run sql requests in batch within provided transaction.
+//
+// Every request must affect at least one row, otherwise ErrOperationFailed is returned.
+func (db *SQLite) execBatch(ctx context.Context, tx *sql.Tx, batch *sqliteBatch) error {
+	for _, q := range batch.queries {
+		res, err := tx.ExecContext(ctx, q.stmt, q.args...)
+		if wrappedErr := wrapSQLiteError(err); wrappedErr != nil {
+			return wrappedErr
+		}
+
+		if n, err := res.RowsAffected(); err != nil || n < 1 {
+			return stackErrors(ErrOperationFailed, errors.New("no rows affected"))
+		}
+	}
+
+	return nil
+}
+
+// beginTx is a helper function to start transaction.
+//
+// In case of failure logs an error, returns nil sql.Tx and already stacked error.
+// componentName is used in log's record.
+func (db *SQLite) beginTx(ctx context.Context, componentName string) (tx *sql.Tx, err error) {
+	if tx, err = db.db.BeginTx(ctx, nil); err != nil {
+		db.logger.Error(err, "begin transaction", componentName)
+		return nil, stackErrors(ErrTransactionFailed, err)
+	}
+
+	return
+}
+
+// commitTx is a helper function for commit transaction.
+//
+// In case of failure logs an error and returns ErrTransactionFailed.
+// componentName is used in log's record.
+func (db *SQLite) commitTx(tx *sql.Tx, componentName string) (err error) {
+	if err = tx.Commit(); err != nil {
+		db.logger.Error(err, "commit transaction", componentName)
+		return stackErrors(ErrTransactionFailed, err)
+	}
+
+	return
+}
+
+// deferTxRollback is a helper function for defering transaction rollback.
+//
+// In case of failure logs an error.
+func (db *SQLite) deferTxRollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		db.logger.Error(err, "failed", "deferTxRollback")
+	}
+}
@@ -0,0 +1,931 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/sqlscan"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateItem creates new item for particular user.
+//
+// CreateItem generates updated time field in RFC3339 format during creation.
+// Returns nil error only on successfully creation.
+// If user's quota is exceeded returns ErrQuotaExceeded.
+func (db *SQLite) CreateItem(ctx context.Context, username string, item *pb.Item) error {
+	if username == "" {
+		return ErrNotFound
+	}
+
+	componentName := "SQLite:CreateItem"
+
+	secret, err := db.blobs.prepare(ctx, item.GetSecrets().GetSecret())
+	if err != nil {
+		return err
+	}
+
+	b, err := db.newCreateItemBatch(username, item, secret)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	if err := db.runBatchInQuota(ctx, b, username, true, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	return nil
+}
+
+// GetItemByNameAndType gets item's information from DB.
+func (db *SQLite) GetItemByNameAndType(ctx context.Context, username Username,
+	itemName string, itemType string) (*pb.Item, error) {
+
+	componentName := "SQLite:GetItemByNameAndType"
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return nil, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	stmtItem, argsItem, err := db.psql.
+		Select("items.id, name, type, reprompt, hash, items.folder_id").
+		Column(`s.notes as "secrets.notes", s.secret as "secrets.secret"`).
+		Column(`a.uris as "additions.uris", a.custom_fields as "additions.custom_fields"`).
+		Column(`a.tags as "additions.tags"`).
+		From("items").
+		LeftJoin("users on user_id=users.id").
+		LeftJoin("secrets s on items.id=s.item_id").
+		LeftJoin("additions a on items.id=a.item_id").
+		Where("users.username=? and items.name=? and items.type=?", username, itemName, itemType).
+		Where("items.deleted_at is null").
+		ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtItem, argsItem), componentName)
+
+	item := new(pb.Item)
+	if err := sqlscan.Get(ctx, tx, item, stmtItem, argsItem...); err != nil {
+		if sqlscan.NotFound(err) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	stmtUpdated, argsUpdated, err := db.psql.
+		Select("items.updated").
+		From("items").Join("users on user_id=users.id").
+		Where("users.username=? and items.name=? and items.type=?", username, itemName, itemType).
+		Where("items.deleted_at is null").
+		ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtUpdated, argsUpdated), componentName)
+
+	var updated sql.NullTime
+	if err := tx.QueryRowContext(ctx, stmtUpdated, argsUpdated...).Scan(&updated); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	if updated.Valid {
+		item.Updated = timestamppb.New(updated.Time)
+	}
+
+	filter := sq.And{sq.Eq{"items.name": itemName, "items.type": itemType}, sq.Expr("items.deleted_at is null")}
+	if err := db.resolveSecretBlobs(ctx, tx, username, []*pb.Item{item}, filter, componentName); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// GetItemList returns short representation of all user's items, including trashed.
+func (db *SQLite) GetItemList(ctx context.Context, username Username) ([]*pb.ItemShort, error) {
+	componentName := "SQLite:GetItemList"
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return nil, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	stmtItems, argsItems, err := db.psql.
+		Select("items.id, name, type, items.updated, hash, items.deleted_at, items.folder_id, a.tags").
+		From("items").
+		LeftJoin("additions a on items.id=a.item_id").
+		LeftJoin("users on user_id=users.id").
+		Where("users.username=?", username).
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtItems, argsItems), componentName)
+
+	var dbItems []*ItemShort
+	if err := sqlscan.Select(ctx, tx, &dbItems, stmtItems, argsItems...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	items := make([]*pb.ItemShort, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.toPB())
+	}
+
+	return items, nil
+}
+
+// GetItemsByID gets item's information from DB.
+//
+// Trashed items are skipped.
+func (db *SQLite) GetItemsByID(ctx context.Context, username Username, ids []int64) ([]*pb.Item, error) {
+	componentName := "SQLite:GetItemsByID"
+
+	filter := sq.And{sq.Eq{"items.id": ids}, sq.Expr("items.deleted_at is null")}
+
+	return db.getItems(ctx, username, filter, componentName)
+}
+
+// GetAllItems gets information of all user's items from DB, including trashed.
+func (db *SQLite) GetAllItems(ctx context.Context, username Username) ([]*pb.Item, error) {
+	componentName := "SQLite:GetAllItems"
+
+	return db.getItems(ctx, username, nil, componentName)
+}
+
+// getItems is a helper function, which gets user's items' information from DB.
+//
+// Items are additionally filtered with provided filter, nil filter selects all user's items.
+//
+//nolint:cyclop // necessary evil
+func (db *SQLite) getItems(ctx context.Context, username Username, filter sq.Sqlizer,
+	componentName string) ([]*pb.Item, error) {
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return nil, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	stmtItems, argsItems, err := db.psql.
+		Select("items.id, name, type, reprompt, hash, items.folder_id").
+		Column(`s.notes as "secrets.notes", s.secret as "secrets.secret"`).
+		Column(`a.uris as "additions.uris", a.custom_fields as "additions.custom_fields"`).
+		Column(`a.tags as "additions.tags"`).
+		From("items").
+		LeftJoin("users on user_id=users.id").
+		LeftJoin("secrets s on items.id=s.item_id").
+		LeftJoin("additions a on items.id=a.item_id").
+		Where(sq.Eq{"users.username": username}).
+		Where(filter).
+		OrderBy("items.id").
+		ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtItems, argsItems), componentName)
+
+	var items []*pb.Item
+	if err := sqlscan.Select(ctx, tx, &items, stmtItems, argsItems...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	stmtUpdateds, argsUpdateds, err := db.psql.
+		Select("items.updated").
+		From("items").Join("users on user_id=users.id").
+		Where(sq.Eq{"users.username": username}).
+		Where(filter).
+		OrderBy("items.id").
+		ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtUpdateds, argsUpdateds), componentName)
+
+	rows, err := tx.QueryContext(ctx, stmtUpdateds, argsUpdateds...)
+	if err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+	defer rows.Close()
+
+	for i := 0; rows.Next() && i < len(items); i++ {
+		var updated sql.NullTime
+		if err := rows.Scan(&updated); err != nil {
+			return nil, stackErrors(ErrInternalDBError, err)
+		}
+
+		if updated.Valid {
+			items[i].Updated = timestamppb.New(updated.Time)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := db.resolveSecretBlobs(ctx, tx, username, items, filter, componentName); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetChangesSince returns changes of user's items made since provided vault revision.
+//
+// If no users were found GetChangesSince returns nil and error (ErrNotFound).
+func (db *SQLite) GetChangesSince(ctx context.Context, username Username, revision int64) (*Changes, error) {
+	componentName := "SQLite:GetChangesSince"
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return nil, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	var current int64
+	if err := tx.QueryRowContext(ctx, `select revision from users where username = ?`, username).Scan(&current); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	stmtChanges, argsChanges, err := newItemChangesSelect(db.psql, username, revision)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtChanges, argsChanges), componentName)
+
+	var log []*ItemChange
+	if err := sqlscan.Select(ctx, tx, &log, stmtChanges, argsChanges...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	return newChanges(current, revision, log), nil
+}
+
+// GetItemHashByID returns hash of user's item.
+//
+// If user doesn't own item GetItemHashByID returns ErrNotFound.
+func (db *SQLite) GetItemHashByID(ctx context.Context, username Username, id int64) ([]byte, error) {
+	componentName := "SQLite:GetItemHashByID"
+
+	sqlStmt := `select i.hash from items i join users u on i.user_id = u.id where i.id = ? and u.username = ?`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s, %d, %s", sqlStmt, id, username), componentName)
+
+	var hash []byte
+	if err := db.db.QueryRowContext(ctx, sqlStmt, id, username).Scan(&hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	return hash, nil
+}
+
+// UpdateItem updates existing item.
+//
+// UpdateItem generates updated time field in RFC3339 format during update.
+// Returns nil error only on successful update.
+// If user's quota of stored data is exceeded returns ErrQuotaExceeded.
+func (db *SQLite) UpdateItem(ctx context.Context, username string, item *pb.Item) error {
+	if username == "" {
+		return ErrNotFound
+	}
+	componentName := "SQLite:UpdateItem"
+
+	secret, err := db.blobs.prepareItemSecret(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	b, err := db.newUpdateItemBatch(username, item, secret)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	if err := db.runBatchInQuota(ctx, b, username, false, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+	db.trimItemVersions(ctx, item.Id, componentName)
+	db.blobs.triggerGC()
+
+	return nil
+}
+
+// DeleteItem moves user's item to trash.
+func (db *SQLite) DeleteItem(ctx context.Context, username Username, itemID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+	componentName := "SQLite:DeleteItem"
+
+	b, err := db.newDeleteItemBatch(username, itemID)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	if err := db.runBatch(ctx, b, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	db.blobs.triggerGC()
+
+	return nil
+}
+
+// GetTrashList returns short representation of all user's trashed items sorted by name.
+func (db *SQLite) GetTrashList(ctx context.Context, username Username) ([]*pb.ItemShort, error) {
+	componentName := "SQLite:GetTrashList"
+
+	stmtItems, argsItems, err := db.psql.
+		Select("items.id, name, type, items.updated, hash, items.deleted_at, items.folder_id, a.tags").
+		From("items").
+		LeftJoin("additions a on items.id=a.item_id").
+		Join("users on user_id=users.id").
+		Where(sq.Eq{"users.username": username}).
+		Where("items.deleted_at is not null").
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtItems, argsItems), componentName)
+
+	var dbItems []*ItemShort
+	if err := sqlscan.Select(ctx, db.db, &dbItems, stmtItems, argsItems...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	items := make([]*pb.ItemShort, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.toPB())
+	}
+
+	return items, nil
+}
+
+// RestoreItem restores user's item from trash.
+func (db *SQLite) RestoreItem(ctx context.Context, username Username, itemID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+	componentName := "SQLite:RestoreItem"
+
+	b, err := db.newRestoreItemBatch(username, itemID)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	if err := db.runBatch(ctx, b, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	return nil
+}
+
+// PurgeItem permanently deletes user's trashed item.
+func (db *SQLite) PurgeItem(ctx context.Context, username Username, itemID int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+	componentName := "SQLite:PurgeItem"
+
+	b, err := db.newPurgeItemBatch(username, itemID)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	if err := db.runBatch(ctx, b, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	db.blobs.triggerGC()
+
+	return nil
+}
+
+// purgeTrash permanently deletes all users' items, which were trashed before provided time.
+//
+// Deletion of items is recorded in change log, so statements are run in single transaction.
+func (db *SQLite) purgeTrash(ctx context.Context, before time.Time, componentName string) {
+	stmtRevision, argsRevision, err := newPurgeTrashRevisionStmt(db.psql, before)
+	if err != nil {
+		db.logger.Error(err, "purge trash", componentName)
+		return
+	}
+
+	stmtChanges, argsChanges, err := newPurgeTrashChangesStmt(db.psql, before)
+	if err != nil {
+		db.logger.Error(err, "purge trash", componentName)
+		return
+	}
+
+	stmtPurge, argsPurge, err := newPurgeTrashStmt(db.psql, before)
+	if err != nil {
+		db.logger.Error(err, "purge trash", componentName)
+		return
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtRevision, argsRevision), componentName)
+
+	if _, err := tx.ExecContext(ctx, stmtRevision, argsRevision...); err != nil {
+		db.logger.Error(err, "purge trash", componentName)
+		return
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtChanges, argsChanges), componentName)
+
+	if _, err := tx.ExecContext(ctx, stmtChanges, argsChanges...); err != nil {
+		db.logger.Error(err, "purge trash", componentName)
+		return
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtPurge, argsPurge), componentName)
+
+	res, err := tx.ExecContext(ctx, stmtPurge, argsPurge...)
+	if err != nil {
+		db.logger.Error(err, "purge trash", componentName)
+		return
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		db.logger.Info(fmt.Sprintf("purged %d trashed items", n), componentName)
+
+		for _, username := range db.watchers.usernames() {
+			db.notifyRevision(ctx, username, componentName)
+		}
+	}
+}
+
+// GetItemVersions returns item's previous versions sorted from newest to oldest.
+func (db *SQLite) GetItemVersions(ctx context.Context, username Username, itemID int64) ([]*pb.ItemVersion, error) {
+	componentName := "SQLite:GetItemVersions"
+
+	stmtVersions, argsVersions, err := newItemVersionsSelect(db.psql, username, itemID).ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtVersions, argsVersions), componentName)
+
+	var dbVersions []*ItemVersion
+	if err := sqlscan.Select(ctx, db.db, &dbVersions, stmtVersions, argsVersions...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	return db.blobs.versionsToPB(ctx, dbVersions)
+}
+
+// RestoreItemVersion restores item's state from previous version.
+//
+// Current item's state is stored as new version, so restore can be reverted.
+// Returns nil error only on successful restore.
+// If user's quota of stored data is exceeded returns ErrQuotaExceeded.
+func (db *SQLite) RestoreItemVersion(ctx context.Context, username Username, itemID int64, version int64) error {
+	if username == "" {
+		return ErrNotFound
+	}
+	componentName := "SQLite:RestoreItemVersion"
+
+	stmtVersion, argsVersion, err := newItemVersionsSelect(db.psql, username, itemID).
+		Where(sq.Eq{"v.version": version}).ToSql()
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtVersion, argsVersion), componentName)
+
+	dbVersion := new(ItemVersion)
+	if err := sqlscan.Get(ctx, db.db, dbVersion, stmtVersion, argsVersion...); err != nil {
+		if sqlscan.NotFound(err) {
+			return stackErrors(ErrNotFound, err)
+		}
+
+		return wrapSQLiteError(err)
+	}
+
+	b, err := db.newRestoreItemVersionBatch(username, dbVersion.toPB(), dbVersion.storedSecret())
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	if err := db.runBatchInQuota(ctx, b, username, false, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+	db.trimItemVersions(ctx, itemID, componentName)
+	db.blobs.triggerGC()
+
+	return nil
+}
+
+// RotateEncryptionKey replaces user's encryption key, private key, all user's items (including trashed),
+// folders and keys of user's emergency accesses with re-encrypted ones in one transaction.
+//
+// All user's items, folders and emergency accesses' keys must be passed, private key must be passed
+// if user has key pair, otherwise nothing is changed and ErrOperationFailed is returned.
+// Items' history is deleted, because it is encrypted with previous key.
+func (db *SQLite) RotateEncryptionKey(ctx context.Context, username Username, ekey []byte,
+	privateKey []byte, items []*pb.Item, folders []*pb.Folder, emergencyKeys []*pb.EmergencyKey) error {
+	if username == "" {
+		return ErrNotFound
+	}
+	componentName := "SQLite:RotateEncryptionKey"
+
+	if err := checkRotationItems(ekey, items, folders, emergencyKeys); err != nil {
+		return err
+	}
+
+	b := new(sqliteBatch)
+	secrets, err := prepareRotationSecrets(ctx, db.blobs, items)
+	if err != nil {
+		return err
+	}
+
+	if err := queueRotateEncryptionKey(b, db.psql, username, ekey, privateKey, items, folders,
+		emergencyKeys, secrets); err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtVersions, argsVersions, err := newUserItemVersionsDeleteStmt(db.psql, username)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("rotate encryption key, items: %d", len(items)), componentName)
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	if err := db.execBatch(ctx, tx, b); err != nil {
+		return err
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtVersions, argsVersions), componentName)
+
+	if _, err := tx.ExecContext(ctx, stmtVersions, argsVersions...); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	return nil
+}
+
+// trimItemVersions deletes item's oldest versions, exceeding retention count.
+//
+// Items' history is auxiliary, so failures are only logged.
+func (db *SQLite) trimItemVersions(ctx context.Context, itemID int64, componentName string) {
+	if db.itemVersions == 0 {
+		return
+	}
+
+	stmtTrim, argsTrim, err := newItemVersionsTrimStmt(db.psql, itemID, db.itemVersions)
+	if err != nil {
+		db.logger.Error(err, "trim item's versions", componentName)
+		return
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtTrim, argsTrim), componentName)
+
+	if _, err := db.db.ExecContext(ctx, stmtTrim, argsTrim...); err != nil {
+		db.logger.Error(err, "trim item's versions", componentName)
+	}
+}
+
+// SaveSecretData replaces data item's content with chunks, received one by one from next.
+//
+// Every chunk is stored as soon as it's received, so neither whole content is kept in memory
+// nor database's only connection is held by slow client. After all chunks are received they
+// replace item's content in one transaction, so in case of any failure previous content is kept.
+// Item's hash and updated time are updated after upload. Returns total size of stored chunks.
+// If total size exceeds maximum size of secret returns ErrSecretTooBig.
+// If user's quota of stored data is exceeded returns ErrQuotaExceeded.
+func (db *SQLite) SaveSecretData(ctx context.Context, username Username, itemID int64,
+	next ChunkReader) (int64, error) {
+	if username == "" {
+		return 0, ErrNotFound
+	}
+	componentName := "SQLite:SaveSecretData"
+
+	if _, err := db.getSecretDataItemName(ctx, db.db, username, itemID, componentName); err != nil {
+		return 0, err
+	}
+
+	db.deleteStaleSecretUploads(ctx, componentName)
+
+	uploadID := uuid.NewString()
+	defer db.deleteSecretUpload(ctx, uploadID, componentName)
+
+	chunks, size, err := receiveSecretChunks(db.maxSecretSize, next, func(seq int64, chunk []byte) error {
+		stmtChunk, argsChunk, err := newSecretUploadChunkInsertStmt(db.psql, uploadID, itemID, seq, chunk)
+		if err != nil {
+			return stackErrors(ErrInternalDBError, err)
+		}
+
+		_, err = db.db.ExecContext(ctx, stmtChunk, argsChunk...)
+
+		return wrapSQLiteError(err)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return 0, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	itemName, err := db.getSecretDataItemName(ctx, tx, username, itemID, componentName)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := db.applySecretUpload(ctx, tx, uploadID, itemID, chunks, componentName); err != nil {
+		return 0, err
+	}
+
+	db.logger.Debug(fmt.Sprintf("item %d: stored %d chunks, %d bytes", itemID, chunks, size), componentName)
+
+	b := new(sqliteBatch)
+	if err := queueSecretDataUpdated(b, db.psql, username, itemID, itemName); err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	if err := db.execBatch(ctx, tx, b); err != nil {
+		return 0, err
+	}
+
+	if db.quota.Enabled() {
+		usage, err := db.getUserUsage(ctx, tx, username, componentName)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := db.quota.check(usage, false); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return 0, err
+	}
+
+	db.notifyRevision(ctx, username, componentName)
+
+	return size, nil
+}
+
+// applySecretUpload is a helper function, which replaces item's content with received chunks
+// of upload inside transaction.
+//
+// If not all chunks of upload are stored (ex. they were deleted as stale) returns ErrOperationFailed.
+func (db *SQLite) applySecretUpload(ctx context.Context, tx *sql.Tx, uploadID string, itemID int64,
+	chunks int64, componentName string) error {
+	current, err := db.getSecretChunksRange(ctx, tx, itemID, componentName)
+	if err != nil {
+		return err
+	}
+
+	stmtDelete, argsDelete, err := newSecretChunksDeleteStmt(db.psql, itemID)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, stmtDelete, argsDelete...); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	stmtApply, argsApply, err := newSecretUploadApplyStmt(db.psql, uploadID, current.Last)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtApply, argsApply), componentName)
+
+	result, err := tx.ExecContext(ctx, stmtApply, argsApply...)
+	if err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	if applied, err := result.RowsAffected(); err != nil || applied != chunks {
+		return stackErrors(ErrOperationFailed, errSecretDataReplaced)
+	}
+
+	return nil
+}
+
+// deleteSecretUpload is a helper function, which deletes stored chunks of upload.
+//
+// Errors are only logged, chunks of interrupted upload are deleted later as stale.
+func (db *SQLite) deleteSecretUpload(ctx context.Context, uploadID string, componentName string) {
+	stmtDelete, argsDelete, err := newSecretUploadDeleteStmt(db.psql, uploadID)
+	if err == nil {
+		_, err = db.db.ExecContext(ctx, stmtDelete, argsDelete...)
+	}
+
+	if err != nil {
+		db.logger.Warn(err, "failed to delete upload's chunks", componentName)
+	}
+}
+
+// deleteStaleSecretUploads is a helper function, which deletes chunks of interrupted uploads.
+//
+// Errors are only logged.
+func (db *SQLite) deleteStaleSecretUploads(ctx context.Context, componentName string) {
+	stmtDelete, argsDelete, err := newStaleSecretUploadsDeleteStmt(db.psql)
+	if err == nil {
+		_, err = db.db.ExecContext(ctx, stmtDelete, argsDelete...)
+	}
+
+	if err != nil {
+		db.logger.Warn(err, "failed to delete stale uploads' chunks", componentName)
+	}
+}
+
+// GetSecretData sends data item's content to send chunk by chunk in order of upload.
+//
+// Every chunk is read just before it's sent, so slow client doesn't hold database's only connection
+// and whole content isn't loaded in memory. If content is replaced during download returns
+// ErrOperationFailed.
+func (db *SQLite) GetSecretData(ctx context.Context, username Username, itemID int64, send ChunkWriter) error {
+	if username == "" {
+		return ErrNotFound
+	}
+	componentName := "SQLite:GetSecretData"
+
+	if _, err := db.getSecretDataItemName(ctx, db.db, username, itemID, componentName); err != nil {
+		return err
+	}
+
+	chunks, err := db.getSecretChunksRange(ctx, db.db, itemID, componentName)
+	if err != nil {
+		return err
+	}
+
+	return sendSecretChunks(*chunks, func(after int64) (*secretChunk, error) {
+		stmtChunk, argsChunk, err := newSecretChunkSelect(db.psql, itemID, after, chunks.Last)
+		if err != nil {
+			return nil, stackErrors(ErrInternalDBError, err)
+		}
+
+		chunk := new(secretChunk)
+		if err := sqlscan.Get(ctx, db.db, chunk, stmtChunk, argsChunk...); err != nil {
+			if sqlscan.NotFound(err) {
+				return nil, stackErrors(ErrNotFound, err)
+			}
+
+			return nil, wrapSQLiteError(err)
+		}
+
+		return chunk, nil
+	}, send)
+}
+
+// getSecretChunksRange is a helper function which returns range of sequence numbers of item's content.
+func (db *SQLite) getSecretChunksRange(ctx context.Context, q sqlscan.Querier, itemID int64,
+	componentName string) (*secretChunksRange, error) {
+	stmtRange, argsRange, err := newSecretChunksRangeSelect(db.psql, itemID)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtRange, argsRange), componentName)
+
+	chunks := new(secretChunksRange)
+	if err := sqlscan.Get(ctx, q, chunks, stmtRange, argsRange...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	return chunks, nil
+}
+
+// getSecretDataItemName is a helper function which returns name of user's not trashed data item.
+//
+// If item is not found returns ErrNotFound.
+func (db *SQLite) getSecretDataItemName(ctx context.Context, q sqlscan.Querier, username Username,
+	itemID int64, componentName string) (string, error) {
+	stmtItem, argsItem, err := newSecretDataItemSelect(db.psql, username, itemID)
+	if err != nil {
+		return "", stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtItem, argsItem), componentName)
+
+	var itemName string
+	if err := sqlscan.Get(ctx, q, &itemName, stmtItem, argsItem...); err != nil {
+		if sqlscan.NotFound(err) {
+			return "", stackErrors(ErrNotFound, err)
+		}
+
+		return "", wrapSQLiteError(err)
+	}
+
+	return itemName, nil
+}
+
+// resolveSecretBlobs is a helper function, which sets secrets of user's items stored in blob store.
+//
+// References to blobs are selected with same filter as items.
+func (db *SQLite) resolveSecretBlobs(ctx context.Context, q sqlscan.Querier, username Username,
+	items []*pb.Item, filter sq.Sqlizer, componentName string) error {
+	stmtRefs, argsRefs, err := newSecretBlobRefsSelect(db.psql, username, filter)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtRefs, argsRefs), componentName)
+
+	var refs []secretBlobRef
+	if err := sqlscan.Select(ctx, q, &refs, stmtRefs, argsRefs...); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	return db.blobs.resolveItems(ctx, items, refs)
+}
+
+// collectBlobs deletes blobs, which are not referenced by any item or item's version.
+//
+// Garbage collection is auxiliary, so failures are only logged.
+func (db *SQLite) collectBlobs(ctx context.Context, componentName string) {
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", referencedBlobsSelect), componentName)
+
+	var referenced []string
+	if err := sqlscan.Select(ctx, db.db, &referenced, referencedBlobsSelect); err != nil {
+		db.logger.Error(err, "select referenced blobs", componentName)
+		return
+	}
+
+	db.blobs.sweep(ctx, referenced, db.logger, componentName)
+}
+
+// WatchRevision returns channel, which receives new revisions of user's vault until context is done.
+func (db *SQLite) WatchRevision(ctx context.Context, username Username) (<-chan int64, error) {
+	return db.watchers.subscribe(ctx, username)
+}
+
+// notifyRevision is a helper function, which sends current revision of user's vault to
+// user's watchers. Revision is read only if user is watched.
+func (db *SQLite) notifyRevision(ctx context.Context, username Username, componentName string) {
+	if !db.watchers.watched(username) {
+		return
+	}
+
+	revision, err := db.GetUserRevision(ctx, username)
+	if err != nil {
+		db.logger.Warn(err, "notify revision", componentName)
+		return
+	}
+
+	db.watchers.publish(username, revision)
+}
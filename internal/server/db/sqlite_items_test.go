@@ -0,0 +1,1198 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/blobstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite_CreateItem(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	db := newTestSQLite(t)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		username Username
+		item     *pb.Item
+		wantErr  bool
+		err      error
+	}{
+		{
+			name:     "Create new login item",
+			ctx:      context.Background(),
+			username: testUser2.Username,
+			item:     testItemLogin,
+			wantErr:  false,
+		},
+		{
+			name:     "Create new item without notes and secrets",
+			ctx:      context.Background(),
+			username: testUser2.Username,
+			item:     testItemEmptyNotesSecrets,
+			wantErr:  false,
+		},
+		{
+			name:    "Missed username",
+			ctx:     context.Background(),
+			item:    testItemCard,
+			wantErr: true,
+			err:     ErrNotFound,
+		},
+		{
+			name:     "Unexisting user",
+			ctx:      context.Background(),
+			username: "unknown",
+			item:     testItemCard,
+			wantErr:  true,
+			err:      ErrOperationFailed,
+		},
+		{
+			name:     "Duplicate entry",
+			ctx:      context.Background(),
+			username: testUser1.Username,
+			item:     testItemLogin,
+			wantErr:  true,
+			err:      ErrDuplicateEntry,
+		},
+		{
+			name:     "Wrong item type",
+			ctx:      context.Background(),
+			username: testUser2.Username,
+			item:     &pb.Item{Name: "wrongtype", Type: "x"},
+			wantErr:  true,
+			err:      ErrConstraintViolation,
+		},
+		{
+			name:     "Secret exceeds maximum size",
+			ctx:      context.Background(),
+			username: testUser2.Username,
+			item: &pb.Item{
+				Name:    "bigsecret",
+				Type:    common.ItemTypeSecData,
+				Secrets: &pb.Secrets{Secret: make([]byte, db.GetMaxSecretSize()+5)},
+			},
+			wantErr: true,
+			err:     ErrConstraintViolation,
+		},
+		{
+			name:     "Canceled context",
+			ctx:      canceledCtx,
+			username: testUser2.Username,
+			item:     testItemCard,
+			wantErr:  true,
+			err:      ErrTransactionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision, err := db.GetUserRevision(context.Background(), testUser2.Username)
+			require.NoError(t, err)
+
+			err = db.CreateItem(tt.ctx, tt.username, tt.item)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			item, err := db.GetItemByNameAndType(context.Background(), tt.username, tt.item.Name, tt.item.Type)
+			require.NoError(t, err)
+			assert.NotZero(t, item.Id)
+			assert.NotEmpty(t, item.Hash)
+			assert.NotNil(t, item.Updated)
+			assert.Equal(t, tt.item.Secrets.GetSecret(), item.Secrets.GetSecret())
+			assert.Equal(t, tt.item.Additions.GetCustomFields(), item.Additions.GetCustomFields())
+			assert.Equal(t, tt.item.Additions.GetTags(), item.Additions.GetTags())
+
+			newRevision, err := db.GetUserRevision(context.Background(), testUser2.Username)
+			require.NoError(t, err)
+			assert.NotEqual(t, revision, newRevision)
+		})
+	}
+}
+
+func TestSQLite_GetItems(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	t.Run("Get item list", func(t *testing.T) {
+		list, err := db.GetItemList(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Len(t, list, len(testItems))
+
+		list, err = db.GetItemList(ctx, "unknown")
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+
+	t.Run("Get items by ID", func(t *testing.T) {
+		login := getTestItem(t, db, testItemLogin)
+		card := getTestItem(t, db, testItemCard)
+
+		items, err := db.GetItemsByID(ctx, testUser1.Username, []int64{card.Id, login.Id, 1000})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Less(t, items[0].Id, items[1].Id)
+
+		items, err = db.GetItemsByID(ctx, testUser2.Username, []int64{card.Id})
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("Get item hash", func(t *testing.T) {
+		login := getTestItem(t, db, testItemLogin)
+
+		hash, err := db.GetItemHashByID(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+		assert.Equal(t, login.Hash, hash)
+
+		_, err = db.GetItemHashByID(ctx, testUser1.Username, 1000)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = db.GetItemHashByID(ctx, testUser2.Username, login.Id)
+		assert.ErrorIs(t, err, ErrNotFound, "another user's item")
+	})
+
+	t.Run("Get unexisting item", func(t *testing.T) {
+		_, err := db.GetItemByNameAndType(ctx, testUser1.Username, "unknown", common.ItemTypeLogin)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = db.GetItemByNameAndType(ctx, "unknown", testItemLogin.Name, testItemLogin.Type)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestSQLite_UpdateItem(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	login := getTestItem(t, db, testItemLogin)
+	card := getTestItem(t, db, testItemCard)
+
+	t.Run("Update item", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:      login.Id,
+			Name:    "renamedlogin",
+			Secrets: &pb.Secrets{Secret: []byte("newsecret")},
+		})
+		require.NoError(t, err)
+
+		updated, err := db.GetItemByNameAndType(ctx, testUser1.Username, "renamedlogin", login.Type)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("newsecret"), updated.Secrets.Secret)
+		assert.Equal(t, login.Secrets.Notes, updated.Secrets.Notes)
+		assert.Equal(t, login.Additions, updated.Additions)
+		assert.NotEqual(t, login.Hash, updated.Hash)
+	})
+
+	t.Run("Rename to existing item", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:   card.Id,
+			Name: card.Name,
+		})
+		require.NoError(t, err)
+
+		err = db.CreateItem(ctx, testUser1.Username, &pb.Item{Name: "anothercard", Type: common.ItemTypeCard})
+		require.NoError(t, err)
+		another := getTestItem(t, db, &pb.Item{Name: "anothercard", Type: common.ItemTypeCard})
+
+		err = db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:   another.Id,
+			Name: card.Name,
+		})
+		assert.ErrorIs(t, err, ErrDuplicateEntry)
+	})
+
+	t.Run("Update item of another user", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser2.Username, &pb.Item{
+			Id:   card.Id,
+			Name: "stolen",
+		})
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+
+	t.Run("Update unexisting item", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:   1000,
+			Name: "unknown",
+		})
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+}
+
+func TestSQLite_DeleteItem(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	login := getTestItem(t, db, testItemLogin)
+
+	err := db.DeleteItem(ctx, testUser2.Username, login.Id)
+	assert.ErrorIs(t, err, ErrOperationFailed)
+
+	err = db.DeleteItem(ctx, "", login.Id)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = db.DeleteItem(ctx, testUser1.Username, login.Id)
+	require.NoError(t, err)
+
+	_, err = db.GetItemByNameAndType(ctx, testUser1.Username, login.Name, login.Type)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = db.DeleteItem(ctx, testUser1.Username, login.Id)
+	assert.ErrorIs(t, err, ErrOperationFailed)
+}
+
+func TestSQLite_ItemVersions(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+	db.itemVersions = 2
+
+	login := getTestItem(t, db, testItemLogin)
+	card := getTestItem(t, db, testItemCard)
+
+	for _, secret := range []string{"v1", "v2", "v3"} {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:      login.Id,
+			Name:    login.Name,
+			Secrets: &pb.Secrets{Secret: []byte(secret)},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Versions are trimmed to retention count", func(t *testing.T) {
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, int64(3), versions[0].Version)
+		assert.Equal(t, []byte("v2"), versions[0].Item.Secrets.Secret)
+		assert.Equal(t, int64(2), versions[1].Version)
+		assert.Equal(t, []byte("v1"), versions[1].Item.Secrets.Secret)
+		assert.Equal(t, login.Name, versions[1].Item.Name)
+		assert.Equal(t, login.Type, versions[1].Item.Type)
+	})
+
+	t.Run("Versions of another user's item", func(t *testing.T) {
+		versions, err := db.GetItemVersions(ctx, testUser2.Username, login.Id)
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+
+	t.Run("Restore version", func(t *testing.T) {
+		err := db.RestoreItemVersion(ctx, testUser1.Username, login.Id, 2)
+		require.NoError(t, err)
+
+		restored := getTestItem(t, db, login)
+		assert.Equal(t, []byte("v1"), restored.Secrets.Secret)
+		assert.Equal(t, login.Secrets.Notes, restored.Secrets.Notes)
+
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, int64(4), versions[0].Version)
+		assert.Equal(t, []byte("v3"), versions[0].Item.Secrets.Secret)
+	})
+
+	t.Run("Restore unexisting version", func(t *testing.T) {
+		err := db.RestoreItemVersion(ctx, testUser1.Username, login.Id, 1)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Restore version of another user's item", func(t *testing.T) {
+		err := db.RestoreItemVersion(ctx, testUser2.Username, login.Id, 4)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("History is disabled", func(t *testing.T) {
+		db.itemVersions = 0
+
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{Id: card.Id, Name: "renamedcard"})
+		require.NoError(t, err)
+
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, card.Id)
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+
+	t.Run("Versions are deleted with purged item", func(t *testing.T) {
+		err := db.DeleteItem(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+
+		err = db.PurgeItem(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+}
+
+func TestSQLite_Trash(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	login := getTestItem(t, db, testItemLogin)
+	card := getTestItem(t, db, testItemCard)
+
+	t.Run("Purge not trashed item", func(t *testing.T) {
+		err := db.PurgeItem(ctx, testUser1.Username, login.Id)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+
+	t.Run("Move items to trash", func(t *testing.T) {
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, login.Id))
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, card.Id))
+
+		_, err := db.GetItemByNameAndType(ctx, testUser1.Username, login.Name, login.Type)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		items, err := db.GetItemsByID(ctx, testUser1.Username, []int64{login.Id, card.Id})
+		require.NoError(t, err)
+		assert.Empty(t, items)
+
+		list, err := db.GetItemList(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, list, len(testItems))
+
+		for _, i := range list {
+			assert.Equal(t, i.Id == login.Id || i.Id == card.Id, i.Deleted != nil)
+		}
+	})
+
+	t.Run("Trashed items are listed in trash", func(t *testing.T) {
+		trash, err := db.GetTrashList(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, trash, 2)
+		assert.Equal(t, card.Id, trash[0].Id)
+		assert.Equal(t, login.Id, trash[1].Id)
+		assert.NotNil(t, trash[0].Deleted)
+
+		trash, err = db.GetTrashList(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Empty(t, trash)
+	})
+
+	t.Run("Trashed item can't be updated or trashed again", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{Id: login.Id, Name: "renamed"})
+		assert.ErrorIs(t, err, ErrOperationFailed)
+
+		err = db.DeleteItem(ctx, testUser1.Username, login.Id)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+
+	t.Run("Trashed item's name is reserved", func(t *testing.T) {
+		err := db.CreateItem(ctx, testUser1.Username, &pb.Item{Name: login.Name, Type: login.Type})
+		assert.ErrorIs(t, err, ErrDuplicateEntry)
+	})
+
+	t.Run("Restore item", func(t *testing.T) {
+		err := db.RestoreItem(ctx, testUser2.Username, login.Id)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+
+		err = db.RestoreItem(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+
+		restored := getTestItem(t, db, login)
+		assert.Equal(t, login.Secrets, restored.Secrets)
+
+		err = db.RestoreItem(ctx, testUser1.Username, login.Id)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+
+	t.Run("Purge expired items", func(t *testing.T) {
+		db.purgeTrash(ctx, time.Now().Add(-time.Hour), "test")
+
+		trash, err := db.GetTrashList(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Len(t, trash, 1)
+
+		db.purgeTrash(ctx, time.Now().Add(time.Second), "test")
+
+		trash, err = db.GetTrashList(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Empty(t, trash)
+	})
+
+	t.Run("Purge item", func(t *testing.T) {
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, login.Id))
+
+		err := db.PurgeItem(ctx, testUser2.Username, login.Id)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+
+		err = db.PurgeItem(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+
+		err = db.RestoreItem(ctx, testUser1.Username, login.Id)
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+}
+
+func TestSQLite_RotateEncryptionKey(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+	db.itemVersions = 2
+
+	login := getTestItem(t, db, testItemLogin)
+	card := getTestItem(t, db, testItemCard)
+
+	require.NoError(t, db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+		Id:      login.Id,
+		Name:    login.Name,
+		Secrets: &pb.Secrets{Secret: []byte("v1")},
+	}))
+	require.NoError(t, db.DeleteItem(ctx, testUser1.Username, card.Id))
+
+	items, err := db.GetAllItems(ctx, testUser1.Username)
+	require.NoError(t, err)
+	require.Len(t, items, len(testItems), "trashed items must be returned")
+
+	revision, err := db.GetUserRevision(ctx, testUser1.Username)
+	require.NoError(t, err)
+
+	newKey := []byte("rotatedkey")
+	for _, item := range items {
+		item.Secrets = &pb.Secrets{Notes: []byte("rotated notes"), Secret: []byte("rotated " + item.Name)}
+		item.Additions = &pb.Additions{CustomFields: []byte("rotated cf")}
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		ekey     []byte
+		items    []*pb.Item
+		wantErr  error
+	}{
+		{
+			name:     "Empty encryption key",
+			username: testUser1.Username,
+			items:    items,
+			wantErr:  ErrConstraintViolation,
+		},
+		{
+			name:     "Duplicate item",
+			username: testUser1.Username,
+			ekey:     newKey,
+			items:    append([]*pb.Item{items[0]}, items[:len(items)-1]...),
+			wantErr:  ErrConstraintViolation,
+		},
+		{
+			name:     "Missed item",
+			username: testUser1.Username,
+			ekey:     newKey,
+			items:    items[1:],
+			wantErr:  ErrOperationFailed,
+		},
+		{
+			name:     "Another user's items",
+			username: testUser2.Username,
+			ekey:     newKey,
+			items:    items,
+			wantErr:  ErrOperationFailed,
+		},
+		{
+			name:     "Item with wrong name",
+			username: testUser1.Username,
+			ekey:     newKey,
+			items:    append([]*pb.Item{{Id: items[0].Id, Name: "wrong", Type: items[0].Type}}, items[1:]...),
+			wantErr:  ErrOperationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := db.RotateEncryptionKey(ctx, tt.username, tt.ekey, nil, tt.items, nil, nil)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			ekey, err := db.GetUserEKey(ctx, testUser1.Username)
+			require.NoError(t, err)
+			assert.Equal(t, testUser1.Ekey, ekey, "nothing must be changed")
+
+			stored := getTestItem(t, db, login)
+			assert.Equal(t, []byte("v1"), stored.Secrets.Secret, "nothing must be changed")
+		})
+	}
+
+	t.Run("Rotate key", func(t *testing.T) {
+		err := db.RotateEncryptionKey(ctx, testUser1.Username, newKey, nil, items, nil, nil)
+		require.NoError(t, err)
+
+		ekey, err := db.GetUserEKey(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, newKey, ekey)
+
+		newRevision, err := db.GetUserRevision(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.NotEqual(t, revision, newRevision)
+
+		rotated, err := db.GetAllItems(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, rotated, len(items))
+
+		for i, item := range rotated {
+			assert.Equal(t, items[i].Secrets, item.Secrets)
+			assert.Equal(t, items[i].Additions.CustomFields, item.Additions.CustomFields)
+		}
+
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+		assert.Empty(t, versions, "history encrypted with previous key must be deleted")
+
+		trash, err := db.GetTrashList(ctx, testUser1.Username)
+		require.NoError(t, err)
+		require.Len(t, trash, 1)
+		assert.Equal(t, card.Id, trash[0].Id)
+	})
+}
+
+func TestSQLite_Quota(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	login := getTestItem(t, db, testItemLogin)
+
+	usage, err := db.GetUserUsage(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(testItems)), usage.Items)
+	assert.Positive(t, usage.Bytes)
+
+	t.Run("Usage of user without items", func(t *testing.T) {
+		usage, err := db.GetUserUsage(ctx, testUser2.Username)
+		require.NoError(t, err)
+		assert.Equal(t, &Usage{}, usage)
+	})
+
+	t.Run("Items limit", func(t *testing.T) {
+		db.quota = Quota{MaxItems: uint32(usage.Items)}
+
+		err := db.CreateItem(ctx, testUser1.Username, &pb.Item{Name: "overlimit", Type: common.ItemTypeSecNote})
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+		err = db.UpdateItem(ctx, testUser1.Username, &pb.Item{Id: login.Id, Name: login.Name})
+		assert.NoError(t, err, "existing items can be updated")
+
+		err = db.CreateItem(ctx, testUser2.Username, &pb.Item{Name: "overlimit", Type: common.ItemTypeSecNote})
+		assert.NoError(t, err, "quota is per-user")
+	})
+
+	t.Run("Bytes limit", func(t *testing.T) {
+		usage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		db.quota = Quota{MaxBytes: uint64(usage.Bytes) + 10}
+
+		err = db.CreateItem(ctx, testUser1.Username, &pb.Item{
+			Name:    "overlimit",
+			Type:    common.ItemTypeSecNote,
+			Secrets: &pb.Secrets{Notes: make([]byte, 11)},
+		})
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+		err = db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:      login.Id,
+			Name:    login.Name,
+			Secrets: &pb.Secrets{Notes: append(login.Secrets.Notes, make([]byte, 11)...)},
+		})
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+		stored := getTestItem(t, db, login)
+		assert.Equal(t, login.Secrets.Notes, stored.Secrets.Notes, "failed update mustn't change item")
+
+		err = db.CreateItem(ctx, testUser1.Username, &pb.Item{
+			Name:    "inlimit",
+			Type:    common.ItemTypeSecNote,
+			Secrets: &pb.Secrets{Notes: make([]byte, 10)},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Bytes limit with history", func(t *testing.T) {
+		db.quota = Quota{}
+		db.itemVersions = 2
+
+		defer func() { db.itemVersions = 0 }()
+
+		note := &pb.Item{Name: "history", Type: common.ItemTypeSecNote, Secrets: &pb.Secrets{Notes: make([]byte, 10)}}
+		require.NoError(t, db.CreateItem(ctx, testUser1.Username, note))
+		note = getTestItem(t, db, note)
+
+		update := func() error {
+			return db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+				Id:      note.Id,
+				Name:    note.Name,
+				Secrets: &pb.Secrets{Notes: make([]byte, 10)},
+			})
+		}
+
+		usage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		db.quota = Quota{MaxBytes: uint64(usage.Bytes) + 15}
+
+		require.NoError(t, update(), "first version fits in quota")
+
+		newUsage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, usage.Bytes+10, newUsage.Bytes, "version is counted")
+
+		assert.ErrorIs(t, update(), ErrQuotaExceeded, "second version exceeds quota")
+
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, note.Id)
+		require.NoError(t, err)
+		require.Len(t, versions, 1, "failed update mustn't add version")
+
+		err = db.RestoreItemVersion(ctx, testUser1.Username, note.Id, versions[0].Version)
+		assert.ErrorIs(t, err, ErrQuotaExceeded, "restore stores current state as version")
+
+		db.quota = Quota{MaxBytes: uint64(usage.Bytes) + 20}
+
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, update(), "only kept versions are counted")
+		}
+	})
+
+	t.Run("Bytes limit with shares", func(t *testing.T) {
+		db.quota = Quota{}
+
+		usage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		db.quota = Quota{MaxBytes: uint64(usage.Bytes) + 10}
+
+		share := &pb.Share{ItemId: login.Id, Recipient: testUser2.Username, Skey: []byte("skey"), Data: make([]byte, 11)}
+		_, err = db.ShareItem(ctx, testUser1.Username, share)
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+		share.Data = make([]byte, 10)
+		_, err = db.ShareItem(ctx, testUser1.Username, share)
+		require.NoError(t, err)
+
+		newUsage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, usage.Bytes+10, newUsage.Bytes, "share's data is counted in owner's usage")
+	})
+
+	db.quota = Quota{}
+}
+
+func TestSQLite_SecretData(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	data := getTestItem(t, db, testItemData)
+	login := getTestItem(t, db, testItemLogin)
+
+	download := func(t *testing.T) [][]byte {
+		t.Helper()
+
+		var chunks [][]byte
+		err := db.GetSecretData(ctx, testUser1.Username, data.Id, func(c []byte) error {
+			chunks = append(chunks, c)
+			return nil
+		})
+		require.NoError(t, err)
+
+		return chunks
+	}
+
+	chunks := [][]byte{[]byte("first chunk"), []byte("second chunk"), []byte("third")}
+
+	t.Run("Upload and download", func(t *testing.T) {
+		assert.Empty(t, download(t), "data item has no content before upload")
+
+		revision, err := db.GetUserRevision(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		size, err := db.SaveSecretData(ctx, testUser1.Username, data.Id, newTestChunkReader(chunks, nil))
+		require.NoError(t, err)
+		assert.Equal(t, int64(28), size)
+		assert.Equal(t, chunks, download(t))
+
+		newRevision, err := db.GetUserRevision(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.NotEqual(t, revision, newRevision)
+	})
+
+	t.Run("Upload replaces previous content", func(t *testing.T) {
+		chunks = [][]byte{[]byte("new content")}
+
+		_, err := db.SaveSecretData(ctx, testUser1.Username, data.Id, newTestChunkReader(chunks, nil))
+		require.NoError(t, err)
+		assert.Equal(t, chunks, download(t))
+	})
+
+	t.Run("Failed upload keeps previous content", func(t *testing.T) {
+		_, err := db.SaveSecretData(ctx, testUser1.Username, data.Id,
+			newTestChunkReader([][]byte{[]byte("lost")}, errors.New("stream closed")))
+		assert.ErrorIs(t, err, ErrOperationFailed)
+		assert.Equal(t, chunks, download(t))
+	})
+
+	t.Run("Too big data", func(t *testing.T) {
+		maxSecretSize := db.maxSecretSize
+		defer func() { db.maxSecretSize = maxSecretSize }()
+
+		db.maxSecretSize = 8
+
+		_, err := db.SaveSecretData(ctx, testUser1.Username, data.Id,
+			newTestChunkReader([][]byte{make([]byte, 100)}, nil))
+		assert.ErrorIs(t, err, ErrSecretTooBig)
+		assert.Equal(t, chunks, download(t))
+	})
+
+	t.Run("Quota", func(t *testing.T) {
+		defer func() { db.quota = Quota{} }()
+
+		usage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		db.quota = Quota{MaxBytes: uint64(usage.Bytes) + 10}
+
+		_, err = db.SaveSecretData(ctx, testUser1.Username, data.Id,
+			newTestChunkReader([][]byte{make([]byte, len(chunks[0])+11)}, nil))
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+		assert.Equal(t, chunks, download(t))
+	})
+
+	t.Run("Not data item or other user's item", func(t *testing.T) {
+		_, err := db.SaveSecretData(ctx, testUser1.Username, login.Id, newTestChunkReader(chunks, nil))
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = db.SaveSecretData(ctx, testUser2.Username, data.Id, newTestChunkReader(chunks, nil))
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		err = db.GetSecretData(ctx, testUser2.Username, data.Id, func(c []byte) error { return nil })
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Failed send", func(t *testing.T) {
+		err := db.GetSecretData(ctx, testUser1.Username, data.Id, func(c []byte) error {
+			return errors.New("stream closed")
+		})
+		assert.ErrorIs(t, err, ErrOperationFailed)
+	})
+
+	t.Run("Slow client doesn't block database", func(t *testing.T) {
+		release := make(chan struct{})
+		received := make(chan struct{})
+		done := make(chan error)
+
+		next := newTestChunkReader(chunks, nil)
+		slowNext := func() ([]byte, error) {
+			chunk, err := next()
+			if err != nil {
+				close(received)
+				<-release
+			}
+
+			return chunk, err
+		}
+
+		go func() {
+			_, err := db.SaveSecretData(ctx, testUser1.Username, data.Id, slowNext)
+			done <- err
+		}()
+
+		<-received
+
+		shortCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		_, err := db.GetItemList(shortCtx, testUser1.Username)
+		require.NoError(t, err, "database is available during upload")
+
+		close(release)
+		require.NoError(t, <-done)
+
+		release = make(chan struct{})
+		sent := make(chan struct{})
+
+		go func() {
+			done <- db.GetSecretData(ctx, testUser1.Username, data.Id, func(c []byte) error {
+				close(sent)
+				<-release
+
+				return nil
+			})
+		}()
+
+		<-sent
+
+		_, err = db.GetItemList(shortCtx, testUser1.Username)
+		require.NoError(t, err, "database is available during download")
+
+		close(release)
+		require.NoError(t, <-done)
+	})
+
+	countUploads := func(t *testing.T) (count int) {
+		t.Helper()
+
+		row := db.db.QueryRowContext(ctx, `select count(*) from secret_chunk_uploads`)
+		require.NoError(t, row.Scan(&count))
+
+		return
+	}
+
+	t.Run("Chunks are stored as they are received", func(t *testing.T) {
+		var stored []int
+
+		next := newTestChunkReader(chunks, nil)
+		countingNext := func() ([]byte, error) {
+			stored = append(stored, countUploads(t))
+			return next()
+		}
+
+		_, err := db.SaveSecretData(ctx, testUser1.Username, data.Id, countingNext)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, stored)
+		assert.Zero(t, countUploads(t), "upload's chunks are deleted after upload")
+
+		_, err = db.SaveSecretData(ctx, testUser1.Username, data.Id,
+			newTestChunkReader(chunks, errors.New("stream closed")))
+		assert.ErrorIs(t, err, ErrOperationFailed)
+		assert.Zero(t, countUploads(t), "upload's chunks are deleted after failed upload")
+	})
+
+	t.Run("Content replaced during download", func(t *testing.T) {
+		chunks = [][]byte{[]byte("first chunk"), []byte("second chunk")}
+
+		_, err := db.SaveSecretData(ctx, testUser1.Username, data.Id, newTestChunkReader(chunks, nil))
+		require.NoError(t, err)
+
+		var received [][]byte
+		err = db.GetSecretData(ctx, testUser1.Username, data.Id, func(c []byte) error {
+			received = append(received, c)
+			if len(received) > 1 {
+				return nil
+			}
+
+			_, err := db.SaveSecretData(ctx, testUser1.Username, data.Id,
+				newTestChunkReader([][]byte{[]byte("other"), []byte("content")}, nil))
+
+			return err
+		})
+		assert.ErrorIs(t, err, ErrOperationFailed)
+		assert.Equal(t, chunks[:1], received, "chunks of new content mustn't be mixed with previous one")
+	})
+}
+
+func TestSQLite_SecretBlobs(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := blobstore.NewFS(dir)
+	require.NoError(t, err)
+
+	db := newTestSQLite(t, func(p *Parameters) {
+		p.SetBlobStore(store, 16)
+		p.SetItemVersions(2)
+	})
+
+	getRow := func(t *testing.T, itemID int64) (secret []byte, key *string, size int64) {
+		t.Helper()
+
+		row := db.db.QueryRowContext(ctx, `select secret, blob_key, blob_size from secrets where item_id = ?`, itemID)
+		require.NoError(t, row.Scan(&secret, &key, &size))
+
+		return
+	}
+
+	// ageBlobs makes all stored blobs older than garbage collector's grace period.
+	ageBlobs := func(t *testing.T) {
+		t.Helper()
+
+		blobs, err := store.List(ctx)
+		require.NoError(t, err)
+
+		old := time.Now().Add(-2 * blobGCGracePeriod)
+		for _, b := range blobs {
+			require.NoError(t, os.Chtimes(filepath.Join(dir, b.Key[:2], b.Key), old, old))
+		}
+	}
+
+	bigSecret := bytes.Repeat([]byte("big secret "), 10)
+	item := &pb.Item{Name: "blob item", Type: common.ItemTypeSecData, Secrets: &pb.Secrets{Secret: bigSecret}}
+
+	t.Run("Small secret is stored in database", func(t *testing.T) {
+		login := getTestItem(t, db, testItemLogin)
+
+		secret, key, _ := getRow(t, login.Id)
+		assert.Equal(t, testItemLogin.Secrets.Secret, secret)
+		assert.Nil(t, key)
+	})
+
+	t.Run("Big secret is stored in blob store", func(t *testing.T) {
+		require.NoError(t, db.CreateItem(ctx, testUser1.Username, item))
+
+		stored := getTestItem(t, db, item)
+		assert.Equal(t, bigSecret, stored.Secrets.Secret)
+		item.Id = stored.Id
+
+		secret, key, size := getRow(t, item.Id)
+		assert.Nil(t, secret)
+		require.NotNil(t, key)
+		assert.Equal(t, blobstore.Key(bigSecret), *key)
+		assert.Equal(t, int64(len(bigSecret)), size)
+
+		items, err := db.GetItemsByID(ctx, testUser1.Username, []int64{item.Id})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, bigSecret, items[0].Secrets.Secret)
+	})
+
+	t.Run("Blob is counted in usage", func(t *testing.T) {
+		usage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, usage.Bytes, int64(len(bigSecret)))
+	})
+
+	t.Run("Too big secret", func(t *testing.T) {
+		err := db.CreateItem(ctx, testUser1.Username, &pb.Item{
+			Name:    "too big",
+			Type:    common.ItemTypeSecData,
+			Secrets: &pb.Secrets{Secret: make([]byte, 1029)},
+		})
+		assert.ErrorIs(t, err, ErrConstraintViolation)
+	})
+
+	newSecret := bytes.Repeat([]byte("new big secret "), 10)
+
+	t.Run("Update keeps previous blob in history", func(t *testing.T) {
+		usage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		err = db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:      item.Id,
+			Name:    item.Name,
+			Secrets: &pb.Secrets{Secret: newSecret},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, newSecret, getTestItem(t, db, item).Secrets.Secret)
+
+		err = db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:      item.Id,
+			Name:    item.Name,
+			Secrets: &pb.Secrets{Notes: []byte("notes only")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, newSecret, getTestItem(t, db, item).Secrets.Secret, "secret mustn't be changed")
+
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, item.Id)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, newSecret, versions[0].Item.Secrets.Secret)
+		assert.Equal(t, bigSecret, versions[1].Item.Secrets.Secret)
+
+		newUsage, err := db.GetUserUsage(ctx, testUser1.Username)
+		require.NoError(t, err)
+		assert.Equal(t, usage.Bytes+int64(len(newSecret)+len("notes only")), newUsage.Bytes,
+			"blob shared by version and item is counted once")
+	})
+
+	t.Run("Restore version", func(t *testing.T) {
+		require.NoError(t, db.RestoreItemVersion(ctx, testUser1.Username, item.Id, 1))
+		assert.Equal(t, bigSecret, getTestItem(t, db, item).Secrets.Secret)
+	})
+
+	t.Run("Garbage collection", func(t *testing.T) {
+		orphan, err := store.Put(ctx, []byte("orphan blob"))
+		require.NoError(t, err)
+
+		ageBlobs(t)
+
+		fresh, err := store.Put(ctx, []byte("fresh orphan blob"))
+		require.NoError(t, err)
+
+		db.collectBlobs(ctx, "test")
+
+		_, err = store.Get(ctx, orphan)
+		assert.ErrorIs(t, err, blobstore.ErrNotFound, "old unreferenced blob must be deleted")
+		_, err = store.Get(ctx, fresh)
+		assert.NoError(t, err, "fresh blob must be kept")
+		_, err = store.Get(ctx, blobstore.Key(bigSecret))
+		assert.NoError(t, err, "referenced blob must be kept")
+		_, err = store.Get(ctx, blobstore.Key(newSecret))
+		assert.NoError(t, err, "blob referenced by version must be kept")
+
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, item.Id))
+		require.NoError(t, db.PurgeItem(ctx, testUser1.Username, item.Id))
+		ageBlobs(t)
+
+		db.collectBlobs(ctx, "test")
+
+		_, err = store.Get(ctx, blobstore.Key(bigSecret))
+		assert.ErrorIs(t, err, blobstore.ErrNotFound, "blob of purged item must be deleted")
+		_, err = store.Get(ctx, blobstore.Key(newSecret))
+		assert.ErrorIs(t, err, blobstore.ErrNotFound, "blob of purged item's version must be deleted")
+	})
+
+	t.Run("Blob store is not configured", func(t *testing.T) {
+		_, err := (&secretBlobs{}).get(ctx, blobstore.Key(bigSecret))
+		assert.ErrorIs(t, err, ErrInternalDBError)
+	})
+}
+
+func TestSQLite_ItemChanges(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	login := getTestItem(t, db, testItemLogin)
+	card := getTestItem(t, db, testItemCard)
+
+	revision, err := db.GetUserRevision(ctx, testUser1.Username)
+	require.NoError(t, err)
+
+	t.Run("All items are created since zero revision", func(t *testing.T) {
+		changes, err := db.GetChangesSince(ctx, testUser1.Username, 0)
+		require.NoError(t, err)
+		assert.Equal(t, revision, changes.Revision)
+		assert.Len(t, changes.Created, len(testItems))
+		assert.Empty(t, changes.Updated)
+		assert.Empty(t, changes.Deleted)
+
+		changes, err = db.GetChangesSince(ctx, testUser2.Username, 0)
+		require.NoError(t, err)
+		assert.Equal(t, &Changes{}, changes)
+	})
+
+	t.Run("No changes since current revision", func(t *testing.T) {
+		changes, err := db.GetChangesSince(ctx, testUser1.Username, revision)
+		require.NoError(t, err)
+		assert.Equal(t, &Changes{Revision: revision}, changes)
+	})
+
+	item := &pb.Item{Name: "changes item", Type: common.ItemTypeSecNote}
+
+	t.Run("Changes are listed by type", func(t *testing.T) {
+		require.NoError(t, db.CreateItem(ctx, testUser1.Username, item))
+		item.Id = getTestItem(t, db, item).Id
+
+		require.NoError(t, db.UpdateItem(ctx, testUser1.Username, &pb.Item{Id: login.Id, Name: login.Name}))
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, card.Id))
+
+		changes, err := db.GetChangesSince(ctx, testUser1.Username, revision)
+		require.NoError(t, err)
+		assert.Equal(t, revision+3, changes.Revision)
+		assert.Equal(t, []int64{item.Id}, changes.Created)
+		assert.ElementsMatch(t, []int64{login.Id, card.Id}, changes.Updated)
+		assert.Empty(t, changes.Deleted)
+	})
+
+	t.Run("Item created and purged since revision is not listed", func(t *testing.T) {
+		require.NoError(t, db.DeleteItem(ctx, testUser1.Username, item.Id))
+		require.NoError(t, db.PurgeItem(ctx, testUser1.Username, item.Id))
+
+		changes, err := db.GetChangesSince(ctx, testUser1.Username, revision)
+		require.NoError(t, err)
+		assert.Empty(t, changes.Created)
+		assert.Empty(t, changes.Deleted)
+
+		changes, err = db.GetChangesSince(ctx, testUser1.Username, revision+1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{item.Id}, changes.Deleted)
+	})
+
+	t.Run("Purge of expired items is recorded", func(t *testing.T) {
+		current, err := db.GetUserRevision(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		db.purgeTrash(ctx, time.Now().Add(time.Second), "test")
+
+		changes, err := db.GetChangesSince(ctx, testUser1.Username, current)
+		require.NoError(t, err)
+		assert.Equal(t, current+1, changes.Revision)
+		assert.Equal(t, []int64{card.Id}, changes.Deleted)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		_, err := db.GetChangesSince(ctx, "unknown", 0)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestSQLite_WatchRevision(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := newTestSQLite(t)
+
+	revisions, err := db.WatchRevision(ctx, testUser1.Username)
+	require.NoError(t, err)
+
+	item := &pb.Item{Name: "watched item", Type: common.ItemTypeSecNote}
+	require.NoError(t, db.CreateItem(ctx, testUser1.Username, item))
+
+	revision, err := db.GetUserRevision(ctx, testUser1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, revision, <-revisions)
+
+	require.NoError(t, db.DeleteItem(ctx, testUser1.Username, getTestItem(t, db, testItemLogin).Id))
+	assert.Equal(t, revision+1, <-revisions)
+
+	db.purgeTrash(ctx, time.Now().Add(time.Second), "test")
+	assert.Equal(t, revision+2, <-revisions)
+
+	cancel()
+
+	_, ok := <-revisions
+	assert.False(t, ok)
+}
+
+func TestSQLite_ItemTags(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+	db.itemVersions = 2
+
+	login := getTestItem(t, db, testItemLogin)
+
+	t.Run("Tags are listed", func(t *testing.T) {
+		items, err := db.GetItemList(ctx, testUser1.Username)
+		require.NoError(t, err)
+
+		for _, item := range items {
+			if item.Id == login.Id {
+				assert.Equal(t, testItemLogin.Additions.Tags, item.Tags)
+			}
+		}
+	})
+
+	t.Run("Not set tags are kept", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:        login.Id,
+			Name:      login.Name,
+			Additions: &pb.Additions{CustomFields: []byte("new cf")},
+		})
+		require.NoError(t, err)
+
+		item := getTestItem(t, db, login)
+		assert.Equal(t, testItemLogin.Additions.Tags, item.Additions.Tags)
+	})
+
+	t.Run("Tags are updated and kept in history", func(t *testing.T) {
+		err := db.UpdateItem(ctx, testUser1.Username, &pb.Item{
+			Id:        login.Id,
+			Name:      login.Name,
+			Additions: &pb.Additions{Tags: []byte("new tags")},
+		})
+		require.NoError(t, err)
+
+		item := getTestItem(t, db, login)
+		assert.Equal(t, []byte("new tags"), item.Additions.Tags)
+
+		versions, err := db.GetItemVersions(ctx, testUser1.Username, login.Id)
+		require.NoError(t, err)
+		require.NotEmpty(t, versions)
+		assert.Equal(t, testItemLogin.Additions.Tags, versions[0].Item.Additions.Tags)
+	})
+}
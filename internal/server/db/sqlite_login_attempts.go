@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// ReserveLoginAttempt atomically reserves login attempt with key, which is counted as failed until
+// it's released, and deletes stale unlocked attempts.
+//
+// If logins with key are locked, attempt isn't reserved and false is returned. Otherwise logins
+// with key are locked for period returned by lockout for new number of failures in same transaction,
+// so parallel attempts can't pass threshold. Failures recorded before resetBefore are forgotten.
+func (db *SQLite) ReserveLoginAttempt(ctx context.Context, key string, resetBefore time.Time,
+	lockout LoginLockout) (*LoginAttempts, bool, error) {
+	componentName := "SQLite:ReserveLoginAttempt"
+	now := time.Now()
+
+	stmtStale, argsStale, err := newStaleLoginAttemptsDeleteStmt(db.psql, resetBefore)
+	if err != nil {
+		return nil, false, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtStale, argsStale), componentName)
+
+	if _, err := db.db.ExecContext(ctx, stmtStale, argsStale...); err != nil {
+		return nil, false, wrapSQLiteError(err)
+	}
+
+	stmtReserve, argsReserve, err := newLoginAttemptReserveStmt(db.psql, key, resetBefore, now)
+	if err != nil {
+		return nil, false, stackErrors(ErrInternalDBError, err)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return nil, false, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtReserve, argsReserve), componentName)
+
+	attempts := new(LoginAttempts)
+	if err := sqlscan.Get(ctx, tx, attempts, stmtReserve, argsReserve...); err != nil {
+		if !sqlscan.NotFound(err) {
+			return nil, false, wrapSQLiteError(err)
+		}
+
+		// Logins with key are locked, so attempt isn't reserved.
+		attempts, err := db.getLoginAttempts(ctx, tx, key, componentName)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return attempts, false, nil
+	}
+
+	if period := lockout(attempts.Failures); period > 0 {
+		attempts.LockedUntil = now.Add(period)
+
+		stmtLock, argsLock, err := newLoginLockStmt(db.psql, key, attempts.LockedUntil)
+		if err != nil {
+			return nil, false, stackErrors(ErrInternalDBError, err)
+		}
+
+		db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtLock, argsLock), componentName)
+
+		if _, err := tx.ExecContext(ctx, stmtLock, argsLock...); err != nil {
+			return nil, false, wrapSQLiteError(err)
+		}
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return nil, false, err
+	}
+
+	return attempts, true, nil
+}
+
+// ReleaseLoginAttempt forgets reserved login attempt with key, which isn't failed.
+//
+// If unlock is true, logins with key are unlocked.
+func (db *SQLite) ReleaseLoginAttempt(ctx context.Context, key string, unlock bool) error {
+	componentName := "SQLite:ReleaseLoginAttempt"
+
+	stmtRelease, argsRelease, err := newLoginAttemptReleaseStmt(db.psql, key, unlock)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtRelease, argsRelease), componentName)
+
+	if _, err := db.db.ExecContext(ctx, stmtRelease, argsRelease...); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	return nil
+}
+
+// GetLoginAttempts returns key's failed login attempts.
+//
+// If there are no recorded attempts GetLoginAttempts returns error (ErrNotFound).
+func (db *SQLite) GetLoginAttempts(ctx context.Context, key string) (*LoginAttempts, error) {
+	return db.getLoginAttempts(ctx, db.db, key, "SQLite:GetLoginAttempts")
+}
+
+// getLoginAttempts is a helper function which returns key's failed login attempts.
+func (db *SQLite) getLoginAttempts(ctx context.Context, q sqlscan.Querier, key string,
+	componentName string) (*LoginAttempts, error) {
+	stmtAttempts, argsAttempts, err := newLoginAttemptsSelect(db.psql, key)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtAttempts, argsAttempts), componentName)
+
+	attempts := new(LoginAttempts)
+	if err := sqlscan.Get(ctx, q, attempts, stmtAttempts, argsAttempts...); err != nil {
+		if sqlscan.NotFound(err) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	return attempts, nil
+}
+
+// DeleteLoginAttempts deletes key's failed login attempts.
+func (db *SQLite) DeleteLoginAttempts(ctx context.Context, key string) error {
+	componentName := "SQLite:DeleteLoginAttempts"
+
+	stmtAttempts, argsAttempts, err := newLoginAttemptsDeleteStmt(db.psql, key)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtAttempts, argsAttempts), componentName)
+
+	if _, err := db.db.ExecContext(ctx, stmtAttempts, argsAttempts...); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestSQLite_LoginAttempts(t *testing.T) {
+	testLoginAttempts(t, newTestSQLite(t))
+}
@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// SQLite represents SQLite implementation of DB.
+//
+// SQLite stores all records in single database file and intended for small
+// installations, where running PostgreSQL server is unnecessary.
+type SQLite struct {
+	// Database connection
+	db *sql.DB
+	// Schema migrations
+	migrations []Migration
+	// Logger
+	logger logger.L
+	// DSN string
+	DSN string
+	// Squirell statement builder for SQLite ? placeholder configuration
+	psql sq.StatementBuilderType
+	// Maximum size of secret in bytes
+	maxSecretSize uint32
+	// Number of items' previous versions, kept in history
+	itemVersions uint32
+	// Period, after which trashed items are purged
+	trashRetention time.Duration
+	// Per-user storage quota
+	quota Quota
+	// Store for large secrets
+	blobs *secretBlobs
+	// Subscriptions to changes of users' vaults
+	watchers *revisionWatchers
+	// Mutex for sync connection and clear operations, which may concrutently use structure fields
+	mu sync.Mutex
+}
+
+var _ DB = (*SQLite)(nil)
+
+// newSQLite is used to create new SQLite instance.
+//
+// Database address should be a path to database file, file is created if not exists.
+func newSQLite(params *Parameters, logger logger.L) (*SQLite, error) {
+	if params.address == "" {
+		return nil, errors.New("missed database file path")
+	}
+
+	db := new(SQLite)
+
+	db.logger = logger
+	db.DSN = fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", params.address)
+
+	migrations, err := loadMigrations(migrationsFS, migrationsDirSQLite, params)
+	if err != nil {
+		return nil, err
+	}
+
+	db.migrations = migrations
+	db.psql = sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	db.maxSecretSize = params.maxSecretSize
+	db.itemVersions = params.itemVersions
+	db.trashRetention = params.trashRetention
+	db.quota = params.quota
+	db.blobs = newSecretBlobs(params)
+	db.watchers = newRevisionWatchers(true)
+
+	return db, nil
+}
+
+// Connect is used for open database file.
+//
+// SQLite allows only one writer at a time, so connection pool is limited to one connection.
+func (db *SQLite) Connect(ctx context.Context) (err error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.db, err = sql.Open(sqliteDriverName, db.DSN)
+	if err != nil {
+		return
+	}
+
+	db.db.SetMaxOpenConns(1)
+
+	return db.db.PingContext(ctx)
+}
+
+// Setup applies all pending schema migrations.
+func (db *SQLite) Setup(ctx context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.migrate(ctx)
+}
+
+// ConnectAndSetup does same as sequentially calling Connect and Setup function.
+func (db *SQLite) ConnectAndSetup(ctx context.Context) (err error) {
+	if err := db.Connect(ctx); err != nil {
+		return err
+	}
+
+	if err := db.Setup(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes database.
+func (db *SQLite) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.db == nil {
+		return nil
+	}
+
+	return db.db.Close()
+}
+
+// Run is used for controls database connection.
+//
+// After context expired or cancel function Run will close database
+// and close channel.
+// If trash retention period is set Run periodically purges expired trashed items.
+// If blob store is set Run periodically and on request collects unreferenced blobs.
+// Run periodically approves requested emergency accesses with elapsed waiting period.
+func (db *SQLite) Run(ctx context.Context, closeCh CloseChannel) {
+	componentName := "SQLite:run"
+	db.logger.Info("DB is running", componentName)
+
+	var purgeCh <-chan time.Time
+
+	if db.trashRetention > 0 {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		purgeCh = ticker.C
+	}
+
+	var gcCh <-chan time.Time
+
+	if db.blobs.store != nil {
+		ticker := time.NewTicker(blobGCInterval)
+		defer ticker.Stop()
+
+		gcCh = ticker.C
+	}
+
+	approveTicker := time.NewTicker(emergencyApproveInterval)
+	defer approveTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := db.Close(); err != nil {
+				db.logger.Error(err, "close database", componentName)
+			}
+
+			db.logger.Info("DB is stopped", componentName)
+			close(closeCh)
+
+			return
+		case <-purgeCh:
+			db.purgeTrash(ctx, time.Now().Add(-db.trashRetention), componentName)
+		case <-approveTicker.C:
+			db.approveEmergencyAccesses(ctx, time.Now(), componentName)
+		case <-gcCh:
+			db.collectBlobs(ctx, componentName)
+		case <-db.blobs.gcCh:
+			db.collectBlobs(ctx, componentName)
+		}
+	}
+}
+
+// Clear is used to delete all database's tables and records.
+//
+// Tables are dropped in reverse order of creation.
+func (db *SQLite) Clear(ctx context.Context) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	componentName := "SQLite:clear"
+
+	var tables []string
+
+	sqlStmt := `select name from sqlite_master where type = 'table' and name not like 'sqlite_%' order by rowid desc`
+	if err := sqlscan.Select(ctx, db.db, &tables, sqlStmt); err != nil {
+		db.logger.Error(err, "list tables", componentName)
+
+		return
+	}
+
+	for _, t := range tables {
+		if _, err := db.db.ExecContext(ctx, fmt.Sprintf("drop table if exists %s", t)); err != nil {
+			db.logger.Error(err, fmt.Sprintf("table '%s'", t), componentName)
+
+			continue
+		}
+
+		db.logger.Info(fmt.Sprintf("table '%s': dropped", t), componentName)
+	}
+}
+
+// GetMaxSecretSize returns maximum available size of secret.
+func (db *SQLite) GetMaxSecretSize() uint32 {
+	return db.maxSecretSize
+}
+
+// GetUserQuota returns per-user storage quota.
+func (db *SQLite) GetUserQuota() Quota {
+	return db.quota
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/georgysavva/scany/sqlscan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSQLite is a helper function which creates SQLite database in temporary directory
+// filled with testing users and items. All testing items belong to testUser1.
+// Options are applied to database's parameters before creation.
+func newTestSQLite(t *testing.T, opts ...func(*Parameters)) *SQLite {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	params := testDBConnParams
+	params.address = filepath.Join(t.TempDir(), "gophkeeper.db")
+	params.maxSecretSize = 1024
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	db, err := newSQLite(&params, mocklogger.NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, db.ConnectAndSetup(ctx))
+
+	closeCh := make(CloseChannel)
+	go db.Run(ctx, closeCh)
+
+	t.Cleanup(func() {
+		cancel()
+		<-closeCh
+	})
+
+	fillTestDB(t, db)
+
+	return db
+}
+
+func TestNewSQLite(t *testing.T) {
+	logger := mocklogger.NewMockLogger()
+
+	t.Run("No DB path", func(t *testing.T) {
+		_, err := newSQLite(NewParameters("", "", "", 10000000), logger)
+		assert.Error(t, err)
+	})
+
+	t.Run("New SQLite DB", func(t *testing.T) {
+		db, err := newSQLite(NewParameters("test.db", "", "", 10000000), logger)
+		require.NoError(t, err)
+		assert.NotEmpty(t, db)
+		assert.Equal(t, uint32(10000000), db.GetMaxSecretSize())
+	})
+}
+
+func TestSQLite_Connect(t *testing.T) {
+	params := NewParameters(filepath.Join(t.TempDir(), "unknown", "test.db"), "", "", 10000000)
+	db, err := newSQLite(params, mocklogger.NewMockLogger())
+	require.NoError(t, err)
+
+	err = db.Connect(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSQLite_Close(t *testing.T) {
+	ctx := context.Background()
+	params := NewParameters(filepath.Join(t.TempDir(), "test.db"), "", "", 10000000)
+
+	db, err := newSQLite(params, mocklogger.NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, db.ConnectAndSetup(ctx))
+
+	require.NoError(t, db.Close())
+
+	_, err = db.GetUserByName(ctx, testUser1.Username)
+	assert.Error(t, err, "closed database can't be used")
+}
+
+func TestSQLite_Clear(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	db.Clear(ctx)
+
+	_, err := db.GetUserByName(ctx, testUser1.Username)
+	assert.ErrorIs(t, err, ErrBadSQLQuery)
+
+	require.NoError(t, db.Setup(ctx))
+
+	_, err = db.GetUserByName(ctx, testUser1.Username)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSQLite_Setup(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSQLite(t)
+
+	// Migrations are already applied, repeated setup must keep records.
+	require.NoError(t, db.Setup(ctx))
+
+	var versions []int64
+	err := sqlscan.Select(ctx, db.db, &versions, `select version from `+migrationsTable+` order by version`)
+	require.NoError(t, err)
+	require.Len(t, versions, len(db.migrations))
+
+	for i, m := range db.migrations {
+		assert.Equal(t, m.Version, versions[i])
+	}
+
+	_, err = db.GetUserByName(ctx, testUser1.Username)
+	assert.NoError(t, err)
+}
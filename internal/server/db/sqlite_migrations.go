@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// migrate applies all pending migrations.
+//
+// Every migration is applied in separate transaction.
+func (db *SQLite) migrate(ctx context.Context) error {
+	componentName := "SQLite:migrate"
+
+	sqlStmt := `
+		create table if not exists ` + migrationsTable + ` (
+			version integer primary key,
+			name varchar not null,
+			applied timestamp not null default current_timestamp
+		)`
+
+	if _, err := db.db.ExecContext(ctx, sqlStmt); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	applied, err := db.getAppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range db.migrations {
+		if applied[m.Version] {
+			continue
+		}
+
+		if err := db.applyMigration(ctx, m); err != nil {
+			db.logger.Error(err, fmt.Sprintf("migration %d_%s", m.Version, m.Name), componentName)
+			return err
+		}
+
+		db.logger.Info(fmt.Sprintf("migration %d_%s is applied", m.Version, m.Name), componentName)
+	}
+
+	return nil
+}
+
+// getAppliedMigrations returns set of already applied migrations' versions.
+func (db *SQLite) getAppliedMigrations(ctx context.Context) (map[int64]bool, error) {
+	rows, err := db.db.QueryContext(ctx, `select version from `+migrationsTable)
+	if err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, stackErrors(ErrInternalDBError, err)
+		}
+
+		applied[version] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	return applied, nil
+}
+
+// applyMigration applies migration and saves it's version in one transaction.
+func (db *SQLite) applyMigration(ctx context.Context, m Migration) error {
+	tx, err := db.beginTx(ctx, "SQLite:applyMigration")
+	if err != nil {
+		return err
+	}
+	defer db.deferTxRollback(tx)
+
+	if _, err := tx.ExecContext(ctx, m.Statement); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `insert into `+migrationsTable+` (version, name) values (?, ?)`,
+		m.Version, m.Name); err != nil {
+		return wrapSQLiteError(err)
+	}
+
+	return db.commitTx(tx, "SQLite:applyMigration")
+}
@@ -0,0 +1,260 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// CreateOrganization creates new organization with its vault, adds creator as organization's
+// administrator and returns organization's ID.
+func (db *SQLite) CreateOrganization(ctx context.Context, creator Username, org *pb.Organization) (int64, error) {
+	if creator == "" {
+		return 0, ErrNotFound
+	}
+
+	componentName := "SQLite:CreateOrganization"
+
+	if err := checkNewOrganization(org); err != nil {
+		return 0, err
+	}
+
+	vault := newOrgVaultName()
+
+	stmtVault, argsVault, err := newOrgVaultInsertStmt(db.psql, vault)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	stmtOrg, argsOrg, err := newOrgInsertStmt(db.psql, vault, org)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return 0, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtVault, argsVault), componentName)
+
+	if _, err := tx.ExecContext(ctx, stmtVault, argsVault...); err != nil {
+		return 0, wrapSQLiteError(err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtOrg, argsOrg), componentName)
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, stmtOrg, argsOrg...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errNoRowsAffected()
+		}
+
+		return 0, wrapSQLiteError(err)
+	}
+
+	creatorMember := &pb.Member{Username: creator, Role: common.OrgRoleAdmin, Okey: org.Okey}
+
+	stmtMember, argsMember, err := newMemberInsertStmt(db.psql, id, creatorMember, true)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtMember, argsMember...)
+
+	if err := db.execBatch(ctx, tx, b); err != nil {
+		return 0, err
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetOrganizations returns user's organizations including not accepted invites.
+func (db *SQLite) GetOrganizations(ctx context.Context, username Username) ([]*pb.Organization, error) {
+	componentName := "SQLite:GetOrganizations"
+
+	stmtOrgs, argsOrgs, err := newOrganizationsSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtOrgs, argsOrgs), componentName)
+
+	var dbOrgs []*Organization
+	if err := sqlscan.Select(ctx, db.db, &dbOrgs, stmtOrgs, argsOrgs...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	orgs := make([]*pb.Organization, 0, len(dbOrgs))
+	for _, org := range dbOrgs {
+		orgs = append(orgs, org.toPB())
+	}
+
+	return orgs, nil
+}
+
+// UpdateOrganization renames organization.
+func (db *SQLite) UpdateOrganization(ctx context.Context, org *pb.Organization) error {
+	componentName := "SQLite:UpdateOrganization"
+
+	if err := checkOrganization(org); err != nil {
+		return err
+	}
+
+	stmtOrg, argsOrg, err := newOrgUpdateStmt(db.psql, org)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtOrg, argsOrg...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// DeleteOrganization deletes organization with its vault, members and items.
+func (db *SQLite) DeleteOrganization(ctx context.Context, orgID int64) error {
+	componentName := "SQLite:DeleteOrganization"
+
+	stmtOrg, argsOrg, err := newOrgDeleteStmt(db.psql, orgID)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtOrg, argsOrg...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// GetMembers returns organization's members without keys.
+func (db *SQLite) GetMembers(ctx context.Context, orgID int64) ([]*pb.Member, error) {
+	componentName := "SQLite:GetMembers"
+
+	stmtMembers, argsMembers, err := newMembersSelect(db.psql, orgID)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtMembers, argsMembers), componentName)
+
+	var dbMembers []*Member
+	if err := sqlscan.Select(ctx, db.db, &dbMembers, stmtMembers, argsMembers...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	members := make([]*pb.Member, 0, len(dbMembers))
+	for _, member := range dbMembers {
+		members = append(members, member.toPB())
+	}
+
+	return members, nil
+}
+
+// GetMembership returns user's membership in organization.
+//
+// If user isn't organization's member GetMembership returns ErrNotFound.
+func (db *SQLite) GetMembership(ctx context.Context, orgID int64, username Username) (*Membership, error) {
+	componentName := "SQLite:GetMembership"
+
+	stmtMembership, argsMembership, err := newMembershipSelect(db.psql, orgID, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtMembership, argsMembership), componentName)
+
+	membership := new(Membership)
+	if err := sqlscan.Get(ctx, db.db, membership, stmtMembership, argsMembership...); err != nil {
+		if sqlscan.NotFound(err) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	return membership, nil
+}
+
+// AddMember invites user to organization.
+//
+// Invited user becomes organization's member after accepting invite.
+func (db *SQLite) AddMember(ctx context.Context, orgID int64, member *pb.Member) error {
+	componentName := "SQLite:AddMember"
+
+	if err := checkMember(member); err != nil {
+		return err
+	}
+
+	stmtMember, argsMember, err := newMemberInsertStmt(db.psql, orgID, member, false)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtMember, argsMember...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// AcceptInvite accepts user's invite to organization.
+func (db *SQLite) AcceptInvite(ctx context.Context, orgID int64, username Username) error {
+	componentName := "SQLite:AcceptInvite"
+
+	stmtMember, argsMember, err := newMemberUpdateStmt(db.psql, orgID, username,
+		map[string]interface{}{"accepted": true})
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtMember, argsMember...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// UpdateMemberRole changes member's role.
+func (db *SQLite) UpdateMemberRole(ctx context.Context, orgID int64, username Username, role string) error {
+	componentName := "SQLite:UpdateMemberRole"
+
+	if err := checkOrgRole(role); err != nil {
+		return err
+	}
+
+	stmtMember, argsMember, err := newMemberUpdateStmt(db.psql, orgID, username,
+		map[string]interface{}{"role": role})
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtMember, argsMember...)
+
+	return db.runBatch(ctx, b, componentName)
+}
+
+// RemoveMember removes member or not accepted invite from organization.
+func (db *SQLite) RemoveMember(ctx context.Context, orgID int64, username Username) error {
+	componentName := "SQLite:RemoveMember"
+
+	stmtMember, argsMember, err := newMemberDeleteStmt(db.psql, orgID, username)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtMember, argsMember...)
+
+	return db.runBatch(ctx, b, componentName)
+}
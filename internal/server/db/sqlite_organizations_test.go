@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestSQLite_Organizations(t *testing.T) {
+	testOrganizations(t, newTestSQLite(t))
+}
@@ -0,0 +1,7 @@
+package db
+
+import "testing"
+
+func TestSQLite_Sessions(t *testing.T) {
+	testSessions(t, newTestSQLite(t))
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// ShareItem shares owner's item with recipient and returns share's ID.
+//
+// If item is already shared with recipient, share is replaced.
+// Share's data is counted in owner's usage, if owner's quota of stored data is exceeded
+// returns ErrQuotaExceeded.
+func (db *SQLite) ShareItem(ctx context.Context, owner Username, share *pb.Share) (int64, error) {
+	if owner == "" {
+		return 0, ErrNotFound
+	}
+
+	componentName := "SQLite:ShareItem"
+
+	if err := checkNewShare(share); err != nil {
+		return 0, err
+	}
+
+	stmtShare, argsShare, err := newShareUpsertStmt(db.psql, owner, share)
+	if err != nil {
+		return 0, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtShare, argsShare), componentName)
+
+	tx, err := db.beginTx(ctx, componentName)
+	if err != nil {
+		return 0, err
+	}
+	defer db.deferTxRollback(tx) //nolint:wsl
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, stmtShare, argsShare...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errNoRowsAffected()
+		}
+
+		return 0, wrapSQLiteError(err)
+	}
+
+	if db.quota.Enabled() {
+		usage, err := db.getUserUsage(ctx, tx, owner, componentName)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := db.quota.check(usage, false); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := db.commitTx(tx, componentName); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetReceivedShares returns items shared with user.
+func (db *SQLite) GetReceivedShares(ctx context.Context, username Username) ([]*pb.Share, error) {
+	componentName := "SQLite:GetReceivedShares"
+
+	stmtShares, argsShares, err := newReceivedSharesSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.getShares(ctx, stmtShares, argsShares, componentName)
+}
+
+// GetSentShares returns shares of user's items without keys and data.
+func (db *SQLite) GetSentShares(ctx context.Context, username Username) ([]*pb.Share, error) {
+	componentName := "SQLite:GetSentShares"
+
+	stmtShares, argsShares, err := newSentSharesSelect(db.psql, username)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	return db.getShares(ctx, stmtShares, argsShares, componentName)
+}
+
+// getShares is a helper function, which selects shares with provided query.
+func (db *SQLite) getShares(ctx context.Context, stmtShares SQLStatement, argsShares []interface{},
+	componentName string) ([]*pb.Share, error) {
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtShares, argsShares), componentName)
+
+	var dbShares []*Share
+	if err := sqlscan.Select(ctx, db.db, &dbShares, stmtShares, argsShares...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	shares := make([]*pb.Share, 0, len(dbShares))
+	for _, share := range dbShares {
+		shares = append(shares, share.toPB())
+	}
+
+	return shares, nil
+}
+
+// RevokeShare deletes share of owner's item.
+func (db *SQLite) RevokeShare(ctx context.Context, owner Username, shareID int64) error {
+	if owner == "" {
+		return ErrNotFound
+	}
+
+	componentName := "SQLite:RevokeShare"
+
+	stmtShare, argsShare, err := newShareDeleteStmt(db.psql, owner, shareID)
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	b := new(sqliteBatch)
+	b.Queue(stmtShare, argsShare...)
+
+	return db.runBatch(ctx, b, componentName)
+}
@@ -0,0 +1,11 @@
+package db
+
+import "testing"
+
+func TestSQLite_Shares(t *testing.T) {
+	testShares(t, newTestSQLite(t))
+}
+
+func TestSQLite_UserKeys(t *testing.T) {
+	testUserKeys(t, newTestSQLite(t))
+}
@@ -0,0 +1,327 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/georgysavva/scany/sqlscan"
+)
+
+// CreateUser creates new user.
+//
+// CreateUser generates regdate and updated fields during creation.
+// In case of error during creation returns error, returns nil error only on successfully creation.
+func (db *SQLite) CreateUser(ctx context.Context, user *pb.User) error {
+	componentName := "SQLite:CreateUser"
+
+	regdate := time.Now().Truncate(time.Second)
+
+	stmtUser, argsUser, err := db.psql.
+		Insert("users").
+		Columns("username, email, pwdhash, otpkey, ekey, public_key, private_key, updated, regdate").
+		Values(user.Username, user.Email, user.Pwdhash, user.OtpKey, user.Ekey,
+			user.PublicKey, user.PrivateKey, regdate, regdate).ToSql()
+	if err != nil {
+		return stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtUser, argsUser), componentName)
+
+	_, err = db.db.ExecContext(ctx, stmtUser, argsUser...)
+	if wrappedErr := wrapSQLiteError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	return nil
+}
+
+// GetUserByName returns user data by provided user login.
+//
+// If no users were found GetUserByName returns nil and error (ErrNotFound).
+func (db *SQLite) GetUserByName(ctx context.Context, username Username) (*pb.User, error) {
+	componentName := "SQLite:GetUserByName"
+
+	stmtUser, argsUser, err := db.psql.
+		Select("username, email, revision, pwdhash, otpkey, ekey, public_key, private_key, updated, regdate").
+		From("users").Where(sq.Eq{"username": username}).ToSql()
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s %v", stmtUser, argsUser), componentName)
+
+	user := new(User)
+	if err := sqlscan.Get(ctx, db.db, user, stmtUser, argsUser...); err != nil {
+		if sqlscan.NotFound(err) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	return user.toPB(), nil
+}
+
+// GetUserAuthData returns password hash and OTP secret key for particular user.
+//
+// If no users were found GetUserAuthData returns empty string and error (ErrNotFound).
+// In case of processing error returns empty string and original error.
+func (db *SQLite) GetUserAuthData(ctx context.Context, username Username) (Password, OTPKey, error) {
+	componentName := "SQLite:GetUserAuthData"
+	none := ""
+
+	sqlStmt := `select pwdhash, coalesce (otpkey, '') from users where username = ?`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
+
+	var pwdhash, otpkey string
+	if err := db.db.QueryRowContext(ctx, sqlStmt, username).Scan(&pwdhash, &otpkey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return none, none, stackErrors(ErrNotFound, err)
+		}
+
+		return none, none, wrapSQLiteError(err)
+	}
+
+	return pwdhash, otpkey, nil
+}
+
+// GetUserEKey returns user encryption key.
+//
+// If no users were found GetUserEKey returns empty slice and error (ErrNotFound).
+// In case of processing error returns empty string and original error.
+func (db *SQLite) GetUserEKey(ctx context.Context, username Username) ([]byte, error) {
+	componentName := "SQLite:GetUserEKey"
+
+	sqlStmt := `select ekey from users where username = ?`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
+
+	var ekey []byte
+	if err := db.db.QueryRowContext(ctx, sqlStmt, username).Scan(&ekey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	return ekey, nil
+}
+
+// GetUserKeys returns user's public key and private key, encrypted with user's encryption key.
+//
+// If user hasn't key pair GetUserKeys returns nil keys and nil error.
+// If no users were found GetUserKeys returns nil keys and error (ErrNotFound).
+func (db *SQLite) GetUserKeys(ctx context.Context, username Username) ([]byte, []byte, error) {
+	componentName := "SQLite:GetUserKeys"
+
+	sqlStmt := `select public_key, private_key from users where username = ?`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
+
+	var publicKey, privateKey []byte
+	if err := db.db.QueryRowContext(ctx, sqlStmt, username).Scan(&publicKey, &privateKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, nil, wrapSQLiteError(err)
+	}
+
+	return publicKey, privateKey, nil
+}
+
+// GetUserRevision returns configuration revision for particular user.
+//
+// If no users were found GetUserRevision returns zero revision and error (ErrNotFound).
+// In case of processing error returns zero revision and original error.
+func (db *SQLite) GetUserRevision(ctx context.Context, username Username) (int64, error) {
+	componentName := "SQLite:GetUserRevision"
+
+	sqlStmt := `select revision from users where username = ?`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
+
+	var revision int64
+	if err := db.db.QueryRowContext(ctx, sqlStmt, username).Scan(&revision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, stackErrors(ErrNotFound, err)
+		}
+
+		return 0, wrapSQLiteError(err)
+	}
+
+	return revision, nil
+}
+
+// UpdateUser updates current user information.
+//
+// UpdateUser generates updated field during update.
+// In case of error during update returns error, returns nil error only on success.
+// ID, username and regdate cannot be updated.
+func (db *SQLite) UpdateUser(ctx context.Context, user *pb.User) error {
+	componentName := "SQLite:UpdateUser"
+
+	sqlStmt := `
+		update users set
+			email = coalesce(?, email),
+			pwdhash = coalesce(?, pwdhash),
+			otpkey = coalesce(?, otpkey),
+			ekey = coalesce(?, ekey),
+			public_key = coalesce(?, public_key),
+			private_key = coalesce(?, private_key),
+			updated = coalesce(?, updated)
+		where username = ?`
+
+	updated := time.Now().Truncate(time.Second)
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
+
+	res, err := db.db.ExecContext(ctx, sqlStmt, user.Email, user.Pwdhash, user.OtpKey,
+		user.Ekey, user.PublicKey, user.PrivateKey, updated, user.Username)
+	if wrappedErr := wrapSQLiteError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n < 1 {
+		return stackErrors(ErrNotFound, errors.New(user.Username))
+	}
+
+	return nil
+}
+
+// UpdateUserSecrets updates user's password hash and encryption key.
+//
+// Both password hash and encryption key are required and updated in single statement.
+// All tokens issued to user before update are revoked.
+func (db *SQLite) UpdateUserSecrets(ctx context.Context, user *pb.User) error {
+	componentName := "SQLite:UpdateUserSecrets"
+
+	if user == nil || user.GetPwdhash() == "" || len(user.Ekey) == 0 {
+		return stackErrors(ErrConstraintViolation, errors.New("password hash and encryption key are required"))
+	}
+
+	sqlStmt := `
+		update users set
+			pwdhash = ?,
+			ekey = ?,
+			updated = ?,
+			tokens_revoked = ?
+		where username = ?`
+
+	now := time.Now()
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s", sqlStmt), componentName)
+
+	res, err := db.db.ExecContext(ctx, sqlStmt, user.Pwdhash, user.Ekey,
+		now.Truncate(time.Second), now, user.Username)
+	if wrappedErr := wrapSQLiteError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n < 1 {
+		return stackErrors(ErrNotFound, errors.New(user.Username))
+	}
+
+	return nil
+}
+
+// GetUserTokensRevoked returns time, before which all user's tokens are revoked.
+//
+// If user's tokens were never revoked GetUserTokensRevoked returns zero time.
+// If no users were found GetUserTokensRevoked returns error (ErrNotFound).
+func (db *SQLite) GetUserTokensRevoked(ctx context.Context, username Username) (time.Time, error) {
+	componentName := "SQLite:GetUserTokensRevoked"
+
+	sqlStmt := `select tokens_revoked from users where username = ?`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
+
+	var revoked sql.NullTime
+	if err := db.db.QueryRowContext(ctx, sqlStmt, username).Scan(&revoked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, stackErrors(ErrNotFound, err)
+		}
+
+		return time.Time{}, wrapSQLiteError(err)
+	}
+
+	return revoked.Time, nil
+}
+
+// GetUserUsage returns user's current storage usage, trashed items are included.
+func (db *SQLite) GetUserUsage(ctx context.Context, username Username) (*Usage, error) {
+	componentName := "SQLite:GetUserUsage"
+
+	return db.getUserUsage(ctx, db.db, username, componentName)
+}
+
+// getUserUsage is a helper function, which calculates user's usage with provided querier
+// (database or transaction).
+func (db *SQLite) getUserUsage(ctx context.Context, q sqlscan.Querier, username Username,
+	componentName string) (*Usage, error) {
+	stmtUsage, argsUsage, err := newUserUsageSelect(db.psql, username, db.itemVersions)
+	if err != nil {
+		return nil, stackErrors(ErrInternalDBError, err)
+	}
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s , args: %v", stmtUsage, argsUsage), componentName)
+
+	usage := new(Usage)
+	if err := sqlscan.Get(ctx, q, usage, stmtUsage, argsUsage...); err != nil {
+		return nil, wrapSQLiteError(err)
+	}
+
+	return usage, nil
+}
+
+// GetUserState returns user's administrator role and lock state.
+//
+// If no users were found GetUserState returns error (ErrNotFound).
+func (db *SQLite) GetUserState(ctx context.Context, username Username) (*UserState, error) {
+	componentName := "SQLite:GetUserState"
+
+	sqlStmt := `select is_admin, locked from users where username = ?`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
+
+	state := new(UserState)
+	if err := db.db.QueryRowContext(ctx, sqlStmt, username).Scan(&state.IsAdmin, &state.Locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, stackErrors(ErrNotFound, err)
+		}
+
+		return nil, wrapSQLiteError(err)
+	}
+
+	return state, nil
+}
+
+// DeleteUserByName deletes user by username. Organizations' vaults are not deleted.
+//
+// In case of error during deletion DeleteUserByName returns error,
+// returns nil error only on successfully deletion.
+func (db *SQLite) DeleteUserByName(ctx context.Context, username Username) error {
+	componentName := "SQLite:DeleteUserByName"
+
+	sqlStmt := `delete from users where username = ? and id not in (select vault_id from organizations)`
+
+	db.logger.Debug(fmt.Sprintf("run SQL: %s, %s", sqlStmt, username), componentName)
+
+	res, err := db.db.ExecContext(ctx, sqlStmt, username)
+	if wrappedErr := wrapSQLiteError(err); wrappedErr != nil {
+		return wrappedErr
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n < 1 {
+		return stackErrors(ErrNotFound, errors.New(username))
+	}
+
+	return nil
+}
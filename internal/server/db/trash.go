@@ -0,0 +1,25 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// trashPurgeInterval defines how often trashed items are checked for purge.
+const trashPurgeInterval = time.Hour
+
+// getDeletedAt is a helper function for generate deleted_at field value for trashed item.
+func getDeletedAt() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// newPurgeTrashStmt is a helper function for construct statement, which permanently deletes
+// all users' items trashed before provided time.
+func newPurgeTrashStmt(psql sq.StatementBuilderType, before time.Time) (SQLStatement, []interface{}, error) {
+	return psql.
+		Delete("items").
+		Where("deleted_at is not null").
+		Where("deleted_at < ?", before.UTC().Format(time.RFC3339)).
+		ToSql()
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWatchUnavailable is returned when changes of vaults can't be watched at the moment.
+var ErrWatchUnavailable = errors.New("vault watch is unavailable")
+
+// vaultRevisionChannel is a name of PostgreSQL notification channel, which receives
+// new vaults' revisions. Notifications are sent by trigger on users table.
+const vaultRevisionChannel = "vault_revision"
+
+// listenRetryInterval defines delay before listening of notifications is restarted after failure.
+const listenRetryInterval = 5 * time.Second
+
+// revisionWatchers keeps subscriptions to changes of users' vault revisions.
+type revisionWatchers struct {
+	mu sync.Mutex
+	// Subscribers' channels by usernames
+	subs map[Username]map[chan int64]struct{}
+	// Whether new revisions are delivered, subscription fails if not
+	available bool
+}
+
+// newRevisionWatchers creates revisionWatchers, available defines whether subscription
+// is allowed right after creation.
+func newRevisionWatchers(available bool) *revisionWatchers {
+	return &revisionWatchers{
+		subs:      make(map[Username]map[chan int64]struct{}),
+		available: available,
+	}
+}
+
+// subscribe returns channel, which receives new revisions of user's vault.
+//
+// Channel keeps only latest revision, so slow subscriber never blocks publisher.
+// Channel is closed after context is done or when revisions become unavailable.
+func (w *revisionWatchers) subscribe(ctx context.Context, username Username) (<-chan int64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.available {
+		return nil, ErrWatchUnavailable
+	}
+
+	ch := make(chan int64, 1)
+
+	if w.subs[username] == nil {
+		w.subs[username] = make(map[chan int64]struct{})
+	}
+
+	w.subs[username][ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		w.unsubscribe(username, ch)
+	}()
+
+	return ch, nil
+}
+
+// unsubscribe removes subscription and closes its channel, already removed subscription is ignored.
+func (w *revisionWatchers) unsubscribe(username Username, ch chan int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.subs[username][ch]; !ok {
+		return
+	}
+
+	delete(w.subs[username], ch)
+	close(ch)
+
+	if len(w.subs[username]) == 0 {
+		delete(w.subs, username)
+	}
+}
+
+// publish sends new revision of user's vault to all user's subscribers.
+//
+// Not yet received previous revision is replaced with new one.
+func (w *revisionWatchers) publish(username Username, revision int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs[username] {
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- revision
+	}
+}
+
+// watched returns true if user has at least one subscriber.
+func (w *revisionWatchers) watched(username Username) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.subs[username]) > 0
+}
+
+// usernames returns names of all users, which have subscribers.
+func (w *revisionWatchers) usernames() []Username {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	usernames := make([]Username, 0, len(w.subs))
+	for username := range w.subs {
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
+
+// setAvailable sets whether new revisions are delivered.
+//
+// When revisions become unavailable all subscriptions are closed, so subscribers know
+// that further changes may be missed.
+func (w *revisionWatchers) setAvailable(available bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.available = available
+
+	if available {
+		return
+	}
+
+	for username, chs := range w.subs {
+		for ch := range chs {
+			close(ch)
+		}
+
+		delete(w.subs, username)
+	}
+}
+
+// parseRevisionNotification is a helper function, which parses payload of notification about new
+// vault revision. Payload has format "<revision>:<username>".
+func parseRevisionNotification(payload string) (Username, int64, error) {
+	rawRevision, username, ok := strings.Cut(payload, ":")
+	if !ok || username == "" {
+		return "", 0, fmt.Errorf("wrong revision notification: %s", payload)
+	}
+
+	revision, err := strconv.ParseInt(rawRevision, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("wrong revision notification: %w", err)
+	}
+
+	return username, revision, nil
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisionWatchers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := newRevisionWatchers(false)
+
+	t.Run("Unavailable watchers", func(t *testing.T) {
+		_, err := w.subscribe(ctx, "user1")
+		assert.ErrorIs(t, err, ErrWatchUnavailable)
+	})
+
+	w.setAvailable(true)
+
+	ch1, err := w.subscribe(ctx, "user1")
+	require.NoError(t, err)
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	ch2, err := w.subscribe(subCtx, "user1")
+	require.NoError(t, err)
+
+	t.Run("Revision is published to user's subscribers", func(t *testing.T) {
+		assert.True(t, w.watched("user1"))
+		assert.False(t, w.watched("user2"))
+		assert.Equal(t, []Username{"user1"}, w.usernames())
+
+		w.publish("user1", 1)
+		w.publish("user2", 10)
+
+		assert.Equal(t, int64(1), <-ch1)
+		assert.Equal(t, int64(1), <-ch2)
+	})
+
+	t.Run("Only latest revision is kept", func(t *testing.T) {
+		w.publish("user1", 2)
+		w.publish("user1", 3)
+
+		assert.Equal(t, int64(3), <-ch1)
+		assert.Equal(t, int64(3), <-ch2)
+	})
+
+	t.Run("Channel is closed after context is done", func(t *testing.T) {
+		subCancel()
+
+		_, ok := <-ch2
+		assert.False(t, ok)
+		assert.True(t, w.watched("user1"))
+	})
+
+	t.Run("Channels are closed when watchers become unavailable", func(t *testing.T) {
+		w.setAvailable(false)
+
+		_, ok := <-ch1
+		assert.False(t, ok)
+		assert.False(t, w.watched("user1"))
+
+		cancel()
+		w.publish("user1", 4)
+	})
+}
+
+func TestParseRevisionNotification(t *testing.T) {
+	tests := []struct {
+		name         string
+		payload      string
+		wantUsername Username
+		wantRevision int64
+		wantErr      bool
+	}{
+		{name: "Valid payload", payload: "15:user", wantUsername: "user", wantRevision: 15},
+		{name: "Username with colon", payload: "1:us:er", wantUsername: "us:er", wantRevision: 1},
+		{name: "Missed username", payload: "15:", wantErr: true},
+		{name: "Missed separator", payload: "15", wantErr: true},
+		{name: "Wrong revision", payload: "abc:user", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			username, revision, err := parseRevisionNotification(tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUsername, username)
+			assert.Equal(t, tt.wantRevision, revision)
+		})
+	}
+}
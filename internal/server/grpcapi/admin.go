@@ -0,0 +1,157 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+)
+
+// AdminService implements all GRPC-method for users' administration and stores service options.
+// Used for registering with GRPC-server.
+//
+// Service's methods must be guarded by IsAdmin interceptor.
+type AdminService struct {
+	pb.UnimplementedAdminServer
+	db     db.DB
+	logger logger.L
+}
+
+// NewAdminService a constructor for AdminService.
+func NewAdminService(db db.DB, l logger.L) *AdminService {
+	return &AdminService{
+		db:     db,
+		logger: l,
+	}
+}
+
+// ListUsers returns information of all users without organizations' vaults.
+func (s *AdminService) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	componentName := "AdminService:ListUsers"
+	resp := new(pb.ListUsersResponse)
+
+	var err error
+	if resp.Users, err = s.db.ListUsers(ctx); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// LockUser locks user. Locked user can't log in, all user's tokens are revoked.
+//
+// Administrator can't lock own account.
+func (s *AdminService) LockUser(ctx context.Context, req *pb.LockUserRequest) (*pb.LockUserResponse, error) {
+	componentName := "AdminService:LockUser"
+	resp := new(pb.LockUserResponse)
+
+	if req.Username == "" {
+		return nil, ErrMissedUserInfo
+	}
+
+	if userPerformSelfOperation(ctx, req.Username) {
+		return nil, ErrAdminSelfOperation
+	}
+
+	if err := s.db.SetUserLocked(ctx, req.Username, true); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	s.logAdminAction(ctx, fmt.Sprintf("locked user '%s'", req.Username), componentName)
+	resp.Info = fmt.Sprintf("successfully lock user '%s'", req.Username)
+
+	return resp, nil
+}
+
+// UnlockUser unlocks user.
+func (s *AdminService) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error) {
+	componentName := "AdminService:UnlockUser"
+	resp := new(pb.UnlockUserResponse)
+
+	if req.Username == "" {
+		return nil, ErrMissedUserInfo
+	}
+
+	if err := s.db.SetUserLocked(ctx, req.Username, false); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	s.logAdminAction(ctx, fmt.Sprintf("unlocked user '%s'", req.Username), componentName)
+	resp.Info = fmt.Sprintf("successfully unlock user '%s'", req.Username)
+
+	return resp, nil
+}
+
+// ResetTwoFactor disables user's two-factor authentication, ex. when user lost verification codes'
+// generator.
+func (s *AdminService) ResetTwoFactor(ctx context.Context,
+	req *pb.ResetTwoFactorRequest) (*pb.ResetTwoFactorResponse, error) {
+	componentName := "AdminService:ResetTwoFactor"
+	resp := new(pb.ResetTwoFactorResponse)
+
+	if req.Username == "" {
+		return nil, ErrMissedUserInfo
+	}
+
+	if err := s.db.ResetTwoFactor(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	s.logAdminAction(ctx, fmt.Sprintf("reset two-factor authentication of user '%s'", req.Username), componentName)
+	resp.Info = fmt.Sprintf("successfully reset two-factor authentication of user '%s'", req.Username)
+
+	return resp, nil
+}
+
+// DeleteUser deletes user with all user's items.
+//
+// Administrator can't delete own account, Users service's DeleteUser should be used instead.
+func (s *AdminService) DeleteUser(ctx context.Context,
+	req *pb.AdminDeleteUserRequest) (*pb.AdminDeleteUserResponse, error) {
+	componentName := "AdminService:DeleteUser"
+	resp := new(pb.AdminDeleteUserResponse)
+
+	if req.Username == "" {
+		return nil, ErrMissedUserInfo
+	}
+
+	if userPerformSelfOperation(ctx, req.Username) {
+		return nil, ErrAdminSelfOperation
+	}
+
+	if err := s.db.DeleteUserByName(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	s.logAdminAction(ctx, fmt.Sprintf("deleted user '%s'", req.Username), componentName)
+	resp.Info = fmt.Sprintf("successfully delete user '%s'", req.Username)
+
+	return resp, nil
+}
+
+// GetServerStats returns numbers of users, organizations and stored items.
+func (s *AdminService) GetServerStats(ctx context.Context,
+	req *pb.GetServerStatsRequest) (*pb.GetServerStatsResponse, error) {
+	componentName := "AdminService:GetServerStats"
+	resp := new(pb.GetServerStatsResponse)
+
+	var err error
+	if resp.Stats, err = s.db.GetServerStats(ctx); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// logAdminAction is a helper function which logs administrator's action with administrator's name.
+func (s *AdminService) logAdminAction(ctx context.Context, action string, componentName string) {
+	s.logger.Info(fmt.Sprintf("administrator '%s' %s", payloadFromContext(ctx).Username, action), componentName)
+}
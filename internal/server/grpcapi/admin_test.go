@@ -0,0 +1,169 @@
+package grpcapi
+
+import (
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/mocks/mockdb"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewAdminService(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	assert.NotEmpty(t, NewAdminService(mockdb.NewMockDB(mockCtrl), mocklogger.NewMockLogger()))
+	mockCtrl.Finish()
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().ListUsers(mockAny).Return(nil, assert.AnError)
+		_, err := ts.AdminClient.ListUsers(testCtx, &pb.ListUsersRequest{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully list users", func(t *testing.T) {
+		users := []*pb.UserInfo{{Username: "user1", IsAdmin: true}, {Username: "user2", Locked: true}}
+		ts.DB.EXPECT().ListUsers(mockAny).Return(users, nil)
+		resp, err := ts.AdminClient.ListUsers(testCtx, &pb.ListUsersRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 2)
+		assert.True(t, resp.Users[1].Locked)
+	})
+}
+
+func TestAdminService_LockUser(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "Admin")
+
+	t.Run("Missed username", func(t *testing.T) {
+		_, err := ts.AdminClient.LockUser(authCtx, &pb.LockUserRequest{})
+		assert.ErrorIs(t, err, ErrMissedUserInfo)
+	})
+
+	t.Run("Lock own account", func(t *testing.T) {
+		_, err := ts.AdminClient.LockUser(authCtx, &pb.LockUserRequest{Username: "Admin"})
+		assert.ErrorIs(t, err, ErrAdminSelfOperation)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		ts.DB.EXPECT().SetUserLocked(mockAny, "unknown", true).Return(db.ErrNotFound)
+		_, err := ts.AdminClient.LockUser(authCtx, &pb.LockUserRequest{Username: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Successfully lock and unlock user", func(t *testing.T) {
+		ts.DB.EXPECT().SetUserLocked(mockAny, "user1", true).Return(nil)
+		_, err := ts.AdminClient.LockUser(authCtx, &pb.LockUserRequest{Username: "user1"})
+		require.NoError(t, err)
+
+		ts.DB.EXPECT().SetUserLocked(mockAny, "user1", false).Return(nil)
+		_, err = ts.AdminClient.UnlockUser(authCtx, &pb.UnlockUserRequest{Username: "user1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Unlock error", func(t *testing.T) {
+		_, err := ts.AdminClient.UnlockUser(authCtx, &pb.UnlockUserRequest{})
+		assert.ErrorIs(t, err, ErrMissedUserInfo)
+
+		ts.DB.EXPECT().SetUserLocked(mockAny, "user1", false).Return(assert.AnError)
+		_, err = ts.AdminClient.UnlockUser(authCtx, &pb.UnlockUserRequest{Username: "user1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestAdminService_ResetTwoFactor(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	t.Run("Missed username", func(t *testing.T) {
+		_, err := ts.AdminClient.ResetTwoFactor(testCtx, &pb.ResetTwoFactorRequest{})
+		assert.ErrorIs(t, err, ErrMissedUserInfo)
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().ResetTwoFactor(mockAny, "user1").Return(assert.AnError)
+		_, err := ts.AdminClient.ResetTwoFactor(testCtx, &pb.ResetTwoFactorRequest{Username: "user1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully reset", func(t *testing.T) {
+		ts.DB.EXPECT().ResetTwoFactor(mockAny, "user1").Return(nil)
+		_, err := ts.AdminClient.ResetTwoFactor(testCtx, &pb.ResetTwoFactorRequest{Username: "user1"})
+		require.NoError(t, err)
+	})
+}
+
+func TestAdminService_DeleteUser(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "Admin")
+
+	t.Run("Missed username", func(t *testing.T) {
+		_, err := ts.AdminClient.DeleteUser(authCtx, &pb.AdminDeleteUserRequest{})
+		assert.ErrorIs(t, err, ErrMissedUserInfo)
+	})
+
+	t.Run("Delete own account", func(t *testing.T) {
+		_, err := ts.AdminClient.DeleteUser(authCtx, &pb.AdminDeleteUserRequest{Username: "Admin"})
+		assert.ErrorIs(t, err, ErrAdminSelfOperation)
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().DeleteUserByName(mockAny, "user1").Return(assert.AnError)
+		_, err := ts.AdminClient.DeleteUser(authCtx, &pb.AdminDeleteUserRequest{Username: "user1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully delete", func(t *testing.T) {
+		ts.DB.EXPECT().DeleteUserByName(mockAny, "user1").Return(nil)
+		_, err := ts.AdminClient.DeleteUser(authCtx, &pb.AdminDeleteUserRequest{Username: "user1"})
+		require.NoError(t, err)
+	})
+}
+
+func TestAdminService_GetServerStats(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetServerStats(mockAny).Return(nil, assert.AnError)
+		_, err := ts.AdminClient.GetServerStats(testCtx, &pb.GetServerStatsRequest{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get statistics", func(t *testing.T) {
+		ts.DB.EXPECT().GetServerStats(mockAny).Return(&pb.ServerStats{Users: 3, Items: 10}, nil)
+		resp, err := ts.AdminClient.GetServerStats(testCtx, &pb.GetServerStatsRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Stats.Users)
+		assert.Equal(t, int64(10), resp.Stats.Items)
+	})
+}
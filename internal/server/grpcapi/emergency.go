@@ -0,0 +1,306 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+)
+
+// EmergencyAccessesService implements all GRPC-method for handling emergency access requests and
+// stores service options. Used for registering with GRPC-server.
+type EmergencyAccessesService struct {
+	pb.UnimplementedEmergencyAccessesServer
+	db     db.DB
+	logger logger.L
+}
+
+// NewEmergencyAccessesService a constructor for EmergencyAccessesService.
+func NewEmergencyAccessesService(db db.DB, l logger.L) *EmergencyAccessesService {
+	return &EmergencyAccessesService{
+		db:     db,
+		logger: l,
+	}
+}
+
+// InviteEmergencyContact invites trusted contact, which can request access to user's vault.
+//
+// Request must contain user's encryption key, encrypted with contact's public key. Key is kept
+// on server and is released to contact only by GetEmergencyVault after access is approved.
+func (s *EmergencyAccessesService) InviteEmergencyContact(ctx context.Context,
+	req *pb.InviteEmergencyContactRequest) (*pb.InviteEmergencyContactResponse, error) {
+
+	componentName := "EmergencyAccessesService:InviteEmergencyContact"
+	resp := new(pb.InviteEmergencyContactResponse)
+
+	if req.Access == nil || req.Access.Grantee == "" || len(req.Access.Ekey) == 0 {
+		return nil, ErrMissedEmergencyInfo
+	}
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+	if resp.Id, err = s.db.CreateEmergencyAccess(ctx, req.Username, req.Access); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully invite '%s' as trusted contact", req.Access.Grantee)
+
+	return resp, nil
+}
+
+// GetEmergencyAccesses returns emergency accesses granted by user and granted to user.
+func (s *EmergencyAccessesService) GetEmergencyAccesses(ctx context.Context,
+	req *pb.GetEmergencyAccessesRequest) (*pb.GetEmergencyAccessesResponse, error) {
+
+	componentName := "EmergencyAccessesService:GetEmergencyAccesses"
+	resp := new(pb.GetEmergencyAccessesResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+	if resp.Granted, err = s.db.GetGrantedEmergencyAccesses(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if resp.Received, err = s.db.GetReceivedEmergencyAccesses(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// AcceptEmergencyInvite accepts invite by trusted contact.
+func (s *EmergencyAccessesService) AcceptEmergencyInvite(ctx context.Context,
+	req *pb.AcceptEmergencyInviteRequest) (*pb.AcceptEmergencyInviteResponse, error) {
+
+	componentName := "EmergencyAccessesService:AcceptEmergencyInvite"
+	resp := new(pb.AcceptEmergencyInviteResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.AcceptEmergencyInvite(ctx, req.Username, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully accept emergency access %d", req.Id)
+
+	return resp, nil
+}
+
+// RequestEmergencyAccess requests access to grantor's vault by trusted contact.
+//
+// Access is approved automatically after access's waiting period, if grantor doesn't reject it.
+func (s *EmergencyAccessesService) RequestEmergencyAccess(ctx context.Context,
+	req *pb.RequestEmergencyAccessRequest) (*pb.RequestEmergencyAccessResponse, error) {
+
+	componentName := "EmergencyAccessesService:RequestEmergencyAccess"
+	resp := new(pb.RequestEmergencyAccessResponse)
+
+	access, err := checkEmergencyContact(ctx, s.db, s.logger, componentName, req.Username, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	approveAfter := time.Now().Add(time.Duration(access.WaitDays) * 24 * time.Hour)
+
+	if err := s.db.RequestEmergencyAccess(ctx, req.Username, req.Id, approveAfter); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("access will be granted after %s, if '%s' doesn't reject it",
+		approveAfter.Format(time.RFC1123), access.Grantor)
+
+	return resp, nil
+}
+
+// ApproveEmergencyAccess approves requested access by grantor before waiting period is over.
+func (s *EmergencyAccessesService) ApproveEmergencyAccess(ctx context.Context,
+	req *pb.ApproveEmergencyAccessRequest) (*pb.ApproveEmergencyAccessResponse, error) {
+
+	componentName := "EmergencyAccessesService:ApproveEmergencyAccess"
+	resp := new(pb.ApproveEmergencyAccessResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.ApproveEmergencyAccess(ctx, req.Username, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully approve emergency access %d", req.Id)
+
+	return resp, nil
+}
+
+// RejectEmergencyAccess rejects requested or approved access by grantor.
+func (s *EmergencyAccessesService) RejectEmergencyAccess(ctx context.Context,
+	req *pb.RejectEmergencyAccessRequest) (*pb.RejectEmergencyAccessResponse, error) {
+
+	componentName := "EmergencyAccessesService:RejectEmergencyAccess"
+	resp := new(pb.RejectEmergencyAccessResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.RejectEmergencyAccess(ctx, req.Username, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully reject emergency access %d", req.Id)
+
+	return resp, nil
+}
+
+// RevokeEmergencyAccess deletes emergency access by grantor or trusted contact.
+func (s *EmergencyAccessesService) RevokeEmergencyAccess(ctx context.Context,
+	req *pb.RevokeEmergencyAccessRequest) (*pb.RevokeEmergencyAccessResponse, error) {
+
+	componentName := "EmergencyAccessesService:RevokeEmergencyAccess"
+	resp := new(pb.RevokeEmergencyAccessResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.DeleteEmergencyAccess(ctx, req.Username, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully revoke emergency access %d", req.Id)
+
+	return resp, nil
+}
+
+// GetEmergencyVault returns grantor's items (without trashed ones) and grantor's encryption key
+// to trusted contact with approved access.
+func (s *EmergencyAccessesService) GetEmergencyVault(ctx context.Context,
+	req *pb.GetEmergencyVaultRequest) (*pb.GetEmergencyVaultResponse, error) {
+
+	componentName := "EmergencyAccessesService:GetEmergencyVault"
+	resp := new(pb.GetEmergencyVaultResponse)
+
+	access, err := checkEmergencyContact(ctx, s.db, s.logger, componentName, req.Username, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Status != common.EmergencyStatusApproved || len(access.Ekey) == 0 {
+		return nil, ErrEmergencyNotApproved
+	}
+
+	items, err := s.db.GetAllItems(ctx, access.Grantor)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	trash, err := s.db.GetTrashList(ctx, access.Grantor)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	trashed := make(map[int64]struct{}, len(trash))
+	for _, item := range trash {
+		trashed[item.Id] = struct{}{}
+	}
+
+	for _, item := range items {
+		if _, ok := trashed[item.Id]; !ok {
+			resp.Items = append(resp.Items, item)
+		}
+	}
+
+	resp.Ekey = access.Ekey
+
+	return resp, nil
+}
+
+// TakeoverAccount sets new password and encryption key of grantor by trusted contact with approved
+// takeover access.
+//
+// Grantor's two-factor authentication is disabled, all grantor's tokens are revoked and emergency
+// access is deleted.
+func (s *EmergencyAccessesService) TakeoverAccount(ctx context.Context,
+	req *pb.TakeoverAccountRequest) (*pb.TakeoverAccountResponse, error) {
+
+	componentName := "EmergencyAccessesService:TakeoverAccount"
+	resp := new(pb.TakeoverAccountResponse)
+
+	if req.NewPwdhash == "" || len(req.Ekey) == 0 {
+		return nil, ErrMissedUserSecrets
+	}
+
+	access, err := checkEmergencyContact(ctx, s.db, s.logger, componentName, req.Username, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Status != common.EmergencyStatusApproved {
+		return nil, ErrEmergencyNotApproved
+	}
+
+	if access.Mode != common.EmergencyModeTakeover {
+		return nil, ErrEmergencyViewOnly
+	}
+
+	user := &pb.User{Pwdhash: &req.NewPwdhash, Ekey: req.Ekey}
+	if err := s.db.TakeoverAccount(ctx, req.Username, req.Id, user); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully take over account '%s'", access.Grantor)
+
+	return resp, nil
+}
+
+// checkEmergencyContact is a helper function which checks that user is trusted contact of
+// emergency access.
+//
+// Returns emergency access or error, which is ready for sending to client.
+func checkEmergencyContact(ctx context.Context, accesses db.EmergencyAccessManager, l logger.L,
+	componentName string, username string, id int64) (*pb.EmergencyAccess, error) {
+	if !userPerformSelfOperation(ctx, username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	access, err := accesses.GetEmergencyAccess(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrNotEmergencyContact
+		}
+
+		l.Warn(err, "db error", componentName)
+
+		return nil, wrapErrorToClient(err)
+	}
+
+	if access.Grantee != username {
+		return nil, ErrNotEmergencyContact
+	}
+
+	return access, nil
+}
@@ -0,0 +1,291 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mockdb"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNewEmergencyAccessesService(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	assert.NotEmpty(t, NewEmergencyAccessesService(mockdb.NewMockDB(mockCtrl), mocklogger.NewMockLogger()))
+	mockCtrl.Finish()
+}
+
+func TestEmergencyAccessesService_InviteEmergencyContact(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	access := &pb.EmergencyAccess{Grantee: "Contact", Mode: common.EmergencyModeView, WaitDays: 2, Ekey: []byte("k")}
+
+	t.Run("Missed grantor's key", func(t *testing.T) {
+		req := &pb.InviteEmergencyContactRequest{Username: "CorrectUser",
+			Access: &pb.EmergencyAccess{Grantee: "Contact"}}
+		_, err := ts.EmergClient.InviteEmergencyContact(authCtx, req)
+		assert.ErrorIs(t, err, ErrMissedEmergencyInfo)
+	})
+
+	t.Run("Another user", func(t *testing.T) {
+		req := &pb.InviteEmergencyContactRequest{Username: "AnotherUser", Access: access}
+		_, err := ts.EmergClient.InviteEmergencyContact(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().CreateEmergencyAccess(mockAny, "CorrectUser", mockAny).Return(int64(0), assert.AnError)
+		req := &pb.InviteEmergencyContactRequest{Username: "CorrectUser", Access: access}
+		_, err := ts.EmergClient.InviteEmergencyContact(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully invite", func(t *testing.T) {
+		ts.DB.EXPECT().CreateEmergencyAccess(mockAny, "CorrectUser", mockAny).Return(int64(4), nil)
+		req := &pb.InviteEmergencyContactRequest{Username: "CorrectUser", Access: access}
+		resp, err := ts.EmergClient.InviteEmergencyContact(authCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), resp.Id)
+	})
+}
+
+func TestEmergencyAccessesService_GetEmergencyAccesses(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	req := &pb.GetEmergencyAccessesRequest{Username: "CorrectUser"}
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetGrantedEmergencyAccesses(mockAny, "CorrectUser").Return(nil, nil)
+		ts.DB.EXPECT().GetReceivedEmergencyAccesses(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		_, err := ts.EmergClient.GetEmergencyAccesses(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get", func(t *testing.T) {
+		ts.DB.EXPECT().GetGrantedEmergencyAccesses(mockAny, "CorrectUser").
+			Return([]*pb.EmergencyAccess{{Id: 1, Grantee: "Contact"}}, nil)
+		ts.DB.EXPECT().GetReceivedEmergencyAccesses(mockAny, "CorrectUser").
+			Return([]*pb.EmergencyAccess{{Id: 2, Grantor: "Friend"}}, nil)
+		resp, err := ts.EmergClient.GetEmergencyAccesses(authCtx, req)
+		require.NoError(t, err)
+		require.Len(t, resp.Granted, 1)
+		require.Len(t, resp.Received, 1)
+		assert.Equal(t, "Friend", resp.Received[0].Grantor)
+	})
+}
+
+func TestEmergencyAccessesService_ChangeStatus(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Another user", func(t *testing.T) {
+		_, err := ts.EmergClient.AcceptEmergencyInvite(authCtx,
+			&pb.AcceptEmergencyInviteRequest{Username: "AnotherUser", Id: 1})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+
+		_, err = ts.EmergClient.ApproveEmergencyAccess(authCtx,
+			&pb.ApproveEmergencyAccessRequest{Username: "AnotherUser", Id: 1})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().RejectEmergencyAccess(mockAny, "CorrectUser", int64(1)).Return(db.ErrOperationFailed)
+		_, err := ts.EmergClient.RejectEmergencyAccess(authCtx,
+			&pb.RejectEmergencyAccessRequest{Username: "CorrectUser", Id: 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully change", func(t *testing.T) {
+		ts.DB.EXPECT().AcceptEmergencyInvite(mockAny, "CorrectUser", int64(1)).Return(nil)
+		ts.DB.EXPECT().ApproveEmergencyAccess(mockAny, "CorrectUser", int64(2)).Return(nil)
+		ts.DB.EXPECT().RejectEmergencyAccess(mockAny, "CorrectUser", int64(3)).Return(nil)
+		ts.DB.EXPECT().DeleteEmergencyAccess(mockAny, "CorrectUser", int64(4)).Return(nil)
+
+		_, err := ts.EmergClient.AcceptEmergencyInvite(authCtx,
+			&pb.AcceptEmergencyInviteRequest{Username: "CorrectUser", Id: 1})
+		require.NoError(t, err)
+
+		_, err = ts.EmergClient.ApproveEmergencyAccess(authCtx,
+			&pb.ApproveEmergencyAccessRequest{Username: "CorrectUser", Id: 2})
+		require.NoError(t, err)
+
+		_, err = ts.EmergClient.RejectEmergencyAccess(authCtx,
+			&pb.RejectEmergencyAccessRequest{Username: "CorrectUser", Id: 3})
+		require.NoError(t, err)
+
+		_, err = ts.EmergClient.RevokeEmergencyAccess(authCtx,
+			&pb.RevokeEmergencyAccessRequest{Username: "CorrectUser", Id: 4})
+		require.NoError(t, err)
+	})
+}
+
+func TestEmergencyAccessesService_RequestEmergencyAccess(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	req := &pb.RequestEmergencyAccessRequest{Username: "CorrectUser", Id: 1}
+
+	t.Run("Access not found", func(t *testing.T) {
+		ts.DB.EXPECT().GetEmergencyAccess(mockAny, int64(1)).Return(nil, db.ErrNotFound)
+		_, err := ts.EmergClient.RequestEmergencyAccess(authCtx, req)
+		assert.ErrorIs(t, err, ErrNotEmergencyContact)
+	})
+
+	t.Run("Not a grantee", func(t *testing.T) {
+		ts.DB.EXPECT().GetEmergencyAccess(mockAny, int64(1)).
+			Return(&pb.EmergencyAccess{Grantor: "CorrectUser", Grantee: "Contact"}, nil)
+		_, err := ts.EmergClient.RequestEmergencyAccess(authCtx, req)
+		assert.ErrorIs(t, err, ErrNotEmergencyContact)
+	})
+
+	t.Run("Successfully request", func(t *testing.T) {
+		ts.DB.EXPECT().GetEmergencyAccess(mockAny, int64(1)).
+			Return(&pb.EmergencyAccess{Grantor: "Friend", Grantee: "CorrectUser", WaitDays: 2}, nil)
+		ts.DB.EXPECT().RequestEmergencyAccess(mockAny, "CorrectUser", int64(1), mockAny).DoAndReturn(
+			func(_ context.Context, _ string, _ int64, approveAfter time.Time) error {
+				assert.WithinDuration(t, time.Now().Add(48*time.Hour), approveAfter, time.Minute)
+				return nil
+			})
+		_, err := ts.EmergClient.RequestEmergencyAccess(authCtx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestEmergencyAccessesService_GetEmergencyVault(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	req := &pb.GetEmergencyVaultRequest{Username: "CorrectUser", Id: 1}
+
+	t.Run("Access isn't approved", func(t *testing.T) {
+		ts.DB.EXPECT().GetEmergencyAccess(mockAny, int64(1)).Return(&pb.EmergencyAccess{Grantor: "Friend",
+			Grantee: "CorrectUser", Status: common.EmergencyStatusRequested}, nil)
+		_, err := ts.EmergClient.GetEmergencyVault(authCtx, req)
+		assert.ErrorIs(t, err, ErrEmergencyNotApproved)
+	})
+
+	t.Run("Successfully get vault", func(t *testing.T) {
+		ts.DB.EXPECT().GetEmergencyAccess(mockAny, int64(1)).Return(&pb.EmergencyAccess{Grantor: "Friend",
+			Grantee: "CorrectUser", Status: common.EmergencyStatusApproved, Ekey: []byte("wrapped")}, nil)
+		ts.DB.EXPECT().GetAllItems(mockAny, "Friend").Return([]*pb.Item{{Id: 1}, {Id: 2}}, nil)
+		ts.DB.EXPECT().GetTrashList(mockAny, "Friend").Return([]*pb.ItemShort{{Id: 2}}, nil)
+		resp, err := ts.EmergClient.GetEmergencyVault(authCtx, req)
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, int64(1), resp.Items[0].Id)
+		assert.Equal(t, []byte("wrapped"), resp.Ekey)
+	})
+}
+
+func TestEmergencyAccessesService_GetEmergencyVault_KeyRelease(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := db.New(db.TypeMemory, db.NewParameters("", "", "", 1024), mocklogger.NewMockLogger())
+	require.NoError(t, err)
+	require.NoError(t, storage.ConnectAndSetup(ctx))
+
+	for _, username := range []string{"Grantor", "Contact"} {
+		require.NoError(t, storage.CreateUser(ctx, &pb.User{Username: username,
+			Pwdhash: common.PtrTo("pwdhash"), Ekey: []byte("ekey")}))
+	}
+
+	s := NewEmergencyAccessesService(storage, mocklogger.NewMockLogger())
+	grantorCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(authUsernameKey, "Grantor"))
+	contactCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(authUsernameKey, "Contact"))
+
+	invite, err := s.InviteEmergencyContact(grantorCtx, &pb.InviteEmergencyContactRequest{Username: "Grantor",
+		Access: &pb.EmergencyAccess{Grantee: "Contact", Mode: common.EmergencyModeView, WaitDays: 1,
+			Ekey: []byte("wrapped")}})
+	require.NoError(t, err)
+
+	getVault := func() (*pb.GetEmergencyVaultResponse, error) {
+		return s.GetEmergencyVault(contactCtx, &pb.GetEmergencyVaultRequest{Username: "Contact", Id: invite.Id})
+	}
+
+	_, err = getVault()
+	assert.ErrorIs(t, err, ErrEmergencyNotApproved, "invited")
+
+	_, err = s.AcceptEmergencyInvite(contactCtx, &pb.AcceptEmergencyInviteRequest{Username: "Contact", Id: invite.Id})
+	require.NoError(t, err)
+
+	_, err = s.RequestEmergencyAccess(contactCtx, &pb.RequestEmergencyAccessRequest{Username: "Contact",
+		Id: invite.Id})
+	require.NoError(t, err)
+
+	_, err = getVault()
+	assert.ErrorIs(t, err, ErrEmergencyNotApproved, "requested")
+
+	_, err = s.ApproveEmergencyAccess(grantorCtx, &pb.ApproveEmergencyAccessRequest{Username: "Grantor",
+		Id: invite.Id})
+	require.NoError(t, err)
+
+	resp, err := getVault()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("wrapped"), resp.Ekey)
+}
+
+func TestEmergencyAccessesService_TakeoverAccount(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	req := &pb.TakeoverAccountRequest{Username: "CorrectUser", Id: 1, NewPwdhash: "hash", Ekey: []byte("ekey")}
+
+	t.Run("Missed secrets", func(t *testing.T) {
+		_, err := ts.EmergClient.TakeoverAccount(authCtx, &pb.TakeoverAccountRequest{Username: "CorrectUser", Id: 1})
+		assert.ErrorIs(t, err, ErrMissedUserSecrets)
+	})
+
+	t.Run("View-only access", func(t *testing.T) {
+		ts.DB.EXPECT().GetEmergencyAccess(mockAny, int64(1)).Return(&pb.EmergencyAccess{Grantor: "Friend",
+			Grantee: "CorrectUser", Status: common.EmergencyStatusApproved, Mode: common.EmergencyModeView}, nil)
+		_, err := ts.EmergClient.TakeoverAccount(authCtx, req)
+		assert.ErrorIs(t, err, ErrEmergencyViewOnly)
+	})
+
+	t.Run("Successfully take over", func(t *testing.T) {
+		ts.DB.EXPECT().GetEmergencyAccess(mockAny, int64(1)).Return(&pb.EmergencyAccess{Grantor: "Friend",
+			Grantee: "CorrectUser", Status: common.EmergencyStatusApproved, Mode: common.EmergencyModeTakeover}, nil)
+		ts.DB.EXPECT().TakeoverAccount(mockAny, "CorrectUser", int64(1), mockAny).DoAndReturn(
+			func(_ context.Context, _ string, _ int64, user *pb.User) error {
+				assert.Equal(t, "hash", user.GetPwdhash())
+				assert.Equal(t, []byte("ekey"), user.Ekey)
+				return nil
+			})
+		_, err := ts.EmergClient.TakeoverAccount(authCtx, req)
+		require.NoError(t, err)
+	})
+}
@@ -2,15 +2,38 @@ package grpcapi
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/server/db"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var (
 	ErrMissedUserInfo        = status.Error(codes.InvalidArgument, "missed user information")
+	ErrReservedUsername      = status.Error(codes.InvalidArgument, "user name is reserved for organizations")
 	ErrWrongVerificationCode = status.Error(codes.PermissionDenied, "wrong verification code")
+	ErrUserLocked            = status.Error(codes.PermissionDenied, "user is locked")
+	ErrAdminRequired         = status.Error(codes.PermissionDenied, "administrator role required")
+	ErrAdminSelfOperation    = status.Error(codes.FailedPrecondition, "administrator can't lock or delete own account")
+	ErrMissedUserSecrets     = status.Error(codes.InvalidArgument, "missed new password hash or encryption key")
+	ErrMissedEncryptionKey   = status.Error(codes.InvalidArgument, "missed encryption key")
+	ErrMissedSessionInfo     = status.Error(codes.InvalidArgument, "missed session information")
+	ErrMissedRefreshToken    = status.Error(codes.InvalidArgument, "missed refresh token")
+	ErrInvalidRefreshToken   = status.Error(codes.PermissionDenied, "invalid refresh token")
+	ErrMissedItemInfo        = status.Error(codes.InvalidArgument, "missed item information")
+	ErrMissedShareInfo       = status.Error(codes.InvalidArgument, "missed share information")
+	ErrMissedOrgInfo         = status.Error(codes.InvalidArgument, "missed organization information")
+	ErrMissedMemberInfo      = status.Error(codes.InvalidArgument, "missed member information")
+	ErrNotOrgMember          = status.Error(codes.PermissionDenied, "not a member of organization")
+	ErrLastOrgAdmin          = status.Error(codes.FailedPrecondition, "organization must have an administrator")
+	ErrMissedEmergencyInfo   = status.Error(codes.InvalidArgument, "missed emergency access information")
+	ErrNotEmergencyContact   = status.Error(codes.NotFound, "emergency access not found")
+	ErrEmergencyNotApproved  = status.Error(codes.FailedPrecondition, "emergency access is not approved")
+	ErrEmergencyViewOnly     = status.Error(codes.FailedPrecondition, "emergency access allows only view of vault")
 )
 
 // permissionDeniedErr is helper function for return error with status code PermissionDenied and
@@ -19,6 +42,43 @@ func permissionDeniedErr(message string) error {
 	return status.Errorf(codes.PermissionDenied, message)
 }
 
+// loginThrottledErr is helper function for return error with status code ResourceExhausted for
+// locked login. Remaining lockout period, rounded up to seconds, is attached as RetryInfo detail.
+func loginThrottledErr(retryAfter time.Duration) error {
+	retryAfter = roundUpToSeconds(retryAfter)
+
+	return retryErr(fmt.Sprintf("too many failed login attempts, retry after %s", retryAfter), retryAfter)
+}
+
+// rateLimitedErr is helper function for return error with status code ResourceExhausted for
+// request rejected by rate limit. Period, after which request is allowed, is attached as
+// RetryInfo detail.
+func rateLimitedErr(retryAfter time.Duration) error {
+	return retryErr(fmt.Sprintf("rate limit exceeded, retry after %s", retryAfter), retryAfter)
+}
+
+// retryErr is helper function for return error with status code ResourceExhausted and
+// RetryInfo detail.
+func retryErr(message string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, message)
+
+	stDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return st.Err()
+	}
+
+	return stDetails.Err()
+}
+
+// roundUpToSeconds is a helper function, which rounds period up to whole seconds.
+func roundUpToSeconds(d time.Duration) time.Duration {
+	if rounded := d.Truncate(time.Second); rounded < d {
+		return rounded + time.Second
+	}
+
+	return d
+}
+
 // wrapErrorToClient wraps known type of Database' errors for sending to client.
 //
 // If wrapErrorToClient receives already wrapped error, it extracts original error message and
@@ -31,10 +91,18 @@ func wrapErrorToClient(err error) error {
 		message = unwrappedErr.Error()
 	}
 
+	if errors.Is(err, db.ErrQuotaExceeded) || errors.Is(err, db.ErrSecretTooBig) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
 	if errors.Is(err, db.ErrNotFound) {
 		return status.Error(codes.NotFound, message)
 	}
 
+	if errors.Is(err, db.ErrWatchUnavailable) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
 	if errors.Is(err, db.ErrDuplicateEntry) {
 		return status.Error(codes.InvalidArgument, message)
 	}
@@ -60,4 +60,16 @@ func Test_wrapErrorToClient(t *testing.T) {
 			assert.ErrorIs(t, err, want)
 		})
 	}
+
+	t.Run("Database ErrQuotaExceeded with details", func(t *testing.T) {
+		err := fmt.Errorf("%w: maximum number of items is 10", db.ErrQuotaExceeded)
+		want := status.Error(codes.ResourceExhausted, "quota exceeded: maximum number of items is 10")
+		assert.ErrorIs(t, wrapErrorToClient(err), want)
+	})
+
+	t.Run("Database ErrSecretTooBig with details", func(t *testing.T) {
+		err := fmt.Errorf("%w: maximum size is 10 bytes", db.ErrSecretTooBig)
+		want := status.Error(codes.ResourceExhausted, "secret data is too big: maximum size is 10 bytes")
+		assert.ErrorIs(t, wrapErrorToClient(err), want)
+	})
 }
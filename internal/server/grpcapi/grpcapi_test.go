@@ -28,11 +28,17 @@ type TestSuiteGRPCServer struct {
 	Authorizer  *mockauth.MockA
 	UsersClient pb.UsersClient
 	ItemsClient pb.ItemsClient
+	OrgsClient  pb.OrganizationsClient
+	EmergClient pb.EmergencyAccessesClient
+	AdminClient pb.AdminClient
 }
 
 type TestGRCPServices struct {
 	usersService *UsersService
 	itemsService *ItemsService
+	orgsService  *OrganizationsService
+	emergService *EmergencyAccessesService
+	adminService *AdminService
 }
 
 func NewTestSuiteGRPCServer(t gomock.TestReporter, opt ...grpc.ServerOption) (ts *TestSuiteGRPCServer, err error) {
@@ -47,6 +53,9 @@ func NewTestSuiteGRPCServer(t gomock.TestReporter, opt ...grpc.ServerOption) (ts
 	services := &TestGRCPServices{
 		usersService: NewUsersService(ts.DB, logger, ts.Authorizer),
 		itemsService: NewItemsService(ts.DB, logger),
+		orgsService:  NewOrganizationsService(ts.DB, logger),
+		emergService: NewEmergencyAccessesService(ts.DB, logger),
+		adminService: NewAdminService(ts.DB, logger),
 	}
 
 	ts.Conn, err = createTestGRPCBufConn(context.Background(), services, opt...)
@@ -56,6 +65,9 @@ func NewTestSuiteGRPCServer(t gomock.TestReporter, opt ...grpc.ServerOption) (ts
 
 	ts.UsersClient = pb.NewUsersClient(ts.Conn)
 	ts.ItemsClient = pb.NewItemsClient(ts.Conn)
+	ts.OrgsClient = pb.NewOrganizationsClient(ts.Conn)
+	ts.EmergClient = pb.NewEmergencyAccessesClient(ts.Conn)
+	ts.AdminClient = pb.NewAdminClient(ts.Conn)
 
 	return
 }
@@ -74,6 +86,9 @@ func testGRPCdialer(s *TestGRCPServices, opt ...grpc.ServerOption) func(context.
 
 	pb.RegisterUsersServer(server, s.usersService)
 	pb.RegisterItemsServer(server, s.itemsService)
+	pb.RegisterOrganizationsServer(server, s.orgsService)
+	pb.RegisterEmergencyAccessesServer(server, s.emergService)
+	pb.RegisterAdminServer(server, s.adminService)
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
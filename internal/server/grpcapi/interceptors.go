@@ -2,12 +2,22 @@ package grpcapi
 
 import (
 	"context"
+	"errors"
+	"net"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/artfuldog/gophkeeper/internal/server/authorizer"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -17,45 +27,513 @@ import (
 var unAuthMethods = []string{
 	"CreateUser",
 	"UserLogin",
+	"RefreshToken",
 }
 
+// List of audited methods (without package's name) and their audit events.
+//
+// UserLogin is audited separately, see loginAuditEvent.
+//
+//nolint:gochecknoglobals
+var auditedMethods = map[string]string{
+	"Users/CreateUser":                    common.AuditEventCreate,
+	"Users/UpdateUser":                    common.AuditEventUpdate,
+	"Users/ChangePassword":                common.AuditEventUpdate,
+	"Users/DeleteUser":                    common.AuditEventDelete,
+	"Items/CreateItem":                    common.AuditEventCreate,
+	"Items/GetItem":                       common.AuditEventRead,
+	"Items/GetItems":                      common.AuditEventRead,
+	"Items/GetAllItems":                   common.AuditEventRead,
+	"Items/ListItemVersions":              common.AuditEventRead,
+	"Items/UpdateItem":                    common.AuditEventUpdate,
+	"Items/RestoreItem":                   common.AuditEventUpdate,
+	"Items/RestoreItemVersion":            common.AuditEventUpdate,
+	"Items/DeleteItem":                    common.AuditEventDelete,
+	"Items/PurgeItem":                     common.AuditEventDelete,
+	"Items/UploadSecretData":              common.AuditEventUpdate,
+	"Items/DownloadSecretData":            common.AuditEventRead,
+	"Items/CreateFolder":                  common.AuditEventCreate,
+	"Items/UpdateFolder":                  common.AuditEventUpdate,
+	"Items/DeleteFolder":                  common.AuditEventDelete,
+	"Items/ShareItem":                     common.AuditEventCreate,
+	"Items/RevokeShare":                   common.AuditEventDelete,
+	"Organizations/CreateOrganization":    common.AuditEventCreate,
+	"Organizations/UpdateOrganization":    common.AuditEventUpdate,
+	"Organizations/DeleteOrganization":    common.AuditEventDelete,
+	"Organizations/InviteMember":          common.AuditEventCreate,
+	"Organizations/AcceptInvite":          common.AuditEventUpdate,
+	"Organizations/UpdateMember":          common.AuditEventUpdate,
+	"Organizations/RemoveMember":          common.AuditEventDelete,
+	"EmergencyAccesses/GetEmergencyVault": common.AuditEventRead,
+	"Admin/ResetTwoFactor":                common.AuditEventTwoFactorReset,
+}
+
+// auditLoginMethod is a name of audited login method.
+const auditLoginMethod = "Users/UserLogin"
+
 // Metadata fields.
 const (
 	authMetadataKey = "authorization"
 	authUsernameKey = "username"
 )
 
+// payloadCtxKey is a context's key for verified token's payload.
+type payloadCtxKey struct{}
+
+// auditCtxKey is a context's key for audit event, which is recorded after handler's completion.
+type auditCtxKey struct{}
+
 // isAuthorized is gRPC interceptor for user authentication and authorization.
-func IsAuthorized(auth authorizer.A) grpc.UnaryServerInterceptor {
+//
+// Tokens issued before user's tokens revocation time (ex. before password change) and tokens,
+// which sessions are revoked, are rejected. Last seen time of token's session is updated.
+// Verified token's payload is passed to handler in context.
+func IsAuthorized(auth authorizer.A, users db.UsersManager) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler) (interface{}, error) {
 
-		method := common.Last(strings.Split(info.FullMethod, "/"))
-		if common.Contains(method, unAuthMethods) {
-			return handler(ctx, req)
+		ctx, err := authorize(ctx, info.FullMethod, auth, users)
+		if err != nil {
+			return nil, err
 		}
 
-		var username, token string
+		return handler(ctx, req)
+	}
+}
+
+// IsAuthorizedStream is gRPC stream interceptor for user authentication and authorization.
+//
+// Performs same checks as IsAuthorized once before stream is handled. Verified token's payload
+// is passed to handler in stream's context. Headers are sent right after authorization, so client
+// learns that stream's token is accepted before it streams any data.
+func IsAuthorizedStream(auth authorizer.A, users db.UsersManager) grpc.StreamServerInterceptor {
+	return func(srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
 
-		username, ok := mdValueFromContext(ctx, authUsernameKey)
-		if !ok {
-			return nil, status.Error(codes.PermissionDenied, "cannot retrieve user name")
+		ctx, err := authorize(ss.Context(), info.FullMethod, auth, users)
+		if err != nil {
+			return err
 		}
 
-		token, ok = mdValueFromContext(ctx, authMetadataKey)
-		if !ok {
-			return nil, status.Error(codes.PermissionDenied, "cannot retrieve token")
+		if err := ss.SendHeader(nil); err != nil {
+			return err
 		}
 
-		fields := authorizer.AuthFields{
-			Username: username,
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextServerStream is a server stream with replaced context.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns stream's replaced context.
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// RateLimit is gRPC interceptor, which limits rate of requests with limiter's token buckets.
+//
+// Authorized requests are limited per user, so RateLimit must be chained after IsAuthorized.
+// Other requests are limited per client's address. Limit, remaining requests and seconds until
+// limit's reset of the most restrictive bucket are sent in trailers. Rejected request gets
+// ResourceExhausted status with RetryInfo detail and "retry-after" trailer.
+func RateLimit(limiter *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		trailer, err := checkRateLimit(ctx, limiter, info.FullMethod)
+		if trailer != nil {
+			_ = grpc.SetTrailer(ctx, trailer)
 		}
-		if err := auth.VerifyToken(token, fields); err != nil {
-			return nil, status.Error(codes.PermissionDenied, err.Error())
+
+		if err != nil {
+			return nil, err
 		}
 
 		return handler(ctx, req)
 	}
 }
+
+// RateLimitStream is gRPC stream interceptor, which limits rate of streams with limiter's token buckets.
+//
+// Stream takes one token, when it is opened. Limits and trailers are the same as in RateLimit,
+// so RateLimitStream must be chained after IsAuthorizedStream.
+func RateLimitStream(limiter *RateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+
+		trailer, err := checkRateLimit(ss.Context(), limiter, info.FullMethod)
+		if trailer != nil {
+			ss.SetTrailer(trailer)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// IsAdmin is gRPC interceptor, which allows Admin service's methods only for users with
+// administrator role.
+//
+// Role is taken from token's payload, so IsAdmin must be chained after IsAuthorized.
+func IsAdmin() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		if strings.HasPrefix(info.FullMethod, "/"+pb.Admin_ServiceDesc.ServiceName+"/") &&
+			!payloadFromContext(ctx).IsAdmin {
+			return nil, ErrAdminRequired
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Audit is gRPC interceptor, which records users' audit events of audited methods.
+//
+// Events are recorded only for successfully handled requests, except login and verification code
+// check, which failures are recorded too. Event's user is taken from token's payload (or from
+// request for methods without authorization), so Audit must be chained after IsAuthorized.
+// Failure of recording is logged and doesn't affect response.
+func Audit(events db.AuditManager, l logger.L) grpc.UnaryServerInterceptor {
+	componentName := "Audit"
+
+	return func(ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		method := common.Last(strings.Split(info.FullMethod, "."))
+
+		eventType, audited := auditedMethods[method]
+		if !audited && method != auditLoginMethod {
+			return handler(ctx, req)
+		}
+
+		event := &pb.AuditEvent{
+			Username: auditUsername(ctx, req),
+			Event:    eventType,
+			Method:   method,
+			ClientIp: clientIPFromContext(ctx),
+		}
+
+		resp, err := handler(context.WithValue(ctx, auditCtxKey{}, event), req)
+
+		switch {
+		case method == auditLoginMethod:
+			event.Event = loginAuditEvent(resp, err)
+		case errors.Is(err, ErrWrongVerificationCode):
+			event.Event = common.AuditEventTwoFactorFailed
+		case err != nil:
+			event.Event = ""
+		}
+
+		if event.Event == "" || event.Username == "" {
+			return resp, err
+		}
+
+		if dbErr := events.CreateAuditEvents(ctx, auditEventsForItems(event, req, resp)); dbErr != nil {
+			l.Error(dbErr, "failed to record audit event", componentName)
+		}
+
+		return resp, err
+	}
+}
+
+// AuditStream is gRPC stream interceptor, which records users' audit events of audited streams.
+//
+// Events are recorded only for successfully completed streams. Event's user and item are taken
+// from the first request of stream, so AuditStream must be chained after IsAuthorizedStream.
+// Failure of recording is logged and doesn't affect response.
+func AuditStream(events db.AuditManager, l logger.L) grpc.StreamServerInterceptor {
+	componentName := "AuditStream"
+
+	return func(srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+
+		method := common.Last(strings.Split(info.FullMethod, "."))
+
+		eventType, audited := auditedMethods[method]
+		if !audited {
+			return handler(srv, ss)
+		}
+
+		stream := &auditServerStream{ServerStream: ss}
+
+		if err := handler(srv, stream); err != nil {
+			return err
+		}
+
+		ctx := ss.Context()
+		event := &pb.AuditEvent{
+			Username: auditUsername(ctx, stream.firstReq),
+			Event:    eventType,
+			Method:   method,
+			ClientIp: clientIPFromContext(ctx),
+		}
+
+		if event.Username == "" {
+			return nil
+		}
+
+		if dbErr := events.CreateAuditEvents(ctx, auditEventsForItems(event, stream.firstReq, nil)); dbErr != nil {
+			l.Error(dbErr, "failed to record audit event", componentName)
+		}
+
+		return nil
+	}
+}
+
+// auditServerStream is a server's stream, which keeps the first received request for audit.
+type auditServerStream struct {
+	grpc.ServerStream
+	firstReq interface{}
+}
+
+// RecvMsg receives message from client's stream and keeps the first one.
+func (s *auditServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.firstReq == nil {
+		s.firstReq = m
+	}
+
+	return err
+}
+
+// auditEventFromContext returns audit event of current request.
+//
+// If request isn't audited nil is returned. Handler may complete event (ex. with created item's ID).
+func auditEventFromContext(ctx context.Context) *pb.AuditEvent {
+	if event, ok := ctx.Value(auditCtxKey{}).(*pb.AuditEvent); ok {
+		return event
+	}
+
+	return nil
+}
+
+// loginAuditEvent returns audit event of login by handler's result.
+//
+// Request for verification code isn't recorded, empty event is returned.
+func loginAuditEvent(resp interface{}, err error) string {
+	if err != nil {
+		if errors.Is(err, ErrWrongVerificationCode) {
+			return common.AuditEventTwoFactorFailed
+		}
+
+		return common.AuditEventLoginFailed
+	}
+
+	if loginResp, ok := resp.(*pb.UserLoginResponse); ok && loginResp.SecondFactor {
+		return ""
+	}
+
+	return common.AuditEventLogin
+}
+
+// auditUsername returns name of user, who performs request.
+//
+// Username is taken from token's payload, for methods without authorization - from request.
+func auditUsername(ctx context.Context, req interface{}) string {
+	if username := payloadFromContext(ctx).Username; username != "" {
+		return username
+	}
+
+	if r, ok := req.(interface{ GetUsername() string }); ok {
+		return r.GetUsername()
+	}
+
+	return ""
+}
+
+// rateLimitClient returns client of rate limiter: user from token's payload for authorized
+// requests or client's address for others.
+func rateLimitClient(ctx context.Context) string {
+	if username := payloadFromContext(ctx).Username; username != "" {
+		return "user:" + username
+	}
+
+	return "ip:" + clientIPFromContext(ctx)
+}
+
+// checkRateLimit is a helper function, which takes token for request from limiter's buckets.
+//
+// Returns trailer with state of the most restrictive bucket (nil, if request isn't limited)
+// and ResourceExhausted status, if request is rejected.
+func checkRateLimit(ctx context.Context, limiter *RateLimiter, fullMethod string) (metadata.MD, error) {
+	method := common.Last(strings.Split(fullMethod, "."))
+
+	result := limiter.allow(rateLimitClient(ctx), method)
+	if result == nil {
+		return nil, nil
+	}
+
+	trailer := metadata.Pairs(
+		rateLimitLimitKey, strconv.FormatUint(uint64(result.limit), 10),
+		rateLimitRemainingKey, strconv.FormatUint(uint64(result.remaining), 10),
+		rateLimitResetKey, secondsString(result.reset),
+	)
+
+	if !result.allowed {
+		retryAfter := roundUpToSeconds(result.retryAfter)
+		trailer.Set(rateLimitRetryKey, secondsString(retryAfter))
+
+		return trailer, rateLimitedErr(retryAfter)
+	}
+
+	return trailer, nil
+}
+
+// secondsString returns period in whole seconds rounded up.
+func secondsString(d time.Duration) string {
+	return strconv.FormatInt(int64(roundUpToSeconds(d)/time.Second), 10)
+}
+
+// clientIPFromContext returns IP address of client from gRPC peer.
+//
+// If peer's address doesn't contain port, address is returned as is.
+func clientIPFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// auditEventsForItems completes audit event with IDs of items from request or response.
+//
+// Request of several items produces event for every item.
+func auditEventsForItems(event *pb.AuditEvent, req, resp interface{}) []*pb.AuditEvent {
+	var itemIDs []int64
+
+	switch r := req.(type) {
+	case *pb.GetItemsRequest:
+		itemIDs = r.Ids
+	case *pb.UpdateItemRequest:
+		itemIDs = []int64{r.GetItem().GetId()}
+	case *pb.DeleteItemRequest:
+		itemIDs = []int64{r.Id}
+	case *pb.RestoreItemRequest:
+		itemIDs = []int64{r.Id}
+	case *pb.PurgeItemRequest:
+		itemIDs = []int64{r.Id}
+	case *pb.ListItemVersionsRequest:
+		itemIDs = []int64{r.ItemId}
+	case *pb.RestoreItemVersionRequest:
+		itemIDs = []int64{r.ItemId}
+	case *pb.UploadSecretDataRequest:
+		itemIDs = []int64{r.ItemId}
+	case *pb.DownloadSecretDataRequest:
+		itemIDs = []int64{r.ItemId}
+	case *pb.ShareItemRequest:
+		itemIDs = []int64{r.GetShare().GetItemId()}
+	}
+
+	if r, ok := resp.(*pb.GetItemResponse); ok && r.GetItem() != nil {
+		itemIDs = []int64{r.Item.Id}
+	}
+
+	if len(itemIDs) == 0 {
+		return []*pb.AuditEvent{event}
+	}
+
+	events := make([]*pb.AuditEvent, 0, len(itemIDs))
+
+	for _, id := range itemIDs {
+		itemID := id
+		events = append(events, &pb.AuditEvent{
+			Username: event.Username,
+			Event:    event.Event,
+			Method:   event.Method,
+			ItemId:   &itemID,
+			ClientIp: event.ClientIp,
+		})
+	}
+
+	return events
+}
+
+// payloadFromContext returns verified token's payload from context.
+//
+// If context doesn't contain payload (ex. for methods without authorization) empty payload is returned.
+func payloadFromContext(ctx context.Context) *authorizer.Payload {
+	if payload, ok := ctx.Value(payloadCtxKey{}).(*authorizer.Payload); ok && payload != nil {
+		return payload
+	}
+
+	return new(authorizer.Payload)
+}
+
+// authorize is a helper function which checks username and token from request's metadata.
+//
+// Returns context with verified token's payload.
+func authorize(ctx context.Context, fullMethod string, auth authorizer.A,
+	users db.UsersManager) (context.Context, error) {
+	method := common.Last(strings.Split(fullMethod, "/"))
+	if common.Contains(method, unAuthMethods) {
+		return ctx, nil
+	}
+
+	var username, token string
+
+	username, ok := mdValueFromContext(ctx, authUsernameKey)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "cannot retrieve user name")
+	}
+
+	token, ok = mdValueFromContext(ctx, authMetadataKey)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "cannot retrieve token")
+	}
+
+	revoked, err := users.GetUserTokensRevoked(ctx, username)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, status.Error(codes.PermissionDenied, "unknown user")
+		}
+
+		return nil, wrapErrorToClient(err)
+	}
+
+	fields := authorizer.AuthFields{
+		Username:      username,
+		TokensRevoked: revoked,
+		CheckSession: func(id uuid.UUID) error {
+			err := users.TouchSession(ctx, username, id.String(), clientIPFromContext(ctx))
+			if errors.Is(err, db.ErrNotFound) {
+				return authorizer.ErrRevokedToken
+			}
+
+			return err
+		},
+	}
+	payload, err := auth.VerifyToken(token, fields)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return context.WithValue(ctx, payloadCtxKey{}, payload), nil
+}
@@ -1,22 +1,34 @@
 package grpcapi
 
 import (
+	"context"
+	"io"
 	"testing"
+	"time"
 
+	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/mocks/mockauth"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mockdb"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/authorizer"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestIsAuthorized(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
-	authorizer := mockauth.NewMockA(mockCtrl)
+	auth := mockauth.NewMockA(mockCtrl)
+	users := mockdb.NewMockDB(mockCtrl)
 
-	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.UnaryInterceptor(IsAuthorized(authorizer)))
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.UnaryInterceptor(IsAuthorized(auth, users)))
 	if tsErr != nil {
 		t.Errorf("failed to init test suite: %v", tsErr)
 	}
@@ -36,33 +48,468 @@ func TestIsAuthorized(t *testing.T) {
 	})
 
 	t.Run("Missed context", func(t *testing.T) {
-		req := &pb.DeleteItemRequest{}
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
 		_, err := ts.ItemsClient.DeleteItem(testCtx, req)
 		assert.Error(t, err)
 	})
 
 	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
 	t.Run("Missed authorization field", func(t *testing.T) {
-		req := &pb.DeleteItemRequest{}
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
 		_, err := ts.ItemsClient.DeleteItem(authCtx, req)
 		assert.Error(t, err)
 	})
 
 	authCtx = metadata.AppendToOutgoingContext(authCtx, authMetadataKey, "token")
 
-	t.Run("Missed authorization field", func(t *testing.T) {
-		authorizer.EXPECT().VerifyToken(mockAny, mockAny).Return(assert.AnError)
-		req := &pb.DeleteItemRequest{}
+	t.Run("Unknown user", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, db.ErrNotFound)
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.DeleteItem(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, assert.AnError)
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
 		_, err := ts.ItemsClient.DeleteItem(authCtx, req)
 		assert.Error(t, err)
 	})
 
+	t.Run("Wrong token", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(nil, assert.AnError)
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.DeleteItem(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	sessionID := uuid.New()
+	verifyWithSession := func(revoked time.Time) func(string, authorizer.AuthFields) (*authorizer.Payload, error) {
+		return func(_ string, fields authorizer.AuthFields) (*authorizer.Payload, error) {
+			assert.Equal(t, "CorrectUser", fields.Username)
+			assert.Equal(t, revoked, fields.TokensRevoked)
+			require.NotNil(t, fields.CheckSession)
+
+			if err := fields.CheckSession(sessionID); err != nil {
+				return nil, err
+			}
+
+			return &authorizer.Payload{ID: sessionID, Username: "CorrectUser"}, nil
+		}
+	}
+
+	t.Run("Revoked session", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).DoAndReturn(verifyWithSession(time.Time{}))
+		users.EXPECT().TouchSession(mockAny, "CorrectUser", sessionID.String(), mockAny).Return(db.ErrNotFound)
+		_, err := ts.ItemsClient.DeleteItem(authCtx, &pb.DeleteItemRequest{Username: "CorrectUser"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, err.Error(), authorizer.ErrRevokedToken.Error())
+	})
+
 	t.Run("Successfully authorized", func(t *testing.T) {
-		authorizer.EXPECT().VerifyToken(mockAny, mockAny).Return(nil)
+		revoked := time.Now().Add(-time.Hour)
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(revoked, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).DoAndReturn(verifyWithSession(revoked))
+		users.EXPECT().TouchSession(mockAny, "CorrectUser", sessionID.String(), mockAny).Return(nil)
 		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(nil)
-		req := &pb.DeleteItemRequest{}
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
 		resp, err := ts.ItemsClient.DeleteItem(authCtx, req)
 		require.NoError(t, err)
 		assert.NotEmpty(t, resp)
 	})
 }
+
+func TestIsAuthorizedStream(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	auth := mockauth.NewMockA(mockCtrl)
+	users := mockdb.NewMockDB(mockCtrl)
+
+	var payload *authorizer.Payload
+	payloadCheck := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		payload = payloadFromContext(ss.Context())
+
+		return handler(srv, ss)
+	}
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.ChainStreamInterceptor(IsAuthorizedStream(auth, users), payloadCheck))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	download := func(ctx context.Context) error {
+		stream, err := ts.ItemsClient.DownloadSecretData(ctx,
+			&pb.DownloadSecretDataRequest{Username: "CorrectUser", ItemId: 1})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		return err
+	}
+
+	t.Run("Missed context", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, status.Code(download(testCtx)))
+	})
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser",
+		authMetadataKey, "token")
+
+	t.Run("Wrong token", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(nil, assert.AnError)
+		assert.Equal(t, codes.PermissionDenied, status.Code(download(authCtx)))
+	})
+
+	t.Run("Successfully authorized", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{Username: "CorrectUser"}, nil)
+		ts.DB.EXPECT().GetSecretData(mockAny, mockAny, mockAny, mockAny).Return(nil)
+		assert.ErrorIs(t, download(authCtx), io.EOF)
+		assert.Equal(t, "CorrectUser", payload.Username)
+	})
+}
+
+func TestIsAdmin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	auth := mockauth.NewMockA(mockCtrl)
+	users := mockdb.NewMockDB(mockCtrl)
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.ChainUnaryInterceptor(IsAuthorized(auth, users), IsAdmin()))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser",
+		authMetadataKey, "token")
+
+	t.Run("User without administrator role", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{Username: "CorrectUser"}, nil)
+		_, err := ts.AdminClient.ListUsers(authCtx, &pb.ListUsersRequest{})
+		assert.ErrorIs(t, err, ErrAdminRequired)
+	})
+
+	t.Run("Other services are allowed", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{Username: "CorrectUser"}, nil)
+		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(nil)
+		_, err := ts.ItemsClient.DeleteItem(authCtx, &pb.DeleteItemRequest{Username: "CorrectUser"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Administrator", func(t *testing.T) {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{Username: "CorrectUser",
+			IsAdmin: true}, nil)
+		ts.DB.EXPECT().ListUsers(mockAny).Return(nil, nil)
+		_, err := ts.AdminClient.ListUsers(authCtx, &pb.ListUsersRequest{})
+		require.NoError(t, err)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	auth := mockauth.NewMockA(mockCtrl)
+	users := mockdb.NewMockDB(mockCtrl)
+	events := mockdb.NewMockDB(mockCtrl)
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.ChainUnaryInterceptor(IsAuthorized(auth, users),
+		Audit(events, mocklogger.NewMockLogger())))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser",
+		authMetadataKey, "token")
+
+	expectAuth := func() {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{Username: "CorrectUser"}, nil)
+	}
+
+	var recorded []*pb.AuditEvent
+	record := func(_ context.Context, e []*pb.AuditEvent) error {
+		recorded = e
+		return nil
+	}
+
+	t.Run("Failed login", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserAuthData(mockAny, "someuser").Return("", "", db.ErrNotFound)
+		events.EXPECT().CreateAuditEvents(mockAny, mockAny).DoAndReturn(record)
+
+		_, err := ts.UsersClient.UserLogin(testCtx, &pb.UserLoginRequest{Username: "someuser"})
+		require.Error(t, err)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, "someuser", recorded[0].Username)
+		assert.Equal(t, common.AuditEventLoginFailed, recorded[0].Event)
+		assert.Equal(t, "Users/UserLogin", recorded[0].Method)
+		assert.NotEmpty(t, recorded[0].ClientIp)
+		assert.Nil(t, recorded[0].ItemId)
+	})
+
+	t.Run("Item's deletion", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, int64(5)).Return(nil)
+		events.EXPECT().CreateAuditEvents(mockAny, mockAny).DoAndReturn(record)
+
+		_, err := ts.ItemsClient.DeleteItem(authCtx, &pb.DeleteItemRequest{Username: "CorrectUser", Id: 5})
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, "CorrectUser", recorded[0].Username)
+		assert.Equal(t, common.AuditEventDelete, recorded[0].Event)
+		assert.Equal(t, int64(5), recorded[0].GetItemId())
+	})
+
+	t.Run("Several items' read", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().GetItemsByID(mockAny, mockAny, mockAny).Return(nil, nil)
+		events.EXPECT().CreateAuditEvents(mockAny, mockAny).DoAndReturn(record)
+
+		_, err := ts.ItemsClient.GetItems(authCtx, &pb.GetItemsRequest{Username: "CorrectUser", Ids: []int64{1, 2}})
+		require.NoError(t, err)
+		require.Len(t, recorded, 2)
+		assert.Equal(t, int64(1), recorded[0].GetItemId())
+		assert.Equal(t, int64(2), recorded[1].GetItemId())
+		assert.Equal(t, common.AuditEventRead, recorded[1].Event)
+	})
+
+	t.Run("Item's creation", func(t *testing.T) {
+		expectAuth()
+		item := &pb.Item{Name: "item", Type: common.ItemTypeLogin}
+		ts.DB.EXPECT().CreateItem(mockAny, mockAny, mockAny).Return(nil)
+		ts.DB.EXPECT().GetItemList(mockAny, "CorrectUser").Return([]*pb.ItemShort{
+			{Id: 3, Name: "item", Type: common.ItemTypeSecNote},
+			{Id: 4, Name: "item", Type: common.ItemTypeLogin},
+		}, nil)
+		events.EXPECT().CreateAuditEvents(mockAny, mockAny).DoAndReturn(record)
+
+		_, err := ts.ItemsClient.CreateItem(authCtx, &pb.CreateItemRequest{Username: "CorrectUser", Item: item})
+		require.NoError(t, err)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, common.AuditEventCreate, recorded[0].Event)
+		assert.Equal(t, int64(4), recorded[0].GetItemId())
+	})
+
+	t.Run("Failed request isn't recorded", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(db.ErrNotFound)
+
+		_, err := ts.ItemsClient.DeleteItem(authCtx, &pb.DeleteItemRequest{Username: "CorrectUser", Id: 5})
+		require.Error(t, err)
+	})
+
+	t.Run("Not audited method", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().GetItemList(mockAny, mockAny).Return(nil, nil)
+
+		_, err := ts.ItemsClient.GetItemList(authCtx, &pb.GetItemListRequest{Username: "CorrectUser"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Recording error", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(nil)
+		events.EXPECT().CreateAuditEvents(mockAny, mockAny).Return(db.ErrInternalDBError)
+
+		_, err := ts.ItemsClient.DeleteItem(authCtx, &pb.DeleteItemRequest{Username: "CorrectUser", Id: 5})
+		require.NoError(t, err)
+	})
+}
+
+func TestAuditStream(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	auth := mockauth.NewMockA(mockCtrl)
+	users := mockdb.NewMockDB(mockCtrl)
+	events := mockdb.NewMockDB(mockCtrl)
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.ChainStreamInterceptor(IsAuthorizedStream(auth, users),
+		AuditStream(events, mocklogger.NewMockLogger())))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser",
+		authMetadataKey, "token")
+
+	expectAuth := func() {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{Username: "CorrectUser"}, nil)
+	}
+
+	var recorded []*pb.AuditEvent
+	record := func(_ context.Context, e []*pb.AuditEvent) error {
+		recorded = e
+		return nil
+	}
+
+	download := func() error {
+		stream, err := ts.ItemsClient.DownloadSecretData(authCtx,
+			&pb.DownloadSecretDataRequest{Username: "CorrectUser", ItemId: 3})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		return err
+	}
+
+	t.Run("Data's upload", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().SaveSecretData(mockAny, mockAny, int64(7), mockAny).DoAndReturn(
+			func(_ context.Context, _ db.Username, _ int64, next db.ChunkReader) (int64, error) {
+				for {
+					if _, err := next(); err != nil {
+						return 0, nil
+					}
+				}
+			})
+		events.EXPECT().CreateAuditEvents(mockAny, mockAny).DoAndReturn(record)
+
+		stream, err := ts.ItemsClient.UploadSecretData(authCtx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.UploadSecretDataRequest{Username: "CorrectUser", ItemId: 7,
+			Chunk: []byte("chunk")}))
+		require.NoError(t, stream.Send(&pb.UploadSecretDataRequest{Chunk: []byte("chunk")}))
+		_, err = stream.CloseAndRecv()
+		require.NoError(t, err)
+
+		require.Len(t, recorded, 1)
+		assert.Equal(t, "CorrectUser", recorded[0].Username)
+		assert.Equal(t, common.AuditEventUpdate, recorded[0].Event)
+		assert.Equal(t, "Items/UploadSecretData", recorded[0].Method)
+		assert.Equal(t, int64(7), recorded[0].GetItemId())
+		assert.NotEmpty(t, recorded[0].ClientIp)
+	})
+
+	t.Run("Data's download", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().GetSecretData(mockAny, mockAny, int64(3), mockAny).Return(nil)
+		events.EXPECT().CreateAuditEvents(mockAny, mockAny).DoAndReturn(record)
+
+		assert.ErrorIs(t, download(), io.EOF)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, common.AuditEventRead, recorded[0].Event)
+		assert.Equal(t, "Items/DownloadSecretData", recorded[0].Method)
+		assert.Equal(t, int64(3), recorded[0].GetItemId())
+	})
+
+	t.Run("Failed stream isn't recorded", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().GetSecretData(mockAny, mockAny, mockAny, mockAny).Return(db.ErrNotFound)
+
+		assert.Equal(t, codes.NotFound, status.Code(download()))
+	})
+}
+
+func TestLoginAuditEvent(t *testing.T) {
+	assert.Equal(t, common.AuditEventLogin, loginAuditEvent(&pb.UserLoginResponse{}, nil))
+	assert.Empty(t, loginAuditEvent(&pb.UserLoginResponse{SecondFactor: true}, nil))
+	assert.Equal(t, common.AuditEventTwoFactorFailed, loginAuditEvent(nil, ErrWrongVerificationCode))
+	assert.Equal(t, common.AuditEventLoginFailed, loginAuditEvent(nil, ErrUserLocked))
+}
+
+func TestRateLimit(t *testing.T) {
+	limiter := NewRateLimiter(Rate{}, map[string]Rate{"Items/DeleteItem": {PerSecond: 0.5, Burst: 2}})
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.ChainUnaryInterceptor(RateLimit(limiter)))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	userCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Allowed requests", func(t *testing.T) {
+		for _, remaining := range []string{"1", "0"} {
+			var trailer metadata.MD
+
+			ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(nil)
+			_, err := ts.ItemsClient.DeleteItem(userCtx, &pb.DeleteItemRequest{Username: "CorrectUser"}, grpc.Trailer(&trailer))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"2"}, trailer.Get(rateLimitLimitKey))
+			assert.Equal(t, []string{remaining}, trailer.Get(rateLimitRemainingKey))
+			assert.Empty(t, trailer.Get(rateLimitRetryKey))
+		}
+	})
+
+	t.Run("Rejected request", func(t *testing.T) {
+		var trailer metadata.MD
+
+		_, err := ts.ItemsClient.DeleteItem(userCtx, &pb.DeleteItemRequest{Username: "CorrectUser"}, grpc.Trailer(&trailer))
+		require.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, []string{"2"}, trailer.Get(rateLimitRetryKey))
+		assert.Equal(t, []string{"4"}, trailer.Get(rateLimitResetKey))
+	})
+
+	t.Run("Not limited method", func(t *testing.T) {
+		var trailer metadata.MD
+
+		ts.DB.EXPECT().PurgeItem(mockAny, mockAny, mockAny).Return(nil)
+		_, err := ts.ItemsClient.PurgeItem(userCtx, &pb.PurgeItemRequest{Username: "CorrectUser"}, grpc.Trailer(&trailer))
+		require.NoError(t, err)
+		assert.Empty(t, trailer.Get(rateLimitLimitKey))
+	})
+
+	t.Run("Bucket is refilled", func(t *testing.T) {
+		now = now.Add(2 * time.Second)
+
+		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(nil)
+		_, err := ts.ItemsClient.DeleteItem(userCtx, &pb.DeleteItemRequest{Username: "CorrectUser"})
+		require.NoError(t, err)
+	})
+}
+
+func TestRateLimitStream(t *testing.T) {
+	limiter := NewRateLimiter(Rate{}, map[string]Rate{"Items/DownloadSecretData": {PerSecond: 0.5, Burst: 1}})
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.ChainStreamInterceptor(RateLimitStream(limiter)))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	userCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	download := func() (metadata.MD, error) {
+		stream, err := ts.ItemsClient.DownloadSecretData(userCtx,
+			&pb.DownloadSecretDataRequest{Username: "CorrectUser", ItemId: 1})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		return stream.Trailer(), err
+	}
+
+	t.Run("Allowed stream", func(t *testing.T) {
+		ts.DB.EXPECT().GetSecretData(mockAny, mockAny, mockAny, mockAny).Return(nil)
+		trailer, err := download()
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, []string{"1"}, trailer.Get(rateLimitLimitKey))
+		assert.Equal(t, []string{"0"}, trailer.Get(rateLimitRemainingKey))
+		assert.Empty(t, trailer.Get(rateLimitRetryKey))
+	})
+
+	t.Run("Rejected stream", func(t *testing.T) {
+		trailer, err := download()
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, []string{"2"}, trailer.Get(rateLimitRetryKey))
+	})
+
+	t.Run("Bucket is refilled", func(t *testing.T) {
+		now = now.Add(2 * time.Second)
+
+		ts.DB.EXPECT().GetSecretData(mockAny, mockAny, mockAny, mockAny).Return(nil)
+		_, err := download()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
@@ -2,7 +2,9 @@ package grpcapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/logger"
@@ -31,11 +33,20 @@ func (s *ItemsService) CreateItem(ctx context.Context, req *pb.CreateItemRequest
 	componentName := "ItemsService:CreateItem"
 	resp := new(pb.CreateItemResponse)
 
-	if err := s.db.CreateItem(ctx, req.Username, req.Item); err != nil {
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.CreateItem(ctx, owner, req.Item); err != nil {
 		s.logger.Warn(err, "db error", componentName)
 		return nil, wrapErrorToClient(err)
 	}
 
+	if event := auditEventFromContext(ctx); event != nil {
+		s.setAuditItemID(ctx, event, owner, req.Item)
+	}
+
 	resp.Info = fmt.Sprintf("successfully create item type %s '%s'",
 		common.ItemTypeText(req.Item.Type), req.Item.Name)
 
@@ -47,9 +58,12 @@ func (s *ItemsService) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb
 	componentName := "ItemsService:GetItem"
 	resp := new(pb.GetItemResponse)
 
-	var err error
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, false)
+	if err != nil {
+		return nil, err
+	}
 
-	resp.Item, err = s.db.GetItemByNameAndType(ctx, req.Username, req.ItemName, req.ItemType)
+	resp.Item, err = s.db.GetItemByNameAndType(ctx, owner, req.ItemName, req.ItemType)
 	if err != nil {
 		s.logger.Warn(err, "db error", componentName)
 		return nil, wrapErrorToClient(err)
@@ -63,9 +77,12 @@ func (s *ItemsService) GetItemList(ctx context.Context, req *pb.GetItemListReque
 	componentName := "ItemsService:GetItemList"
 	resp := new(pb.GetItemListResponse)
 
-	var err error
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, false)
+	if err != nil {
+		return nil, err
+	}
 
-	resp.Items, err = s.db.GetItemList(ctx, req.Username)
+	resp.Items, err = s.db.GetItemList(ctx, owner)
 	if err != nil {
 		s.logger.Warn(err, "db error", componentName)
 		return nil, wrapErrorToClient(err)
@@ -74,14 +91,17 @@ func (s *ItemsService) GetItemList(ctx context.Context, req *pb.GetItemListReque
 	return resp, nil
 }
 
-// GetItem returns item's information.
+// GetItems returns information of items with provided IDs.
 func (s *ItemsService) GetItems(ctx context.Context, req *pb.GetItemsRequest) (*pb.GetItemsResponse, error) {
 	componentName := "ItemsService:GetItems"
 	resp := new(pb.GetItemsResponse)
 
-	var err error
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, false)
+	if err != nil {
+		return nil, err
+	}
 
-	resp.Items, err = s.db.GetItemsByID(ctx, req.Username, req.Ids)
+	resp.Items, err = s.db.GetItemsByID(ctx, owner, req.Ids)
 	if err != nil {
 		s.logger.Warn(err, "db error", componentName)
 		return nil, wrapErrorToClient(err)
@@ -90,14 +110,44 @@ func (s *ItemsService) GetItems(ctx context.Context, req *pb.GetItemsRequest) (*
 	return resp, nil
 }
 
-// GetItem returns item's information.
+// GetChangesSince returns IDs of user's items created, updated and deleted since provided revision.
+//
+// If provided revision is newer than user's current revision, client must synchronize all items.
+func (s *ItemsService) GetChangesSince(ctx context.Context,
+	req *pb.GetChangesSinceRequest) (*pb.GetChangesSinceResponse, error) {
+	componentName := "ItemsService:GetChangesSince"
+	resp := new(pb.GetChangesSinceResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	changes, err := s.db.GetChangesSince(ctx, req.Username, req.Revision)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Revision = changes.Revision
+	resp.FullSync = req.Revision > changes.Revision
+	resp.Created = changes.Created
+	resp.Updated = changes.Updated
+	resp.Deleted = changes.Deleted
+
+	return resp, nil
+}
+
+// GetItemHash returns item's hash.
 func (s *ItemsService) GetItemHash(ctx context.Context, req *pb.GetItemHashRequest) (*pb.GetItemHashResponse, error) {
 	componentName := "ItemsService:GetItemHash"
 	resp := new(pb.GetItemHashResponse)
 
-	var err error
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, false)
+	if err != nil {
+		return nil, err
+	}
 
-	resp.Hash, err = s.db.GetItemHashByID(ctx, req.Id)
+	resp.Hash, err = s.db.GetItemHashByID(ctx, owner, req.Id)
 	if err != nil {
 		s.logger.Warn(err, "db error", componentName)
 		return nil, wrapErrorToClient(err)
@@ -111,7 +161,12 @@ func (s *ItemsService) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest
 	componentName := "ItemsService:UpdateItem"
 	resp := new(pb.UpdateItemResponse)
 
-	if err := s.db.UpdateItem(ctx, req.Username, req.Item); err != nil {
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.UpdateItem(ctx, owner, req.Item); err != nil {
 		s.logger.Warn(err, "db error", componentName)
 		return nil, wrapErrorToClient(err)
 	}
@@ -122,17 +177,500 @@ func (s *ItemsService) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest
 	return resp, nil
 }
 
-// DeleteItem deletes item.
+// DeleteItem moves item to trash.
 func (s *ItemsService) DeleteItem(ctx context.Context, req *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
 	componentName := "ItemsService:DeleteItem"
 	resp := new(pb.DeleteItemResponse)
 
-	if err := s.db.DeleteItem(ctx, req.Username, req.Id); err != nil {
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.DeleteItem(ctx, owner, req.Id); err != nil {
 		s.logger.Warn(err, "db error", componentName)
 		return nil, wrapErrorToClient(err)
 	}
 
-	resp.Info = "item deleted"
+	resp.Info = "item moved to trash"
+
+	return resp, nil
+}
+
+// ListTrash returns list with trashed items' short representation.
+func (s *ItemsService) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.ListTrashResponse, error) {
+	componentName := "ItemsService:ListTrash"
+	resp := new(pb.ListTrashResponse)
+
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Items, err = s.db.GetTrashList(ctx, owner)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
 
 	return resp, nil
 }
+
+// RestoreItem restores item from trash.
+func (s *ItemsService) RestoreItem(ctx context.Context, req *pb.RestoreItemRequest) (*pb.RestoreItemResponse, error) {
+	componentName := "ItemsService:RestoreItem"
+	resp := new(pb.RestoreItemResponse)
+
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.RestoreItem(ctx, owner, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = "item restored"
+
+	return resp, nil
+}
+
+// PurgeItem permanently deletes trashed item.
+func (s *ItemsService) PurgeItem(ctx context.Context, req *pb.PurgeItemRequest) (*pb.PurgeItemResponse, error) {
+	componentName := "ItemsService:PurgeItem"
+	resp := new(pb.PurgeItemResponse)
+
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.PurgeItem(ctx, owner, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = "item purged"
+
+	return resp, nil
+}
+
+// ListItemVersions returns item's previous versions.
+func (s *ItemsService) ListItemVersions(ctx context.Context,
+	req *pb.ListItemVersionsRequest) (*pb.ListItemVersionsResponse, error) {
+
+	componentName := "ItemsService:ListItemVersions"
+	resp := new(pb.ListItemVersionsResponse)
+
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Versions, err = s.db.GetItemVersions(ctx, owner, req.ItemId)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// RestoreItemVersion restores item's state from previous version.
+func (s *ItemsService) RestoreItemVersion(ctx context.Context,
+	req *pb.RestoreItemVersionRequest) (*pb.RestoreItemVersionResponse, error) {
+
+	componentName := "ItemsService:RestoreItemVersion"
+	resp := new(pb.RestoreItemVersionResponse)
+
+	owner, err := s.vaultOwner(ctx, componentName, req.Username, req.OrgId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.RestoreItemVersion(ctx, owner, req.ItemId, req.Version); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("item restored from version %d", req.Version)
+
+	return resp, nil
+}
+
+// GetAllItems returns all user's items, including trashed.
+func (s *ItemsService) GetAllItems(ctx context.Context, req *pb.GetAllItemsRequest) (*pb.GetAllItemsResponse, error) {
+	componentName := "ItemsService:GetAllItems"
+	resp := new(pb.GetAllItemsResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+
+	resp.Items, err = s.db.GetAllItems(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// RotateEncryptionKey replaces user's encryption key, private key, all user's items and folders with
+// re-encrypted ones.
+//
+// Request must contain all user's items (including trashed), folders, private key (if user has key pair)
+// and keys of all emergency accesses granted by user, otherwise nothing is changed.
+func (s *ItemsService) RotateEncryptionKey(ctx context.Context,
+	req *pb.RotateEncryptionKeyRequest) (*pb.RotateEncryptionKeyResponse, error) {
+
+	componentName := "ItemsService:RotateEncryptionKey"
+	resp := new(pb.RotateEncryptionKeyResponse)
+
+	if len(req.Ekey) == 0 {
+		return nil, ErrMissedEncryptionKey
+	}
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.RotateEncryptionKey(ctx, req.Username, req.Ekey, req.PrivateKey, req.Items, req.Folders,
+		req.EmergencyKeys); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("encryption key rotated, %d items and %d folders re-encrypted",
+		len(req.Items), len(req.Folders))
+
+	return resp, nil
+}
+
+// UploadSecretData replaces data item's content with chunks, received from client's stream.
+//
+// Username and item's ID are taken from first message of stream. Previous content is replaced
+// only after whole stream is received, so interrupted upload doesn't change item.
+func (s *ItemsService) UploadSecretData(stream pb.Items_UploadSecretDataServer) error {
+	componentName := "ItemsService:UploadSecretData"
+	resp := new(pb.UploadSecretDataResponse)
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrMissedItemInfo
+		}
+
+		return err
+	}
+
+	if !userPerformSelfOperation(ctx, first.Username) {
+		return permissionDeniedErr("access denied")
+	}
+
+	owner, err := s.vaultOwner(ctx, componentName, first.Username, first.OrgId, true)
+	if err != nil {
+		return err
+	}
+
+	chunk := first.Chunk
+	next := func() ([]byte, error) {
+		for len(chunk) == 0 {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			chunk = req.Chunk
+		}
+
+		received := chunk
+		chunk = nil
+
+		return received, nil
+	}
+
+	if resp.Size, err = s.db.SaveSecretData(ctx, owner, first.ItemId, next); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully upload data of item %d, %d bytes stored", first.ItemId, resp.Size)
+
+	return stream.SendAndClose(resp)
+}
+
+// DownloadSecretData sends data item's content to client's stream chunk by chunk.
+func (s *ItemsService) DownloadSecretData(req *pb.DownloadSecretDataRequest,
+	stream pb.Items_DownloadSecretDataServer) error {
+
+	componentName := "ItemsService:DownloadSecretData"
+
+	if !userPerformSelfOperation(stream.Context(), req.Username) {
+		return permissionDeniedErr("access denied")
+	}
+
+	owner, err := s.vaultOwner(stream.Context(), componentName, req.Username, req.OrgId, false)
+	if err != nil {
+		return err
+	}
+
+	send := func(chunk []byte) error {
+		return stream.Send(&pb.DownloadSecretDataResponse{Chunk: chunk})
+	}
+
+	if err := s.db.GetSecretData(stream.Context(), owner, req.ItemId, send); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return wrapErrorToClient(err)
+	}
+
+	return nil
+}
+
+// WatchVault sends new revisions of user's vault to client's stream as soon as vault is changed.
+//
+// Current revision is sent first, so client can synchronize changes made before stream was opened.
+// If changes can't be watched anymore stream is closed with Unavailable status, client should
+// poll revision in such case.
+func (s *ItemsService) WatchVault(req *pb.WatchVaultRequest, stream pb.Items_WatchVaultServer) error {
+	componentName := "ItemsService:WatchVault"
+	ctx := stream.Context()
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return permissionDeniedErr("access denied")
+	}
+
+	revisions, err := s.db.WatchRevision(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return wrapErrorToClient(err)
+	}
+
+	revision, err := s.db.GetUserRevision(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return wrapErrorToClient(err)
+	}
+
+	for {
+		if err := stream.Send(&pb.WatchVaultResponse{Revision: revision}); err != nil {
+			return err
+		}
+
+		var ok bool
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case revision, ok = <-revisions:
+			if !ok {
+				return wrapErrorToClient(db.ErrWatchUnavailable)
+			}
+		}
+	}
+}
+
+// CreateFolder creates new folder.
+func (s *ItemsService) CreateFolder(ctx context.Context, req *pb.CreateFolderRequest) (*pb.CreateFolderResponse, error) {
+	componentName := "ItemsService:CreateFolder"
+	resp := new(pb.CreateFolderResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+
+	resp.Id, err = s.db.CreateFolder(ctx, req.Username, req.Folder)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully create folder %d", resp.Id)
+
+	return resp, nil
+}
+
+// GetFolders returns all user's folders.
+func (s *ItemsService) GetFolders(ctx context.Context, req *pb.GetFoldersRequest) (*pb.GetFoldersResponse, error) {
+	componentName := "ItemsService:GetFolders"
+	resp := new(pb.GetFoldersResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+
+	resp.Folders, err = s.db.GetFolders(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// UpdateFolder renames existing folder.
+func (s *ItemsService) UpdateFolder(ctx context.Context, req *pb.UpdateFolderRequest) (*pb.UpdateFolderResponse, error) {
+	componentName := "ItemsService:UpdateFolder"
+	resp := new(pb.UpdateFolderResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.UpdateFolder(ctx, req.Username, req.Folder); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully update folder %d", req.Folder.GetId())
+
+	return resp, nil
+}
+
+// DeleteFolder deletes folder, folder's items are kept without folder.
+func (s *ItemsService) DeleteFolder(ctx context.Context, req *pb.DeleteFolderRequest) (*pb.DeleteFolderResponse, error) {
+	componentName := "ItemsService:DeleteFolder"
+	resp := new(pb.DeleteFolderResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.DeleteFolder(ctx, req.Username, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully delete folder %d", req.Id)
+
+	return resp, nil
+}
+
+// ShareItem shares user's item with another user.
+//
+// Item is stored re-encrypted by client with share's key, which is encrypted with recipient's public key.
+// If item is already shared with recipient, share is replaced.
+func (s *ItemsService) ShareItem(ctx context.Context, req *pb.ShareItemRequest) (*pb.ShareItemResponse, error) {
+	componentName := "ItemsService:ShareItem"
+	resp := new(pb.ShareItemResponse)
+
+	if req.Share == nil {
+		return nil, ErrMissedShareInfo
+	}
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+	if resp.Id, err = s.db.ShareItem(ctx, req.Username, req.Share); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully share item %d with user '%s'", req.Share.ItemId, req.Share.Recipient)
+
+	return resp, nil
+}
+
+// GetShares returns items shared with user and shares of user's items.
+func (s *ItemsService) GetShares(ctx context.Context, req *pb.GetSharesRequest) (*pb.GetSharesResponse, error) {
+	componentName := "ItemsService:GetShares"
+	resp := new(pb.GetSharesResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+	if resp.Received, err = s.db.GetReceivedShares(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if resp.Sent, err = s.db.GetSentShares(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// RevokeShare deletes share of user's item.
+func (s *ItemsService) RevokeShare(ctx context.Context, req *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	componentName := "ItemsService:RevokeShare"
+	resp := new(pb.RevokeShareResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.RevokeShare(ctx, req.Username, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully revoke share %d", req.Id)
+
+	return resp, nil
+}
+
+// vaultOwner returns owner of vault, which request is performed with.
+//
+// Zero orgID means user's own vault, organizations' vaults can't be accessed as own vault.
+// Otherwise user must be accepted member of organization, viewers have read-only access
+// to organization's vault.
+func (s *ItemsService) vaultOwner(ctx context.Context, componentName string, username string, orgID int64,
+	write bool) (db.Username, error) {
+	if orgID == 0 {
+		if !userPerformSelfOperation(ctx, username) || db.IsOrgVault(username) {
+			return "", permissionDeniedErr("access denied")
+		}
+
+		return username, nil
+	}
+
+	roles := common.ListOrgRoles()
+	if write {
+		roles = []string{common.OrgRoleAdmin, common.OrgRoleMember}
+	}
+
+	membership, err := checkMembership(ctx, s.db, s.logger, componentName, username, orgID, roles...)
+	if err != nil {
+		return "", err
+	}
+
+	return membership.Vault, nil
+}
+
+// setAuditItemID sets ID of created item to audit event.
+//
+// Created item is found by name and type among vault's items. Failure of search is only logged,
+// event is recorded without item's ID.
+func (s *ItemsService) setAuditItemID(ctx context.Context, event *pb.AuditEvent, owner db.Username,
+	item *pb.Item) {
+	componentName := "ItemsService:setAuditItemID"
+
+	items, err := s.db.GetItemList(ctx, owner)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return
+	}
+
+	for _, i := range items {
+		if i.Name == item.Name && i.Type == item.Type && i.Deleted == nil {
+			itemID := i.Id
+			event.ItemId = &itemID
+
+			return
+		}
+	}
+}
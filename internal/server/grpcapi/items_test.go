@@ -1,15 +1,22 @@
 package grpcapi
 
 import (
+	"context"
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/mocks/mockdb"
 	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewItemsService(t *testing.T) {
@@ -25,22 +32,25 @@ func TestItemsService_CreateItem(t *testing.T) {
 	}
 	defer ts.Stop()
 
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
 	t.Run("DB returns error", func(t *testing.T) {
 		ts.DB.EXPECT().CreateItem(mockAny, mockAny, mockAny).Return(assert.AnError)
-		req := &pb.CreateItemRequest{}
-		_, err := ts.ItemsClient.CreateItem(testCtx, req)
+		req := &pb.CreateItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.CreateItem(authCtx, req)
 		assert.Error(t, err)
 	})
 
 	t.Run("Successfully create", func(t *testing.T) {
 		ts.DB.EXPECT().CreateItem(mockAny, mockAny, mockAny).Return(nil)
 		req := &pb.CreateItemRequest{
+			Username: "CorrectUser",
 			Item: &pb.Item{
 				Name: "name",
 				Type: common.ItemTypeLogin,
 			},
 		}
-		resp, err := ts.ItemsClient.CreateItem(testCtx, req)
+		resp, err := ts.ItemsClient.CreateItem(authCtx, req)
 		require.NoError(t, err)
 		assert.NotEmpty(t, resp)
 	})
@@ -53,10 +63,25 @@ func TestItemsService_GetItem(t *testing.T) {
 	}
 	defer ts.Stop()
 
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.GetItemRequest{Username: "AnotherUser"}
+		_, err := ts.ItemsClient.GetItem(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("Organization's vault as own vault", func(t *testing.T) {
+		vaultCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "org-0123456789abcdef")
+		req := &pb.GetItemRequest{Username: "org-0123456789abcdef"}
+		_, err := ts.ItemsClient.GetItem(vaultCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
 	t.Run("DB returns error", func(t *testing.T) {
 		ts.DB.EXPECT().GetItemByNameAndType(mockAny, mockAny, mockAny, mockAny).Return(nil, assert.AnError)
-		req := &pb.GetItemRequest{}
-		_, err := ts.ItemsClient.GetItem(testCtx, req)
+		req := &pb.GetItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.GetItem(authCtx, req)
 		assert.Error(t, err)
 	})
 
@@ -68,13 +93,57 @@ func TestItemsService_GetItem(t *testing.T) {
 			Item: respItem,
 		}
 		ts.DB.EXPECT().GetItemByNameAndType(mockAny, mockAny, mockAny, mockAny).Return(respItem, nil)
-		req := &pb.GetItemRequest{}
-		gotResp, err := ts.ItemsClient.GetItem(testCtx, req)
+		req := &pb.GetItemRequest{Username: "CorrectUser"}
+		gotResp, err := ts.ItemsClient.GetItem(authCtx, req)
 		require.NoError(t, err)
 		assert.Equal(t, resp.Item.Name, gotResp.Item.Name)
 	})
 }
 
+func TestItemsService_GetChangesSince(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.GetChangesSinceRequest{Username: "AnotherUser"}
+		_, err := ts.ItemsClient.GetChangesSince(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetChangesSince(mockAny, "CorrectUser", mockAny).Return(nil, assert.AnError)
+		req := &pb.GetChangesSinceRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.GetChangesSince(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get changes", func(t *testing.T) {
+		changes := &db.Changes{Revision: 10, Created: []int64{1}, Updated: []int64{2, 3}, Deleted: []int64{4}}
+		ts.DB.EXPECT().GetChangesSince(mockAny, "CorrectUser", int64(5)).Return(changes, nil)
+		req := &pb.GetChangesSinceRequest{Username: "CorrectUser", Revision: 5}
+		gotResp, err := ts.ItemsClient.GetChangesSince(authCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, changes.Revision, gotResp.Revision)
+		assert.False(t, gotResp.FullSync)
+		assert.Equal(t, changes.Created, gotResp.Created)
+		assert.Equal(t, changes.Updated, gotResp.Updated)
+		assert.Equal(t, changes.Deleted, gotResp.Deleted)
+	})
+
+	t.Run("Revision is newer than current", func(t *testing.T) {
+		ts.DB.EXPECT().GetChangesSince(mockAny, "CorrectUser", int64(20)).Return(&db.Changes{Revision: 10}, nil)
+		req := &pb.GetChangesSinceRequest{Username: "CorrectUser", Revision: 20}
+		gotResp, err := ts.ItemsClient.GetChangesSince(authCtx, req)
+		require.NoError(t, err)
+		assert.True(t, gotResp.FullSync)
+	})
+}
+
 func TestItemsService_GetItemList(t *testing.T) {
 	ts, tsErr := NewTestSuiteGRPCServer(t)
 	if tsErr != nil {
@@ -82,10 +151,12 @@ func TestItemsService_GetItemList(t *testing.T) {
 	}
 	defer ts.Stop()
 
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
 	t.Run("DB returns error", func(t *testing.T) {
 		ts.DB.EXPECT().GetItemList(mockAny, mockAny).Return(nil, assert.AnError)
-		req := &pb.GetItemListRequest{}
-		_, err := ts.ItemsClient.GetItemList(testCtx, req)
+		req := &pb.GetItemListRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.GetItemList(authCtx, req)
 		assert.Error(t, err)
 	})
 
@@ -96,8 +167,8 @@ func TestItemsService_GetItemList(t *testing.T) {
 			},
 		}
 		ts.DB.EXPECT().GetItemList(mockAny, mockAny).Return(respItems, nil)
-		req := &pb.GetItemListRequest{}
-		gotResp, err := ts.ItemsClient.GetItemList(testCtx, req)
+		req := &pb.GetItemListRequest{Username: "CorrectUser"}
+		gotResp, err := ts.ItemsClient.GetItemList(authCtx, req)
 		require.NoError(t, err)
 		assert.NotEmpty(t, gotResp)
 	})
@@ -110,10 +181,18 @@ func TestItemsService_GetItems(t *testing.T) {
 	}
 	defer ts.Stop()
 
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.GetItemsRequest{Username: "AnotherUser", Ids: []int64{1}}
+		_, err := ts.ItemsClient.GetItems(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
 	t.Run("DB returns error", func(t *testing.T) {
 		ts.DB.EXPECT().GetItemsByID(mockAny, mockAny, mockAny).Return(nil, assert.AnError)
-		req := &pb.GetItemsRequest{}
-		_, err := ts.ItemsClient.GetItems(testCtx, req)
+		req := &pb.GetItemsRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.GetItems(authCtx, req)
 		assert.Error(t, err)
 	})
 
@@ -123,9 +202,9 @@ func TestItemsService_GetItems(t *testing.T) {
 				Name: "name",
 			},
 		}
-		ts.DB.EXPECT().GetItemsByID(mockAny, mockAny, mockAny).Return(respItems, nil)
-		req := &pb.GetItemsRequest{}
-		gotResp, err := ts.ItemsClient.GetItems(testCtx, req)
+		ts.DB.EXPECT().GetItemsByID(mockAny, "CorrectUser", mockAny).Return(respItems, nil)
+		req := &pb.GetItemsRequest{Username: "CorrectUser"}
+		gotResp, err := ts.ItemsClient.GetItems(authCtx, req)
 		require.NoError(t, err)
 		assert.NotEmpty(t, gotResp)
 	})
@@ -138,17 +217,30 @@ func TestItemsService_GetItemHash(t *testing.T) {
 	}
 	defer ts.Stop()
 
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.GetItemHashRequest{Username: "AnotherUser", Id: 1}
+		_, err := ts.ItemsClient.GetItemHash(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("Missed username", func(t *testing.T) {
+		_, err := ts.ItemsClient.GetItemHash(authCtx, &pb.GetItemHashRequest{Id: 1})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
 	t.Run("DB returns error", func(t *testing.T) {
-		ts.DB.EXPECT().GetItemHashByID(mockAny, mockAny).Return(nil, assert.AnError)
-		req := &pb.GetItemHashRequest{}
-		_, err := ts.ItemsClient.GetItemHash(testCtx, req)
+		ts.DB.EXPECT().GetItemHashByID(mockAny, mockAny, mockAny).Return(nil, assert.AnError)
+		req := &pb.GetItemHashRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.GetItemHash(authCtx, req)
 		assert.Error(t, err)
 	})
 
 	t.Run("Successfully get hash", func(t *testing.T) {
-		ts.DB.EXPECT().GetItemHashByID(mockAny, mockAny).Return([]byte("hash"), nil)
-		req := &pb.GetItemHashRequest{}
-		resp, err := ts.ItemsClient.GetItemHash(testCtx, req)
+		ts.DB.EXPECT().GetItemHashByID(mockAny, "CorrectUser", int64(1)).Return([]byte("hash"), nil)
+		req := &pb.GetItemHashRequest{Username: "CorrectUser", Id: 1}
+		resp, err := ts.ItemsClient.GetItemHash(authCtx, req)
 		require.NoError(t, err)
 		assert.Equal(t, []byte("hash"), resp.Hash)
 	})
@@ -161,22 +253,25 @@ func TestItemsService_UpdateItem(t *testing.T) {
 	}
 	defer ts.Stop()
 
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
 	t.Run("DB returns error", func(t *testing.T) {
 		ts.DB.EXPECT().UpdateItem(mockAny, mockAny, mockAny).Return(assert.AnError)
-		req := &pb.UpdateItemRequest{}
-		_, err := ts.ItemsClient.UpdateItem(testCtx, req)
+		req := &pb.UpdateItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.UpdateItem(authCtx, req)
 		assert.Error(t, err)
 	})
 
 	t.Run("Successfully updated", func(t *testing.T) {
 		ts.DB.EXPECT().UpdateItem(mockAny, mockAny, mockAny).Return(nil)
 		req := &pb.UpdateItemRequest{
+			Username: "CorrectUser",
 			Item: &pb.Item{
 				Name: "name",
 				Type: common.ItemTypeLogin,
 			},
 		}
-		resp, err := ts.ItemsClient.UpdateItem(testCtx, req)
+		resp, err := ts.ItemsClient.UpdateItem(authCtx, req)
 		require.NoError(t, err)
 		assert.NotEmpty(t, resp)
 	})
@@ -189,18 +284,720 @@ func TestItemsService_DeleteItem(t *testing.T) {
 	}
 	defer ts.Stop()
 
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
 	t.Run("DB returns error", func(t *testing.T) {
 		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(assert.AnError)
-		req := &pb.DeleteItemRequest{}
-		_, err := ts.ItemsClient.DeleteItem(testCtx, req)
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.DeleteItem(authCtx, req)
 		assert.Error(t, err)
 	})
 
 	t.Run("Successfully deleted", func(t *testing.T) {
 		ts.DB.EXPECT().DeleteItem(mockAny, mockAny, mockAny).Return(nil)
-		req := &pb.DeleteItemRequest{}
-		resp, err := ts.ItemsClient.DeleteItem(testCtx, req)
+		req := &pb.DeleteItemRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.DeleteItem(authCtx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp)
+	})
+}
+
+func TestItemsService_ListTrash(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetTrashList(mockAny, mockAny).Return(nil, assert.AnError)
+		req := &pb.ListTrashRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.ListTrash(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get trash", func(t *testing.T) {
+		respItems := []*pb.ItemShort{
+			{
+				Name: "name",
+			},
+		}
+		ts.DB.EXPECT().GetTrashList(mockAny, mockAny).Return(respItems, nil)
+		req := &pb.ListTrashRequest{Username: "CorrectUser"}
+		gotResp, err := ts.ItemsClient.ListTrash(authCtx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, gotResp)
+	})
+}
+
+func TestItemsService_RestoreItem(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().RestoreItem(mockAny, mockAny, mockAny).Return(assert.AnError)
+		req := &pb.RestoreItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.RestoreItem(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully restored", func(t *testing.T) {
+		ts.DB.EXPECT().RestoreItem(mockAny, mockAny, mockAny).Return(nil)
+		req := &pb.RestoreItemRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.RestoreItem(authCtx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp)
+	})
+}
+
+func TestItemsService_PurgeItem(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().PurgeItem(mockAny, mockAny, mockAny).Return(assert.AnError)
+		req := &pb.PurgeItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.PurgeItem(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully purged", func(t *testing.T) {
+		ts.DB.EXPECT().PurgeItem(mockAny, mockAny, mockAny).Return(nil)
+		req := &pb.PurgeItemRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.PurgeItem(authCtx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp)
+	})
+}
+
+func TestItemsService_ListItemVersions(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetItemVersions(mockAny, mockAny, mockAny).Return(nil, assert.AnError)
+		req := &pb.ListItemVersionsRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.ListItemVersions(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get versions", func(t *testing.T) {
+		respVersions := []*pb.ItemVersion{
+			{
+				Version: 1,
+				Item:    &pb.Item{Name: "name"},
+			},
+		}
+		ts.DB.EXPECT().GetItemVersions(mockAny, mockAny, mockAny).Return(respVersions, nil)
+		req := &pb.ListItemVersionsRequest{Username: "CorrectUser"}
+		gotResp, err := ts.ItemsClient.ListItemVersions(authCtx, req)
+		require.NoError(t, err)
+		require.Len(t, gotResp.Versions, 1)
+		assert.Equal(t, "name", gotResp.Versions[0].Item.Name)
+	})
+}
+
+func TestItemsService_RestoreItemVersion(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().RestoreItemVersion(mockAny, mockAny, mockAny, mockAny).Return(assert.AnError)
+		req := &pb.RestoreItemVersionRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.RestoreItemVersion(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully restored", func(t *testing.T) {
+		ts.DB.EXPECT().RestoreItemVersion(mockAny, mockAny, mockAny, mockAny).Return(nil)
+		req := &pb.RestoreItemVersionRequest{Username: "CorrectUser", Version: 1}
+		resp, err := ts.ItemsClient.RestoreItemVersion(authCtx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp)
+	})
+}
+
+func TestItemsService_GetAllItems(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.GetAllItemsRequest{Username: "AnotherUser"}
+		_, err := ts.ItemsClient.GetAllItems(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetAllItems(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		req := &pb.GetAllItemsRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.GetAllItems(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get all items", func(t *testing.T) {
+		respItems := []*pb.Item{{Name: "name"}, {Name: "trashed"}}
+		ts.DB.EXPECT().GetAllItems(mockAny, "CorrectUser").Return(respItems, nil)
+		req := &pb.GetAllItemsRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.GetAllItems(authCtx, req)
+		require.NoError(t, err)
+		assert.Len(t, resp.Items, 2)
+	})
+}
+
+func TestItemsService_RotateEncryptionKey(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Missed encryption key", func(t *testing.T) {
+		req := &pb.RotateEncryptionKeyRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.RotateEncryptionKey(authCtx, req)
+		assert.ErrorIs(t, err, ErrMissedEncryptionKey)
+	})
+
+	t.Run("Rotate key of another user", func(t *testing.T) {
+		req := &pb.RotateEncryptionKeyRequest{Username: "AnotherUser", Ekey: []byte("newkey")}
+		_, err := ts.ItemsClient.RotateEncryptionKey(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().RotateEncryptionKey(mockAny, mockAny, mockAny, mockAny, mockAny, mockAny, mockAny).Return(assert.AnError)
+		req := &pb.RotateEncryptionKeyRequest{Username: "CorrectUser", Ekey: []byte("newkey")}
+		_, err := ts.ItemsClient.RotateEncryptionKey(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully rotated", func(t *testing.T) {
+		ts.DB.EXPECT().RotateEncryptionKey(mockAny, mockAny, []byte("newkey"), []byte("private"), mockAny, mockAny, mockAny).
+			Return(nil)
+		req := &pb.RotateEncryptionKeyRequest{Username: "CorrectUser", Ekey: []byte("newkey"),
+			PrivateKey: []byte("private"), Items: []*pb.Item{{Name: "name"}}}
+		resp, err := ts.ItemsClient.RotateEncryptionKey(authCtx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Info)
+	})
+}
+
+func TestItemsService_UploadSecretData(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	upload := func(ctx context.Context, reqs ...*pb.UploadSecretDataRequest) (*pb.UploadSecretDataResponse, error) {
+		stream, err := ts.ItemsClient.UploadSecretData(ctx)
+		require.NoError(t, err)
+
+		for _, req := range reqs {
+			require.NoError(t, stream.Send(req))
+		}
+
+		return stream.CloseAndRecv()
+	}
+
+	t.Run("Empty stream", func(t *testing.T) {
+		_, err := upload(authCtx)
+		assert.ErrorIs(t, err, ErrMissedItemInfo)
+	})
+
+	t.Run("Access denied", func(t *testing.T) {
+		_, err := upload(authCtx, &pb.UploadSecretDataRequest{Username: "OtherUser", ItemId: 1})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().SaveSecretData(mockAny, "CorrectUser", int64(1), mockAny).
+			Return(int64(0), db.ErrSecretTooBig)
+		_, err := upload(authCtx, &pb.UploadSecretDataRequest{Username: "CorrectUser", ItemId: 1})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Successfully uploaded", func(t *testing.T) {
+		var received [][]byte
+
+		ts.DB.EXPECT().SaveSecretData(mockAny, "CorrectUser", int64(1), mockAny).DoAndReturn(
+			func(_ context.Context, _ db.Username, _ int64, next db.ChunkReader) (int64, error) {
+				for {
+					chunk, err := next()
+					if errors.Is(err, io.EOF) {
+						return 10, nil
+					}
+
+					if err != nil {
+						return 0, err
+					}
+
+					received = append(received, chunk)
+				}
+			})
+
+		resp, err := upload(authCtx,
+			&pb.UploadSecretDataRequest{Username: "CorrectUser", ItemId: 1},
+			&pb.UploadSecretDataRequest{Chunk: []byte("first")},
+			&pb.UploadSecretDataRequest{Chunk: []byte("second")})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), resp.Size)
+		assert.NotEmpty(t, resp.Info)
+		assert.Equal(t, [][]byte{[]byte("first"), []byte("second")}, received, "empty chunks are skipped")
+	})
+}
+
+func TestItemsService_DownloadSecretData(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	download := func(ctx context.Context, req *pb.DownloadSecretDataRequest) ([][]byte, error) {
+		stream, err := ts.ItemsClient.DownloadSecretData(ctx, req)
+		require.NoError(t, err)
+
+		var chunks [][]byte
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return chunks, nil
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			chunks = append(chunks, resp.Chunk)
+		}
+	}
+
+	t.Run("Access denied", func(t *testing.T) {
+		_, err := download(authCtx, &pb.DownloadSecretDataRequest{Username: "OtherUser", ItemId: 1})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetSecretData(mockAny, "CorrectUser", int64(1), mockAny).Return(db.ErrNotFound)
+		_, err := download(authCtx, &pb.DownloadSecretDataRequest{Username: "CorrectUser", ItemId: 1})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Successfully downloaded", func(t *testing.T) {
+		chunks := [][]byte{[]byte("first"), []byte("second")}
+
+		ts.DB.EXPECT().GetSecretData(mockAny, "CorrectUser", int64(1), mockAny).DoAndReturn(
+			func(_ context.Context, _ db.Username, _ int64, send db.ChunkWriter) error {
+				for _, c := range chunks {
+					if err := send(c); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+
+		got, err := download(authCtx, &pb.DownloadSecretDataRequest{Username: "CorrectUser", ItemId: 1})
+		require.NoError(t, err)
+		assert.Equal(t, chunks, got)
+	})
+}
+
+func TestItemsService_WatchVault(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	watch := func(req *pb.WatchVaultRequest) pb.Items_WatchVaultClient {
+		stream, err := ts.ItemsClient.WatchVault(authCtx, req)
+		require.NoError(t, err)
+
+		return stream
+	}
+
+	t.Run("Access denied", func(t *testing.T) {
+		stream := watch(&pb.WatchVaultRequest{Username: "OtherUser"})
+		_, err := stream.Recv()
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Watch is unavailable", func(t *testing.T) {
+		ts.DB.EXPECT().WatchRevision(mockAny, "CorrectUser").Return(nil, db.ErrWatchUnavailable)
+
+		stream := watch(&pb.WatchVaultRequest{Username: "CorrectUser"})
+		_, err := stream.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("Get revision error", func(t *testing.T) {
+		ts.DB.EXPECT().WatchRevision(mockAny, "CorrectUser").Return(make(chan int64), nil)
+		ts.DB.EXPECT().GetUserRevision(mockAny, "CorrectUser").Return(int64(0), db.ErrNotFound)
+
+		stream := watch(&pb.WatchVaultRequest{Username: "CorrectUser"})
+		_, err := stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Revisions are sent until watch is interrupted", func(t *testing.T) {
+		revisions := make(chan int64, 1)
+
+		ts.DB.EXPECT().WatchRevision(mockAny, "CorrectUser").Return(revisions, nil)
+		ts.DB.EXPECT().GetUserRevision(mockAny, "CorrectUser").Return(int64(1), nil)
+
+		stream := watch(&pb.WatchVaultRequest{Username: "CorrectUser"})
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Revision)
+
+		revisions <- 2
+
+		resp, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Revision)
+
+		close(revisions)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestItemsService_CreateFolder(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.CreateFolderRequest{Username: "AnotherUser"}
+		_, err := ts.ItemsClient.CreateFolder(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().CreateFolder(mockAny, "CorrectUser", mockAny).Return(int64(0), assert.AnError)
+		req := &pb.CreateFolderRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.CreateFolder(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully created", func(t *testing.T) {
+		ts.DB.EXPECT().CreateFolder(mockAny, "CorrectUser", mockAny).Return(int64(3), nil)
+		req := &pb.CreateFolderRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.CreateFolder(authCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Id)
+	})
+}
+
+func TestItemsService_GetFolders(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.GetFoldersRequest{Username: "AnotherUser"}
+		_, err := ts.ItemsClient.GetFolders(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetFolders(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		req := &pb.GetFoldersRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.GetFolders(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get folders", func(t *testing.T) {
+		folders := []*pb.Folder{{Id: 1, Name: []byte("name")}}
+		ts.DB.EXPECT().GetFolders(mockAny, "CorrectUser").Return(folders, nil)
+		req := &pb.GetFoldersRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.GetFolders(authCtx, req)
+		require.NoError(t, err)
+		require.Len(t, resp.Folders, 1)
+		assert.Equal(t, folders[0].Name, resp.Folders[0].Name)
+	})
+}
+
+func TestItemsService_UpdateFolder(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.UpdateFolderRequest{Username: "AnotherUser"}
+		_, err := ts.ItemsClient.UpdateFolder(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().UpdateFolder(mockAny, "CorrectUser", mockAny).Return(assert.AnError)
+		req := &pb.UpdateFolderRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.UpdateFolder(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully updated", func(t *testing.T) {
+		ts.DB.EXPECT().UpdateFolder(mockAny, "CorrectUser", mockAny).Return(nil)
+		req := &pb.UpdateFolderRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.UpdateFolder(authCtx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp)
+	})
+}
+
+func TestItemsService_DeleteFolder(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.DeleteFolderRequest{Username: "AnotherUser"}
+		_, err := ts.ItemsClient.DeleteFolder(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().DeleteFolder(mockAny, "CorrectUser", mockAny).Return(assert.AnError)
+		req := &pb.DeleteFolderRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.DeleteFolder(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully deleted", func(t *testing.T) {
+		ts.DB.EXPECT().DeleteFolder(mockAny, "CorrectUser", mockAny).Return(nil)
+		req := &pb.DeleteFolderRequest{Username: "CorrectUser"}
+		resp, err := ts.ItemsClient.DeleteFolder(authCtx, req)
 		require.NoError(t, err)
 		assert.NotEmpty(t, resp)
 	})
 }
+
+func TestItemsService_ShareItem(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Missed share", func(t *testing.T) {
+		req := &pb.ShareItemRequest{Username: "CorrectUser"}
+		_, err := ts.ItemsClient.ShareItem(authCtx, req)
+		assert.ErrorIs(t, err, ErrMissedShareInfo)
+	})
+
+	t.Run("Share item of another user", func(t *testing.T) {
+		req := &pb.ShareItemRequest{Username: "AnotherUser", Share: &pb.Share{}}
+		_, err := ts.ItemsClient.ShareItem(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().ShareItem(mockAny, "CorrectUser", mockAny).Return(int64(0), assert.AnError)
+		req := &pb.ShareItemRequest{Username: "CorrectUser", Share: &pb.Share{}}
+		_, err := ts.ItemsClient.ShareItem(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully shared", func(t *testing.T) {
+		ts.DB.EXPECT().ShareItem(mockAny, "CorrectUser", mockAny).Return(int64(7), nil)
+		req := &pb.ShareItemRequest{Username: "CorrectUser", Share: &pb.Share{ItemId: 1, Recipient: "recipient"}}
+		resp, err := ts.ItemsClient.ShareItem(authCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), resp.Id)
+	})
+}
+
+func TestItemsService_GetShares(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Missed Context", func(t *testing.T) {
+		_, err := ts.ItemsClient.GetShares(testCtx, &pb.GetSharesRequest{Username: "CorrectUser"})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error on received shares", func(t *testing.T) {
+		ts.DB.EXPECT().GetReceivedShares(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		_, err := ts.ItemsClient.GetShares(authCtx, &pb.GetSharesRequest{Username: "CorrectUser"})
+		assert.Error(t, err)
+	})
+
+	t.Run("DB returns error on sent shares", func(t *testing.T) {
+		ts.DB.EXPECT().GetReceivedShares(mockAny, "CorrectUser").Return(nil, nil)
+		ts.DB.EXPECT().GetSentShares(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		_, err := ts.ItemsClient.GetShares(authCtx, &pb.GetSharesRequest{Username: "CorrectUser"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully got", func(t *testing.T) {
+		ts.DB.EXPECT().GetReceivedShares(mockAny, "CorrectUser").Return([]*pb.Share{{Id: 1}}, nil)
+		ts.DB.EXPECT().GetSentShares(mockAny, "CorrectUser").Return([]*pb.Share{{Id: 2}, {Id: 3}}, nil)
+		resp, err := ts.ItemsClient.GetShares(authCtx, &pb.GetSharesRequest{Username: "CorrectUser"})
+		require.NoError(t, err)
+		assert.Len(t, resp.Received, 1)
+		assert.Len(t, resp.Sent, 2)
+	})
+}
+
+func TestItemsService_RevokeShare(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Missed Context", func(t *testing.T) {
+		_, err := ts.ItemsClient.RevokeShare(testCtx, &pb.RevokeShareRequest{Username: "CorrectUser", Id: 1})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().RevokeShare(mockAny, "CorrectUser", int64(1)).Return(assert.AnError)
+		_, err := ts.ItemsClient.RevokeShare(authCtx, &pb.RevokeShareRequest{Username: "CorrectUser", Id: 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully revoked", func(t *testing.T) {
+		ts.DB.EXPECT().RevokeShare(mockAny, "CorrectUser", int64(1)).Return(nil)
+		resp, err := ts.ItemsClient.RevokeShare(authCtx, &pb.RevokeShareRequest{Username: "CorrectUser", Id: 1})
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Info)
+	})
+}
+
+func TestItemsService_OrganizationVault(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	item := &pb.Item{Name: "name", Type: common.ItemTypeLogin}
+
+	t.Run("Another user", func(t *testing.T) {
+		req := &pb.CreateItemRequest{Username: "AnotherUser", OrgId: 1, Item: item}
+		_, err := ts.ItemsClient.CreateItem(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("Not a member", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(nil, db.ErrNotFound)
+		req := &pb.GetItemListRequest{Username: "CorrectUser", OrgId: 1}
+		_, err := ts.ItemsClient.GetItemList(authCtx, req)
+		assert.ErrorIs(t, err, ErrNotOrgMember)
+	})
+
+	t.Run("Invite not accepted", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").
+			Return(&db.Membership{Role: common.OrgRoleMember, Vault: "org-vault"}, nil)
+		req := &pb.GetItemListRequest{Username: "CorrectUser", OrgId: 1}
+		_, err := ts.ItemsClient.GetItemList(authCtx, req)
+		assert.ErrorIs(t, err, ErrNotOrgMember)
+	})
+
+	t.Run("Membership DB error", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(nil, db.ErrTransactionFailed)
+		req := &pb.GetItemListRequest{Username: "CorrectUser", OrgId: 1}
+		_, err := ts.ItemsClient.GetItemList(authCtx, req)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("Viewer reads items", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").
+			Return(&db.Membership{Role: common.OrgRoleViewer, Accepted: true, Vault: "org-vault"}, nil)
+		ts.DB.EXPECT().GetItemList(mockAny, "org-vault").Return([]*pb.ItemShort{{Name: "name"}}, nil)
+		req := &pb.GetItemListRequest{Username: "CorrectUser", OrgId: 1}
+		resp, err := ts.ItemsClient.GetItemList(authCtx, req)
+		require.NoError(t, err)
+		assert.Len(t, resp.Items, 1)
+	})
+
+	t.Run("Viewer can't change items", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").
+			Return(&db.Membership{Role: common.OrgRoleViewer, Accepted: true, Vault: "org-vault"}, nil)
+		req := &pb.DeleteItemRequest{Username: "CorrectUser", OrgId: 1, Id: 1}
+		_, err := ts.ItemsClient.DeleteItem(authCtx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Member creates item", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").
+			Return(&db.Membership{Role: common.OrgRoleMember, Accepted: true, Vault: "org-vault"}, nil)
+		ts.DB.EXPECT().CreateItem(mockAny, "org-vault", mockAny).Return(nil)
+		req := &pb.CreateItemRequest{Username: "CorrectUser", OrgId: 1, Item: item}
+		_, err := ts.ItemsClient.CreateItem(authCtx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("Own vault", func(t *testing.T) {
+		ts.DB.EXPECT().CreateItem(mockAny, "CorrectUser", mockAny).Return(nil)
+		req := &pb.CreateItemRequest{Username: "CorrectUser", Item: item}
+		_, err := ts.ItemsClient.CreateItem(authCtx, req)
+		require.NoError(t, err)
+	})
+}
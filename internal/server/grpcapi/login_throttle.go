@@ -0,0 +1,177 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Period without failed attempts, after which key's failures are forgotten.
+const loginFailuresResetPeriod = 24 * time.Hour
+
+// Prefixes of keys of failed login attempts.
+const (
+	loginKeyUserPrefix = "user:"
+	loginKeyIPPrefix   = "ip:"
+)
+
+// LoginThrottle represents parameters of protection from brute-force of users' passwords and
+// verification codes.
+//
+// Failed login attempts are counted per username and per client's address. When number of
+// failures reaches threshold, logins are locked for lockout period. Every next failure doubles
+// lockout period up to maximum one. Every attempt is reserved as failed before credentials' check,
+// so parallel attempts can't exceed threshold. Attempts are stored in database, so they are shared
+// by all server's instances.
+type LoginThrottle struct {
+	// Number of failures per username, after which logins are locked. Zero disables check.
+	MaxUserFailures uint32
+	// Number of failures per client's address, after which logins are locked. Zero disables check.
+	MaxIPFailures uint32
+	// First lockout period.
+	Lockout time.Duration
+	// Maximum lockout period. Lockout period isn't increased, if maximum is less than first one.
+	MaxLockout time.Duration
+}
+
+// Enabled returns true if any of throttle's checks is enabled.
+func (t LoginThrottle) Enabled() bool {
+	return t.Lockout > 0 && (t.MaxUserFailures > 0 || t.MaxIPFailures > 0)
+}
+
+// lockoutPeriod returns lockout period after provided number of failures, zero period means
+// that logins aren't locked.
+func (t LoginThrottle) lockoutPeriod(failures, maxFailures uint32) time.Duration {
+	if maxFailures == 0 || failures < maxFailures {
+		return 0
+	}
+
+	period := t.Lockout
+	for i := maxFailures; i < failures && period < t.MaxLockout; i++ {
+		period *= 2
+	}
+
+	if period > t.MaxLockout && t.MaxLockout > t.Lockout {
+		period = t.MaxLockout
+	}
+
+	return period
+}
+
+// loginKey represents key of failed login attempts with its threshold.
+type loginKey struct {
+	key         string
+	maxFailures uint32
+}
+
+// loginKeys returns keys of failed login attempts for user's login request.
+func (t LoginThrottle) loginKeys(ctx context.Context, username string) []loginKey {
+	var keys []loginKey
+
+	if t.MaxUserFailures > 0 {
+		keys = append(keys, loginKey{key: loginKeyUserPrefix + username, maxFailures: t.MaxUserFailures})
+	}
+
+	if clientIP := clientIPFromContext(ctx); clientIP != "" && t.MaxIPFailures > 0 {
+		keys = append(keys, loginKey{key: loginKeyIPPrefix + clientIP, maxFailures: t.MaxIPFailures})
+	}
+
+	return keys
+}
+
+// reservedLoginKey represents key, for which login attempt is reserved as failed.
+type reservedLoginKey struct {
+	key string
+	// Logins with key were locked by reservation.
+	locked bool
+}
+
+// reserveLoginAttempt is a helper function, which reserves login attempt for all request's keys
+// before credentials' check. Reserved attempt is counted as failed until it's released, so
+// parallel attempts can't pass threshold, and locks logins with keys, which reached threshold.
+//
+// If logins with any of request's keys are locked, attempt isn't reserved and remaining lockout
+// period is returned.
+func (s *UsersService) reserveLoginAttempt(ctx context.Context, username string,
+	componentName string) ([]reservedLoginKey, time.Duration, error) {
+	if s.loginThrottle == nil {
+		return nil, 0, nil
+	}
+
+	resetBefore := time.Now().Add(-loginFailuresResetPeriod)
+
+	var (
+		reserved   []reservedLoginKey
+		retryAfter time.Duration
+		locked     bool
+	)
+
+	for _, k := range s.loginThrottle.loginKeys(ctx, username) {
+		maxFailures := k.maxFailures
+		lockout := func(failures uint32) time.Duration {
+			return s.loginThrottle.lockoutPeriod(failures, maxFailures)
+		}
+
+		attempts, ok, err := s.db.ReserveLoginAttempt(ctx, k.key, resetBefore, lockout)
+		if err != nil {
+			s.releaseLoginAttempt(ctx, reserved, componentName)
+			return nil, 0, err
+		}
+
+		if !ok {
+			locked = true
+
+			if remaining := time.Until(attempts.LockedUntil); remaining > retryAfter {
+				retryAfter = remaining
+			}
+
+			continue
+		}
+
+		lockedByAttempt := lockout(attempts.Failures) > 0
+		if lockedByAttempt {
+			s.logger.Info(fmt.Sprintf("logins with '%s' are locked until %s after %d failed attempts",
+				k.key, attempts.LockedUntil.Format(time.RFC3339), attempts.Failures), componentName)
+		}
+
+		reserved = append(reserved, reservedLoginKey{key: k.key, locked: lockedByAttempt})
+	}
+
+	if locked {
+		s.releaseLoginAttempt(ctx, reserved, componentName)
+
+		if retryAfter < time.Second {
+			retryAfter = time.Second
+		}
+
+		return nil, retryAfter, nil
+	}
+
+	return reserved, 0, nil
+}
+
+// releaseLoginAttempt is a helper function, which forgets reserved login attempt, which isn't failed,
+// and unlocks logins locked by it.
+//
+// Errors are only logged, not released attempt is counted as failed one.
+func (s *UsersService) releaseLoginAttempt(ctx context.Context, reserved []reservedLoginKey,
+	componentName string) {
+	for _, r := range reserved {
+		if err := s.db.ReleaseLoginAttempt(ctx, r.key, r.locked); err != nil {
+			s.logger.Warn(err, "failed to release login attempt", componentName)
+		}
+	}
+}
+
+// resetLoginFailures is a helper function, which forgets user's failed login attempts after
+// successful login. Attempts from client's address are kept, so successful login with one
+// account doesn't unlock guessing of other accounts' passwords.
+func (s *UsersService) resetLoginFailures(ctx context.Context, username string, componentName string) {
+	if s.loginThrottle == nil || s.loginThrottle.MaxUserFailures == 0 {
+		return
+	}
+
+	if err := s.db.DeleteLoginAttempts(ctx, loginKeyUserPrefix+username); err != nil {
+		s.logger.Warn(err, "failed to reset failed login attempts", componentName)
+	}
+}
@@ -0,0 +1,256 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/artfuldog/gophkeeper/internal/crypt"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mockdb"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginThrottle_lockoutPeriod(t *testing.T) {
+	throttle := LoginThrottle{Lockout: time.Minute, MaxLockout: 5 * time.Minute}
+
+	tests := []struct {
+		name        string
+		failures    uint32
+		maxFailures uint32
+		want        time.Duration
+	}{
+		{name: "Check disabled", failures: 10, maxFailures: 0, want: 0},
+		{name: "Below threshold", failures: 2, maxFailures: 3, want: 0},
+		{name: "Threshold reached", failures: 3, maxFailures: 3, want: time.Minute},
+		{name: "Backoff", failures: 5, maxFailures: 3, want: 4 * time.Minute},
+		{name: "Maximum lockout", failures: 6, maxFailures: 3, want: 5 * time.Minute},
+		{name: "Many failures", failures: 1000, maxFailures: 3, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, throttle.lockoutPeriod(tt.failures, tt.maxFailures))
+		})
+	}
+
+	constant := LoginThrottle{Lockout: time.Minute}
+	assert.Equal(t, time.Minute, constant.lockoutPeriod(10, 3), "lockout isn't increased without maximum")
+}
+
+func TestUsersService_SetLoginThrottle(t *testing.T) {
+	s := NewUsersService(nil, mocklogger.NewMockLogger(), nil)
+	assert.Nil(t, s.loginThrottle)
+
+	assert.Nil(t, s.SetLoginThrottle(LoginThrottle{MaxUserFailures: 5}).loginThrottle, "zero lockout")
+	assert.Nil(t, s.SetLoginThrottle(LoginThrottle{Lockout: time.Minute}).loginThrottle, "no thresholds")
+
+	throttle := LoginThrottle{MaxIPFailures: 5, Lockout: time.Minute}
+	assert.Equal(t, &throttle, s.SetLoginThrottle(throttle).loginThrottle)
+}
+
+func TestUsersService_UserLogin_Throttle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mockdb.NewMockDB(mockCtrl)
+	s := NewUsersService(mockDB, mocklogger.NewMockLogger(), nil).SetLoginThrottle(LoginThrottle{
+		MaxUserFailures: 3,
+		MaxIPFailures:   10,
+		Lockout:         time.Minute,
+		MaxLockout:      time.Hour,
+	})
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5555},
+	})
+
+	testPassword := "TestPassword!@34"
+	testPwdHash, _ := crypt.CalculatePasswordHash(testPassword)
+
+	// reserve returns reservation's result for key with provided number of failures.
+	reserve := func(key string, failures uint32) *gomock.Call {
+		return mockDB.EXPECT().ReserveLoginAttempt(mockAny, key, mockAny, mockAny).DoAndReturn(
+			func(_ context.Context, _ string, _ time.Time, lockout db.LoginLockout) (*db.LoginAttempts, bool, error) {
+				return &db.LoginAttempts{
+					Failures:    failures,
+					LockedUntil: time.Now().Add(lockout(failures)),
+				}, true, nil
+			})
+	}
+
+	t.Run("Locked login", func(t *testing.T) {
+		reserve("user:alice", 1)
+		mockDB.EXPECT().ReserveLoginAttempt(mockAny, "ip:10.0.0.1", mockAny, mockAny).
+			Return(&db.LoginAttempts{LockedUntil: time.Now().Add(90 * time.Second)}, false, nil)
+		mockDB.EXPECT().ReleaseLoginAttempt(mockAny, "user:alice", false).Return(nil)
+
+		_, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "alice", Password: testPassword})
+		require.Error(t, err)
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		require.Len(t, st.Details(), 1)
+
+		retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+		require.True(t, ok)
+		assert.Equal(t, 90*time.Second, retryInfo.RetryDelay.AsDuration())
+	})
+
+	t.Run("Failed attempts' check error", func(t *testing.T) {
+		mockDB.EXPECT().ReserveLoginAttempt(mockAny, "user:alice", mockAny, mockAny).
+			Return(nil, false, db.ErrInternalDBError)
+
+		_, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "alice", Password: testPassword})
+		assert.Error(t, err)
+	})
+
+	t.Run("Attempt is reserved before password check", func(t *testing.T) {
+		gomock.InOrder(
+			mockDB.EXPECT().ReserveLoginAttempt(mockAny, "user:alice", mockAny, mockAny).DoAndReturn(
+				func(_ context.Context, _ string, _ time.Time, lockout db.LoginLockout) (*db.LoginAttempts, bool, error) {
+					assert.Zero(t, lockout(2))
+					assert.Equal(t, 2*time.Minute, lockout(4))
+
+					return &db.LoginAttempts{Failures: 4, LockedUntil: time.Now().Add(lockout(4))}, true, nil
+				}),
+			reserve("ip:10.0.0.1", 4),
+			mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return(testPwdHash, "", nil),
+		)
+
+		_, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "alice", Password: "wrong"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "wrong password stays counted")
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		reserve("user:bob", 1)
+		reserve("ip:10.0.0.1", 1)
+		mockDB.EXPECT().GetUserAuthData(mockAny, "bob").Return("", "", db.ErrNotFound)
+
+		_, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "bob", Password: testPassword})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Database error releases attempt", func(t *testing.T) {
+		reserve("user:alice", 1)
+		reserve("ip:10.0.0.1", 1)
+		mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return("", "", db.ErrInternalDBError)
+		mockDB.EXPECT().ReleaseLoginAttempt(mockAny, mockAny, false).Return(nil).Times(2)
+
+		_, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "alice", Password: testPassword})
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong verification code", func(t *testing.T) {
+		reserve("user:alice", 1)
+		reserve("ip:10.0.0.1", 1)
+		mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return(testPwdHash, "key", nil)
+
+		_, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "alice", Password: testPassword, OtpCode: "123"})
+		assert.ErrorIs(t, err, ErrWrongVerificationCode)
+	})
+
+	t.Run("Missed verification code releases attempt", func(t *testing.T) {
+		reserve("user:alice", 1)
+		reserve("ip:10.0.0.1", 1)
+		mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return(testPwdHash, "key", nil)
+		mockDB.EXPECT().ReleaseLoginAttempt(mockAny, mockAny, false).Return(nil).Times(2)
+
+		resp, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "alice", Password: testPassword})
+		require.NoError(t, err)
+		assert.True(t, resp.SecondFactor)
+	})
+
+	t.Run("Successful check releases attempt and resets user's failures", func(t *testing.T) {
+		reserve("user:alice", 3)
+		reserve("ip:10.0.0.1", 1)
+		mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return(testPwdHash, "", nil)
+		mockDB.EXPECT().ReleaseLoginAttempt(mockAny, "user:alice", true).Return(nil)
+		mockDB.EXPECT().ReleaseLoginAttempt(mockAny, "ip:10.0.0.1", false).Return(nil)
+		mockDB.EXPECT().DeleteLoginAttempts(mockAny, "user:alice").Return(nil)
+		mockDB.EXPECT().GetUserState(mockAny, "alice").Return(&db.UserState{Locked: true}, nil)
+
+		_, err := s.UserLogin(ctx, &pb.UserLoginRequest{Username: "alice", Password: testPassword})
+		assert.ErrorIs(t, err, ErrUserLocked)
+	})
+}
+
+func TestUsersService_ChangePassword_Throttle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mockdb.NewMockDB(mockCtrl)
+	s := NewUsersService(mockDB, mocklogger.NewMockLogger(), nil).SetLoginThrottle(LoginThrottle{
+		MaxUserFailures: 3,
+		MaxIPFailures:   10,
+		Lockout:         time.Minute,
+		MaxLockout:      time.Hour,
+	})
+
+	ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs(authUsernameKey, "alice")),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5555}})
+
+	testPassword := "TestPassword!@34"
+	testPwdHash, _ := crypt.CalculatePasswordHash(testPassword)
+	req := func(password string) *pb.ChangePasswordRequest {
+		return &pb.ChangePasswordRequest{Username: "alice", Password: password,
+			NewPwdhash: "newhash", Ekey: []byte("ekey")}
+	}
+
+	reserve := func(key string, failures uint32) *gomock.Call {
+		return mockDB.EXPECT().ReserveLoginAttempt(mockAny, key, mockAny, mockAny).
+			Return(&db.LoginAttempts{Failures: failures, LockedUntil: time.Now()}, true, nil)
+	}
+
+	t.Run("Locked change", func(t *testing.T) {
+		mockDB.EXPECT().ReserveLoginAttempt(mockAny, "user:alice", mockAny, mockAny).
+			Return(&db.LoginAttempts{LockedUntil: time.Now().Add(time.Minute)}, false, nil)
+		mockDB.EXPECT().ReserveLoginAttempt(mockAny, "ip:10.0.0.1", mockAny, mockAny).
+			Return(&db.LoginAttempts{LockedUntil: time.Now().Add(time.Minute)}, false, nil)
+
+		_, err := s.ChangePassword(ctx, req(testPassword))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Wrong password is counted", func(t *testing.T) {
+		gomock.InOrder(
+			reserve("user:alice", 3),
+			reserve("ip:10.0.0.1", 3),
+			mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return(testPwdHash, "", nil),
+		)
+
+		_, err := s.ChangePassword(ctx, req("wrong"))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Wrong verification code is counted", func(t *testing.T) {
+		reserve("user:alice", 1)
+		reserve("ip:10.0.0.1", 1)
+		mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return(testPwdHash, "key", nil)
+
+		_, err := s.ChangePassword(ctx, req(testPassword))
+		assert.ErrorIs(t, err, ErrWrongVerificationCode)
+	})
+
+	t.Run("Successful check resets user's failures", func(t *testing.T) {
+		reserve("user:alice", 1)
+		reserve("ip:10.0.0.1", 1)
+		mockDB.EXPECT().GetUserAuthData(mockAny, "alice").Return(testPwdHash, "", nil)
+		mockDB.EXPECT().ReleaseLoginAttempt(mockAny, mockAny, false).Return(nil).Times(2)
+		mockDB.EXPECT().DeleteLoginAttempts(mockAny, "user:alice").Return(nil)
+		mockDB.EXPECT().UpdateUserSecrets(mockAny, mockAny).Return(db.ErrInternalDBError)
+
+		_, err := s.ChangePassword(ctx, req(testPassword))
+		assert.Error(t, err)
+	})
+}
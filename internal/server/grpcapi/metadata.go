@@ -6,6 +6,12 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// userAgentKey is a metadata field with client's user agent.
+const userAgentKey = "user-agent"
+
+// Maximum length of device's description.
+const maxDeviceLen = 256
+
 // mdValueFromContext returns value of field from provided context.
 func mdValueFromContext(ctx context.Context, field string) (string, bool) {
 	var none string
@@ -19,3 +25,19 @@ func mdValueFromContext(ctx context.Context, field string) (string, bool) {
 
 	return none, false
 }
+
+// deviceFromContext returns description of client's device, which is client's user agent.
+//
+// Long description is truncated, if user agent is unknown "unknown device" is returned.
+func deviceFromContext(ctx context.Context) string {
+	device, ok := mdValueFromContext(ctx, userAgentKey)
+	if !ok || device == "" {
+		return "unknown device"
+	}
+
+	if len(device) > maxDeviceLen {
+		device = device[:maxDeviceLen]
+	}
+
+	return device
+}
@@ -0,0 +1,315 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/logger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+)
+
+// OrganizationsService implements all GRPC-method for handling organizations requests and stores
+// service options. Used for registering with GRPC-server.
+type OrganizationsService struct {
+	pb.UnimplementedOrganizationsServer
+	db     db.DB
+	logger logger.L
+}
+
+// NewOrganizationsService a constructor for OrganizationsService.
+func NewOrganizationsService(db db.DB, l logger.L) *OrganizationsService {
+	return &OrganizationsService{
+		db:     db,
+		logger: l,
+	}
+}
+
+// CreateOrganization creates new organization, user becomes organization's administrator.
+func (s *OrganizationsService) CreateOrganization(ctx context.Context,
+	req *pb.CreateOrganizationRequest) (*pb.CreateOrganizationResponse, error) {
+
+	componentName := "OrganizationsService:CreateOrganization"
+	resp := new(pb.CreateOrganizationResponse)
+
+	if req.Name == "" || len(req.Okey) == 0 {
+		return nil, ErrMissedOrgInfo
+	}
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	org := &pb.Organization{Name: req.Name, Okey: req.Okey}
+
+	var err error
+	if resp.Id, err = s.db.CreateOrganization(ctx, req.Username, org); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully create organization '%s'", req.Name)
+
+	return resp, nil
+}
+
+// GetOrganizations returns user's organizations, including not accepted invites.
+func (s *OrganizationsService) GetOrganizations(ctx context.Context,
+	req *pb.GetOrganizationsRequest) (*pb.GetOrganizationsResponse, error) {
+
+	componentName := "OrganizationsService:GetOrganizations"
+	resp := new(pb.GetOrganizationsResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+	if resp.Organizations, err = s.db.GetOrganizations(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// UpdateOrganization renames organization, only administrators can rename organization.
+func (s *OrganizationsService) UpdateOrganization(ctx context.Context,
+	req *pb.UpdateOrganizationRequest) (*pb.UpdateOrganizationResponse, error) {
+
+	componentName := "OrganizationsService:UpdateOrganization"
+	resp := new(pb.UpdateOrganizationResponse)
+
+	if _, err := checkMembership(ctx, s.db, s.logger, componentName, req.Username, req.Id,
+		common.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.UpdateOrganization(ctx, &pb.Organization{Id: req.Id, Name: req.Name}); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully rename organization to '%s'", req.Name)
+
+	return resp, nil
+}
+
+// DeleteOrganization deletes organization with all its items, only administrators can delete organization.
+func (s *OrganizationsService) DeleteOrganization(ctx context.Context,
+	req *pb.DeleteOrganizationRequest) (*pb.DeleteOrganizationResponse, error) {
+
+	componentName := "OrganizationsService:DeleteOrganization"
+	resp := new(pb.DeleteOrganizationResponse)
+
+	if _, err := checkMembership(ctx, s.db, s.logger, componentName, req.Username, req.Id,
+		common.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.DeleteOrganization(ctx, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully delete organization %d", req.Id)
+
+	return resp, nil
+}
+
+// GetMembers returns organization's members and invited users.
+func (s *OrganizationsService) GetMembers(ctx context.Context,
+	req *pb.GetMembersRequest) (*pb.GetMembersResponse, error) {
+
+	componentName := "OrganizationsService:GetMembers"
+	resp := new(pb.GetMembersResponse)
+
+	if _, err := checkMembership(ctx, s.db, s.logger, componentName, req.Username, req.OrgId); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if resp.Members, err = s.db.GetMembers(ctx, req.OrgId); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
+// InviteMember invites user to organization, only administrators can invite users.
+//
+// Invite contains organization's key, encrypted by administrator with invited user's public key.
+func (s *OrganizationsService) InviteMember(ctx context.Context,
+	req *pb.InviteMemberRequest) (*pb.InviteMemberResponse, error) {
+
+	componentName := "OrganizationsService:InviteMember"
+	resp := new(pb.InviteMemberResponse)
+
+	if req.Member == nil {
+		return nil, ErrMissedMemberInfo
+	}
+
+	if _, err := checkMembership(ctx, s.db, s.logger, componentName, req.Username, req.OrgId,
+		common.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.AddMember(ctx, req.OrgId, req.Member); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully invite user '%s'", req.Member.Username)
+
+	return resp, nil
+}
+
+// AcceptInvite accepts user's invite to organization.
+func (s *OrganizationsService) AcceptInvite(ctx context.Context,
+	req *pb.AcceptInviteRequest) (*pb.AcceptInviteResponse, error) {
+
+	componentName := "OrganizationsService:AcceptInvite"
+	resp := new(pb.AcceptInviteResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.AcceptInvite(ctx, req.OrgId, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully join organization %d", req.OrgId)
+
+	return resp, nil
+}
+
+// UpdateMember changes member's role, only administrators can change roles.
+//
+// The last administrator can't be demoted.
+func (s *OrganizationsService) UpdateMember(ctx context.Context,
+	req *pb.UpdateMemberRequest) (*pb.UpdateMemberResponse, error) {
+
+	componentName := "OrganizationsService:UpdateMember"
+	resp := new(pb.UpdateMemberResponse)
+
+	if _, err := checkMembership(ctx, s.db, s.logger, componentName, req.Username, req.OrgId,
+		common.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if req.Role != common.OrgRoleAdmin {
+		if err := s.checkNotLastAdmin(ctx, componentName, req.OrgId, req.Member); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.db.UpdateMemberRole(ctx, req.OrgId, req.Member, req.Role); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully change role of '%s' to %s", req.Member, req.Role)
+
+	return resp, nil
+}
+
+// RemoveMember removes member or invite from organization.
+//
+// Administrators can remove any member, other users can only leave organization or decline invite.
+// The last administrator can't leave organization, organization should be deleted instead.
+func (s *OrganizationsService) RemoveMember(ctx context.Context,
+	req *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+
+	componentName := "OrganizationsService:RemoveMember"
+	resp := new(pb.RemoveMemberResponse)
+
+	if req.Member == req.Username {
+		if !userPerformSelfOperation(ctx, req.Username) {
+			return nil, permissionDeniedErr("access denied")
+		}
+	} else if _, err := checkMembership(ctx, s.db, s.logger, componentName, req.Username, req.OrgId,
+		common.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNotLastAdmin(ctx, componentName, req.OrgId, req.Member); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.RemoveMember(ctx, req.OrgId, req.Member); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("successfully remove '%s' from organization %d", req.Member, req.OrgId)
+
+	return resp, nil
+}
+
+// checkNotLastAdmin is a helper function which checks that member isn't the only administrator
+// of organization.
+func (s *OrganizationsService) checkNotLastAdmin(ctx context.Context, componentName string, orgID int64,
+	member string) error {
+	members, err := s.db.GetMembers(ctx, orgID)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return wrapErrorToClient(err)
+	}
+
+	var admins int
+
+	isAdmin := false
+
+	for _, m := range members {
+		if m.Role == common.OrgRoleAdmin && m.Accepted {
+			admins++
+
+			if m.Username == member {
+				isAdmin = true
+			}
+		}
+	}
+
+	if isAdmin && admins == 1 {
+		return ErrLastOrgAdmin
+	}
+
+	return nil
+}
+
+// checkMembership is a helper function which checks that user is accepted member of organization
+// with one of provided roles, any role is allowed if no roles are provided.
+//
+// Returns user's membership or error, which is ready for sending to client.
+func checkMembership(ctx context.Context, orgs db.OrganizationsManager, l logger.L, componentName string,
+	username string, orgID int64, roles ...string) (*db.Membership, error) {
+	if !userPerformSelfOperation(ctx, username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	membership, err := orgs.GetMembership(ctx, orgID, username)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrNotOrgMember
+		}
+
+		l.Warn(err, "db error", componentName)
+
+		return nil, wrapErrorToClient(err)
+	}
+
+	if !membership.Accepted {
+		return nil, ErrNotOrgMember
+	}
+
+	if len(roles) > 0 && !common.Contains(membership.Role, roles) {
+		return nil, permissionDeniedErr(fmt.Sprintf("operation is not allowed for organization's %s", membership.Role))
+	}
+
+	return membership, nil
+}
@@ -0,0 +1,342 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artfuldog/gophkeeper/internal/common"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mockdb"
+	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	testAdminMembership  = &db.Membership{Role: common.OrgRoleAdmin, Accepted: true, Vault: "org-vault"}
+	testMemberMembership = &db.Membership{Role: common.OrgRoleMember, Accepted: true, Vault: "org-vault"}
+)
+
+func TestNewOrganizationsService(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	assert.NotEmpty(t, NewOrganizationsService(mockdb.NewMockDB(mockCtrl), mocklogger.NewMockLogger()))
+	mockCtrl.Finish()
+}
+
+func TestOrganizationsService_CreateOrganization(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Missed organization's key", func(t *testing.T) {
+		req := &pb.CreateOrganizationRequest{Username: "CorrectUser", Name: "team"}
+		_, err := ts.OrgsClient.CreateOrganization(authCtx, req)
+		assert.ErrorIs(t, err, ErrMissedOrgInfo)
+	})
+
+	t.Run("Another user", func(t *testing.T) {
+		req := &pb.CreateOrganizationRequest{Username: "AnotherUser", Name: "team", Okey: []byte("okey")}
+		_, err := ts.OrgsClient.CreateOrganization(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().CreateOrganization(mockAny, "CorrectUser", mockAny).Return(int64(0), assert.AnError)
+		req := &pb.CreateOrganizationRequest{Username: "CorrectUser", Name: "team", Okey: []byte("okey")}
+		_, err := ts.OrgsClient.CreateOrganization(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully create", func(t *testing.T) {
+		ts.DB.EXPECT().CreateOrganization(mockAny, "CorrectUser", mockAny).DoAndReturn(
+			func(_ context.Context, _ string, org *pb.Organization) (int64, error) {
+				assert.Equal(t, "team", org.Name)
+				assert.Equal(t, []byte("okey"), org.Okey)
+
+				return int64(3), nil
+			})
+		req := &pb.CreateOrganizationRequest{Username: "CorrectUser", Name: "team", Okey: []byte("okey")}
+		resp, err := ts.OrgsClient.CreateOrganization(authCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Id)
+	})
+}
+
+func TestOrganizationsService_GetOrganizations(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Another user", func(t *testing.T) {
+		_, err := ts.OrgsClient.GetOrganizations(authCtx, &pb.GetOrganizationsRequest{Username: "AnotherUser"})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetOrganizations(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		_, err := ts.OrgsClient.GetOrganizations(authCtx, &pb.GetOrganizationsRequest{Username: "CorrectUser"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get", func(t *testing.T) {
+		orgs := []*pb.Organization{{Id: 1, Name: "team", Role: common.OrgRoleViewer}}
+		ts.DB.EXPECT().GetOrganizations(mockAny, "CorrectUser").Return(orgs, nil)
+		resp, err := ts.OrgsClient.GetOrganizations(authCtx, &pb.GetOrganizationsRequest{Username: "CorrectUser"})
+		require.NoError(t, err)
+		require.Len(t, resp.Organizations, 1)
+		assert.Equal(t, "team", resp.Organizations[0].Name)
+	})
+}
+
+func TestOrganizationsService_UpdateOrganization(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	req := &pb.UpdateOrganizationRequest{Username: "CorrectUser", Id: 1, Name: "renamed"}
+
+	t.Run("Not an administrator", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testMemberMembership, nil)
+		_, err := ts.OrgsClient.UpdateOrganization(authCtx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().UpdateOrganization(mockAny, mockAny).Return(assert.AnError)
+		_, err := ts.OrgsClient.UpdateOrganization(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully rename", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().UpdateOrganization(mockAny, mockAny).Return(nil)
+		_, err := ts.OrgsClient.UpdateOrganization(authCtx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestOrganizationsService_DeleteOrganization(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	req := &pb.DeleteOrganizationRequest{Username: "CorrectUser", Id: 1}
+
+	t.Run("Not a member", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(nil, db.ErrNotFound)
+		_, err := ts.OrgsClient.DeleteOrganization(authCtx, req)
+		assert.ErrorIs(t, err, ErrNotOrgMember)
+	})
+
+	t.Run("Not an administrator", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testMemberMembership, nil)
+		_, err := ts.OrgsClient.DeleteOrganization(authCtx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Successfully delete", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().DeleteOrganization(mockAny, int64(1)).Return(nil)
+		_, err := ts.OrgsClient.DeleteOrganization(authCtx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestOrganizationsService_GetMembers(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	req := &pb.GetMembersRequest{Username: "CorrectUser", OrgId: 1}
+
+	t.Run("Invite not accepted", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").
+			Return(&db.Membership{Role: common.OrgRoleAdmin}, nil)
+		_, err := ts.OrgsClient.GetMembers(authCtx, req)
+		assert.ErrorIs(t, err, ErrNotOrgMember)
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testMemberMembership, nil)
+		ts.DB.EXPECT().GetMembers(mockAny, int64(1)).Return(nil, assert.AnError)
+		_, err := ts.OrgsClient.GetMembers(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get", func(t *testing.T) {
+		members := []*pb.Member{{Username: "CorrectUser", Role: common.OrgRoleMember, Accepted: true}}
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testMemberMembership, nil)
+		ts.DB.EXPECT().GetMembers(mockAny, int64(1)).Return(members, nil)
+		resp, err := ts.OrgsClient.GetMembers(authCtx, req)
+		require.NoError(t, err)
+		assert.Len(t, resp.Members, 1)
+	})
+}
+
+func TestOrganizationsService_InviteMember(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	member := &pb.Member{Username: "invited", Role: common.OrgRoleViewer, Okey: []byte("okey")}
+
+	t.Run("Missed member", func(t *testing.T) {
+		_, err := ts.OrgsClient.InviteMember(authCtx, &pb.InviteMemberRequest{Username: "CorrectUser", OrgId: 1})
+		assert.ErrorIs(t, err, ErrMissedMemberInfo)
+	})
+
+	t.Run("Not an administrator", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testMemberMembership, nil)
+		req := &pb.InviteMemberRequest{Username: "CorrectUser", OrgId: 1, Member: member}
+		_, err := ts.OrgsClient.InviteMember(authCtx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Successfully invite", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().AddMember(mockAny, int64(1), mockAny).Return(nil)
+		req := &pb.InviteMemberRequest{Username: "CorrectUser", OrgId: 1, Member: member}
+		_, err := ts.OrgsClient.InviteMember(authCtx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestOrganizationsService_AcceptInvite(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Another user", func(t *testing.T) {
+		_, err := ts.OrgsClient.AcceptInvite(authCtx, &pb.AcceptInviteRequest{Username: "AnotherUser", OrgId: 1})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().AcceptInvite(mockAny, int64(1), "CorrectUser").Return(db.ErrOperationFailed)
+		_, err := ts.OrgsClient.AcceptInvite(authCtx, &pb.AcceptInviteRequest{Username: "CorrectUser", OrgId: 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully accept", func(t *testing.T) {
+		ts.DB.EXPECT().AcceptInvite(mockAny, int64(1), "CorrectUser").Return(nil)
+		_, err := ts.OrgsClient.AcceptInvite(authCtx, &pb.AcceptInviteRequest{Username: "CorrectUser", OrgId: 1})
+		require.NoError(t, err)
+	})
+}
+
+func TestOrganizationsService_UpdateMember(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	admins := []*pb.Member{
+		{Username: "CorrectUser", Role: common.OrgRoleAdmin, Accepted: true},
+		{Username: "member", Role: common.OrgRoleMember, Accepted: true},
+	}
+
+	t.Run("Demote last administrator", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().GetMembers(mockAny, int64(1)).Return(admins, nil)
+		req := &pb.UpdateMemberRequest{Username: "CorrectUser", OrgId: 1, Member: "CorrectUser",
+			Role: common.OrgRoleMember}
+		_, err := ts.OrgsClient.UpdateMember(authCtx, req)
+		assert.ErrorIs(t, err, ErrLastOrgAdmin)
+	})
+
+	t.Run("Promote member", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().UpdateMemberRole(mockAny, int64(1), "member", common.OrgRoleAdmin).Return(nil)
+		req := &pb.UpdateMemberRequest{Username: "CorrectUser", OrgId: 1, Member: "member", Role: common.OrgRoleAdmin}
+		_, err := ts.OrgsClient.UpdateMember(authCtx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("Demote member", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().GetMembers(mockAny, int64(1)).Return(admins, nil)
+		ts.DB.EXPECT().UpdateMemberRole(mockAny, int64(1), "member", common.OrgRoleViewer).Return(nil)
+		req := &pb.UpdateMemberRequest{Username: "CorrectUser", OrgId: 1, Member: "member", Role: common.OrgRoleViewer}
+		_, err := ts.OrgsClient.UpdateMember(authCtx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestOrganizationsService_RemoveMember(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+	members := []*pb.Member{
+		{Username: "admin", Role: common.OrgRoleAdmin, Accepted: true},
+		{Username: "CorrectUser", Role: common.OrgRoleMember, Accepted: true},
+	}
+
+	t.Run("Member removes another member", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testMemberMembership, nil)
+		req := &pb.RemoveMemberRequest{Username: "CorrectUser", OrgId: 1, Member: "admin"}
+		_, err := ts.OrgsClient.RemoveMember(authCtx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Last administrator leaves", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembers(mockAny, int64(1)).Return([]*pb.Member{
+			{Username: "CorrectUser", Role: common.OrgRoleAdmin, Accepted: true},
+		}, nil)
+		req := &pb.RemoveMemberRequest{Username: "CorrectUser", OrgId: 1, Member: "CorrectUser"}
+		_, err := ts.OrgsClient.RemoveMember(authCtx, req)
+		assert.ErrorIs(t, err, ErrLastOrgAdmin)
+	})
+
+	t.Run("Member leaves", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembers(mockAny, int64(1)).Return(members, nil)
+		ts.DB.EXPECT().RemoveMember(mockAny, int64(1), "CorrectUser").Return(nil)
+		req := &pb.RemoveMemberRequest{Username: "CorrectUser", OrgId: 1, Member: "CorrectUser"}
+		_, err := ts.OrgsClient.RemoveMember(authCtx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("Administrator removes member", func(t *testing.T) {
+		ts.DB.EXPECT().GetMembership(mockAny, int64(1), "CorrectUser").Return(testAdminMembership, nil)
+		ts.DB.EXPECT().GetMembers(mockAny, int64(1)).Return(members, nil)
+		ts.DB.EXPECT().RemoveMember(mockAny, int64(1), "member").Return(db.ErrOperationFailed)
+		req := &pb.RemoveMemberRequest{Username: "CorrectUser", OrgId: 1, Member: "member"}
+		_, err := ts.OrgsClient.RemoveMember(authCtx, req)
+		assert.Error(t, err)
+	})
+}
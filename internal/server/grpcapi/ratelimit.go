@@ -0,0 +1,275 @@
+package grpcapi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Period between sweeps of idle token buckets.
+const rateLimiterSweepPeriod = time.Minute
+
+// Rate limit's trailers.
+const (
+	rateLimitLimitKey     = "x-ratelimit-limit"
+	rateLimitRemainingKey = "x-ratelimit-remaining"
+	rateLimitResetKey     = "x-ratelimit-reset"
+	rateLimitRetryKey     = "retry-after"
+)
+
+// Rate represents limit of token bucket: bucket holds at most Burst tokens and is refilled with
+// PerSecond tokens every second, every request takes one token.
+type Rate struct {
+	PerSecond float64
+	Burst     uint32
+}
+
+// Enabled returns true if rate limits requests.
+func (r Rate) Enabled() bool {
+	return r.PerSecond > 0 && r.Burst > 0
+}
+
+// String returns rate in format "rate:burst".
+func (r Rate) String() string {
+	return fmt.Sprintf("%s:%d", strconv.FormatFloat(r.PerSecond, 'f', -1, 64), r.Burst)
+}
+
+// ParseRate parses rate in format "rate:burst", ex. "10:20" - 10 requests per second with bursts
+// up to 20 requests. Burst can be omitted, then it equals rate rounded up. Empty string means
+// disabled limit.
+func ParseRate(s string) (Rate, error) {
+	var rate Rate
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return rate, nil
+	}
+
+	perSecond, burst, hasBurst := strings.Cut(s, ":")
+
+	var err error
+	if rate.PerSecond, err = strconv.ParseFloat(perSecond, 64); err != nil || rate.PerSecond <= 0 ||
+		math.IsInf(rate.PerSecond, 0) {
+		return rate, fmt.Errorf("invalid rate '%s': must be positive number", s)
+	}
+
+	if !hasBurst {
+		rate.Burst = uint32(math.Min(math.Ceil(rate.PerSecond), math.MaxUint32))
+		return rate, nil
+	}
+
+	parsedBurst, err := strconv.ParseUint(burst, 10, 32)
+	if err != nil || parsedBurst == 0 {
+		return rate, fmt.Errorf("invalid rate '%s': burst must be positive integer", s)
+	}
+
+	rate.Burst = uint32(parsedBurst)
+
+	return rate, nil
+}
+
+// ParseMethodRates parses comma-separated list of methods' rates in format "Service/Method=rate:burst",
+// ex. "Items/GetItems=10:20,Items/CreateItem=2". Please see ParseRate for rate's format.
+func ParseMethodRates(s string) (map[string]Rate, error) {
+	rates := make(map[string]Rate)
+
+	for _, item := range strings.Split(s, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		method, rateStr, ok := strings.Cut(item, "=")
+		method = strings.TrimSpace(method)
+
+		if !ok || method == "" || strings.Count(method, "/") != 1 {
+			return nil, fmt.Errorf("invalid method's rate '%s': must be Service/Method=rate:burst", item)
+		}
+
+		rate, err := ParseRate(rateStr)
+		if err != nil {
+			return nil, err
+		}
+
+		if !rate.Enabled() {
+			return nil, fmt.Errorf("invalid method's rate '%s': missed rate", item)
+		}
+
+		rates[method] = rate
+	}
+
+	return rates, nil
+}
+
+// RateLimiter limits requests' rate with token buckets.
+//
+// Every client has bucket for all requests (user's rate) and bucket for every method with
+// method's rate. Client is user for authorized requests and client's address for others.
+// Request is allowed only if all client's buckets have tokens. Buckets are kept in memory,
+// so limits are applied per server's instance.
+type RateLimiter struct {
+	userRate    Rate
+	methodRates map[string]Rate
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	// Returns current time, replaced in tests.
+	now func() time.Time
+}
+
+// NewRateLimiter creates new RateLimiter with user's rate for all requests and rates for
+// particular methods (in format "Service/Method"). Disabled rates don't limit requests.
+func NewRateLimiter(userRate Rate, methodRates map[string]Rate) *RateLimiter {
+	return &RateLimiter{
+		userRate:    userRate,
+		methodRates: methodRates,
+		buckets:     make(map[string]*tokenBucket),
+		now:         time.Now,
+	}
+}
+
+// Enabled returns true if any of limiter's rates is enabled.
+func (l *RateLimiter) Enabled() bool {
+	if l.userRate.Enabled() {
+		return true
+	}
+
+	for _, rate := range l.methodRates {
+		if rate.Enabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rateLimitResult represents result of limiter's check of the most restrictive client's bucket.
+type rateLimitResult struct {
+	allowed bool
+	// Burst of bucket.
+	limit uint32
+	// Tokens left in bucket after request.
+	remaining uint32
+	// Period, after which bucket is full.
+	reset time.Duration
+	// Period, after which next request is allowed. Zero for allowed request.
+	retryAfter time.Duration
+}
+
+// allow checks client's request of method (in format "Service/Method") and takes tokens from
+// client's buckets if request is allowed. Returns nil if request isn't limited.
+func (l *RateLimiter) allow(client string, method string) *rateLimitResult {
+	type limitedBucket struct {
+		key  string
+		rate Rate
+	}
+
+	var limited []limitedBucket
+
+	if l.userRate.Enabled() {
+		limited = append(limited, limitedBucket{key: client, rate: l.userRate})
+	}
+
+	if rate := l.methodRates[method]; rate.Enabled() {
+		limited = append(limited, limitedBucket{key: client + "|" + method, rate: rate})
+	}
+
+	if len(limited) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	buckets := make([]*tokenBucket, 0, len(limited))
+	allowed := true
+
+	for _, lb := range limited {
+		b, ok := l.buckets[lb.key]
+		if !ok {
+			b = &tokenBucket{rate: lb.rate, tokens: float64(lb.rate.Burst), updated: now}
+			l.buckets[lb.key] = b
+		}
+
+		b.refill(now)
+		buckets = append(buckets, b)
+
+		if b.tokens < 1 {
+			allowed = false
+		}
+	}
+
+	var result *rateLimitResult
+
+	for _, b := range buckets {
+		if allowed {
+			b.tokens--
+		}
+
+		bucketResult := &rateLimitResult{
+			allowed:   allowed,
+			limit:     b.rate.Burst,
+			remaining: uint32(b.tokens),
+			reset:     b.untilTokens(float64(b.rate.Burst)),
+		}
+
+		if !allowed {
+			bucketResult.retryAfter = b.untilTokens(1)
+		}
+
+		if result == nil || (allowed && bucketResult.remaining < result.remaining) ||
+			(!allowed && bucketResult.retryAfter > result.retryAfter) {
+			result = bucketResult
+		}
+	}
+
+	return result
+}
+
+// sweep is a helper function, which periodically deletes full buckets, i.e. buckets of idle
+// clients. Caller must hold the lock.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimiterSweepPeriod {
+		return
+	}
+
+	for key, b := range l.buckets {
+		b.refill(now)
+
+		if b.tokens >= float64(b.rate.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastSweep = now
+}
+
+// tokenBucket represents client's token bucket.
+type tokenBucket struct {
+	rate    Rate
+	tokens  float64
+	updated time.Time
+}
+
+// refill adds tokens for period since last update.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(float64(b.rate.Burst), b.tokens+elapsed.Seconds()*b.rate.PerSecond)
+		b.updated = now
+	}
+}
+
+// untilTokens returns period, after which bucket has provided number of tokens.
+func (b *tokenBucket) untilTokens(tokens float64) time.Duration {
+	if b.tokens >= tokens {
+		return 0
+	}
+
+	return time.Duration((tokens - b.tokens) / b.rate.PerSecond * float64(time.Second))
+}
@@ -0,0 +1,138 @@
+package grpcapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Rate
+		wantErr bool
+	}{
+		{name: "Empty rate", s: "", want: Rate{}},
+		{name: "Rate with burst", s: "10:20", want: Rate{PerSecond: 10, Burst: 20}},
+		{name: "Fractional rate", s: " 0.5 ", want: Rate{PerSecond: 0.5, Burst: 1}},
+		{name: "Rate without burst", s: "2.5", want: Rate{PerSecond: 2.5, Burst: 3}},
+		{name: "Invalid rate", s: "fast", wantErr: true},
+		{name: "Negative rate", s: "-1:10", wantErr: true},
+		{name: "Zero burst", s: "1:0", wantErr: true},
+		{name: "Invalid burst", s: "1:x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRate(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, "0.5:1", Rate{PerSecond: 0.5, Burst: 1}.String())
+}
+
+func TestParseMethodRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    map[string]Rate
+		wantErr bool
+	}{
+		{name: "Empty list", s: "", want: map[string]Rate{}},
+		{
+			name: "Methods' rates",
+			s:    "Items/GetItems=10:20, Items/CreateItem=2,",
+			want: map[string]Rate{
+				"Items/GetItems":   {PerSecond: 10, Burst: 20},
+				"Items/CreateItem": {PerSecond: 2, Burst: 2},
+			},
+		},
+		{name: "Missed service", s: "GetItems=10", wantErr: true},
+		{name: "Missed rate", s: "Items/GetItems", wantErr: true},
+		{name: "Empty rate", s: "Items/GetItems=", wantErr: true},
+		{name: "Invalid rate", s: "Items/GetItems=0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMethodRates(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRateLimiter_allow(t *testing.T) {
+	limiter := NewRateLimiter(Rate{PerSecond: 1, Burst: 3},
+		map[string]Rate{"Items/CreateItem": {PerSecond: 1, Burst: 1}})
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.Enabled())
+	assert.False(t, NewRateLimiter(Rate{}, nil).Enabled())
+	assert.True(t, NewRateLimiter(Rate{}, map[string]Rate{"Items/GetItems": {PerSecond: 1, Burst: 1}}).Enabled())
+	assert.Nil(t, NewRateLimiter(Rate{}, nil).allow("user:alice", "Items/GetItems"), "request isn't limited")
+
+	t.Run("User's rate", func(t *testing.T) {
+		for remaining := uint32(2); remaining > 0; remaining-- {
+			result := limiter.allow("user:alice", "Items/GetItems")
+			require.True(t, result.allowed)
+			assert.Equal(t, uint32(3), result.limit)
+			assert.Equal(t, remaining, result.remaining)
+		}
+	})
+
+	t.Run("Method's rate", func(t *testing.T) {
+		result := limiter.allow("user:bob", "Items/CreateItem")
+		require.True(t, result.allowed)
+		assert.Equal(t, uint32(1), result.limit, "the most restrictive bucket")
+		assert.Equal(t, uint32(0), result.remaining)
+
+		result = limiter.allow("user:alice", "Items/CreateItem")
+		assert.True(t, result.allowed, "other user's bucket")
+		assert.Equal(t, uint32(0), result.remaining)
+	})
+
+	t.Run("Rejected request", func(t *testing.T) {
+		result := limiter.allow("user:alice", "Items/GetItems")
+		require.False(t, result.allowed)
+		assert.Equal(t, time.Second, result.retryAfter)
+		assert.Equal(t, 3*time.Second, result.reset)
+
+		result = limiter.allow("user:alice", "Items/CreateItem")
+		assert.False(t, result.allowed)
+	})
+
+	t.Run("Rejected request doesn't take tokens", func(t *testing.T) {
+		now = now.Add(time.Second)
+
+		result := limiter.allow("user:alice", "Items/CreateItem")
+		require.True(t, result.allowed)
+
+		result = limiter.allow("user:alice", "Items/GetItems")
+		assert.False(t, result.allowed, "user's token is taken by allowed request")
+	})
+
+	t.Run("Idle buckets are deleted", func(t *testing.T) {
+		now = now.Add(rateLimiterSweepPeriod)
+
+		result := limiter.allow("user:carol", "Items/GetItems")
+		require.True(t, result.allowed)
+		assert.Len(t, limiter.buckets, 1)
+	})
+}
@@ -2,11 +2,15 @@ package grpcapi
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/artfuldog/gophkeeper/internal/crypt"
 	"github.com/artfuldog/gophkeeper/internal/logger"
@@ -22,15 +26,50 @@ type UsersService struct {
 	db         db.DB
 	logger     logger.L
 	authorizer authorizer.A
+
+	// Valid period of refresh tokens, refresh token's session expires with it.
+	refreshTokenPeriod time.Duration
+	// Parameters of failed logins' throttling, nil if throttling is disabled.
+	loginThrottle *LoginThrottle
 }
 
+// Page sizes of audit events' list.
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+// Default valid period of refresh tokens.
+const defaultRefreshTokenPeriod = 30 * 24 * time.Hour
+
 // NewGRPCService a constructor for GRPCService.
 func NewUsersService(db db.DB, l logger.L, a authorizer.A) *UsersService {
 	return &UsersService{
 		db:         db,
 		logger:     l,
 		authorizer: a,
+
+		refreshTokenPeriod: defaultRefreshTokenPeriod,
+	}
+}
+
+// SetRefreshTokenPeriod sets valid period of refresh tokens. Zero value keeps current period.
+func (s *UsersService) SetRefreshTokenPeriod(period time.Duration) *UsersService {
+	if period > 0 {
+		s.refreshTokenPeriod = period
 	}
+
+	return s
+}
+
+// SetLoginThrottle enables throttling of failed logins. Throttling stays disabled, if all
+// throttle's checks are disabled.
+func (s *UsersService) SetLoginThrottle(throttle LoginThrottle) *UsersService {
+	if throttle.Enabled() {
+		s.loginThrottle = &throttle
+	}
+
+	return s
 }
 
 // CreateUser creates new user.
@@ -42,6 +81,10 @@ func (s *UsersService) CreateUser(ctx context.Context, req *pb.CreateUserRequest
 		return nil, ErrMissedUserInfo
 	}
 
+	if db.IsOrgVault(req.User.Username) {
+		return nil, ErrReservedUsername
+	}
+
 	var err error
 
 	TOTPKey := new(crypt.TOTPKey)
@@ -105,6 +148,26 @@ func (s *UsersService) GetRevision(ctx context.Context, req *pb.GetRevisionReque
 	return resp, nil
 }
 
+// GetServerLimits returns server's limits and user's current usage.
+func (s *UsersService) GetServerLimits(ctx context.Context,
+	req *pb.GetServerLimitsRequest) (*pb.GetServerLimitsResponse, error) {
+
+	componentName := "UsersService:GetServerLimits"
+	resp := new(pb.GetServerLimitsResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	var err error
+	if resp.ServerLimits, err = s.getServerLimits(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	return resp, nil
+}
+
 // UpdateUser updates user's information.
 func (s *UsersService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 	componentName := "UsersService:UpdateUser"
@@ -154,14 +217,37 @@ func (s *UsersService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest
 // with SecondFactor flag and nil error. Handling this situation should be implemented on
 // client side.
 //
-// After successful login responses with Token, encryption key and server's limits.
+// Locked user can't log in. Token carries user's administrator role.
+//
+// If login throttling is enabled, every attempt is counted as failed before password check and
+// forgotten only after successful check, so wrong password, wrong verification code and unknown
+// username remain counted and parallel attempts can't exceed threshold. Login locked after too
+// many failed attempts is rejected with ResourceExhausted status before password check,
+// remaining lockout period is returned in RetryInfo detail.
+//
+// After successful login responses with Token, refresh token, encryption key, key pair and server's limits.
 func (s *UsersService) UserLogin(ctx context.Context, req *pb.UserLoginRequest) (*pb.UserLoginResponse, error) {
 	componentName := "UsersService:UserLogin"
 	resp := new(pb.UserLoginResponse)
 
+	reserved, retryAfter, err := s.reserveLoginAttempt(ctx, req.Username, componentName)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if retryAfter > 0 {
+		return nil, loginThrottledErr(retryAfter)
+	}
+
 	pwdHash, optKey, err := s.db.GetUserAuthData(ctx, req.Username)
 	if err != nil {
+		if !errors.Is(err, db.ErrNotFound) {
+			s.releaseLoginAttempt(ctx, reserved, componentName)
+		}
+
 		s.logger.Warn(err, "db error", componentName)
+
 		return nil, wrapErrorToClient(err)
 	}
 
@@ -171,7 +257,10 @@ func (s *UsersService) UserLogin(ctx context.Context, req *pb.UserLoginRequest)
 
 	if optKey != "" {
 		if req.OtpCode == "" {
+			s.releaseLoginAttempt(ctx, reserved, componentName)
+
 			resp.SecondFactor = true
+
 			return resp, nil
 		}
 
@@ -180,10 +269,24 @@ func (s *UsersService) UserLogin(ctx context.Context, req *pb.UserLoginRequest)
 		}
 	}
 
+	s.releaseLoginAttempt(ctx, reserved, componentName)
+	s.resetLoginFailures(ctx, req.Username, componentName)
+
+	state, err := s.db.GetUserState(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if state.Locked {
+		return nil, ErrUserLocked
+	}
+
 	fields := authorizer.AuthFields{
 		Username: req.Username,
+		IsAdmin:  state.IsAdmin,
 	}
-	if resp.Token, err = s.authorizer.CreateToken(fields); err != nil {
+	if resp.Token, resp.RefreshToken, err = s.issueToken(ctx, fields, componentName); err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate token")
 	}
 
@@ -191,12 +294,386 @@ func (s *UsersService) UserLogin(ctx context.Context, req *pb.UserLoginRequest)
 		return nil, status.Error(codes.Internal, "failed to fetch encryption key")
 	}
 
-	resp.ServerLimits = new(pb.ServerLimits)
-	resp.ServerLimits.MaxSecretSize = int32(s.db.GetMaxSecretSize())
+	if resp.PublicKey, resp.PrivateKey, err = s.db.GetUserKeys(ctx, req.Username); err != nil {
+		return nil, status.Error(codes.Internal, "failed to fetch key pair")
+	}
+
+	if resp.ServerLimits, err = s.getServerLimits(ctx, req.Username); err != nil {
+		return nil, status.Error(codes.Internal, "failed to fetch server limits")
+	}
+
+	return resp, nil
+}
+
+// ChangePassword changes user's password and encryption key.
+//
+// Current password and verification code (if 2-factor authorization is enabled) are checked
+// before change. Encryption key must be re-encrypted by client. All previously issued tokens
+// are revoked, new token with same administrator role and new refresh token are returned
+// in response.
+//
+// Wrong password and wrong verification code are counted as failed login attempts, so change
+// is rejected with ResourceExhausted status while user's or client's logins are locked.
+func (s *UsersService) ChangePassword(ctx context.Context,
+	req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	componentName := "UsersService:ChangePassword"
+	resp := new(pb.ChangePasswordResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if req.NewPwdhash == "" || len(req.Ekey) == 0 {
+		return nil, ErrMissedUserSecrets
+	}
+
+	reserved, retryAfter, err := s.reserveLoginAttempt(ctx, req.Username, componentName)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if retryAfter > 0 {
+		return nil, loginThrottledErr(retryAfter)
+	}
+
+	pwdHash, optKey, err := s.db.GetUserAuthData(ctx, req.Username)
+	if err != nil {
+		s.releaseLoginAttempt(ctx, reserved, componentName)
+		s.logger.Warn(err, "db error", componentName)
+
+		return nil, wrapErrorToClient(err)
+	}
+
+	if !crypt.CheckPasswordHashStr(req.Password, pwdHash) {
+		return nil, status.Error(codes.PermissionDenied, "wrong password")
+	}
+
+	if optKey != "" && !crypt.ValidateTOTP(req.OtpCode, optKey) {
+		return nil, ErrWrongVerificationCode
+	}
+
+	s.releaseLoginAttempt(ctx, reserved, componentName)
+	s.resetLoginFailures(ctx, req.Username, componentName)
+
+	user := &pb.User{
+		Username: req.Username,
+		Pwdhash:  &req.NewPwdhash,
+		Ekey:     req.Ekey,
+	}
+	if err := s.db.UpdateUserSecrets(ctx, user); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if err := s.db.DeleteSessions(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "failed to delete revoked sessions", componentName)
+	}
+
+	fields := authorizer.AuthFields{
+		Username: req.Username,
+		IsAdmin:  payloadFromContext(ctx).IsAdmin,
+	}
+	if resp.Token, resp.RefreshToken, err = s.issueToken(ctx, fields, componentName); err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+
+	s.logger.Info(fmt.Sprintf("user '%s' changed password", req.Username), componentName)
+	resp.Info = fmt.Sprintf("successfully change password for user '%s'", req.Username)
 
 	return resp, nil
 }
 
+// RefreshToken issues new token for session of provided refresh token.
+//
+// Refresh token is rotated: new refresh token is returned in response and previous one becomes
+// invalid. Method doesn't require authorization, so expired token is replaced without user's
+// credentials. Refresh tokens of locked user and of sessions revoked by time (ex. after
+// password change) are rejected.
+func (s *UsersService) RefreshToken(ctx context.Context,
+	req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	componentName := "UsersService:RefreshToken"
+	resp := new(pb.RefreshTokenResponse)
+
+	if req.Username == "" || req.RefreshToken == "" {
+		return nil, ErrMissedRefreshToken
+	}
+
+	session, err := s.db.GetSessionByRefreshHash(ctx, req.Username, refreshTokenHash(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		s.logger.Warn(err, "db error", componentName)
+
+		return nil, wrapErrorToClient(err)
+	}
+
+	state, err := s.db.GetUserState(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if state.Locked {
+		return nil, ErrUserLocked
+	}
+
+	revoked, err := s.db.GetUserTokensRevoked(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if session.GetCreated().AsTime().Before(revoked) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	fields := authorizer.AuthFields{
+		Username: req.Username,
+		IsAdmin:  state.IsAdmin,
+	}
+
+	token, payload, err := s.authorizer.CreateToken(fields)
+	if err != nil {
+		s.logger.Warn(err, "failed to create token", componentName)
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+
+	refreshToken := newRefreshToken()
+	rotated := &pb.Session{
+		Id:          payload.ID.String(),
+		ClientIp:    clientIPFromContext(ctx),
+		Expires:     timestamppb.New(payload.IssuedAt.Add(s.refreshTokenPeriod)),
+		RefreshHash: refreshTokenHash(refreshToken),
+	}
+	if err := s.db.RotateSession(ctx, req.Username, session.Id, rotated); err != nil {
+		// Session was revoked or refreshed by concurrent request.
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+
+		s.logger.Warn(err, "db error", componentName)
+
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Token = token
+	resp.RefreshToken = refreshToken
+
+	return resp, nil
+}
+
+// GetPublicKey returns user's public key, which is used for sharing items with user.
+//
+// Public key of any user is available for every authorized user.
+func (s *UsersService) GetPublicKey(ctx context.Context, req *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	componentName := "UsersService:GetPublicKey"
+	resp := new(pb.GetPublicKeyResponse)
+
+	var err error
+	if resp.PublicKey, _, err = s.db.GetUserKeys(ctx, req.Username); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if len(resp.PublicKey) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "user '%s' hasn't public key", req.Username)
+	}
+
+	return resp, nil
+}
+
+// ListAuditEvents returns page of user's audit events newest first.
+//
+// Events are available for user himself and for administrators. Next page's token is ID of
+// last event in page, zero token means that there are no more events.
+func (s *UsersService) ListAuditEvents(ctx context.Context,
+	req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	componentName := "UsersService:ListAuditEvents"
+	resp := new(pb.ListAuditEventsResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) && !payloadFromContext(ctx).IsAdmin {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	pageSize := uint32(defaultAuditPageSize)
+	if req.PageSize > 0 {
+		pageSize = uint32(req.PageSize)
+	}
+
+	if pageSize > maxAuditPageSize {
+		pageSize = maxAuditPageSize
+	}
+
+	events, err := s.db.GetAuditEvents(ctx, req.Username, req.PageToken, pageSize+1)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	if uint32(len(events)) > pageSize {
+		events = events[:pageSize]
+		resp.NextPageToken = events[len(events)-1].Id
+	}
+
+	resp.Events = events
+
+	return resp, nil
+}
+
+// Logout revokes session of request's token.
+func (s *UsersService) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	componentName := "UsersService:Logout"
+	resp := new(pb.LogoutResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if err := s.db.DeleteSession(ctx, req.Username, payloadFromContext(ctx).ID.String()); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = fmt.Sprintf("user '%s' logged out", req.Username)
+
+	return resp, nil
+}
+
+// ListSessions returns user's active sessions, last seen first.
+//
+// Session of request's token is marked as current. Sessions of tokens revoked by time (ex. after
+// password change) are skipped.
+func (s *UsersService) ListSessions(ctx context.Context,
+	req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	componentName := "UsersService:ListSessions"
+	resp := new(pb.ListSessionsResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	revoked, err := s.db.GetUserTokensRevoked(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	sessions, err := s.db.GetSessions(ctx, req.Username)
+	if err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	current := payloadFromContext(ctx).ID.String()
+
+	for _, session := range sessions {
+		if session.GetCreated().AsTime().Before(revoked) {
+			continue
+		}
+
+		session.Current = session.Id == current
+		resp.Sessions = append(resp.Sessions, session)
+	}
+
+	return resp, nil
+}
+
+// RevokeSession revokes user's session or all user's sessions (including current one).
+func (s *UsersService) RevokeSession(ctx context.Context,
+	req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	componentName := "UsersService:RevokeSession"
+	resp := new(pb.RevokeSessionResponse)
+
+	if !userPerformSelfOperation(ctx, req.Username) {
+		return nil, permissionDeniedErr("access denied")
+	}
+
+	if req.All {
+		if err := s.db.DeleteSessions(ctx, req.Username); err != nil {
+			s.logger.Warn(err, "db error", componentName)
+			return nil, wrapErrorToClient(err)
+		}
+
+		resp.Info = fmt.Sprintf("all sessions of user '%s' are revoked", req.Username)
+
+		return resp, nil
+	}
+
+	if req.Id == "" {
+		return nil, ErrMissedSessionInfo
+	}
+
+	if err := s.db.DeleteSession(ctx, req.Username, req.Id); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return nil, wrapErrorToClient(err)
+	}
+
+	resp.Info = "session is revoked"
+
+	return resp, nil
+}
+
+// issueToken is a helper function, which creates token with refresh token and records their
+// session with client's device and address.
+//
+// Session expires with refresh token, token itself expires earlier according authorizer's settings.
+func (s *UsersService) issueToken(ctx context.Context, fields authorizer.AuthFields,
+	componentName string) (string, string, error) {
+	token, payload, err := s.authorizer.CreateToken(fields)
+	if err != nil {
+		s.logger.Warn(err, "failed to create token", componentName)
+		return "", "", err
+	}
+
+	refreshToken := newRefreshToken()
+	session := &pb.Session{
+		Id:          payload.ID.String(),
+		Device:      deviceFromContext(ctx),
+		ClientIp:    clientIPFromContext(ctx),
+		Created:     timestamppb.New(payload.IssuedAt),
+		Expires:     timestamppb.New(payload.IssuedAt.Add(s.refreshTokenPeriod)),
+		RefreshHash: refreshTokenHash(refreshToken),
+	}
+	if err := s.db.CreateSession(ctx, fields.Username, session); err != nil {
+		s.logger.Warn(err, "db error", componentName)
+		return "", "", err
+	}
+
+	return token, refreshToken, nil
+}
+
+// newRefreshToken is a helper function, which generates random refresh token.
+func newRefreshToken() string {
+	return base64.RawURLEncoding.EncodeToString(crypt.GenerateRandomKey32())
+}
+
+// refreshTokenHash is a helper function, which returns hash of refresh token. Only hashes of
+// refresh tokens are stored by server.
+func refreshTokenHash(refreshToken string) string {
+	return hex.EncodeToString(crypt.GetSHA256hash(refreshToken))
+}
+
+// getServerLimits is a helper function which prepares server's limits with user's current usage.
+func (s *UsersService) getServerLimits(ctx context.Context, username string) (*pb.ServerLimits, error) {
+	usage, err := s.db.GetUserUsage(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := s.db.GetUserQuota()
+
+	return &pb.ServerLimits{
+		MaxSecretSize: int32(s.db.GetMaxSecretSize()),
+		MaxItems:      int64(quota.MaxItems),
+		MaxBytes:      int64(quota.MaxBytes),
+		Items:         usage.Items,
+		Bytes:         usage.Bytes,
+	}, nil
+}
+
 // userPerformSelfOperation is helper function which checks if user want to preform operation with his/her
 // own account.
 func userPerformSelfOperation(ctx context.Context, reqUserName string) bool {
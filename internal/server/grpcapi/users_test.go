@@ -1,7 +1,9 @@
 package grpcapi
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/common"
 	"github.com/artfuldog/gophkeeper/internal/crypt"
@@ -9,10 +11,18 @@ import (
 	"github.com/artfuldog/gophkeeper/internal/mocks/mockdb"
 	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
 	"github.com/artfuldog/gophkeeper/internal/pb"
+	"github.com/artfuldog/gophkeeper/internal/server/authorizer"
+	"github.com/artfuldog/gophkeeper/internal/server/db"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewUsersService(t *testing.T) {
@@ -22,6 +32,13 @@ func TestNewUsersService(t *testing.T) {
 	mockCtrl.Finish()
 }
 
+func TestUsersService_SetRefreshTokenPeriod(t *testing.T) {
+	s := NewUsersService(nil, mocklogger.NewMockLogger(), nil)
+	assert.Equal(t, defaultRefreshTokenPeriod, s.refreshTokenPeriod)
+	assert.Equal(t, time.Hour, s.SetRefreshTokenPeriod(time.Hour).refreshTokenPeriod)
+	assert.Equal(t, time.Hour, s.SetRefreshTokenPeriod(0).refreshTokenPeriod, "zero keeps previous period")
+}
+
 func TestUsersService_CreateUser(t *testing.T) {
 	ts, tsErr := NewTestSuiteGRPCServer(t)
 	if tsErr != nil {
@@ -35,6 +52,12 @@ func TestUsersService_CreateUser(t *testing.T) {
 		assert.ErrorIs(t, err, ErrMissedUserInfo)
 	})
 
+	t.Run("Reserved user name", func(t *testing.T) {
+		req := &pb.CreateUserRequest{User: &pb.User{Username: "org-team"}}
+		_, err := ts.UsersClient.CreateUser(testCtx, req)
+		assert.ErrorIs(t, err, ErrReservedUsername)
+	})
+
 	t.Run("TOTP key generation error", func(t *testing.T) {
 		req := &pb.CreateUserRequest{
 			User: &pb.User{
@@ -144,7 +167,7 @@ func TestUsersService_GetRevision(t *testing.T) {
 		req := &pb.GetRevisionRequest{
 			Username: "CorrectUser",
 		}
-		ts.DB.EXPECT().GetUserRevision(mockAny, mockAny).Return(nil, assert.AnError)
+		ts.DB.EXPECT().GetUserRevision(mockAny, mockAny).Return(int64(0), assert.AnError)
 		_, err := ts.UsersClient.GetRevision(authCtx, req)
 		assert.Error(t, err)
 	})
@@ -153,7 +176,7 @@ func TestUsersService_GetRevision(t *testing.T) {
 		req := &pb.GetRevisionRequest{
 			Username: "CorrectUser",
 		}
-		ts.DB.EXPECT().GetUserRevision(mockAny, mockAny).Return([]byte("revision"), nil)
+		ts.DB.EXPECT().GetUserRevision(mockAny, mockAny).Return(int64(1), nil)
 		resp, err := ts.UsersClient.GetRevision(authCtx, req)
 		require.NoError(t, err)
 		assert.NotEmpty(t, resp)
@@ -306,6 +329,30 @@ func TestUsersService_UserLogin(t *testing.T) {
 		assert.ErrorIs(t, err, ErrWrongVerificationCode)
 	})
 
+	t.Run("Get user state error", func(t *testing.T) {
+		req := &pb.UserLoginRequest{
+			Username: "CorrectUser",
+			Password: testPassword,
+		}
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "", nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(nil, assert.AnError)
+
+		_, err := ts.UsersClient.UserLogin(testCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Locked user", func(t *testing.T) {
+		req := &pb.UserLoginRequest{
+			Username: "CorrectUser",
+			Password: testPassword,
+		}
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "", nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{Locked: true}, nil)
+
+		_, err := ts.UsersClient.UserLogin(testCtx, req)
+		assert.ErrorIs(t, err, ErrUserLocked)
+	})
+
 	t.Run("Create token error", func(t *testing.T) {
 		verCode, err := crypt.GenerateVerificationCode("CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2")
 		require.NoError(t, err)
@@ -315,7 +362,25 @@ func TestUsersService_UserLogin(t *testing.T) {
 			OtpCode:  verCode,
 		}
 		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2", nil)
-		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("", assert.AnError)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{}, nil)
+		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("", nil, assert.AnError)
+
+		_, err = ts.UsersClient.UserLogin(testCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Create session error", func(t *testing.T) {
+		verCode, err := crypt.GenerateVerificationCode("CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2")
+		require.NoError(t, err)
+		req := &pb.UserLoginRequest{
+			Username: "CorrectUser",
+			Password: testPassword,
+			OtpCode:  verCode,
+		}
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2", nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{}, nil)
+		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("token", &authorizer.Payload{Username: "CorrectUser"}, nil)
+		ts.DB.EXPECT().CreateSession(mockAny, "CorrectUser", mockAny).Return(assert.AnError)
 
 		_, err = ts.UsersClient.UserLogin(testCtx, req)
 		assert.Error(t, err)
@@ -330,13 +395,34 @@ func TestUsersService_UserLogin(t *testing.T) {
 			OtpCode:  verCode,
 		}
 		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2", nil)
-		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("token", nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{}, nil)
+		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("token", &authorizer.Payload{Username: "CorrectUser"}, nil)
+		ts.DB.EXPECT().CreateSession(mockAny, "CorrectUser", mockAny).Return(nil)
 		ts.DB.EXPECT().GetUserEKey(mockAny, "CorrectUser").Return(nil, assert.AnError)
 
 		_, err = ts.UsersClient.UserLogin(testCtx, req)
 		assert.Error(t, err)
 	})
 
+	t.Run("Get user key pair error", func(t *testing.T) {
+		verCode, err := crypt.GenerateVerificationCode("CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2")
+		require.NoError(t, err)
+		req := &pb.UserLoginRequest{
+			Username: "CorrectUser",
+			Password: testPassword,
+			OtpCode:  verCode,
+		}
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2", nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{}, nil)
+		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("token", &authorizer.Payload{Username: "CorrectUser"}, nil)
+		ts.DB.EXPECT().CreateSession(mockAny, "CorrectUser", mockAny).Return(nil)
+		ts.DB.EXPECT().GetUserEKey(mockAny, "CorrectUser").Return([]byte("encryption key"), nil)
+		ts.DB.EXPECT().GetUserKeys(mockAny, "CorrectUser").Return(nil, nil, assert.AnError)
+
+		_, err = ts.UsersClient.UserLogin(testCtx, req)
+		assert.Error(t, err)
+	})
+
 	t.Run("Succesfull two-factor login", func(t *testing.T) {
 		verCode, err := crypt.GenerateVerificationCode("CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2")
 		require.NoError(t, err)
@@ -346,13 +432,448 @@ func TestUsersService_UserLogin(t *testing.T) {
 			OtpCode:  verCode,
 		}
 		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2", nil)
-		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("token", nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{IsAdmin: true}, nil)
+		payload, err := authorizer.NewPayload("CorrectUser", true, time.Minute)
+		require.NoError(t, err)
+		ts.Authorizer.EXPECT().CreateToken(authorizer.AuthFields{Username: "CorrectUser", IsAdmin: true}).
+			Return("token", payload, nil)
+		var session *pb.Session
+		ts.DB.EXPECT().CreateSession(mockAny, "CorrectUser", mockAny).
+			DoAndReturn(func(_ context.Context, _ string, s *pb.Session) error {
+				session = s
+				return nil
+			})
 		ts.DB.EXPECT().GetUserEKey(mockAny, "CorrectUser").Return([]byte("encryption key"), nil)
+		ts.DB.EXPECT().GetUserKeys(mockAny, "CorrectUser").Return([]byte("public"), []byte("private"), nil)
+		ts.DB.EXPECT().GetUserUsage(mockAny, "CorrectUser").Return(&db.Usage{Items: 3, Bytes: 100}, nil)
+		ts.DB.EXPECT().GetUserQuota().Return(db.Quota{MaxItems: 10})
 		ts.DB.EXPECT().GetMaxSecretSize().Return(uint32(12345))
 
 		resp, err := ts.UsersClient.UserLogin(testCtx, req)
 		require.NoError(t, err)
 		assert.Equal(t, resp.Ekey, []byte("encryption key"))
+		assert.Equal(t, []byte("public"), resp.PublicKey)
+		assert.Equal(t, []byte("private"), resp.PrivateKey)
 		assert.Equal(t, resp.ServerLimits.MaxSecretSize, int32(12345))
+		assert.Equal(t, int64(10), resp.ServerLimits.MaxItems)
+		assert.Equal(t, int64(3), resp.ServerLimits.Items)
+
+		require.NotNil(t, session)
+		assert.Equal(t, payload.ID.String(), session.Id)
+		assert.Contains(t, session.Device, "grpc-go")
+		assert.NotEmpty(t, session.ClientIp)
+		assert.True(t, session.Expires.AsTime().Equal(payload.IssuedAt.Add(defaultRefreshTokenPeriod)),
+			"session expires with refresh token")
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.Equal(t, refreshTokenHash(resp.RefreshToken), session.RefreshHash)
+	})
+}
+
+func TestUsersService_GetServerLimits(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	t.Run("Missed Context", func(t *testing.T) {
+		req := &pb.GetServerLimitsRequest{}
+		_, err := ts.UsersClient.GetServerLimits(testCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		req := &pb.GetServerLimitsRequest{Username: "CorrectUser"}
+		ts.DB.EXPECT().GetUserUsage(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		_, err := ts.UsersClient.GetServerLimits(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully get limits", func(t *testing.T) {
+		req := &pb.GetServerLimitsRequest{Username: "CorrectUser"}
+		ts.DB.EXPECT().GetUserUsage(mockAny, "CorrectUser").Return(&db.Usage{Items: 3, Bytes: 100}, nil)
+		ts.DB.EXPECT().GetUserQuota().Return(db.Quota{MaxItems: 10, MaxBytes: 1000})
+		ts.DB.EXPECT().GetMaxSecretSize().Return(uint32(500))
+
+		resp, err := ts.UsersClient.GetServerLimits(authCtx, req)
+		require.NoError(t, err)
+
+		want := &pb.ServerLimits{MaxSecretSize: 500, MaxItems: 10, MaxBytes: 1000, Items: 3, Bytes: 100}
+		assert.True(t, proto.Equal(want, resp.ServerLimits))
+	})
+}
+
+func TestUsersService_ChangePassword(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	testPassword := "TestPassword!@34"
+	testPwdHash, _ := crypt.CalculatePasswordHash(testPassword)
+	testOTPKey := "CROOWIM25UJJ5JFJ23UV4QCODWRFKIO2"
+
+	newRequest := func() *pb.ChangePasswordRequest {
+		return &pb.ChangePasswordRequest{
+			Username:   "CorrectUser",
+			Password:   testPassword,
+			NewPwdhash: "newpwdhash",
+			Ekey:       []byte("new encryption key"),
+		}
+	}
+
+	t.Run("Change another user's password", func(t *testing.T) {
+		req := newRequest()
+		req.Username = "AnotherUser"
+		_, err := ts.UsersClient.ChangePassword(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Missed encryption key", func(t *testing.T) {
+		req := newRequest()
+		req.Ekey = nil
+		_, err := ts.UsersClient.ChangePassword(authCtx, req)
+		assert.ErrorIs(t, err, ErrMissedUserSecrets)
+	})
+
+	t.Run("Database returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return("", "", assert.AnError)
+		_, err := ts.UsersClient.ChangePassword(authCtx, newRequest())
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return("wronghash", "", nil)
+		_, err := ts.UsersClient.ChangePassword(authCtx, newRequest())
+		assert.Error(t, err)
+	})
+
+	t.Run("Missed verification code", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, testOTPKey, nil)
+		_, err := ts.UsersClient.ChangePassword(authCtx, newRequest())
+		assert.ErrorIs(t, err, ErrWrongVerificationCode)
+	})
+
+	t.Run("Update secrets error", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "", nil)
+		ts.DB.EXPECT().UpdateUserSecrets(mockAny, mockAny).Return(assert.AnError)
+		_, err := ts.UsersClient.ChangePassword(authCtx, newRequest())
+		assert.Error(t, err)
+	})
+
+	t.Run("Create token error", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, "", nil)
+		ts.DB.EXPECT().UpdateUserSecrets(mockAny, mockAny).Return(nil)
+		ts.DB.EXPECT().DeleteSessions(mockAny, "CorrectUser").Return(nil)
+		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("", nil, assert.AnError)
+		_, err := ts.UsersClient.ChangePassword(authCtx, newRequest())
+		assert.Error(t, err)
+	})
+
+	t.Run("Successfully changed with two-factor", func(t *testing.T) {
+		verCode, err := crypt.GenerateVerificationCode(testOTPKey)
+		require.NoError(t, err)
+		req := newRequest()
+		req.OtpCode = verCode
+
+		ts.DB.EXPECT().GetUserAuthData(mockAny, mockAny).Return(testPwdHash, testOTPKey, nil)
+		ts.DB.EXPECT().UpdateUserSecrets(mockAny, mockAny).Return(nil)
+		ts.DB.EXPECT().DeleteSessions(mockAny, "CorrectUser").Return(nil)
+		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("token", &authorizer.Payload{Username: "CorrectUser"}, nil)
+		ts.DB.EXPECT().CreateSession(mockAny, "CorrectUser", mockAny).Return(nil)
+
+		resp, err := ts.UsersClient.ChangePassword(authCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "token", resp.Token)
+	})
+}
+
+func TestUsersService_GetPublicKey(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	t.Run("DB returns error", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserKeys(mockAny, "recipient").Return(nil, nil, assert.AnError)
+		_, err := ts.UsersClient.GetPublicKey(testCtx, &pb.GetPublicKeyRequest{Username: "recipient"})
+		assert.Error(t, err)
+	})
+
+	t.Run("User hasn't public key", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserKeys(mockAny, "recipient").Return(nil, nil, nil)
+		_, err := ts.UsersClient.GetPublicKey(testCtx, &pb.GetPublicKeyRequest{Username: "recipient"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Successfully got", func(t *testing.T) {
+		ts.DB.EXPECT().GetUserKeys(mockAny, "recipient").Return([]byte("public"), []byte("private"), nil)
+		resp, err := ts.UsersClient.GetPublicKey(testCtx, &pb.GetPublicKeyRequest{Username: "recipient"})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("public"), resp.PublicKey)
+	})
+}
+
+func TestUsersService_ListAuditEvents(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser")
+
+	newEvents := func(n int, lastID int64) []*pb.AuditEvent {
+		events := make([]*pb.AuditEvent, 0, n)
+		for i := 0; i < n; i++ {
+			events = append(events, &pb.AuditEvent{Id: lastID - int64(i), Username: "CorrectUser"})
+		}
+
+		return events
+	}
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := &pb.ListAuditEventsRequest{Username: "OtherUser"}
+		_, err := ts.UsersClient.ListAuditEvents(authCtx, req)
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("DB returns error", func(t *testing.T) {
+		req := &pb.ListAuditEventsRequest{Username: "CorrectUser"}
+		ts.DB.EXPECT().GetAuditEvents(mockAny, "CorrectUser", int64(0), uint32(defaultAuditPageSize+1)).
+			Return(nil, assert.AnError)
+		_, err := ts.UsersClient.ListAuditEvents(authCtx, req)
+		assert.Error(t, err)
+	})
+
+	t.Run("Page with next page", func(t *testing.T) {
+		req := &pb.ListAuditEventsRequest{Username: "CorrectUser", PageSize: 2, PageToken: 10}
+		ts.DB.EXPECT().GetAuditEvents(mockAny, "CorrectUser", int64(10), uint32(3)).Return(newEvents(3, 9), nil)
+
+		resp, err := ts.UsersClient.ListAuditEvents(authCtx, req)
+		require.NoError(t, err)
+		assert.Len(t, resp.Events, 2)
+		assert.Equal(t, int64(8), resp.NextPageToken)
+	})
+
+	t.Run("Last page", func(t *testing.T) {
+		req := &pb.ListAuditEventsRequest{Username: "CorrectUser", PageSize: maxAuditPageSize + 100}
+		ts.DB.EXPECT().GetAuditEvents(mockAny, "CorrectUser", int64(0), uint32(maxAuditPageSize+1)).
+			Return(newEvents(2, 2), nil)
+
+		resp, err := ts.UsersClient.ListAuditEvents(authCtx, req)
+		require.NoError(t, err)
+		assert.Len(t, resp.Events, 2)
+		assert.Zero(t, resp.NextPageToken)
+	})
+}
+
+func TestUsersService_ListAuditEvents_Admin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	auth := mockauth.NewMockA(mockCtrl)
+	users := mockdb.NewMockDB(mockCtrl)
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.UnaryInterceptor(IsAuthorized(auth, users)))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "Admin", authMetadataKey, "token")
+
+	users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+	auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{Username: "Admin", IsAdmin: true}, nil)
+	ts.DB.EXPECT().GetAuditEvents(mockAny, "OtherUser", int64(0), mockAny).Return(nil, nil)
+
+	_, err := ts.UsersClient.ListAuditEvents(authCtx, &pb.ListAuditEventsRequest{Username: "OtherUser"})
+	require.NoError(t, err)
+}
+
+func TestUsersService_Sessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	auth := mockauth.NewMockA(mockCtrl)
+	users := mockdb.NewMockDB(mockCtrl)
+
+	ts, tsErr := NewTestSuiteGRPCServer(t, grpc.UnaryInterceptor(IsAuthorized(auth, users)))
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	authCtx := metadata.AppendToOutgoingContext(testCtx, authUsernameKey, "CorrectUser", authMetadataKey, "token")
+
+	current := uuid.New()
+	expectAuth := func() {
+		users.EXPECT().GetUserTokensRevoked(mockAny, mockAny).Return(time.Time{}, nil)
+		auth.EXPECT().VerifyToken(mockAny, mockAny).Return(&authorizer.Payload{ID: current, Username: "CorrectUser"}, nil)
+	}
+
+	t.Run("Access denied", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			expectAuth()
+		}
+
+		_, err := ts.UsersClient.Logout(authCtx, &pb.LogoutRequest{Username: "OtherUser"})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+		_, err = ts.UsersClient.ListSessions(authCtx, &pb.ListSessionsRequest{Username: "OtherUser"})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+		_, err = ts.UsersClient.RevokeSession(authCtx, &pb.RevokeSessionRequest{Username: "OtherUser", All: true})
+		assert.ErrorIs(t, err, permissionDeniedErr("access denied"))
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().DeleteSession(mockAny, "CorrectUser", current.String()).Return(nil)
+		_, err := ts.UsersClient.Logout(authCtx, &pb.LogoutRequest{Username: "CorrectUser"})
+		require.NoError(t, err)
+
+		expectAuth()
+		ts.DB.EXPECT().DeleteSession(mockAny, "CorrectUser", current.String()).Return(db.ErrNotFound)
+		_, err = ts.UsersClient.Logout(authCtx, &pb.LogoutRequest{Username: "CorrectUser"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("List sessions", func(t *testing.T) {
+		revoked := time.Now().Add(-time.Hour)
+		expectAuth()
+		ts.DB.EXPECT().GetUserTokensRevoked(mockAny, "CorrectUser").Return(revoked, nil)
+		ts.DB.EXPECT().GetSessions(mockAny, "CorrectUser").Return([]*pb.Session{
+			{Id: current.String(), Created: timestamppb.Now()},
+			{Id: "other", Created: timestamppb.New(revoked.Add(time.Minute))},
+			{Id: "revoked", Created: timestamppb.New(revoked.Add(-time.Minute))},
+		}, nil)
+
+		resp, err := ts.UsersClient.ListSessions(authCtx, &pb.ListSessionsRequest{Username: "CorrectUser"})
+		require.NoError(t, err)
+		require.Len(t, resp.Sessions, 2)
+		assert.True(t, resp.Sessions[0].Current)
+		assert.Equal(t, "other", resp.Sessions[1].Id)
+		assert.False(t, resp.Sessions[1].Current)
+	})
+
+	t.Run("List sessions DB error", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().GetUserTokensRevoked(mockAny, "CorrectUser").Return(time.Time{}, nil)
+		ts.DB.EXPECT().GetSessions(mockAny, "CorrectUser").Return(nil, assert.AnError)
+		_, err := ts.UsersClient.ListSessions(authCtx, &pb.ListSessionsRequest{Username: "CorrectUser"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Revoke session", func(t *testing.T) {
+		expectAuth()
+		_, err := ts.UsersClient.RevokeSession(authCtx, &pb.RevokeSessionRequest{Username: "CorrectUser"})
+		assert.ErrorIs(t, err, ErrMissedSessionInfo)
+
+		expectAuth()
+		ts.DB.EXPECT().DeleteSession(mockAny, "CorrectUser", "other").Return(nil)
+		_, err = ts.UsersClient.RevokeSession(authCtx, &pb.RevokeSessionRequest{Username: "CorrectUser", Id: "other"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Revoke all sessions", func(t *testing.T) {
+		expectAuth()
+		ts.DB.EXPECT().DeleteSessions(mockAny, "CorrectUser").Return(nil)
+		_, err := ts.UsersClient.RevokeSession(authCtx, &pb.RevokeSessionRequest{Username: "CorrectUser", All: true})
+		require.NoError(t, err)
+
+		expectAuth()
+		ts.DB.EXPECT().DeleteSessions(mockAny, "CorrectUser").Return(assert.AnError)
+		_, err = ts.UsersClient.RevokeSession(authCtx, &pb.RevokeSessionRequest{Username: "CorrectUser", All: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestUsersService_RefreshToken(t *testing.T) {
+	ts, tsErr := NewTestSuiteGRPCServer(t)
+	if tsErr != nil {
+		t.Errorf("failed to init test suite: %v", tsErr)
+	}
+	defer ts.Stop()
+
+	req := &pb.RefreshTokenRequest{
+		Username:     "CorrectUser",
+		RefreshToken: "refresh token",
+	}
+	session := &pb.Session{
+		Id:      "session",
+		Created: timestamppb.New(time.Now().Add(-time.Hour)),
+	}
+
+	t.Run("Missed refresh token", func(t *testing.T) {
+		_, err := ts.UsersClient.RefreshToken(testCtx, &pb.RefreshTokenRequest{Username: "CorrectUser"})
+		assert.ErrorIs(t, err, ErrMissedRefreshToken)
+	})
+
+	t.Run("Unknown refresh token", func(t *testing.T) {
+		ts.DB.EXPECT().GetSessionByRefreshHash(mockAny, "CorrectUser", refreshTokenHash(req.RefreshToken)).
+			Return(nil, db.ErrNotFound)
+		_, err := ts.UsersClient.RefreshToken(testCtx, req)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		ts.DB.EXPECT().GetSessionByRefreshHash(mockAny, mockAny, mockAny).Return(nil, db.ErrInternalDBError)
+		_, err := ts.UsersClient.RefreshToken(testCtx, req)
+		assert.Equal(t, codes.Unknown, status.Code(err))
+	})
+
+	t.Run("Locked user", func(t *testing.T) {
+		ts.DB.EXPECT().GetSessionByRefreshHash(mockAny, mockAny, mockAny).Return(session, nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{Locked: true}, nil)
+		_, err := ts.UsersClient.RefreshToken(testCtx, req)
+		assert.ErrorIs(t, err, ErrUserLocked)
+	})
+
+	t.Run("Revoked session", func(t *testing.T) {
+		ts.DB.EXPECT().GetSessionByRefreshHash(mockAny, mockAny, mockAny).Return(session, nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{}, nil)
+		ts.DB.EXPECT().GetUserTokensRevoked(mockAny, "CorrectUser").Return(time.Now(), nil)
+		_, err := ts.UsersClient.RefreshToken(testCtx, req)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("Session is refreshed concurrently", func(t *testing.T) {
+		payload, err := authorizer.NewPayload("CorrectUser", false, time.Minute)
+		require.NoError(t, err)
+		ts.DB.EXPECT().GetSessionByRefreshHash(mockAny, mockAny, mockAny).Return(session, nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{}, nil)
+		ts.DB.EXPECT().GetUserTokensRevoked(mockAny, "CorrectUser").Return(time.Time{}, nil)
+		ts.Authorizer.EXPECT().CreateToken(mockAny).Return("token", payload, nil)
+		ts.DB.EXPECT().RotateSession(mockAny, "CorrectUser", session.Id, mockAny).Return(db.ErrNotFound)
+		_, err = ts.UsersClient.RefreshToken(testCtx, req)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("Successful refresh", func(t *testing.T) {
+		payload, err := authorizer.NewPayload("CorrectUser", true, time.Minute)
+		require.NoError(t, err)
+		ts.DB.EXPECT().GetSessionByRefreshHash(mockAny, mockAny, mockAny).Return(session, nil)
+		ts.DB.EXPECT().GetUserState(mockAny, "CorrectUser").Return(&db.UserState{IsAdmin: true}, nil)
+		ts.DB.EXPECT().GetUserTokensRevoked(mockAny, "CorrectUser").Return(time.Time{}, nil)
+		ts.Authorizer.EXPECT().CreateToken(authorizer.AuthFields{Username: "CorrectUser", IsAdmin: true}).
+			Return("new token", payload, nil)
+		var rotated *pb.Session
+		ts.DB.EXPECT().RotateSession(mockAny, "CorrectUser", session.Id, mockAny).
+			DoAndReturn(func(_ context.Context, _ string, _ string, s *pb.Session) error {
+				rotated = s
+				return nil
+			})
+
+		resp, err := ts.UsersClient.RefreshToken(testCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "new token", resp.Token)
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.NotEqual(t, req.RefreshToken, resp.RefreshToken)
+
+		require.NotNil(t, rotated)
+		assert.Equal(t, payload.ID.String(), rotated.Id)
+		assert.Equal(t, refreshTokenHash(resp.RefreshToken), rotated.RefreshHash)
+		assert.True(t, rotated.Expires.AsTime().Equal(payload.IssuedAt.Add(defaultRefreshTokenPeriod)))
 	})
 }
@@ -3,12 +3,17 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/logger"
 	"github.com/artfuldog/gophkeeper/internal/pb"
 	"github.com/artfuldog/gophkeeper/internal/server/authorizer"
+	"github.com/artfuldog/gophkeeper/internal/server/blobstore"
 	"github.com/artfuldog/gophkeeper/internal/server/db"
 	"github.com/artfuldog/gophkeeper/internal/server/grpcapi"
 	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
@@ -25,6 +30,9 @@ type Server struct {
 	DB         db.DB
 	Logger     logger.L
 	LogLevel   logger.Level
+	admin      db.Username // user granted administrator role on start
+	// Authorizer's keys reloaded on SIGHUP, nil if authorizer's keys can't be reloaded.
+	keysReloader authorizer.Reloader
 }
 
 // NewSrv is a constructor used to initialize server and set up all parameters and components.
@@ -32,6 +40,7 @@ func NewServer(cfg *Config) (*Server, error) {
 	s := new(Server)
 
 	s.Address = cfg.Address
+	s.admin = cfg.Admin
 
 	var err error
 
@@ -50,6 +59,11 @@ func NewServer(cfg *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// gRPC server isn't required for applying migrations
+	if cfg.MigrateOnly {
+		return s, nil
+	}
+
 	if err := s.createGRPCServer(cfg); err != nil {
 		return nil, err
 	}
@@ -72,6 +86,10 @@ func (s *Server) Run(ctx context.Context, statusChan chan error) {
 	go s.DB.Run(dbCtx, dbControlCh)
 	defer dbCancel()
 
+	s.grantAdmin(ctx)
+
+	go s.reloadKeysOnSignal(ctx)
+
 	grpcControlCh := make(db.CloseChannel)
 	grpcCtx, grpcCancel := context.WithCancel(ctx)
 
@@ -98,6 +116,68 @@ func (s *Server) Run(ctx context.Context, statusChan chan error) {
 	close(statusChan)
 }
 
+// Migrate applies database migrations and closes database connections.
+func (s *Server) Migrate(ctx context.Context) error {
+	if err := s.DB.ConnectAndSetup(ctx); err != nil {
+		return err
+	}
+
+	return s.DB.Close()
+}
+
+// grantAdmin is a helper function, which grants administrator role to user from configuration.
+//
+// Server starts even if role can't be granted, ex. when user isn't registered yet.
+func (s *Server) grantAdmin(ctx context.Context) {
+	componentName := "Server:grantAdmin"
+
+	if s.admin == "" {
+		return
+	}
+
+	if err := s.DB.SetUserAdmin(ctx, s.admin, true); err != nil {
+		s.Logger.Warn(err, fmt.Sprintf("failed to grant administrator role to '%s'", s.admin), componentName)
+		return
+	}
+
+	s.Logger.Info(fmt.Sprintf("administrator role is granted to '%s'", s.admin), componentName)
+}
+
+// reloadKeysOnSignal reloads authorizer's keys on every SIGHUP until context is done.
+func (s *Server) reloadKeysOnSignal(ctx context.Context) {
+	if s.keysReloader == nil {
+		return
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			s.reloadKeys()
+		}
+	}
+}
+
+// reloadKeys is a helper function, which reloads authorizer's keys.
+//
+// Server continues to use current keys if reload fails.
+func (s *Server) reloadKeys() {
+	componentName := "Server:reloadKeys"
+
+	if err := s.keysReloader.Reload(); err != nil {
+		s.Logger.Warn(err, "failed to reload authorizer's keys, current keys are kept", componentName)
+		return
+	}
+
+	s.Logger.Info("authorizer's keys are reloaded", componentName)
+}
+
 // createAuthorizer is a helper function for initialization and configuration authorizer.
 func (s *Server) createAuthorizer(cfg *Config) (a authorizer.A, err error) {
 	var authLogger logger.L
@@ -107,7 +187,28 @@ func (s *Server) createAuthorizer(cfg *Config) (a authorizer.A, err error) {
 		return
 	}
 
-	if a, err = authorizer.New(authorizer.TypePaseto, cfg.ServerKey,
+	key := cfg.ServerKey
+
+	switch cfg.Authorizer {
+	case authorizer.TypePaseto:
+		if cfg.ServerKeysFile != "" {
+			pasetoAuthorizer, err := authorizer.NewPasetoAuthorizerFromFile(cfg.ServerKeysFile,
+				time.Duration(cfg.TokenValidPeriod)*time.Second)
+			if err != nil {
+				return nil, err
+			}
+
+			s.keysReloader = pasetoAuthorizer
+
+			return pasetoAuthorizer, nil
+		}
+	case authorizer.TypeJWT:
+		key = cfg.JWTKeys
+	default:
+		return nil, fmt.Errorf("unsupported authorizer type: %s", cfg.Authorizer)
+	}
+
+	if a, err = authorizer.New(cfg.Authorizer, key,
 		time.Duration(cfg.TokenValidPeriod)*time.Second, authLogger); err != nil {
 		return
 	}
@@ -123,7 +224,19 @@ func (s *Server) createDB(cfg *Config) (err error) {
 		return
 	}
 
-	dbParams := db.NewParameters(cfg.DBDSN, cfg.DBUser, cfg.DBPassword, cfg.MaxSecretSize)
+	dbParams := db.NewParameters(cfg.DBDSN, cfg.DBUser, cfg.DBPassword, cfg.MaxSecretSize).
+		SetItemVersions(cfg.ItemVersions).
+		SetTrashRetention(time.Duration(cfg.TrashRetention) * 24 * time.Hour).
+		SetUserQuota(db.Quota{MaxItems: cfg.MaxItems, MaxBytes: cfg.MaxUserSize})
+
+	if cfg.BlobDir != "" {
+		store, err := blobstore.New(blobstore.TypeFS, cfg.BlobDir)
+		if err != nil {
+			return err
+		}
+
+		dbParams.SetBlobStore(store, cfg.BlobThreshold)
+	}
 
 	if s.DB, err = db.New(cfg.DBType, dbParams, dbLogger); err != nil {
 		return
@@ -139,11 +252,26 @@ func (s *Server) createGRPCServer(cfg *Config) (err error) {
 		return
 	}
 
+	limiter, err := createRateLimiter(cfg)
+	if err != nil {
+		return
+	}
+
 	grpcUnaryInterceptors := []grpc.UnaryServerInterceptor{
-		grpcapi.IsAuthorized(authorizer),
+		grpcapi.IsAuthorized(authorizer, s.DB),
+		grpcapi.RateLimit(limiter),
+		grpcapi.Audit(s.DB, s.Logger),
+		grpcapi.IsAdmin(),
 		grpcrecovery.UnaryServerInterceptor(),
 	}
 
+	grpcStreamInterceptors := []grpc.StreamServerInterceptor{
+		grpcapi.IsAuthorizedStream(authorizer, s.DB),
+		grpcapi.RateLimitStream(limiter),
+		grpcapi.AuditStream(s.DB, s.Logger),
+		grpcrecovery.StreamServerInterceptor(),
+	}
+
 	creds, err := s.getGRPCCredentials(cfg)
 	if err != nil {
 		s.Logger.Warn(err, "TLS error", "Server:createGRPCServer")
@@ -152,7 +280,8 @@ func (s *Server) createGRPCServer(cfg *Config) (err error) {
 
 	s.grpcServer = grpc.NewServer(
 		grpc.Creds(creds),
-		grpc.ChainUnaryInterceptor(grpcUnaryInterceptors...))
+		grpc.ChainUnaryInterceptor(grpcUnaryInterceptors...),
+		grpc.ChainStreamInterceptor(grpcStreamInterceptors...))
 
 	var grpcLogger logger.L
 
@@ -163,12 +292,43 @@ func (s *Server) createGRPCServer(cfg *Config) (err error) {
 	itemsService := grpcapi.NewItemsService(s.DB, grpcLogger)
 	pb.RegisterItemsServer(s.grpcServer, itemsService)
 
-	usersService := grpcapi.NewUsersService(s.DB, grpcLogger, authorizer)
+	usersService := grpcapi.NewUsersService(s.DB, grpcLogger, authorizer).
+		SetRefreshTokenPeriod(time.Duration(cfg.RefreshTokenValidPeriod) * time.Second).
+		SetLoginThrottle(grpcapi.LoginThrottle{
+			MaxUserFailures: cfg.LoginMaxUserFailures,
+			MaxIPFailures:   cfg.LoginMaxIPFailures,
+			Lockout:         time.Duration(cfg.LoginLockout) * time.Second,
+			MaxLockout:      time.Duration(cfg.LoginMaxLockout) * time.Second,
+		})
 	pb.RegisterUsersServer(s.grpcServer, usersService)
 
+	orgsService := grpcapi.NewOrganizationsService(s.DB, grpcLogger)
+	pb.RegisterOrganizationsServer(s.grpcServer, orgsService)
+
+	emergencyService := grpcapi.NewEmergencyAccessesService(s.DB, grpcLogger)
+	pb.RegisterEmergencyAccessesServer(s.grpcServer, emergencyService)
+
+	adminService := grpcapi.NewAdminService(s.DB, grpcLogger)
+	pb.RegisterAdminServer(s.grpcServer, adminService)
+
 	return nil
 }
 
+// createRateLimiter is a helper function for initialization rate limiter of gRPC requests.
+func createRateLimiter(cfg *Config) (*grpcapi.RateLimiter, error) {
+	userRate, err := grpcapi.ParseRate(cfg.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	methodRates, err := grpcapi.ParseMethodRates(cfg.RateLimitMethods)
+	if err != nil {
+		return nil, err
+	}
+
+	return grpcapi.NewRateLimiter(userRate, methodRates), nil
+}
+
 // getGRPCCredentials is a helper function used to configure transport credentials for
 // GRPC-server.
 func (s *Server) getGRPCCredentials(cfg *Config) (credentials.TransportCredentials, error) {
@@ -2,10 +2,17 @@ package server
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/artfuldog/gophkeeper/internal/mocks/mocklogger"
+	"github.com/artfuldog/gophkeeper/internal/server/authorizer"
 	"github.com/artfuldog/gophkeeper/internal/server/db"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +34,172 @@ func TestNewServer(t *testing.T) {
 	})
 }
 
+func TestNewServer_WithMemoryDB(t *testing.T) {
+	cfg := &Config{
+		Address:          "127.0.0.1:3201",
+		DBType:           db.TypeMemory,
+		LogLevel:         "fatal",
+		MaxSecretSize:    defMaxSecretSize,
+		Authorizer:       defAuthorizer,
+		ServerKey:        "123456789f123456789q123456789pQ1",
+		TokenValidPeriod: defTokenValidPeriod,
+		TLSDisable:       true,
+	}
+	s, err := NewServer(cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan error)
+	go s.Run(ctx, ch)
+
+	time.Sleep(time.Second)
+	cancel()
+
+	chErr := <-ch
+	require.NoError(t, chErr)
+}
+
+func TestNewServer_Authorizer(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "jwt.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	newConfig := func(authorizerType string, jwtKeys string) *Config {
+		return &Config{
+			Address:          "127.0.0.1:3203",
+			DBType:           db.TypeMemory,
+			LogLevel:         "fatal",
+			MaxSecretSize:    defMaxSecretSize,
+			Authorizer:       authorizerType,
+			JWTKeys:          jwtKeys,
+			TokenValidPeriod: defTokenValidPeriod,
+			TLSDisable:       true,
+		}
+	}
+
+	t.Run("JWT authorizer", func(t *testing.T) {
+		s, err := NewServer(newConfig(authorizer.TypeJWT, keyPath))
+		require.NoError(t, err)
+		assert.NotEmpty(t, s)
+	})
+
+	t.Run("JWT authorizer without keys", func(t *testing.T) {
+		_, err := NewServer(newConfig(authorizer.TypeJWT, ""))
+		assert.Error(t, err)
+	})
+
+	t.Run("Unsupported authorizer", func(t *testing.T) {
+		_, err := NewServer(newConfig(authorizer.TypeYesMan, ""))
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid rate limit", func(t *testing.T) {
+		cfg := newConfig(authorizer.TypeJWT, keyPath)
+		cfg.RateLimit = "fast"
+		_, err := NewServer(cfg)
+		assert.Error(t, err)
+
+		cfg.RateLimit = ""
+		cfg.RateLimitMethods = "Items/GetItems"
+		_, err = NewServer(cfg)
+		assert.Error(t, err)
+
+		cfg.RateLimitMethods = "Items/GetItems=10:20"
+		s, err := NewServer(cfg)
+		require.NoError(t, err)
+		assert.NotEmpty(t, s)
+	})
+
+	t.Run("Paseto authorizer with key ring", func(t *testing.T) {
+		keysPath := filepath.Join(t.TempDir(), "keys")
+		require.NoError(t, os.WriteFile(keysPath, []byte("2023-01 123456789f123456789q123456789pQ1\n"), 0o600))
+
+		cfg := newConfig(authorizer.TypePaseto, "")
+		cfg.ServerKeysFile = keysPath
+		s, err := NewServer(cfg)
+		require.NoError(t, err)
+		require.NotNil(t, s.keysReloader)
+
+		a, ok := s.keysReloader.(authorizer.A)
+		require.True(t, ok)
+		fields := authorizer.AuthFields{Username: "user"}
+		token, _, err := a.CreateToken(fields)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(keysPath, []byte("2023-02 123456789f123456789q123456789pQ2\n"), 0o600))
+		s.reloadKeys()
+		_, err = a.VerifyToken(token, fields)
+		assert.ErrorIs(t, err, authorizer.ErrInvalidToken, "removed key is not used")
+
+		token, _, err = a.CreateToken(fields)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(keysPath, []byte("2023-03 short\n"), 0o600))
+		s.reloadKeys()
+		_, err = a.VerifyToken(token, fields)
+		assert.NoError(t, err, "current keys are kept")
+
+		cfg.ServerKeysFile = filepath.Join(t.TempDir(), "missed")
+		_, err = NewServer(cfg)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewServer_WithSQLiteDB(t *testing.T) {
+	cfg := &Config{
+		Address:          "127.0.0.1:3202",
+		DBType:           db.TypeSQLite,
+		DBDSN:            filepath.Join(t.TempDir(), "gophkeeper.db"),
+		LogLevel:         "fatal",
+		MaxSecretSize:    defMaxSecretSize,
+		Authorizer:       defAuthorizer,
+		ServerKey:        "123456789f123456789q123456789pQ1",
+		TokenValidPeriod: defTokenValidPeriod,
+		TLSDisable:       true,
+		// Server starts even if administrator isn't registered
+		Admin: "admin",
+	}
+	s, err := NewServer(cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan error)
+	go s.Run(ctx, ch)
+
+	time.Sleep(time.Second)
+	s.DB.Clear(ctx)
+	cancel()
+
+	chErr := <-ch
+	require.NoError(t, chErr)
+}
+
+func TestServer_Migrate(t *testing.T) {
+	cfg := &Config{
+		DBType:        db.TypeSQLite,
+		DBDSN:         filepath.Join(t.TempDir(), "gophkeeper.db"),
+		LogLevel:      "fatal",
+		MaxSecretSize: defMaxSecretSize,
+		MigrateOnly:   true,
+	}
+	s, err := NewServer(cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, s)
+
+	err = s.Migrate(context.Background())
+	require.NoError(t, err)
+
+	// Migrations are already applied
+	err = s.Migrate(context.Background())
+	require.NoError(t, err)
+}
+
 func TestNewServer_WithDB(t *testing.T) {
 	testDBConnParams := db.NewParameters("localhost:5432/gophkeeper_db_tests",
 		"gksa", "", uint32(50*1024*1024))
@@ -44,6 +217,7 @@ func TestNewServer_WithDB(t *testing.T) {
 			DBUser:           "gksa",
 			LogLevel:         "fatal",
 			MaxSecretSize:    defMaxSecretSize,
+			Authorizer:       defAuthorizer,
 			ServerKey:        "123456789f1",
 			TokenValidPeriod: defTokenValidPeriod,
 		}
@@ -59,6 +233,7 @@ func TestNewServer_WithDB(t *testing.T) {
 			DBUser:           "gksa",
 			LogLevel:         "fatal",
 			MaxSecretSize:    defMaxSecretSize,
+			Authorizer:       defAuthorizer,
 			ServerKey:        "123456789f123456789q123456789pQ1",
 			TokenValidPeriod: defTokenValidPeriod,
 		}
@@ -74,6 +249,7 @@ func TestNewServer_WithDB(t *testing.T) {
 			DBUser:           "gksa",
 			LogLevel:         "fatal",
 			MaxSecretSize:    defMaxSecretSize,
+			Authorizer:       defAuthorizer,
 			ServerKey:        "123456789f123456789q123456789pQ1",
 			TokenValidPeriod: defTokenValidPeriod,
 			TLSDisable:       true,
@@ -102,6 +278,7 @@ func TestNewServer_WithDB(t *testing.T) {
 			DBUser:           "gksa",
 			LogLevel:         "fatal",
 			MaxSecretSize:    defMaxSecretSize,
+			Authorizer:       defAuthorizer,
 			ServerKey:        "123456789f123456789q123456789pQ1",
 			TokenValidPeriod: defTokenValidPeriod,
 			TLSDisable:       true,
@@ -130,6 +307,7 @@ func TestNewServer_WithDB(t *testing.T) {
 			DBUser:           "gksa",
 			LogLevel:         "fatal",
 			MaxSecretSize:    defMaxSecretSize,
+			Authorizer:       defAuthorizer,
 			ServerKey:        "123456789f123456789q123456789pQ1",
 			TokenValidPeriod: defTokenValidPeriod,
 			TLSCertFilepath:  "test_data/service.pem",